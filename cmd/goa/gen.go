@@ -24,6 +24,10 @@ type Generator struct {
 	// Output is the absolute path to the output directory.
 	Output string
 
+	// Incremental is true if only the files of the services whose design
+	// changed since the last incremental generation must be rendered.
+	Incremental bool
+
 	// bin is the filename of the generated generator.
 	bin string
 
@@ -65,7 +69,8 @@ func (g *Generator) Write(debug bool) error {
 	{
 		data := map[string]interface{}{
 			"Command":     g.Command,
-			"CleanupDirs": cleanupDirs(g.Command, g.Output),
+			"CleanupDirs": cleanupDirs(g.Command, g.Output, g.Incremental),
+			"Incremental": g.Incremental && g.Command == "gen",
 		}
 		imports := []*codegen.ImportSpec{
 			codegen.SimpleImport("flag"),
@@ -162,9 +167,10 @@ func (g *Generator) Remove() {
 }
 
 // cleanupDirs returns the names of the directories to delete before generating
-// code.
-func cleanupDirs(cmd, output string) []string {
-	if cmd == "gen" {
+// code. The gen directory is kept when generating incrementally so that the
+// files of the services whose design did not change are not rendered again.
+func cleanupDirs(cmd, output string, incremental bool) []string {
+	if cmd == "gen" && !incremental {
 		return []string{filepath.Join(output, codegen.Gendir)}
 	}
 	return nil
@@ -205,7 +211,7 @@ const mainT = `func main() {
 	}
 {{- end }}
 
-	outputs, err := generator.Generate(*out, {{ printf "%q" .Command }})
+	outputs, err := generator.{{ if .Incremental }}GenerateIncremental{{ else }}Generate{{ end }}(*out, {{ printf "%q" .Command }})
 	if err != nil {
 		fail(err.Error())
 	}
@@ -3,9 +3,12 @@ package main
 import (
 	"fmt"
 	"go/build"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"goa.design/goa/codegen/importer"
 	"goa.design/goa/pkg"
 
 	"flag"
@@ -26,7 +29,7 @@ func main() {
 		case "version":
 			fmt.Println("goa version " + pkg.Version())
 			os.Exit(0)
-		case "gen", "example":
+		case "gen", "example", "import":
 			if len(os.Args) == 2 {
 				usage()
 			}
@@ -39,8 +42,9 @@ func main() {
 	}
 
 	var (
-		output = "."
-		debug  bool
+		output      = "."
+		debug       bool
+		incremental bool
 	)
 	if len(os.Args) > offset+1 {
 		var (
@@ -49,6 +53,7 @@ func main() {
 			out  = fset.String("output", output, "output `directory`")
 		)
 		fset.BoolVar(&debug, "debug", false, "Print debug information")
+		fset.BoolVar(&incremental, "incremental", false, "Only generate the files of the services whose design changed")
 
 		fset.Usage = usage
 		fset.Parse(os.Args[offset+1:])
@@ -59,16 +64,21 @@ func main() {
 		}
 	}
 
-	gen(cmd, path, output, debug)
+	if cmd == "import" {
+		imp(path, output)
+		return
+	}
+	gen(cmd, path, output, debug, incremental)
 }
 
 // help with tests
 var (
 	usage = help
 	gen   = generate
+	imp   = importDesign
 )
 
-func generate(cmd, path, output string, debug bool) {
+func generate(cmd, path, output string, debug, incremental bool) {
 	var (
 		files []string
 		err   error
@@ -80,6 +90,7 @@ func generate(cmd, path, output string, debug bool) {
 	}
 
 	tmp = NewGenerator(cmd, path, output)
+	tmp.Incremental = incremental
 	if !debug {
 		defer tmp.Remove()
 	}
@@ -106,20 +117,63 @@ fail:
 	os.Exit(1)
 }
 
+// importDesign writes the design produced from the OpenAPI document or the
+// protobuf definitions at path to the design package under output.
+func importDesign(path, output string) {
+	var (
+		doc []byte
+		src []byte
+		dir = filepath.Join(output, "design")
+		err error
+	)
+
+	if doc, err = ioutil.ReadFile(path); err != nil {
+		goto fail
+	}
+
+	if filepath.Ext(path) == ".proto" {
+		src, err = importer.Protobuf(doc, "design")
+	} else {
+		src, err = importer.OpenAPI(doc, "design")
+	}
+	if err != nil {
+		goto fail
+	}
+
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		goto fail
+	}
+
+	if err = ioutil.WriteFile(filepath.Join(dir, "design.go"), src, 0644); err != nil {
+		goto fail
+	}
+
+	fmt.Println(filepath.Join(dir, "design.go"))
+	return
+fail:
+	fmt.Fprintln(os.Stderr, err.Error())
+	os.Exit(1)
+}
+
 func help() {
 	fmt.Fprint(os.Stderr, `goa is the code generation tool for the goa framework.
 Learn more at https://goa.design.
 
 Usage:
-  goa gen PACKAGE [--out DIRECTORY] [--debug]
+  goa gen PACKAGE [--out DIRECTORY] [--debug] [--incremental]
   goa example PACKAGE [--out DIRECTORY] [--debug]
+  goa import FILE [--out DIRECTORY]
   goa version
 
 Commands:
   gen
-        Generate service interfaces, endpoints, transport code and OpenAPI spec.
+        Generate service interfaces, endpoints, transport code, OpenAPI spec
+        and API reference.
   example
         Generate example server and client tool.
+  import
+        Generate a starter design package from a Swagger 2.0 or OpenAPI 3
+        document in YAML or JSON or from a .proto file.
   version
         Print version information (exclusive with other flags and commands).
 
@@ -127,6 +181,9 @@ Args:
   PACKAGE
         Go import path to design package
 
+  FILE
+        Path to the OpenAPI document or .proto file (import command only)
+
 Flags:
   -o, -output DIRECTORY
         output directory, defaults to the current working directory
@@ -134,9 +191,14 @@ Flags:
   -debug
         Print debug information (mainly intended for goa developers)
 
+  -incremental
+        Only generate the files of the services whose design changed since
+        the last incremental generation (gen command only)
+
 Example:
 
   goa gen goa.design/cellar/design -o gendir
+  goa import openapi.yaml -o $GOPATH/src/goa.design/cellar
 
 `)
 	os.Exit(1)
@@ -16,33 +16,42 @@ func TestCmdLine(t *testing.T) {
 		cmd          string
 		path, output string
 		debug        bool
+		incremental  bool
 	)
 
 	usage = func() { usageCalled = true }
-	gen = func(c string, p, o string, d bool) { cmd, path, output, debug = c, p, o, d }
+	gen = func(c string, p, o string, d, i bool) { cmd, path, output, debug, incremental = c, p, o, d, i }
+	imp = func(p, o string) { cmd, path, output = "import", p, o }
 	defer func() {
 		usage = help
 		gen = generate
+		imp = importDesign
 	}()
 
 	cases := map[string]struct {
-		CmdLine         string
-		ExpectedUsage   bool
-		ExpectedCommand string
-		ExpectedPath    string
-		ExpectedOutput  string
-		ExpectedDebug   bool
+		CmdLine             string
+		ExpectedUsage       bool
+		ExpectedCommand     string
+		ExpectedPath        string
+		ExpectedOutput      string
+		ExpectedDebug       bool
+		ExpectedIncremental bool
 	}{
-		"gen": {"gen " + testPkg, false, "gen", testPkg, ".", false},
+		"gen": {"gen " + testPkg, false, "gen", testPkg, ".", false, false},
 
-		"invalid":     {"invalid " + testPkg, true, "", "", ".", false},
-		"empty":       {"", true, "", "", ".", false},
-		"invalid gen": {"invalid gen" + testPkg, true, "", "", ".", false},
+		"invalid":     {"invalid " + testPkg, true, "", "", ".", false, false},
+		"empty":       {"", true, "", "", ".", false, false},
+		"invalid gen": {"invalid gen" + testPkg, true, "", "", ".", false, false},
 
-		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, testOutput, false},
-		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, testOutput, false},
+		"output":       {"gen " + testPkg + " -output " + testOutput, false, "gen", testPkg, testOutput, false, false},
+		"output short": {"gen " + testPkg + " -o " + testOutput, false, "gen", testPkg, testOutput, false, false},
 
-		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, ".", true},
+		"debug": {"gen " + testPkg + " -debug", false, "gen", testPkg, ".", true, false},
+
+		"incremental": {"gen " + testPkg + " -incremental", false, "gen", testPkg, ".", false, true},
+
+		"import":        {"import openapi.yaml", false, "import", "openapi.yaml", ".", false, false},
+		"import output": {"import openapi.yaml -o " + testOutput, false, "import", "openapi.yaml", testOutput, false, false},
 	}
 
 	for k, c := range cases {
@@ -54,6 +63,7 @@ func TestCmdLine(t *testing.T) {
 			path = ""
 			output = ""
 			debug = false
+			incremental = false
 		}
 
 		main()
@@ -73,5 +83,8 @@ func TestCmdLine(t *testing.T) {
 		if debug != c.ExpectedDebug {
 			t.Errorf("%s: Expected debug to be %v but got %v", k, c.ExpectedDebug, debug)
 		}
+		if incremental != c.ExpectedIncremental {
+			t.Errorf("%s: Expected incremental to be %v but got %v", k, c.ExpectedIncremental, incremental)
+		}
 	}
 }
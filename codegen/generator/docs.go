@@ -17,5 +17,24 @@ OpenAPI
 
 The OpenAPI generator generates a OpenAPI v2 specification for the service
 REST endpoints. This generator requires the design to define the HTTP transport.
+
+Reference
+
+The reference generator renders the API reference in Markdown and HTML in the
+gen/docs directory. The reference describes the services, methods, HTTP routes,
+types, validations, examples and errors of the API.
+
+JSON Schema
+
+The JSON Schema generator renders a standalone JSON Schema (draft 2020-12)
+document for each user and result type in the gen/jsonschema directory. The
+documents describe the type validations and may be used to validate messages
+outside of the transport layer.
+
+Graph
+
+The graph generator renders the graph of the services, methods, payload,
+result and error types of the design and of the relationships between the
+types in the Graphviz DOT and Mermaid formats in the gen/docs directory.
 */
 package generator
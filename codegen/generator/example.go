@@ -10,7 +10,8 @@ import (
 )
 
 // Example iterates through the roots and returns files that implement an
-// example service and client.
+// example service and client as well as tests for the HTTP endpoints and
+// optionally the files needed to build a container image of the service.
 func Example(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 	var files []*codegen.File
 	for _, root := range roots {
@@ -25,6 +26,10 @@ func Example(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 			if cli := httpcodegen.ExampleCLI(genpkg, r); cli != nil {
 				files = append(files, cli)
 			}
+			if t := httpcodegen.ExampleTestsFile(genpkg, r); t != nil {
+				files = append(files, t)
+			}
+			files = append(files, httpcodegen.ExampleDockerFiles(genpkg, r)...)
 		}
 	}
 	return files, nil
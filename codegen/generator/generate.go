@@ -7,11 +7,29 @@ import (
 	"sort"
 
 	"goa.design/goa/codegen"
+	"goa.design/goa/design"
 	"goa.design/goa/eval"
 )
 
 // Generate runs the code generation algorithms.
 func Generate(dir, cmd string) ([]string, error) {
+	return generate(dir, cmd, false)
+}
+
+// GenerateIncremental runs the code generation algorithms like Generate but
+// does not render the files of the services whose design did not change since
+// the last incremental generation. The hashes of the service designs and the
+// list of generated files are recorded in the file "gen/.goa-manifest.json".
+// The files produced by plugins for a given service are assumed to only
+// depend on the design of that service.
+func GenerateIncremental(dir, cmd string) ([]string, error) {
+	return generate(dir, cmd, true)
+}
+
+// generate runs the code generation algorithms, skipUnchanged indicates
+// whether the files of the services whose design did not change since the
+// last incremental generation should be skipped.
+func generate(dir, cmd string, skipUnchanged bool) ([]string, error) {
 	// 1. Compute design roots.
 	var roots []eval.Root
 	{
@@ -50,7 +68,18 @@ func Generate(dir, cmd string) ([]string, error) {
 		genfuncs = gs
 	}
 
-	// 4. Generate initial set of files produced by goa code generators.
+	// 4. Compute the service design hashes before the generators modify
+	// the expressions.
+	var inc *incremental
+	if skipUnchanged {
+		i, err := newIncremental(dir, genpkg, roots)
+		if err != nil {
+			return nil, err
+		}
+		inc = i
+	}
+
+	// 5. Generate initial set of files produced by goa code generators.
 	var genfiles []*codegen.File
 	for _, gen := range genfuncs {
 		fs, err := gen(genpkg, roots)
@@ -60,15 +89,29 @@ func Generate(dir, cmd string) ([]string, error) {
 		genfiles = append(genfiles, fs...)
 	}
 
-	// 5. Run the code generation plugins.
+	// 6. Run the code generation plugins.
 	genfiles, err := codegen.RunPlugins(cmd, genpkg, roots, genfiles)
 	if err != nil {
 		return nil, err
 	}
 
-	// 6. Write the files.
+	// 7. Write the files, skipping the files of the services whose design
+	// did not change when generating incrementally.
+	if inc != nil {
+		if err := inc.prepare(genfiles); err != nil {
+			return nil, err
+		}
+	} else if cmd == "gen" {
+		if err := removeServiceDirs(dir, roots); err != nil {
+			return nil, err
+		}
+	}
 	written := make(map[string]struct{})
 	for _, f := range genfiles {
+		if inc.skip(f) {
+			written[filepath.Join(inc.base, f.Path)] = struct{}{}
+			continue
+		}
 		filename, err := f.Render(dir)
 		if err != nil {
 			return nil, err
@@ -76,7 +119,14 @@ func Generate(dir, cmd string) ([]string, error) {
 		written[filename] = struct{}{}
 	}
 
-	// 7. Compute all output filenames.
+	// 8. Record the state of the generation.
+	if inc != nil {
+		if err := inc.save(written); err != nil {
+			return nil, err
+		}
+	}
+
+	// 9. Compute all output filenames.
 	var outputs []string
 	{
 		outputs = make([]string, len(written))
@@ -98,3 +148,32 @@ func Generate(dir, cmd string) ([]string, error) {
 
 	return outputs, nil
 }
+
+// removeServiceDirs deletes the directories that contain the code generated
+// for the services whose output directory is not the gen directory. The gen
+// directory itself is deleted by the goa tool prior to running the generator.
+func removeServiceDirs(dir string, roots []eval.Root) error {
+	for _, root := range roots {
+		r, ok := root.(*design.RootExpr)
+		if !ok {
+			continue
+		}
+		for _, svc := range r.Services {
+			gendir := codegen.ServiceGendir(svc)
+			if gendir == codegen.Gendir {
+				continue
+			}
+			name := codegen.SnakeCase(svc.Name)
+			for _, d := range []string{
+				filepath.Join(dir, gendir, name),
+				filepath.Join(dir, gendir, "http", name),
+				filepath.Join(dir, gendir, "pubsub", name),
+			} {
+				if err := os.RemoveAll(d); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+package generator
+
+import (
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/graph"
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+// Graph iterates through the roots and returns the files that render the graph
+// of the design services, methods and types in the DOT and Mermaid formats. It
+// returns no file if the roots slice does not include a goa design.
+func Graph(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*design.RootExpr); ok {
+			return graph.Files(r), nil
+		}
+	}
+	return nil, nil
+}
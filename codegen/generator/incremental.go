@@ -0,0 +1,403 @@
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+	httpdesign "goa.design/goa/http/design"
+	"goa.design/goa/pkg"
+)
+
+type (
+	// incremental keeps track of the state needed to only render the files
+	// of the services whose design changed since the last incremental
+	// generation.
+	incremental struct {
+		// base is the absolute path to the output directory.
+		base string
+		// hashes lists the hashes of the service designs indexed by
+		// service name.
+		hashes map[string]string
+		// dirs lists the names of the services indexed by the paths of
+		// the directories that contain their generated files relative
+		// to the output directory.
+		dirs map[string]string
+		// prev is the manifest recorded by the last generation, nil if
+		// there is none.
+		prev *manifest
+		// skipped lists the paths of the files that are not rendered
+		// relative to the output directory.
+		skipped map[string]bool
+	}
+
+	// manifest is the content of the file that records the state of the
+	// last incremental generation.
+	manifest struct {
+		// Services lists the hashes of the service designs indexed by
+		// service name.
+		Services map[string]string `json:"services"`
+		// Files lists the paths of the generated files relative to the
+		// output directory indexed by the name of the service that
+		// owns them. Files that are not specific to a service are
+		// listed under the empty name.
+		Files map[string][]string `json:"files"`
+	}
+
+	// hasher computes the hash of expressions.
+	hasher struct {
+		h hash.Hash
+		// seen lists the index of the pointers already hashed to deal
+		// with cyclic expressions.
+		seen map[uintptr]int
+	}
+)
+
+// manifestPath is the path to the manifest file relative to the output
+// directory.
+var manifestPath = filepath.Join(codegen.Gendir, ".goa-manifest.json")
+
+// newIncremental computes the hashes of the service designs and loads the
+// manifest recorded by the last incremental generation if any. It must be
+// called before the generators run as they may modify the expressions.
+func newIncremental(dir, genpkg string, roots []eval.Root) (*incremental, error) {
+	base, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	inc := &incremental{
+		base:    base,
+		hashes:  serviceHashes(genpkg, roots),
+		dirs:    make(map[string]string),
+		skipped: make(map[string]bool),
+	}
+	for _, root := range roots {
+		if r, ok := root.(*design.RootExpr); ok {
+			for _, svc := range r.Services {
+				var (
+					gendir = filepath.ToSlash(codegen.ServiceGendir(svc))
+					name   = codegen.SnakeCase(svc.Name)
+				)
+				inc.dirs[path.Join(gendir, name)] = svc.Name
+				inc.dirs[path.Join(gendir, "http", name)] = svc.Name
+			}
+		}
+	}
+	content, err := ioutil.ReadFile(filepath.Join(base, manifestPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return inc, nil
+		}
+		return nil, err
+	}
+	var m manifest
+	if err := json.Unmarshal(content, &m); err != nil {
+		// Regenerate everything if the manifest is corrupted.
+		return inc, nil
+	}
+	inc.prev = &m
+	return inc, nil
+}
+
+// prepare computes the files that need not be rendered because the design of
+// the service that owns them did not change and deletes the files produced
+// by the last generation that are going to be rendered again or that are
+// stale. Files that are not specific to a service are always rendered.
+func (inc *incremental) prepare(files []*codegen.File) error {
+	counts := make(map[string]int)
+	for _, f := range files {
+		counts[f.Path]++
+	}
+	if inc.prev != nil {
+		for _, f := range files {
+			svc := inc.owner(f.Path)
+			if svc == "" || counts[f.Path] > 1 {
+				// Files with the same path are renamed when
+				// rendered, keep it simple and render them.
+				continue
+			}
+			if h, ok := inc.prev.Services[svc]; !ok || h != inc.hashes[svc] {
+				continue
+			}
+			if !contains(inc.prev.Files[svc], f.Path) {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(inc.base, f.Path)); err != nil {
+				continue
+			}
+			inc.skipped[f.Path] = true
+		}
+	}
+	var stale []string
+	if inc.prev != nil {
+		for _, paths := range inc.prev.Files {
+			for _, p := range paths {
+				if !inc.skipped[p] {
+					stale = append(stale, p)
+				}
+			}
+		}
+	}
+	// Files generated by a non incremental generation are not listed in
+	// the manifest, delete all the files of the services being rendered.
+	for _, f := range files {
+		if !inc.skipped[f.Path] {
+			stale = append(stale, f.Path)
+		}
+	}
+	sort.Strings(stale)
+	for _, p := range stale {
+		path := filepath.Join(inc.base, p)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		removeEmptyDirs(inc.base, filepath.Dir(path))
+	}
+	return nil
+}
+
+// skip returns true if the file does not need to be rendered. inc may be nil
+// in which case all files are rendered.
+func (inc *incremental) skip(f *codegen.File) bool {
+	return inc != nil && inc.skipped[f.Path]
+}
+
+// save records the service hashes and the generated files in the manifest.
+// written lists the absolute paths of the files.
+func (inc *incremental) save(written map[string]struct{}) error {
+	m := manifest{Services: inc.hashes, Files: make(map[string][]string)}
+	for path := range written {
+		rel, err := filepath.Rel(inc.base, path)
+		if err != nil {
+			return err
+		}
+		svc := inc.owner(rel)
+		m.Files[svc] = append(m.Files[svc], rel)
+	}
+	for _, paths := range m.Files {
+		sort.Strings(paths)
+	}
+	content, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(inc.base, manifestPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, 0644)
+}
+
+// owner returns the name of the service that owns the file with the given
+// path relative to the output directory, the empty string if the file is not
+// specific to a service. Service files are generated under "<gendir>/<service>"
+// and "<gendir>/http/<service>" where gendir is the output directory of the
+// service.
+func (inc *incremental) owner(rel string) string {
+	for dir := path.Dir(filepath.ToSlash(rel)); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if svc, ok := inc.dirs[dir]; ok {
+			return svc
+		}
+	}
+	return ""
+}
+
+// serviceHashes returns the hashes of the service designs indexed by service
+// name. The hash of a service covers the transport agnostic and HTTP service
+// expressions including the types they use as well as the API level
+// expressions shared by all services, the goa version and the import path of
+// the gen package.
+func serviceHashes(genpkg string, roots []eval.Root) map[string]string {
+	var (
+		shared = []interface{}{pkg.Version(), genpkg}
+		svcs   = make(map[string][]interface{})
+	)
+	for _, root := range roots {
+		switch r := root.(type) {
+		case *design.RootExpr:
+			shared = append(shared, r)
+			for _, svc := range r.Services {
+				svcs[svc.Name] = append(svcs[svc.Name], svc)
+			}
+		case *httpdesign.RootExpr:
+			shared = append(shared, r)
+			for _, svc := range r.HTTPServices {
+				svcs[svc.Name()] = append(svcs[svc.Name()], svc)
+			}
+		default:
+			shared = append(shared, root.EvalName())
+		}
+	}
+	hashes := make(map[string]string, len(svcs))
+	for name, exprs := range svcs {
+		hs := newHasher()
+		for _, e := range append(shared, exprs...) {
+			hs.write(reflect.ValueOf(e), true)
+		}
+		hashes[name] = hex.EncodeToString(hs.h.Sum(nil))
+	}
+	return hashes
+}
+
+// newHasher returns a hasher that computes SHA-256 hashes.
+func newHasher() *hasher {
+	return &hasher{h: sha256.New(), seen: make(map[uintptr]int)}
+}
+
+// write writes the content of v to the hash. Pointers are followed once,
+// functions and channels are ignored. The design roots are only hashed when
+// top is true, that is when they are not referenced by another expression,
+// and then only the fields that are not specific to a service are hashed.
+func (hs *hasher) write(v reflect.Value, top bool) {
+	if !v.IsValid() {
+		hs.str("nil")
+		return
+	}
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			hs.str("nil")
+			return
+		}
+		if !top && isRoot(v.Type()) {
+			hs.str("root")
+			return
+		}
+		if i, ok := hs.seen[v.Pointer()]; ok {
+			hs.str(fmt.Sprintf("ref:%d", i))
+			return
+		}
+		hs.seen[v.Pointer()] = len(hs.seen)
+		hs.write(v.Elem(), top)
+	case reflect.Interface:
+		if v.IsNil() {
+			hs.str("nil")
+			return
+		}
+		hs.str(v.Elem().Type().String())
+		hs.write(v.Elem(), false)
+	case reflect.Struct:
+		hs.str(v.Type().String())
+		for i := 0; i < v.NumField(); i++ {
+			if top && isServicesField(v.Type(), v.Type().Field(i).Name) {
+				continue
+			}
+			hs.write(v.Field(i), false)
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			hs.str("nil")
+			return
+		}
+		hs.int(int64(v.Len()))
+		for i := 0; i < v.Len(); i++ {
+			hs.write(v.Index(i), false)
+		}
+	case reflect.Map:
+		if v.IsNil() {
+			hs.str("nil")
+			return
+		}
+		// Hash the entries sorted by the hash of their keys.
+		type entry struct {
+			key []byte
+			val reflect.Value
+		}
+		entries := make([]entry, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			kh := newHasher()
+			kh.write(k, false)
+			entries = append(entries, entry{kh.h.Sum(nil), v.MapIndex(k)})
+		}
+		sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i].key, entries[j].key) < 0 })
+		hs.int(int64(len(entries)))
+		for _, e := range entries {
+			hs.h.Write(e.key)
+			hs.write(e.val, false)
+		}
+	case reflect.String:
+		hs.str(v.String())
+	case reflect.Bool:
+		if v.Bool() {
+			hs.int(1)
+		} else {
+			hs.int(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		hs.int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		hs.int(int64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		hs.int(int64(math.Float64bits(v.Float())))
+	case reflect.Complex64, reflect.Complex128:
+		c := v.Complex()
+		hs.int(int64(math.Float64bits(real(c))))
+		hs.int(int64(math.Float64bits(imag(c))))
+	}
+}
+
+// str writes a length prefixed string to the hash.
+func (hs *hasher) str(s string) {
+	hs.int(int64(len(s)))
+	hs.h.Write([]byte(s))
+}
+
+// int writes an integer to the hash.
+func (hs *hasher) int(i int64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], uint64(i))
+	hs.h.Write(b[:])
+}
+
+// isRoot returns true if t is the type of a design root.
+func isRoot(t reflect.Type) bool {
+	return t == reflect.TypeOf(&design.RootExpr{}) || t == reflect.TypeOf(&httpdesign.RootExpr{})
+}
+
+// isServicesField returns true if the field with the given name of the struct
+// t lists expressions that are hashed with the services that use them rather
+// than with the root.
+func isServicesField(t reflect.Type, name string) bool {
+	switch t {
+	case reflect.TypeOf(design.RootExpr{}):
+		return name == "Services" || name == "Types" || name == "ResultTypes" || name == "GeneratedTypes"
+	case reflect.TypeOf(httpdesign.RootExpr{}):
+		return name == "Design" || name == "HTTPServices"
+	}
+	return false
+}
+
+// removeEmptyDirs deletes dir and its parents up to base if they are empty.
+func removeEmptyDirs(base, dir string) {
+	for dir != base && strings.HasPrefix(dir, base) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// contains returns true if s is one of the elements of vals.
+func contains(vals []string, s string) bool {
+	for _, v := range vals {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"goa.design/goa/codegen/generator/testdata"
+	"goa.design/goa/eval"
+	httpcodegen "goa.design/goa/http/codegen"
+)
+
+func TestServiceHashes(t *testing.T) {
+	base := hashes(t, testdata.IncrementalDSL)
+	if len(base) != 2 {
+		t.Fatalf("got %d hashes, expected 2", len(base))
+	}
+	cases := []struct {
+		Name            string
+		DSL             func()
+		ExpectedChanged []string
+	}{
+		{"same", testdata.IncrementalDSL, nil},
+		{"service-changed", testdata.IncrementalServiceChangedDSL, []string{"two"}},
+		{"type-changed", testdata.IncrementalTypeChangedDSL, []string{"one"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			hs := hashes(t, c.DSL)
+			var changed []string
+			for name, h := range hs {
+				if base[name] != h {
+					changed = append(changed, name)
+				}
+			}
+			sort.Strings(changed)
+			if len(changed) != len(c.ExpectedChanged) {
+				t.Fatalf("got changed services %v, expected %v", changed, c.ExpectedChanged)
+			}
+			for i, name := range changed {
+				if name != c.ExpectedChanged[i] {
+					t.Errorf("got changed services %v, expected %v", changed, c.ExpectedChanged)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateIncremental(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-incremental")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var (
+		oneService = filepath.Join("gen", "one", "service.go")
+		twoService = filepath.Join("gen", "two", "service.go")
+		twoServer  = filepath.Join("gen", "http", "two", "server", "server.go")
+		openapi    = filepath.Join("gen", "http", "openapi.json")
+	)
+	cases := []struct {
+		Name             string
+		DSL              func()
+		ExpectedRendered []string
+		ExpectedSkipped  []string
+		ExpectedDeleted  []string
+	}{
+		{"initial", testdata.IncrementalDSL, []string{oneService, twoService, twoServer, openapi}, nil, nil},
+		{"unchanged", testdata.IncrementalDSL, []string{openapi}, []string{oneService, twoService, twoServer}, nil},
+		{"service-changed", testdata.IncrementalServiceChangedDSL, []string{twoService, twoServer, openapi}, []string{oneService}, nil},
+		{"service-removed", testdata.IncrementalServiceRemovedDSL, []string{openapi}, []string{oneService}, []string{twoService, twoServer}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			httpcodegen.RunHTTPDSL(t, c.DSL)
+			for _, p := range append(c.ExpectedSkipped, c.ExpectedRendered...) {
+				mark(t, filepath.Join(dir, p))
+			}
+			if _, err := GenerateIncremental(dir, "gen"); err != nil {
+				t.Fatal(err)
+			}
+			for _, p := range c.ExpectedRendered {
+				if marked(t, filepath.Join(dir, p)) {
+					t.Errorf("%s was not rendered", p)
+				}
+			}
+			for _, p := range c.ExpectedSkipped {
+				if !marked(t, filepath.Join(dir, p)) {
+					t.Errorf("%s was rendered", p)
+				}
+			}
+			for _, p := range c.ExpectedDeleted {
+				if _, err := os.Stat(filepath.Join(dir, p)); !os.IsNotExist(err) {
+					t.Errorf("%s was not deleted", p)
+				}
+			}
+		})
+	}
+}
+
+// hashes returns the service hashes of the given DSL.
+func hashes(t *testing.T, dsl func()) map[string]string {
+	httpcodegen.RunHTTPDSL(t, dsl)
+	roots, err := eval.Context.Roots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return serviceHashes("goa.design/goa/gen", roots)
+}
+
+// marker is appended to existing files to detect whether they are rendered
+// again.
+const marker = "\n// marked\n"
+
+// mark appends the marker to the file with the given path if it exists.
+func mark(t *testing.T, path string) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return
+		}
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(marker); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// marked returns true if the file with the given path ends with the marker.
+func marked(t *testing.T, path string) bool {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(content) >= len(marker) && string(content[len(content)-len(marker):]) == marker
+}
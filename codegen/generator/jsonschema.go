@@ -0,0 +1,20 @@
+package generator
+
+import (
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/jsonschema"
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+// JSONSchema iterates through the roots and returns the files that contain the
+// JSON Schema documents of the design user and result types. It returns no
+// file if the roots slice does not include a goa design.
+func JSONSchema(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*design.RootExpr); ok {
+			return jsonschema.Files(r), nil
+		}
+	}
+	return nil, nil
+}
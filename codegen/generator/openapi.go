@@ -10,9 +10,10 @@ import (
 )
 
 // OpenAPI iterates through the roots and returns the files needed to render
-// the service OpenAPI spec. It returns an error if the roots slice does not
+// the service OpenAPI spec and the contract tests that validate a running
+// implementation against it. It returns an error if the roots slice does not
 // include a HTTP root.
-func OpenAPI(_ string, roots []eval.Root) ([]*codegen.File, error) {
+func OpenAPI(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 	var (
 		files []*codegen.File
 		err   error
@@ -20,6 +21,9 @@ func OpenAPI(_ string, roots []eval.Root) ([]*codegen.File, error) {
 	for _, root := range roots {
 		if r, ok := root.(*httpdesign.RootExpr); ok {
 			files, err = httpcodegen.OpenAPIFiles(r)
+			if f := httpcodegen.ContractTestsFile(genpkg, r); err == nil && f != nil {
+				files = append(files, f)
+			}
 			break
 		}
 	}
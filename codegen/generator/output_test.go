@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"goa.design/goa/codegen/generator/testdata"
+	"goa.design/goa/eval"
+	httpcodegen "goa.design/goa/http/codegen"
+)
+
+func TestGenerateOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-output")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var (
+		stale    = filepath.Join("services", "one", "gen", "one", "stale.go")
+		expected = []string{
+			filepath.Join("services", "one", "gen", "one", "service.go"),
+			filepath.Join("services", "one", "gen", "http", "one", "server", "server.go"),
+			filepath.Join("billing", "gen", "two", "service.go"),
+			filepath.Join("billing", "gen", "http", "two", "client", "client.go"),
+			filepath.Join("gen", "http", "cli", "cli.go"),
+		}
+		imports = map[string][]string{
+			filepath.Join("gen", "http", "cli", "cli.go"): {
+				`services/one/gen/http/one/client"`,
+				`"example.com/billing/gen/http/two/client"`,
+			},
+			filepath.Join("services", "one", "gen", "http", "one", "server", "server.go"): {
+				`services/one/gen/one"`,
+			},
+			filepath.Join("billing", "gen", "http", "two", "server", "server.go"): {
+				`"example.com/billing/gen/two"`,
+			},
+		}
+	)
+	if err := os.MkdirAll(filepath.Join(dir, filepath.Dir(stale)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, stale), []byte("package one\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	httpcodegen.RunHTTPDSL(t, testdata.OutputDSL)
+	if _, err := Generate(dir, "gen"); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range expected {
+		if _, err := os.Stat(filepath.Join(dir, p)); err != nil {
+			t.Errorf("%s was not generated", p)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, stale)); !os.IsNotExist(err) {
+		t.Errorf("%s was not deleted", stale)
+	}
+	for p, imps := range imports {
+		content, err := ioutil.ReadFile(filepath.Join(dir, p))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, imp := range imps {
+			if !strings.Contains(string(content), imp) {
+				t.Errorf("%s does not import %s", p, imp)
+			}
+		}
+	}
+}
+
+func TestIncrementalOwnerOutput(t *testing.T) {
+	httpcodegen.RunHTTPDSL(t, testdata.OutputDSL)
+	roots, err := eval.Context.Roots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	inc, err := newIncremental(os.TempDir(), "goa.design/goa/gen", roots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := map[string]string{
+		filepath.Join("services", "one", "gen", "one", "service.go"):                  "one",
+		filepath.Join("services", "one", "gen", "http", "one", "server", "server.go"): "one",
+		filepath.Join("billing", "gen", "two", "views", "view.go"):                    "two",
+		filepath.Join("billing", "gen", "http", "two", "client", "client.go"):         "two",
+		filepath.Join("gen", "http", "cli", "cli.go"):                                 "",
+		filepath.Join("gen", "one", "service.go"):                                     "",
+	}
+	for p, expected := range cases {
+		if actual := inc.owner(p); actual != expected {
+			t.Errorf("%s: got owner %q, expected %q", p, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package generator
+
+import (
+	"goa.design/goa/codegen"
+	"goa.design/goa/eval"
+	httpcodegen "goa.design/goa/http/codegen"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// Reference iterates through the roots and returns the files that render the
+// API reference in Markdown and HTML. It returns no file if the roots slice
+// does not include a HTTP root.
+func Reference(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
+	for _, root := range roots {
+		if r, ok := root.(*httpdesign.RootExpr); ok {
+			return httpcodegen.ReferenceFiles(r), nil
+		}
+	}
+	return nil, nil
+}
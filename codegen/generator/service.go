@@ -17,12 +17,18 @@ func Service(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 	for _, root := range roots {
 		switch r := root.(type) {
 		case *design.RootExpr:
+			service.Services.Analyze(r.Services)
 			for _, s := range r.Services {
 				// Make sure service is first so name scope is
 				// properly initialized.
 				files = append(files, service.File(genpkg, s))
 				files = append(files, service.EndpointFile(genpkg, s))
 				files = append(files, service.ClientFile(s))
+				files = append(files, service.MocksFile(genpkg, s))
+				files = append(files, service.ServiceMockFile(s))
+				if f := service.ProxyFile(s); f != nil {
+					files = append(files, f)
+				}
 				if f := service.ViewsFile(genpkg, s); f != nil {
 					files = append(files, f)
 				}
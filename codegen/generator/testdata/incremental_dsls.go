@@ -0,0 +1,94 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var IncrementalDSL = func() {
+	var Shared = Type("Shared", func() {
+		Attribute("name", String)
+	})
+	Service("one", func() {
+		Method("get", func() {
+			Payload(Shared)
+			Result(String)
+			HTTP(func() {
+				POST("/one")
+			})
+		})
+	})
+	Service("two", func() {
+		Method("get", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/two")
+			})
+		})
+	})
+}
+
+var IncrementalServiceChangedDSL = func() {
+	var Shared = Type("Shared", func() {
+		Attribute("name", String)
+	})
+	Service("one", func() {
+		Method("get", func() {
+			Payload(Shared)
+			Result(String)
+			HTTP(func() {
+				POST("/one")
+			})
+		})
+	})
+	Service("two", func() {
+		Method("get", func() {
+			Payload(String)
+			Result(Int)
+			HTTP(func() {
+				POST("/two")
+			})
+		})
+	})
+}
+
+var IncrementalTypeChangedDSL = func() {
+	var Shared = Type("Shared", func() {
+		Attribute("name", String)
+		Attribute("count", Int)
+	})
+	Service("one", func() {
+		Method("get", func() {
+			Payload(Shared)
+			Result(String)
+			HTTP(func() {
+				POST("/one")
+			})
+		})
+	})
+	Service("two", func() {
+		Method("get", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/two")
+			})
+		})
+	})
+}
+
+var IncrementalServiceRemovedDSL = func() {
+	var Shared = Type("Shared", func() {
+		Attribute("name", String)
+	})
+	Service("one", func() {
+		Method("get", func() {
+			Payload(Shared)
+			Result(String)
+			HTTP(func() {
+				POST("/one")
+			})
+		})
+	})
+}
@@ -0,0 +1,31 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var OutputDSL = func() {
+	API("monorepo", func() {
+		Output("services/{service}/gen")
+	})
+	Service("one", func() {
+		Method("get", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/one")
+			})
+		})
+	})
+	Service("two", func() {
+		Output("billing/gen", "example.com/billing/gen")
+		Method("get", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/two")
+			})
+		})
+	})
+}
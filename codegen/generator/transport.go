@@ -4,29 +4,44 @@ import (
 	"fmt"
 
 	"goa.design/goa/codegen"
+	"goa.design/goa/design"
 	"goa.design/goa/eval"
 	httpcodegen "goa.design/goa/http/codegen"
 	httpdesign "goa.design/goa/http/design"
+	pubsubcodegen "goa.design/goa/pubsub/codegen"
 )
 
 // Transport iterates through the roots and returns the files needed to render
 // the transport code. It returns an error if the roots slice does not include
-// at least one transport design roots.
+// at least one transport design roots. The pub/sub transport files are
+// generated in addition to the HTTP transport files when the design defines
+// events.
 func Transport(genpkg string, roots []eval.Root) ([]*codegen.File, error) {
 	var files []*codegen.File
 	for _, root := range roots {
 		if r, ok := root.(*httpdesign.RootExpr); ok {
+			httpcodegen.HTTPServices.Analyze(r.HTTPServices)
 			files = httpcodegen.ServerFiles(genpkg, r)
 			files = append(files, httpcodegen.ClientFiles(genpkg, r)...)
 			files = append(files, httpcodegen.ServerTypeFiles(genpkg, r)...)
 			files = append(files, httpcodegen.ClientTypeFiles(genpkg, r)...)
 			files = append(files, httpcodegen.PathFiles(r)...)
 			files = append(files, httpcodegen.ClientCLIFiles(genpkg, r)...)
+			files = append(files, httpcodegen.BenchmarkFiles(genpkg, r)...)
+			files = append(files, httpcodegen.WebhookFiles(genpkg, r)...)
+			files = append(files, httpcodegen.ComposeFiles(genpkg, r)...)
 			break
 		}
 	}
 	if len(files) == 0 {
 		return nil, fmt.Errorf("transport: no HTTP design found")
 	}
+	for _, root := range roots {
+		if r, ok := root.(*design.RootExpr); ok && pubsubcodegen.HasEvents(r.Services) {
+			files = append(files, pubsubcodegen.PublisherFiles(genpkg, r)...)
+			files = append(files, pubsubcodegen.SubscriberFiles(genpkg, r)...)
+			break
+		}
+	}
 	return files, nil
 }
@@ -70,8 +70,8 @@ func Goify(str string, firstUpper bool) string {
 
 		// [w,i] is a word.
 		word := string(runes[w:i])
-		// is it one of our initialisms?
-		if u := strings.ToUpper(word); commonInitialisms[u] {
+		// is it one of our initialisms or one listed in the design?
+		if u := strings.ToUpper(word); commonInitialisms[u] || isDesignInitialism(u) {
 			if firstUpper {
 				u = strings.ToUpper(u)
 			} else if w == 0 {
@@ -0,0 +1,270 @@
+/*
+Package graph renders the graph of the services, methods and types of a design
+in the Graphviz DOT and Mermaid formats so that large designs can be
+visualized and reviewed. The methods are grouped by service and linked to the
+types of their payloads, results and errors, the types are linked to the types
+of their attributes.
+*/
+package graph
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+)
+
+type (
+	// Data is the data needed to render the design graph.
+	Data struct {
+		// Name is the API name.
+		Name string
+		// Services lists the services and their methods.
+		Services []*ServiceData
+		// Types lists the type nodes sorted by name.
+		Types []*NodeData
+		// Edges lists the relationships between the methods and the
+		// types and between the types.
+		Edges []*EdgeData
+	}
+
+	// ServiceData describes a service node group.
+	ServiceData struct {
+		// ID is the identifier of the group.
+		ID string
+		// Name is the service name.
+		Name string
+		// Methods lists the method nodes.
+		Methods []*NodeData
+	}
+
+	// NodeData describes a graph node.
+	NodeData struct {
+		// ID is the node identifier.
+		ID string
+		// Label is the node label.
+		Label string
+	}
+
+	// EdgeData describes a graph edge.
+	EdgeData struct {
+		// From is the identifier of the source node.
+		From string
+		// To is the identifier of the target node.
+		To string
+		// Label is the edge label, e.g. "payload" or the name of the
+		// attribute for edges between types.
+		Label string
+	}
+
+	// builder collects the nodes and edges of the graph.
+	builder struct {
+		data  *Data
+		types map[string]design.UserType
+		edges map[EdgeData]struct{}
+	}
+)
+
+// Files returns the files that render the design graph in the DOT and Mermaid
+// formats in the gen/docs directory. The files are made of the "graph-dot" and
+// "graph-mermaid" sections respectively.
+func Files(root *design.RootExpr) []*codegen.File {
+	data := Build(root)
+	return []*codegen.File{
+		graphFile("design.dot", "graph-dot", dotT, data),
+		graphFile("design.mmd", "graph-mermaid", mermaidT, data),
+	}
+}
+
+// Build returns the graph of the services, methods and types of the given
+// design. The built-in empty and error types are not included in the graph.
+func Build(root *design.RootExpr) *Data {
+	b := &builder{
+		data:  &Data{},
+		types: make(map[string]design.UserType),
+		edges: make(map[EdgeData]struct{}),
+	}
+	if root.API != nil {
+		b.data.Name = root.API.Name
+	}
+	for _, t := range root.Types {
+		b.addType(t)
+	}
+	for _, t := range root.ResultTypes {
+		b.addType(t)
+	}
+	for _, svc := range root.Services {
+		sd := &ServiceData{ID: nodeID("svc", svc.Name), Name: svc.Name}
+		for _, m := range svc.Methods {
+			id := nodeID("m", svc.Name, m.Name)
+			sd.Methods = append(sd.Methods, &NodeData{ID: id, Label: m.Name})
+			payload, result := "payload", "result"
+			if m.Stream == design.ClientStreamKind || m.Stream == design.BidirectionalStreamKind {
+				payload = "payload stream"
+			}
+			if m.IsResultStreaming() {
+				result = "result stream"
+			}
+			b.link(id, payload, m.Payload)
+			b.link(id, result, m.Result)
+			for _, e := range append(append([]*design.ErrorExpr{}, svc.Errors...), m.Errors...) {
+				att := e.AttributeExpr
+				if ut, ok := att.Type.(*design.UserTypeExpr); ok && ut.TypeName == e.Name && design.IsPrimitive(ut.Type) {
+					// Primitive error types are wrapped in a user
+					// type named after the error.
+					continue
+				}
+				b.link(id, "error "+e.Name, att)
+			}
+		}
+		b.data.Services = append(b.data.Services, sd)
+	}
+
+	// Link the types to the types of their attributes, the types referenced
+	// by the attributes are added to the graph as the loop proceeds.
+	for i := 0; i < len(b.data.Types); i++ {
+		ut := b.types[b.data.Types[i].ID]
+		if obj := design.AsObject(ut.Attribute().Type); obj != nil {
+			for _, nat := range *obj {
+				b.link(b.data.Types[i].ID, nat.Name, nat.Attribute)
+			}
+			continue
+		}
+		b.link(b.data.Types[i].ID, "", ut.Attribute())
+	}
+	sort.Slice(b.data.Types, func(i, j int) bool { return b.data.Types[i].Label < b.data.Types[j].Label })
+	return b.data
+}
+
+// addType adds a node for ut to the graph if not already present and returns
+// its identifier.
+func (b *builder) addType(ut design.UserType) string {
+	id := nodeID("t", ut.Name())
+	if _, ok := b.types[id]; ok {
+		return id
+	}
+	b.types[id] = ut
+	b.data.Types = append(b.data.Types, &NodeData{ID: id, Label: ut.Name()})
+	return id
+}
+
+// link adds edges with the given label from the node with the given
+// identifier to the nodes of the user types used by att. Arrays, maps and
+// inline objects are traversed.
+func (b *builder) link(from, label string, att *design.AttributeExpr) {
+	if att == nil {
+		return
+	}
+	for _, ut := range userTypes(att.Type, nil) {
+		if ut == design.Empty || ut == design.ErrorResult {
+			continue
+		}
+		e := EdgeData{From: from, To: b.addType(ut), Label: label}
+		if _, ok := b.edges[e]; ok {
+			continue
+		}
+		b.edges[e] = struct{}{}
+		b.data.Edges = append(b.data.Edges, &e)
+	}
+}
+
+// userTypes returns the user types used by dt without traversing them.
+func userTypes(dt design.DataType, seen map[*design.Object]struct{}) []design.UserType {
+	switch actual := dt.(type) {
+	case design.UserType:
+		return []design.UserType{actual}
+	case *design.Array:
+		return userTypes(actual.ElemType.Type, seen)
+	case *design.Map:
+		return append(userTypes(actual.KeyType.Type, seen), userTypes(actual.ElemType.Type, seen)...)
+	case *design.Object:
+		if seen == nil {
+			seen = make(map[*design.Object]struct{})
+		}
+		if _, ok := seen[actual]; ok {
+			return nil
+		}
+		seen[actual] = struct{}{}
+		var uts []design.UserType
+		for _, nat := range *actual {
+			uts = append(uts, userTypes(nat.Attribute.Type, seen)...)
+		}
+		return uts
+	}
+	return nil
+}
+
+// nodeID returns a node identifier made of the given prefix and names that
+// is valid in both the DOT and Mermaid formats.
+func nodeID(prefix string, names ...string) string {
+	id := prefix
+	for _, n := range names {
+		id += "_" + codegen.SnakeCase(n)
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, id)
+}
+
+// graphFile returns the file with the given name in the gen/docs directory
+// rendering data with the given template.
+func graphFile(name, section, source string, data *Data) *codegen.File {
+	return &codegen.File{
+		Path: filepath.Join(codegen.Gendir, "docs", name),
+		SectionTemplates: []*codegen.SectionTemplate{{
+			Name:    section,
+			Source:  source,
+			Data:    data,
+			FuncMap: template.FuncMap{"mermaid": mermaidEscape},
+		}},
+	}
+}
+
+// mermaidEscape escapes the double quotes of the Mermaid label s.
+func mermaidEscape(s string) string {
+	return strings.Replace(s, `"`, "#quot;", -1)
+}
+
+// input: Data
+const dotT = `digraph {{ printf "%q" .Name }} {
+	rankdir=LR;
+	node [fontname="Helvetica"];
+{{- range .Services }}
+	subgraph {{ printf "cluster_%s" .ID }} {
+		label={{ printf "%q" .Name }};
+	{{- range .Methods }}
+		{{ .ID }} [label={{ printf "%q" .Label }}, shape=ellipse];
+	{{- end }}
+	}
+{{- end }}
+{{- range .Types }}
+	{{ .ID }} [label={{ printf "%q" .Label }}, shape=box];
+{{- end }}
+{{- range .Edges }}
+	{{ .From }} -> {{ .To }}{{ if .Label }} [label={{ printf "%q" .Label }}]{{ end }};
+{{- end }}
+}
+`
+
+// input: Data
+const mermaidT = `flowchart LR
+{{- range .Services }}
+	subgraph {{ .ID }}["{{ mermaid .Name }}"]
+	{{- range .Methods }}
+		{{ .ID }}(["{{ mermaid .Label }}"])
+	{{- end }}
+	end
+{{- end }}
+{{- range .Types }}
+	{{ .ID }}["{{ mermaid .Label }}"]
+{{- end }}
+{{- range .Edges }}
+	{{ .From }} -->{{ if .Label }}|"{{ mermaid .Label }}"|{{ end }} {{ .To }}
+{{- end }}
+`
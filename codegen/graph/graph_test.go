@@ -0,0 +1,41 @@
+package graph
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/graph/testdata"
+)
+
+func TestFiles(t *testing.T) {
+	root := codegen.RunDSL(t, testdata.GraphDSL)
+	files := Files(root)
+	expected := []struct {
+		Path string
+		Code string
+	}{
+		{filepath.Join("gen", "docs", "design.dot"), testdata.GraphDOTCode},
+		{filepath.Join("gen", "docs", "design.mmd"), testdata.GraphMermaidCode},
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("got %d files, expected %d", len(files), len(expected))
+	}
+	for i, f := range files {
+		if f.Path != expected[i].Path {
+			t.Errorf("file %d: got path %q, expected %q", i, f.Path, expected[i].Path)
+			continue
+		}
+		s := f.SectionTemplates[0]
+		var buf bytes.Buffer
+		tmpl := template.Must(template.New(s.Name).Funcs(s.FuncMap).Parse(s.Source))
+		if err := tmpl.Execute(&buf, s.Data); err != nil {
+			t.Fatalf("failed to render template: %s", err)
+		}
+		if code := buf.String(); code != expected[i].Code {
+			t.Errorf("invalid code for %s, got:\n%s\ngot vs. expected:\n%s", f.Path, code, codegen.Diff(t, code, expected[i].Code))
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package testdata
+
+var GraphDOTCode = `digraph "test api" {
+	rankdir=LR;
+	node [fontname="Helvetica"];
+	subgraph cluster_svc_storage {
+		label="storage";
+		m_storage_show [label="show", shape=ellipse];
+		m_storage_watch [label="watch", shape=ellipse];
+	}
+	subgraph cluster_svc_sommelier {
+		label="sommelier";
+		m_sommelier_pick [label="pick", shape=ellipse];
+	}
+	t_bottle [label="Bottle", shape=box];
+	t_not_found [label="NotFound", shape=box];
+	t_winery [label="Winery", shape=box];
+	m_storage_show -> t_bottle [label="result"];
+	m_storage_show -> t_not_found [label="error not_found"];
+	m_storage_watch -> t_bottle [label="result stream"];
+	m_sommelier_pick -> t_winery [label="payload"];
+	m_sommelier_pick -> t_bottle [label="result"];
+	t_bottle -> t_winery [label="winery"];
+	t_bottle -> t_bottle [label="parent"];
+	t_bottle -> t_bottle [label="related"];
+}
+`
+
+var GraphMermaidCode = `flowchart LR
+	subgraph svc_storage["storage"]
+		m_storage_show(["show"])
+		m_storage_watch(["watch"])
+	end
+	subgraph svc_sommelier["sommelier"]
+		m_sommelier_pick(["pick"])
+	end
+	t_bottle["Bottle"]
+	t_not_found["NotFound"]
+	t_winery["Winery"]
+	m_storage_show -->|"result"| t_bottle
+	m_storage_show -->|"error not_found"| t_not_found
+	m_storage_watch -->|"result stream"| t_bottle
+	m_sommelier_pick -->|"payload"| t_winery
+	m_sommelier_pick -->|"result"| t_bottle
+	t_bottle -->|"winery"| t_winery
+	t_bottle -->|"parent"| t_bottle
+	t_bottle -->|"related"| t_bottle
+`
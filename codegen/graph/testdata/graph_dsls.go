@@ -0,0 +1,43 @@
+package testdata
+
+import (
+	. "goa.design/goa/design"
+	. "goa.design/goa/dsl"
+)
+
+var GraphDSL = func() {
+	var Winery = Type("Winery", func() {
+		Attribute("name", String)
+	})
+	var Bottle = Type("Bottle", func() {
+		Attribute("name", String)
+		Attribute("winery", Winery)
+		Attribute("parent", "Bottle")
+		Attribute("related", ArrayOf("Bottle"))
+	})
+	var NotFound = Type("NotFound", func() {
+		Attribute("id", String)
+	})
+	Service("storage", func() {
+		Error("unavailable")
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(Bottle)
+			Error("not_found", NotFound)
+			Error("bad_request", String)
+		})
+		Method("watch", func() {
+			StreamingResult(MapOf(String, Bottle))
+		})
+	})
+	Service("sommelier", func() {
+		Method("pick", func() {
+			Payload(func() {
+				Attribute("winery", Winery)
+			})
+			Result(ArrayOf(Bottle))
+		})
+	})
+}
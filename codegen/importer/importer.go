@@ -0,0 +1,127 @@
+// Package importer produces starter goa designs from existing API
+// descriptions so that APIs can be migrated to goa without transcribing their
+// contracts by hand. The produced designs are meant to be reviewed and edited:
+// constructs that have no equivalent in the goa DSL are skipped.
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"goa.design/goa/codegen"
+)
+
+// designWriter accumulates the Go code of a design file.
+type designWriter struct {
+	buf bytes.Buffer
+}
+
+// reserved lists the identifiers exported by the DSL packages that the design
+// variables must not shadow.
+var reserved = map[string]struct{}{
+	"API": {}, "Any": {}, "ArrayOf": {}, "Attribute": {}, "Body": {},
+	"Boolean": {}, "Bytes": {}, "Default": {}, "Description": {}, "Docs": {},
+	"Enum": {}, "Error": {}, "ErrorResult": {}, "Example": {}, "Extend": {},
+	"Field": {}, "Float32": {}, "Float64": {}, "Format": {}, "HTTP": {},
+	"Header": {}, "Int": {}, "Int32": {}, "Int64": {}, "MapOf": {},
+	"MaxLength": {}, "Maximum": {}, "Meta": {}, "Method": {},
+	"MinLength": {}, "Minimum": {}, "Param": {}, "Path": {}, "Pattern": {},
+	"Payload": {}, "Required": {}, "Response": {}, "Result": {},
+	"ResultType": {}, "Server": {}, "Service": {}, "String": {}, "Title": {},
+	"Type": {}, "UInt": {}, "UInt32": {}, "UInt64": {}, "Version": {},
+	"View": {},
+}
+
+// line writes a line indented with the given depth.
+func (w *designWriter) line(depth int, format string, args ...interface{}) {
+	w.buf.WriteString(strings.Repeat("\t", depth))
+	fmt.Fprintf(&w.buf, format, args...)
+	w.buf.WriteByte('\n')
+}
+
+// header writes the package clause and the DSL imports.
+func (w *designWriter) header(pkg string) {
+	w.line(0, "package %s", pkg)
+	w.line(0, "")
+	w.line(0, "import (")
+	w.line(1, `. "goa.design/goa/http/design"`)
+	w.line(1, `. "goa.design/goa/http/dsl"`)
+	w.line(0, ")")
+}
+
+// source returns the formatted Go code.
+func (w *designWriter) source() ([]byte, error) {
+	src, err := format.Source(w.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format design: %s\n%s", err, w.buf.String())
+	}
+	return src, nil
+}
+
+// typeVarName returns the name of the Go variable holding the user type with
+// the given name.
+func typeVarName(name string) string {
+	v := codegen.Goify(name, true)
+	if _, ok := reserved[v]; ok {
+		v += "Type"
+	}
+	return v
+}
+
+// snakeName returns the snake case version of name where the characters that
+// are not letters or digits separate words, e.g. "pet_id" for "petId" and
+// "x_request_id" for "X-Request-ID".
+func snakeName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, w := range words {
+		words[i] = codegen.SnakeCase(w)
+	}
+	return strings.Join(words, "_")
+}
+
+// literal returns the Go literal of the given value, the empty string if the
+// value is not a primitive.
+func literal(v interface{}) string {
+	switch actual := v.(type) {
+	case string:
+		return strconv.Quote(actual)
+	case bool:
+		return strconv.FormatBool(actual)
+	case int:
+		return strconv.Itoa(actual)
+	case int64:
+		return strconv.FormatInt(actual, 10)
+	case uint64:
+		return strconv.FormatUint(actual, 10)
+	case float64:
+		return strconv.FormatFloat(actual, 'f', -1, 64)
+	}
+	return ""
+}
+
+// literals returns the comma separated Go literals of the given values, the
+// empty string if one of the values is not a primitive.
+func literals(vs []interface{}) string {
+	ls := make([]string, len(vs))
+	for i, v := range vs {
+		if ls[i] = literal(v); ls[i] == "" {
+			return ""
+		}
+	}
+	return strings.Join(ls, ", ")
+}
+
+// quoted returns the comma separated Go literals of the given strings.
+func quoted(ss []string) string {
+	qs := make([]string, len(ss))
+	for i, s := range ss {
+		qs[i] = strconv.Quote(s)
+	}
+	return strings.Join(qs, ", ")
+}
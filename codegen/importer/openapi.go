@@ -0,0 +1,978 @@
+package importer
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"goa.design/goa/codegen"
+	yaml "gopkg.in/yaml.v2"
+)
+
+type (
+	// openAPIDoc is the subset of the Swagger 2.0 and OpenAPI 3 documents
+	// used to produce designs.
+	openAPIDoc struct {
+		Swagger     string                      `yaml:"swagger"`
+		OpenAPI     string                      `yaml:"openapi"`
+		Info        *openAPIInfo                `yaml:"info"`
+		Host        string                      `yaml:"host"`
+		BasePath    string                      `yaml:"basePath"`
+		Schemes     []string                    `yaml:"schemes"`
+		Servers     []*openAPIServer            `yaml:"servers"`
+		Paths       orderedPaths                `yaml:"paths"`
+		Definitions orderedSchemas              `yaml:"definitions"`
+		Parameters  map[string]*openAPIParam    `yaml:"parameters"`
+		Responses   map[string]*openAPIResponse `yaml:"responses"`
+		Components  *openAPIComponents          `yaml:"components"`
+	}
+
+	// openAPIInfo describes the API.
+	openAPIInfo struct {
+		Title       string `yaml:"title"`
+		Description string `yaml:"description"`
+		Version     string `yaml:"version"`
+	}
+
+	// openAPIServer describes an OpenAPI 3 server.
+	openAPIServer struct {
+		URL         string `yaml:"url"`
+		Description string `yaml:"description"`
+	}
+
+	// openAPIComponents holds the OpenAPI 3 reusable objects.
+	openAPIComponents struct {
+		Schemas       orderedSchemas              `yaml:"schemas"`
+		Parameters    map[string]*openAPIParam    `yaml:"parameters"`
+		Responses     map[string]*openAPIResponse `yaml:"responses"`
+		RequestBodies map[string]*openAPIBody     `yaml:"requestBodies"`
+	}
+
+	// openAPIPath describes the operations of a path.
+	openAPIPath struct {
+		Get        *openAPIOperation `yaml:"get"`
+		Put        *openAPIOperation `yaml:"put"`
+		Post       *openAPIOperation `yaml:"post"`
+		Delete     *openAPIOperation `yaml:"delete"`
+		Options    *openAPIOperation `yaml:"options"`
+		Head       *openAPIOperation `yaml:"head"`
+		Patch      *openAPIOperation `yaml:"patch"`
+		Parameters []*openAPIParam   `yaml:"parameters"`
+	}
+
+	// openAPIOperation describes an operation.
+	openAPIOperation struct {
+		OperationID string                      `yaml:"operationId"`
+		Summary     string                      `yaml:"summary"`
+		Description string                      `yaml:"description"`
+		Tags        []string                    `yaml:"tags"`
+		Parameters  []*openAPIParam             `yaml:"parameters"`
+		RequestBody *openAPIBody                `yaml:"requestBody"`
+		Responses   map[string]*openAPIResponse `yaml:"responses"`
+	}
+
+	// openAPIParam describes an operation parameter.
+	openAPIParam struct {
+		Ref         string `yaml:"$ref"`
+		Name        string `yaml:"name"`
+		In          string `yaml:"in"`
+		Description string `yaml:"description"`
+		Required    bool   `yaml:"required"`
+		// Schema describes the Swagger 2.0 body parameters and the
+		// OpenAPI 3 parameters.
+		Schema *openAPISchema `yaml:"schema"`
+
+		// The fields below describe the Swagger 2.0 non-body
+		// parameters.
+
+		Type      string         `yaml:"type"`
+		Format    string         `yaml:"format"`
+		Items     *openAPISchema `yaml:"items"`
+		Enum      []interface{}  `yaml:"enum"`
+		Default   interface{}    `yaml:"default"`
+		Pattern   string         `yaml:"pattern"`
+		Minimum   *float64       `yaml:"minimum"`
+		Maximum   *float64       `yaml:"maximum"`
+		MinLength *int           `yaml:"minLength"`
+		MaxLength *int           `yaml:"maxLength"`
+		MinItems  *int           `yaml:"minItems"`
+		MaxItems  *int           `yaml:"maxItems"`
+	}
+
+	// openAPIBody describes an OpenAPI 3 request body.
+	openAPIBody struct {
+		Ref         string                       `yaml:"$ref"`
+		Description string                       `yaml:"description"`
+		Content     map[string]*openAPIMediaType `yaml:"content"`
+	}
+
+	// openAPIResponse describes an operation response.
+	openAPIResponse struct {
+		Ref         string                       `yaml:"$ref"`
+		Description string                       `yaml:"description"`
+		Schema      *openAPISchema               `yaml:"schema"`
+		Content     map[string]*openAPIMediaType `yaml:"content"`
+	}
+
+	// openAPIMediaType describes the OpenAPI 3 content of a request or
+	// response body.
+	openAPIMediaType struct {
+		Schema *openAPISchema `yaml:"schema"`
+	}
+
+	// openAPISchema describes a data type.
+	openAPISchema struct {
+		Ref                  string            `yaml:"$ref"`
+		Type                 string            `yaml:"type"`
+		Format               string            `yaml:"format"`
+		Description          string            `yaml:"description"`
+		Properties           orderedSchemas    `yaml:"properties"`
+		Required             []string          `yaml:"required"`
+		Items                *openAPISchema    `yaml:"items"`
+		AdditionalProperties *additionalSchema `yaml:"additionalProperties"`
+		AllOf                []*openAPISchema  `yaml:"allOf"`
+		Enum                 []interface{}     `yaml:"enum"`
+		Default              interface{}       `yaml:"default"`
+		Example              interface{}       `yaml:"example"`
+		Pattern              string            `yaml:"pattern"`
+		Minimum              *float64          `yaml:"minimum"`
+		Maximum              *float64          `yaml:"maximum"`
+		MinLength            *int              `yaml:"minLength"`
+		MaxLength            *int              `yaml:"maxLength"`
+		MinItems             *int              `yaml:"minItems"`
+		MaxItems             *int              `yaml:"maxItems"`
+	}
+
+	// additionalSchema describes the additionalProperties field which
+	// is either a boolean or a schema.
+	additionalSchema struct {
+		Allowed bool
+		Schema  *openAPISchema
+	}
+
+	// orderedSchemas is a set of schemas indexed by name that records the
+	// order the schemas are defined in.
+	orderedSchemas struct {
+		Names   []string
+		Schemas map[string]*openAPISchema
+	}
+
+	// orderedPaths is a set of paths that records the order the paths are
+	// defined in.
+	orderedPaths struct {
+		Keys  []string
+		Paths map[string]*openAPIPath
+	}
+
+	// openAPIImporter produces a design from an OpenAPI document.
+	openAPIImporter struct {
+		doc *openAPIDoc
+		w   *designWriter
+		// schemas lists the named schemas indexed by name.
+		schemas map[string]*openAPISchema
+		// cyclic records the names of the named schemas that refer to
+		// themselves directly or indirectly.
+		cyclic map[string]bool
+	}
+
+	// importedMethod describes a design method built from an operation.
+	importedMethod struct {
+		Name    string
+		Verb    string
+		Path    string
+		Op      *openAPIOperation
+		Params  []*openAPIParam
+		Body    *openAPISchema
+		Success string
+		Result  *openAPISchema
+		Errors  []*importedError
+	}
+
+	// importedError describes a design error built from an error response.
+	importedError struct {
+		Name   string
+		Status string
+		Desc   string
+		Schema *openAPISchema
+	}
+)
+
+// OpenAPI returns the Go source code of a design package named pkg built from
+// the given Swagger 2.0 or OpenAPI 3 document encoded in YAML or JSON. The
+// design defines one service per operation tag (or a single service named
+// after the API if the operations are not tagged), one method per operation
+// and one user type per schema definition. The method payloads gather the
+// operation parameters and request body, the results are built from the first
+// successful response and the other error responses define method errors.
+func OpenAPI(doc []byte, pkg string) ([]byte, error) {
+	var d openAPIDoc
+	if err := yaml.Unmarshal(doc, &d); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %s", err)
+	}
+	if d.Swagger == "" && d.OpenAPI == "" {
+		return nil, fmt.Errorf("not an OpenAPI document: missing swagger or openapi version field")
+	}
+	imp := &openAPIImporter{doc: &d, w: &designWriter{}, schemas: make(map[string]*openAPISchema), cyclic: make(map[string]bool)}
+	return imp.design(pkg)
+}
+
+// UnmarshalYAML records the order of the schemas.
+func (o *orderedSchemas) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var ms yaml.MapSlice
+	if err := unmarshal(&ms); err != nil {
+		return err
+	}
+	if err := unmarshal(&o.Schemas); err != nil {
+		return err
+	}
+	for _, item := range ms {
+		o.Names = append(o.Names, fmt.Sprint(item.Key))
+	}
+	return nil
+}
+
+// UnmarshalYAML records the order of the paths.
+func (o *orderedPaths) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var ms yaml.MapSlice
+	if err := unmarshal(&ms); err != nil {
+		return err
+	}
+	if err := unmarshal(&o.Paths); err != nil {
+		return err
+	}
+	for _, item := range ms {
+		o.Keys = append(o.Keys, fmt.Sprint(item.Key))
+	}
+	return nil
+}
+
+// UnmarshalYAML decodes either a boolean or a schema.
+func (a *additionalSchema) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var b bool
+	if err := unmarshal(&b); err == nil {
+		a.Allowed = b
+		return nil
+	}
+	a.Allowed = true
+	return unmarshal(&a.Schema)
+}
+
+// design writes the design and returns the formatted code.
+func (i *openAPIImporter) design(pkg string) ([]byte, error) {
+	names := i.doc.Definitions.Names
+	for n, s := range i.doc.Definitions.Schemas {
+		i.schemas[n] = s
+	}
+	if c := i.doc.Components; c != nil {
+		names = append(names, c.Schemas.Names...)
+		for n, s := range c.Schemas.Schemas {
+			i.schemas[n] = s
+		}
+	}
+	for _, n := range names {
+		i.cyclic[n] = i.refers(i.schemas[n], n, make(map[string]struct{}))
+	}
+
+	i.w.header(pkg)
+	i.api()
+	i.services()
+	for _, n := range names {
+		i.userType(n, i.schemas[n])
+	}
+	return i.w.source()
+}
+
+// api writes the API expression.
+func (i *openAPIImporter) api() {
+	var (
+		info  = i.doc.Info
+		title string
+	)
+	if info == nil {
+		info = &openAPIInfo{}
+	}
+	title = info.Title
+	if title == "" {
+		title = "api"
+	}
+	i.w.line(0, "")
+	i.w.line(0, "var _ = API(%q, func() {", i.apiName())
+	i.w.line(1, "Title(%q)", title)
+	if info.Description != "" {
+		i.w.line(1, "Description(%q)", info.Description)
+	}
+	if info.Version != "" {
+		i.w.line(1, "Version(%q)", info.Version)
+	}
+	basePath := i.doc.BasePath
+	if i.doc.Host != "" {
+		schemes := i.doc.Schemes
+		if len(schemes) == 0 {
+			schemes = []string{"http"}
+		}
+		for _, s := range schemes {
+			i.w.line(1, "Server(%q)", s+"://"+i.doc.Host)
+		}
+	}
+	for _, s := range i.doc.Servers {
+		u, err := url.Parse(s.URL)
+		if err == nil && u.Host != "" {
+			if basePath == "" && u.Path != "" && u.Path != "/" {
+				basePath = u.Path
+			}
+			u.Path = ""
+			i.w.line(1, "Server(%q)", u.String())
+		}
+	}
+	if basePath != "" && basePath != "/" {
+		i.w.line(1, "HTTP(func() {")
+		i.w.line(2, "Path(%q)", basePath)
+		i.w.line(1, "})")
+	}
+	i.w.line(0, "})")
+}
+
+// apiName returns the name of the API.
+func (i *openAPIImporter) apiName() string {
+	if i.doc.Info == nil || i.doc.Info.Title == "" {
+		return "api"
+	}
+	return snakeName(i.doc.Info.Title)
+}
+
+// services writes one service per operation tag.
+func (i *openAPIImporter) services() {
+	var (
+		order    []string
+		services = make(map[string][]*importedMethod)
+		verbs    = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH"}
+	)
+	for _, p := range i.doc.Paths.Keys {
+		item := i.doc.Paths.Paths[p]
+		if item == nil {
+			continue
+		}
+		ops := []*openAPIOperation{item.Get, item.Put, item.Post, item.Delete, item.Options, item.Head, item.Patch}
+		for j, op := range ops {
+			if op == nil {
+				continue
+			}
+			svc := i.apiName()
+			if len(op.Tags) > 0 {
+				svc = snakeName(op.Tags[0])
+			}
+			if _, ok := services[svc]; !ok {
+				order = append(order, svc)
+			}
+			services[svc] = append(services[svc], i.method(verbs[j], p, item, op))
+		}
+	}
+	for _, svc := range order {
+		i.w.line(0, "")
+		i.w.line(0, "var _ = Service(%q, func() {", svc)
+		seen := make(map[string]int)
+		for j, m := range services[svc] {
+			if j > 0 {
+				i.w.line(0, "")
+			}
+			if n := seen[m.Name]; n > 0 {
+				seen[m.Name]++
+				m.Name += strconv.Itoa(n + 1)
+			} else {
+				seen[m.Name] = 1
+			}
+			i.writeMethod(m)
+		}
+		i.w.line(0, "})")
+	}
+}
+
+// method builds the method corresponding to the given operation.
+func (i *openAPIImporter) method(verb, path string, item *openAPIPath, op *openAPIOperation) *importedMethod {
+	m := &importedMethod{Verb: verb, Path: path, Op: op}
+	m.Name = op.OperationID
+	if m.Name == "" {
+		m.Name = strings.ToLower(verb) + " " + path
+	}
+	m.Name = snakeName(m.Name)
+
+	// Operation parameters override the path parameters with the same
+	// name and location.
+	var params []*openAPIParam
+	for _, p := range append(append([]*openAPIParam{}, item.Parameters...), op.Parameters...) {
+		p = i.param(p)
+		if p == nil {
+			continue
+		}
+		replaced := false
+		for j, e := range params {
+			if e.Name == p.Name && e.In == p.In {
+				params[j] = p
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			params = append(params, p)
+		}
+	}
+	var form []*openAPIParam
+	for _, p := range params {
+		switch p.In {
+		case "path", "query", "header":
+			m.Params = append(m.Params, p)
+		case "body":
+			m.Body = p.Schema
+		case "formData":
+			form = append(form, p)
+		}
+	}
+	if len(form) > 0 {
+		// Swagger 2.0 form parameters define the attributes of the
+		// request body.
+		m.Body = &openAPISchema{Type: "object", Properties: orderedSchemas{Schemas: make(map[string]*openAPISchema)}}
+		for _, p := range form {
+			s := p.schema()
+			s.Description = p.Description
+			m.Body.Properties.Names = append(m.Body.Properties.Names, p.Name)
+			m.Body.Properties.Schemas[p.Name] = s
+			if p.Required {
+				m.Body.Required = append(m.Body.Required, p.Name)
+			}
+		}
+	}
+	if b := i.body(op.RequestBody); b != nil {
+		m.Body = b
+	}
+
+	var codes []string
+	for c := range op.Responses {
+		codes = append(codes, c)
+	}
+	sort.Strings(codes)
+	for _, c := range codes {
+		resp := i.response(op.Responses[c])
+		if resp == nil {
+			continue
+		}
+		status, err := strconv.Atoi(c)
+		if err != nil {
+			continue // "default" and ranges such as "5XX"
+		}
+		schema := resp.Schema
+		if schema == nil {
+			schema = jsonSchema(resp.Content)
+		}
+		if status < 300 {
+			if m.Success == "" {
+				m.Success = statusName(status)
+				m.Result = schema
+			}
+			continue
+		}
+		if status >= 400 {
+			m.Errors = append(m.Errors, &importedError{
+				Name:   errorName(status),
+				Status: statusName(status),
+				Desc:   resp.Description,
+				Schema: schema,
+			})
+		}
+	}
+	return m
+}
+
+// writeMethod writes the expression of the given method.
+func (i *openAPIImporter) writeMethod(m *importedMethod) {
+	w := i.w
+	w.line(1, "Method(%q, func() {", m.Name)
+	desc := m.Op.Description
+	if desc == "" {
+		desc = m.Op.Summary
+	}
+	if desc != "" {
+		w.line(2, "Description(%q)", desc)
+	}
+
+	// Payload
+	bodyAttr := ""
+	switch {
+	case len(m.Params) == 0 && m.Body != nil && (m.Body.Ref != "" || !isObject(m.Body)):
+		w.line(2, "Payload(%s)", i.typeRef(m.Body, ""))
+	case len(m.Params) > 0 || m.Body != nil:
+		w.line(2, "Payload(func() {")
+		var required []string
+		if m.Body != nil {
+			switch {
+			case m.Body.Ref != "" && isObject(i.resolve(m.Body)):
+				w.line(3, "Extend(%s)", i.typeRef(m.Body, ""))
+			case m.Body.Ref == "" && isObject(m.Body):
+				required = append(required, i.attributes(3, m.Body, "")...)
+			default:
+				bodyAttr = "body"
+				w.line(3, "Attribute(%q, %s)", bodyAttr, i.typeRef(m.Body, ""))
+				required = append(required, bodyAttr)
+			}
+		}
+		for _, p := range m.Params {
+			i.attribute(3, paramName(p.Name), p.schema(), p.Description, "")
+			if p.Required {
+				required = append(required, paramName(p.Name))
+			}
+		}
+		if len(required) > 0 {
+			w.line(3, "Required(%s)", quoted(required))
+		}
+		w.line(2, "})")
+	}
+
+	// Result and errors
+	if m.Result != nil {
+		w.line(2, "Result(%s)", i.typeRef(m.Result, ""))
+	}
+	for _, e := range m.Errors {
+		t := "ErrorResult"
+		if e.Schema != nil && e.Schema.Ref != "" {
+			t = i.typeRef(e.Schema, "")
+		}
+		if e.Desc != "" {
+			w.line(2, "Error(%q, %s, %q)", e.Name, t, e.Desc)
+		} else {
+			w.line(2, "Error(%q, %s)", e.Name, t)
+		}
+	}
+
+	// HTTP mapping
+	path := m.Path
+	for _, p := range m.Params {
+		if p.In == "path" {
+			path = strings.Replace(path, "{"+p.Name+"}", "{"+paramName(p.Name)+"}", -1)
+		}
+	}
+	w.line(2, "HTTP(func() {")
+	w.line(3, "%s(%q)", m.Verb, path)
+	for _, p := range m.Params {
+		n := paramName(p.Name)
+		mapping := n
+		if n != p.Name {
+			mapping = n + ":" + p.Name
+		}
+		switch p.In {
+		case "query":
+			w.line(3, "Param(%q)", mapping)
+		case "header":
+			w.line(3, "Header(%q)", mapping)
+		}
+	}
+	if bodyAttr != "" {
+		w.line(3, "Body(%q)", bodyAttr)
+	}
+	if m.Success != "" && m.Success != "StatusOK" {
+		w.line(3, "Response(%s)", m.Success)
+	}
+	for _, e := range m.Errors {
+		w.line(3, "Response(%q, %s)", e.Name, e.Status)
+	}
+	w.line(2, "})")
+	w.line(1, "})")
+}
+
+// userType writes the user type built from the named schema.
+func (i *openAPIImporter) userType(name string, s *openAPISchema) {
+	if s == nil {
+		return
+	}
+	w := i.w
+	w.line(0, "")
+	if s.Ref == "" && (isObject(s) || len(s.AllOf) > 0) {
+		w.line(0, "var %s = Type(%q, func() {", typeVarName(name), codegen.Goify(name, true))
+		if s.Description != "" {
+			w.line(1, "Description(%q)", s.Description)
+		}
+		required := i.objectAttributes(1, s, name)
+		if len(required) > 0 {
+			w.line(1, "Required(%s)", quoted(required))
+		}
+		w.line(0, "})")
+		return
+	}
+	w.line(0, "var %s = Type(%q, %s, func() {", typeVarName(name), codegen.Goify(name, true), i.typeRef(s, name))
+	if s.Description != "" {
+		w.line(1, "Description(%q)", s.Description)
+	}
+	i.validations(1, s)
+	w.line(0, "})")
+}
+
+// objectAttributes writes the attributes of the object described by s
+// including the attributes of the allOf schemas and returns the names of the
+// required attributes. parent is the name of the named schema being written
+// if any.
+func (i *openAPIImporter) objectAttributes(depth int, s *openAPISchema, parent string) []string {
+	var required []string
+	for _, sub := range s.AllOf {
+		if sub.Ref != "" {
+			i.w.line(depth, "Extend(%s)", i.typeRef(sub, parent))
+			continue
+		}
+		required = append(required, i.objectAttributes(depth, sub, parent)...)
+	}
+	return append(required, i.attributes(depth, s, parent)...)
+}
+
+// attributes writes the attributes of the properties of the object described
+// by s and returns the names of the required attributes.
+func (i *openAPIImporter) attributes(depth int, s *openAPISchema, parent string) []string {
+	for _, n := range s.Properties.Names {
+		i.attribute(depth, n, s.Properties.Schemas[n], "", parent)
+	}
+	var required []string
+	for _, r := range s.Required {
+		if _, ok := s.Properties.Schemas[r]; ok {
+			required = append(required, r)
+		}
+	}
+	return required
+}
+
+// attribute writes the attribute with the given name described by s.
+func (i *openAPIImporter) attribute(depth int, name string, s *openAPISchema, desc, parent string) {
+	w := i.w
+	if s == nil {
+		s = &openAPISchema{}
+	}
+	if desc == "" {
+		desc = s.Description
+	}
+	args := fmt.Sprintf("%q", name)
+	if s.Ref == "" && isObject(s) && len(s.Properties.Names) > 0 {
+		// Inline object
+		if desc != "" {
+			args += fmt.Sprintf(", %q", desc)
+		}
+		w.line(depth, "Attribute(%s, func() {", args)
+		required := i.objectAttributes(depth+1, s, parent)
+		if len(required) > 0 {
+			w.line(depth+1, "Required(%s)", quoted(required))
+		}
+		w.line(depth, "})")
+		return
+	}
+	args += ", " + i.typeRef(s, parent)
+	if desc != "" {
+		args += fmt.Sprintf(", %q", desc)
+	}
+	if !hasValidations(s) {
+		w.line(depth, "Attribute(%s)", args)
+		return
+	}
+	w.line(depth, "Attribute(%s, func() {", args)
+	i.validations(depth+1, s)
+	w.line(depth, "})")
+}
+
+// validations writes the validations, default value and example of s.
+func (i *openAPIImporter) validations(depth int, s *openAPISchema) {
+	w := i.w
+	if s.Ref != "" {
+		return
+	}
+	if l := literals(s.Enum); l != "" {
+		w.line(depth, "Enum(%s)", l)
+	}
+	if f, ok := formats[s.Format]; ok {
+		w.line(depth, "Format(%s)", f)
+	}
+	if s.Pattern != "" {
+		w.line(depth, "Pattern(%q)", s.Pattern)
+	}
+	if s.Minimum != nil {
+		w.line(depth, "Minimum(%s)", literal(*s.Minimum))
+	}
+	if s.Maximum != nil {
+		w.line(depth, "Maximum(%s)", literal(*s.Maximum))
+	}
+	for _, l := range []*int{s.MinLength, s.MinItems} {
+		if l != nil {
+			w.line(depth, "MinLength(%d)", *l)
+		}
+	}
+	for _, l := range []*int{s.MaxLength, s.MaxItems} {
+		if l != nil {
+			w.line(depth, "MaxLength(%d)", *l)
+		}
+	}
+	if l := literal(s.Default); l != "" {
+		w.line(depth, "Default(%s)", l)
+	}
+	if l := literal(s.Example); l != "" {
+		w.line(depth, "Example(%s)", l)
+	}
+}
+
+// typeRef returns the DSL expression of the type described by s. parent is
+// the name of the named schema being written if any, the references to the
+// named schemas that refer to parent are written using the schema names to
+// avoid initialization cycles.
+func (i *openAPIImporter) typeRef(s *openAPISchema, parent string) string {
+	if s == nil {
+		return "Any"
+	}
+	if s.Ref != "" {
+		n := refName(s.Ref)
+		if _, ok := i.schemas[n]; !ok {
+			return "Any"
+		}
+		if parent != "" && i.cyclic[parent] && (n == parent || i.refers(i.schemas[n], parent, make(map[string]struct{}))) {
+			return strconv.Quote(codegen.Goify(n, true))
+		}
+		return typeVarName(n)
+	}
+	switch s.Type {
+	case "integer":
+		switch s.Format {
+		case "int32":
+			return "Int32"
+		case "int64":
+			return "Int64"
+		}
+		return "Int"
+	case "number":
+		if s.Format == "float" {
+			return "Float32"
+		}
+		return "Float64"
+	case "boolean":
+		return "Boolean"
+	case "string":
+		if s.Format == "byte" || s.Format == "binary" {
+			return "Bytes"
+		}
+		return "String"
+	case "array":
+		return fmt.Sprintf("ArrayOf(%s)", i.typeRef(s.Items, parent))
+	}
+	if a := s.AdditionalProperties; a != nil && a.Allowed && len(s.Properties.Names) == 0 {
+		return fmt.Sprintf("MapOf(String, %s)", i.typeRef(a.Schema, parent))
+	}
+	return "Any"
+}
+
+// refers returns true if the type described by s refers to the named schema
+// with the given name.
+func (i *openAPIImporter) refers(s *openAPISchema, name string, seen map[string]struct{}) bool {
+	if s == nil {
+		return false
+	}
+	if s.Ref != "" {
+		n := refName(s.Ref)
+		if n == name {
+			return true
+		}
+		if _, ok := seen[n]; ok {
+			return false
+		}
+		seen[n] = struct{}{}
+		return i.refers(i.schemas[n], name, seen)
+	}
+	children := append([]*openAPISchema{s.Items}, s.AllOf...)
+	for _, n := range s.Properties.Names {
+		children = append(children, s.Properties.Schemas[n])
+	}
+	if s.AdditionalProperties != nil {
+		children = append(children, s.AdditionalProperties.Schema)
+	}
+	for _, c := range children {
+		if i.refers(c, name, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// schema returns the schema of the parameter.
+func (p *openAPIParam) schema() *openAPISchema {
+	if p.Schema != nil {
+		return p.Schema
+	}
+	return &openAPISchema{
+		Type:      p.Type,
+		Format:    p.Format,
+		Items:     p.Items,
+		Enum:      p.Enum,
+		Default:   p.Default,
+		Pattern:   p.Pattern,
+		Minimum:   p.Minimum,
+		Maximum:   p.Maximum,
+		MinLength: p.MinLength,
+		MaxLength: p.MaxLength,
+		MinItems:  p.MinItems,
+		MaxItems:  p.MaxItems,
+	}
+}
+
+// resolve returns the schema s refers to if any, s otherwise.
+func (i *openAPIImporter) resolve(s *openAPISchema) *openAPISchema {
+	if s.Ref == "" {
+		return s
+	}
+	if r, ok := i.schemas[refName(s.Ref)]; ok {
+		return r
+	}
+	return s
+}
+
+// param resolves the parameter references.
+func (i *openAPIImporter) param(p *openAPIParam) *openAPIParam {
+	if p == nil || p.Ref == "" {
+		return p
+	}
+	n := refName(p.Ref)
+	if r, ok := i.doc.Parameters[n]; ok {
+		return r
+	}
+	if c := i.doc.Components; c != nil {
+		return c.Parameters[n]
+	}
+	return nil
+}
+
+// response resolves the response references.
+func (i *openAPIImporter) response(r *openAPIResponse) *openAPIResponse {
+	if r == nil || r.Ref == "" {
+		return r
+	}
+	n := refName(r.Ref)
+	if res, ok := i.doc.Responses[n]; ok {
+		return res
+	}
+	if c := i.doc.Components; c != nil {
+		return c.Responses[n]
+	}
+	return nil
+}
+
+// body returns the schema of the JSON content of the given OpenAPI 3 request
+// body.
+func (i *openAPIImporter) body(b *openAPIBody) *openAPISchema {
+	if b == nil {
+		return nil
+	}
+	if b.Ref != "" {
+		c := i.doc.Components
+		if c == nil {
+			return nil
+		}
+		if b = c.RequestBodies[refName(b.Ref)]; b == nil {
+			return nil
+		}
+	}
+	return jsonSchema(b.Content)
+}
+
+// jsonSchema returns the schema of the JSON content if any or of the first
+// content type otherwise.
+func jsonSchema(content map[string]*openAPIMediaType) *openAPISchema {
+	if mt, ok := content["application/json"]; ok {
+		return mt.Schema
+	}
+	var cts []string
+	for ct := range content {
+		cts = append(cts, ct)
+	}
+	sort.Strings(cts)
+	for _, ct := range cts {
+		if content[ct] != nil {
+			return content[ct].Schema
+		}
+	}
+	return nil
+}
+
+// isObject returns true if s describes an object with properties.
+func isObject(s *openAPISchema) bool {
+	return s.Type == "object" || s.Type == "" && len(s.Properties.Names) > 0
+}
+
+// hasValidations returns true if validations must be written for s.
+func hasValidations(s *openAPISchema) bool {
+	if s.Ref != "" {
+		return false
+	}
+	_, format := formats[s.Format]
+	return literals(s.Enum) != "" || format || s.Pattern != "" ||
+		s.Minimum != nil || s.Maximum != nil || s.MinLength != nil ||
+		s.MaxLength != nil || s.MinItems != nil || s.MaxItems != nil ||
+		literal(s.Default) != "" || literal(s.Example) != ""
+}
+
+// refName returns the name of the object a reference refers to.
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// paramName returns the name of the attribute corresponding to the parameter
+// with the given name.
+func paramName(name string) string {
+	return snakeName(name)
+}
+
+// formats maps the OpenAPI formats to the corresponding DSL validation
+// formats.
+var formats = map[string]string{
+	"date":      "FormatDate",
+	"date-time": "FormatDateTime",
+	"uuid":      "FormatUUID",
+	"email":     "FormatEmail",
+	"hostname":  "FormatHostname",
+	"ipv4":      "FormatIPv4",
+	"ipv6":      "FormatIPv6",
+	"uri":       "FormatURI",
+	"regex":     "FormatRegexp",
+}
+
+// statuses maps the HTTP status codes to the DSL constants.
+var statuses = map[int]string{
+	200: "StatusOK",
+	201: "StatusCreated",
+	202: "StatusAccepted",
+	204: "StatusNoContent",
+	206: "StatusPartialContent",
+	400: "StatusBadRequest",
+	401: "StatusUnauthorized",
+	403: "StatusForbidden",
+	404: "StatusNotFound",
+	405: "StatusMethodNotAllowed",
+	406: "StatusNotAcceptable",
+	409: "StatusConflict",
+	410: "StatusGone",
+	412: "StatusPreconditionFailed",
+	413: "StatusRequestEntityTooLarge",
+	415: "StatusUnsupportedMediaType",
+	422: "StatusUnprocessableEntity",
+	429: "StatusTooManyRequests",
+	500: "StatusInternalServerError",
+	501: "StatusNotImplemented",
+	502: "StatusBadGateway",
+	503: "StatusServiceUnavailable",
+	504: "StatusGatewayTimeout",
+}
+
+// statusName returns the DSL expression of the given HTTP status code.
+func statusName(status int) string {
+	if n, ok := statuses[status]; ok {
+		return n
+	}
+	return strconv.Itoa(status)
+}
+
+// errorName returns the name of the error corresponding to the given HTTP
+// status code, e.g. "not_found" for 404.
+func errorName(status int) string {
+	if n, ok := statuses[status]; ok {
+		return codegen.SnakeCase(strings.TrimPrefix(n, "Status"))
+	}
+	return "error_" + strconv.Itoa(status)
+}
@@ -0,0 +1,135 @@
+package importer
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestOpenAPI(t *testing.T) {
+	petstore, err := ioutil.ReadFile("testdata/petstore.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		Name     string
+		Doc      string
+		Expected []string
+	}{
+		{"swagger", string(petstore), []string{
+			`var _ = API("swagger_petstore", func() {`,
+			`Server("https://petstore.swagger.io")`,
+			`Path("/v1")`,
+			`var _ = Service("pets", func() {`,
+			`Method("list_pets", func() {`,
+			`Attribute("limit", Int32, "How many items to return at one time (max 100)", func() {`,
+			`Maximum(100)`,
+			`Format(FormatUUID)`,
+			`Result(Pets)`,
+			`Param("limit")`,
+			`Header("x_request_id:X-Request-ID")`,
+			`Payload(Pet)`,
+			`Error("conflict", ErrorResult, "The pet already exists")`,
+			`Response(StatusCreated)`,
+			`Response("conflict", StatusConflict)`,
+			`Extend(Pet)`,
+			`Required("pet_id")`,
+			`GET("/pets/{pet_id}")`,
+			`Error("not_found", ErrorType, "Pet not found")`,
+			`Response(StatusNoContent)`,
+			`var Pet = Type("Pet", func() {`,
+			`Attribute("parent", "Pet")`,
+			`Attribute("labels", MapOf(String, String))`,
+			`Required("email")`,
+			`Enum("dog", "cat")`,
+			`Default("dog")`,
+			`Example("doggie")`,
+			`Required("id", "name")`,
+			`var Pets = Type("Pets", ArrayOf(Pet), func() {`,
+			`MaxLength(100)`,
+			`var ErrorType = Type("Error", func() {`,
+		}},
+		{"openapi3", openAPI3Doc, []string{
+			`var _ = API("api", func() {`,
+			`Server("https://api.example.com")`,
+			`Path("/v2")`,
+			`var _ = Service("api", func() {`,
+			`Method("post_accounts", func() {`,
+			`Attribute("name", String)`,
+			`Required("name")`,
+			`Result(Account)`,
+			`Response(StatusCreated)`,
+			`var Account = Type("Account", func() {`,
+			`Extend(Named)`,
+			`Format(FormatDateTime)`,
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			src, err := OpenAPI([]byte(c.Doc), "design")
+			if err != nil {
+				t.Fatal(err)
+			}
+			code := string(src)
+			if !strings.HasPrefix(code, "package design\n") {
+				t.Errorf("invalid package clause:\n%s", code)
+			}
+			for _, e := range c.Expected {
+				if !strings.Contains(code, e) {
+					t.Errorf("missing %s in:\n%s", e, code)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenAPIInvalid(t *testing.T) {
+	cases := map[string]string{
+		"not yaml":    "{",
+		"not openapi": "title: foo",
+	}
+	for k, doc := range cases {
+		if _, err := OpenAPI([]byte(doc), "design"); err == nil {
+			t.Errorf("%s: expected an error", k)
+		}
+	}
+}
+
+const openAPI3Doc = `openapi: 3.0.0
+servers:
+  - url: https://api.example.com/v2
+paths:
+  /accounts:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+              required: [name]
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Account"
+components:
+  schemas:
+    Named:
+      type: object
+      properties:
+        name:
+          type: string
+    Account:
+      allOf:
+        - $ref: "#/components/schemas/Named"
+        - type: object
+          properties:
+            created_at:
+              type: string
+              format: date-time
+`
@@ -0,0 +1,834 @@
+package importer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"goa.design/goa/codegen"
+)
+
+type (
+	// protoFile describes the definitions of a .proto file.
+	protoFile struct {
+		// Package is the protobuf package name.
+		Package string
+		// Messages lists the messages including the nested messages in
+		// definition order.
+		Messages []*protoMessage
+		// Enums lists the enums including the nested enums in definition
+		// order.
+		Enums []*protoEnum
+		// Services lists the services in definition order.
+		Services []*protoService
+	}
+
+	// protoMessage describes a message.
+	protoMessage struct {
+		// Name is the name of the message qualified with the names of
+		// the enclosing messages, e.g. "Outer.Inner".
+		Name   string
+		Desc   string
+		Fields []*protoField
+	}
+
+	// protoField describes a message field.
+	protoField struct {
+		Name     string
+		Desc     string
+		Type     string
+		Num      int
+		Repeated bool
+		Required bool
+		// KeyType is the type of the map keys if the field is a map.
+		KeyType string
+		// Scope is the qualified name of the message defining the
+		// field.
+		Scope string
+	}
+
+	// protoEnum describes an enum.
+	protoEnum struct {
+		Name   string
+		Desc   string
+		Values []string
+	}
+
+	// protoService describes a service.
+	protoService struct {
+		Name string
+		Desc string
+		RPCs []*protoRPC
+	}
+
+	// protoRPC describes a service RPC.
+	protoRPC struct {
+		Name         string
+		Desc         string
+		Input        string
+		Output       string
+		ClientStream bool
+		ServerStream bool
+	}
+
+	// protoToken is a lexical token of a .proto file.
+	protoToken struct {
+		// Text is the token text, string literals are unquoted.
+		Text string
+		// Str is true if the token is a string literal.
+		Str bool
+		// Comment is the text of the comments preceding the token.
+		Comment string
+		// Line is the line number of the token.
+		Line int
+	}
+
+	// protoParser parses the tokens of a .proto file.
+	protoParser struct {
+		tokens []*protoToken
+		pos    int
+		file   *protoFile
+	}
+
+	// protoImporter produces a design from protobuf definitions.
+	protoImporter struct {
+		file *protoFile
+		w    *designWriter
+		// messages lists the messages indexed by qualified name.
+		messages map[string]*protoMessage
+		// enums lists the enums indexed by qualified name.
+		enums map[string]*protoEnum
+		// cyclic records the names of the messages that refer to
+		// themselves directly or indirectly.
+		cyclic map[string]bool
+	}
+)
+
+// Protobuf returns the Go source code of a design package named pkg built from
+// the given proto2 or proto3 definitions. The design defines one user type per
+// message and enum and one service per protobuf service with one method per
+// RPC. The message fields are defined with Field so that the design retains
+// the field numbers. The RPCs whose requests are streamed are skipped as the
+// DSL cannot describe them.
+func Protobuf(proto []byte, pkg string) ([]byte, error) {
+	tokens, err := protoTokens(string(proto))
+	if err != nil {
+		return nil, err
+	}
+	p := &protoParser{tokens: tokens, file: &protoFile{}}
+	if err := p.parseFile(); err != nil {
+		return nil, err
+	}
+	imp := &protoImporter{
+		file:     p.file,
+		w:        &designWriter{},
+		messages: make(map[string]*protoMessage),
+		enums:    make(map[string]*protoEnum),
+		cyclic:   make(map[string]bool),
+	}
+	return imp.design(pkg)
+}
+
+// protoTokens splits the .proto source into tokens.
+func protoTokens(src string) ([]*protoToken, error) {
+	var (
+		tokens  []*protoToken
+		comment []string
+		line    = 1
+		rs      = []rune(src)
+	)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case r == '\n':
+			line++
+			i++
+			j := i
+			for j < len(rs) && rs[j] != '\n' && unicode.IsSpace(rs[j]) {
+				j++
+			}
+			if j < len(rs) && rs[j] == '\n' {
+				// A blank line detaches the comments from the next
+				// token.
+				comment = nil
+			}
+		case unicode.IsSpace(r):
+			i++
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '/':
+			j := i
+			for j < len(rs) && rs[j] != '\n' {
+				j++
+			}
+			if len(tokens) == 0 || tokens[len(tokens)-1].Line != line {
+				// Trailing comments are ignored.
+				comment = append(comment, strings.TrimSpace(string(rs[i+2:j])))
+			}
+			i = j
+		case r == '/' && i+1 < len(rs) && rs[i+1] == '*':
+			end := strings.Index(string(rs[i+2:]), "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			text := string(rs[i+2:])[:end]
+			for _, l := range strings.Split(text, "\n") {
+				if l = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(l), "*")); l != "" {
+					comment = append(comment, l)
+				}
+			}
+			line += strings.Count(text, "\n")
+			i += 2 + len([]rune(text)) + 2
+		case r == '"' || r == '\'':
+			j := i + 1
+			for j < len(rs) && rs[j] != r {
+				if rs[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(rs) {
+				return nil, fmt.Errorf("line %d: unterminated string", line)
+			}
+			s, err := strconv.Unquote(`"` + strings.Replace(string(rs[i+1:j]), `"`, `\"`, -1) + `"`)
+			if err != nil {
+				s = string(rs[i+1 : j])
+			}
+			tokens = append(tokens, &protoToken{Text: s, Str: true, Comment: strings.Join(comment, " "), Line: line})
+			comment = nil
+			i = j + 1
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-' || r == '+':
+			j := i
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '_' || rs[j] == '.' || rs[j] == '-' || rs[j] == '+') {
+				j++
+			}
+			tokens = append(tokens, &protoToken{Text: string(rs[i:j]), Comment: strings.Join(comment, " "), Line: line})
+			comment = nil
+			i = j
+		default:
+			tokens = append(tokens, &protoToken{Text: string(r), Comment: strings.Join(comment, " "), Line: line})
+			comment = nil
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// parseFile parses the top level statements.
+func (p *protoParser) parseFile() error {
+	for !p.done() {
+		t := p.next()
+		switch t.Text {
+		case ";":
+		case "syntax", "import", "option":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case "package":
+			name := p.next()
+			if name == nil {
+				return p.unexpected(name)
+			}
+			p.file.Package = name.Text
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		case "message":
+			if err := p.parseMessage("", t.Comment); err != nil {
+				return err
+			}
+		case "enum":
+			if err := p.parseEnum("", t.Comment); err != nil {
+				return err
+			}
+		case "service":
+			if err := p.parseService(t.Comment); err != nil {
+				return err
+			}
+		case "extend":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			return p.unexpected(t)
+		}
+	}
+	return nil
+}
+
+// parseMessage parses a message definition. scope is the qualified name of
+// the enclosing message if any.
+func (p *protoParser) parseMessage(scope, desc string) error {
+	name := p.next()
+	if name == nil {
+		return p.unexpected(name)
+	}
+	m := &protoMessage{Name: qualify(scope, name.Text), Desc: desc}
+	p.file.Messages = append(p.file.Messages, m)
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	return p.parseFields(m, false)
+}
+
+// parseFields parses the body of a message or oneof up to the closing brace.
+func (p *protoParser) parseFields(m *protoMessage, oneof bool) error {
+	for {
+		t := p.next()
+		if t == nil {
+			return p.unexpected(t)
+		}
+		switch t.Text {
+		case "}":
+			return nil
+		case ";":
+		case "option", "reserved", "extensions":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case "extend":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case "message":
+			if oneof {
+				return p.unexpected(t)
+			}
+			if err := p.parseMessage(m.Name, t.Comment); err != nil {
+				return err
+			}
+		case "enum":
+			if oneof {
+				return p.unexpected(t)
+			}
+			if err := p.parseEnum(m.Name, t.Comment); err != nil {
+				return err
+			}
+		case "oneof":
+			if p.next() == nil {
+				return p.unexpected(nil)
+			}
+			if err := p.expect("{"); err != nil {
+				return err
+			}
+			if err := p.parseFields(m, true); err != nil {
+				return err
+			}
+		default:
+			p.pos--
+			if err := p.parseField(m); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// parseField parses a field definition.
+func (p *protoParser) parseField(m *protoMessage) error {
+	f := &protoField{Scope: m.Name, Desc: p.peek().Comment}
+	switch p.peek().Text {
+	case "repeated":
+		f.Repeated = true
+		p.next()
+	case "required":
+		f.Required = true
+		p.next()
+	case "optional":
+		p.next()
+	}
+	typ := p.next()
+	if typ == nil {
+		return p.unexpected(typ)
+	}
+	f.Type = typ.Text
+	if typ.Text == "map" {
+		if err := p.expect("<"); err != nil {
+			return err
+		}
+		key := p.next()
+		if key == nil {
+			return p.unexpected(key)
+		}
+		if err := p.expect(","); err != nil {
+			return err
+		}
+		val := p.next()
+		if val == nil {
+			return p.unexpected(val)
+		}
+		if err := p.expect(">"); err != nil {
+			return err
+		}
+		f.KeyType, f.Type = key.Text, val.Text
+	}
+	name := p.next()
+	if name == nil {
+		return p.unexpected(name)
+	}
+	f.Name = name.Text
+	if err := p.expect("="); err != nil {
+		return err
+	}
+	num := p.next()
+	if num == nil {
+		return p.unexpected(num)
+	}
+	n, err := strconv.Atoi(num.Text)
+	if err != nil {
+		return fmt.Errorf("line %d: invalid field number %q", num.Line, num.Text)
+	}
+	f.Num = n
+	if err := p.skipStatement(); err != nil {
+		return err
+	}
+	m.Fields = append(m.Fields, f)
+	return nil
+}
+
+// parseEnum parses an enum definition.
+func (p *protoParser) parseEnum(scope, desc string) error {
+	name := p.next()
+	if name == nil {
+		return p.unexpected(name)
+	}
+	e := &protoEnum{Name: qualify(scope, name.Text), Desc: desc}
+	p.file.Enums = append(p.file.Enums, e)
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		t := p.next()
+		if t == nil {
+			return p.unexpected(t)
+		}
+		switch t.Text {
+		case "}":
+			return nil
+		case ";":
+		case "option", "reserved":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		default:
+			e.Values = append(e.Values, t.Text)
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// parseService parses a service definition.
+func (p *protoParser) parseService(desc string) error {
+	name := p.next()
+	if name == nil {
+		return p.unexpected(name)
+	}
+	s := &protoService{Name: name.Text, Desc: desc}
+	p.file.Services = append(p.file.Services, s)
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		t := p.next()
+		if t == nil {
+			return p.unexpected(t)
+		}
+		switch t.Text {
+		case "}":
+			return nil
+		case ";":
+		case "option":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case "rpc":
+			r, err := p.parseRPC(t.Comment)
+			if err != nil {
+				return err
+			}
+			s.RPCs = append(s.RPCs, r)
+		default:
+			return p.unexpected(t)
+		}
+	}
+}
+
+// parseRPC parses a RPC definition following the rpc keyword.
+func (p *protoParser) parseRPC(desc string) (*protoRPC, error) {
+	name := p.next()
+	if name == nil {
+		return nil, p.unexpected(name)
+	}
+	r := &protoRPC{Name: name.Text, Desc: desc}
+	var err error
+	if r.Input, r.ClientStream, err = p.parseRPCType(); err != nil {
+		return nil, err
+	}
+	if err := p.expect("returns"); err != nil {
+		return nil, err
+	}
+	if r.Output, r.ServerStream, err = p.parseRPCType(); err != nil {
+		return nil, err
+	}
+	return r, p.skipStatement()
+}
+
+// parseRPCType parses the parenthesized request or response type of a RPC.
+func (p *protoParser) parseRPCType() (string, bool, error) {
+	if err := p.expect("("); err != nil {
+		return "", false, err
+	}
+	stream := false
+	t := p.next()
+	if t != nil && t.Text == "stream" {
+		stream = true
+		t = p.next()
+	}
+	if t == nil {
+		return "", false, p.unexpected(t)
+	}
+	return t.Text, stream, p.expect(")")
+}
+
+// skipStatement skips the tokens up to the end of the current statement: the
+// next semicolon or the closing brace of the next block.
+func (p *protoParser) skipStatement() error {
+	depth := 0
+	for {
+		t := p.next()
+		if t == nil {
+			return p.unexpected(t)
+		}
+		switch t.Text {
+		case ";":
+			if depth == 0 {
+				return nil
+			}
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+}
+
+// expect consumes the next token and returns an error if it is not text.
+func (p *protoParser) expect(text string) error {
+	t := p.next()
+	if t == nil || t.Text != text || t.Str {
+		return p.unexpected(t)
+	}
+	return nil
+}
+
+// next consumes and returns the next token, nil at the end of the file.
+func (p *protoParser) next() *protoToken {
+	if p.done() {
+		return nil
+	}
+	p.pos++
+	return p.tokens[p.pos-1]
+}
+
+// peek returns the next token without consuming it.
+func (p *protoParser) peek() *protoToken {
+	if p.done() {
+		return &protoToken{}
+	}
+	return p.tokens[p.pos]
+}
+
+// done returns true if all the tokens have been consumed.
+func (p *protoParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// unexpected returns the error reported for the unexpected token t.
+func (p *protoParser) unexpected(t *protoToken) error {
+	if t == nil {
+		return fmt.Errorf("unexpected end of protobuf definitions")
+	}
+	return fmt.Errorf("line %d: unexpected %q", t.Line, t.Text)
+}
+
+// design writes the design and returns the formatted code.
+func (i *protoImporter) design(pkg string) ([]byte, error) {
+	for _, m := range i.file.Messages {
+		i.messages[m.Name] = m
+	}
+	for _, e := range i.file.Enums {
+		i.enums[e.Name] = e
+	}
+	for _, m := range i.file.Messages {
+		i.cyclic[m.Name] = i.refers(m, m.Name, make(map[string]struct{}))
+	}
+
+	i.w.header(pkg)
+	i.api()
+	for _, s := range i.file.Services {
+		i.service(s)
+	}
+	for _, m := range i.file.Messages {
+		i.message(m)
+	}
+	for _, e := range i.file.Enums {
+		i.enum(e)
+	}
+	return i.w.source()
+}
+
+// api writes the API expression.
+func (i *protoImporter) api() {
+	name := "api"
+	if i.file.Package != "" {
+		name = snakeName(i.file.Package)
+	}
+	i.w.line(0, "")
+	i.w.line(0, "var _ = API(%q, func() {", name)
+	i.w.line(1, "Title(%q)", name)
+	i.w.line(0, "})")
+}
+
+// service writes the expression of the given service.
+func (i *protoImporter) service(s *protoService) {
+	w := i.w
+	w.line(0, "")
+	w.line(0, "var _ = Service(%q, func() {", snakeName(s.Name))
+	if s.Desc != "" {
+		w.line(1, "Description(%q)", s.Desc)
+	}
+	for j, r := range s.RPCs {
+		if j > 0 || s.Desc != "" {
+			w.line(0, "")
+		}
+		if r.ClientStream {
+			w.line(1, "// %s is skipped: the DSL cannot describe streamed requests.", r.Name)
+			continue
+		}
+		w.line(1, "Method(%q, func() {", snakeName(r.Name))
+		if r.Desc != "" {
+			w.line(2, "Description(%q)", r.Desc)
+		}
+		if in := i.typeRef(r.Input, "", ""); in != "Empty" {
+			w.line(2, "Payload(%s)", in)
+		}
+		if out := i.typeRef(r.Output, "", ""); out != "Empty" {
+			if r.ServerStream {
+				w.line(2, "StreamingResult(%s)", out)
+			} else {
+				w.line(2, "Result(%s)", out)
+			}
+		}
+		w.line(1, "})")
+	}
+	w.line(0, "})")
+}
+
+// message writes the user type built from the given message.
+func (i *protoImporter) message(m *protoMessage) {
+	w := i.w
+	w.line(0, "")
+	w.line(0, "var %s = Type(%q, func() {", typeVarName(protoTypeName(m.Name)), protoTypeName(m.Name))
+	if m.Desc != "" {
+		w.line(1, "Description(%q)", m.Desc)
+	}
+	var required []string
+	for _, f := range m.Fields {
+		t := i.typeRef(f.Type, f.Scope, m.Name)
+		switch {
+		case f.KeyType != "":
+			t = fmt.Sprintf("MapOf(%s, %s)", i.typeRef(f.KeyType, f.Scope, m.Name), t)
+		case f.Repeated:
+			t = fmt.Sprintf("ArrayOf(%s)", t)
+		}
+		args := fmt.Sprintf("%d, %q, %s", f.Num, f.Name, t)
+		if f.Desc != "" {
+			args += fmt.Sprintf(", %q", f.Desc)
+		}
+		if protoWellKnown[i.wellKnown(f.Type)] == "timestamp" && !f.Repeated && f.KeyType == "" {
+			w.line(1, "Field(%s, func() {", args)
+			w.line(2, "Format(FormatDateTime)")
+			w.line(1, "})")
+		} else {
+			w.line(1, "Field(%s)", args)
+		}
+		if f.Required {
+			required = append(required, f.Name)
+		}
+	}
+	if len(required) > 0 {
+		w.line(1, "Required(%s)", quoted(required))
+	}
+	w.line(0, "})")
+}
+
+// enum writes the user type built from the given enum.
+func (i *protoImporter) enum(e *protoEnum) {
+	w := i.w
+	w.line(0, "")
+	w.line(0, "var %s = Type(%q, String, func() {", typeVarName(protoTypeName(e.Name)), protoTypeName(e.Name))
+	if e.Desc != "" {
+		w.line(1, "Description(%q)", e.Desc)
+	}
+	if len(e.Values) > 0 {
+		w.line(1, "Enum(%s)", quoted(e.Values))
+	}
+	w.line(0, "})")
+}
+
+// typeRef returns the DSL expression of the type with the given name
+// referenced from the message with the given scope. parent is the name of the
+// message being written if any, the references to the messages that refer to
+// parent are written using the type names to avoid initialization cycles.
+func (i *protoImporter) typeRef(name, scope, parent string) string {
+	if t, ok := protoScalars[name]; ok {
+		return t
+	}
+	if n := i.wellKnown(name); n != "" {
+		switch t := protoWellKnown[n]; t {
+		case "timestamp", "duration":
+			return "String"
+		default:
+			return t
+		}
+	}
+	n := i.resolve(name, scope)
+	if _, ok := i.enums[n]; ok {
+		return typeVarName(protoTypeName(n))
+	}
+	m, ok := i.messages[n]
+	if !ok {
+		return "Any"
+	}
+	if parent != "" && i.cyclic[parent] && (n == parent || i.refers(m, parent, make(map[string]struct{}))) {
+		return strconv.Quote(protoTypeName(n))
+	}
+	return typeVarName(protoTypeName(n))
+}
+
+// wellKnown returns the name of the well-known type with the given name
+// without the google.protobuf prefix, the empty string if the type is not a
+// well-known type.
+func (i *protoImporter) wellKnown(name string) string {
+	name = strings.TrimPrefix(name, ".")
+	if !strings.HasPrefix(name, "google.protobuf.") {
+		return ""
+	}
+	n := strings.TrimPrefix(name, "google.protobuf.")
+	if _, ok := protoWellKnown[n]; !ok {
+		return ""
+	}
+	return n
+}
+
+// resolve returns the qualified name of the message or enum with the given
+// name referenced from the message with the given scope following the
+// protobuf scoping rules: the name is looked up in the innermost scope first.
+func (i *protoImporter) resolve(name, scope string) string {
+	if strings.HasPrefix(name, ".") {
+		return strings.TrimPrefix(name[1:], i.file.Package+".")
+	}
+	if pkg := i.file.Package; pkg != "" && strings.HasPrefix(name, pkg+".") {
+		if n := strings.TrimPrefix(name, pkg+"."); i.defined(n) {
+			return n
+		}
+	}
+	for {
+		if n := qualify(scope, name); i.defined(n) {
+			return n
+		}
+		if scope == "" {
+			return name
+		}
+		if idx := strings.LastIndex(scope, "."); idx >= 0 {
+			scope = scope[:idx]
+		} else {
+			scope = ""
+		}
+	}
+}
+
+// defined returns true if a message or an enum with the given qualified name
+// exists.
+func (i *protoImporter) defined(name string) bool {
+	_, msg := i.messages[name]
+	_, enum := i.enums[name]
+	return msg || enum
+}
+
+// refers returns true if the given message refers to the message with the
+// given name.
+func (i *protoImporter) refers(m *protoMessage, name string, seen map[string]struct{}) bool {
+	for _, f := range m.Fields {
+		n := i.resolve(f.Type, f.Scope)
+		if n == name {
+			return true
+		}
+		if _, ok := seen[n]; ok {
+			continue
+		}
+		seen[n] = struct{}{}
+		if fm, ok := i.messages[n]; ok && i.refers(fm, name, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// qualify returns the qualified name of the definition with the given name in
+// the given scope.
+func qualify(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// protoTypeName returns the name of the user type corresponding to the message
+// or enum with the given qualified name, e.g. "OuterInner" for "Outer.Inner".
+func protoTypeName(name string) string {
+	return codegen.Goify(strings.Replace(name, ".", "_", -1), true)
+}
+
+// protoScalars maps the protobuf scalar types to the DSL types.
+var protoScalars = map[string]string{
+	"double":   "Float64",
+	"float":    "Float32",
+	"int32":    "Int32",
+	"sint32":   "Int32",
+	"sfixed32": "Int32",
+	"int64":    "Int64",
+	"sint64":   "Int64",
+	"sfixed64": "Int64",
+	"uint32":   "UInt32",
+	"fixed32":  "UInt32",
+	"uint64":   "UInt64",
+	"fixed64":  "UInt64",
+	"bool":     "Boolean",
+	"string":   "String",
+	"bytes":    "Bytes",
+}
+
+// protoWellKnown maps the well-known protobuf types to the DSL types. The
+// timestamps and durations are mapped to strings using their JSON encoding.
+var protoWellKnown = map[string]string{
+	"Empty":       "Empty",
+	"Any":         "Any",
+	"Struct":      "MapOf(String, Any)",
+	"Value":       "Any",
+	"ListValue":   "ArrayOf(Any)",
+	"Timestamp":   "timestamp",
+	"Duration":    "duration",
+	"DoubleValue": "Float64",
+	"FloatValue":  "Float32",
+	"Int64Value":  "Int64",
+	"UInt64Value": "UInt64",
+	"Int32Value":  "Int32",
+	"UInt32Value": "UInt32",
+	"BoolValue":   "Boolean",
+	"StringValue": "String",
+	"BytesValue":  "Bytes",
+}
@@ -0,0 +1,121 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProtobuf(t *testing.T) {
+	src, err := Protobuf([]byte(petsProto), "design")
+	if err != nil {
+		t.Fatal(err)
+	}
+	code := string(src)
+	expected := []string{
+		`var _ = API("acme_pets_v1", func() {`,
+		`var _ = Service("pet_store", func() {`,
+		`Description("PetStore manages pets.")`,
+		`Method("get_pet", func() {`,
+		`Description("GetPet returns the pet with the given ID.")`,
+		`Payload(GetPetRequest)`,
+		`Result(Pet)`,
+		`Method("watch_pets", func() {`,
+		`StreamingResult(Pet)`,
+		`// UploadPets is skipped: the DSL cannot describe streamed requests.`,
+		`Method("delete_pet", func() {`,
+		`var Pet = Type("Pet", func() {`,
+		`Description("Pet is a pet.")`,
+		`Field(1, "id", Int64, "ID of the pet")`,
+		`Field(2, "tags", ArrayOf(String))`,
+		`Field(3, "kind", PetKind)`,
+		`Field(4, "attributes", MapOf(String, Int32))`,
+		`Field(5, "parent", "Pet")`,
+		`Field(6, "owner", PetOwner)`,
+		`Field(7, "created_at", String, func() {`,
+		`Format(FormatDateTime)`,
+		`Field(8, "email", String)`,
+		`Field(9, "phone", String)`,
+		`var PetOwner = Type("PetOwner", func() {`,
+		`Field(1, "name", String)`,
+		`var GetPetRequest = Type("GetPetRequest", func() {`,
+		`var PetKind = Type("PetKind", String, func() {`,
+		`Enum("UNKNOWN", "DOG", "CAT")`,
+	}
+	for _, e := range expected {
+		if !strings.Contains(code, e) {
+			t.Errorf("missing %s in:\n%s", e, code)
+		}
+	}
+	if strings.Contains(code, "Method(\"upload_pets\"") {
+		t.Errorf("client streaming RPC should be skipped:\n%s", code)
+	}
+	if strings.Contains(code, "Payload(Empty)") || strings.Contains(code, "Result(Empty)") {
+		t.Errorf("google.protobuf.Empty should not be used as payload or result:\n%s", code)
+	}
+}
+
+func TestProtobufInvalid(t *testing.T) {
+	cases := map[string]string{
+		"unterminated message": "message Foo {",
+		"invalid number":       "message Foo { string bar = x; }",
+		"unknown statement":    "foo bar;",
+		"unterminated comment": "/* foo",
+	}
+	for k, proto := range cases {
+		if _, err := Protobuf([]byte(proto), "design"); err == nil {
+			t.Errorf("%s: expected an error", k)
+		}
+	}
+}
+
+const petsProto = `syntax = "proto3";
+
+package acme.pets.v1;
+
+import "google/protobuf/empty.proto";
+import "google/protobuf/timestamp.proto";
+
+option go_package = "acme/pets/v1";
+
+// PetStore manages pets.
+service PetStore {
+  // GetPet returns the pet with the given ID.
+  rpc GetPet(GetPetRequest) returns (Pet);
+  rpc WatchPets(google.protobuf.Empty) returns (stream Pet) {
+    option deprecated = true;
+  }
+  rpc UploadPets(stream Pet) returns (google.protobuf.Empty);
+  rpc DeletePet(GetPetRequest) returns (google.protobuf.Empty);
+}
+
+// Pet is a pet.
+message Pet {
+  // ID of the pet
+  int64 id = 1;
+  repeated string tags = 2; // trailing comment
+  Kind kind = 3 [json_name = "kind"];
+  map<string, int32> attributes = 4;
+  Pet parent = 5;
+  Owner owner = 6;
+  google.protobuf.Timestamp created_at = 7;
+  oneof contact {
+    string email = 8;
+    string phone = 9;
+  }
+  reserved 10, 11;
+
+  enum Kind {
+    UNKNOWN = 0;
+    DOG = 1;
+    CAT = 2;
+  }
+
+  message Owner {
+    string name = 1;
+  }
+}
+
+message GetPetRequest {
+  int64 id = 1;
+}
+`
@@ -0,0 +1,350 @@
+/*
+Package jsonschema produces standalone JSON Schema documents (draft 2020-12)
+describing the user and result types of a design. Each document describes a
+single type together with the types it references and may be used to validate
+messages exchanged outside of the transport layer, for example messages posted
+to queues or configuration files.
+*/
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"text/template"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+)
+
+// SchemaURI is the URI of the JSON Schema dialect used by the documents.
+const SchemaURI = "https://json-schema.org/draft/2020-12/schema"
+
+type (
+	// Schema represents a JSON Schema (draft 2020-12).
+	Schema struct {
+		// Schema is the URI of the dialect of the document, only set on
+		// the root schema.
+		Schema string `json:"$schema,omitempty"`
+		// ID is the identifier of the document, only set on the root
+		// schema.
+		ID string `json:"$id,omitempty"`
+		// Ref references a schema defined in Defs or the root schema.
+		Ref string `json:"$ref,omitempty"`
+		// Title is the name of the type described by the schema.
+		Title string `json:"title,omitempty"`
+		// Description is the type or attribute description.
+		Description string `json:"description,omitempty"`
+		// Type is the JSON type of the values.
+		Type string `json:"type,omitempty"`
+		// Format is the format of the string values.
+		Format string `json:"format,omitempty"`
+		// ContentEncoding is the encoding of the string values.
+		ContentEncoding string `json:"contentEncoding,omitempty"`
+		// Properties describes the object properties.
+		Properties map[string]*Schema `json:"properties,omitempty"`
+		// Required lists the required object properties.
+		Required []string `json:"required,omitempty"`
+		// AdditionalProperties describes the values of maps.
+		AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+		// PropertyNames describes the keys of maps.
+		PropertyNames *Schema `json:"propertyNames,omitempty"`
+		// Items describes the array elements.
+		Items *Schema `json:"items,omitempty"`
+		// Const is the only value allowed.
+		Const interface{} `json:"const,omitempty"`
+		// Enum lists the values allowed.
+		Enum []interface{} `json:"enum,omitempty"`
+		// Pattern is the regular expression the string values must
+		// match.
+		Pattern string `json:"pattern,omitempty"`
+		// Minimum is the minimum numeric value.
+		Minimum *float64 `json:"minimum,omitempty"`
+		// Maximum is the maximum numeric value.
+		Maximum *float64 `json:"maximum,omitempty"`
+		// MinLength is the minimum length of the string values.
+		MinLength *int `json:"minLength,omitempty"`
+		// MaxLength is the maximum length of the string values.
+		MaxLength *int `json:"maxLength,omitempty"`
+		// MinItems is the minimum number of array elements.
+		MinItems *int `json:"minItems,omitempty"`
+		// MaxItems is the maximum number of array elements.
+		MaxItems *int `json:"maxItems,omitempty"`
+		// MinProperties is the minimum number of map keys.
+		MinProperties *int `json:"minProperties,omitempty"`
+		// MaxProperties is the maximum number of map keys.
+		MaxProperties *int `json:"maxProperties,omitempty"`
+		// AllOf lists schemas that the values must all validate.
+		AllOf []*Schema `json:"allOf,omitempty"`
+		// If is the condition of a conditional validation.
+		If *Schema `json:"if,omitempty"`
+		// Then is the schema values that validate If must validate.
+		Then *Schema `json:"then,omitempty"`
+		// Default is the default value.
+		Default interface{} `json:"default,omitempty"`
+		// Examples lists example values.
+		Examples []interface{} `json:"examples,omitempty"`
+		// Deprecated is true if the type or attribute is deprecated.
+		Deprecated bool `json:"deprecated,omitempty"`
+		// Defs lists the schemas of the types referenced by the root
+		// schema indexed by type name.
+		Defs map[string]*Schema `json:"$defs,omitempty"`
+	}
+
+	// builder computes the schema of a root type.
+	builder struct {
+		// root is the type described by the document.
+		root design.UserType
+		// defs lists the schemas of the referenced types.
+		defs map[string]*Schema
+	}
+)
+
+// Files returns the files containing the JSON Schema documents of the user and
+// result types defined in the design. The files are written to the
+// gen/jsonschema directory and named after the types. Files returns nil if the
+// API defines the "jsonschema:generate" metadata with value "false". Types that
+// define the same metadata are skipped.
+func Files(root *design.RootExpr) []*codegen.File {
+	if root.API != nil && !generate(root.API.Metadata) {
+		return nil
+	}
+	var types []design.UserType
+	for _, t := range append(append([]design.UserType{}, root.Types...), root.ResultTypes...) {
+		if t == design.ErrorResult || !generate(t.Attribute().Metadata) {
+			continue
+		}
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name() < types[j].Name() })
+	files := make([]*codegen.File, len(types))
+	for i, t := range types {
+		name := codegen.SnakeCase(t.Name()) + ".json"
+		files[i] = &codegen.File{
+			Path: filepath.Join(codegen.Gendir, "jsonschema", name),
+			SectionTemplates: []*codegen.SectionTemplate{{
+				Name:    "jsonschema",
+				FuncMap: template.FuncMap{"toJSON": toJSON},
+				Source:  "{{ toJSON . }}",
+				Data:    TypeSchema(t, name),
+			}},
+		}
+	}
+	return files
+}
+
+// TypeSchema returns the JSON Schema document describing the user type ut
+// identified with id. The types referenced by ut are described in the "$defs"
+// section of the document.
+func TypeSchema(ut design.UserType, id string) *Schema {
+	b := &builder{root: ut, defs: make(map[string]*Schema)}
+	s := b.attributeSchema(ut.Attribute())
+	s.Schema = SchemaURI
+	s.ID = id
+	s.Title = ut.Name()
+	if len(b.defs) > 0 {
+		s.Defs = b.defs
+	}
+	return s
+}
+
+// attributeSchema returns the schema of the attribute including its
+// description, validations, default value and examples.
+func (b *builder) attributeSchema(att *design.AttributeExpr) *Schema {
+	s := b.typeSchema(att.Type)
+	s.Description = att.Description
+	if codegen.DeprecationReason(att.Metadata) != "" {
+		s.Deprecated = true
+	}
+	if att.DefaultValue != nil {
+		s.Default = toStringMap(codegen.WireExample(att, att.DefaultValue))
+	}
+	for _, ex := range att.UserExamples {
+		s.Examples = append(s.Examples, toStringMap(codegen.WireExample(att, ex.Value)))
+	}
+	initValidations(s, att)
+	return s
+}
+
+// typeSchema returns the schema of the given data type. User types other than
+// the root type are described in the definitions.
+func (b *builder) typeSchema(dt design.DataType) *Schema {
+	s := &Schema{}
+	switch actual := dt.(type) {
+	case design.Primitive:
+		switch actual.Kind() {
+		case design.BooleanKind:
+			s.Type = "boolean"
+		case design.IntKind, design.UIntKind, design.Int32Kind, design.UInt32Kind, design.Int64Kind, design.UInt64Kind:
+			s.Type = "integer"
+			switch actual.Kind() {
+			case design.Int32Kind, design.UInt32Kind:
+				s.Format = "int32"
+			case design.Int64Kind, design.UInt64Kind:
+				s.Format = "int64"
+			}
+			switch actual.Kind() {
+			case design.UIntKind, design.UInt32Kind, design.UInt64Kind:
+				zero := 0.0
+				s.Minimum = &zero
+			}
+		case design.Float32Kind, design.Float64Kind:
+			s.Type = "number"
+		case design.StringKind:
+			s.Type = "string"
+		case design.BytesKind:
+			s.Type = "string"
+			s.ContentEncoding = "base64"
+		}
+	case *design.Array:
+		s.Type = "array"
+		s.Items = b.attributeSchema(actual.ElemType)
+	case *design.Map:
+		s.Type = "object"
+		s.AdditionalProperties = b.attributeSchema(actual.ElemType)
+		if actual.KeyType.Validation != nil {
+			// JSON object keys are always strings.
+			ks := &Schema{}
+			initValidations(ks, actual.KeyType)
+			s.PropertyNames = ks
+		}
+	case *design.Object:
+		s.Type = "object"
+		s.Properties = make(map[string]*Schema, len(*actual))
+		for _, nat := range *actual {
+			s.Properties[codegen.WireName(nat.Name)] = b.attributeSchema(nat.Attribute)
+			if v := nat.Attribute.Validation; v != nil && v.RequiredIf != nil {
+				s.AllOf = append(s.AllOf, &Schema{
+					If: &Schema{
+						Properties: map[string]*Schema{codegen.WireName(v.RequiredIf.Attribute): {Const: v.RequiredIf.Value}},
+						Required:   []string{codegen.WireName(v.RequiredIf.Attribute)},
+					},
+					Then: &Schema{Required: []string{codegen.WireName(nat.Name)}},
+				})
+			}
+		}
+	case design.UserType:
+		if actual == b.root {
+			s.Ref = "#"
+			return s
+		}
+		name := actual.Name()
+		if _, ok := b.defs[name]; !ok {
+			b.defs[name] = nil // break recursion
+			def := b.attributeSchema(actual.Attribute())
+			def.Title = name
+			b.defs[name] = def
+		}
+		s.Ref = "#/$defs/" + name
+	}
+	return s
+}
+
+// initValidations initializes the validation keywords of s with the
+// validations of att.
+func initValidations(s *Schema, att *design.AttributeExpr) {
+	val := att.Validation
+	if val == nil {
+		return
+	}
+	for _, v := range val.Values {
+		s.Enum = append(s.Enum, toStringMap(v))
+	}
+	if val.Format != "" {
+		s.Format = format(val.Format)
+	}
+	s.Pattern = val.Pattern
+	if val.Minimum != nil {
+		s.Minimum = val.Minimum
+	}
+	if val.Maximum != nil {
+		s.Maximum = val.Maximum
+	}
+	switch {
+	case design.IsArray(att.Type):
+		s.MinItems, s.MaxItems = val.MinLength, val.MaxLength
+	case design.IsMap(att.Type):
+		s.MinProperties, s.MaxProperties = val.MinLength, val.MaxLength
+	default:
+		s.MinLength, s.MaxLength = val.MinLength, val.MaxLength
+	}
+	for _, r := range val.Required {
+		s.Required = append(s.Required, codegen.WireName(r))
+	}
+}
+
+// format returns the JSON Schema format corresponding to the given design
+// format. The formats not defined by JSON Schema are annotations that
+// validators may ignore.
+func format(f design.ValidationFormat) string {
+	switch f {
+	case design.FormatRegexp:
+		return "regex"
+	case design.FormatURI:
+		return "uri"
+	default:
+		return string(f)
+	}
+}
+
+// generate returns false if the "jsonschema:generate" metadata is set to
+// "false".
+func generate(m design.MetadataExpr) bool {
+	if v, ok := m["jsonschema:generate"]; ok && len(v) > 0 {
+		return v[0] != "false"
+	}
+	return true
+}
+
+// toStringMap converts the maps with interface{} keys contained in val to maps
+// with string keys so that val can be serialized to JSON.
+func toStringMap(val interface{}) interface{} {
+	switch actual := val.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(actual))
+		for k, v := range actual {
+			m[toString(k)] = toStringMap(v)
+		}
+		return m
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(actual))
+		for k, v := range actual {
+			m[k] = toStringMap(v)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(actual))
+		for i, e := range actual {
+			s[i] = toStringMap(e)
+		}
+		return s
+	default:
+		return actual
+	}
+}
+
+// toString returns the string representation of the given map key.
+func toString(val interface{}) string {
+	switch actual := val.(type) {
+	case string:
+		return actual
+	case int:
+		return strconv.Itoa(actual)
+	case float64:
+		return strconv.FormatFloat(actual, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(actual)
+	default:
+		return fmt.Sprintf("%v", actual)
+	}
+}
+
+func toJSON(s *Schema) string {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		panic("jsonschema: " + err.Error()) // bug
+	}
+	return string(b) + "\n"
+}
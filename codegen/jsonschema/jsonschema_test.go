@@ -0,0 +1,54 @@
+package jsonschema
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/jsonschema/testdata"
+)
+
+func TestFiles(t *testing.T) {
+	root := codegen.RunDSL(t, testdata.JSONSchemaDSL)
+	files := Files(root)
+	expected := []struct {
+		Path string
+		Code string
+	}{
+		{filepath.Join("gen", "jsonschema", "bottle.json"), testdata.BottleSchemaCode},
+		{filepath.Join("gen", "jsonschema", "winery.json"), testdata.WinerySchemaCode},
+	}
+	if len(files) != len(expected) {
+		t.Fatalf("got %d files, expected %d", len(files), len(expected))
+	}
+	for i, f := range files {
+		if f.Path != expected[i].Path {
+			t.Errorf("file %d: got path %q, expected %q", i, f.Path, expected[i].Path)
+			continue
+		}
+		code := render(t, f.SectionTemplates[0])
+		if code != expected[i].Code {
+			t.Errorf("invalid code for %s, got:\n%s\ngot vs. expected:\n%s", f.Path, code, codegen.Diff(t, code, expected[i].Code))
+		}
+	}
+}
+
+func TestFilesDisabled(t *testing.T) {
+	root := codegen.RunDSL(t, testdata.JSONSchemaDSL)
+	root.API.Metadata = map[string][]string{"jsonschema:generate": {"false"}}
+	if files := Files(root); files != nil {
+		t.Errorf("got %d files, expected none", len(files))
+	}
+}
+
+// render renders the JSON document rendered by the given section.
+func render(t *testing.T, s *codegen.SectionTemplate) string {
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New(s.Name).Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	return buf.String()
+}
@@ -0,0 +1,139 @@
+package testdata
+
+var BottleSchemaCode = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "bottle.json",
+  "title": "Bottle",
+  "description": "Bottle of wine.",
+  "type": "object",
+  "properties": {
+    "id": {
+      "type": "integer",
+      "format": "int64",
+      "minimum": 0
+    },
+    "kind": {
+      "type": "string",
+      "enum": [
+        "red",
+        "white"
+      ],
+      "default": "red"
+    },
+    "label": {
+      "type": "string",
+      "contentEncoding": "base64"
+    },
+    "name": {
+      "type": "string",
+      "maxLength": 100,
+      "examples": [
+        "Chateau"
+      ]
+    },
+    "parent": {
+      "$ref": "#"
+    },
+    "rating": {
+      "type": "number"
+    },
+    "scores": {
+      "type": "object",
+      "additionalProperties": {
+        "type": "integer"
+      }
+    },
+    "sku": {
+      "type": "string",
+      "format": "uuid"
+    },
+    "tags": {
+      "type": "array",
+      "items": {
+        "type": "string"
+      },
+      "maxItems": 5
+    },
+    "vendor": {
+      "type": "string"
+    },
+    "vendor_id": {
+      "type": "string"
+    },
+    "vintage": {
+      "type": "integer",
+      "format": "int32",
+      "minimum": 1900,
+      "maximum": 2030
+    },
+    "winery": {
+      "$ref": "#/$defs/Winery"
+    }
+  },
+  "required": [
+    "name",
+    "winery"
+  ],
+  "allOf": [
+    {
+      "if": {
+        "properties": {
+          "vendor": {
+            "const": "acme"
+          }
+        },
+        "required": [
+          "vendor"
+        ]
+      },
+      "then": {
+        "required": [
+          "vendor_id"
+        ]
+      }
+    }
+  ],
+  "$defs": {
+    "Winery": {
+      "title": "Winery",
+      "description": "Winery producing bottles.",
+      "type": "object",
+      "properties": {
+        "country": {
+          "type": "string",
+          "pattern": "^[A-Z]{2}$"
+        },
+        "name": {
+          "type": "string",
+          "minLength": 1
+        }
+      },
+      "required": [
+        "name"
+      ]
+    }
+  }
+}
+`
+
+var WinerySchemaCode = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "winery.json",
+  "title": "Winery",
+  "description": "Winery producing bottles.",
+  "type": "object",
+  "properties": {
+    "country": {
+      "type": "string",
+      "pattern": "^[A-Z]{2}$"
+    },
+    "name": {
+      "type": "string",
+      "minLength": 1
+    }
+  },
+  "required": [
+    "name"
+  ]
+}
+`
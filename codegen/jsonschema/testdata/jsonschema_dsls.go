@@ -0,0 +1,55 @@
+package testdata
+
+import (
+	. "goa.design/goa/design"
+	. "goa.design/goa/dsl"
+)
+
+var JSONSchemaDSL = func() {
+	var Winery = Type("Winery", func() {
+		Description("Winery producing bottles.")
+		Attribute("name", String, func() {
+			MinLength(1)
+		})
+		Attribute("country", String, func() {
+			Pattern("^[A-Z]{2}$")
+		})
+		Required("name")
+	})
+	var _ = Type("Bottle", func() {
+		Description("Bottle of wine.")
+		Attribute("id", UInt64)
+		Attribute("name", String, func() {
+			MaxLength(100)
+			Example("Chateau")
+		})
+		Attribute("kind", String, func() {
+			Enum("red", "white")
+			Default("red")
+		})
+		Attribute("vintage", Int32, func() {
+			Minimum(1900)
+			Maximum(2030)
+		})
+		Attribute("rating", Float64)
+		Attribute("label", Bytes)
+		Attribute("sku", String, func() {
+			Format(FormatUUID)
+		})
+		Attribute("tags", ArrayOf(String), func() {
+			MaxLength(5)
+		})
+		Attribute("scores", MapOf(String, Int))
+		Attribute("winery", Winery)
+		Attribute("vendor", String)
+		Attribute("vendor_id", String, func() {
+			RequiredIf("vendor", "acme")
+		})
+		Attribute("parent", "Bottle")
+		Required("name", "winery")
+	})
+	var _ = Type("Hidden", func() {
+		Metadata("jsonschema:generate", "false")
+		Attribute("secret", String)
+	})
+}
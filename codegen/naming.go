@@ -0,0 +1,138 @@
+package codegen
+
+import (
+	"strings"
+	"unicode"
+
+	"goa.design/goa/design"
+)
+
+// WireName returns the name used to serialize the attribute with the given
+// name according to the "goa:naming:wire" metadata of the API: "snake" for
+// snake_case, "camel" for camelCase. The name is returned unchanged if the
+// metadata is not set.
+func WireName(name string) string {
+	ws := lowerWords(name)
+	if len(ws) == 0 {
+		return name
+	}
+	switch wireNaming() {
+	case "snake":
+		return strings.Join(ws, "_")
+	case "camel":
+		for i := 1; i < len(ws); i++ {
+			r := []rune(ws[i])
+			r[0] = unicode.ToUpper(r[0])
+			ws[i] = string(r)
+		}
+		return strings.Join(ws, "")
+	}
+	return name
+}
+
+// WireExample returns a copy of the example value v of the given attribute
+// where the object keys are transformed with WireName. The keys of maps are
+// left unchanged.
+func WireExample(att *design.AttributeExpr, v interface{}) interface{} {
+	if wireNaming() == "" || v == nil {
+		return v
+	}
+	switch actual := att.Type.(type) {
+	case design.UserType:
+		return WireExample(actual.Attribute(), v)
+	case *design.Object:
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return v
+		}
+		res := make(map[string]interface{}, len(m))
+		for k, val := range m {
+			if nat := actual.Attribute(k); nat != nil {
+				res[WireName(k)] = WireExample(nat, val)
+				continue
+			}
+			res[k] = val
+		}
+		return res
+	case *design.Array:
+		vals, ok := v.([]interface{})
+		if !ok {
+			return v
+		}
+		res := make([]interface{}, len(vals))
+		for i, val := range vals {
+			res[i] = WireExample(actual.ElemType, val)
+		}
+		return res
+	case *design.Map:
+		m, ok := v.(map[interface{}]interface{})
+		if !ok {
+			return v
+		}
+		res := make(map[interface{}]interface{}, len(m))
+		for k, val := range m {
+			res[k] = WireExample(actual.ElemType, val)
+		}
+		return res
+	}
+	return v
+}
+
+// wireNaming returns the value of the "goa:naming:wire" metadata of the API,
+// the empty string if not set.
+func wireNaming() string {
+	if design.Root == nil || design.Root.API == nil {
+		return ""
+	}
+	if n := design.Root.API.Metadata["goa:naming:wire"]; len(n) > 0 {
+		return n[0]
+	}
+	return ""
+}
+
+// isDesignInitialism returns true if the upper case word u is one of the
+// initialisms listed in the "goa:naming:initialisms" metadata of the API.
+func isDesignInitialism(u string) bool {
+	if design.Root == nil || design.Root.API == nil {
+		return false
+	}
+	for _, i := range design.Root.API.Metadata["goa:naming:initialisms"] {
+		if strings.ToUpper(i) == u {
+			return true
+		}
+	}
+	return false
+}
+
+// lowerWords splits name into lower case words. Words are separated by non
+// letter and non digit characters and by case changes, for example "userID",
+// "user_id" and "UserId" all produce "user" and "id".
+func lowerWords(name string) []string {
+	var (
+		words []string
+		word  []rune
+		runes = []rune(name)
+	)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+	}
+	for i, r := range runes {
+		if !validIdentifier(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextLower {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
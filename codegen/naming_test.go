@@ -0,0 +1,61 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/design"
+)
+
+func TestWireName(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Naming   string
+		Attr     string
+		Expected string
+	}{
+		{"none", "", "first_name", "first_name"},
+		{"snake-from-snake", "snake", "first_name", "first_name"},
+		{"snake-from-camel", "snake", "firstName", "first_name"},
+		{"snake-initialism", "snake", "userID", "user_id"},
+		{"snake-dash", "snake", "first-name", "first_name"},
+		{"camel-from-snake", "camel", "first_name", "firstName"},
+		{"camel-from-camel", "camel", "firstName", "firstName"},
+		{"camel-initialism", "camel", "HTTPServer", "httpServer"},
+		{"camel-digits", "camel", "line2_total", "line2Total"},
+		{"camel-invalid", "camel", "_", "_"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			defer withAPIMetadata(design.MetadataExpr{"goa:naming:wire": {c.Naming}})()
+			if actual := WireName(c.Attr); actual != c.Expected {
+				t.Errorf("got %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestGoifyDesignInitialisms(t *testing.T) {
+	defer withAPIMetadata(design.MetadataExpr{"goa:naming:initialisms": {"SKU", "grpc"}})()
+	cases := map[string]struct {
+		firstUpper bool
+		expected   string
+	}{
+		"item_sku":  {true, "ItemSKU"},
+		"sku_value": {false, "skuValue"},
+		"grpc_port": {true, "GRPCPort"},
+		"user_id":   {true, "UserID"},
+	}
+	for str, c := range cases {
+		if actual := Goify(str, c.firstUpper); actual != c.expected {
+			t.Errorf("%s: got %q, expected %q", str, actual, c.expected)
+		}
+	}
+}
+
+// withAPIMetadata sets the design API to an API with the given metadata and
+// returns a function that restores the previous API.
+func withAPIMetadata(m design.MetadataExpr) func() {
+	prev := design.Root.API
+	design.Root.API = &design.APIExpr{Metadata: m}
+	return func() { design.Root.API = prev }
+}
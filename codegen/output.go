@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/design"
+)
+
+// ServiceGendir returns the directory that contains the code generated for the
+// given service relative to the output directory. It is Gendir unless the
+// design specifies a different directory with the Output DSL.
+func ServiceGendir(svc *design.ServiceExpr) string {
+	o := serviceOutput(svc)
+	if o == nil {
+		return Gendir
+	}
+	return filepath.FromSlash(expandService(o.Dir, svc))
+}
+
+// ServiceGenpkg returns the import path of the directory returned by
+// ServiceGendir given the import path genpkg of the Gendir directory.
+func ServiceGenpkg(genpkg string, svc *design.ServiceExpr) string {
+	o := serviceOutput(svc)
+	if o == nil {
+		return genpkg
+	}
+	if o.ImportPath != "" {
+		return expandService(o.ImportPath, svc)
+	}
+	return path.Join(path.Dir(genpkg), expandService(o.Dir, svc))
+}
+
+// serviceOutput returns the output expression that applies to the given
+// service, nil if the code is generated in the Gendir directory.
+func serviceOutput(svc *design.ServiceExpr) *design.OutputExpr {
+	if svc.Output != nil {
+		return svc.Output
+	}
+	if design.Root != nil && design.Root.API != nil {
+		return design.Root.API.Output
+	}
+	return nil
+}
+
+// expandService replaces the "{service}" placeholder in s with the snake case
+// name of the given service.
+func expandService(s string, svc *design.ServiceExpr) string {
+	return strings.Replace(s, "{service}", SnakeCase(svc.Name), -1)
+}
@@ -0,0 +1,34 @@
+package codegen
+
+import (
+	"runtime"
+	"sync"
+)
+
+// RunParallel calls fn with each integer in [0, n) using a pool of workers,
+// one per usable CPU as reported by GOMAXPROCS. RunParallel returns once all
+// the calls have returned.
+func RunParallel(n int, fn func(i int)) {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+	var (
+		wg   sync.WaitGroup
+		jobs = make(chan int)
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
@@ -0,0 +1,37 @@
+package codegen
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunParallel(t *testing.T) {
+	cases := []struct {
+		Name string
+		N    int
+	}{
+		{"none", 0},
+		{"one", 1},
+		{"many", 100},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var (
+				calls = make([]int32, c.N)
+				total int32
+			)
+			RunParallel(c.N, func(i int) {
+				atomic.AddInt32(&calls[i], 1)
+				atomic.AddInt32(&total, 1)
+			})
+			if int(total) != c.N {
+				t.Errorf("got %d calls, expected %d", total, c.N)
+			}
+			for i, n := range calls {
+				if n != 1 {
+					t.Errorf("got %d calls with %d, expected 1", n, i)
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package codegen
 
-import "goa.design/goa/eval"
+import (
+	"fmt"
+
+	"goa.design/goa/eval"
+)
 
 type (
 	// GenerateFunc makes it possible to modify the files generated by the
@@ -87,7 +91,8 @@ func RegisterPluginLast(name string, cmd string, p GenerateFunc) {
 }
 
 // RunPlugins executes the plugins registered with the given command in the order
-// they were registered.
+// they were registered. The error returned by a plugin is prefixed with the
+// plugin name.
 func RunPlugins(cmd, genpkg string, roots []eval.Root, genfiles []*File) ([]*File, error) {
 	for _, plugin := range plugins {
 		if plugin.cmd != cmd {
@@ -95,7 +100,7 @@ func RunPlugins(cmd, genpkg string, roots []eval.Root, genfiles []*File) ([]*Fil
 		}
 		gs, err := plugin.GenerateFunc(genpkg, roots, genfiles)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("plugin %s: %s", plugin.name, err)
 		}
 		genfiles = gs
 	}
@@ -1,8 +1,11 @@
 package codegen
 
 import (
+	"errors"
 	"reflect"
 	"testing"
+
+	"goa.design/goa/eval"
 )
 
 func TestRegisterPlugin(t *testing.T) {
@@ -108,3 +111,54 @@ func TestRegisterPluginLast(t *testing.T) {
 		})
 	}
 }
+
+func TestRunPlugins(t *testing.T) {
+	var (
+		add = func(path string) GenerateFunc {
+			return func(_ string, _ []eval.Root, files []*File) ([]*File, error) {
+				return append(files, &File{Path: path}), nil
+			}
+		}
+		fail = func(string, []eval.Root, []*File) ([]*File, error) {
+			return nil, errors.New("boom")
+		}
+
+		pa   = &plugin{name: "a", cmd: "gen", GenerateFunc: add("a.go")}
+		pb   = &plugin{name: "b", cmd: "gen", GenerateFunc: add("b.go")}
+		pex  = &plugin{name: "ex", cmd: "example", GenerateFunc: add("ex.go")}
+		perr = &plugin{name: "err", cmd: "gen", GenerateFunc: fail}
+	)
+	tests := []struct {
+		name          string
+		ps            []*plugin
+		expectedPaths []string
+		expectedErr   string
+	}{
+		{"no-plugins", nil, []string{"goa.go"}, ""},
+		{"in-order", []*plugin{pa, pb}, []string{"goa.go", "a.go", "b.go"}, ""},
+		{"other-command", []*plugin{pa, pex}, []string{"goa.go", "a.go"}, ""},
+		{"error", []*plugin{pa, perr, pb}, nil, "plugin err: boom"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			plugins = tc.ps
+			files, err := RunPlugins("gen", "", nil, []*File{{Path: "goa.go"}})
+			if tc.expectedErr != "" {
+				if err == nil || err.Error() != tc.expectedErr {
+					t.Fatalf("got error %v, expected %q", err, tc.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var paths []string
+			for _, f := range files {
+				paths = append(paths, f.Path)
+			}
+			if !reflect.DeepEqual(paths, tc.expectedPaths) {
+				t.Errorf("got files %v, expected %v", paths, tc.expectedPaths)
+			}
+		})
+	}
+}
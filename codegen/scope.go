@@ -11,8 +11,9 @@ import (
 type (
 	// NameScope defines a naming scope.
 	NameScope struct {
-		names  map[string]string // type hash to unique name
-		counts map[string]int    // raw type name to occurrence count
+		names   map[string]string                      // type hash to unique name
+		counts  map[string]int                         // raw type name to occurrence count
+		helpers map[helperKey][]*TransformFunctionData // memoized transform helpers
 	}
 
 	// Hasher is the interface implemented by the objects that must be
@@ -27,8 +28,9 @@ type (
 // NewNameScope creates an empty name scope.
 func NewNameScope() *NameScope {
 	ns := &NameScope{
-		names:  make(map[string]string),
-		counts: make(map[string]int),
+		names:   make(map[string]string),
+		counts:  make(map[string]int),
+		helpers: make(map[helperKey][]*TransformFunctionData),
 	}
 	if design.Root.API != nil {
 		ns.HashedUnique(design.Root.API, design.Root.API.Name)
@@ -129,12 +131,21 @@ func (s *NameScope) GoTypeDef(att *design.AttributeExpr, useDefault bool) string
 			{
 				fn = GoifyAtt(at, name, true)
 				tdef = s.GoTypeDef(at, useDefault)
+				if en := GoEnumTypeName(at, name); en != "" {
+					tdef = en
+				}
 				if design.IsObject(at.Type) || att.IsPrimitivePointer(name, useDefault) {
 					tdef = "*" + tdef
 				}
 				if at.Description != "" {
 					desc = Comment(at.Description) + "\n\t"
 				}
+				if r := DeprecationReason(at.Metadata); r != "" {
+					if desc != "" {
+						desc += "//\n\t"
+					}
+					desc += Comment("Deprecated: "+r) + "\n\t"
+				}
 				tags = AttributeTags(att, at)
 			}
 			ss = append(ss, fmt.Sprintf("\t%s%s %s%s", desc, fn, tdef, tags))
@@ -159,6 +170,10 @@ func (s *NameScope) GoTypeRef(att *design.AttributeExpr) string {
 // the given attribute type defined in the given package if a user type.
 func (s *NameScope) GoFullTypeRef(att *design.AttributeExpr, pkg string) string {
 	name := s.GoFullTypeName(att, pkg)
+	if _, ok := att.Metadata["convert:value"]; ok {
+		// external struct held by value
+		return name
+	}
 	return goTypeRef(name, att.Type)
 }
 
@@ -186,6 +201,10 @@ func (s *NameScope) GoFullTypeName(att *design.AttributeExpr, pkg string) string
 		if actual == design.ErrorResult {
 			return "goa.ServiceError"
 		}
+		if ext := actual.Attribute().Metadata["convert:pkg"]; len(ext) > 0 && pkg != "" {
+			// external type, use its actual package and name
+			return ext[0] + "." + actual.Name()
+		}
 		n := s.HashedUnique(actual, Goify(actual.Name(), true), "")
 		if pkg == "" {
 			return n
@@ -42,7 +42,7 @@ func AuthFuncsFile(genpkg string, root *design.RootExpr) *codegen.File {
 		for _, svc := range root.Services {
 			pkgName := Services.Get(svc.Name).PkgName
 			specs = append(specs, &codegen.ImportSpec{
-				Path: path.Join(genpkg, codegen.SnakeCase(svc.Name)),
+				Path: path.Join(codegen.ServiceGenpkg(genpkg, svc), codegen.SnakeCase(svc.Name)),
 				Name: pkgName,
 			})
 		}
@@ -72,6 +72,20 @@ func AuthFuncsFile(genpkg string, root *design.RootExpr) *codegen.File {
 
 // data: Data
 const dummyAuthFuncsT = `{{ range .Schemes }}
+{{- if eq .Type "APIKey" }}
+{{ printf "%sAPIKeys returns the API keys accepted by service %q. The first key is the primary key, the other keys are secondary keys that remain valid during key rotation." $.StructName $.Name | comment }}
+func {{ $.StructName }}APIKeys(ctx context.Context, s *security.APIKeyScheme) ([]string, error) {
+	//
+	// TBD: retrieve the keys, e.g. from a secret store.
+	//
+	return nil, fmt.Errorf("not implemented")
+}
+
+{{ printf "%sAPIKeyAuth implements the authorization logic for service %q for the %q security scheme. It accepts any of the keys returned by %sAPIKeys." $.StructName $.Name .SchemeName $.StructName | comment }}
+func {{ $.StructName }}APIKeyAuth(ctx context.Context, key string, s *security.APIKeyScheme) (context.Context, error) {
+	return security.AuthAPIKeys({{ $.StructName }}APIKeys)(ctx, key, s)
+}
+{{- else }}
 {{ printf "%s%sAuth implements the authorization logic for service %q for the %q security scheme." $.StructName .Type $.Name .SchemeName | comment }}
 func {{ $.StructName }}{{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basic" }}user, pass{{ else if eq .Type "APIKey" }}key{{ else }}token{{ end }} string, s *security.{{ .Type }}Scheme) (context.Context, error) {
 	//
@@ -88,7 +102,17 @@ func {{ $.StructName }}{{ .Type }}Auth(ctx context.Context, {{ if eq .Type "Basi
 	//
 	//    return ctx, goa.PermanentError("unauthorized", "invalid token")
 	//
+	{{- if or (eq .Type "JWT") (eq .Type "OAuth2") }}
+	// Record the scopes granted by the token so that the endpoint
+	// returns a 403 error when the required scopes are missing, e.g.:
+	//
+	//    ctx = security.ContextWithScopes(ctx, claims.Scopes)
+	//
+	// The required scopes are not enforced if no scopes are recorded.
+	//
+	{{- end }}
 	return ctx, fmt.Errorf("not implemented")
 }
 {{- end }}
+{{- end }}
 `
@@ -12,9 +12,34 @@ const (
 	ClientStructName = "Client"
 )
 
+type (
+	// clientTokenSourceData contains the data needed to render the client
+	// method that sets the source of the OAuth2 access tokens.
+	clientTokenSourceData struct {
+		// ClientVarName is the client struct name.
+		ClientVarName string
+		// ServiceName is the service name.
+		ServiceName string
+		// Methods lists the client methods secured with OAuth2.
+		Methods []*clientTokenMethodData
+	}
+
+	// clientTokenMethodData describes a client method secured with OAuth2.
+	clientTokenMethodData struct {
+		// VarName is the method name.
+		VarName string
+		// PayloadRef is the reference to the method payload type.
+		PayloadRef string
+		// Scheme is the OAuth2 scheme used to secure the method.
+		Scheme *SchemeData
+		// RequiredScopes lists the scopes required by the method.
+		RequiredScopes []string
+	}
+)
+
 // ClientFile returns the client file for the given service.
 func ClientFile(service *design.ServiceExpr) *codegen.File {
-	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(service.Name), "client.go")
+	path := filepath.Join(codegen.ServiceGendir(service), codegen.SnakeCase(service.Name), "client.go")
 	data := endpointData(service)
 	svc := Services.Get(service.Name)
 	var (
@@ -24,7 +49,9 @@ func ClientFile(service *design.ServiceExpr) *codegen.File {
 		header := codegen.Header(service.Name+" client", svc.PkgName,
 			[]*codegen.ImportSpec{
 				&codegen.ImportSpec{Path: "context"},
+				&codegen.ImportSpec{Path: "io"},
 				&codegen.ImportSpec{Name: "goa", Path: "goa.design/goa"},
+				&codegen.ImportSpec{Path: "goa.design/goa/security"},
 			})
 		def := &codegen.SectionTemplate{
 			Name:   "client-struct",
@@ -43,12 +70,61 @@ func ClientFile(service *design.ServiceExpr) *codegen.File {
 				Source: serviceClientMethodT,
 				Data:   m,
 			})
+			if m.Pagination != nil && !m.SkipRequestBodyEncodeDecode && !m.SkipResponseBodyEncodeDecode {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-iterator",
+					Source: serviceClientIteratorT,
+					Data:   m,
+				})
+			}
+		}
+		if ts := tokenSourceData(data); ts != nil {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "client-oauth2-token-source",
+				Source: serviceClientTokenSourceT,
+				Data:   ts,
+			})
 		}
 	}
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
+// tokenSourceData returns the data needed to render the client method that
+// sets the OAuth2 token source, nil if no method uses OAuth2 with an access
+// token payload attribute.
+func tokenSourceData(data *EndpointsData) *clientTokenSourceData {
+	var methods []*clientTokenMethodData
+	for _, m := range data.Methods {
+		if m.SkipRequestBodyEncodeDecode {
+			continue
+		}
+	reqs:
+		for _, r := range m.Requirements {
+			for _, s := range r.Schemes {
+				if s == nil || s.Type != "OAuth2" {
+					continue
+				}
+				methods = append(methods, &clientTokenMethodData{
+					VarName:        m.VarName,
+					PayloadRef:     m.PayloadRef,
+					Scheme:         s,
+					RequiredScopes: r.Scopes,
+				})
+				break reqs
+			}
+		}
+	}
+	if len(methods) == 0 {
+		return nil
+	}
+	return &clientTokenSourceData{
+		ClientVarName: data.ClientVarName,
+		ServiceName:   data.Name,
+		Methods:       methods,
+	}
+}
+
 // input: EndpointsData
 const serviceClientT = `// {{ .ClientVarName }} is the {{ printf "%q" .Name }} service client.
 type {{ .ClientVarName }} struct {
@@ -79,18 +155,168 @@ const serviceClientMethodT = `
 	{{- end }}
 //	- error: internal error
 {{- end }}
-func (c *{{ .ClientVarName }}) {{ .VarName }}(ctx context.Context, {{ if .PayloadRef }}p {{ .PayloadRef }}{{ end }})({{ if .ClientStream }}res {{ .ClientStream.Interface }}, {{ else if .ResultRef }}res {{ .ResultRef }}, {{ end }}err error) {
-	{{- if .ResultRef }}
+{{- if .Deprecated }}
+//
+{{ printf "Deprecated: %s" .Deprecated | comment }}
+{{- end }}
+func (c *{{ .ClientVarName }}) {{ .VarName }}(ctx context.Context, {{ if .PayloadRef }}p {{ .PayloadRef }}{{ end }}{{ if .SkipRequestBodyEncodeDecode }}{{ if .PayloadRef }}, {{ end }}req io.ReadCloser{{ end }})({{ if .ClientStream }}res {{ .ClientStream.Interface }}, {{ else if .ResultRef }}res {{ .ResultRef }}, {{ end }}{{ if .SkipResponseBodyEncodeDecode }}resp io.ReadCloser, {{ end }}err error) {
+	{{- if or .ResultRef .SkipResponseBodyEncodeDecode }}
 	var ires interface{}
 	{{- end }}
-	{{ if .ResultRef }}ires{{ else }}_{{ end }}, err = c.{{ .VarName}}Endpoint(ctx, {{ if .PayloadRef }}p{{ else }}nil{{ end }})
-	{{- if not .ResultRef }}
+	{{ if or .ResultRef .SkipResponseBodyEncodeDecode }}ires{{ else }}_{{ end }}, err = c.{{ .VarName}}Endpoint(ctx, {{ if .SkipRequestBodyEncodeDecode }}&{{ .RequestStruct }}{ {{ if .PayloadRef }}Payload: p, {{ end }}Body: req}{{ else if .PayloadRef }}p{{ else }}nil{{ end }})
+	{{- if not (or .ResultRef .SkipResponseBodyEncodeDecode) }}
 	return
 	{{- else }}
 	if err != nil {
 		return
 	}
+		{{- if .SkipResponseBodyEncodeDecode }}
+	o := ires.(*{{ .ResponseStruct }})
+	return {{ if .ResultRef }}o.Result, {{ end }}o.Body, nil
+		{{- else }}
 	return ires.({{ if .ClientStream }}{{ .ClientStream.Interface }}{{ else }}{{ .ResultRef }}{{ end }}), nil
+		{{- end }}
+	{{- end }}
+}
+`
+
+// input: EndpointMethodData
+const serviceClientIteratorT = `
+{{ printf "%s returns an iterator over the items of the pages returned by the %q endpoint starting with the page described by p. The iterator fetches the subsequent pages as needed." .Pagination.IterMethod .Name | comment }}
+func (c *{{ .ClientVarName }}) {{ .Pagination.IterMethod }}(ctx context.Context, p {{ .PayloadRef }}) *{{ .Pagination.IteratorStruct }} {
+	if p == nil {
+		p = &{{ .Payload }}{}
+	}
+	return &{{ .Pagination.IteratorStruct }}{client: c, ctx: ctx, payload: p}
+}
+
+{{ printf "%s iterates over the items of the pages returned by the %q endpoint." .Pagination.IteratorStruct .Name | comment }}
+type {{ .Pagination.IteratorStruct }} struct {
+	client  *{{ .ClientVarName }}
+	ctx     context.Context
+	payload {{ .PayloadRef }}
+	items   []{{ .Pagination.ItemRef }}
+	item    {{ .Pagination.ItemRef }}
+	done    bool
+	err     error
+}
+
+// Next advances the iterator to the next item, fetching the next page if
+// needed. It returns false once all the items have been read or if an error
+// occurred, use Err to tell the two cases apart.
+func (it *{{ .Pagination.IteratorStruct }}) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the current item.
+func (it *{{ .Pagination.IteratorStruct }}) Item() {{ .Pagination.ItemRef }} {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration if any.
+func (it *{{ .Pagination.IteratorStruct }}) Err() error {
+	return it.err
+}
+
+// fetch retrieves the current page and sets the payload to describe the next
+// page.
+func (it *{{ .Pagination.IteratorStruct }}) fetch() {
+	res, err := it.client.{{ .VarName }}(it.ctx, it.payload)
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.items = res.{{ .Pagination.ItemsField }}
+	p := *it.payload
+{{- if .Pagination.CursorField }}
+	{{- if .Pagination.NextCursorPointer }}
+	if res.{{ .Pagination.NextCursorField }} == nil || *res.{{ .Pagination.NextCursorField }} == "" {
+		it.done = true
+		return
+	}
+	p.{{ .Pagination.CursorField }} = {{ if not .Pagination.CursorPointer }}*{{ end }}res.{{ .Pagination.NextCursorField }}
+	{{- else }}
+	if res.{{ .Pagination.NextCursorField }} == "" {
+		it.done = true
+		return
+	}
+	p.{{ .Pagination.CursorField }} = {{ if .Pagination.CursorPointer }}&{{ end }}res.{{ .Pagination.NextCursorField }}
 	{{- end }}
+{{- else }}
+	n := len(res.{{ .Pagination.ItemsField }})
+	{{- if .Pagination.LimitField }}
+	if n == 0 || {{ if .Pagination.LimitPointer }}p.{{ .Pagination.LimitField }} != nil && n < *p.{{ .Pagination.LimitField }}{{ else }}n < p.{{ .Pagination.LimitField }}{{ end }} {
+	{{- else }}
+	if n == 0 {
+	{{- end }}
+		it.done = true
+		return
+	}
+	{{- if .Pagination.OffsetPointer }}
+	offset := n
+	if p.{{ .Pagination.OffsetField }} != nil {
+		offset += *p.{{ .Pagination.OffsetField }}
+	}
+	p.{{ .Pagination.OffsetField }} = &offset
+	{{- else }}
+	p.{{ .Pagination.OffsetField }} += n
+	{{- end }}
+{{- end }}
+	it.payload = &p
+}
+`
+
+// input: clientTokenSourceData
+const serviceClientTokenSourceT = `
+{{ printf "UseOAuth2TokenSource sets the source of the OAuth2 access tokens sent with the requests made by the %q service client. The client retrieves a token from ts for the requests whose payload does not set one." .ServiceName | comment }}
+func (c *{{ .ClientVarName }}) UseOAuth2TokenSource(ts security.OAuth2TokenSource) {
+{{- range .Methods }}
+	{
+		sc := security.OAuth2Scheme{
+			Name: {{ printf "%q" .Scheme.SchemeName }},
+			Scopes: []string{ {{- range .Scheme.Scopes }}{{ printf "%q" . }}, {{ end }} },
+			RequiredScopes: []string{ {{- range .RequiredScopes }}{{ printf "%q" . }}, {{ end }} },
+			{{- if .Scheme.Flows }}
+			Flows: []*security.OAuthFlow{
+				{{- range .Scheme.Flows }}
+				&security.OAuthFlow{
+					Type: "{{ .Type }}",
+					{{- if .AuthorizationURL }}
+					AuthorizationURL: {{ printf "%q" .AuthorizationURL }},
+					{{- end }}
+					{{- if .TokenURL }}
+					TokenURL: {{ printf "%q" .TokenURL }},
+					{{- end }}
+					{{- if .RefreshURL }}
+					RefreshURL: {{ printf "%q" .RefreshURL }},
+					{{- end }}
+				},
+				{{- end }}
+			},
+			{{- end }}
+		}
+		next := c.{{ .VarName }}Endpoint
+		c.{{ .VarName }}Endpoint = func(ctx context.Context, v interface{}) (interface{}, error) {
+			p := v.({{ .PayloadRef }})
+			if p.{{ .Scheme.CredField }} == {{ if .Scheme.CredPointer }}nil{{ else }}""{{ end }} {
+				token, err := ts(ctx, &sc)
+				if err != nil {
+					return nil, err
+				}
+				pc := *p
+				pc.{{ .Scheme.CredField }} = {{ if .Scheme.CredPointer }}&{{ end }}token
+				v = &pc
+			}
+			return next(ctx, v)
+		}
+	}
+{{- end }}
 }
 `
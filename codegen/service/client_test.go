@@ -18,6 +18,10 @@ func TestClient(t *testing.T) {
 		{"single", testdata.SingleEndpointDSL, testdata.SingleMethodClient},
 		{"multiple", testdata.MultipleEndpointsDSL, testdata.MultipleMethodsClient},
 		{"no-payload", testdata.NoPayloadEndpointDSL, testdata.NoPayloadMethodsClient},
+		{"skip-body-encode-decode", testdata.SkipBodyEncodeDecodeEndpointDSL, testdata.SkipBodyEncodeDecodeMethodsClient},
+		{"cursor-paginated", testdata.CursorPaginatedEndpointDSL, testdata.CursorPaginatedMethodClient},
+		{"offset-paginated", testdata.OffsetPaginatedEndpointDSL, testdata.OffsetPaginatedMethodClient},
+		{"oauth2", testdata.EndpointWithOAuth2DSL, testdata.OAuth2MethodClient},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -6,11 +6,15 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"goa.design/goa/codegen"
 	"goa.design/goa/design"
 )
 
+// timeType is the reflect type of time.Time values.
+var timeType = reflect.TypeOf(time.Time{})
+
 // ConvertData contains the info needed to render convert and create functions.
 type ConvertData struct {
 	// Name is the name of the function.
@@ -84,35 +88,64 @@ func ConvertFile(root *design.RootExpr, service *design.ServiceExpr) (*codegen.F
 		return nil, nil
 	}
 
-	// Retrieve external packages info
-	ppm := make(map[string]string)
-	for _, c := range conversions {
-		pkg := reflect.TypeOf(c.External)
-		p := pkg.PkgPath()
-
-		alias := strings.Split(pkg.String(), ".")[0]
-
-		ppm[p] = alias
+	// Build design types that represent the external types
+	var (
+		convTypes   = make([]design.DataType, len(conversions))
+		createTypes = make([]design.DataType, len(creations))
+	)
+	for i, c := range conversions {
+		if err := buildDesignType(&convTypes[i], reflect.TypeOf(c.External), c.User); err != nil {
+			return nil, err
+		}
 	}
-	for _, c := range creations {
+	for i, c := range creations {
+		if err := buildDesignType(&createTypes[i], reflect.TypeOf(c.External), c.User); err != nil {
+			return nil, err
+		}
+	}
+
+	// Retrieve external packages info in declaration order so that the
+	// generated imports are stable.
+	var (
+		ppm  = make(map[string]string)
+		pkgs []*codegen.ImportSpec
+	)
+	for _, c := range append(conversions, creations...) {
 		pkg := reflect.TypeOf(c.External)
 		p := pkg.PkgPath()
-
+		if _, ok := ppm[p]; ok {
+			continue
+		}
 		alias := strings.Split(pkg.String(), ".")[0]
-
 		ppm[p] = alias
+		pkgs = append(pkgs, &codegen.ImportSpec{Name: alias, Path: p})
 	}
-	pkgs := make([]*codegen.ImportSpec, len(ppm))
-	i := 0
-	for pp, alias := range ppm {
-		pkgs[i] = &codegen.ImportSpec{Name: alias, Path: pp}
-		i++
+
+	// Retrieve conversion functions packages info and helpers
+	var (
+		helpers []string
+		seen    = make(map[string]struct{})
+	)
+	for _, dt := range append(convTypes, createTypes...) {
+		imports, hs := conversionDeps(dt)
+		for _, imp := range imports {
+			if _, ok := ppm[imp.Path]; !ok {
+				ppm[imp.Path] = imp.Name
+				pkgs = append(pkgs, imp)
+			}
+		}
+		for _, h := range hs {
+			if _, ok := seen[h]; !ok {
+				seen[h] = struct{}{}
+				helpers = append(helpers, h)
+			}
+		}
 	}
 
 	// Build header section
 	pkgs = append(pkgs, &codegen.ImportSpec{Path: "context"})
 	pkgs = append(pkgs, &codegen.ImportSpec{Path: "goa.design/goa"})
-	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(service.Name), "convert.go")
+	path := filepath.Join(codegen.ServiceGendir(service), codegen.SnakeCase(service.Name), "convert.go")
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(service.Name+" service type conversion functions", svc.PkgName, pkgs),
 	}
@@ -123,11 +156,8 @@ func ConvertFile(root *design.RootExpr, service *design.ServiceExpr) (*codegen.F
 	)
 
 	// Build conversion sections if any
-	for _, c := range conversions {
-		var dt design.DataType
-		if err := buildDesignType(&dt, reflect.TypeOf(c.External), c.User); err != nil {
-			return nil, err
-		}
+	for i, c := range conversions {
+		dt := convTypes[i]
 		t := reflect.TypeOf(c.External)
 		tgtPkg := t.String()
 		tgtPkg = tgtPkg[:strings.Index(tgtPkg, ".")]
@@ -135,6 +165,11 @@ func ConvertFile(root *design.RootExpr, service *design.ServiceExpr) (*codegen.F
 		if err != nil {
 			return nil, err
 		}
+		oneof, err := oneofConvertCode(c.User, t, tgtPkg)
+		if err != nil {
+			return nil, err
+		}
+		code += oneof
 		transFuncs = append(transFuncs, tf...)
 		base := "ConvertTo" + t.Name()
 		name := uniquify(base, names)
@@ -157,11 +192,8 @@ func ConvertFile(root *design.RootExpr, service *design.ServiceExpr) (*codegen.F
 	}
 
 	// Build creation sections if any
-	for _, c := range creations {
-		var dt design.DataType
-		if err := buildDesignType(&dt, reflect.TypeOf(c.External), c.User); err != nil {
-			return nil, err
-		}
+	for i, c := range creations {
+		dt := createTypes[i]
 		t := reflect.TypeOf(c.External)
 		srcPkg := t.String()
 		srcPkg = srcPkg[:strings.Index(srcPkg, ".")]
@@ -169,6 +201,11 @@ func ConvertFile(root *design.RootExpr, service *design.ServiceExpr) (*codegen.F
 		if err != nil {
 			return nil, err
 		}
+		oneof, err := oneofCreateCode(c.User, t, srcPkg)
+		if err != nil {
+			return nil, err
+		}
+		code += oneof
 		transFuncs = append(transFuncs, tf...)
 		base := "CreateFrom" + t.Name()
 		name := uniquify(base, names)
@@ -198,6 +235,14 @@ func ConvertFile(root *design.RootExpr, service *design.ServiceExpr) (*codegen.F
 		})
 	}
 
+	// Build scalar conversion helper functions sections if any.
+	for _, h := range helpers {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "convert-scalar-helper",
+			Source: h,
+		})
+	}
+
 	return &codegen.File{Path: path, SectionTemplates: sections}, nil
 }
 
@@ -298,7 +343,7 @@ func buildDesignType(dt *design.DataType, t reflect.Type, ref design.DataType, r
 		if err := buildDesignType(&elem, e, eref, rec.append("[0]")); err != nil {
 			return fmt.Errorf("%s", err)
 		}
-		*dt = &design.Array{ElemType: &design.AttributeExpr{Type: elem}}
+		*dt = &design.Array{ElemType: &design.AttributeExpr{Type: elem, Metadata: valueMetadata(e)}}
 
 	case reflect.Map:
 		var kref, vref design.DataType
@@ -315,7 +360,10 @@ func buildDesignType(dt *design.DataType, t reflect.Type, ref design.DataType, r
 		if err := buildDesignType(&vt, t.Elem(), vref, rec.append(".value")); err != nil {
 			return fmt.Errorf("%s", err)
 		}
-		*dt = &design.Map{KeyType: &design.AttributeExpr{Type: kt}, ElemType: &design.AttributeExpr{Type: vt}}
+		*dt = &design.Map{
+			KeyType:  &design.AttributeExpr{Type: kt},
+			ElemType: &design.AttributeExpr{Type: vt, Metadata: valueMetadata(t.Elem())},
+		}
 
 	case reflect.Struct:
 		var oref *design.Object
@@ -327,6 +375,11 @@ func buildDesignType(dt *design.DataType, t reflect.Type, ref design.DataType, r
 		var fields []reflect.StructField
 		for i := 0; i < t.NumField(); i++ {
 			f := t.FieldByIndex([]int{i})
+			if f.PkgPath != "" || strings.HasPrefix(f.Name, "XXX_") || isOneof(f) {
+				// Unexported, protobuf internal or oneof fields, see
+				// oneofConvertCode and oneofCreateCode.
+				continue
+			}
 			atn, _ := attributeName(oref, f.Name)
 			if oref != nil {
 				if at := oref.Attribute(atn); at != nil {
@@ -342,20 +395,53 @@ func buildDesignType(dt *design.DataType, t reflect.Type, ref design.DataType, r
 
 		// Avoid infinite recursions
 		obj := design.Object(make([]*design.NamedAttributeExpr, len(fields)))
+		pkg := t.String()
+		pkg = pkg[:strings.Index(pkg, ".")]
 		ut := &design.UserTypeExpr{
-			AttributeExpr: &design.AttributeExpr{Type: &obj},
-			TypeName:      t.Name(),
+			AttributeExpr: &design.AttributeExpr{
+				Type:     &obj,
+				Metadata: design.MetadataExpr{"convert:pkg": {pkg, t.PkgPath()}},
+			},
+			TypeName: t.Name(),
 		}
 		*dt = ut
 		rec.seen[t.Name()] = ut
 		var required []string
 		for i, f := range fields {
 			atn, fn := attributeName(oref, f.Name)
-			var aref design.DataType
+			var (
+				aatt *design.AttributeExpr
+				aref design.DataType
+			)
 			if oref != nil {
-				if at := oref.Attribute(atn); at != nil {
-					aref = at.Type
+				if aatt = oref.Attribute(atn); aatt != nil {
+					aref = aatt.Type
+				}
+			}
+			name := atn
+			if fn != "" {
+				name = name + ":" + fn
+			}
+			if conv := scalarConversion(aatt, f.Type); conv != nil {
+				if f.Type.Kind() != reflect.Ptr {
+					required = append(required, atn)
 				}
+				meta := design.MetadataExpr{
+					"convert:encode": {conv.Encode},
+					"convert:decode": {conv.Decode},
+					"convert:helper": conv.Helpers,
+				}
+				if conv.ByRef {
+					meta["convert:byref"] = nil
+				}
+				for _, imp := range conv.Imports {
+					meta["convert:import"] = append(meta["convert:import"], strings.TrimSpace(imp.Name+" "+imp.Path))
+				}
+				obj[i] = &design.NamedAttributeExpr{
+					Name:      name,
+					Attribute: &design.AttributeExpr{Type: aref, Metadata: meta},
+				}
+				continue
 			}
 			var fdt design.DataType
 			if f.Type.Kind() == reflect.Ptr {
@@ -369,20 +455,16 @@ func buildDesignType(dt *design.DataType, t reflect.Type, ref design.DataType, r
 					return fmt.Errorf("%s: field of type pointer to map are not supported, use map instead", rec.path)
 				}
 			} else {
-				if isPrimitive(f.Type) {
+				if isPrimitive(f.Type) || f.Type.Kind() == reflect.Struct {
 					required = append(required, atn)
 				}
 				if err := buildDesignType(&fdt, f.Type, aref, rec.append("."+f.Name)); err != nil {
 					return fmt.Errorf("%q.%s: %s", t.Name(), f.Name, err)
 				}
 			}
-			name := atn
-			if fn != "" {
-				name = name + ":" + fn
-			}
 			obj[i] = &design.NamedAttributeExpr{
 				Name:      name,
-				Attribute: &design.AttributeExpr{Type: fdt},
+				Attribute: &design.AttributeExpr{Type: fdt, Metadata: valueMetadata(f.Type)},
 			}
 		}
 		if len(required) > 0 {
@@ -404,6 +486,16 @@ func buildDesignType(dt *design.DataType, t reflect.Type, ref design.DataType, r
 	return nil
 }
 
+// valueMetadata returns the metadata of the attribute that holds a value of
+// the given type. Attributes that hold structs by value are marked with the
+// "convert:value" metadata so that the generated code does not use pointers.
+func valueMetadata(t reflect.Type) design.MetadataExpr {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return design.MetadataExpr{"convert:value": nil}
+}
+
 // attributeName computes the name of the attribute for the given field name and
 // object that must contain the matching attribute.
 func attributeName(obj *design.Object, name string) (string, string) {
@@ -442,6 +534,193 @@ func attributeName(obj *design.Object, name string) (string, string) {
 	return name, ""
 }
 
+// scalarConv describes the functions used to convert a primitive attribute
+// value to and from the value of the corresponding external type field.
+type scalarConv struct {
+	// Encode is the function that converts the attribute value to the field
+	// value.
+	Encode string
+	// Decode is the function that converts the field value to the attribute
+	// value.
+	Decode string
+	// ByRef is true if the functions accept and return pointers to the
+	// field values, nil pointers are then never dereferenced.
+	ByRef bool
+	// Imports lists the packages used by the functions.
+	Imports []*codegen.ImportSpec
+	// Helpers contains the code of the generated functions if any.
+	Helpers []string
+}
+
+// scalarConversion returns the functions used to initialize a field of the
+// given type from the value of the attribute att and vice versa if the
+// assignment requires a function call, nil otherwise. The functions are given
+// by the attribute "struct.field.convert" metadata. Otherwise String attributes
+// with the DateTime format are converted to and from time.Time fields and
+// protobuf timestamps, String attributes are converted to and from protobuf
+// enums and attributes are cast to and from named types with the same
+// underlying type.
+func scalarConversion(att *design.AttributeExpr, t reflect.Type) *scalarConv {
+	if att == nil || !design.IsPrimitive(att.Type) {
+		return nil
+	}
+	if m := att.Metadata["struct.field.convert"]; len(m) == 2 {
+		enc, epkg := conversionFunc(m[0])
+		dec, dpkg := conversionFunc(m[1])
+		conv := &scalarConv{Encode: enc, Decode: dec}
+		for _, pkg := range []string{epkg, dpkg} {
+			if pkg != "" {
+				conv.Imports = append(conv.Imports, &codegen.ImportSpec{Path: pkg})
+			}
+		}
+		return conv
+	}
+	isDateTime := att.Type.Kind() == design.StringKind &&
+		att.Validation != nil && att.Validation.Format == design.FormatDateTime
+	if t.Kind() == reflect.Ptr {
+		if isDateTime && isTimestamp(t.Elem()) {
+			alias := typePkg(t.Elem())
+			return &scalarConv{
+				Encode: "parseTimestamp",
+				Decode: "formatTimestamp",
+				ByRef:  true,
+				Imports: []*codegen.ImportSpec{
+					{Path: "time"},
+					{Name: alias, Path: t.Elem().PkgPath()},
+				},
+				Helpers: []string{fmt.Sprintf(timestampHelpersT, alias)},
+			}
+		}
+		t = t.Elem()
+	}
+	if t == timeType && isDateTime {
+		return &scalarConv{
+			Encode:  "parseDateTime",
+			Decode:  "formatDateTime",
+			Imports: []*codegen.ImportSpec{{Path: "time"}},
+			Helpers: []string{dateTimeHelpersT},
+		}
+	}
+	if t.PkgPath() == "" || !isPrimitive(t) || t.Kind() == reflect.Interface {
+		return nil
+	}
+	alias := typePkg(t)
+	ref := alias + "." + t.Name()
+	imports := []*codegen.ImportSpec{{Name: alias, Path: t.PkgPath()}}
+	if isEnum(t) && att.Type.Kind() == design.StringKind {
+		name := alias + t.Name() + "FromString"
+		return &scalarConv{
+			Encode:  name,
+			Decode:  ref + ".String",
+			Imports: imports,
+			Helpers: []string{fmt.Sprintf(enumHelperT, name, ref)},
+		}
+	}
+	var dt design.DataType
+	if err := buildDesignType(&dt, t, nil); err != nil || !design.Equal(dt, att.Type) {
+		return nil
+	}
+	return &scalarConv{
+		Encode:  ref,
+		Decode:  codegen.GoNativeTypeName(att.Type),
+		Imports: imports,
+	}
+}
+
+// isTimestamp returns true if t is a protobuf timestamp, i.e. a struct named
+// Timestamp with Seconds and Nanos fields.
+func isTimestamp(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || t.Name() != "Timestamp" {
+		return false
+	}
+	secs, ok := t.FieldByName("Seconds")
+	if !ok || secs.Type.Kind() != reflect.Int64 {
+		return false
+	}
+	nanos, ok := t.FieldByName("Nanos")
+	return ok && nanos.Type.Kind() == reflect.Int32
+}
+
+// isEnum returns true if t is a protobuf enum, i.e. a named int32 with String
+// and Enum methods.
+func isEnum(t reflect.Type) bool {
+	if t.Kind() != reflect.Int32 {
+		return false
+	}
+	_, ok := t.MethodByName("String")
+	_, ok2 := t.MethodByName("Enum")
+	return ok && ok2
+}
+
+// typePkg returns the name of the package that defines the named type t.
+func typePkg(t reflect.Type) string {
+	n := t.String()
+	return n[:strings.Index(n, ".")]
+}
+
+// conversionFunc returns the Go reference to the function with the given
+// fully qualified name (e.g. "github.com/repo/model.ParseID") and the path of
+// the package that defines it.
+func conversionFunc(name string) (string, string) {
+	idx := strings.LastIndex(name, ".")
+	if idx == -1 {
+		return name, ""
+	}
+	pkg := name[:idx]
+	return pkg[strings.LastIndex(pkg, "/")+1:] + name[idx:], pkg
+}
+
+// conversionDeps returns the import specs of the packages that define the
+// types and the conversion functions used by the given data type built with
+// buildDesignType as well as the code of the conversion helper functions.
+func conversionDeps(dt design.DataType, seen ...map[string]struct{}) ([]*codegen.ImportSpec, []string) {
+	var s map[string]struct{}
+	if len(seen) > 0 {
+		s = seen[0]
+	} else {
+		s = make(map[string]struct{})
+	}
+	if ut, ok := dt.(design.UserType); ok {
+		if _, ok := s[ut.Name()]; ok {
+			return nil, nil
+		}
+		s[ut.Name()] = struct{}{}
+	}
+	var (
+		imports []*codegen.ImportSpec
+		helpers []string
+	)
+	switch actual := dt.(type) {
+	case design.UserType:
+		if ext := actual.Attribute().Metadata["convert:pkg"]; len(ext) == 2 {
+			imports = append(imports, &codegen.ImportSpec{Name: ext[0], Path: ext[1]})
+		}
+		aimports, ahelpers := conversionDeps(actual.Attribute().Type, s)
+		return append(imports, aimports...), ahelpers
+	case *design.Array:
+		return conversionDeps(actual.ElemType.Type, s)
+	case *design.Map:
+		imports, helpers = conversionDeps(actual.KeyType.Type, s)
+		eimports, ehelpers := conversionDeps(actual.ElemType.Type, s)
+		return append(imports, eimports...), append(helpers, ehelpers...)
+	case *design.Object:
+		for _, nat := range *actual {
+			for _, imp := range nat.Attribute.Metadata["convert:import"] {
+				spec := &codegen.ImportSpec{Path: imp}
+				if parts := strings.Fields(imp); len(parts) == 2 {
+					spec = &codegen.ImportSpec{Name: parts[0], Path: parts[1]}
+				}
+				imports = append(imports, spec)
+			}
+			helpers = append(helpers, nat.Attribute.Metadata["convert:helper"]...)
+			aimports, ahelpers := conversionDeps(nat.Attribute.Type, s)
+			imports = append(imports, aimports...)
+			helpers = append(helpers, ahelpers...)
+		}
+	}
+	return imports, helpers
+}
+
 // isPrimitive is true if the given kind matches a goa primitive type.
 func isPrimitive(t reflect.Type) bool {
 	switch t.Kind() {
@@ -567,10 +846,18 @@ func compatible(from design.DataType, to reflect.Type, recs ...compRec) error {
 					field, ok = to.FieldByName(ef)
 				}
 			}
+			if !ok {
+				if opt, k := oneofOptions(to)[fname]; k {
+					field, ok = opt.Value, true
+				}
+			}
 			if !ok {
 				return fmt.Errorf("types don't match: could not find field %q of external type %q matching attribute %q of type %q",
 					fname, toName, nat.Name, from.Name())
 			}
+			if scalarConversion(nat.Attribute, field.Type) != nil {
+				continue
+			}
 			err := compatible(
 				nat.Attribute.Type,
 				field.Type,
@@ -596,6 +883,133 @@ func compatible(from design.DataType, to reflect.Type, recs ...compRec) error {
 	return fmt.Errorf("types don't match: type of %s is %s but type of corresponding attribute is %s", rec.path, toName, from.Name())
 }
 
+// isOneof returns true if f is a protobuf oneof field.
+func isOneof(f reflect.StructField) bool {
+	_, ok := f.Tag.Lookup("protobuf_oneof")
+	return ok && f.Type.Kind() == reflect.Interface
+}
+
+// oneofOption describes a possible value of a protobuf oneof field.
+type oneofOption struct {
+	// Field is the name of the oneof field.
+	Field string
+	// Wrapper is the struct type that wraps the option value.
+	Wrapper reflect.Type
+	// Value is the wrapper struct field that holds the option value.
+	Value reflect.StructField
+}
+
+// oneofOptions returns the options of the oneof fields of the protobuf
+// message struct t indexed by the names of the wrapper fields that hold the
+// option values. The options are retrieved from the XXX_OneofWrappers method
+// generated by protoc-gen-go.
+func oneofOptions(t reflect.Type) map[string]*oneofOption {
+	msg, ok := reflect.New(t).Interface().(interface{ XXX_OneofWrappers() []interface{} })
+	if !ok {
+		return nil
+	}
+	opts := make(map[string]*oneofOption)
+	for _, w := range msg.XXX_OneofWrappers() {
+		wt := reflect.TypeOf(w)
+		if wt.Kind() != reflect.Ptr || wt.Elem().Kind() != reflect.Struct || wt.Elem().NumField() != 1 {
+			continue
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if isOneof(f) && wt.Implements(f.Type) {
+				v := wt.Elem().Field(0)
+				opts[v.Name] = &oneofOption{Field: f.Name, Wrapper: wt.Elem(), Value: v}
+			}
+		}
+	}
+	return opts
+}
+
+// oneofMatches returns the attributes of ut that match the options of the
+// oneof fields of the external type t together with the options.
+func oneofMatches(ut design.UserType, t reflect.Type) ([]*design.NamedAttributeExpr, []*oneofOption, error) {
+	opts := oneofOptions(t)
+	if len(opts) == 0 {
+		return nil, nil, nil
+	}
+	var (
+		nats    []*design.NamedAttributeExpr
+		matches []*oneofOption
+	)
+	obj := design.AsObject(ut)
+	ma := design.NewMappedAttributeExpr(&design.AttributeExpr{Type: obj})
+	for _, nat := range *obj {
+		fname := codegen.Goify(ma.ElemName(nat.Name), true)
+		if ef, ok := nat.Attribute.Metadata["struct.field.external"]; ok {
+			fname = ef[0]
+		}
+		opt, ok := opts[fname]
+		if !ok {
+			continue
+		}
+		if opt.Value.Type.PkgPath() != "" || !design.IsPrimitive(nat.Attribute.Type) {
+			return nil, nil, fmt.Errorf("%q.%s: only oneof fields of primitive types are supported", t.Name(), opt.Field)
+		}
+		nats = append(nats, nat)
+		matches = append(matches, opt)
+	}
+	return nats, matches, nil
+}
+
+// oneofConvertCode returns the code that initializes the oneof fields of the
+// external type t held in v from the values of the attributes of ut held in t.
+func oneofConvertCode(ut design.UserType, t reflect.Type, pkg string) (string, error) {
+	nats, opts, err := oneofMatches(ut, t)
+	if err != nil {
+		return "", err
+	}
+	var code string
+	for i, nat := range nats {
+		var (
+			opt     = opts[i]
+			field   = "t." + codegen.Goify(nat.Name, true)
+			wrapper = pkg + "." + opt.Wrapper.Name()
+		)
+		if (&design.AttributeExpr{Type: ut}).IsPrimitivePointer(nat.Name, true) {
+			code += fmt.Sprintf("\nif %s != nil {\n\tv.%s = &%s{%s: *%s}\n}", field, opt.Field, wrapper, opt.Value.Name, field)
+			continue
+		}
+		code += fmt.Sprintf("\nv.%s = &%s{%s: %s}", opt.Field, wrapper, opt.Value.Name, field)
+	}
+	return code, nil
+}
+
+// oneofCreateCode returns the code that initializes the attributes of ut held
+// in temp from the values of the oneof fields of the external type t held in
+// v.
+func oneofCreateCode(ut design.UserType, t reflect.Type, pkg string) (string, error) {
+	nats, opts, err := oneofMatches(ut, t)
+	if err != nil {
+		return "", err
+	}
+	var (
+		fields []string
+		cases  = make(map[string]string)
+	)
+	for i, nat := range nats {
+		opt := opts[i]
+		if _, ok := cases[opt.Field]; !ok {
+			fields = append(fields, opt.Field)
+		}
+		ref := "&"
+		if !(&design.AttributeExpr{Type: ut}).IsPrimitivePointer(nat.Name, true) {
+			ref = ""
+		}
+		cases[opt.Field] += fmt.Sprintf("\ncase *%s.%s:\n\ttemp.%s = %sval.%s",
+			pkg, opt.Wrapper.Name(), codegen.Goify(nat.Name, true), ref, opt.Value.Name)
+	}
+	var code string
+	for _, f := range fields {
+		code += fmt.Sprintf("\nswitch val := v.%s.(type) {%s\n}", f, cases[f])
+	}
+	return code, nil
+}
+
 // input: ConvertData
 const convertT = `{{ printf "%s creates an instance of %s initialized from t." .Name .TypeName | comment }}
 func (t {{ .ReceiverTypeRef }}) {{ .Name }}() {{ .TypeRef }} {
@@ -619,3 +1033,44 @@ func {{ .Name }}(v {{ .ParamTypeRef }}) {{ .ResultTypeRef }} {
         return res
 }
 `
+
+// input: nil
+const dateTimeHelpersT = `// parseDateTime returns the time represented by the RFC3339 date time s or the
+// zero time if s is not a valid date time.
+func parseDateTime(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+// formatDateTime returns the RFC3339 representation of t.
+func formatDateTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+`
+
+// input: name of the function and reference to the enum type
+const enumHelperT = `// %[1]s returns the %[2]s value with the given name.
+func %[1]s(s string) %[2]s {
+	return %[2]s(%[2]s_value[s])
+}
+`
+
+// input: name of the package that defines the Timestamp type
+const timestampHelpersT = `// parseTimestamp returns the protobuf timestamp for the RFC3339 date time s or
+// nil if s is not a valid date time.
+func parseTimestamp(s string) *%[1]s.Timestamp {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &%[1]s.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
+
+// formatTimestamp returns the RFC3339 representation of ts.
+func formatTimestamp(ts *%[1]s.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return time.Unix(ts.Seconds, int64(ts.Nanos)).UTC().Format(time.RFC3339)
+}
+`
@@ -96,6 +96,8 @@ func TestCompatible(t *testing.T) {
 		{"object-ignored", objIgnored, objT{}, ""},
 		{"object-extra", objIgnored, objExtraT{}, ""},
 		{"object-recursive", objRecursive(), objRecursiveT{}, ""},
+		{"object-time", objTime, objTimeT{}, ""},
+		{"object-time-pointer", objTime, objTimePointerT{}, ""},
 		{"array-object", dsl.ArrayOf(obj), []objT{objT{}}, ""},
 
 		{"invalid-primitive", design.String, 0, "types don't match: type of <value> is int but type of corresponding attribute is string"},
@@ -146,10 +148,32 @@ func TestConvertFile(t *testing.T) {
 		{"create-string-required", testdata.CreateStringRequiredDSL, 1, testdata.CreateStringRequiredCode},
 		{"create-string-pointer", testdata.CreateStringPointerDSL, 1, testdata.CreateStringPointerCode},
 		{"create-string-pointer-required", testdata.CreateStringPointerRequiredDSL, 1, testdata.CreateStringPointerRequiredCode},
+		{"convert-time", testdata.ConvertTimeDSL, 1, testdata.ConvertTimeCode},
+		{"convert-time-required", testdata.ConvertTimeRequiredDSL, 1, testdata.ConvertTimeRequiredCode},
+		{"convert-time-pointer", testdata.ConvertTimePointerDSL, 1, testdata.ConvertTimePointerCode},
+		{"convert-time-helpers", testdata.ConvertTimeDSL, 2, testdata.ConvertTimeHelpersCode},
+		{"convert-custom-scalar", testdata.ConvertCustomScalarDSL, 0, testdata.ConvertCustomScalarCode},
+		{"create-time", testdata.CreateTimeDSL, 1, testdata.CreateTimeCode},
+		{"create-time-pointer-required", testdata.CreateTimePointerRequiredDSL, 1, testdata.CreateTimePointerRequiredCode},
+		{"create-custom-scalar", testdata.CreateCustomScalarDSL, 0, testdata.CreateCustomScalarCode},
 		{"convert-array-string", testdata.ConvertArrayStringDSL, 1, testdata.ConvertArrayStringCode},
 		{"convert-array-string-required", testdata.ConvertArrayStringRequiredDSL, 1, testdata.ConvertArrayStringRequiredCode},
 		{"create-array-string", testdata.CreateArrayStringDSL, 1, testdata.CreateArrayStringCode},
 		{"create-array-string-required", testdata.CreateArrayStringRequiredDSL, 1, testdata.CreateArrayStringRequiredCode},
+		{"convert-array-object", testdata.ConvertArrayObjectDSL, 1, testdata.ConvertArrayObjectCode},
+		{"convert-map-object", testdata.ConvertMapObjectDSL, 1, testdata.ConvertMapObjectCode},
+		{"convert-nested", testdata.ConvertNestedDSL, 0, testdata.ConvertNestedCode},
+		{"convert-nested-helper", testdata.ConvertNestedDSL, 2, testdata.ConvertNestedHelperCode},
+		{"convert-mapped-fields", testdata.ConvertMappedFieldsDSL, 1, testdata.ConvertMappedFieldsCode},
+		{"convert-proto", testdata.ConvertProtoDSL, 0, testdata.ConvertProtoCode},
+		{"convert-proto-helpers", testdata.ConvertProtoDSL, 2, testdata.ConvertProtoEnumHelperCode},
+		{"create-array-object", testdata.CreateArrayObjectDSL, 1, testdata.CreateArrayObjectCode},
+		{"create-map-object", testdata.CreateMapObjectDSL, 1, testdata.CreateMapObjectCode},
+		{"create-nested", testdata.CreateNestedDSL, 0, testdata.CreateNestedCode},
+		{"create-nested-helper", testdata.CreateNestedDSL, 2, testdata.CreateNestedHelperCode},
+		{"create-mapped-fields", testdata.CreateMappedFieldsDSL, 1, testdata.CreateMappedFieldsCode},
+		{"create-proto", testdata.CreateProtoDSL, 0, testdata.CreateProtoCode},
+		{"create-proto-helpers", testdata.CreateProtoDSL, 3, testdata.CreateProtoTimestampHelperCode},
 		{"convert-object", testdata.ConvertObjectDSL, 1, testdata.ConvertObjectCode},
 		{"convert-object-2", testdata.ConvertObjectDSL, 2, testdata.ConvertObjectHelperCode},
 		{"convert-object-required", testdata.ConvertObjectRequiredDSL, 2, testdata.ConvertObjectRequiredHelperCode},
@@ -193,10 +217,35 @@ func TestConvertFile(t *testing.T) {
 	}
 }
 
+func TestConvertFileImports(t *testing.T) {
+	expected := []string{
+		"goa.design/goa/codegen/service/testdata/external",
+		"goa.design/goa/codegen/service/testdata/alias-external",
+		"context",
+		"goa.design/goa",
+	}
+	// Run multiple times as map iteration order is random.
+	for i := 0; i < 10; i++ {
+		root := runDSL(t, testdata.ConvertMultiplePackagesDSL)
+		f, err := ConvertFile(root, root.Services[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		specs := f.SectionTemplates[0].Data.(map[string]interface{})["Imports"].([]*codegen.ImportSpec)
+		var paths []string
+		for _, s := range specs {
+			paths = append(paths, s.Path)
+		}
+		if !reflect.DeepEqual(paths, expected) {
+			t.Fatalf("got imports %v, expected %v", paths, expected)
+		}
+	}
+}
+
 // runDSL returns the DSL root resulting from running the given DSL.
 func runDSL(t *testing.T, dsl func()) *design.RootExpr {
 	// reset all roots and codegen data structures
-	Services = make(ServicesData)
+	Services = NewServicesData()
 	eval.Reset()
 	design.Root = new(design.RootExpr)
 	eval.Register(design.Root)
@@ -256,6 +305,15 @@ var objIgnored = &design.UserTypeExpr{
 	TypeName: "objT",
 }
 
+var objTime = &design.UserTypeExpr{
+	AttributeExpr: &design.AttributeExpr{
+		Type: &design.Object{
+			{"Time", &design.AttributeExpr{Type: design.String, Validation: &design.ValidationExpr{Format: design.FormatDateTime}}},
+		},
+	},
+	TypeName: "objTimeT",
+}
+
 func objRecursive() *design.UserTypeExpr {
 	res := &design.UserTypeExpr{
 		AttributeExpr: &design.AttributeExpr{
@@ -289,6 +347,14 @@ type objExtraT struct {
 	Extra time.Time
 }
 
+type objTimeT struct {
+	Time time.Time
+}
+
+type objTimePointerT struct {
+	Time *time.Time
+}
+
 type objRecursiveT struct {
 	Foo  string
 	Bar  int
@@ -30,6 +30,9 @@ type (
 		// Schemes contains the security schemes types used by the
 		// method.
 		Schemes []string
+		// Interceptors lists the interceptors used by the service
+		// methods.
+		Interceptors []*InterceptorData
 	}
 
 	// EndpointMethodData describes a single endpoint method.
@@ -68,7 +71,7 @@ const (
 
 // EndpointFile returns the endpoint file for the given service.
 func EndpointFile(genpkg string, service *design.ServiceExpr) *codegen.File {
-	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(service.Name), "endpoints.go")
+	path := filepath.Join(codegen.ServiceGendir(service), codegen.SnakeCase(service.Name), "endpoints.go")
 	svc := Services.Get(service.Name)
 	data := endpointData(service)
 	var (
@@ -79,9 +82,10 @@ func EndpointFile(genpkg string, service *design.ServiceExpr) *codegen.File {
 			[]*codegen.ImportSpec{
 				&codegen.ImportSpec{Path: "context"},
 				&codegen.ImportSpec{Path: "fmt"},
+				&codegen.ImportSpec{Path: "io"},
 				&codegen.ImportSpec{Name: "goa", Path: "goa.design/goa"},
 				&codegen.ImportSpec{Path: "goa.design/goa/security"},
-				&codegen.ImportSpec{Path: genpkg + "/" + codegen.SnakeCase(service.Name) + "/" + "views", Name: svc.ViewsPkg},
+				&codegen.ImportSpec{Path: codegen.ServiceGenpkg(genpkg, service) + "/" + codegen.SnakeCase(service.Name) + "/" + "views", Name: svc.ViewsPkg},
 			})
 		def := &codegen.SectionTemplate{
 			Name:   "endpoints-struct",
@@ -89,6 +93,13 @@ func EndpointFile(genpkg string, service *design.ServiceExpr) *codegen.File {
 			Data:   data,
 		}
 		sections = []*codegen.SectionTemplate{header, def}
+		if len(data.Interceptors) > 0 {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "endpoints-interceptors",
+				Source: serviceInterceptorsT,
+				Data:   data,
+			})
+		}
 		for _, m := range data.Methods {
 			if m.ServerStream != nil {
 				sections = append(sections, &codegen.SectionTemplate{
@@ -97,6 +108,20 @@ func EndpointFile(genpkg string, service *design.ServiceExpr) *codegen.File {
 					Data:   m,
 				})
 			}
+			if m.SkipRequestBodyEncodeDecode {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "request-data-struct",
+					Source: serviceRequestDataStructT,
+					Data:   m,
+				})
+			}
+			if m.SkipResponseBodyEncodeDecode {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "response-data-struct",
+					Source: serviceResponseDataStructT,
+					Data:   m,
+				})
+			}
 		}
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "endpoints-init",
@@ -163,11 +188,12 @@ func endpointData(service *design.ServiceExpr) *EndpointsData {
 		ClientInitArgs: strings.Join(names, ", "),
 		Methods:        methods,
 		Schemes:        schemes,
+		Interceptors:   svc.Interceptors,
 	}
 }
 
 func payloadVar(e *EndpointMethodData) string {
-	if e.ServerStream != nil {
+	if e.ServerStream != nil || e.SkipRequestBodyEncodeDecode {
 		return "ep.Payload"
 	}
 	return "p"
@@ -184,15 +210,29 @@ type {{ .VarName }} struct {
 
 // input: EndpointsData
 const serviceEndpointsInitT = `{{ printf "New%s wraps the methods of the %q service with endpoints." .VarName .Name | comment }}
-func New{{ .VarName }}(s {{ .ServiceVarName }}{{ range .Schemes }}, auth{{ . }}Fn security.Auth{{ . }}Func{{ end }}) *{{ .VarName }} {
+func New{{ .VarName }}(s {{ .ServiceVarName }}{{ range .Schemes }}, auth{{ . }}Fn security.Auth{{ . }}Func{{ end }}{{ if .Interceptors }}, i Interceptors{{ end }}) *{{ .VarName }} {
 	return &{{ .VarName }}{
 {{- range .Methods }}
+	{{- if .Interceptors }}
+		{{ .VarName }}: goa.Intercept({{ printf "%q" .Name }}, New{{ .VarName }}Endpoint(s{{ range .Schemes }}, auth{{ . }}Fn{{ end }}){{ range .Interceptors }}, i.{{ .VarName }}{{ end }}),
+	{{- else }}
 		{{ .VarName }}: New{{ .VarName }}Endpoint(s{{ range .Schemes }}, auth{{ . }}Fn{{ end }}),
+	{{- end }}
 {{- end }}
 	}
 }
 `
 
+// input: EndpointsData
+const serviceInterceptorsT = `{{ printf "Interceptors lists the interceptors declared in the design of the %q service. NewEndpoints wraps the endpoints with the interceptors listed in the design of the corresponding methods." .Name | comment }}
+type Interceptors interface {
+{{- range .Interceptors }}
+	{{ printf "%s implements the %q interceptor, it must call next to carry on with the request." .VarName .Name | comment }}
+	{{ .VarName }}(ctx context.Context, method string, req interface{}, next goa.Endpoint) (interface{}, error)
+{{- end }}
+}
+`
+
 // input: EndpointMethodData
 const serviceEndpointInputStructT = `{{ printf "%s is the input type of %q endpoint that holds the method payload and the server stream." .ServerStream.EndpointStruct .Name | comment }}
 type {{ .ServerStream.EndpointStruct }} struct {
@@ -205,12 +245,38 @@ type {{ .ServerStream.EndpointStruct }} struct {
 }
 `
 
+// input: EndpointMethodData
+const serviceRequestDataStructT = `{{ printf "%s is the input type of %q endpoint that holds the method payload and the request body reader." .RequestStruct .Name | comment }}
+type {{ .RequestStruct }} struct {
+{{- if .PayloadRef }}
+	{{ comment "Payload is the method payload." }}
+	Payload {{ .PayloadRef }}
+{{- end }}
+	{{ comment "Body streams the request body, it must be closed by the service method." }}
+	Body io.ReadCloser
+}
+`
+
+// input: EndpointMethodData
+const serviceResponseDataStructT = `{{ printf "%s is the output type of %q endpoint that holds the method result and the response body reader." .ResponseStruct .Name | comment }}
+type {{ .ResponseStruct }} struct {
+{{- if .ResultRef }}
+	{{ comment "Result is the method result." }}
+	Result {{ .ResultRef }}
+{{- end }}
+	{{ comment "Body streams the response body, it is closed once the response has been written." }}
+	Body io.ReadCloser
+}
+`
+
 // input: EndpointMethodData
 const serviceEndpointMethodT = `{{ printf "New%sEndpoint returns an endpoint function that calls the method %q of service %q." .VarName .Name .ServiceName | comment }}
 func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth{{ . }}Fn security.Auth{{ . }}Func{{ end }}) goa.Endpoint {
 	return func(ctx context.Context, req interface{}) (interface{}, error) {
 {{- if .ServerStream }}
 		ep := req.(*{{ .ServerStream.EndpointStruct }})
+{{- else if .SkipRequestBodyEncodeDecode }}
+		ep := req.(*{{ .RequestStruct }})
 {{- else if .PayloadRef }}
 		p := req.({{ .PayloadRef }})
 {{- end }}
@@ -269,6 +335,11 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				}
 				{{- end }}
 				ctx, err = auth{{ .Type }}Fn(ctx, {{ if $s.CredPointer }}token{{ else }}{{ $payload }}.{{ $s.CredField }}{{ end }}, &sc)
+				{{- if $r.Scopes }}
+				if err == nil {
+					err = security.CheckScopes(ctx, sc.RequiredScopes)
+				}
+				{{- end }}
 
 			{{- else if eq .Type "OAuth2" }}
 				sc := security.OAuth2Scheme{
@@ -301,6 +372,11 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 				}
 				{{- end }}
 				ctx, err = auth{{ .Type }}Fn(ctx, {{ if $s.CredPointer }}token{{ else }}{{ $payload }}.{{ $s.CredField }}{{ end }}, &sc)
+				{{- if $r.Scopes }}
+				if err == nil {
+					err = security.CheckScopes(ctx, sc.RequiredScopes)
+				}
+				{{- end }}
 
 			{{- end }}
 			{{- if ne $sidx 0 }}
@@ -322,22 +398,40 @@ func New{{ .VarName }}Endpoint(s {{ .ServiceVarName }}{{ range .Schemes }}, auth
 		if err != nil {
 			return nil, err
 		}
+		{{- if not .ViewedResult.ViewName }}
+		if v, ok := ctx.Value(goa.ViewKey).(string); ok {
+			view = v
+		}
+		{{- end }}
 		vres := {{ $.ViewedResult.Init.Name }}(res, {{ if .ViewedResult.ViewName }}{{ printf "%q" .ViewedResult.ViewName }}{{ else }}view{{ end }})
 		return vres, nil
+{{- else if .SkipResponseBodyEncodeDecode }}
+		{{ if .ResultRef }}res, {{ end }}body, err := s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }}{{ if .SkipRequestBodyEncodeDecode }}, ep.Body{{ end }})
+		if err != nil {
+			return nil, err
+		}
+		return &{{ .ResponseStruct }}{ {{ if .ResultRef }}Result: res, {{ end }}Body: body}, nil
 {{- else if .ResultRef }}
-		return s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
+		return s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }}{{ if .SkipRequestBodyEncodeDecode }}, ep.Body{{ end }})
 {{- else }}
-	return {{ if not .ResultRef }}nil, {{ end }}s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }})
+	return {{ if not .ResultRef }}nil, {{ end }}s.{{ .VarName }}(ctx{{ if .PayloadRef }}, {{ $payload }}{{ end }}{{ if .SkipRequestBodyEncodeDecode }}, ep.Body{{ end }})
 {{- end }}
 	}
 }
 `
 
-// input: EndpointMethodData
+// input: EndpointsData
 const serviceEndpointsUseT = `{{ printf "Use applies the given middleware to all the %q service endpoints." .Name | comment }}
 func (e *{{ .VarName }}) Use(m func(goa.Endpoint) goa.Endpoint) {
 {{- range .Methods }}
 	e.{{ .VarName }} = m(e.{{ .VarName }})
 {{- end }}
 }
+{{- range .Methods }}
+
+{{ printf "Use%s applies the given middleware to the %q endpoint of the %q service." .VarName .Name $.Name | comment }}
+func (e *{{ $.VarName }}) Use{{ .VarName }}(m func(goa.Endpoint) goa.Endpoint) {
+	e.{{ .VarName }} = m(e.{{ .VarName }})
+}
+{{- end }}
 `
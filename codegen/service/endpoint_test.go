@@ -24,6 +24,8 @@ func TestEndpoint(t *testing.T) {
 		{"with-result-multiple-views", testdata.WithResultMultipleViewsEndpointDSL, testdata.WithResultMultipleViewsEndpoint},
 		{"streaming-result", testdata.StreamingResultEndpointDSL, testdata.StreamingResultMethodEndpoint},
 		{"streaming-result-no-payload", testdata.StreamingResultNoPayloadEndpointDSL, testdata.StreamingResultNoPayloadMethodEndpoint},
+		{"skip-body-encode-decode", testdata.SkipBodyEncodeDecodeEndpointDSL, testdata.SkipBodyEncodeDecodeMethodEndpoint},
+		{"intercepted", testdata.InterceptedEndpointDSL, testdata.InterceptedEndpoint},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -0,0 +1,364 @@
+package service
+
+import (
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+)
+
+type (
+	// MocksData contains the data necessary to render the fake service
+	// client.
+	MocksData struct {
+		// Name is the service name.
+		Name string
+		// ClientVarName is the fake client struct name.
+		ClientVarName string
+		// Methods lists the fake client methods.
+		Methods []*MockMethodData
+	}
+
+	// MockMethodData describes a single fake client method.
+	MockMethodData struct {
+		// Name is the method name.
+		Name string
+		// VarName is the Go method name.
+		VarName string
+		// ClientVarName is the fake client struct name.
+		ClientVarName string
+		// CallStruct is the name of the struct recording the calls.
+		CallStruct string
+		// StubField is the name of the field holding the stub function.
+		StubField string
+		// CallsField is the name of the field holding the recorded
+		// calls.
+		CallsField string
+		// StubType is the signature of the stub function.
+		StubType string
+		// Params lists the method parameters.
+		Params string
+		// Args lists the method arguments given to the stub function.
+		Args string
+		// Results lists the method named results.
+		Results string
+		// PayloadRef is the qualified reference to the payload type if
+		// any.
+		PayloadRef string
+		// SkipRequestBodyEncodeDecode is true if the method accepts the
+		// request body reader.
+		SkipRequestBodyEncodeDecode bool
+	}
+
+	// ServiceMockData contains the data necessary to render the mock
+	// implementation of the service interface.
+	ServiceMockData struct {
+		// Name is the service name.
+		Name string
+		// VarName is the mock struct name.
+		VarName string
+		// ServiceVarName is the service interface name.
+		ServiceVarName string
+		// Methods lists the mock methods.
+		Methods []*ServiceMockMethodData
+	}
+
+	// ServiceMockMethodData describes a single mock service method.
+	ServiceMockMethodData struct {
+		// Name is the method name.
+		Name string
+		// VarName is the Go method name.
+		VarName string
+		// MockVarName is the mock struct name.
+		MockVarName string
+		// FuncType is the type of the function field implementing the
+		// method.
+		FuncType string
+		// Params lists the method parameters.
+		Params string
+		// Args lists the method arguments given to the function field.
+		Args string
+		// Results lists the method named results.
+		Results string
+	}
+)
+
+// MocksFile returns the file defining a fake implementation of the service
+// client that records calls and returns stubbed results for use in tests.
+func MocksFile(genpkg string, service *design.ServiceExpr) *codegen.File {
+	path := filepath.Join(codegen.ServiceGendir(service), codegen.SnakeCase(service.Name), "mocks", "client.go")
+	svc := Services.Get(service.Name)
+	data := mocksData(service)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" mocks", "mocks",
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "io"},
+				{Path: "sync"},
+				{Path: codegen.ServiceGenpkg(genpkg, service) + "/" + codegen.SnakeCase(service.Name), Name: svc.PkgName},
+			}),
+		{Name: "mock-client-struct", Source: mockClientT, Data: data},
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "mock-client-method",
+			Source: mockClientMethodT,
+			Data:   m,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// ServiceMockFile returns the file defining a mock implementation of the
+// service interface for use in tests.
+func ServiceMockFile(service *design.ServiceExpr) *codegen.File {
+	path := filepath.Join(codegen.ServiceGendir(service), codegen.SnakeCase(service.Name), "service_mock.go")
+	svc := Services.Get(service.Name)
+	data := serviceMockData(svc)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" service mock", svc.PkgName,
+			[]*codegen.ImportSpec{
+				{Path: "context"},
+				{Path: "io"},
+				{Path: "sync"},
+				{Path: "testing"},
+			}),
+		{Name: "service-mock-struct", Source: serviceMockT, Data: data},
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "service-mock-method",
+			Source: serviceMockMethodT,
+			Data:   m,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// serviceMockData builds the data needed to render the mock implementation of
+// the given service interface.
+func serviceMockData(svc *Data) *ServiceMockData {
+	vname := "Mock" + ServiceInterfaceName
+	methods := make([]*ServiceMockMethodData, len(svc.Methods))
+	for i, m := range svc.Methods {
+		var (
+			params  = []string{"ctx context.Context"}
+			args    = []string{"ctx"}
+			types   = []string{"context.Context"}
+			results []string
+		)
+		if m.PayloadRef != "" {
+			params = append(params, "p "+m.PayloadRef)
+			args = append(args, "p")
+			types = append(types, m.PayloadRef)
+		}
+		if m.ServerStream != nil {
+			params = append(params, "stream "+m.ServerStream.Interface)
+			args = append(args, "stream")
+			types = append(types, m.ServerStream.Interface)
+		} else {
+			if m.SkipRequestBodyEncodeDecode {
+				params = append(params, "req io.ReadCloser")
+				args = append(args, "req")
+				types = append(types, "io.ReadCloser")
+			}
+			if m.ResultRef != "" {
+				results = append(results, "res "+m.ResultRef)
+				if m.ViewedResult != nil && m.ViewedResult.ViewName == "" {
+					results = append(results, "view string")
+				}
+			}
+			if m.SkipResponseBodyEncodeDecode {
+				results = append(results, "body io.ReadCloser")
+			}
+		}
+		results = append(results, "err error")
+		res := strings.Join(results, ", ")
+		methods[i] = &ServiceMockMethodData{
+			Name:        m.Name,
+			VarName:     m.VarName,
+			MockVarName: vname,
+			FuncType:    "func(" + strings.Join(types, ", ") + ") (" + res + ")",
+			Params:      strings.Join(params, ", "),
+			Args:        strings.Join(args, ", "),
+			Results:     res,
+		}
+	}
+	return &ServiceMockData{Name: svc.Name, VarName: vname, ServiceVarName: ServiceInterfaceName, Methods: methods}
+}
+
+// mocksData builds the data needed to render the fake client of the given
+// service.
+func mocksData(service *design.ServiceExpr) *MocksData {
+	svc := Services.Get(service.Name)
+	methods := make([]*MockMethodData, len(svc.Methods))
+	for i, m := range svc.Methods {
+		var (
+			me      = service.Methods[i]
+			params  = []string{"ctx context.Context"}
+			args    = []string{"ctx"}
+			types   = []string{"context.Context"}
+			results []string
+			rtypes  []string
+			pref    string
+		)
+		if m.PayloadRef != "" {
+			pref = svc.Scope.GoFullTypeRef(me.Payload, svc.PkgName)
+			params = append(params, "p "+pref)
+			args = append(args, "p")
+			types = append(types, pref)
+		}
+		if m.SkipRequestBodyEncodeDecode {
+			params = append(params, "req io.ReadCloser")
+			args = append(args, "req")
+			types = append(types, "io.ReadCloser")
+		}
+		if m.ClientStream != nil {
+			results = append(results, "res "+svc.PkgName+"."+m.ClientStream.Interface)
+			rtypes = append(rtypes, svc.PkgName+"."+m.ClientStream.Interface)
+		} else if m.ResultRef != "" {
+			rref := svc.Scope.GoFullTypeRef(me.Result, svc.PkgName)
+			results = append(results, "res "+rref)
+			rtypes = append(rtypes, rref)
+		}
+		if m.SkipResponseBodyEncodeDecode {
+			results = append(results, "resp io.ReadCloser")
+			rtypes = append(rtypes, "io.ReadCloser")
+		}
+		results = append(results, "err error")
+		rtypes = append(rtypes, "error")
+		stub := "func(" + strings.Join(types, ", ") + ") "
+		if len(rtypes) == 1 {
+			stub += rtypes[0]
+		} else {
+			stub += "(" + strings.Join(rtypes, ", ") + ")"
+		}
+		methods[i] = &MockMethodData{
+			Name:                        m.Name,
+			VarName:                     m.VarName,
+			ClientVarName:               ClientStructName,
+			CallStruct:                  m.VarName + "Call",
+			StubField:                   codegen.Goify(m.VarName, false) + "Stub",
+			CallsField:                  codegen.Goify(m.VarName, false) + "Calls",
+			StubType:                    stub,
+			Params:                      strings.Join(params, ", "),
+			Args:                        strings.Join(args, ", "),
+			Results:                     strings.Join(results, ", "),
+			PayloadRef:                  pref,
+			SkipRequestBodyEncodeDecode: m.SkipRequestBodyEncodeDecode,
+		}
+	}
+	return &MocksData{Name: service.Name, ClientVarName: ClientStructName, Methods: methods}
+}
+
+// input: MocksData
+const mockClientT = `{{ printf "%s is a fake %q service client for use in tests. It implements the same methods as the service client: the calls are recorded and forwarded to the functions set with the Stub methods. Methods that have no stub return zero values." .ClientVarName .Name | comment }}
+type {{ .ClientVarName }} struct {
+	mu sync.Mutex
+{{- range .Methods }}
+	{{ .StubField }} {{ .StubType }}
+	{{ .CallsField }} []*{{ .CallStruct }}
+{{- end }}
+}
+{{ range .Methods }}
+{{ printf "%s records a call made to the %q method." .CallStruct .Name | comment }}
+type {{ .CallStruct }} struct {
+	// Ctx is the context given to the method.
+	Ctx context.Context
+	{{- if .PayloadRef }}
+	// Payload is the method payload.
+	Payload {{ .PayloadRef }}
+	{{- end }}
+	{{- if .SkipRequestBodyEncodeDecode }}
+	// Body is the request body reader.
+	Body io.ReadCloser
+	{{- end }}
+}
+{{ end }}
+{{ printf "New%s returns a fake %q service client with no stub." .ClientVarName .Name | comment }}
+func New{{ .ClientVarName }}() *{{ .ClientVarName }} {
+	return &{{ .ClientVarName }}{}
+}
+`
+
+// input: MockMethodData
+const mockClientMethodT = `
+{{ printf "%s records the call and invokes the function set with Stub%s." .VarName .VarName | comment }}
+func (c *{{ .ClientVarName }}) {{ .VarName }}({{ .Params }}) ({{ .Results }}) {
+	c.mu.Lock()
+	c.{{ .CallsField }} = append(c.{{ .CallsField }}, &{{ .CallStruct }}{Ctx: ctx{{ if .PayloadRef }}, Payload: p{{ end }}{{ if .SkipRequestBodyEncodeDecode }}, Body: req{{ end }}})
+	stub := c.{{ .StubField }}
+	c.mu.Unlock()
+	if stub == nil {
+		return
+	}
+	return stub({{ .Args }})
+}
+
+{{ printf "Stub%s sets the function invoked by %s." .VarName .VarName | comment }}
+func (c *{{ .ClientVarName }}) Stub{{ .VarName }}(f {{ .StubType }}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.{{ .StubField }} = f
+}
+
+{{ printf "%sCalls returns the calls made to %s in order." .VarName .VarName | comment }}
+func (c *{{ .ClientVarName }}) {{ .VarName }}Calls() []*{{ .CallStruct }} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*{{ .CallStruct }}(nil), c.{{ .CallsField }}...)
+}
+`
+
+// input: ServiceMockData
+const serviceMockT = `{{ printf "%s is a mock implementation of the %q service %s interface for use in tests. Assign the Func fields to define the behavior of the methods, methods whose field is nil return zero values. The calls are counted and can be checked with AssertCalls." .VarName .Name .ServiceVarName | comment }}
+type {{ .VarName }} struct {
+{{- range .Methods }}
+	{{ printf "%sFunc implements %s." .VarName .VarName | comment }}
+	{{ .VarName }}Func {{ .FuncType }}
+{{- end }}
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+var _ {{ .ServiceVarName }} = (*{{ .VarName }})(nil)
+
+{{ printf "Calls returns the number of calls made to the method with the given name as defined in the design." | comment }}
+func (m *{{ .VarName }}) Calls(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[method]
+}
+
+{{ printf "AssertCalls reports an error to t if the method with the given name as defined in the design was not called exactly n times." | comment }}
+func (m *{{ .VarName }}) AssertCalls(t testing.TB, method string, n int) {
+	t.Helper()
+	if c := m.Calls(method); c != n {
+		t.Errorf("got %d calls to %q, expected %d", c, method, n)
+	}
+}
+
+// record records a call made to the method with the given name.
+func (m *{{ .VarName }}) record(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[method]++
+}
+`
+
+// input: ServiceMockMethodData
+const serviceMockMethodT = `
+{{ printf "%s records the call and invokes %sFunc." .VarName .VarName | comment }}
+func (m *{{ .MockVarName }}) {{ .VarName }}({{ .Params }}) ({{ .Results }}) {
+	m.record({{ printf "%q" .Name }})
+	if m.{{ .VarName }}Func == nil {
+		return
+	}
+	return m.{{ .VarName }}Func({{ .Args }})
+}
+`
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/service/testdata"
+	"goa.design/goa/design"
+)
+
+func TestMocks(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"single", testdata.SingleEndpointDSL, testdata.SingleMethodMocks},
+		{"no-payload", testdata.NoPayloadEndpointDSL, testdata.NoPayloadMethodMocks},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			if len(design.Root.Services) != 1 {
+				t.Fatalf("got %d services, expected 1", len(design.Root.Services))
+			}
+			fs := MocksFile("goa.design/goa/example", design.Root.Services[0])
+			if fs == nil {
+				t.Fatalf("got nil file, expected not nil")
+			}
+			buf := new(bytes.Buffer)
+			for _, s := range fs.SectionTemplates[1:] {
+				if err := s.Write(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			code := buf.String()
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs expected\n:%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestServiceMock(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"single", testdata.SingleMethodDSL, testdata.SingleMethodMock},
+		{"streaming-result", testdata.StreamingResultMethodDSL, testdata.StreamingResultMethodMock},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSLWithFunc(t, c.DSL, func() {
+				design.Root.Types = []design.UserType{testdata.APayload, testdata.BPayload, testdata.AResult, testdata.BResult, testdata.ParentType, testdata.ChildType}
+			})
+			if len(design.Root.Services) != 1 {
+				t.Fatalf("got %d services, expected 1", len(design.Root.Services))
+			}
+			fs := ServiceMockFile(design.Root.Services[0])
+			if fs == nil {
+				t.Fatalf("got nil file, expected not nil")
+			}
+			buf := new(bytes.Buffer)
+			for _, s := range fs.SectionTemplates[1:] {
+				if err := s.Write(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			code := buf.String()
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs expected\n:%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+)
+
+const (
+	// ProxyStructName is the name of the generated struct implementing the
+	// proxied methods. The name is suffixed with "Service" if the service
+	// package defines a type with the same name.
+	ProxyStructName = "Proxy"
+)
+
+type (
+	// ProxyData contains the data necessary to render the struct
+	// implementing the proxied methods of a service.
+	ProxyData struct {
+		// Name is the service name.
+		Name string
+		// VarName is the proxy struct name.
+		VarName string
+		// Clients lists the clients of the remote services.
+		Clients []*ProxyClientData
+		// Methods lists the proxied methods.
+		Methods []*ProxyMethodData
+	}
+
+	// ProxyClientData describes the client of a remote service.
+	ProxyClientData struct {
+		// ServiceName is the name of the remote service.
+		ServiceName string
+		// ImportPath is the import path of the remote service package.
+		ImportPath string
+		// PkgName is the name used to import the remote service package.
+		PkgName string
+		// VarName is the name of the proxy struct field holding the
+		// client and of the corresponding constructor argument.
+		VarName string
+	}
+
+	// ProxyMethodData describes a single proxied method.
+	ProxyMethodData struct {
+		// Name is the method name.
+		Name string
+		// VarName is the Go method name.
+		VarName string
+		// ProxyVarName is the proxy struct name.
+		ProxyVarName string
+		// Params lists the method parameters.
+		Params string
+		// Results lists the method named results.
+		Results string
+		// Client is the client of the remote service.
+		Client *ProxyClientData
+		// RemoteName is the name of the remote method.
+		RemoteName string
+		// RemoteVarName is the name of the remote client method.
+		RemoteVarName string
+		// HasPayload is true if the method has a payload.
+		HasPayload bool
+		// HasResult is true if the method has a result.
+		HasResult bool
+		// View is the name of the view returned by the method if the
+		// method result is a result type whose view is not set in the
+		// design.
+		View string
+	}
+)
+
+// ProxyFile returns the file defining the struct that implements the methods
+// of the service that forward the requests to the services of other designs,
+// nil if the service does not proxy any method.
+func ProxyFile(service *design.ServiceExpr) *codegen.File {
+	data := proxyData(service)
+	if data == nil {
+		return nil
+	}
+	svc := Services.Get(service.Name)
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "goa.design/goa", Name: "goa"},
+	}
+	for _, c := range data.Clients {
+		specs = append(specs, &codegen.ImportSpec{Path: c.ImportPath, Name: c.PkgName})
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(service.Name+" service proxy", svc.PkgName, specs),
+		{Name: "proxy-struct", Source: proxyT, Data: data},
+	}
+	for _, m := range data.Methods {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "proxy-method",
+			Source: proxyMethodT,
+			Data:   m,
+		})
+	}
+	path := filepath.Join(codegen.ServiceGendir(service), codegen.SnakeCase(service.Name), "proxy.go")
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// proxyData builds the data needed to render the proxy of the given service,
+// nil if the service does not proxy any method.
+func proxyData(service *design.ServiceExpr) *ProxyData {
+	svc := Services.Get(service.Name)
+	var (
+		clients = make(map[string]*ProxyClientData)
+		names   = map[string]struct{}{svc.PkgName: {}}
+		data    = &ProxyData{Name: service.Name, VarName: svc.Scope.Unique(ProxyStructName, "Service")}
+	)
+	for i, m := range svc.Methods {
+		px := service.Methods[i].Proxy
+		if px == nil {
+			continue
+		}
+		ipath := path.Join(px.Package, codegen.SnakeCase(px.Service))
+		c, ok := clients[ipath]
+		if !ok {
+			pkg := strings.ToLower(codegen.Goify(px.Service, false))
+			if _, ok := names[pkg]; ok {
+				pkg += "remote"
+				for j := 2; ; j++ {
+					if _, ok := names[pkg]; !ok {
+						break
+					}
+					pkg = fmt.Sprintf("%sremote%d", strings.ToLower(codegen.Goify(px.Service, false)), j)
+				}
+			}
+			names[pkg] = struct{}{}
+			c = &ProxyClientData{
+				ServiceName: px.Service,
+				ImportPath:  ipath,
+				PkgName:     pkg,
+				VarName:     pkg + ClientStructName,
+			}
+			clients[ipath] = c
+			data.Clients = append(data.Clients, c)
+		}
+		var (
+			params  = []string{"ctx context.Context"}
+			results []string
+			view    string
+		)
+		if m.PayloadRef != "" {
+			params = append(params, "p "+m.PayloadRef)
+		}
+		if m.ResultRef != "" {
+			results = append(results, "res "+m.ResultRef)
+			if m.ViewedResult != nil && m.ViewedResult.ViewName == "" {
+				results = append(results, "view string")
+				view = design.DefaultView
+			}
+		}
+		results = append(results, "err error")
+		data.Methods = append(data.Methods, &ProxyMethodData{
+			Name:          m.Name,
+			VarName:       m.VarName,
+			ProxyVarName:  data.VarName,
+			Params:        strings.Join(params, ", "),
+			Results:       strings.Join(results, ", "),
+			Client:        c,
+			RemoteName:    px.RemoteMethod,
+			RemoteVarName: codegen.Goify(px.RemoteMethod, true),
+			HasPayload:    m.PayloadRef != "",
+			HasResult:     m.ResultRef != "",
+			View:          view,
+		})
+	}
+	if len(data.Methods) == 0 {
+		return nil
+	}
+	return data
+}
+
+// input: ProxyData
+const proxyT = `{{ printf "%s implements the methods of the %q service that forward the requests to the services of other designs. Embed %s in the service implementation to provide these methods." .VarName .Name .VarName | comment }}
+type {{ .VarName }} struct {
+{{- range .Clients }}
+	{{ .VarName }} *{{ .PkgName }}.Client
+{{- end }}
+}
+
+{{ printf "New%s returns a %s that forwards the requests using the given remote service clients." .VarName .VarName | comment }}
+func New{{ .VarName }}({{ range $i, $c := .Clients }}{{ if $i }}, {{ end }}{{ $c.VarName }} *{{ $c.PkgName }}.Client{{ end }}) *{{ .VarName }} {
+	return &{{ .VarName }}{
+{{- range .Clients }}
+		{{ .VarName }}: {{ .VarName }},
+{{- end }}
+	}
+}
+`
+
+// input: ProxyMethodData
+const proxyMethodT = `
+{{ printf "%s forwards the requests to the %q method of the %q service. The compatibility of the payload and result types with the remote method is not checked at compile time." .VarName .RemoteName .Client.ServiceName | comment }}
+func (px *{{ .ProxyVarName }}) {{ .VarName }}({{ .Params }}) ({{ .Results }}) {
+	err = goa.Forward(ctx, px.{{ .Client.VarName }}.{{ .RemoteVarName }}, {{ if .HasPayload }}p{{ else }}nil{{ end }}, {{ if .HasResult }}&res{{ else }}nil{{ end }})
+{{- if .View }}
+	view = {{ printf "%q" .View }}
+{{- end }}
+	return
+}
+`
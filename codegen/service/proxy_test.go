@@ -0,0 +1,51 @@
+package service
+
+import (
+	"bytes"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/service/testdata"
+	"goa.design/goa/design"
+)
+
+func TestProxy(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"proxy", testdata.ProxyDSL, testdata.ProxyCode},
+		{"same-name", testdata.ProxySameNameDSL, testdata.ProxySameNameCode},
+		{"type-name", testdata.ProxyTypeNameDSL, testdata.ProxyTypeNameCode},
+		{"no-proxy", testdata.NoProxyDSL, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			codegen.RunDSL(t, c.DSL)
+			if len(design.Root.Services) != 1 {
+				t.Fatalf("got %d services, expected 1", len(design.Root.Services))
+			}
+			fs := ProxyFile(design.Root.Services[0])
+			if c.Code == "" {
+				if fs != nil {
+					t.Fatalf("got file %s, expected nil", fs.Path)
+				}
+				return
+			}
+			if fs == nil {
+				t.Fatalf("got nil file, expected not nil")
+			}
+			buf := new(bytes.Buffer)
+			for _, s := range fs.SectionTemplates[1:] {
+				if err := s.Write(buf); err != nil {
+					t.Fatal(err)
+				}
+			}
+			code := buf.String()
+			if code != c.Code {
+				t.Errorf("%s: got\n%s\ngot vs expected\n:%s", c.Name, code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
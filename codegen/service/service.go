@@ -10,15 +10,17 @@ import (
 
 // File returns the service file for the given service.
 func File(genpkg string, service *design.ServiceExpr) *codegen.File {
-	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(service.Name), "service.go")
+	path := filepath.Join(codegen.ServiceGendir(service), codegen.SnakeCase(service.Name), "service.go")
 	svc := Services.Get(service.Name)
 	header := codegen.Header(
 		service.Name+" service",
 		svc.PkgName,
 		[]*codegen.ImportSpec{
 			{Path: "context"},
+			{Path: "fmt"},
+			{Path: "io"},
 			{Path: "goa.design/goa"},
-			{Path: genpkg + "/" + codegen.SnakeCase(service.Name) + "/" + "views", Name: svc.ViewsPkg},
+			{Path: codegen.ServiceGenpkg(genpkg, service) + "/" + codegen.SnakeCase(service.Name) + "/" + "views", Name: svc.ViewsPkg},
 		})
 	def := &codegen.SectionTemplate{
 		Name:   "service",
@@ -65,6 +67,14 @@ func File(genpkg string, service *design.ServiceExpr) *codegen.File {
 		}
 	}
 
+	for _, et := range svc.EnumTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "service-enum-type",
+			Source: enumTypeT,
+			Data:   et,
+		})
+	}
+
 	var errorTypes []*UserTypeData
 	for _, et := range svc.ErrorTypes {
 		if et.Type == design.ErrorResult {
@@ -111,6 +121,13 @@ func File(genpkg string, service *design.ServiceExpr) *codegen.File {
 			Source: typeInitT,
 			Data:   t.Init,
 		})
+		if t.MixedInit != nil {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "service-result-type-to-mixed-viewed-result-type",
+				Source: typeInitT,
+				Data:   t.MixedInit,
+			})
+		}
 	}
 	var projh []*codegen.TransformFunctionData
 	for _, t := range svc.ProjectedTypes {
@@ -172,6 +189,10 @@ const serviceT = `
 type Service interface {
 {{- range .Methods }}
 	{{ comment .Description }}
+	{{- if .Deprecated }}
+	//
+	{{ printf "Deprecated: %s" .Deprecated | comment }}
+	{{- end }}
 	{{- if .ViewedResult }}
 		{{- if not .ViewedResult.ViewName }}
 		{{ comment "The \"view\" return value must have one of the following views" }}
@@ -187,7 +208,7 @@ type Service interface {
 	{{- if .ServerStream }}
 	{{ .VarName }}(context.Context{{ if .Payload }}, {{ .PayloadRef }}{{ end }}, {{ .ServerStream.Interface }}) (err error)
 	{{- else }}
-	{{ .VarName }}(context.Context{{ if .Payload }}, {{ .PayloadRef }}{{ end }}) ({{ if .Result }}res {{ .ResultRef }}, {{ if .ViewedResult }}{{ if not .ViewedResult.ViewName }}view string, {{ end }}{{ end }}{{ end }}err error)
+	{{ .VarName }}(context.Context{{ if .Payload }}, {{ .PayloadRef }}{{ end }}{{ if .SkipRequestBodyEncodeDecode }}, io.ReadCloser{{ end }}) ({{ if .Result }}res {{ .ResultRef }}, {{ if .ViewedResult }}{{ if not .ViewedResult.ViewName }}view string, {{ end }}{{ end }}{{ end }}{{ if .SkipResponseBodyEncodeDecode }}body io.ReadCloser, {{ end }}err error)
 	{{- end }}
 {{- end }}
 }
@@ -241,6 +262,38 @@ const userTypeT = `{{ comment .Description }}
 type {{ .VarName }} {{ .Def }}
 `
 
+// input: EnumTypeData
+const enumTypeT = `{{ comment .Description }}
+type {{ .Name }} {{ .NativeType }}
+
+const (
+{{- range .Values }}
+	{{ printf "%s is the %s value %q." .Name $.Name .String | comment }}
+	{{ .Name }} {{ $.Name }} = {{ .Value }}
+{{- end }}
+)
+
+{{ printf "String returns the string representation of the %s value." .Name | comment }}
+func (v {{ .Name }}) String() string {
+{{- if eq .NativeType "string" }}
+	return string(v)
+{{- else }}
+	return fmt.Sprint({{ .NativeType }}(v))
+{{- end }}
+}
+
+{{ printf "Parse%s returns the %s value corresponding to s or an error if s is not one of the enum values." .Name .Name | comment }}
+func Parse{{ .Name }}(s string) ({{ .Name }}, error) {
+	switch s {
+{{- range .Values }}
+	case {{ printf "%q" .String }}:
+		return {{ .Name }}, nil
+{{- end }}
+	}
+	return {{ .Zero }}, fmt.Errorf("invalid {{ .Name }} value %q", s)
+}
+`
+
 const errorT = `// Error returns an error description.
 func (e {{ .Ref }}) Error() string {
 	return {{ printf "%q" .Description }}
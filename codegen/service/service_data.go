@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"sync"
 	"text/template"
 
 	"goa.design/goa/codegen"
@@ -12,7 +13,7 @@ import (
 
 // Services holds the data computed from the design needed to generate the code
 // of the services.
-var Services = make(ServicesData)
+var Services = NewServicesData()
 
 var (
 	// initTypeTmpl is the template used to render the code that initializes a
@@ -21,11 +22,34 @@ var (
 	// validateTypeCodeTmpl is the template used to render the code to
 	// validate a projected type or a viewed result type.
 	validateTypeCodeTmpl = template.Must(template.New("validateType").Funcs(template.FuncMap{"goify": codegen.Goify}).Parse(validateTypeT))
+	// initMixedCodeTmpl is the template used to render the code that
+	// initializes a viewed result type collection using a view per element.
+	initMixedCodeTmpl = template.Must(template.New("initMixedCode").Funcs(template.FuncMap{"goify": codegen.Goify}).Parse(initMixedCodeT))
+	// validateMixedCodeTmpl is the template used to render the code to
+	// validate a projected collection using a view per element.
+	validateMixedCodeTmpl = template.Must(template.New("validateMixed").Funcs(template.FuncMap{"goify": codegen.Goify}).Parse(validateMixedCodeT))
 )
 
 type (
 	// ServicesData encapsulates the data computed from the service designs.
-	ServicesData map[string]*Data
+	// ServicesData is safe for concurrent use, the data of each service is
+	// computed once.
+	ServicesData struct {
+		// mu protects services.
+		mu sync.Mutex
+		// services lists the analyzed services indexed by name.
+		services map[string]*analyzedService
+	}
+
+	// analyzedService records the data computed for a service.
+	analyzedService struct {
+		// once makes sure the service is analyzed once.
+		once sync.Once
+		// expr is the service expression.
+		expr *design.ServiceExpr
+		// data is the data computed from expr.
+		data *Data
+	}
 
 	// Data contains the data used to render the code related to a
 	// single service.
@@ -49,6 +73,9 @@ type (
 		// Schemes is the list of security schemes required by the
 		// service methods.
 		Schemes []*SchemeData
+		// Interceptors lists the interceptors used by the service
+		// methods.
+		Interceptors []*InterceptorData
 		// UserTypes lists the type definitions that the service
 		// depends on.
 		UserTypes []*UserTypeData
@@ -63,8 +90,20 @@ type (
 		ProjectedTypes []*ProjectedTypeData
 		// ViewedResultTypes lists all the viewed method result types.
 		ViewedResultTypes []*ViewedResultTypeData
+		// EnumTypes lists the named Go types generated for the
+		// attributes of the service types that define the "struct:enum"
+		// metadata.
+		EnumTypes []*EnumTypeData
 		// Scope initialized with all the service types.
 		Scope *codegen.NameScope
+		// Random is the generator used to build the examples of the
+		// service. It is seeded like the API generator and is not
+		// shared with the other services so that the examples do not
+		// depend on the order in which the services are analyzed. As a
+		// consequence the examples of a service differ from the ones
+		// produced when all the services shared the API generator
+		// unless it is the first service of the design.
+		Random *design.Random
 	}
 
 	// ErrorInitData describes an error returned by a service method of type
@@ -94,6 +133,9 @@ type (
 		Name string
 		// Description is the method description.
 		Description string
+		// Deprecated is the reason given to the Deprecated DSL if the
+		// method is deprecated, the empty string otherwise.
+		Deprecated string
 		// VarName is the Go method name.
 		VarName string
 		// Payload is the name of the payload type if any,
@@ -133,6 +175,91 @@ type (
 		// ClientStream indicates that the service method receives a result
 		// stream or sends a payload result or both.
 		ClientStream *StreamData
+		// SkipRequestBodyEncodeDecode is true if the method receives the
+		// raw request body reader in addition to the payload.
+		SkipRequestBodyEncodeDecode bool
+		// SkipResponseBodyEncodeDecode is true if the method returns a
+		// reader for the raw response body in addition to the result.
+		SkipResponseBodyEncodeDecode bool
+		// RequestStruct is the name of the endpoint input struct that
+		// holds the payload and the request body reader. It is set only
+		// if SkipRequestBodyEncodeDecode is true.
+		RequestStruct string
+		// ResponseStruct is the name of the endpoint output struct that
+		// holds the result and the response body reader. It is set only
+		// if SkipResponseBodyEncodeDecode is true.
+		ResponseStruct string
+		// Timeout is the Go expression for the maximum duration of the
+		// requests made to the method, e.g. "30 * time.Second". It is
+		// empty if the method does not define a timeout.
+		Timeout string
+		// Pagination contains the data needed to render the client
+		// iterator if the method results are paginated.
+		Pagination *PaginationData
+		// Interceptors lists the interceptors invoked prior to the
+		// method in order.
+		Interceptors []*InterceptorData
+		// RateLimit describes the maximum rate of requests accepted by
+		// the method if any.
+		RateLimit *RateLimitData
+	}
+
+	// RateLimitData describes the rate limit of a method.
+	RateLimitData struct {
+		// Requests is the maximum number of requests accepted per
+		// period.
+		Requests int
+		// Per is the Go expression for the duration of the period,
+		// e.g. "time.Minute".
+		Per string
+	}
+
+	// InterceptorData describes an interceptor declared in the design.
+	InterceptorData struct {
+		// Name is the interceptor name as defined in the design.
+		Name string
+		// VarName is the name of the corresponding Interceptors
+		// interface method.
+		VarName string
+	}
+
+	// PaginationData contains the data needed to render the iterator over
+	// the items of the pages returned by a paginated method.
+	PaginationData struct {
+		// IterMethod is the name of the client method that returns the
+		// iterator.
+		IterMethod string
+		// IteratorStruct is the name of the iterator struct.
+		IteratorStruct string
+		// ItemRef is the reference to the type of the page items.
+		ItemRef string
+		// ItemsField is the name of the result field listing the page
+		// items.
+		ItemsField string
+		// CursorField is the name of the payload field holding the
+		// cursor if pages are identified with cursors.
+		CursorField string
+		// CursorPointer is true if the payload cursor field is a
+		// pointer.
+		CursorPointer bool
+		// NextCursorField is the name of the result field holding the
+		// cursor of the next page.
+		NextCursorField string
+		// NextCursorPointer is true if the result next cursor field is
+		// a pointer.
+		NextCursorPointer bool
+		// OffsetField is the name of the payload field holding the
+		// offset if pages are identified with offsets.
+		OffsetField string
+		// OffsetPointer is true if the payload offset field is a
+		// pointer.
+		OffsetPointer bool
+		// LimitField is the name of the payload field holding the
+		// maximum number of items in a page if any.
+		LimitField string
+		// LimitPointer is true if the payload limit field is a
+		// pointer.
+		LimitPointer bool
 	}
 
 	// StreamData is the data used to generate client and server interfaces that
@@ -183,6 +310,32 @@ type (
 		Type design.UserType
 	}
 
+	// EnumTypeData contains the data describing the named Go type generated
+	// for an attribute that defines enum values and the "struct:enum"
+	// metadata.
+	EnumTypeData struct {
+		// Name is the Go type name.
+		Name string
+		// Description is the type human description.
+		Description string
+		// NativeType is the name of the underlying Go type.
+		NativeType string
+		// Zero is the Go code for the type zero value.
+		Zero string
+		// Values lists the enum values.
+		Values []*EnumValueData
+	}
+
+	// EnumValueData contains the data describing a single enum value.
+	EnumValueData struct {
+		// Name is the name of the Go constant.
+		Name string
+		// Value is the Go code for the constant value.
+		Value string
+		// String is the string representation of the value.
+		String string
+	}
+
 	// SchemeData describes a single security scheme.
 	SchemeData struct {
 		// Kind is the type of scheme, one of "Basic", "APIKey", "JWT"
@@ -253,6 +406,10 @@ type (
 		// ResultInit is the constructor code to initialize a result type
 		// from the viewed result type.
 		ResultInit *InitData
+		// MixedInit is the constructor code to initialize a viewed result
+		// type collection rendering each element with its own view. It is
+		// set only for collections of result types with multiple views.
+		MixedInit *InitData
 		// FullName is the fully qualified name of the viewed result type.
 		FullName string
 		// FullRef is the complete reference to the viewed result type
@@ -288,6 +445,10 @@ type (
 		// function for each view is generated. For user types, only one validation
 		// function is generated.
 		Validations []*ValidateData
+		// MixedValidation is the validation function to run on a projected
+		// collection whose elements may use different views. It is set only
+		// for collections of result types with multiple views.
+		MixedValidation *ValidateData
 		// Projections contains the code to create a projected type based on
 		// views. If the projected type corresponds to a result type, then a
 		// function for each view is generated.
@@ -338,18 +499,36 @@ type (
 	}
 )
 
+// NewServicesData returns an empty ServicesData.
+func NewServicesData() *ServicesData {
+	return &ServicesData{services: make(map[string]*analyzedService)}
+}
+
 // Get retrieves the data for the service with the given name computing it if
 // needed. It returns nil if there is no service with the given name.
-func (d ServicesData) Get(name string) *Data {
-	if data, ok := d[name]; ok {
-		return data
-	}
-	service := design.Root.Service(name)
-	if service == nil {
-		return nil
+func (d *ServicesData) Get(name string) *Data {
+	d.mu.Lock()
+	as, ok := d.services[name]
+	if !ok {
+		service := design.Root.Service(name)
+		if service == nil {
+			d.mu.Unlock()
+			return nil
+		}
+		as = &analyzedService{expr: service}
+		d.services[name] = as
 	}
-	d[name] = d.analyze(service)
-	return d[name]
+	d.mu.Unlock()
+	as.once.Do(func() { as.data = d.analyze(as.expr) })
+	return as.data
+}
+
+// Analyze computes the data of the given services concurrently so that
+// subsequent calls to Get do not need to.
+func (d *ServicesData) Analyze(services []*design.ServiceExpr) {
+	codegen.RunParallel(len(services), func(i int) {
+		d.Get(services[i].Name)
+	})
 }
 
 // Method returns the service method data for the method with the given name,
@@ -365,9 +544,10 @@ func (s *Data) Method(name string) *MethodData {
 
 // analyze creates the data necessary to render the code of the given service.
 // It records the user types needed by the service definition in userTypes.
-func (d ServicesData) analyze(service *design.ServiceExpr) *Data {
+func (d *ServicesData) analyze(service *design.ServiceExpr) *Data {
 	var (
 		scope      *codegen.NameScope
+		random     *design.Random
 		pkgName    string
 		viewspkg   string
 		types      []*UserTypeData
@@ -382,6 +562,7 @@ func (d ServicesData) analyze(service *design.ServiceExpr) *Data {
 	)
 	{
 		scope = codegen.NewNameScope()
+		random = design.NewRandom(design.Root.API.Name)
 		pkgName = scope.HashedUnique(service, strings.ToLower(codegen.Goify(service.Name, false)), "svc")
 		viewspkg = pkgName + "views"
 		seen = make(map[string]struct{})
@@ -468,13 +649,15 @@ func (d ServicesData) analyze(service *design.ServiceExpr) *Data {
 	}
 
 	var (
-		methods []*MethodData
-		schemes []*SchemeData
+		methods      []*MethodData
+		schemes      []*SchemeData
+		interceptors []*InterceptorData
 	)
 	{
 		methods = make([]*MethodData, len(service.Methods))
+		seenInterceptors := make(map[string]struct{})
 		for i, e := range service.Methods {
-			m := buildMethodData(e, pkgName, scope)
+			m := buildMethodData(e, pkgName, scope, random)
 			if rt, ok := e.Result.Type.(*design.ResultTypeExpr); ok {
 				if vrt, ok := seenViewed[m.Result]; ok {
 					m.ViewedResult = vrt
@@ -501,6 +684,36 @@ func (d ServicesData) analyze(service *design.ServiceExpr) *Data {
 					}
 				}
 			}
+			for _, ic := range m.Interceptors {
+				if _, ok := seenInterceptors[ic.Name]; !ok {
+					seenInterceptors[ic.Name] = struct{}{}
+					interceptors = append(interceptors, ic)
+				}
+			}
+		}
+	}
+
+	var (
+		enums []*EnumTypeData
+	)
+	{
+		seenEnums := make(map[string]struct{})
+		seenTypes := make(map[string]struct{})
+		collect := func(att *design.AttributeExpr) {
+			enums = append(enums, collectEnumTypes(att, seenEnums, seenTypes)...)
+		}
+		for _, m := range service.Methods {
+			collect(m.Payload)
+			collect(m.Result)
+			for _, er := range m.Errors {
+				collect(er.AttributeExpr)
+			}
+		}
+		for _, er := range service.Errors {
+			collect(er.AttributeExpr)
+		}
+		for _, t := range types {
+			collect(&design.AttributeExpr{Type: t.Type})
 		}
 	}
 
@@ -523,14 +736,16 @@ func (d ServicesData) analyze(service *design.ServiceExpr) *Data {
 		ViewsPkg:          viewspkg,
 		Methods:           methods,
 		Schemes:           schemes,
+		Interceptors:      interceptors,
 		UserTypes:         types,
 		ErrorTypes:        errTypes,
 		ErrorInits:        errorInits,
 		ProjectedTypes:    projTypes,
 		ViewedResultTypes: viewedRTs,
+		EnumTypes:         enums,
 		Scope:             scope,
+		Random:            random,
 	}
-	d[service.Name] = data
 
 	return data
 }
@@ -570,6 +785,68 @@ func collectTypes(at *design.AttributeExpr, seen map[string]struct{}, scope *cod
 	return
 }
 
+// collectEnumTypes recurses through the attribute to gather the attributes
+// that define the "struct:enum" metadata and returns the corresponding enum
+// types. seen records the names of the enum types already collected and
+// seenTypes the user types already traversed.
+func collectEnumTypes(at *design.AttributeExpr, seen, seenTypes map[string]struct{}) (data []*EnumTypeData) {
+	if at == nil || at.Type == design.Empty {
+		return
+	}
+	collect := func(at *design.AttributeExpr) []*EnumTypeData { return collectEnumTypes(at, seen, seenTypes) }
+	switch dt := at.Type.(type) {
+	case design.UserType:
+		if _, ok := seenTypes[dt.ID()]; ok {
+			return nil
+		}
+		seenTypes[dt.ID()] = struct{}{}
+		data = append(data, collect(dt.Attribute())...)
+	case *design.Object:
+		for _, nat := range *dt {
+			name := codegen.GoEnumTypeName(nat.Attribute, nat.Name)
+			if name == "" {
+				data = append(data, collect(nat.Attribute)...)
+				continue
+			}
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			data = append(data, buildEnumTypeData(name, nat.Name, nat.Attribute))
+		}
+	case *design.Array:
+		data = append(data, collect(dt.ElemType)...)
+	case *design.Map:
+		data = append(data, collect(dt.KeyType)...)
+		data = append(data, collect(dt.ElemType)...)
+	}
+	return
+}
+
+// buildEnumTypeData builds the data needed to render the enum type with the
+// given name generated for the attribute att.
+func buildEnumTypeData(name, attName string, att *design.AttributeExpr) *EnumTypeData {
+	zero := "0"
+	if att.Type.Kind() == design.StringKind {
+		zero = `""`
+	}
+	values := make([]*EnumValueData, len(att.Validation.Values))
+	for i, v := range att.Validation.Values {
+		values[i] = &EnumValueData{
+			Name:   codegen.GoEnumValueName(name, v),
+			Value:  fmt.Sprintf("%#v", v),
+			String: fmt.Sprint(v),
+		}
+	}
+	return &EnumTypeData{
+		Name:        name,
+		Description: fmt.Sprintf("%s enumerates the values of the %q attribute.", name, attName),
+		NativeType:  codegen.GoNativeTypeName(att.Type),
+		Zero:        zero,
+		Values:      values,
+	}
+}
+
 // buildErrorInitData creates the data needed to generate code around endpoint error return values.
 func buildErrorInitData(er *design.ErrorExpr, scope *codegen.NameScope) *ErrorInitData {
 	_, temporary := er.AttributeExpr.Metadata["goa:error:temporary"]
@@ -589,7 +866,7 @@ func buildErrorInitData(er *design.ErrorExpr, scope *codegen.NameScope) *ErrorIn
 
 // buildMethodData creates the data needed to render the given endpoint. It
 // records the user types needed by the service definition in userTypes.
-func buildMethodData(m *design.MethodExpr, svcPkgName string, scope *codegen.NameScope) *MethodData {
+func buildMethodData(m *design.MethodExpr, svcPkgName string, scope *codegen.NameScope, random *design.Random) *MethodData {
 	var (
 		vname       string
 		desc        string
@@ -608,6 +885,10 @@ func buildMethodData(m *design.MethodExpr, svcPkgName string, scope *codegen.Nam
 		schemes     []string
 		svrStream   *StreamData
 		cliStream   *StreamData
+		skipReq     bool
+		skipResp    bool
+		reqStruct   string
+		respStruct  string
 	)
 	vname = codegen.Goify(m.Name, true)
 	desc = m.Description
@@ -625,7 +906,7 @@ func buildMethodData(m *design.MethodExpr, svcPkgName string, scope *codegen.Nam
 			payloadDesc = fmt.Sprintf("%s is the payload type of the %s service %s method.",
 				payloadName, m.Service.Name, m.Name)
 		}
-		payloadEx = m.Payload.Example(design.Root.API.Random())
+		payloadEx = m.Payload.Example(random)
 	}
 	if m.Result.Type != design.Empty {
 		rname = scope.GoTypeName(m.Result)
@@ -638,7 +919,7 @@ func buildMethodData(m *design.MethodExpr, svcPkgName string, scope *codegen.Nam
 			resultDesc = fmt.Sprintf("%s is the result type of the %s service %s method.",
 				rname, m.Service.Name, m.Name)
 		}
-		resultEx = m.Result.Example(design.Root.API.Random())
+		resultEx = m.Result.Example(random)
 	}
 	if len(m.Errors) > 0 {
 		errors = make([]*ErrorInitData, len(m.Errors))
@@ -678,6 +959,14 @@ func buildMethodData(m *design.MethodExpr, svcPkgName string, scope *codegen.Nam
 			cliStream.RecvRef = resultRef
 		}
 	}
+	if _, ok := m.Metadata["goa:skip-request-body-encode-decode"]; ok {
+		skipReq = true
+		reqStruct = vname + "RequestData"
+	}
+	if _, ok := m.Metadata["goa:skip-response-body-encode-decode"]; ok {
+		skipResp = true
+		respStruct = vname + "ResponseData"
+	}
 	for _, req := range m.Requirements {
 		var rs []*SchemeData
 		for _, s := range req.Schemes {
@@ -696,26 +985,87 @@ func buildMethodData(m *design.MethodExpr, svcPkgName string, scope *codegen.Nam
 		reqs = append(reqs, &RequirementData{Schemes: rs, Scopes: req.Scopes})
 	}
 
+	var timeout string
+	if m.Timeout > 0 {
+		timeout = codegen.DurationCode(m.Timeout)
+	}
+
+	var rateLimit *RateLimitData
+	if m.RateLimit != nil {
+		rateLimit = &RateLimitData{
+			Requests: m.RateLimit.Requests,
+			Per:      codegen.DurationCode(m.RateLimit.Per),
+		}
+	}
+
+	interceptors := make([]*InterceptorData, len(m.Interceptors))
+	for i, ic := range m.Interceptors {
+		interceptors[i] = &InterceptorData{
+			Name:    ic,
+			VarName: codegen.Goify(ic, true),
+		}
+	}
+
 	return &MethodData{
-		Name:         m.Name,
-		VarName:      vname,
-		Description:  desc,
-		Payload:      payloadName,
-		PayloadDef:   payloadDef,
-		PayloadRef:   payloadRef,
-		PayloadDesc:  payloadDesc,
-		PayloadEx:    payloadEx,
-		Result:       rname,
-		ResultDef:    resultDef,
-		ResultRef:    resultRef,
-		ResultDesc:   resultDesc,
-		ResultEx:     resultEx,
-		Errors:       errors,
-		Requirements: reqs,
-		Schemes:      schemes,
-		ServerStream: svrStream,
-		ClientStream: cliStream,
+		Name:                         m.Name,
+		VarName:                      vname,
+		Description:                  desc,
+		Deprecated:                   codegen.DeprecationReason(m.Metadata),
+		Payload:                      payloadName,
+		PayloadDef:                   payloadDef,
+		PayloadRef:                   payloadRef,
+		PayloadDesc:                  payloadDesc,
+		PayloadEx:                    payloadEx,
+		Result:                       rname,
+		ResultDef:                    resultDef,
+		ResultRef:                    resultRef,
+		ResultDesc:                   resultDesc,
+		ResultEx:                     resultEx,
+		Errors:                       errors,
+		Requirements:                 reqs,
+		Schemes:                      schemes,
+		ServerStream:                 svrStream,
+		ClientStream:                 cliStream,
+		SkipRequestBodyEncodeDecode:  skipReq,
+		SkipResponseBodyEncodeDecode: skipResp,
+		RequestStruct:                reqStruct,
+		ResponseStruct:               respStruct,
+		Timeout:                      timeout,
+		Pagination:                   buildPaginationData(m, scope),
+		Interceptors:                 interceptors,
+		RateLimit:                    rateLimit,
+	}
+}
+
+// buildPaginationData returns the data needed to render the iterator of the
+// given method, nil if the method results are not paginated.
+func buildPaginationData(m *design.MethodExpr, scope *codegen.NameScope) *PaginationData {
+	p := m.Pagination
+	if p == nil {
+		return nil
+	}
+	vname := codegen.Goify(m.Name, true)
+	arr := design.AsArray(m.Result.Find(p.Items).Type)
+	data := &PaginationData{
+		IterMethod:     vname + "Iter",
+		IteratorStruct: vname + "Iterator",
+		ItemRef:        scope.GoTypeRef(arr.ElemType),
+		ItemsField:     codegen.Goify(p.Items, true),
 	}
+	if p.IsCursor() {
+		data.CursorField = codegen.Goify(p.CursorParam, true)
+		data.CursorPointer = m.Payload.IsPrimitivePointer(p.CursorParam, true)
+		data.NextCursorField = codegen.Goify(p.NextCursor, true)
+		data.NextCursorPointer = m.Result.IsPrimitivePointer(p.NextCursor, true)
+		return data
+	}
+	data.OffsetField = codegen.Goify(p.OffsetParam, true)
+	data.OffsetPointer = m.Payload.IsPrimitivePointer(p.OffsetParam, true)
+	if p.LimitParam != "" {
+		data.LimitField = codegen.Goify(p.LimitParam, true)
+		data.LimitPointer = m.Payload.IsPrimitivePointer(p.LimitParam, true)
+	}
+	return data
 }
 
 // buildSchemeData builds the scheme data for the given scheme and method expressions.
@@ -850,6 +1200,12 @@ func collectProjectedTypes(projected, att *design.AttributeExpr, seen map[string
 		}
 	case design.Primitive:
 		projected.ForcePointer = true
+		if _, ok := projected.Metadata["struct:enum"]; ok {
+			// Projected types use the native Go types, the metadata is
+			// shared with the service type attribute so make a copy.
+			projected.Metadata = projected.Metadata.Dup()
+			delete(projected.Metadata, "struct:enum")
+		}
 	}
 	return
 }
@@ -876,10 +1232,11 @@ func buildProjectedType(projected, att *design.AttributeExpr, scope *codegen.Nam
 			Ref:         scope.GoTypeRef(projected),
 			Type:        pt,
 		},
-		Projections: projections,
-		TypeInits:   typeInits,
-		Validations: buildValidations(projected, scope),
-		ViewsPkg:    viewspkg,
+		Projections:     projections,
+		TypeInits:       typeInits,
+		Validations:     buildValidations(projected, scope),
+		MixedValidation: buildMixedValidation(projected, scope),
+		ViewsPkg:        viewspkg,
 	}
 }
 
@@ -888,13 +1245,14 @@ func buildProjectedType(projected, att *design.AttributeExpr, scope *codegen.Nam
 func buildViewedResultType(att *design.AttributeExpr, projected design.UserType, scope *codegen.NameScope, viewspkg string) *ViewedResultTypeData {
 	rt := att.Type.(*design.ResultTypeExpr)
 	var (
-		views    []*ViewData
-		resinit  *InitData
-		init     *InitData
-		validate *ValidateData
-		data     map[string]interface{}
-		buf      bytes.Buffer
-		viewName string
+		views     []*ViewData
+		resinit   *InitData
+		init      *InitData
+		mixedinit *InitData
+		validate  *ValidateData
+		data      map[string]interface{}
+		buf       bytes.Buffer
+		viewName  string
 
 		resvar  = scope.GoTypeName(att)
 		resref  = scope.GoTypeRef(att)
@@ -982,6 +1340,35 @@ func buildViewedResultType(att *design.AttributeExpr, projected design.UserType,
 	}
 	buf.Reset()
 
+	// build constructor to initialize viewed result type collection from
+	// result type using a view per element
+	if isarr && viewName == "" {
+		parr := design.AsArray(projected)
+		data = map[string]interface{}{
+			"ArgVar":       "res",
+			"Views":        views,
+			"ProjectedRef": scope.GoFullTypeRef(&design.AttributeExpr{Type: projected}, viewspkg),
+			"TargetType":   scope.GoFullTypeName(att, viewspkg),
+			"InitName":     "new" + scope.GoTypeName(parr.ElemType),
+		}
+		if err := initMixedCodeTmpl.Execute(&buf, data); err != nil {
+			panic(err) // bug
+		}
+		name = "NewViewed" + resvar + "Mixed"
+		mixedinit = &InitData{
+			Name:        name,
+			Description: fmt.Sprintf("%s initializes viewed result type %s from result type %s rendering each element with the view at the same index in views. Elements with no view use the given view which is also the view of the viewed result type.", name, resvar, resvar),
+			Args: []*InitArgData{
+				{Name: "res", Ref: scope.GoTypeRef(att)},
+				{Name: "view", Ref: "string"},
+				{Name: "views", Ref: "[]string"},
+			},
+			ReturnTypeRef: vresref,
+			Code:          buf.String(),
+		}
+		buf.Reset()
+	}
+
 	projected = wrapProjected(projected)
 	return &ViewedResultTypeData{
 		UserTypeData: &UserTypeData{
@@ -996,6 +1383,7 @@ func buildViewedResultType(att *design.AttributeExpr, projected design.UserType,
 		FullRef:      vresref,
 		ResultInit:   resinit,
 		Init:         init,
+		MixedInit:    mixedinit,
 		Views:        views,
 		Validate:     validate,
 		IsCollection: isarr,
@@ -1235,6 +1623,32 @@ func buildValidations(projected *design.AttributeExpr, scope *codegen.NameScope)
 	return validations
 }
 
+// buildMixedValidation builds the data required to generate the validation of
+// a projected collection whose elements may be rendered using different
+// views. It returns nil if the projected type is not a collection of result
+// types with multiple views.
+func buildMixedValidation(projected *design.AttributeExpr, scope *codegen.NameScope) *ValidateData {
+	rt, ok := projected.Type.(*design.ResultTypeExpr)
+	if !ok || !design.IsArray(rt) || len(rt.Views) < 2 {
+		return nil
+	}
+	views := make([]*ViewData, 0, len(rt.Views))
+	for _, view := range rt.Views {
+		views = append(views, &ViewData{Name: view.Name, Description: view.Description})
+	}
+	var buf bytes.Buffer
+	if err := validateMixedCodeTmpl.Execute(&buf, map[string]interface{}{"Views": views}); err != nil {
+		panic(err) // bug
+	}
+	tname := scope.GoTypeName(projected)
+	return &ValidateData{
+		Name:        "ValidateMixed",
+		Description: fmt.Sprintf("ValidateMixed runs the validations defined on %s using the view at the same index in views for each element. Elements with no view are validated using the given view.", tname),
+		Ref:         scope.GoTypeRef(projected),
+		Validate:    buf.String(),
+	}
+}
+
 // buildConstructorCode builds the transformation code to create a projected
 // type from a service type and vice versa.
 //
@@ -1356,6 +1770,38 @@ if {{ $.Source }}.{{ .VarName }} != nil {
 {{- end }}
 return {{ .ReturnVar }}`
 
+	initMixedCodeT = `p := make({{ .ProjectedRef }}, len({{ .ArgVar }}))
+for i, n := range {{ .ArgVar }} {
+  v := view
+  if i < len(views) && views[i] != "" {
+    v = views[i]
+  }
+  switch v {
+  {{- range .Views }}
+  case {{ printf "%q" .Name }}{{ if eq .Name "default" }}, ""{{ end }}:
+    p[i] = {{ $.InitName }}{{ if ne .Name "default" }}{{ goify .Name true }}{{ end }}(n)
+  {{- end }}
+  }
+}
+return {{ .TargetType }}{ p, view }`
+
+	validateMixedCodeT = `for i, item := range result {
+	v := view
+	if i < len(views) && views[i] != "" {
+		v = views[i]
+	}
+	switch v {
+	{{- range .Views }}
+	case {{ printf "%q" .Name }}{{ if eq .Name "default" }}, ""{{ end }}:
+		if err2 := item.Validate{{ if ne .Name "default" }}{{ goify .Name true }}{{ end }}(); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	{{- end }}
+	default:
+		err = goa.MergeErrors(err, goa.InvalidEnumValueError("view", v, []interface{}{ {{ range .Views }}{{ printf "%q" .Name }}, {{ end }} }))
+	}
+}`
+
 	validateTypeT = `{{- if .IsViewed -}}
 switch {{ .ArgVar }}.View {
 	{{- range .Views }}
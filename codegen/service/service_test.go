@@ -30,6 +30,10 @@ func TestService(t *testing.T) {
 		{"force-generate-type-explicit", testdata.ForceGenerateTypeExplicitDSL, testdata.ForceGenerateTypeExplicit},
 		{"streaming-result", testdata.StreamingResultMethodDSL, testdata.StreamingResultMethod},
 		{"streaming-result-no-payload", testdata.StreamingResultNoPayloadMethodDSL, testdata.StreamingResultNoPayloadMethod},
+		{"skip-body-encode-decode", testdata.SkipBodyEncodeDecodeMethodDSL, testdata.SkipBodyEncodeDecodeMethod},
+		{"enum-type", testdata.EnumTypeMethodDSL, testdata.EnumTypeMethod},
+		{"deprecated", testdata.DeprecatedMethodDSL, testdata.DeprecatedMethod},
+		{"namespace", testdata.NamespaceMethodDSL, testdata.NamespaceMethod},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
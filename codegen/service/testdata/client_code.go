@@ -62,3 +62,267 @@ func (c *Client) NoPayload(ctx context.Context, )(err error) {
 	return
 }
 `
+
+const SkipBodyEncodeDecodeMethodsClient = `// Client is the "SkipBodyEncodeDecode" service client.
+type Client struct {
+	UploadEndpoint goa.Endpoint
+	DownloadEndpoint goa.Endpoint
+}
+// NewClient initializes a "SkipBodyEncodeDecode" service client given the
+// endpoints.
+func NewClient(upload, download goa.Endpoint) *Client {
+	return &Client{
+		UploadEndpoint: upload,
+		DownloadEndpoint: download,
+	}
+}
+
+// Upload calls the "Upload" endpoint of the "SkipBodyEncodeDecode" service.
+func (c *Client) Upload(ctx context.Context, p *AType, req io.ReadCloser)(err error) {
+	_, err = c.UploadEndpoint(ctx, &UploadRequestData{ Payload: p, Body: req})
+	return
+}
+
+// Download calls the "Download" endpoint of the "SkipBodyEncodeDecode" service.
+func (c *Client) Download(ctx context.Context, )(res *RType, resp io.ReadCloser, err error) {
+	var ires interface{}
+	ires, err = c.DownloadEndpoint(ctx, nil)
+	if err != nil {
+		return
+	}
+	o := ires.(*DownloadResponseData)
+	return o.Result, o.Body, nil
+}
+`
+
+const CursorPaginatedMethodClient = `// Client is the "CursorPaginated" service client.
+type Client struct {
+	ListEndpoint goa.Endpoint
+}
+// NewClient initializes a "CursorPaginated" service client given the endpoints.
+func NewClient(list goa.Endpoint) *Client {
+	return &Client{
+		ListEndpoint: list,
+	}
+}
+
+// List calls the "List" endpoint of the "CursorPaginated" service.
+func (c *Client) List(ctx context.Context, p *ListPayload)(res *ListResult, err error) {
+	var ires interface{}
+	ires, err = c.ListEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*ListResult), nil
+}
+
+// ListIter returns an iterator over the items of the pages returned by the
+// "List" endpoint starting with the page described by p. The iterator fetches
+// the subsequent pages as needed.
+func (c *Client) ListIter(ctx context.Context, p *ListPayload) *ListIterator {
+	if p == nil {
+		p = &ListPayload{}
+	}
+	return &ListIterator{client: c, ctx: ctx, payload: p}
+}
+
+// ListIterator iterates over the items of the pages returned by the "List"
+// endpoint.
+type ListIterator struct {
+	client  *Client
+	ctx     context.Context
+	payload *ListPayload
+	items   []string
+	item    string
+	done    bool
+	err     error
+}
+
+// Next advances the iterator to the next item, fetching the next page if
+// needed. It returns false once all the items have been read or if an error
+// occurred, use Err to tell the two cases apart.
+func (it *ListIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the current item.
+func (it *ListIterator) Item() string {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration if any.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// fetch retrieves the current page and sets the payload to describe the next
+// page.
+func (it *ListIterator) fetch() {
+	res, err := it.client.List(it.ctx, it.payload)
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.items = res.Items
+	p := *it.payload
+	if res.Next == nil || *res.Next == "" {
+		it.done = true
+		return
+	}
+	p.Cursor = res.Next
+	it.payload = &p
+}
+`
+
+const OffsetPaginatedMethodClient = `// Client is the "OffsetPaginated" service client.
+type Client struct {
+	ListEndpoint goa.Endpoint
+}
+// NewClient initializes a "OffsetPaginated" service client given the endpoints.
+func NewClient(list goa.Endpoint) *Client {
+	return &Client{
+		ListEndpoint: list,
+	}
+}
+
+// List calls the "List" endpoint of the "OffsetPaginated" service.
+func (c *Client) List(ctx context.Context, p *ListPayload)(res *ListResult, err error) {
+	var ires interface{}
+	ires, err = c.ListEndpoint(ctx, p)
+	if err != nil {
+		return
+	}
+	return ires.(*ListResult), nil
+}
+
+// ListIter returns an iterator over the items of the pages returned by the
+// "List" endpoint starting with the page described by p. The iterator fetches
+// the subsequent pages as needed.
+func (c *Client) ListIter(ctx context.Context, p *ListPayload) *ListIterator {
+	if p == nil {
+		p = &ListPayload{}
+	}
+	return &ListIterator{client: c, ctx: ctx, payload: p}
+}
+
+// ListIterator iterates over the items of the pages returned by the "List"
+// endpoint.
+type ListIterator struct {
+	client  *Client
+	ctx     context.Context
+	payload *ListPayload
+	items   []*IType
+	item    *IType
+	done    bool
+	err     error
+}
+
+// Next advances the iterator to the next item, fetching the next page if
+// needed. It returns false once all the items have been read or if an error
+// occurred, use Err to tell the two cases apart.
+func (it *ListIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.fetch()
+	}
+	it.item, it.items = it.items[0], it.items[1:]
+	return true
+}
+
+// Item returns the current item.
+func (it *ListIterator) Item() *IType {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration if any.
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// fetch retrieves the current page and sets the payload to describe the next
+// page.
+func (it *ListIterator) fetch() {
+	res, err := it.client.List(it.ctx, it.payload)
+	if err != nil {
+		it.err = err
+		return
+	}
+	it.items = res.Items
+	p := *it.payload
+	n := len(res.Items)
+	if n == 0 || n < p.Limit {
+		it.done = true
+		return
+	}
+	offset := n
+	if p.Offset != nil {
+		offset += *p.Offset
+	}
+	p.Offset = &offset
+	it.payload = &p
+}
+`
+
+const OAuth2MethodClient = `// Client is the "EndpointWithOAuth2" service client.
+type Client struct {
+	SecureWithOAuth2Endpoint goa.Endpoint
+}
+// NewClient initializes a "EndpointWithOAuth2" service client given the
+// endpoints.
+func NewClient(secureWithOAuth2 goa.Endpoint) *Client {
+	return &Client{
+		SecureWithOAuth2Endpoint: secureWithOAuth2,
+	}
+}
+
+// SecureWithOAuth2 calls the "SecureWithOAuth2" endpoint of the
+// "EndpointWithOAuth2" service.
+func (c *Client) SecureWithOAuth2(ctx context.Context, p *SecureWithOAuth2Payload)(err error) {
+	_, err = c.SecureWithOAuth2Endpoint(ctx, p)
+	return
+}
+
+// UseOAuth2TokenSource sets the source of the OAuth2 access tokens sent with
+// the requests made by the "EndpointWithOAuth2" service client. The client
+// retrieves a token from ts for the requests whose payload does not set one.
+func (c *Client) UseOAuth2TokenSource(ts security.OAuth2TokenSource) {
+	{
+		sc := security.OAuth2Scheme{
+			Name: "authCode",
+			Scopes: []string{"api:write", "api:read",  },
+			RequiredScopes: []string{ },
+			Flows: []*security.OAuthFlow{
+				&security.OAuthFlow{
+					Type: "authorization_code",
+					AuthorizationURL: "/authorization",
+					TokenURL: "/token",
+					RefreshURL: "/refresh",
+				},
+			},
+		}
+		next := c.SecureWithOAuth2Endpoint
+		c.SecureWithOAuth2Endpoint = func(ctx context.Context, v interface{}) (interface{}, error) {
+			p := v.(*SecureWithOAuth2Payload)
+			if p.Token == nil {
+				token, err := ts(ctx, &sc)
+				if err != nil {
+					return nil, err
+				}
+				pc := *p
+				pc.Token = &token
+				v = &pc
+			}
+			return next(ctx, v)
+		}
+	}
+}
+`
@@ -4,6 +4,7 @@ import (
 	. "goa.design/goa/design"
 	. "goa.design/goa/dsl"
 	"goa.design/goa/codegen/service/testdata/external"
+	"goa.design/goa/codegen/service/testdata/external/pb"
 	"goa.design/goa/codegen/service/testdata/alias-external"
 )
 
@@ -57,6 +58,66 @@ var ConvertStringPointerRequiredDSL = func() {
 	})
 }
 
+var ConvertTimeDSL = func() {
+	var TimeType = Type("TimeType", func() {
+		ConvertTo(TimeT{})
+		Attribute("Time", String, func() {
+			Format(FormatDateTime)
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(TimeType)
+		})
+	})
+}
+
+var ConvertTimeRequiredDSL = func() {
+	var TimeType = Type("TimeType", func() {
+		ConvertTo(TimeT{})
+		Attribute("Time", String, func() {
+			Format(FormatDateTime)
+		})
+		Required("Time")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(TimeType)
+		})
+	})
+}
+
+var ConvertTimePointerDSL = func() {
+	var TimePointerType = Type("TimePointerType", func() {
+		ConvertTo(TimePointerT{})
+		Attribute("Time", String, func() {
+			Format(FormatDateTime)
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(TimePointerType)
+		})
+	})
+}
+
+var ConvertCustomScalarDSL = func() {
+	var IDType = Type("IDType", func() {
+		ConvertTo(external.IDModel{})
+		Attribute("ID", String, func() {
+			Metadata("struct.field.convert",
+				"goa.design/goa/codegen/service/testdata/external.ParseID",
+				"goa.design/goa/codegen/service/testdata/external.FormatID")
+		})
+		Required("ID")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(IDType)
+		})
+	})
+}
+
 var ConvertArrayStringDSL = func() {
 	var ArrayStringType = Type("ArrayStringType", func() {
 		ConvertTo(ArrayStringT{})
@@ -169,3 +230,116 @@ var ConvertAliasDSL = func() {
 		})
 	})
 }
+
+var ConvertMultiplePackagesDSL = func() {
+	var ExternalType = Type("ExternalType", func() {
+		ConvertTo(external.ConvertModel{})
+		Attribute("Foo", String)
+	})
+	var AliasType = Type("AliasType", func() {
+		ConvertTo(aliasd.ConvertModel{})
+		Attribute("Bar", String)
+	})
+
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(ExternalType)
+			Result(AliasType)
+		})
+	})
+}
+
+var ConvertArrayObjectDSL = func() {
+	var ObjectField = Type("ObjectField", func() {
+		Attribute("String", String)
+	})
+	var ArrayObjectType = Type("ArrayObjectType", func() {
+		ConvertTo(ArrayObjectT{})
+		Attribute("Objects", ArrayOf(ObjectField))
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(ArrayObjectType)
+		})
+	})
+}
+
+var ConvertMapObjectDSL = func() {
+	var ObjectField = Type("ObjectField", func() {
+		Attribute("String", String)
+	})
+	var MapObjectType = Type("MapObjectType", func() {
+		ConvertTo(MapObjectT{})
+		Attribute("Objects", MapOf(String, ObjectField))
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(MapObjectType)
+		})
+	})
+}
+
+var ConvertNestedDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("Name", String)
+	})
+	var NestedType = Type("NestedType", func() {
+		ConvertTo(external.NestedModel{})
+		Attribute("Items", ArrayOf(Item))
+		Attribute("ByName", MapOf(String, Item))
+		Attribute("Main", Item)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(NestedType)
+		})
+	})
+}
+
+var ConvertMappedFieldsDSL = func() {
+	var ObjectField = Type("ObjectField", func() {
+		Attribute("String", String)
+	})
+	var MappedFieldsType = Type("MappedFieldsType", func() {
+		ConvertTo(MappedFieldsT{})
+		Attribute("name", String, func() {
+			Metadata("struct.field.external", "LegacyName")
+		})
+		Attribute("count", Int, func() {
+			Metadata("struct.field.external", "LegacyCount")
+		})
+		Attribute("details", ObjectField, func() {
+			Metadata("struct.field.external", "Object")
+		})
+		Attribute("ignored", String, func() {
+			Metadata("struct.field.external", "-")
+		})
+		Required("name")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(MappedFieldsType)
+		})
+	})
+}
+
+var ConvertProtoDSL = func() {
+	var BottleType = Type("BottleType", func() {
+		ConvertTo(pb.Bottle{})
+		Attribute("name", String)
+		Attribute("color", String, func() {
+			Enum("RED", "GREEN")
+		})
+		Attribute("created_at", String, func() {
+			Format(FormatDateTime)
+		})
+		Attribute("year", Int32)
+		Attribute("label", String)
+		Required("name")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(BottleType)
+		})
+	})
+}
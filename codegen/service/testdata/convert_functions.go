@@ -194,3 +194,209 @@ func (t *StringType) CreateFromConvertModel(v *external.ConvertModel) {
 	*t = *temp
 }
 `
+
+var ConvertTimeCode = `// ConvertToTimeT creates an instance of TimeT initialized from t.
+func (t *TimeType) ConvertToTimeT() *testdata.TimeT {
+	v := &testdata.TimeT{}
+	if t.Time != nil {
+		v.Time = parseDateTime(*t.Time)
+	}
+	return v
+}
+`
+
+var ConvertTimeRequiredCode = `// ConvertToTimeT creates an instance of TimeT initialized from t.
+func (t *TimeType) ConvertToTimeT() *testdata.TimeT {
+	v := &testdata.TimeT{
+		Time: parseDateTime(t.Time),
+	}
+	return v
+}
+`
+
+var ConvertTimePointerCode = `// ConvertToTimePointerT creates an instance of TimePointerT initialized from t.
+func (t *TimePointerType) ConvertToTimePointerT() *testdata.TimePointerT {
+	v := &testdata.TimePointerT{}
+	if t.Time != nil {
+		tmp := parseDateTime(*t.Time)
+		v.Time = &tmp
+	}
+	return v
+}
+`
+
+var ConvertTimeHelpersCode = `// parseDateTime returns the time represented by the RFC3339 date time s or the
+// zero time if s is not a valid date time.
+func parseDateTime(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+// formatDateTime returns the RFC3339 representation of t.
+func formatDateTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+`
+
+var ConvertCustomScalarCode = `// Service service type conversion functions
+//
+// Command:
+// $ goa
+
+package service
+
+import (
+	external "goa.design/goa/codegen/service/testdata/external"
+)
+
+// ConvertToIDModel creates an instance of IDModel initialized from t.
+func (t *IDType) ConvertToIDModel() *external.IDModel {
+	v := &external.IDModel{
+		ID: external.ParseID(t.ID),
+	}
+	return v
+}
+`
+
+var ConvertArrayObjectCode = `// ConvertToArrayObjectT creates an instance of ArrayObjectT initialized from t.
+func (t *ArrayObjectType) ConvertToArrayObjectT() *testdata.ArrayObjectT {
+	v := &testdata.ArrayObjectT{}
+	if t.Objects != nil {
+		v.Objects = make([]*testdata.ObjectFieldT, len(t.Objects))
+		for j, val := range t.Objects {
+			v.Objects[j] = &testdata.ObjectFieldT{}
+			if val.String != nil {
+				v.Objects[j].String = *val.String
+			}
+		}
+	}
+	return v
+}
+`
+
+var ConvertMapObjectCode = `// ConvertToMapObjectT creates an instance of MapObjectT initialized from t.
+func (t *MapObjectType) ConvertToMapObjectT() *testdata.MapObjectT {
+	v := &testdata.MapObjectT{}
+	if t.Objects != nil {
+		v.Objects = make(map[string]testdata.ObjectFieldT, len(t.Objects))
+		for key, val := range t.Objects {
+			tk := key
+			tvb := testdata.ObjectFieldT{}
+			if val.String != nil {
+				tvb.String = *val.String
+			}
+			v.Objects[tk] = tvb
+		}
+	}
+	return v
+}
+`
+
+var ConvertNestedCode = `// Service service type conversion functions
+//
+// Command:
+// $ goa
+
+package service
+
+import (
+	external "goa.design/goa/codegen/service/testdata/external"
+	nested "goa.design/goa/codegen/service/testdata/external/nested"
+)
+
+// ConvertToNestedModel creates an instance of NestedModel initialized from t.
+func (t *NestedType) ConvertToNestedModel() *external.NestedModel {
+	v := &external.NestedModel{}
+	if t.Items != nil {
+		v.Items = make([]*nested.Item, len(t.Items))
+		for j, val := range t.Items {
+			v.Items[j] = &nested.Item{}
+			if val.Name != nil {
+				v.Items[j].Name = *val.Name
+			}
+		}
+	}
+	if t.ByName != nil {
+		v.ByName = make(map[string]nested.Item, len(t.ByName))
+		for key, val := range t.ByName {
+			tk := key
+			tv := nested.Item{}
+			if val.Name != nil {
+				tv.Name = *val.Name
+			}
+			v.ByName[tk] = tv
+		}
+	}
+	if t.Main != nil {
+		v.Main = *marshalItemToItem(t.Main)
+	}
+	return v
+}
+`
+
+var ConvertNestedHelperCode = `// marshalItemToItem builds a value of type *nested.Item from a value of type
+// *Item.
+func marshalItemToItem(v *Item) *nested.Item {
+	if v == nil {
+		return nil
+	}
+	res := &nested.Item{}
+	if v.Name != nil {
+		res.Name = *v.Name
+	}
+
+	return res
+}
+`
+
+var ConvertMappedFieldsCode = `// ConvertToMappedFieldsT creates an instance of MappedFieldsT initialized from
+// t.
+func (t *MappedFieldsType) ConvertToMappedFieldsT() *testdata.MappedFieldsT {
+	v := &testdata.MappedFieldsT{
+		LegacyName:  t.Name,
+		LegacyCount: t.Count,
+	}
+	if t.Details != nil {
+		v.Object = marshalObjectFieldToObjectFieldT(t.Details)
+	}
+	return v
+}
+`
+
+var ConvertProtoCode = `// Service service type conversion functions
+//
+// Command:
+// $ goa
+
+package service
+
+import (
+	pb "goa.design/goa/codegen/service/testdata/external/pb"
+)
+
+// ConvertToBottle creates an instance of Bottle initialized from t.
+func (t *BottleType) ConvertToBottle() *pb.Bottle {
+	v := &pb.Bottle{
+		Name: t.Name,
+	}
+	if t.Color != nil {
+		v.Color = pbColorFromString(*t.Color)
+	}
+	if t.CreatedAt != nil {
+		v.CreatedAt = parseTimestamp(*t.CreatedAt)
+	}
+	if t.Year != nil {
+		v.Vintage = &pb.Bottle_Year{Year: *t.Year}
+	}
+	if t.Label != nil {
+		v.Vintage = &pb.Bottle_Label{Label: *t.Label}
+	}
+	return v
+}
+`
+
+var ConvertProtoEnumHelperCode = `// pbColorFromString returns the pb.Color value with the given name.
+func pbColorFromString(s string) pb.Color {
+	return pb.Color(pb.Color_value[s])
+}
+`
@@ -4,6 +4,7 @@ import (
 	. "goa.design/goa/design"
 	. "goa.design/goa/dsl"
 	"goa.design/goa/codegen/service/testdata/external"
+	"goa.design/goa/codegen/service/testdata/external/pb"
 	"goa.design/goa/codegen/service/testdata/alias-external"
 )
 
@@ -19,6 +20,51 @@ var CreateStringDSL = func() {
 	})
 }
 
+var CreateTimeDSL = func() {
+	var TimeType = Type("TimeType", func() {
+		CreateFrom(TimeT{})
+		Attribute("Time", String, func() {
+			Format(FormatDateTime)
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(TimeType)
+		})
+	})
+}
+
+var CreateTimePointerRequiredDSL = func() {
+	var TimePointerType = Type("TimePointerType", func() {
+		CreateFrom(TimePointerT{})
+		Attribute("Time", String, func() {
+			Format(FormatDateTime)
+		})
+		Required("Time")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(TimePointerType)
+		})
+	})
+}
+
+var CreateCustomScalarDSL = func() {
+	var IDType = Type("IDType", func() {
+		CreateFrom(external.IDModel{})
+		Attribute("ID", String, func() {
+			Metadata("struct.field.convert",
+				"goa.design/goa/codegen/service/testdata/external.ParseID",
+				"goa.design/goa/codegen/service/testdata/external.FormatID")
+		})
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(IDType)
+		})
+	})
+}
+
 var CreateStringRequiredDSL = func() {
 	var StringType = Type("StringType", func() {
 		CreateFrom(StringT{})
@@ -199,3 +245,98 @@ var CreateAliasDSL = func() {
 		})
 	})
 }
+
+var CreateNestedDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("Name", String)
+	})
+	var NestedType = Type("NestedType", func() {
+		CreateFrom(external.NestedModel{})
+		Attribute("Items", ArrayOf(Item))
+		Attribute("ByName", MapOf(String, Item))
+		Attribute("Main", Item)
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(NestedType)
+		})
+	})
+}
+
+var CreateMappedFieldsDSL = func() {
+	var ObjectField = Type("ObjectField", func() {
+		Attribute("String", String)
+	})
+	var MappedFieldsType = Type("MappedFieldsType", func() {
+		CreateFrom(MappedFieldsT{})
+		Attribute("name", String, func() {
+			Metadata("struct.field.external", "LegacyName")
+		})
+		Attribute("count", Int, func() {
+			Metadata("struct.field.external", "LegacyCount")
+		})
+		Attribute("details", ObjectField, func() {
+			Metadata("struct.field.external", "Object")
+		})
+		Attribute("ignored", String, func() {
+			Metadata("struct.field.external", "-")
+		})
+		Required("name")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(MappedFieldsType)
+		})
+	})
+}
+
+var CreateProtoDSL = func() {
+	var BottleType = Type("BottleType", func() {
+		CreateFrom(pb.Bottle{})
+		Attribute("name", String)
+		Attribute("color", String, func() {
+			Enum("RED", "GREEN")
+		})
+		Attribute("created_at", String, func() {
+			Format(FormatDateTime)
+		})
+		Attribute("year", Int32)
+		Attribute("label", String)
+		Required("name")
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(BottleType)
+		})
+	})
+}
+
+var CreateArrayObjectDSL = func() {
+	var ObjectField = Type("ObjectField", func() {
+		Attribute("String", String)
+	})
+	var ArrayObjectType = Type("ArrayObjectType", func() {
+		CreateFrom(ArrayObjectT{})
+		Attribute("Objects", ArrayOf(ObjectField))
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(ArrayObjectType)
+		})
+	})
+}
+
+var CreateMapObjectDSL = func() {
+	var ObjectField = Type("ObjectField", func() {
+		Attribute("String", String)
+	})
+	var MapObjectType = Type("MapObjectType", func() {
+		CreateFrom(MapObjectT{})
+		Attribute("Objects", MapOf(String, ObjectField))
+	})
+	Service("Service", func() {
+		Method("Method", func() {
+			Payload(MapObjectType)
+		})
+	})
+}
@@ -112,3 +112,194 @@ func (t *StringType) CreateFromConvertModel(v *aliasd.ConvertModel) {
 	*t = *temp
 }
 `
+
+var CreateTimeCode = `// CreateFromTimeT initializes t from the fields of v
+func (t *TimeType) CreateFromTimeT(v *testdata.TimeT) {
+	temp := &TimeType{}
+	{
+		tmp := formatDateTime(v.Time)
+		temp.Time = &tmp
+	}
+	*t = *temp
+}
+`
+
+var CreateTimePointerRequiredCode = `// CreateFromTimePointerT initializes t from the fields of v
+func (t *TimePointerType) CreateFromTimePointerT(v *testdata.TimePointerT) {
+	temp := &TimePointerType{}
+	if v.Time != nil {
+		temp.Time = formatDateTime(*v.Time)
+	}
+	*t = *temp
+}
+`
+
+var CreateCustomScalarCode = `// Service service type conversion functions
+//
+// Command:
+// $ goa
+
+package service
+
+import (
+	external "goa.design/goa/codegen/service/testdata/external"
+)
+
+// CreateFromIDModel initializes t from the fields of v
+func (t *IDType) CreateFromIDModel(v *external.IDModel) {
+	temp := &IDType{}
+	{
+		tmp := external.FormatID(v.ID)
+		temp.ID = &tmp
+	}
+	*t = *temp
+}
+`
+
+var CreateArrayObjectCode = `// CreateFromArrayObjectT initializes t from the fields of v
+func (t *ArrayObjectType) CreateFromArrayObjectT(v *testdata.ArrayObjectT) {
+	temp := &ArrayObjectType{}
+	if v.Objects != nil {
+		temp.Objects = make([]*ObjectField, len(v.Objects))
+		for j, val := range v.Objects {
+			temp.Objects[j] = &ObjectField{
+				String: &val.String,
+			}
+		}
+	}
+	*t = *temp
+}
+`
+
+var CreateMapObjectCode = `// CreateFromMapObjectT initializes t from the fields of v
+func (t *MapObjectType) CreateFromMapObjectT(v *testdata.MapObjectT) {
+	temp := &MapObjectType{}
+	if v.Objects != nil {
+		temp.Objects = make(map[string]*ObjectField, len(v.Objects))
+		for key, val := range v.Objects {
+			val := val
+			tk := key
+			tv := &ObjectField{
+				String: &val.String,
+			}
+			temp.Objects[tk] = tv
+		}
+	}
+	*t = *temp
+}
+`
+
+var CreateNestedCode = `// Service service type conversion functions
+//
+// Command:
+// $ goa
+
+package service
+
+import (
+	external "goa.design/goa/codegen/service/testdata/external"
+)
+
+// CreateFromNestedModel initializes t from the fields of v
+func (t *NestedType) CreateFromNestedModel(v *external.NestedModel) {
+	temp := &NestedType{}
+	if v.Items != nil {
+		temp.Items = make([]*Item, len(v.Items))
+		for j, val := range v.Items {
+			temp.Items[j] = &Item{
+				Name: &val.Name,
+			}
+		}
+	}
+	if v.ByName != nil {
+		temp.ByName = make(map[string]*Item, len(v.ByName))
+		for key, val := range v.ByName {
+			val := val
+			tk := key
+			tv := &Item{
+				Name: &val.Name,
+			}
+			temp.ByName[tk] = tv
+		}
+	}
+	temp.Main = marshalItemToItem(&v.Main)
+	*t = *temp
+}
+`
+
+var CreateNestedHelperCode = `// marshalItemToItem builds a value of type *Item from a value of type
+// *nested.Item.
+func marshalItemToItem(v *nested.Item) *Item {
+	res := &Item{
+		Name: &v.Name,
+	}
+
+	return res
+}
+`
+
+var CreateMappedFieldsCode = `// CreateFromMappedFieldsT initializes t from the fields of v
+func (t *MappedFieldsType) CreateFromMappedFieldsT(v *testdata.MappedFieldsT) {
+	temp := &MappedFieldsType{
+		Name:  v.LegacyName,
+		Count: v.LegacyCount,
+	}
+	if v.Object != nil {
+		temp.Details = marshalObjectFieldTToObjectField(v.Object)
+	}
+	*t = *temp
+}
+`
+
+var CreateProtoCode = `// Service service type conversion functions
+//
+// Command:
+// $ goa
+
+package service
+
+import (
+	pb "goa.design/goa/codegen/service/testdata/external/pb"
+)
+
+// CreateFromBottle initializes t from the fields of v
+func (t *BottleType) CreateFromBottle(v *pb.Bottle) {
+	temp := &BottleType{
+		Name: v.Name,
+	}
+	{
+		tmp := pb.Color.String(v.Color)
+		temp.Color = &tmp
+	}
+	if v.CreatedAt != nil {
+		tmp := formatTimestamp(v.CreatedAt)
+		temp.CreatedAt = &tmp
+	}
+	switch val := v.Vintage.(type) {
+	case *pb.Bottle_Year:
+		temp.Year = &val.Year
+	case *pb.Bottle_Label:
+		temp.Label = &val.Label
+	}
+	*t = *temp
+}
+`
+
+var CreateProtoTimestampHelperCode = `// parseTimestamp returns the protobuf timestamp for the RFC3339 date time s or
+// nil if s is not a valid date time.
+func parseTimestamp(s string) *timestamp.Timestamp {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &timestamp.Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+}
+
+// formatTimestamp returns the RFC3339 representation of ts.
+func formatTimestamp(ts *timestamp.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return time.Unix(ts.Seconds, int64(ts.Nanos)).UTC().Format(time.RFC3339)
+}
+`
@@ -19,6 +19,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.A = m(e.A)
 }
 
+// UseA applies the given middleware to the "A" endpoint of the
+// "SingleEndpoint" service.
+func (e *Endpoints) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
 // NewAEndpoint returns an endpoint function that calls the method "A" of
 // service "SingleEndpoint".
 func NewAEndpoint(s Service) goa.Endpoint {
@@ -51,6 +57,18 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.C = m(e.C)
 }
 
+// UseB applies the given middleware to the "B" endpoint of the
+// "MultipleEndpoints" service.
+func (e *Endpoints) UseB(m func(goa.Endpoint) goa.Endpoint) {
+	e.B = m(e.B)
+}
+
+// UseC applies the given middleware to the "C" endpoint of the
+// "MultipleEndpoints" service.
+func (e *Endpoints) UseC(m func(goa.Endpoint) goa.Endpoint) {
+	e.C = m(e.C)
+}
+
 // NewBEndpoint returns an endpoint function that calls the method "B" of
 // service "MultipleEndpoints".
 func NewBEndpoint(s Service) goa.Endpoint {
@@ -87,6 +105,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.NoPayload = m(e.NoPayload)
 }
 
+// UseNoPayload applies the given middleware to the "NoPayload" endpoint of the
+// "NoPayload" service.
+func (e *Endpoints) UseNoPayload(m func(goa.Endpoint) goa.Endpoint) {
+	e.NoPayload = m(e.NoPayload)
+}
+
 // NewNoPayloadEndpoint returns an endpoint function that calls the method
 // "NoPayload" of service "NoPayload".
 func NewNoPayloadEndpoint(s Service) goa.Endpoint {
@@ -113,6 +137,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.A = m(e.A)
 }
 
+// UseA applies the given middleware to the "A" endpoint of the "WithResult"
+// service.
+func (e *Endpoints) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
 // NewAEndpoint returns an endpoint function that calls the method "A" of
 // service "WithResult".
 func NewAEndpoint(s Service) goa.Endpoint {
@@ -146,6 +176,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.A = m(e.A)
 }
 
+// UseA applies the given middleware to the "A" endpoint of the
+// "WithResultMultipleViews" service.
+func (e *Endpoints) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
 // NewAEndpoint returns an endpoint function that calls the method "A" of
 // service "WithResultMultipleViews".
 func NewAEndpoint(s Service) goa.Endpoint {
@@ -154,6 +190,9 @@ func NewAEndpoint(s Service) goa.Endpoint {
 		if err != nil {
 			return nil, err
 		}
+		if v, ok := ctx.Value(goa.ViewKey).(string); ok {
+			view = v
+		}
 		vres := NewViewedViewtype(res, view)
 		return vres, nil
 	}
@@ -190,6 +229,12 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.StreamingResultMethod = m(e.StreamingResultMethod)
 }
 
+// UseStreamingResultMethod applies the given middleware to the
+// "StreamingResultMethod" endpoint of the "StreamingResultEndpoint" service.
+func (e *Endpoints) UseStreamingResultMethod(m func(goa.Endpoint) goa.Endpoint) {
+	e.StreamingResultMethod = m(e.StreamingResultMethod)
+}
+
 // NewStreamingResultMethodEndpoint returns an endpoint function that calls the
 // method "StreamingResultMethod" of service "StreamingResultEndpoint".
 func NewStreamingResultMethodEndpoint(s Service) goa.Endpoint {
@@ -228,6 +273,13 @@ func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
 	e.StreamingResultNoPayloadMethod = m(e.StreamingResultNoPayloadMethod)
 }
 
+// UseStreamingResultNoPayloadMethod applies the given middleware to the
+// "StreamingResultNoPayloadMethod" endpoint of the
+// "StreamingResultNoPayloadEndpoint" service.
+func (e *Endpoints) UseStreamingResultNoPayloadMethod(m func(goa.Endpoint) goa.Endpoint) {
+	e.StreamingResultNoPayloadMethod = m(e.StreamingResultNoPayloadMethod)
+}
+
 // NewStreamingResultNoPayloadMethodEndpoint returns an endpoint function that
 // calls the method "StreamingResultNoPayloadMethod" of service
 // "StreamingResultNoPayloadEndpoint".
@@ -238,3 +290,140 @@ func NewStreamingResultNoPayloadMethodEndpoint(s Service) goa.Endpoint {
 	}
 }
 `
+
+const SkipBodyEncodeDecodeMethodEndpoint = `// Endpoints wraps the "SkipBodyEncodeDecode" service endpoints.
+type Endpoints struct {
+	Upload   goa.Endpoint
+	Download goa.Endpoint
+}
+
+// UploadRequestData is the input type of "Upload" endpoint that holds the
+// method payload and the request body reader.
+type UploadRequestData struct {
+	// Payload is the method payload.
+	Payload *AType
+	// Body streams the request body, it must be closed by the service method.
+	Body io.ReadCloser
+}
+
+// DownloadResponseData is the output type of "Download" endpoint that holds
+// the method result and the response body reader.
+type DownloadResponseData struct {
+	// Result is the method result.
+	Result *RType
+	// Body streams the response body, it is closed once the response has been
+	// written.
+	Body io.ReadCloser
+}
+
+// NewEndpoints wraps the methods of the "SkipBodyEncodeDecode" service with
+// endpoints.
+func NewEndpoints(s Service) *Endpoints {
+	return &Endpoints{
+		Upload:   NewUploadEndpoint(s),
+		Download: NewDownloadEndpoint(s),
+	}
+}
+
+// Use applies the given middleware to all the "SkipBodyEncodeDecode" service
+// endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.Upload = m(e.Upload)
+	e.Download = m(e.Download)
+}
+
+// UseUpload applies the given middleware to the "Upload" endpoint of the
+// "SkipBodyEncodeDecode" service.
+func (e *Endpoints) UseUpload(m func(goa.Endpoint) goa.Endpoint) {
+	e.Upload = m(e.Upload)
+}
+
+// UseDownload applies the given middleware to the "Download" endpoint of the
+// "SkipBodyEncodeDecode" service.
+func (e *Endpoints) UseDownload(m func(goa.Endpoint) goa.Endpoint) {
+	e.Download = m(e.Download)
+}
+
+// NewUploadEndpoint returns an endpoint function that calls the method
+// "Upload" of service "SkipBodyEncodeDecode".
+func NewUploadEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		ep := req.(*UploadRequestData)
+		return nil, s.Upload(ctx, ep.Payload, ep.Body)
+	}
+}
+
+// NewDownloadEndpoint returns an endpoint function that calls the method
+// "Download" of service "SkipBodyEncodeDecode".
+func NewDownloadEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		res, body, err := s.Download(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &DownloadResponseData{Result: res, Body: body}, nil
+	}
+}
+`
+
+const InterceptedEndpoint = `// Endpoints wraps the "Intercepted" service endpoints.
+type Endpoints struct {
+	A goa.Endpoint
+	B goa.Endpoint
+}
+
+// Interceptors lists the interceptors declared in the design of the
+// "Intercepted" service. NewEndpoints wraps the endpoints with the
+// interceptors listed in the design of the corresponding methods.
+type Interceptors interface {
+	// Logging implements the "logging" interceptor, it must call next to carry on
+	// with the request.
+	Logging(ctx context.Context, method string, req interface{}, next goa.Endpoint) (interface{}, error)
+	// Auth implements the "auth" interceptor, it must call next to carry on with
+	// the request.
+	Auth(ctx context.Context, method string, req interface{}, next goa.Endpoint) (interface{}, error)
+}
+
+// NewEndpoints wraps the methods of the "Intercepted" service with endpoints.
+func NewEndpoints(s Service, i Interceptors) *Endpoints {
+	return &Endpoints{
+		A: goa.Intercept("A", NewAEndpoint(s), i.Logging, i.Auth),
+		B: goa.Intercept("B", NewBEndpoint(s), i.Logging),
+	}
+}
+
+// Use applies the given middleware to all the "Intercepted" service endpoints.
+func (e *Endpoints) Use(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+	e.B = m(e.B)
+}
+
+// UseA applies the given middleware to the "A" endpoint of the "Intercepted"
+// service.
+func (e *Endpoints) UseA(m func(goa.Endpoint) goa.Endpoint) {
+	e.A = m(e.A)
+}
+
+// UseB applies the given middleware to the "B" endpoint of the "Intercepted"
+// service.
+func (e *Endpoints) UseB(m func(goa.Endpoint) goa.Endpoint) {
+	e.B = m(e.B)
+}
+
+// NewAEndpoint returns an endpoint function that calls the method "A" of
+// service "Intercepted".
+func NewAEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		p := req.(*AType)
+		return nil, s.A(ctx, p)
+	}
+}
+
+// NewBEndpoint returns an endpoint function that calls the method "B" of
+// service "Intercepted".
+func NewBEndpoint(s Service) goa.Endpoint {
+	return func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, s.B(ctx)
+	}
+}
+`
@@ -119,3 +119,78 @@ var StreamingResultNoPayloadEndpointDSL = func() {
 		})
 	})
 }
+
+var SkipBodyEncodeDecodeEndpointDSL = func() {
+	var AType = Type("AType", func() {
+		Attribute("a", String)
+	})
+	var RType = Type("RType", func() {
+		Attribute("b", String)
+	})
+	Service("SkipBodyEncodeDecode", func() {
+		Method("Upload", func() {
+			Payload(AType)
+			Metadata("goa:skip-request-body-encode-decode")
+		})
+		Method("Download", func() {
+			Result(RType)
+			Metadata("goa:skip-response-body-encode-decode")
+		})
+	})
+}
+
+var InterceptedEndpointDSL = func() {
+	var AType = Type("AType", func() {
+		Attribute("a", String)
+	})
+	Service("Intercepted", func() {
+		Interceptor("logging")
+		Method("A", func() {
+			Interceptor("auth", "logging")
+			Payload(AType)
+		})
+		Method("B", func() {
+		})
+	})
+}
+
+var CursorPaginatedEndpointDSL = func() {
+	Service("CursorPaginated", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("cursor", String)
+				Attribute("limit", Int)
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(String))
+				Attribute("next", String)
+			})
+			Paginate(func() {
+				Cursor("cursor", "next")
+				Items("items")
+			})
+		})
+	})
+}
+
+var OffsetPaginatedEndpointDSL = func() {
+	var IType = Type("IType", func() {
+		Attribute("id", String)
+	})
+	Service("OffsetPaginated", func() {
+		Method("List", func() {
+			Payload(func() {
+				Attribute("offset", Int)
+				Attribute("limit", Int)
+				Required("limit")
+			})
+			Result(func() {
+				Attribute("items", ArrayOf(IType))
+			})
+			Paginate(func() {
+				Offset("offset", "limit")
+				Items("items")
+			})
+		})
+	})
+}
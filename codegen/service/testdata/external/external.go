@@ -1,5 +1,28 @@
 package external
 
+import "goa.design/goa/codegen/service/testdata/external/nested"
+
 type ConvertModel struct {
 	Foo string
-}
\ No newline at end of file
+}
+type IDModel struct {
+	ID ID
+}
+
+type ID struct {
+	value string
+}
+
+func ParseID(s string) ID {
+	return ID{value: s}
+}
+
+func FormatID(id ID) string {
+	return id.value
+}
+
+type NestedModel struct {
+	Items  []*nested.Item
+	ByName map[string]nested.Item
+	Main   nested.Item
+}
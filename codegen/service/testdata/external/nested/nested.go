@@ -0,0 +1,5 @@
+package nested
+
+type Item struct {
+	Name string
+}
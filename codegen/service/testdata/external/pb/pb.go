@@ -0,0 +1,67 @@
+package pb
+
+import "goa.design/goa/codegen/service/testdata/external/timestamp"
+
+type Color int32
+
+const (
+	Color_RED   Color = 0
+	Color_GREEN Color = 1
+)
+
+var Color_name = map[int32]string{
+	0: "RED",
+	1: "GREEN",
+}
+
+var Color_value = map[string]int32{
+	"RED":   0,
+	"GREEN": 1,
+}
+
+func (x Color) Enum() *Color {
+	p := new(Color)
+	*p = x
+	return p
+}
+
+func (x Color) String() string {
+	return Color_name[int32(x)]
+}
+
+type Bottle struct {
+	Name      string               `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Color     Color                `protobuf:"varint,2,opt,name=color,proto3,enum=pb.Color" json:"color,omitempty"`
+	CreatedAt *timestamp.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Types that are valid to be assigned to Vintage:
+	//	*Bottle_Year
+	//	*Bottle_Label
+	Vintage              isBottle_Vintage `protobuf_oneof:"vintage"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+type isBottle_Vintage interface {
+	isBottle_Vintage()
+}
+
+type Bottle_Year struct {
+	Year int32 `protobuf:"varint,4,opt,name=year,proto3,oneof"`
+}
+
+type Bottle_Label struct {
+	Label string `protobuf:"bytes,5,opt,name=label,proto3,oneof"`
+}
+
+func (*Bottle_Year) isBottle_Vintage() {}
+
+func (*Bottle_Label) isBottle_Vintage() {}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Bottle) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Bottle_Year)(nil),
+		(*Bottle_Label)(nil),
+	}
+}
@@ -0,0 +1,99 @@
+package testdata
+
+const SingleMethodMocks = `// Client is a fake "SingleEndpoint" service client for use in tests. It
+// implements the same methods as the service client: the calls are recorded
+// and forwarded to the functions set with the Stub methods. Methods that have
+// no stub return zero values.
+type Client struct {
+	mu sync.Mutex
+	aStub func(context.Context, *singleendpoint.AType) error
+	aCalls []*ACall
+}
+
+// ACall records a call made to the "A" method.
+type ACall struct {
+	// Ctx is the context given to the method.
+	Ctx context.Context
+	// Payload is the method payload.
+	Payload *singleendpoint.AType
+}
+
+// NewClient returns a fake "SingleEndpoint" service client with no stub.
+func NewClient() *Client {
+	return &Client{}
+}
+
+// A records the call and invokes the function set with StubA.
+func (c *Client) A(ctx context.Context, p *singleendpoint.AType) (err error) {
+	c.mu.Lock()
+	c.aCalls = append(c.aCalls, &ACall{Ctx: ctx, Payload: p})
+	stub := c.aStub
+	c.mu.Unlock()
+	if stub == nil {
+		return
+	}
+	return stub(ctx, p)
+}
+
+// StubA sets the function invoked by A.
+func (c *Client) StubA(f func(context.Context, *singleendpoint.AType) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.aStub = f
+}
+
+// ACalls returns the calls made to A in order.
+func (c *Client) ACalls() []*ACall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*ACall(nil), c.aCalls...)
+}
+`
+
+const NoPayloadMethodMocks = `// Client is a fake "NoPayload" service client for use in tests. It implements
+// the same methods as the service client: the calls are recorded and forwarded
+// to the functions set with the Stub methods. Methods that have no stub return
+// zero values.
+type Client struct {
+	mu sync.Mutex
+	noPayloadStub func(context.Context) error
+	noPayloadCalls []*NoPayloadCall
+}
+
+// NoPayloadCall records a call made to the "NoPayload" method.
+type NoPayloadCall struct {
+	// Ctx is the context given to the method.
+	Ctx context.Context
+}
+
+// NewClient returns a fake "NoPayload" service client with no stub.
+func NewClient() *Client {
+	return &Client{}
+}
+
+// NoPayload records the call and invokes the function set with StubNoPayload.
+func (c *Client) NoPayload(ctx context.Context) (err error) {
+	c.mu.Lock()
+	c.noPayloadCalls = append(c.noPayloadCalls, &NoPayloadCall{Ctx: ctx})
+	stub := c.noPayloadStub
+	c.mu.Unlock()
+	if stub == nil {
+		return
+	}
+	return stub(ctx)
+}
+
+// StubNoPayload sets the function invoked by NoPayload.
+func (c *Client) StubNoPayload(f func(context.Context) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.noPayloadStub = f
+}
+
+// NoPayloadCalls returns the calls made to NoPayload in order.
+func (c *Client) NoPayloadCalls() []*NoPayloadCall {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*NoPayloadCall(nil), c.noPayloadCalls...)
+}
+`
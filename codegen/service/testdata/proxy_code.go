@@ -0,0 +1,92 @@
+package testdata
+
+const ProxyCode = `// Proxy implements the methods of the "Gateway" service that forward the
+// requests to the services of other designs. Embed Proxy in the service
+// implementation to provide these methods.
+type Proxy struct {
+	usersClient *users.Client
+	ordersClient *orders.Client
+}
+
+// NewProxy returns a Proxy that forwards the requests using the given remote
+// service clients.
+func NewProxy(usersClient *users.Client, ordersClient *orders.Client) *Proxy {
+	return &Proxy{
+		usersClient: usersClient,
+		ordersClient: ordersClient,
+	}
+}
+
+// ShowUser forwards the requests to the "show" method of the "users" service.
+// The compatibility of the payload and result types with the remote method is
+// not checked at compile time.
+func (px *Proxy) ShowUser(ctx context.Context, p *ShowUserPayload) (res *User, view string, err error) {
+	err = goa.Forward(ctx, px.usersClient.Show, p, &res)
+	view = "default"
+	return
+}
+
+// DeleteUser forwards the requests to the "delete" method of the "users"
+// service. The compatibility of the payload and result types with the remote
+// method is not checked at compile time.
+func (px *Proxy) DeleteUser(ctx context.Context, p string) (err error) {
+	err = goa.Forward(ctx, px.usersClient.Delete, p, nil)
+	return
+}
+
+// ListOrders forwards the requests to the "list" method of the "orders"
+// service. The compatibility of the payload and result types with the remote
+// method is not checked at compile time.
+func (px *Proxy) ListOrders(ctx context.Context) (res []string, err error) {
+	err = goa.Forward(ctx, px.ordersClient.List, nil, &res)
+	return
+}
+`
+
+const ProxySameNameCode = `// Proxy implements the methods of the "Users" service that forward the
+// requests to the services of other designs. Embed Proxy in the service
+// implementation to provide these methods.
+type Proxy struct {
+	usersremoteClient *usersremote.Client
+}
+
+// NewProxy returns a Proxy that forwards the requests using the given remote
+// service clients.
+func NewProxy(usersremoteClient *usersremote.Client) *Proxy {
+	return &Proxy{
+		usersremoteClient: usersremoteClient,
+	}
+}
+
+// Show forwards the requests to the "show" method of the "users" service. The
+// compatibility of the payload and result types with the remote method is not
+// checked at compile time.
+func (px *Proxy) Show(ctx context.Context, p string) (res string, err error) {
+	err = goa.Forward(ctx, px.usersremoteClient.Show, p, &res)
+	return
+}
+`
+
+const ProxyTypeNameCode = `// ProxyService implements the methods of the "Hosts" service that forward the
+// requests to the services of other designs. Embed ProxyService in the service
+// implementation to provide these methods.
+type ProxyService struct {
+	registryClient *registry.Client
+}
+
+// NewProxyService returns a ProxyService that forwards the requests using the
+// given remote service clients.
+func NewProxyService(registryClient *registry.Client) *ProxyService {
+	return &ProxyService{
+		registryClient: registryClient,
+	}
+}
+
+// Register forwards the requests to the "register" method of the "registry"
+// service. The compatibility of the payload and result types with the remote
+// method is not checked at compile time.
+func (px *ProxyService) Register(ctx context.Context, p *Proxy) (err error) {
+	err = goa.Forward(ctx, px.registryClient.Register, p, nil)
+	return
+}
+`
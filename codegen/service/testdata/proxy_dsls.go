@@ -0,0 +1,73 @@
+package testdata
+
+import (
+	. "goa.design/goa/design"
+	. "goa.design/goa/dsl"
+)
+
+var ProxyDSL = func() {
+	var User = ResultType("application/vnd.user", func() {
+		Attributes(func() {
+			Attribute("id", String)
+			Attribute("name", String)
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("name")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("Gateway", func() {
+		Method("ShowUser", func() {
+			Payload(func() {
+				Token("token", String)
+				Attribute("id", String)
+			})
+			Result(User)
+			Proxy("acme.com/users/gen", "users", "show")
+		})
+		Method("DeleteUser", func() {
+			Payload(String)
+			Proxy("acme.com/users/gen", "users", "delete")
+		})
+		Method("ListOrders", func() {
+			Result(ArrayOf(String))
+			Proxy("acme.com/orders/gen", "orders", "list")
+		})
+		Method("Health", func() {
+			Result(String)
+		})
+	})
+}
+
+var ProxySameNameDSL = func() {
+	Service("Users", func() {
+		Method("Show", func() {
+			Payload(String)
+			Result(String)
+			Proxy("acme.com/users/gen", "users", "show")
+		})
+	})
+}
+
+var NoProxyDSL = func() {
+	Service("NoProxy", func() {
+		Method("Health", func() {
+			Result(String)
+		})
+	})
+}
+
+var ProxyTypeNameDSL = func() {
+	var ProxyType = Type("Proxy", func() {
+		Attribute("host", String)
+	})
+	Service("Hosts", func() {
+		Method("Register", func() {
+			Payload(ProxyType)
+			Proxy("acme.com/registry/gen", "registry", "register")
+		})
+	})
+}
@@ -55,6 +55,9 @@ func NewSecureWithRequiredScopesEndpoint(s Service, authJWTFn security.AuthJWTFu
 			token = *p.Token
 		}
 		ctx, err = authJWTFn(ctx, token, &sc)
+		if err == nil {
+			err = security.CheckScopes(ctx, sc.RequiredScopes)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -416,6 +416,27 @@ func NewViewedMultipleViewsCollection(res MultipleViewsCollection, view string)
 	return vres
 }
 
+// NewViewedMultipleViewsCollectionMixed initializes viewed result type
+// MultipleViewsCollection from result type MultipleViewsCollection rendering
+// each element with the view at the same index in views. Elements with no view
+// use the given view which is also the view of the viewed result type.
+func NewViewedMultipleViewsCollectionMixed(res MultipleViewsCollection, view string, views []string) resultcollectionmultipleviewsmethodviews.MultipleViewsCollection {
+	p := make(resultcollectionmultipleviewsmethodviews.MultipleViewsCollectionView, len(res))
+	for i, n := range res {
+		v := view
+		if i < len(views) && views[i] != "" {
+			v = views[i]
+		}
+		switch v {
+		case "default", "":
+			p[i] = newMultipleViewsView(n)
+		case "tiny":
+			p[i] = newMultipleViewsViewTiny(n)
+		}
+	}
+	return resultcollectionmultipleviewsmethodviews.MultipleViewsCollection{p, view}
+}
+
 // newMultipleViewsCollection converts projected type MultipleViewsCollection
 // to service type MultipleViewsCollection.
 func newMultipleViewsCollection(vres resultcollectionmultipleviewsmethodviews.MultipleViewsCollectionView) MultipleViewsCollection {
@@ -790,3 +811,192 @@ type AResult struct {
 	OptionalField *string
 }
 `
+
+const SkipBodyEncodeDecodeMethod = `
+// Service is the SkipBodyEncodeDecodeService service interface.
+type Service interface {
+	// SkipBodyEncodeDecodeMethod implements SkipBodyEncodeDecodeMethod.
+	SkipBodyEncodeDecodeMethod(context.Context, *APayload, io.ReadCloser) (res *AResult, body io.ReadCloser, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "SkipBodyEncodeDecodeService"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"SkipBodyEncodeDecodeMethod"}
+
+// APayload is the payload type of the SkipBodyEncodeDecodeService service
+// SkipBodyEncodeDecodeMethod method.
+type APayload struct {
+	IntField      int
+	StringField   string
+	BooleanField  bool
+	BytesField    []byte
+	OptionalField *string
+}
+
+// AResult is the result type of the SkipBodyEncodeDecodeService service
+// SkipBodyEncodeDecodeMethod method.
+type AResult struct {
+	IntField      int
+	StringField   string
+	BooleanField  bool
+	BytesField    []byte
+	OptionalField *string
+}
+`
+
+const EnumTypeMethod = `
+// Service is the EnumTypeService service interface.
+type Service interface {
+	// EnumTypeMethod implements EnumTypeMethod.
+	EnumTypeMethod(context.Context, *EnumTypeMethodPayload) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "EnumTypeService"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"EnumTypeMethod"}
+
+// EnumTypeMethodPayload is the payload type of the EnumTypeService service
+// EnumTypeMethod method.
+type EnumTypeMethodPayload struct {
+	Color Color
+	Level *Level
+}
+
+// Color enumerates the values of the "color" attribute.
+type Color string
+
+const (
+	// ColorRed is the Color value "red".
+	ColorRed Color = "red"
+	// ColorGreen is the Color value "green".
+	ColorGreen Color = "green"
+)
+
+// String returns the string representation of the Color value.
+func (v Color) String() string {
+	return string(v)
+}
+
+// ParseColor returns the Color value corresponding to s or an error if s is
+// not one of the enum values.
+func ParseColor(s string) (Color, error) {
+	switch s {
+	case "red":
+		return ColorRed, nil
+	case "green":
+		return ColorGreen, nil
+	}
+	return "", fmt.Errorf("invalid Color value %q", s)
+}
+
+// Level enumerates the values of the "level" attribute.
+type Level int
+
+const (
+	// Level1 is the Level value "1".
+	Level1 Level = 1
+	// Level2 is the Level value "2".
+	Level2 Level = 2
+)
+
+// String returns the string representation of the Level value.
+func (v Level) String() string {
+	return fmt.Sprint(int(v))
+}
+
+// ParseLevel returns the Level value corresponding to s or an error if s is
+// not one of the enum values.
+func ParseLevel(s string) (Level, error) {
+	switch s {
+	case "1":
+		return Level1, nil
+	case "2":
+		return Level2, nil
+	}
+	return 0, fmt.Errorf("invalid Level value %q", s)
+}
+`
+
+const DeprecatedMethod = `
+// Service is the DeprecatedService service interface.
+type Service interface {
+	// DeprecatedMethod lists things.
+	//
+	// Deprecated: use ListMethod instead
+	DeprecatedMethod(context.Context, *DeprecatedMethodPayload) (err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "DeprecatedService"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"DeprecatedMethod"}
+
+// DeprecatedMethodPayload is the payload type of the DeprecatedService service
+// DeprecatedMethod method.
+type DeprecatedMethodPayload struct {
+	// Filter to apply
+	//
+	// Deprecated: use query instead
+	Filter *string
+	Query  *string
+}
+`
+
+const NamespaceMethod = `
+// Service is the NamespaceService service interface.
+type Service interface {
+	// NamespaceMethod implements NamespaceMethod.
+	NamespaceMethod(context.Context, *NamespaceMethodPayload) (res *NamespaceMethodResult, err error)
+}
+
+// ServiceName is the name of the service as defined in the design. This is the
+// same value that is set in the endpoint request contexts under the ServiceKey
+// key.
+const ServiceName = "NamespaceService"
+
+// MethodNames lists the service method names as defined in the design. These
+// are the same values that are set in the endpoint request contexts under the
+// MethodKey key.
+var MethodNames = [1]string{"NamespaceMethod"}
+
+// NamespaceMethodPayload is the payload type of the NamespaceService service
+// NamespaceMethod method.
+type NamespaceMethodPayload struct {
+	ID       *CommonUUID
+	Invoices *BillingPage
+}
+
+// NamespaceMethodResult is the result type of the NamespaceService service
+// NamespaceMethod method.
+type NamespaceMethodResult struct {
+	Page *CommonPage
+}
+
+type CommonUUID string
+
+type BillingPage struct {
+	Number *int
+}
+
+type CommonPage struct {
+	Cursor *CommonUUID
+	Size   *int
+}
+`
@@ -237,3 +237,78 @@ var StreamingResultNoPayloadMethodDSL = func() {
 		})
 	})
 }
+
+var SkipBodyEncodeDecodeMethodDSL = func() {
+	Service("SkipBodyEncodeDecodeService", func() {
+		Method("SkipBodyEncodeDecodeMethod", func() {
+			Payload(APayload)
+			Result(AResult)
+			Metadata("goa:skip-request-body-encode-decode")
+			Metadata("goa:skip-response-body-encode-decode")
+		})
+	})
+}
+
+var EnumTypeMethodDSL = func() {
+	Service("EnumTypeService", func() {
+		Method("EnumTypeMethod", func() {
+			Payload(func() {
+				Attribute("color", String, func() {
+					Enum("red", "green")
+					Metadata("struct:enum", "Color")
+				})
+				Attribute("level", Int, func() {
+					Enum(1, 2)
+					Metadata("struct:enum")
+				})
+				Required("color")
+			})
+		})
+	})
+}
+
+var DeprecatedMethodDSL = func() {
+	Service("DeprecatedService", func() {
+		Method("DeprecatedMethod", func() {
+			Description("DeprecatedMethod lists things.")
+			Deprecated("use ListMethod instead")
+			Payload(func() {
+				Attribute("filter", String, "Filter to apply", func() {
+					Deprecated("use query instead")
+				})
+				Attribute("query", String)
+			})
+		})
+	})
+}
+
+var NamespaceMethodDSL = func() {
+	Namespace("common", func() {
+		Type("UUID", String, func() {
+			Format(FormatUUID)
+		})
+		Type("Page", func() {
+			Attribute("cursor", "UUID")
+			Attribute("size", Int)
+		})
+	})
+	Namespace("billing", func() {
+		Type("Page", func() {
+			Attribute("number", Int)
+		})
+	})
+	API("NamespaceAPI", func() {
+		Import("common")
+	})
+	Service("NamespaceService", func() {
+		Method("NamespaceMethod", func() {
+			Payload(func() {
+				Attribute("id", "UUID")
+				Attribute("invoices", "billing.Page")
+			})
+			Result(func() {
+				Attribute("page", "Page")
+			})
+		})
+	})
+}
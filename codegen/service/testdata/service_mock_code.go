@@ -0,0 +1,103 @@
+package testdata
+
+const SingleMethodMock = `// MockService is a mock implementation of the "SingleMethod" service Service
+// interface for use in tests. Assign the Func fields to define the behavior of
+// the methods, methods whose field is nil return zero values. The calls are
+// counted and can be checked with AssertCalls.
+type MockService struct {
+	// AFunc implements A.
+	AFunc func(context.Context, *APayload) (res *AResult, err error)
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+var _ Service = (*MockService)(nil)
+
+// Calls returns the number of calls made to the method with the given name as
+// defined in the design.
+func (m *MockService) Calls(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[method]
+}
+
+// AssertCalls reports an error to t if the method with the given name as
+// defined in the design was not called exactly n times.
+func (m *MockService) AssertCalls(t testing.TB, method string, n int) {
+	t.Helper()
+	if c := m.Calls(method); c != n {
+		t.Errorf("got %d calls to %q, expected %d", c, method, n)
+	}
+}
+
+// record records a call made to the method with the given name.
+func (m *MockService) record(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[method]++
+}
+
+// A records the call and invokes AFunc.
+func (m *MockService) A(ctx context.Context, p *APayload) (res *AResult, err error) {
+	m.record("A")
+	if m.AFunc == nil {
+		return
+	}
+	return m.AFunc(ctx, p)
+}
+`
+
+const StreamingResultMethodMock = `// MockService is a mock implementation of the "StreamingResultService" service
+// Service interface for use in tests. Assign the Func fields to define the
+// behavior of the methods, methods whose field is nil return zero values. The
+// calls are counted and can be checked with AssertCalls.
+type MockService struct {
+	// StreamingResultMethodFunc implements StreamingResultMethod.
+	StreamingResultMethodFunc func(context.Context, *APayload, StreamingResultMethodServerStream) (err error)
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+var _ Service = (*MockService)(nil)
+
+// Calls returns the number of calls made to the method with the given name as
+// defined in the design.
+func (m *MockService) Calls(method string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[method]
+}
+
+// AssertCalls reports an error to t if the method with the given name as
+// defined in the design was not called exactly n times.
+func (m *MockService) AssertCalls(t testing.TB, method string, n int) {
+	t.Helper()
+	if c := m.Calls(method); c != n {
+		t.Errorf("got %d calls to %q, expected %d", c, method, n)
+	}
+}
+
+// record records a call made to the method with the given name.
+func (m *MockService) record(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.calls == nil {
+		m.calls = make(map[string]int)
+	}
+	m.calls[method]++
+}
+
+// StreamingResultMethod records the call and invokes StreamingResultMethodFunc.
+func (m *MockService) StreamingResultMethod(ctx context.Context, p *APayload, stream StreamingResultMethodServerStream) (err error) {
+	m.record("StreamingResultMethod")
+	if m.StreamingResultMethodFunc == nil {
+		return
+	}
+	return m.StreamingResultMethodFunc(ctx, p, stream)
+}
+`
@@ -10,6 +10,14 @@ type StringPointerT struct {
 	String *string
 }
 
+type TimeT struct {
+	Time time.Time
+}
+
+type TimePointerT struct {
+	Time *time.Time
+}
+
 type ArrayStringT struct {
 	ArrayString []string
 }
@@ -38,3 +46,17 @@ type ObjectFieldT struct {
 	Array   []bool
 	Map     map[string]bool
 }
+
+type ArrayObjectT struct {
+	Objects []*ObjectFieldT
+}
+
+type MapObjectT struct {
+	Objects map[string]ObjectFieldT
+}
+
+type MappedFieldsT struct {
+	LegacyName  string
+	LegacyCount *int
+	Object      *ObjectFieldT
+}
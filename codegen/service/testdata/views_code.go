@@ -103,6 +103,31 @@ func (result ResultTypeCollectionView) ValidateTiny() (err error) {
 	return
 }
 
+// ValidateMixed runs the validations defined on ResultTypeCollectionView using
+// the view at the same index in views for each element. Elements with no view
+// are validated using the given view.
+func (result ResultTypeCollectionView) ValidateMixed(view string, views []string) (err error) {
+	for i, item := range result {
+		v := view
+		if i < len(views) && views[i] != "" {
+			v = views[i]
+		}
+		switch v {
+		case "default", "":
+			if err2 := item.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		case "tiny":
+			if err2 := item.ValidateTiny(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		default:
+			err = goa.MergeErrors(err, goa.InvalidEnumValueError("view", v, []interface{}{"default", "tiny"}))
+		}
+	}
+	return
+}
+
 // Validate runs the validations defined on ResultTypeView using the "default"
 // view.
 func (result *ResultTypeView) Validate() (err error) {
@@ -14,7 +14,7 @@ func ViewsFile(genpkg string, service *design.ServiceExpr) *codegen.File {
 	if len(svc.ProjectedTypes) == 0 {
 		return nil
 	}
-	path := filepath.Join(codegen.Gendir, codegen.SnakeCase(service.Name), "views", "view.go")
+	path := filepath.Join(codegen.ServiceGendir(service), codegen.SnakeCase(service.Name), "views", "view.go")
 	var (
 		sections []*codegen.SectionTemplate
 	)
@@ -58,6 +58,13 @@ func ViewsFile(genpkg string, service *design.ServiceExpr) *codegen.File {
 					Data:   v,
 				})
 			}
+			if t.MixedValidation != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "validate-mixed-projected-type",
+					Source: validateMixedT,
+					Data:   t.MixedValidation,
+				})
+			}
 		}
 	}
 
@@ -71,3 +78,11 @@ func (result {{ .Ref }}) {{ .Name }}() (err error) {
   return
 }
 `
+
+// input: ValidateData
+const validateMixedT = `{{ comment .Description }}
+func (result {{ .Ref }}) {{ .Name }}(view string, views []string) (err error) {
+	{{ .Validate }}
+  return
+}
+`
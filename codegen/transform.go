@@ -53,11 +53,18 @@ type (
 		unmarshal            bool
 		scope                *NameScope
 	}
+
+	// helperKey identifies the transform helpers memoized in a scope.
+	helperKey struct {
+		source, target       design.DataType
+		sourcePkg, targetPkg string
+		unmarshal            bool
+	}
 )
 
 // NOTE: can't initialize inline because https://github.com/golang/go/issues/1817
 func init() {
-	funcMap := template.FuncMap{"transformAttribute": transformAttributeHelper}
+	funcMap := template.FuncMap{"transformAttribute": transformAttributeHelper, "isValue": isValue}
 	transformArrayT = template.Must(template.New("transformArray").Funcs(funcMap).Parse(transformArrayTmpl))
 	transformMapT = template.Must(template.New("transformMap").Funcs(funcMap).Parse(transformMapTmpl))
 }
@@ -98,6 +105,13 @@ func init() {
 // scope is used to compute the name of the user types when initializing fields
 // that use them.
 //
+// Primitive fields whose target attribute defines the "convert:encode"
+// metadata or whose source attribute defines the "convert:decode" metadata are
+// initialized by calling the function named by the metadata value with the
+// source field value. The functions of attributes that define the
+// "convert:byref" metadata accept and return pointers. Attributes that define
+// the "convert:value" metadata hold structs by value rather than by reference.
+//
 func GoTypeTransform(source, target design.DataType, sourceVar, targetVar, sourcePkg, targetPkg string, unmarshal bool, scope *NameScope) (string, []*TransformFunctionData, error) {
 
 	var (
@@ -111,8 +125,7 @@ func GoTypeTransform(source, target design.DataType, sourceVar, targetVar, sourc
 		return "", nil, err
 	}
 
-	b := thargs{sourcePkg, targetPkg, unmarshal, scope}
-	funcs, err := transformAttributeHelpers(source, target, b)
+	funcs, err := transformHelpers(source, target, thargs{sourcePkg, targetPkg, unmarshal, scope})
 	if err != nil {
 		return "", nil, err
 	}
@@ -120,6 +133,25 @@ func GoTypeTransform(source, target design.DataType, sourceVar, targetVar, sourc
 	return strings.TrimRight(code, "\n"), funcs, nil
 }
 
+// transformHelpers returns the transform helper functions required to
+// transform source into target. The helpers are memoized in the scope as the
+// same types are usually transformed by multiple endpoints. The returned slice
+// must not be modified.
+func transformHelpers(source, target design.DataType, a thargs) ([]*TransformFunctionData, error) {
+	key := helperKey{source, target, a.sourcePkg, a.targetPkg, a.unmarshal}
+	if funcs, ok := a.scope.helpers[key]; ok {
+		return funcs, nil
+	}
+	funcs, err := transformAttributeHelpers(source, target, a)
+	if err != nil {
+		return nil, err
+	}
+	if a.scope.helpers != nil {
+		a.scope.helpers[key] = funcs
+	}
+	return funcs, nil
+}
+
 func transformAttribute(source, target *design.AttributeExpr, newVar bool, a targs) (string, error) {
 	if err := isCompatible(source.Type, target.Type, a.sourceVar, a.targetVar); err != nil {
 		return "", err
@@ -159,25 +191,47 @@ func transformObject(source, target *design.AttributeExpr, newVar bool, a targs)
 		initCode     string
 		postInitCode string
 	)
-	walkMatches(source, target, func(src, tgt *design.MappedAttributeExpr, srcAtt, _ *design.AttributeExpr, n string) {
+	walkMatches(source, target, func(src, tgt *design.MappedAttributeExpr, srcAtt, tgtAtt *design.AttributeExpr, n string) {
 		if !design.IsPrimitive(srcAtt.Type) {
 			return
 		}
-		srcPtr := a.unmarshal || source.IsPrimitivePointer(n, !a.unmarshal)
-		tgtPtr := target.IsPrimitivePointer(n, true)
+		srcPtr := a.unmarshal || src.IsPrimitivePointer(n, !a.unmarshal)
+		tgtPtr := tgt.IsPrimitivePointer(n, true)
 		deref := ""
 		srcField := a.sourceVar + "." + Goify(src.ElemName(n), true)
+		if fn := scalarConversion(srcAtt, tgtAtt); fn != "" {
+			tgtField := a.targetVar + "." + Goify(tgt.ElemName(n), true)
+			arg := srcField
+			if srcPtr && !isByRef(srcAtt) {
+				arg = "*" + srcField
+			}
+			tgtPtr = tgtPtr && !isByRef(tgtAtt)
+			switch {
+			case srcPtr && tgtPtr:
+				postInitCode += fmt.Sprintf("if %s != nil {\n\ttmp := %s(%s)\n\t%s = &tmp\n}\n", srcField, fn, arg, tgtField)
+			case srcPtr && !src.IsRequired(n):
+				postInitCode += fmt.Sprintf("if %s != nil {\n\t%s = %s(%s)\n}\n", srcField, tgtField, fn, arg)
+			case srcPtr:
+				initCode += fmt.Sprintf("\n%s: %s(%s),", Goify(tgt.ElemName(n), true), fn, arg)
+			case tgtPtr:
+				postInitCode += fmt.Sprintf("{\n\ttmp := %s(%s)\n\t%s = &tmp\n}\n", fn, srcField, tgtField)
+			default:
+				initCode += fmt.Sprintf("\n%s: %s(%s),", Goify(tgt.ElemName(n), true), fn, srcField)
+			}
+			return
+		}
+		conv := enumConversion(srcAtt, tgtAtt, n, a)
 		if srcPtr && !tgtPtr {
-			if !source.IsRequired(n) {
+			if !src.IsRequired(n) {
 				postInitCode += fmt.Sprintf("if %s != nil {\n\t%s.%s = %s\n}\n",
-					srcField, a.targetVar, Goify(tgt.ElemName(n), true), "*"+srcField)
+					srcField, a.targetVar, Goify(tgt.ElemName(n), true), convert("*"+srcField, conv, false))
 				return
 			}
 			deref = "*"
 		} else if !srcPtr && tgtPtr {
 			deref = "&"
 		}
-		initCode += fmt.Sprintf("\n%s: %s%s,", Goify(tgt.ElemName(n), true), deref, srcField)
+		initCode += fmt.Sprintf("\n%s: %s,", Goify(tgt.ElemName(n), true), convert(deref+srcField, conv, tgtPtr))
 	})
 	if initCode != "" {
 		initCode += "\n"
@@ -191,6 +245,10 @@ func transformObject(source, target *design.AttributeExpr, newVar bool, a targs)
 	if _, ok := target.Type.(*design.Object); ok {
 		deref = ""
 	}
+	// same if the target is an external struct held by value
+	if isValue(target) {
+		deref = ""
+	}
 	buffer.WriteString(fmt.Sprintf("%s %s %s%s{%s}\n", a.targetVar, assign, deref,
 		a.scope.GoFullTypeName(target, a.targetPkg), initCode))
 	buffer.WriteString(postInitCode)
@@ -214,7 +272,14 @@ func transformObject(source, target *design.AttributeExpr, newVar bool, a targs)
 		case design.IsMap(srcAtt.Type):
 			code, err = transformMap(design.AsMap(srcAtt.Type), design.AsMap(tgtAtt.Type), false, b)
 		case ok:
-			code = fmt.Sprintf("%s = %s(%s)\n", b.targetVar, transformHelperName(srcAtt, tgtAtt, b), b.sourceVar)
+			src, call := b.sourceVar, transformHelperName(srcAtt, tgtAtt, b)
+			if isValue(srcAtt) {
+				src = "&" + src
+			}
+			if isValue(tgtAtt) {
+				call = "*" + call
+			}
+			code = fmt.Sprintf("%s = %s(%s)\n", b.targetVar, call, src)
 		case design.IsObject(srcAtt.Type):
 			code, err = transformAttribute(srcAtt, tgtAtt, false, b)
 		}
@@ -237,7 +302,7 @@ func transformObject(source, target *design.AttributeExpr, newVar bool, a targs)
 		{
 			isRef := !design.IsPrimitive(srcAtt.Type) && !src.IsRequired(n) || src.IsPrimitivePointer(n, !b.unmarshal)
 			marshalNonPrimitive := !b.unmarshal && !design.IsPrimitive(srcAtt.Type)
-			checkNil = isRef || marshalNonPrimitive
+			checkNil = (isRef || marshalNonPrimitive) && !isValue(srcAtt)
 		}
 		if code != "" && checkNil {
 			code = fmt.Sprintf("if %s != nil {\n\t%s}\n", b.sourceVar, code)
@@ -258,10 +323,17 @@ func transformObject(source, target *design.AttributeExpr, newVar bool, a targs)
 		// When generating unmarshaler code we rely on validations
 		// running prior to this code so assume required fields are set.
 		if tgt.HasDefaultValue(n) {
+			var tgtTypeName string
+			if tgt.IsPrimitivePointer(n, true) {
+				tgtTypeName = GoNativeTypeName(tgtAtt.Type)
+				if en := GoEnumTypeName(tgtAtt, n); en != "" {
+					tgtTypeName = qualify(en, b.targetPkg)
+				}
+			}
 			if b.unmarshal {
 				code += fmt.Sprintf("if %s == nil {\n\t", b.sourceVar)
 				if tgt.IsPrimitivePointer(n, true) {
-					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", GoNativeTypeName(tgtAtt.Type), tgtAtt.DefaultValue, b.targetVar)
+					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", tgtTypeName, tgtAtt.DefaultValue, b.targetVar)
 				} else {
 					code += fmt.Sprintf("%s = %#v\n", b.targetVar, tgtAtt.DefaultValue)
 				}
@@ -269,7 +341,7 @@ func transformObject(source, target *design.AttributeExpr, newVar bool, a targs)
 			} else if src.IsPrimitivePointer(n, true) || !design.IsPrimitive(srcAtt.Type) {
 				code += fmt.Sprintf("if %s == nil {\n\t", b.sourceVar)
 				if tgt.IsPrimitivePointer(n, true) {
-					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", GoNativeTypeName(tgtAtt.Type), tgtAtt.DefaultValue, b.targetVar)
+					code += fmt.Sprintf("var tmp %s = %#v\n\t%s = &tmp\n", tgtTypeName, tgtAtt.DefaultValue, b.targetVar)
 				} else {
 					code += fmt.Sprintf("%s = %#v\n", b.targetVar, tgtAtt.DefaultValue)
 				}
@@ -286,6 +358,75 @@ func transformObject(source, target *design.AttributeExpr, newVar bool, a targs)
 	return buffer.String(), nil
 }
 
+// enumConversion returns the Go type the value of the source attribute with
+// the given name must be converted to when assigned to the target attribute.
+// Only the service types use the named enum types (see GoEnumTypeName), the
+// value must be converted when exactly one of the attributes uses it. The
+// function returns the empty string if no conversion is needed.
+func enumConversion(srcAtt, tgtAtt *design.AttributeExpr, name string, a targs) string {
+	var (
+		srcEnum = GoEnumTypeName(srcAtt, name)
+		tgtEnum = GoEnumTypeName(tgtAtt, name)
+	)
+	switch {
+	case tgtEnum != "" && srcEnum == "":
+		return qualify(tgtEnum, a.targetPkg)
+	case tgtEnum == "" && srcEnum != "":
+		return GoNativeTypeName(srcAtt.Type)
+	}
+	return ""
+}
+
+// scalarConversion returns the name of the function that converts the value
+// of the source attribute into the value of the target attribute if any. The
+// function is given by the "convert:encode" metadata of the target attribute
+// or by the "convert:decode" metadata of the source attribute. The function
+// returns the empty string if no conversion is needed.
+func scalarConversion(srcAtt, tgtAtt *design.AttributeExpr) string {
+	if m := tgtAtt.Metadata["convert:encode"]; len(m) > 0 {
+		return m[0]
+	}
+	if m := srcAtt.Metadata["convert:decode"]; len(m) > 0 {
+		return m[0]
+	}
+	return ""
+}
+
+// isByRef returns true if the conversion functions of the given attribute
+// accept and return pointers, see the "convert:byref" metadata.
+func isByRef(att *design.AttributeExpr) bool {
+	_, ok := att.Metadata["convert:byref"]
+	return ok
+}
+
+// isValue returns true if the given attribute holds an external struct by value
+// rather than by reference, see the "convert:value" metadata.
+func isValue(att *design.AttributeExpr) bool {
+	_, ok := att.Metadata["convert:value"]
+	return ok
+}
+
+// convert returns the Go code that converts the value v to the type typ. ptr
+// indicates whether v is a pointer. convert returns v if typ is empty.
+func convert(v, typ string, ptr bool) string {
+	if typ == "" {
+		return v
+	}
+	if ptr {
+		return fmt.Sprintf("(*%s)(%s)", typ, v)
+	}
+	return fmt.Sprintf("%s(%s)", typ, v)
+}
+
+// qualify returns the name of the type qualified with the given package name
+// if not empty.
+func qualify(name, pkg string) string {
+	if pkg == "" {
+		return name
+	}
+	return pkg + "." + name
+}
+
 func transformArray(source, target *design.Array, newVar bool, a targs) (string, error) {
 	if err := isCompatible(source.ElemType.Type, target.ElemType.Type, a.sourceVar+"[0]", a.targetVar+"[0]"); err != nil {
 		return "", err
@@ -504,6 +645,15 @@ func collectHelpers(source, target *design.AttributeExpr, a thargs, req bool, se
 		data = append(data, helpers...)
 	case design.IsObject(source.Type):
 		if ut, ok := source.Type.(design.UserType); ok {
+			// helpers always use references, including for external
+			// structs held by value
+			source, target := source, target
+			if isValue(source) {
+				source = &design.AttributeExpr{Type: source.Type}
+			}
+			if isValue(target) {
+				target = &design.AttributeExpr{Type: target.Type}
+			}
 			name := transformHelperName(source, target, targs{unmarshal: a.unmarshal, scope: a.scope})
 			var s map[string]*TransformFunctionData
 			if len(seen) > 0 {
@@ -612,12 +762,18 @@ func transformAttributeHelper(source, target *design.AttributeExpr, sourceVar, t
 
 const transformArrayTmpl = `{{ .Target}} {{ if .NewVar }}:{{ end }}= make([]{{ .ElemTypeRef }}, len({{ .Source }}))
 for {{ .LoopVar }}, val := range {{ .Source }} {
+	{{- if isValue .SourceElem }}
+	val := val
+	{{- end }}
 	{{ transformAttribute .SourceElem .TargetElem "val" (printf "%s[%s]" .Target .LoopVar) .SourcePkg .TargetPkg .Unmarshal false .Scope -}}
 }
 `
 
 const transformMapTmpl = `{{ .Target }} {{ if .NewVar }}:{{ end }}= make(map[{{ .KeyTypeRef }}]{{ .ElemTypeRef }}, len({{ .Source }}))
 for key, val := range {{ .Source }} {
+	{{- if isValue .SourceElem }}
+	val := val
+	{{- end }}
 	{{ transformAttribute .SourceKey .TargetKey "key" "tk" .SourcePkg .TargetPkg .Unmarshal true .Scope -}}
 	{{ transformAttribute .SourceElem .TargetElem "val" (printf "tv%s" .LoopVar) .SourcePkg .TargetPkg .Unmarshal true .Scope -}}
 	{{ .Target }}[tk] = {{ printf "tv%s" .LoopVar }}
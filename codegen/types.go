@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"goa.design/goa/design"
 )
@@ -43,24 +44,94 @@ func GoNativeTypeName(t design.DataType) string {
 
 // AttributeTags computes the struct field tags from its metadata if any.
 func AttributeTags(parent, att *design.AttributeExpr) string {
-	var elems []string
-	keys := make([]string, len(att.Metadata))
-	i := 0
-	for k := range att.Metadata {
-		keys[i] = k
-		i++
-	}
-	sort.Strings(keys)
-	for _, key := range keys {
-		val := att.Metadata[key]
+	return FormatTags(StructTags(att))
+}
+
+// StructTags returns the struct field tags set on the attribute with the
+// "struct:tag:xxx" metadata indexed by tag name. The metadata values are
+// joined with commas.
+func StructTags(att *design.AttributeExpr) map[string]string {
+	tags := make(map[string]string)
+	for key, val := range att.Metadata {
 		if strings.HasPrefix(key, "struct:tag:") {
-			name := key[11:]
-			value := strings.Join(val, ",")
-			elems = append(elems, fmt.Sprintf("%s:\"%s\"", name, value))
+			tags[key[11:]] = strings.Join(val, ",")
 		}
 	}
-	if len(elems) > 0 {
-		return " `" + strings.Join(elems, " ") + "`"
+	return tags
+}
+
+// FormatTags returns the Go struct field tag literal listing the given tags
+// sorted by name preceded with a space, the empty string if there are no
+// tags.
+func FormatTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	elems := make([]string, len(names))
+	for i, name := range names {
+		elems[i] = fmt.Sprintf("%s:\"%s\"", name, tags[name])
+	}
+	return " `" + strings.Join(elems, " ") + "`"
+}
+
+// GoEnumTypeName returns the name of the Go type generated for the attribute
+// att with the given name if att defines enum values and the "struct:enum"
+// metadata, the empty string otherwise. The metadata value if any overrides
+// the name of the type.
+func GoEnumTypeName(att *design.AttributeExpr, name string) string {
+	meta, ok := att.Metadata["struct:enum"]
+	if !ok || att.Validation == nil || len(att.Validation.Values) == 0 {
+		return ""
+	}
+	if _, ok := att.Type.(design.Primitive); !ok {
+		return ""
+	}
+	if len(meta) > 0 && meta[0] != "" {
+		name = meta[0]
+	}
+	return Goify(name, true)
+}
+
+// GoEnumValueName returns the name of the Go constant generated for the value
+// v of the enum type with the given name.
+func GoEnumValueName(typeName string, v interface{}) string {
+	return typeName + Goify(fmt.Sprint(v), true)
+}
+
+// DeprecationReason returns the reason given to the Deprecated DSL for the
+// method or attribute with the given metadata, the empty string if the method
+// or attribute is not deprecated.
+func DeprecationReason(m design.MetadataExpr) string {
+	if r, ok := m["goa:deprecated"]; ok && len(r) > 0 {
+		return r[0]
 	}
 	return ""
 }
+
+// DurationCode returns the Go expression that evaluates to the given duration,
+// e.g. "30 * time.Second".
+func DurationCode(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			if d == u.unit {
+				return u.name
+			}
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", d)
+}
@@ -17,6 +17,7 @@ func TestGoTypeDef(t *testing.T) {
 		userType    = &design.AttributeExpr{Type: ut}
 		resultType  = &design.AttributeExpr{Type: rt}
 		mixedObj    = require(object("IntField", design.Int, "ArrayField", simpleArray.Type, "MapField", simpleMap.Type, "UserTypeField", ut), "IntField", "ArrayField", "MapField", "UserTypeField")
+		taggedObj   = require(withMetadata(object("IntField", design.Int, "StringField", design.String), "IntField", metadata("struct:tag:db", "int_field", "struct:tag:json", "intField", "struct:tag:json", "omitempty")), "IntField", "StringField")
 	)
 	cases := map[string]struct {
 		att        *design.AttributeExpr
@@ -45,6 +46,7 @@ func TestGoTypeDef(t *testing.T) {
 		"ObjDefault":      {defaultObj, true, "struct {\n\tIntField int\n\tStringField string\n}"},
 		"ObjDefaultNoDef": {defaultObj, false, "struct {\n\tIntField *int\n\tStringField *string\n}"},
 		"ObjMixed":        {mixedObj, true, "struct {\n\tIntField int\n\tArrayField []bool\n\tMapField map[int]string\n\tUserTypeField UserType\n}"},
+		"ObjTags":         {taggedObj, true, "struct {\n\tIntField int `db:\"int_field\" json:\"intField,omitempty\"`\n\tStringField string\n}"},
 	}
 
 	for k, tc := range cases {
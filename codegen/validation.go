@@ -20,6 +20,9 @@ var (
 	arrayValT    *template.Template
 	mapValT      *template.Template
 	userValT     *template.Template
+
+	requiredIfValT *template.Template
+	comparisonValT *template.Template
 )
 
 func init() {
@@ -39,6 +42,8 @@ func init() {
 	arrayValT = template.Must(template.New("array").Funcs(fm).Parse(arrayValTmpl))
 	mapValT = template.Must(template.New("map").Funcs(fm).Parse(mapValTmpl))
 	userValT = template.Must(template.New("user").Funcs(fm).Parse(userValTmpl))
+	requiredIfValT = template.Must(template.New("requiredIf").Funcs(fm).Parse(requiredIfValTmpl))
+	comparisonValT = template.Must(template.New("comparison").Funcs(fm).Parse(comparisonValTmpl))
 }
 
 // HasValidations returns true if the given attribute or any of its children
@@ -96,7 +101,7 @@ func HasValidations(att *design.AttributeExpr, ignoreRequired bool) bool {
 func ValidationCode(att *design.AttributeExpr, req, ptr, def bool, target, context string) string {
 	validation := att.Validation
 	if validation == nil {
-		return ""
+		return siblingValidationCode(att, ptr, def, target, context)
 	}
 	var (
 		kind            = att.Type.Kind()
@@ -192,6 +197,82 @@ func ValidationCode(att *design.AttributeExpr, req, ptr, def bool, target, conte
 			res = append(res, runTemplate(requiredValT, data))
 		}
 	}
+	if val := siblingValidationCode(att, ptr, def, target, context); val != "" {
+		res = append(res, val)
+	}
+	return strings.Join(res, "\n")
+}
+
+// siblingValidationCode produces Go code that runs the RequiredIf, LessThan
+// and GreaterThan validations defined on the child attributes of the object
+// att. These validations compare the values of two fields so the code is
+// generated for the parent object rather than for the attributes themselves.
+func siblingValidationCode(att *design.AttributeExpr, ptr, def bool, target, context string) string {
+	o := design.AsObject(att.Type)
+	if o == nil {
+		return ""
+	}
+	var (
+		res  []string
+		buf  bytes.Buffer
+		isPt = func(n string, a *design.AttributeExpr) bool {
+			if !design.IsPrimitive(a.Type) {
+				return true
+			}
+			if a.Type.Kind() == design.BytesKind || a.Type.Kind() == design.AnyKind {
+				return true
+			}
+			return ptr || (!att.IsRequired(n) && (a.DefaultValue == nil || !def))
+		}
+	)
+	for _, nat := range *o {
+		v := nat.Attribute.Validation
+		if v == nil {
+			continue
+		}
+		data := map[string]interface{}{
+			"target":  target,
+			"context": context,
+			"name":    nat.Name,
+			"field":   GoifyAtt(nat.Attribute, nat.Name, true),
+		}
+		if r := v.RequiredIf; r != nil {
+			other := o.Attribute(r.Attribute)
+			if other != nil && design.IsPrimitive(other.Type) && isPt(nat.Name, nat.Attribute) {
+				data["other"] = GoifyAtt(other, r.Attribute, true)
+				data["otherPointer"] = isPt(r.Attribute, other)
+				data["value"] = fmt.Sprintf("%#v", r.Value)
+				buf.Reset()
+				if err := requiredIfValT.Execute(&buf, data); err != nil {
+					panic(err) // bug
+				}
+				res = append(res, buf.String())
+			}
+		}
+		for _, cmp := range []struct {
+			name string
+			less bool
+		}{{v.LessThan, true}, {v.GreaterThan, false}} {
+			if cmp.name == "" {
+				continue
+			}
+			other := o.Attribute(cmp.name)
+			if other == nil {
+				continue
+			}
+			data["context"] = fmt.Sprintf("%s.%s", context, nat.Name)
+			data["isPointer"] = isPt(nat.Name, nat.Attribute)
+			data["other"] = GoifyAtt(other, cmp.name, true)
+			data["otherName"] = cmp.name
+			data["otherPointer"] = isPt(cmp.name, other)
+			data["less"] = cmp.less
+			buf.Reset()
+			if err := comparisonValT.Execute(&buf, data); err != nil {
+				panic(err) // bug
+			}
+			res = append(res, buf.String())
+		}
+	}
 	return strings.Join(res, "\n")
 }
 
@@ -412,7 +493,8 @@ func oneof(target string, vals []interface{}) string {
 	return strings.Join(elems, " || ")
 }
 
-// constant returns the Go constant name of the format with the given value.
+// constant returns the Go constant name of the format with the given value or
+// the expression that converts the name of a custom format.
 func constant(formatName string) string {
 	switch formatName {
 	case "date":
@@ -444,7 +526,8 @@ func constant(formatName string) string {
 	case "rfc1123":
 		return "goa.FormatRFC1123"
 	}
-	panic("unknown format") // bug
+	// Custom format registered with goa.RegisterFormat.
+	return fmt.Sprintf("goa.Format(%q)", formatName)
 }
 
 const (
@@ -510,4 +593,14 @@ if {{ if .string }}utf8.RuneCountInString({{ $target }}){{ else }}len({{ $target
 	requiredValTmpl = `if {{ $.target }}.{{ goifyAtt $.reqAtt .req true }} == nil {
         err = goa.MergeErrors(err, goa.MissingFieldError("{{ .req }}", {{ printf "%q" $.context }}))
 }`
+
+	requiredIfValTmpl = `if {{ if .otherPointer }}{{ .target }}.{{ .other }} != nil && *{{ end }}{{ .target }}.{{ .other }} == {{ .value }} && {{ .target }}.{{ .field }} == nil {
+        err = goa.MergeErrors(err, goa.MissingFieldError("{{ .name }}", {{ printf "%q" .context }}))
+}`
+
+	comparisonValTmpl = `{{ $val := printf "%s%s.%s" (or (and .isPointer "*") "") .target .field -}}
+{{ $other := printf "%s%s.%s" (or (and .otherPointer "*") "") .target .other -}}
+if {{ if .isPointer }}{{ .target }}.{{ .field }} != nil && {{ end }}{{ if .otherPointer }}{{ .target }}.{{ .other }} != nil && {{ end }}{{ $val }} {{ if .less }}>={{ else }}<={{ end }} {{ $other }} {
+        err = goa.MergeErrors(err, goa.InvalidComparisonError({{ printf "%q" .context }}, {{ $val }}, {{ printf "%q" .otherName }}, {{ $other }}, {{ .less }}))
+}`
 )
@@ -0,0 +1,84 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/design"
+)
+
+var (
+	RequiredIfObj   = validate(object("method", design.String, "card", design.String), "card", &design.ValidationExpr{RequiredIf: &design.RequiredIfExpr{Attribute: "method", Value: "card"}})
+	LessThanObj     = validate(require(object("min", design.Int, "max", design.Int), "min", "max"), "min", &design.ValidationExpr{LessThan: "max"})
+	GreaterThanObj  = validate(require(object("start", design.String, "end", design.String), "start"), "end", &design.ValidationExpr{GreaterThan: "start"})
+	CustomFormatObj = validate(object("id", design.String), "id", &design.ValidationExpr{Format: "ulid"})
+)
+
+func TestRecursiveValidationCode(t *testing.T) {
+	cases := []struct {
+		Name string
+		Att  *design.AttributeExpr
+		Ptr  bool
+		Code string
+	}{
+		{"required-if", RequiredIfObj, false, requiredIfValidationCode},
+		{"less-than", LessThanObj, false, lessThanValidationCode},
+		{"less-than-pointer", LessThanObj, true, lessThanPointerValidationCode},
+		{"greater-than", GreaterThanObj, false, greaterThanValidationCode},
+		{"custom-format", CustomFormatObj, false, customFormatValidationCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			code := RecursiveValidationCode(c.Att, true, c.Ptr, false, "body")
+			code = FormatTestCode(t, "package foo\nfunc Validate() (err error){\n"+code+"\n}")
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func validate(att *design.AttributeExpr, name string, v *design.ValidationExpr) *design.AttributeExpr {
+	design.AsObject(att.Type).Attribute(name).Validation = v
+	return att
+}
+
+const requiredIfValidationCode = `func Validate() (err error) {
+	if body.Method != nil && *body.Method == "card" && body.Card == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("card", "body"))
+	}
+}
+`
+
+const lessThanValidationCode = `func Validate() (err error) {
+	if body.Min >= body.Max {
+		err = goa.MergeErrors(err, goa.InvalidComparisonError("body.min", body.Min, "max", body.Max, true))
+	}
+}
+`
+
+const lessThanPointerValidationCode = `func Validate() (err error) {
+	if body.Min == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("min", "body"))
+	}
+	if body.Max == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("max", "body"))
+	}
+	if body.Min != nil && body.Max != nil && *body.Min >= *body.Max {
+		err = goa.MergeErrors(err, goa.InvalidComparisonError("body.min", *body.Min, "max", *body.Max, true))
+	}
+}
+`
+
+const greaterThanValidationCode = `func Validate() (err error) {
+	if body.End != nil && *body.End <= body.Start {
+		err = goa.MergeErrors(err, goa.InvalidComparisonError("body.end", *body.End, "start", body.Start, false))
+	}
+}
+`
+
+const customFormatValidationCode = `func Validate() (err error) {
+	if body.ID != nil {
+		err = goa.MergeErrors(err, goa.ValidateFormat("body.id", *body.ID, goa.Format("ulid")))
+	}
+}
+`
@@ -1,6 +1,7 @@
 package design
 
 import (
+	"fmt"
 	"net/url"
 	"regexp"
 	"sort"
@@ -38,6 +39,18 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Interceptors lists the names of the interceptors that apply
+		// to all the API service methods.
+		Interceptors []string
+		// RateLimit is the default rate limit of the API service
+		// methods if any.
+		RateLimit *RateLimitExpr
+		// Output is the default location of the code generated for the
+		// API services if any.
+		Output *OutputExpr
+		// Imports lists the namespaces whose types and security schemes
+		// may be referred to by their unqualified names.
+		Imports []string
 
 		// random generator used to build examples for the API types.
 		random *Random
@@ -92,7 +105,7 @@ type (
 func (a *APIExpr) Schemes() []string {
 	schemes := make(map[string]bool)
 	for _, s := range a.Servers {
-		if u, err := url.Parse(s.URL); err == nil && u.Scheme != "" {
+		if u, err := url.Parse(s.DefaultURL()); err == nil && u.Scheme != "" {
 			schemes[u.Scheme] = true
 		}
 	}
@@ -124,6 +137,19 @@ func (a *APIExpr) EvalName() string { return "API " + a.Name }
 // Hash returns a unique hash value for a.
 func (a *APIExpr) Hash() string { return "_api_+" + a.Name }
 
+// Validate makes sure the API servers are valid.
+func (a *APIExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	for _, s := range a.Servers {
+		if err := s.Validate(); err != nil {
+			if verrs, ok := err.(*eval.ValidationErrors); ok {
+				verr.Merge(verrs)
+			}
+		}
+	}
+	return verr
+}
+
 // Finalize makes sure there's one server definition.
 func (a *APIExpr) Finalize() {
 	if len(a.Servers) == 0 {
@@ -146,7 +172,7 @@ func (s *ServerExpr) Validate() error {
 		verr   = new(eval.ValidationErrors)
 		params = URLParams(s.URL)
 	)
-	if s.Params == nil {
+	if s.Params == nil || s.Params.Type == nil {
 		if len(params) > 0 {
 			verr.Add(s, "missing Param expressions")
 		}
@@ -176,11 +202,48 @@ func (s *ServerExpr) Validate() error {
 		if nat.Attribute.DefaultValue == nil {
 			verr.Add(s, "parameter %s has no default value", nat.Name)
 		}
+		if nat.Attribute.Type != nil && !IsPrimitive(nat.Attribute.Type) {
+			verr.Add(s, "parameter %s must be of a primitive type", nat.Name)
+		}
 	}
 
 	return verr
 }
 
+// DefaultURL returns the server URL where the parameters are replaced with
+// their default values.
+func (s *ServerExpr) DefaultURL() string {
+	return s.ResolveURL(nil)
+}
+
+// ResolveURL returns the server URL where the parameters are replaced with the
+// values given in vars or with their default values if vars does not contain
+// a value for them. Parameters that have neither are left untouched.
+func (s *ServerExpr) ResolveURL(vars map[string]string) string {
+	return URLParamsRegexp.ReplaceAllStringFunc(s.URL, func(m string) string {
+		name := m[1 : len(m)-1]
+		if v, ok := vars[name]; ok {
+			return v
+		}
+		if p := s.Param(name); p != nil && p.DefaultValue != nil {
+			return fmt.Sprint(p.DefaultValue)
+		}
+		return m
+	})
+}
+
+// Param returns the attribute of the URL parameter with the given name, nil if
+// there isn't one.
+func (s *ServerExpr) Param(name string) *AttributeExpr {
+	if s.Params == nil {
+		return nil
+	}
+	if o := AsObject(s.Params.Type); o != nil {
+		return o.Attribute(name)
+	}
+	return nil
+}
+
 // EvalName is the qualified name of the expression.
 func (p *ServerParamExpr) EvalName() string { return "URL parameter " + p.Name }
 
@@ -110,6 +110,16 @@ func TestServerExprValidate(t *testing.T) {
 			params:   &AttributeExpr{Type: &Object{&NamedAttributeExpr{Name: "accountID", Attribute: &AttributeExpr{DefaultValue: nil}}}},
 			expected: &eval.ValidationErrors{Errors: []error{fmt.Errorf("parameter %s has no default value", "accountID")}},
 		},
+		"no parameter defined": {
+			url:      "http://example.com/cellar/accounts/{accountID}",
+			params:   &AttributeExpr{},
+			expected: &eval.ValidationErrors{Errors: []error{fmt.Errorf("missing Param expressions")}},
+		},
+		"parameter is not primitive": {
+			url:      "http://example.com/cellar/accounts/{accountID}",
+			params:   &AttributeExpr{Type: &Object{&NamedAttributeExpr{Name: "accountID", Attribute: &AttributeExpr{Type: &Array{ElemType: &AttributeExpr{Type: String}}, DefaultValue: []interface{}{"foo"}}}}},
+			expected: &eval.ValidationErrors{Errors: []error{fmt.Errorf("parameter %s must be of a primitive type", "accountID")}},
+		},
 	}
 
 	for k, tc := range cases {
@@ -129,6 +139,32 @@ func TestServerExprValidate(t *testing.T) {
 	}
 }
 
+func TestServerExprResolveURL(t *testing.T) {
+	server := &ServerExpr{
+		URL: "https://{environment}.example.com:{port}/{version}",
+		Params: &AttributeExpr{Type: &Object{
+			&NamedAttributeExpr{Name: "environment", Attribute: &AttributeExpr{Type: String, DefaultValue: "prod"}},
+			&NamedAttributeExpr{Name: "port", Attribute: &AttributeExpr{Type: Int, DefaultValue: 443}},
+		}},
+	}
+	cases := map[string]struct {
+		vars     map[string]string
+		expected string
+	}{
+		"defaults":  {vars: nil, expected: "https://prod.example.com:443/{version}"},
+		"variables": {vars: map[string]string{"environment": "staging", "version": "v1"}, expected: "https://staging.example.com:443/v1"},
+	}
+
+	for k, tc := range cases {
+		if actual := server.ResolveURL(tc.vars); actual != tc.expected {
+			t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+		}
+	}
+	if actual := server.DefaultURL(); actual != "https://prod.example.com:443/{version}" {
+		t.Errorf("default URL: got %#v", actual)
+	}
+}
+
 func TestServerParamExprEvalName(t *testing.T) {
 	cases := map[string]struct {
 		name     string
@@ -3,6 +3,7 @@ package design
 import (
 	"fmt"
 
+	"goa.design/goa"
 	"goa.design/goa/eval"
 )
 
@@ -88,6 +89,25 @@ type (
 		// described at
 		// http://json-schema.org/latest/json-schema-validation.html#anchor61.
 		Required []string
+		// RequiredIf makes the attribute required when a sibling
+		// attribute has a given value.
+		RequiredIf *RequiredIfExpr
+		// LessThan is the name of a sibling attribute whose value must
+		// be greater than the attribute value.
+		LessThan string
+		// GreaterThan is the name of a sibling attribute whose value
+		// must be lesser than the attribute value.
+		GreaterThan string
+	}
+
+	// RequiredIfExpr describes the condition under which an attribute is
+	// required.
+	RequiredIfExpr struct {
+		// Attribute is the name of the sibling attribute.
+		Attribute string
+		// Value is the value of the sibling attribute that makes the
+		// attribute required.
+		Value interface{}
 	}
 
 	// ValidationFormat is the type used to enumerate the possible string
@@ -189,6 +209,7 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 		for _, nat := range *o {
 			ctx = fmt.Sprintf("field %s", nat.Name)
 			verr.Merge(nat.Attribute.Validate(ctx, parent))
+			verr.Merge(validateSiblingRefs(ctx, nat.Attribute, o, parent))
 		}
 	} else {
 		if ar := AsArray(a.Type); ar != nil {
@@ -217,6 +238,14 @@ func (a *AttributeExpr) Validate(ctx string, parent eval.Expression) *eval.Valid
 		}
 	}
 
+	if _, ok := a.Metadata["struct:enum"]; ok {
+		if a.Validation == nil || len(a.Validation.Values) == 0 {
+			verr.Add(parent, "%sdefines struct:enum metadata but does not define enum values", ctx)
+		} else if !isEnumKind(a.Type) {
+			verr.Add(parent, "%sdefines struct:enum metadata but is not a string or number", ctx)
+		}
+	}
+
 	return verr
 }
 
@@ -332,12 +361,11 @@ func (a *AttributeExpr) IsRequiredNoDefault(attName string) bool {
 // between request types where attributes with default values should not be
 // generated using a pointer value and response types where they should.
 //
-//    DefaultValue UseDefault Pointer (assuming all other conditions are true)
-//    Yes          True       False
-//    Yes          False      True
-//    No           True       True
-//    No           False      True
-//
+//	DefaultValue UseDefault Pointer (assuming all other conditions are true)
+//	Yes          True       False
+//	Yes          False      True
+//	No           True       True
+//	No           False      True
 func (a *AttributeExpr) IsPrimitivePointer(attName string, useDefault bool) bool {
 	o := AsObject(a.Type)
 	if o == nil {
@@ -569,6 +597,15 @@ func (v *ValidationExpr) Merge(other *ValidationExpr) {
 	if v.MaxLength == nil || (other.MaxLength != nil && *v.MaxLength < *other.MaxLength) {
 		v.MaxLength = other.MaxLength
 	}
+	if v.RequiredIf == nil {
+		v.RequiredIf = other.RequiredIf
+	}
+	if v.LessThan == "" {
+		v.LessThan = other.LessThan
+	}
+	if v.GreaterThan == "" {
+		v.GreaterThan = other.GreaterThan
+	}
 	v.AddRequired(other.Required...)
 }
 
@@ -610,6 +647,9 @@ func (v *ValidationExpr) HasRequiredOnly() bool {
 	if (v.Minimum != nil) || (v.Maximum != nil) || (v.MaxLength != nil) {
 		return false
 	}
+	if v.RequiredIf != nil || v.LessThan != "" || v.GreaterThan != "" {
+		return false
+	}
 	return true
 }
 
@@ -623,18 +663,22 @@ func (v *ValidationExpr) Dup() *ValidationExpr {
 		}
 	}
 	return &ValidationExpr{
-		Values:    v.Values,
-		Format:    v.Format,
-		Pattern:   v.Pattern,
-		Minimum:   v.Minimum,
-		Maximum:   v.Maximum,
-		MinLength: v.MinLength,
-		MaxLength: v.MaxLength,
-		Required:  req,
+		Values:      v.Values,
+		Format:      v.Format,
+		Pattern:     v.Pattern,
+		Minimum:     v.Minimum,
+		Maximum:     v.Maximum,
+		MinLength:   v.MinLength,
+		MaxLength:   v.MaxLength,
+		Required:    req,
+		RequiredIf:  v.RequiredIf,
+		LessThan:    v.LessThan,
+		GreaterThan: v.GreaterThan,
 	}
 }
 
-// IsSupportedValidationFormat checks if the validation format is supported by goa.
+// IsSupportedValidationFormat checks if the validation format is supported by
+// goa, either built-in or registered with goa.RegisterFormat.
 func (a *AttributeExpr) IsSupportedValidationFormat(vf ValidationFormat) bool {
 	switch vf {
 	case FormatDate:
@@ -666,5 +710,50 @@ func (a *AttributeExpr) IsSupportedValidationFormat(vf ValidationFormat) bool {
 	case FormatRFC1123:
 		return true
 	}
-	return false
+	return goa.IsFormatRegistered(goa.Format(vf))
+}
+
+// isEnumKind returns true if dt is a primitive type that can be used to define
+// a named Go enum type, i.e. a string or a number.
+func isEnumKind(dt DataType) bool {
+	if _, ok := dt.(Primitive); !ok {
+		return false
+	}
+	switch dt.Kind() {
+	case BooleanKind, BytesKind, AnyKind:
+		return false
+	}
+	return true
+}
+
+// validateSiblingRefs makes sure that the sibling attributes referenced by the
+// RequiredIf, LessThan and GreaterThan validations of att exist in o and have
+// compatible types.
+func validateSiblingRefs(ctx string, att *AttributeExpr, o *Object, parent eval.Expression) *eval.ValidationErrors {
+	if att.Validation == nil {
+		return nil
+	}
+	verr := new(eval.ValidationErrors)
+	v := att.Validation
+	if r := v.RequiredIf; r != nil {
+		if other := o.Attribute(r.Attribute); other == nil {
+			verr.Add(parent, "%s - required if field %q does not exist", ctx, r.Attribute)
+		} else if !IsPrimitive(other.Type) || !other.Type.IsCompatible(r.Value) {
+			verr.Add(parent, "%s - value %#v is not compatible with the type of field %q", ctx, r.Value, r.Attribute)
+		}
+	}
+	for _, n := range []string{v.LessThan, v.GreaterThan} {
+		if n == "" {
+			continue
+		}
+		other := o.Attribute(n)
+		if other == nil {
+			verr.Add(parent, "%s - compared field %q does not exist", ctx, n)
+			continue
+		}
+		if !isEnumKind(att.Type) || att.Type.Kind() != other.Type.Kind() {
+			verr.Add(parent, "%s - cannot be compared with field %q, both fields must be strings or numbers of the same type", ctx, n)
+		}
+	}
+	return verr
 }
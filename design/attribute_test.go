@@ -24,6 +24,13 @@ func TestAttributeExprValidate(t *testing.T) {
 		errRequiredFieldNotExist = fmt.Errorf(`%srequired field %q does not exist`, normalizedCtx, "foo")
 		errViewButNotAResultType = fmt.Errorf("%sdefines a view %v but is not a result type", normalizedCtx, metadata["view"])
 		errTypeNotDefineViewe    = fmt.Errorf("%stype does not define view %q", normalizedCtx, "foo")
+		errEnumNoValues          = fmt.Errorf("%sdefines struct:enum metadata but does not define enum values", normalizedCtx)
+		errEnumNotStringOrNumber = fmt.Errorf("%sdefines struct:enum metadata but is not a string or number", normalizedCtx)
+
+		errRequiredIfNotExist = fmt.Errorf("field foo - required if field %q does not exist", "baz")
+		errRequiredIfValue    = fmt.Errorf("field foo - value %#v is not compatible with the type of field %q", 1, "bar")
+		errComparedNotExist   = fmt.Errorf("field foo - compared field %q does not exist", "baz")
+		errComparedType       = fmt.Errorf("field foo - cannot be compared with field %q, both fields must be strings or numbers of the same type", "bar")
 	)
 	cases := map[string]struct {
 		typ        DataType
@@ -183,6 +190,47 @@ func TestAttributeExprValidate(t *testing.T) {
 			metadata: metadata,
 			expected: &eval.ValidationErrors{Errors: []error{errTypeNotDefineViewe}},
 		},
+		"struct enum": {
+			typ:        String,
+			validation: &ValidationExpr{Values: []interface{}{"foo", "bar"}},
+			metadata:   MetadataExpr{"struct:enum": nil},
+			expected:   &eval.ValidationErrors{},
+		},
+		"struct enum without enum values": {
+			typ:      String,
+			metadata: MetadataExpr{"struct:enum": nil},
+			expected: &eval.ValidationErrors{Errors: []error{errEnumNoValues}},
+		},
+		"struct enum with boolean type": {
+			typ:        Boolean,
+			validation: &ValidationExpr{Values: []interface{}{true}},
+			metadata:   MetadataExpr{"struct:enum": nil},
+			expected:   &eval.ValidationErrors{Errors: []error{errEnumNotStringOrNumber}},
+		},
+		"required if": {
+			typ:      siblings(Int, String, &ValidationExpr{RequiredIf: &RequiredIfExpr{Attribute: "bar", Value: "x"}}),
+			expected: &eval.ValidationErrors{},
+		},
+		"required if field does not exist": {
+			typ:      siblings(Int, String, &ValidationExpr{RequiredIf: &RequiredIfExpr{Attribute: "baz", Value: "x"}}),
+			expected: &eval.ValidationErrors{Errors: []error{errRequiredIfNotExist}},
+		},
+		"required if incompatible value": {
+			typ:      siblings(Int, String, &ValidationExpr{RequiredIf: &RequiredIfExpr{Attribute: "bar", Value: 1}}),
+			expected: &eval.ValidationErrors{Errors: []error{errRequiredIfValue}},
+		},
+		"less than": {
+			typ:      siblings(Int, Int, &ValidationExpr{LessThan: "bar"}),
+			expected: &eval.ValidationErrors{},
+		},
+		"less than field does not exist": {
+			typ:      siblings(Int, Int, &ValidationExpr{LessThan: "baz"}),
+			expected: &eval.ValidationErrors{Errors: []error{errComparedNotExist}},
+		},
+		"greater than incompatible types": {
+			typ:      siblings(Int, String, &ValidationExpr{GreaterThan: "bar"}),
+			expected: &eval.ValidationErrors{Errors: []error{errComparedType}},
+		},
 	}
 
 	for k, tc := range cases {
@@ -525,3 +573,12 @@ func TestAttributeExprHasDefaultValue(t *testing.T) {
 		}
 	}
 }
+
+// siblings returns an object with two attributes "foo" and "bar" of the given
+// types, foo defines the given validation.
+func siblings(foo, bar DataType, v *ValidationExpr) *Object {
+	return &Object{
+		&NamedAttributeExpr{Name: "foo", Attribute: &AttributeExpr{Type: foo, Validation: v}},
+		&NamedAttributeExpr{Name: "bar", Attribute: &AttributeExpr{Type: bar}},
+	}
+}
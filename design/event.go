@@ -0,0 +1,38 @@
+package design
+
+import "goa.design/goa/eval"
+
+// EventExpr describes a method whose payload is published as a message on a
+// message broker subject instead of being sent in a request. The method has
+// no result: publishers do not wait for the subscribers to handle the event.
+type EventExpr struct {
+	// Method is the event method.
+	Method *MethodExpr
+	// Subject is the name of the broker subject (NATS) or topic (Kafka)
+	// the event messages are published on.
+	Subject string
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (e *EventExpr) EvalName() string {
+	return "event of " + e.Method.EvalName()
+}
+
+// Validate makes sure the event defines a subject, has an object payload, no
+// result and does not stream.
+func (e *EventExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if e.Subject == "" {
+		verr.Add(e, "event subject cannot be empty")
+	}
+	if e.Method.IsStreaming() {
+		verr.Add(e, "streaming methods cannot be events")
+	}
+	if e.Method.Payload == nil || !IsObject(e.Method.Payload.Type) {
+		verr.Add(e, "event payload must be an object")
+	}
+	if e.Method.Result != nil && e.Method.Result.Type != Empty {
+		verr.Add(e, "events cannot define a result")
+	}
+	return verr
+}
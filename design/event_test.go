@@ -0,0 +1,66 @@
+package design
+
+import (
+	"testing"
+)
+
+func TestEventExprValidate(t *testing.T) {
+	var (
+		payload = &AttributeExpr{Type: &Object{
+			{Name: "id", Attribute: &AttributeExpr{Type: String}},
+		}}
+		empty  = &AttributeExpr{Type: Empty}
+		result = &AttributeExpr{Type: String}
+	)
+	cases := map[string]struct {
+		subject  string
+		payload  *AttributeExpr
+		result   *AttributeExpr
+		stream   streamKind
+		expected []string
+	}{
+		"valid": {
+			subject: "orders.created",
+			payload: payload,
+			result:  empty,
+		},
+		"no subject": {
+			payload:  payload,
+			result:   empty,
+			expected: []string{"event subject cannot be empty"},
+		},
+		"streaming": {
+			subject:  "orders.created",
+			payload:  payload,
+			result:   empty,
+			stream:   ClientStreamKind,
+			expected: []string{"streaming methods cannot be events"},
+		},
+		"primitive payload": {
+			subject:  "orders.created",
+			payload:  &AttributeExpr{Type: String},
+			result:   empty,
+			expected: []string{"event payload must be an object"},
+		},
+		"result": {
+			subject:  "orders.created",
+			payload:  payload,
+			result:   result,
+			expected: []string{"events cannot define a result"},
+		},
+	}
+	for k, tc := range cases {
+		m := &MethodExpr{Name: "created", Payload: tc.payload, Result: tc.result, Stream: tc.stream}
+		e := &EventExpr{Method: m, Subject: tc.subject}
+		verr := e.Validate()
+		if len(verr.Errors) != len(tc.expected) {
+			t.Errorf("%s: got %d errors, expected %d: %v", k, len(verr.Errors), len(tc.expected), verr.Errors)
+			continue
+		}
+		for i, err := range verr.Errors {
+			if err.Error() != tc.expected[i] {
+				t.Errorf("%s: got error %q, expected %q", k, err.Error(), tc.expected[i])
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	regen "github.com/zach-klippenstein/goregen"
+	"goa.design/goa"
 )
 
 const (
@@ -67,6 +68,25 @@ func (a *AttributeExpr) Example(r *Random) interface{} {
 	return a.Type.Example(r)
 }
 
+// NamedExamples returns the examples defined in the design for the attribute
+// or, if there are none, for its user type. NamedExamples returns nil if none
+// of the examples was given a summary, for example if they were all defined
+// with the single argument syntax of the Example DSL.
+func (a *AttributeExpr) NamedExamples() []*ExampleExpr {
+	exs := a.UserExamples
+	if len(exs) == 0 {
+		if ut, ok := a.Type.(UserType); ok {
+			exs = ut.Attribute().UserExamples
+		}
+	}
+	for _, ex := range exs {
+		if ex.Summary != "" && ex.Summary != "default" {
+			return exs
+		}
+	}
+	return nil
+}
+
 // NewLength returns an int that validates the generator attribute length validations if any.
 func NewLength(a *AttributeExpr, r *Random) int {
 	if hasLengthValidation(a) {
@@ -171,6 +191,16 @@ func byFormat(a *AttributeExpr, r *Random) interface{} {
 		return nil
 	}
 	format := a.Validation.Format
+	if format == FormatUUID {
+		// Generate a version 4 UUID.
+		b := make([]byte, 16)
+		for i := range b {
+			b[i] = byte(r.Int())
+		}
+		b[6] = b[6]&0x0f | 0x40
+		b[8] = b[8]&0x3f | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	}
 	if res, ok := map[ValidationFormat]interface{}{
 		FormatEmail:    r.faker.Email(),
 		FormatHostname: r.faker.DomainName() + "." + r.faker.DomainSuffix(),
@@ -193,6 +223,10 @@ func byFormat(a *AttributeExpr, r *Random) interface{} {
 	}[format]; ok {
 		return res
 	}
+	if goa.IsFormatRegistered(goa.Format(format)) {
+		// There is no way to infer an example for a custom format.
+		return r.String()
+	}
 	panic("Validation: unknown format '" + format + "'") // bug
 }
 
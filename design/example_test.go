@@ -4,6 +4,8 @@ import (
 	"regexp"
 	"testing"
 	"unicode/utf8"
+
+	"goa.design/goa"
 )
 
 func TestByPattern(t *testing.T) {
@@ -33,3 +35,63 @@ func TestByPattern(t *testing.T) {
 		})
 	}
 }
+
+func TestByFormatUUID(t *testing.T) {
+	att := &AttributeExpr{Type: String, Validation: &ValidationExpr{Format: FormatUUID}}
+	example, ok := att.Example(NewRandom("test")).(string)
+	if !ok {
+		t.Fatalf("got example of type %T, expected string", att.Example(NewRandom("test")))
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(example) {
+		t.Errorf("got %s, expected a version 4 UUID", example)
+	}
+	if again := att.Example(NewRandom("test")); again != example {
+		t.Errorf("got %v with the same seed, expected %s", again, example)
+	}
+}
+
+func TestByFormatCustom(t *testing.T) {
+	goa.RegisterFormat("example-ulid", func(string) error { return nil })
+	att := &AttributeExpr{Type: String, Validation: &ValidationExpr{Format: "example-ulid"}}
+	example, ok := att.Example(NewRandom("test")).(string)
+	if !ok {
+		t.Fatalf("got example of type %T, expected string", att.Example(NewRandom("test")))
+	}
+	if example == "" {
+		t.Error("got empty example")
+	}
+}
+
+func TestNamedExamples(t *testing.T) {
+	var (
+		def    = &ExampleExpr{Summary: "default", Value: "foo"}
+		named  = &ExampleExpr{Summary: "expired token", Value: "bar"}
+		ut     = &UserTypeExpr{TypeName: "Token", AttributeExpr: &AttributeExpr{Type: String, UserExamples: []*ExampleExpr{def, named}}}
+		noName = &UserTypeExpr{TypeName: "Token", AttributeExpr: &AttributeExpr{Type: String, UserExamples: []*ExampleExpr{def}}}
+	)
+	cases := []struct {
+		Name      string
+		Attribute *AttributeExpr
+		Expected  []*ExampleExpr
+	}{
+		{"none", &AttributeExpr{Type: String}, nil},
+		{"default-only", &AttributeExpr{Type: String, UserExamples: []*ExampleExpr{def}}, nil},
+		{"named", &AttributeExpr{Type: String, UserExamples: []*ExampleExpr{def, named}}, []*ExampleExpr{def, named}},
+		{"user-type", &AttributeExpr{Type: ut}, []*ExampleExpr{def, named}},
+		{"user-type-default-only", &AttributeExpr{Type: noName}, nil},
+		{"attribute-first", &AttributeExpr{Type: ut, UserExamples: []*ExampleExpr{named}}, []*ExampleExpr{named}},
+	}
+	for _, k := range cases {
+		t.Run(k.Name, func(t *testing.T) {
+			exs := k.Attribute.NamedExamples()
+			if len(exs) != len(k.Expected) {
+				t.Fatalf("got %d examples, expected %d", len(exs), len(k.Expected))
+			}
+			for i, ex := range exs {
+				if ex != k.Expected[i] {
+					t.Errorf("example %d: got %q, expected %q", i, ex.Summary, k.Expected[i].Summary)
+				}
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package design
 
 import (
 	"fmt"
+	"time"
 
 	"goa.design/goa/eval"
 )
@@ -31,6 +32,26 @@ type (
 		// schemes. Incoming requests must validate at least one
 		// requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Interceptors lists the names of the interceptors invoked
+		// prior to the method in order. Finalize prepends the API and
+		// service interceptors.
+		Interceptors []string
+		// Timeout is the maximum duration of a request, zero means
+		// there is no timeout.
+		Timeout time.Duration
+		// RateLimit is the maximum rate of requests accepted by the
+		// method if any. Finalize initializes it with the service or
+		// API rate limit if the method does not define one.
+		RateLimit *RateLimitExpr
+		// Pagination describes how the method results are paginated if
+		// at all.
+		Pagination *PaginationExpr
+		// Event describes the broker subject the method payload is
+		// published on if the method is an event.
+		Event *EventExpr
+		// Proxy describes the method of the remote service the requests
+		// are forwarded to if the method is proxied.
+		Proxy *ProxyExpr
 		// Service that owns method.
 		Service *ServiceExpr
 		// Metadata is an arbitrary set of key/value pairs, see dsl.Metadata
@@ -148,6 +169,20 @@ func (m *MethodExpr) Validate() error {
 			}
 		}
 	}
+	if m.Pagination != nil {
+		verr.Merge(m.Pagination.Validate())
+	}
+	if m.Event != nil {
+		verr.Merge(m.Event.Validate())
+	}
+	if m.Proxy != nil {
+		verr.Merge(m.Proxy.Validate())
+	}
+	for _, ic := range m.Interceptors {
+		if ic == "" {
+			verr.Add(m, "interceptor name cannot be empty")
+		}
+	}
 	return verr
 }
 
@@ -213,6 +248,22 @@ func (m *MethodExpr) Finalize() {
 		}
 	}
 
+	// Inherit interceptors
+	var ics []string
+	if Root.API != nil {
+		ics = append(ics, Root.API.Interceptors...)
+	}
+	ics = append(ics, m.Service.Interceptors...)
+	m.Interceptors = mergeInterceptors(append(ics, m.Interceptors...))
+
+	// Inherit rate limit
+	if m.RateLimit == nil {
+		if m.Service.RateLimit != nil {
+			m.RateLimit = m.Service.RateLimit
+		} else if Root.API != nil {
+			m.RateLimit = Root.API.RateLimit
+		}
+	}
 }
 
 // IsStreaming determines whether the method streams payload or result.
@@ -249,3 +300,18 @@ func copyReqs(reqs []*SecurityExpr) []*SecurityExpr {
 	}
 	return reqs2
 }
+
+// mergeInterceptors returns the given interceptor names with duplicates
+// removed, the first occurrence of a name determines its position.
+func mergeInterceptors(names []string) []string {
+	var merged []string
+	seen := make(map[string]struct{})
+	for _, n := range names {
+		if _, ok := seen[n]; ok {
+			continue
+		}
+		seen[n] = struct{}{}
+		merged = append(merged, n)
+	}
+	return merged
+}
@@ -0,0 +1,42 @@
+package design
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NamespaceSeparator separates the namespace from the name in the qualified
+// names of the types and security schemes defined in a namespace.
+const NamespaceSeparator = "."
+
+// NamespaceExpr describes a set of types and security schemes that may be
+// shared by multiple designs. The names of the types and security schemes
+// defined in a namespace are qualified with the namespace name.
+type NamespaceExpr struct {
+	// Name is the namespace name.
+	Name string
+	// Types lists the user and result types defined in the namespace.
+	Types []UserType
+	// Schemes lists the security schemes defined in the namespace.
+	Schemes []*SchemeExpr
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (n *NamespaceExpr) EvalName() string {
+	return fmt.Sprintf("namespace %#v", n.Name)
+}
+
+// QualifiedName returns the name qualified with the given namespace. It returns
+// name unchanged if ns is empty.
+func QualifiedName(ns, name string) string {
+	if ns == "" {
+		return name
+	}
+	return ns + NamespaceSeparator + name
+}
+
+// IsQualified returns true if the given type or security scheme name is
+// qualified with a namespace.
+func IsQualified(name string) bool {
+	return strings.Contains(name, NamespaceSeparator)
+}
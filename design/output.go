@@ -0,0 +1,21 @@
+package design
+
+import "fmt"
+
+// OutputExpr describes where the code generated for a service is written.
+type OutputExpr struct {
+	// Dir is the directory that contains the generated code relative to
+	// the output directory. Dir may contain the "{service}" placeholder
+	// which is replaced with the snake case name of the service.
+	Dir string
+	// ImportPath is the import path of Dir. It may also contain the
+	// "{service}" placeholder. ImportPath is only needed when the import
+	// path cannot be computed from the import path of the default "gen"
+	// directory, for example when Dir belongs to a different Go module.
+	ImportPath string
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (o *OutputExpr) EvalName() string {
+	return fmt.Sprintf("output directory %#v", o.Dir)
+}
@@ -0,0 +1,85 @@
+package design
+
+import "goa.design/goa/eval"
+
+type (
+	// PaginationExpr describes how the results of a method are split into
+	// pages. The method payload and result must be objects. Pages are
+	// identified either with a cursor returned in the result or with an
+	// offset and a limit.
+	PaginationExpr struct {
+		// Method is the paginated method.
+		Method *MethodExpr
+		// CursorParam is the name of the payload attribute holding the
+		// cursor of the requested page if any.
+		CursorParam string
+		// NextCursor is the name of the result attribute holding the
+		// cursor of the next page if any.
+		NextCursor string
+		// OffsetParam is the name of the payload attribute holding the
+		// index of the first item of the requested page if any.
+		OffsetParam string
+		// LimitParam is the name of the payload attribute holding the
+		// maximum number of items in a page if any.
+		LimitParam string
+		// Items is the name of the result attribute listing the page
+		// items.
+		Items string
+	}
+)
+
+// EvalName returns the generic expression name used in error messages.
+func (p *PaginationExpr) EvalName() string {
+	return "pagination of " + p.Method.EvalName()
+}
+
+// IsCursor returns true if the pages are identified with cursors, false if
+// they are identified with offsets.
+func (p *PaginationExpr) IsCursor() bool {
+	return p.CursorParam != ""
+}
+
+// Validate makes sure the pagination attributes are defined by the method
+// payload and result.
+func (p *PaginationExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if p.Method.IsStreaming() {
+		verr.Add(p, "streaming methods cannot be paginated")
+		return verr
+	}
+	if p.Items == "" {
+		verr.Add(p, "missing items attribute, use Items to define it")
+	} else if att := p.Method.Result.Find(p.Items); att == nil {
+		verr.Add(p, "result does not define an attribute %q", p.Items)
+	} else if _, ok := att.Type.(*Array); !ok {
+		verr.Add(p, "items attribute %q must be an array", p.Items)
+	}
+	switch {
+	case p.CursorParam != "" && p.OffsetParam != "":
+		verr.Add(p, "pagination cannot use both cursors and offsets")
+	case p.CursorParam != "":
+		p.validateAttribute(verr, p.Method.Payload, "payload", p.CursorParam, String)
+		p.validateAttribute(verr, p.Method.Result, "result", p.NextCursor, String)
+	case p.OffsetParam != "":
+		p.validateAttribute(verr, p.Method.Payload, "payload", p.OffsetParam, Int)
+		if p.LimitParam != "" {
+			p.validateAttribute(verr, p.Method.Payload, "payload", p.LimitParam, Int)
+		}
+	default:
+		verr.Add(p, "missing page attributes, use Cursor or Offset to define them")
+	}
+	return verr
+}
+
+// validateAttribute adds an error to verr if att does not define an attribute
+// with the given name and type.
+func (p *PaginationExpr) validateAttribute(verr *eval.ValidationErrors, att *AttributeExpr, ctx, name string, typ Primitive) {
+	a := att.Find(name)
+	if a == nil {
+		verr.Add(p, "%s does not define an attribute %q", ctx, name)
+		return
+	}
+	if a.Type.Kind() != typ.Kind() {
+		verr.Add(p, "%s attribute %q must be of type %s", ctx, name, typ.Name())
+	}
+}
@@ -0,0 +1,74 @@
+package design
+
+import (
+	"testing"
+)
+
+func TestPaginationExprValidate(t *testing.T) {
+	var (
+		payload = &AttributeExpr{Type: &Object{
+			{Name: "cursor", Attribute: &AttributeExpr{Type: String}},
+			{Name: "offset", Attribute: &AttributeExpr{Type: Int}},
+			{Name: "limit", Attribute: &AttributeExpr{Type: Int}},
+		}}
+		result = &AttributeExpr{Type: &Object{
+			{Name: "items", Attribute: &AttributeExpr{Type: &Array{ElemType: &AttributeExpr{Type: String}}}},
+			{Name: "next", Attribute: &AttributeExpr{Type: String}},
+		}}
+	)
+	cases := map[string]struct {
+		pagination *PaginationExpr
+		stream     streamKind
+		expected   []string
+	}{
+		"cursor": {
+			pagination: &PaginationExpr{CursorParam: "cursor", NextCursor: "next", Items: "items"},
+		},
+		"offset": {
+			pagination: &PaginationExpr{OffsetParam: "offset", LimitParam: "limit", Items: "items"},
+		},
+		"streaming": {
+			pagination: &PaginationExpr{CursorParam: "cursor", NextCursor: "next", Items: "items"},
+			stream:     ServerStreamKind,
+			expected:   []string{"streaming methods cannot be paginated"},
+		},
+		"missing items": {
+			pagination: &PaginationExpr{CursorParam: "cursor", NextCursor: "next"},
+			expected:   []string{"missing items attribute, use Items to define it"},
+		},
+		"items not array": {
+			pagination: &PaginationExpr{CursorParam: "cursor", NextCursor: "next", Items: "next"},
+			expected:   []string{`items attribute "next" must be an array`},
+		},
+		"both": {
+			pagination: &PaginationExpr{CursorParam: "cursor", NextCursor: "next", OffsetParam: "offset", Items: "items"},
+			expected:   []string{"pagination cannot use both cursors and offsets"},
+		},
+		"neither": {
+			pagination: &PaginationExpr{Items: "items"},
+			expected:   []string{"missing page attributes, use Cursor or Offset to define them"},
+		},
+		"unknown cursor": {
+			pagination: &PaginationExpr{CursorParam: "foo", NextCursor: "next", Items: "items"},
+			expected:   []string{`payload does not define an attribute "foo"`},
+		},
+		"invalid offset type": {
+			pagination: &PaginationExpr{OffsetParam: "cursor", Items: "items"},
+			expected:   []string{`payload attribute "cursor" must be of type int`},
+		},
+	}
+	for k, tc := range cases {
+		m := &MethodExpr{Name: "list", Payload: payload, Result: result, Stream: tc.stream}
+		tc.pagination.Method = m
+		verr := tc.pagination.Validate()
+		if len(verr.Errors) != len(tc.expected) {
+			t.Errorf("%s: got %d errors, expected %d: %v", k, len(verr.Errors), len(tc.expected), verr.Errors)
+			continue
+		}
+		for i, err := range verr.Errors {
+			if err.Error() != tc.expected[i] {
+				t.Errorf("%s: got error %q, expected %q", k, err.Error(), tc.expected[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package design
+
+import "goa.design/goa/eval"
+
+// ProxyExpr describes a method implemented by forwarding the requests to a
+// method of a service defined in another design. The generated code calls the
+// typed client of the remote service so that the requests are encoded using
+// the transport and paths defined in the remote design.
+type ProxyExpr struct {
+	// Method is the proxied method.
+	Method *MethodExpr
+	// Package is the import path of the package containing the code
+	// generated for the remote design, for example
+	// "acme.com/users/gen".
+	Package string
+	// Service is the name of the remote service.
+	Service string
+	// RemoteMethod is the name of the remote service method.
+	RemoteMethod string
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (p *ProxyExpr) EvalName() string {
+	return "proxy of " + p.Method.EvalName()
+}
+
+// Validate makes sure the proxy identifies the remote method and that the
+// proxied method does not stream.
+func (p *ProxyExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if p.Package == "" {
+		verr.Add(p, "proxy package cannot be empty")
+	}
+	if p.Service == "" {
+		verr.Add(p, "proxy service cannot be empty")
+	}
+	if p.RemoteMethod == "" {
+		verr.Add(p, "proxy method cannot be empty")
+	}
+	if p.Method.IsStreaming() {
+		verr.Add(p, "streaming methods cannot be proxied")
+	}
+	if p.Method.Event != nil {
+		verr.Add(p, "events cannot be proxied")
+	}
+	return verr
+}
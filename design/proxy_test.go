@@ -0,0 +1,62 @@
+package design
+
+import (
+	"testing"
+)
+
+func TestProxyExprValidate(t *testing.T) {
+	cases := map[string]struct {
+		pkg      string
+		service  string
+		method   string
+		stream   streamKind
+		event    bool
+		expected []string
+	}{
+		"valid": {
+			pkg:     "acme.com/users/gen",
+			service: "users",
+			method:  "show",
+		},
+		"no package": {
+			service:  "users",
+			method:   "show",
+			expected: []string{"proxy package cannot be empty"},
+		},
+		"no service and method": {
+			pkg:      "acme.com/users/gen",
+			expected: []string{"proxy service cannot be empty", "proxy method cannot be empty"},
+		},
+		"streaming": {
+			pkg:      "acme.com/users/gen",
+			service:  "users",
+			method:   "show",
+			stream:   ServerStreamKind,
+			expected: []string{"streaming methods cannot be proxied"},
+		},
+		"event": {
+			pkg:      "acme.com/users/gen",
+			service:  "users",
+			method:   "show",
+			event:    true,
+			expected: []string{"events cannot be proxied"},
+		},
+	}
+	for k, tc := range cases {
+		m := &MethodExpr{Name: "show_user", Stream: tc.stream}
+		if tc.event {
+			m.Event = &EventExpr{Method: m, Subject: "users.shown"}
+		}
+		p := &ProxyExpr{Method: m, Package: tc.pkg, Service: tc.service, RemoteMethod: tc.method}
+		verr := p.Validate()
+		if len(verr.Errors) != len(tc.expected) {
+			t.Errorf("%s: got %d errors, expected %d: %v", k, len(verr.Errors), len(tc.expected), verr.Errors)
+			continue
+		}
+		for i, err := range verr.Errors {
+			if err.Error() != tc.expected[i] {
+				t.Errorf("%s: got error %q, expected %q", k, err.Error(), tc.expected[i])
+			}
+		}
+	}
+}
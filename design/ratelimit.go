@@ -0,0 +1,20 @@
+package design
+
+import (
+	"fmt"
+	"time"
+)
+
+// RateLimitExpr describes the maximum number of requests accepted by a method
+// over a period of time.
+type RateLimitExpr struct {
+	// Requests is the maximum number of requests accepted during Per.
+	Requests int
+	// Per is the duration of the period.
+	Per time.Duration
+}
+
+// EvalName returns the generic expression name used in error messages.
+func (r *RateLimitExpr) EvalName() string {
+	return fmt.Sprintf("rate limit of %d requests per %s", r.Requests, r.Per)
+}
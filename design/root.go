@@ -28,6 +28,9 @@ type (
 		Creations []*TypeMap
 		// Schemes list the registered security schemes.
 		Schemes []*SchemeExpr
+		// Namespaces lists the namespaces defining shared types and
+		// security schemes.
+		Namespaces []*NamespaceExpr
 	}
 
 	// MetadataExpr is a set of key/value pairs
@@ -109,6 +112,27 @@ func (r *RootExpr) UserType(name string) UserType {
 	return nil
 }
 
+// Scheme returns the security scheme with the given name if found, nil
+// otherwise.
+func (r *RootExpr) Scheme(name string) *SchemeExpr {
+	for _, s := range r.Schemes {
+		if s.SchemeName == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Namespace returns the namespace with the given name if found, nil otherwise.
+func (r *RootExpr) Namespace(name string) *NamespaceExpr {
+	for _, n := range r.Namespaces {
+		if n.Name == name {
+			return n
+		}
+	}
+	return nil
+}
+
 // GeneratedResultType returns the generated result type expression with the given
 // id, nil if there isn't one.
 func (r *RootExpr) GeneratedResultType(id string) *ResultTypeExpr {
@@ -182,6 +182,13 @@ func (s *FlowExpr) Validate() *eval.ValidationErrors {
 	if err != nil {
 		verr.Add(s, "invalid refresh URL %q: %s", s.RefreshURL, err)
 	}
+	needAuth := s.Kind == AuthorizationCodeFlowKind || s.Kind == ImplicitFlowKind
+	if needAuth && s.AuthorizationURL == "" {
+		verr.Add(s, "%s flow requires an authorization URL", s.Type())
+	}
+	if s.Kind != ImplicitFlowKind && s.TokenURL == "" {
+		verr.Add(s, "%s flow requires a token URL", s.Type())
+	}
 	return verr
 }
 
@@ -201,7 +208,7 @@ func (s *FlowExpr) Finalize() {
 		host   string
 	)
 	if len(Root.API.Servers) > 0 {
-		u, _ := url.Parse(Root.API.Servers[0].URL)
+		u, _ := url.Parse(Root.API.Servers[0].DefaultURL())
 		scheme = u.Scheme
 		host = u.Host
 	}
@@ -0,0 +1,52 @@
+package design
+
+import (
+	"testing"
+)
+
+func TestFlowExprValidate(t *testing.T) {
+	cases := map[string]struct {
+		flow     *FlowExpr
+		expected []string
+	}{
+		"authorization code": {
+			flow: &FlowExpr{Kind: AuthorizationCodeFlowKind, AuthorizationURL: "/auth", TokenURL: "/token"},
+		},
+		"implicit": {
+			flow: &FlowExpr{Kind: ImplicitFlowKind, AuthorizationURL: "/auth"},
+		},
+		"password": {
+			flow: &FlowExpr{Kind: PasswordFlowKind, TokenURL: "/token"},
+		},
+		"client credentials": {
+			flow: &FlowExpr{Kind: ClientCredentialsFlowKind, TokenURL: "/token"},
+		},
+		"authorization code without URLs": {
+			flow: &FlowExpr{Kind: AuthorizationCodeFlowKind},
+			expected: []string{
+				"authorization_code flow requires an authorization URL",
+				"authorization_code flow requires a token URL",
+			},
+		},
+		"implicit without authorization URL": {
+			flow:     &FlowExpr{Kind: ImplicitFlowKind, TokenURL: "/token"},
+			expected: []string{"implicit flow requires an authorization URL"},
+		},
+		"client credentials without token URL": {
+			flow:     &FlowExpr{Kind: ClientCredentialsFlowKind, RefreshURL: "/refresh"},
+			expected: []string{"client_credentials flow requires a token URL"},
+		},
+	}
+	for k, tc := range cases {
+		verr := tc.flow.Validate()
+		if len(verr.Errors) != len(tc.expected) {
+			t.Errorf("%s: got %d errors, expected %d: %v", k, len(verr.Errors), len(tc.expected), verr.Errors)
+			continue
+		}
+		for i, err := range verr.Errors {
+			if err.Error() != tc.expected[i] {
+				t.Errorf("%s: got error %q, expected %q", k, err.Error(), tc.expected[i])
+			}
+		}
+	}
+}
@@ -30,6 +30,17 @@ type (
 		// potentially multiple schemes. Incoming requests must validate
 		// at least one requirement to be authorized.
 		Requirements []*SecurityExpr
+		// Interceptors lists the names of the interceptors that apply
+		// to all the service methods.
+		Interceptors []string
+		// RateLimit is the default rate limit of the service methods if
+		// any.
+		RateLimit *RateLimitExpr
+		// Output is the location of the code generated for the
+		// service if it differs from the API default.
+		Output *OutputExpr
+		// Version is the version of the service if any.
+		Version string
 		// Metadata is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Metadata MetadataExpr
@@ -79,9 +90,16 @@ func (s *ServiceExpr) Hash() string {
 	return "_service_+" + s.Name
 }
 
-// Validate validates the service methods and errors.
+// Validate validates the service servers, methods and errors.
 func (s *ServiceExpr) Validate() error {
 	verr := new(eval.ValidationErrors)
+	for _, srv := range s.Servers {
+		if err := srv.Validate(); err != nil {
+			if verrs, ok := err.(*eval.ValidationErrors); ok {
+				verr.Merge(verrs)
+			}
+		}
+	}
 	for _, m := range s.Methods {
 		if err := m.Validate(); err != nil {
 			if verrs, ok := err.(*eval.ValidationErrors); ok {
@@ -115,7 +133,7 @@ func (s *ServiceExpr) Finalize() {
 func (s *ServiceExpr) Schemes() []string {
 	schemes := make(map[string]bool)
 	for _, srv := range s.Servers {
-		if u, err := url.Parse(srv.URL); err == nil && u.Scheme != "" {
+		if u, err := url.Parse(srv.DefaultURL()); err == nil && u.Scheme != "" {
 			schemes[u.Scheme] = true
 		}
 	}
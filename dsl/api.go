@@ -55,13 +55,38 @@ func Title(val string) {
 	eval.IncompatibleDSL()
 }
 
-// Version specifies the API version. One design describes one version.
+// Version specifies the API or service version.
+//
+// Version may appear in API or Service. The API version is used by the
+// generated documentation. The service version makes it possible for multiple
+// versions of a service to coexist in the same server: the transports map the
+// version to the requests, for example the HTTP transport prefixes the service
+// routes with the version by default. Two versions of a service are described
+// with two services defining different versions. The "version:strategy"
+// metadata set on the API makes the HTTP transport use the API version for the
+// services that do not define one, see the HTTP Version DSL.
+//
+// Version takes a single argument: the version string.
+//
+// Example:
+//
+//    var _ = Service("calc", func() {
+//        Version("v1")
+//    })
+//
+//    var _ = Service("calc_v2", func() {
+//        Version("v2")
+//    })
+//
 func Version(ver string) {
-	if s, ok := eval.Current().(*design.APIExpr); ok {
-		s.Version = ver
-		return
+	switch actual := eval.Current().(type) {
+	case *design.APIExpr:
+		actual.Version = ver
+	case *design.ServiceExpr:
+		actual.Version = ver
+	default:
+		eval.IncompatibleDSL()
 	}
-	eval.IncompatibleDSL()
 }
 
 // Contact sets the API contact information.
@@ -133,7 +158,36 @@ func TermsOfService(terms string) {
 	eval.IncompatibleDSL()
 }
 
-// Server defines an API host.
+// Server defines an API host. An API or service may define multiple servers,
+// for example one per environment. The server URL may contain variables using
+// the "{name}" syntax, for example to select a region. The variables must be
+// described with Param and must define a default value. The generated HTTP
+// clients make it possible to build the URL of a server given the values of
+// its variables.
+//
+// Server must appear in API or Service.
+//
+// Server accepts one or two arguments: the server URL and optionally a DSL
+// that defines the server description and variables.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        Server("https://{environment}.{region}.calc.com", func() {
+//            Description("Calc hosts")
+//            Param("environment", String, "Deployment environment", func() {
+//                Enum("prod", "staging")
+//                Default("prod")
+//            })
+//            Param("region", String, "AWS region", func() {
+//                Default("us-east-1")
+//            })
+//        })
+//        Server("http://localhost:8080", func() {
+//            Description("Development host")
+//        })
+//    })
+//
 func Server(url string, fn ...func()) {
 	if len(fn) > 1 {
 		eval.ReportError("too many arguments given to Server")
@@ -158,7 +212,12 @@ func Server(url string, fn ...func()) {
 	}
 }
 
-// Param defines a server URL parameter.
+// Param defines a server URL variable.
+//
+// Param must appear in Server.
+//
+// Param accepts the same arguments as Attribute. The variable must define a
+// default value, see Server for an example.
 func Param(name string, args ...interface{}) {
 	if _, ok := eval.Current().(*design.ServerExpr); !ok {
 		eval.IncompatibleDSL()
@@ -219,6 +219,12 @@ func Default(def interface{}) {
 // description. The other syntax accepts a single argument and is equivalent to
 // using the first syntax where the summary is the string "default".
 //
+// The examples given a summary (named examples) of payloads, results and
+// errors are listed in the "x-examples" extension of the OpenAPI
+// specification, initialize the cases of the generated HTTP tests and the
+// results of the example service implementation and are shown in the help of
+// the example CLI.
+//
 // If no example is explicitly provided in an attribute expression then a random
 // example is generated unless the "swagger:example" metadata is set to "false".
 // See Metadata.
@@ -278,6 +284,8 @@ func Example(args ...interface{}) {
 		ex := &design.ExampleExpr{Summary: summary}
 		if dsl, ok := arg.(func()); ok {
 			eval.Execute(dsl, ex)
+		} else if v, ok := arg.(design.Val); ok {
+			ex.Value = map[string]interface{}(v)
 		} else {
 			ex.Value = arg
 		}
@@ -305,7 +313,7 @@ func parseAttributeArgs(baseAttr *design.AttributeExpr, args ...interface{}) (de
 	parseDataType := func(expected string, index int) {
 		if name, ok2 := args[index].(string); ok2 {
 			// Lookup type by name
-			if dataType = design.Root.UserType(name); dataType == nil {
+			if dataType = userType(name); dataType == nil {
 				eval.InvalidArgError(expected, args[index])
 			}
 			return
@@ -24,6 +24,18 @@ import (
 // match is found or if the matching field type does not correspond to the
 // attribute type.
 //
+// String attributes with the DateTime format may be matched to time.Time
+// fields. Attributes may also be matched to fields of a different type using
+// the "struct.field.convert" metadata. The metadata values are the fully
+// qualified names of the function that converts the attribute value to the
+// field value and of the function that converts the field value back.
+//
+// The external type may be a struct generated by protoc-gen-go. Unexported and
+// XXX_ fields are ignored, String attributes are matched to enum fields using
+// the enum value names, String attributes with the DateTime format are matched
+// to Timestamp fields and attributes are matched to the fields wrapped by the
+// oneof fields.
+//
 // ConvertTo must appear in Type or ResutType.
 //
 // ConvertTo accepts one arguments: an instance of the external type.
@@ -47,6 +59,18 @@ import (
 //            // The "vineyard" attribute is not converted.
 //            Metadata("struct.field.external", "-")
 //        })
+//        Attribute("created_at", String, func() {
+//            // The "created_at" attribute is matched to the external
+//            // type time.Time "CreatedAt" field.
+//            Format(FormatDateTime)
+//        })
+//        Attribute("id", String, func() {
+//            // The "id" attribute is matched to the external type
+//            // "ID" field using the given conversion functions.
+//            Metadata("struct.field.convert",
+//                "github.com/repo/model.ParseID",
+//                "github.com/repo/model.FormatID")
+//        })
 //    })
 //
 // External (i.e. non design) package:
@@ -57,6 +81,8 @@ import (
 //        Rating int
 //        // Mapped field
 //        MyName string
+//        CreatedAt time.Time
+//        ID ID
 //        // Additional fields are OK
 //        Description string
 //    }
@@ -100,6 +126,12 @@ func ConvertTo(obj interface{}) {
 // match is found or if the matching field type does not correspond to the
 // attribute type.
 //
+// String attributes with the DateTime format may be matched to time.Time
+// fields. Attributes may also be matched to fields of a different type using
+// the "struct.field.convert" metadata. The metadata values are the fully
+// qualified names of the function that converts the attribute value to the
+// field value and of the function that converts the field value back.
+//
 // CreateFrom must appear in Type or ResutType.
 //
 // CreateFrom accepts one arguments: an instance of the external type.
@@ -122,6 +154,18 @@ func ConvertTo(obj interface{}) {
 //            // generated constructor method.
 //            Metadata("struct.field.external", "-")
 //        })
+//        Attribute("created_at", String, func() {
+//            // The "created_at" attribute is matched to the external
+//            // type time.Time "CreatedAt" field.
+//            Format(FormatDateTime)
+//        })
+//        Attribute("id", String, func() {
+//            // The "id" attribute is matched to the external type
+//            // "ID" field using the given conversion functions.
+//            Metadata("struct.field.convert",
+//                "github.com/repo/model.ParseID",
+//                "github.com/repo/model.FormatID")
+//        })
 //    })
 //
 // External (i.e. non design) package:
@@ -132,6 +176,8 @@ func ConvertTo(obj interface{}) {
 //        Rating int
 //        // Mapped field
 //        MyName string
+//        CreatedAt time.Time
+//        ID ID
 //        // Additional fields are OK
 //        Description string
 //    }
@@ -0,0 +1,50 @@
+package dsl
+
+import (
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+// Deprecated marks a method or an attribute as deprecated. The generated code
+// documents deprecated methods and struct fields with a "Deprecated:" comment,
+// the OpenAPI specification flags the corresponding operations and properties
+// as deprecated and the generated CLI prints a warning when a deprecated
+// endpoint is invoked.
+//
+// Deprecated must appear in Method or Attribute.
+//
+// Deprecated accepts a single non-empty argument which is the reason for the
+// deprecation, typically indicating what to use instead.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Deprecated("use the paginated method instead")
+//        Payload(func() {
+//            Attribute("filter", String, func() {
+//                Deprecated("use the query attribute instead")
+//            })
+//            Attribute("query", String)
+//        })
+//    })
+//
+func Deprecated(reason string) {
+	if reason == "" {
+		eval.InvalidArgError("non empty deprecation reason", reason)
+		return
+	}
+	var m *design.MetadataExpr
+	switch expr := eval.Current().(type) {
+	case *design.MethodExpr:
+		m = &expr.Metadata
+	case *design.AttributeExpr:
+		m = &expr.Metadata
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if *m == nil {
+		*m = make(design.MetadataExpr)
+	}
+	(*m)["goa:deprecated"] = []string{reason}
+}
@@ -1,6 +1,8 @@
 package dsl
 
 import (
+	"time"
+
 	"goa.design/goa/design"
 	"goa.design/goa/eval"
 )
@@ -80,11 +82,17 @@ func Temporary() {
 	attr.Metadata["goa:error:temporary"] = nil
 }
 
-// Timeout qualifies an error type as describing errors due to timeouts.
+// Timeout qualifies an error type as describing errors due to timeouts when
+// used in an Error expression. Timeout sets the maximum duration of the
+// requests made to the method when used in a Method expression: the generated
+// server code cancels the request context once the duration has elapsed, the
+// generated client code sets the same timeout on the requests and the
+// generated CLI exposes it via a "timeout" flag.
 //
-// Timeout must appear in a Error expression.
+// Timeout must appear in a Error or Method expression.
 //
-// Timeout takes no argument.
+// Timeout takes no argument when used in an Error expression and a positive
+// duration when used in a Method expression.
 //
 // Example:
 //
@@ -92,13 +100,28 @@ func Temporary() {
 //	   Error("request_timeout", func() {
 //		   Timeout()
 //	   })
+//	   Method("divide", func() {
+//		   Timeout(5 * time.Second)
+//	   })
 //    })
-func Timeout() {
+func Timeout(d ...time.Duration) {
+	if m, ok := eval.Current().(*design.MethodExpr); ok {
+		if len(d) != 1 || d[0] <= 0 {
+			eval.ReportError("Timeout in a method requires a single positive duration")
+			return
+		}
+		m.Timeout = d[0]
+		return
+	}
 	attr, ok := eval.Current().(*design.AttributeExpr)
 	if !ok {
 		eval.IncompatibleDSL()
 		return
 	}
+	if len(d) > 0 {
+		eval.ReportError("Timeout in an error takes no argument")
+		return
+	}
 	if attr.Metadata == nil {
 		attr.Metadata = make(design.MetadataExpr)
 	}
@@ -0,0 +1,35 @@
+package dsl
+
+import (
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+// Event marks the method as an event published on a message broker. The code
+// generator produces a pub/sub transport for the services that define events:
+// a publisher whose endpoints encode the method payload and publish it on the
+// event subject and a subscriber that decodes and validates the messages
+// received on the subject before calling the method endpoint. The generated
+// code uses the pubsub package interfaces so that the same code works with
+// the NATS and Kafka drivers.
+//
+// Event must appear in a Method expression. The method payload must be an
+// object and the method may not define a result or stream.
+//
+// Event takes one argument: the name of the subject (NATS) or topic (Kafka).
+//
+// Example:
+//
+//    Method("order_created", func() {
+//        Payload(Order)
+//        Event("orders.created")
+//    })
+//
+func Event(subject string) {
+	m, ok := eval.Current().(*design.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	m.Event = &design.EventExpr{Method: m, Subject: subject}
+}
@@ -0,0 +1,53 @@
+package dsl
+
+import (
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+// Interceptor lists interceptors invoked prior to the service methods. The
+// generated service package defines an Interceptors interface with one method
+// per interceptor name, the generated NewEndpoints function accepts an
+// implementation of the interface and wraps each endpoint so that its
+// interceptors are invoked in order before the service method.
+//
+// Interceptor may appear in an API, Service or Method expression. The
+// interceptors of a method are the API interceptors followed by the service
+// interceptors followed by the method interceptors, an interceptor listed more
+// than once is invoked only once.
+//
+// Interceptor accepts one or more interceptor names as argument. Interceptor
+// may be called multiple times in the same expression.
+//
+// Example:
+//
+//    var _ = Service("divider", func() {
+//        Interceptor("logging")
+//
+//        Method("divide", func() {
+//            Interceptor("auth", "ratelimit")
+//        })
+//    })
+//
+func Interceptor(names ...string) {
+	if len(names) == 0 {
+		eval.ReportError("missing interceptor name")
+		return
+	}
+	for _, n := range names {
+		if n == "" {
+			eval.ReportError("interceptor name cannot be empty")
+			return
+		}
+	}
+	switch actual := eval.Current().(type) {
+	case *design.APIExpr:
+		actual.Interceptors = append(actual.Interceptors, names...)
+	case *design.ServiceExpr:
+		actual.Interceptors = append(actual.Interceptors, names...)
+	case *design.MethodExpr:
+		actual.Interceptors = append(actual.Interceptors, names...)
+	default:
+		eval.IncompatibleDSL()
+	}
+}
@@ -0,0 +1,37 @@
+package dsl
+
+import (
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+// Internal marks an attribute as internal to the service. The attribute is a
+// field of the generated service types but is never exposed by the transport:
+// the HTTP request and response bodies and the OpenAPI specification do not
+// include it and it cannot be mapped to a header, parameter or cookie. Internal
+// is a shorthand for Metadata("transport:omit").
+//
+// Internal must appear in Attribute.
+//
+// Internal takes no argument.
+//
+// Example:
+//
+//    var User = Type("User", func() {
+//        Attribute("name", String)
+//        Attribute("password_hash", String, func() {
+//            Internal()
+//        })
+//    })
+//
+func Internal() {
+	att, ok := eval.Current().(*design.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if att.Metadata == nil {
+		att.Metadata = make(design.MetadataExpr)
+	}
+	att.Metadata["transport:omit"] = []string{}
+}
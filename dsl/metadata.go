@@ -71,14 +71,132 @@ import (
 //
 //        Metadata("struct:field:origin", "X-API-Version")
 //
-// `struct:tag:xxx`: sets the struct field tag xxx on generated Go structs.
-// Overrides tags that goa would otherwise set.  If the metadata value is a
-// slice then the strings are joined with the space character as separator.
+// `struct:tag:xxx`: sets the struct field tag xxx on the generated payload,
+// result and HTTP body types, for example to use the types with an ORM or an
+// alternative encoder. The tag is added to the form, json and xml tags goa
+// sets on HTTP body types and overrides the tag with the same name. If the
+// metadata value is a slice then the strings are joined with commas.
 // Applicable to attributes only.
 //
-//        Metadata("struct:tag:json", "myName,omitempty")
+//        Metadata("struct:tag:db", "my_name")
+//        Metadata("struct:tag:json", "myName", "omitempty")
 //        Metadata("struct:tag:xml", "myName,attr")
 //
+// `struct:enum`: generates a named Go type for an attribute that defines enum
+// values together with typed constants for each value, a String method and a
+// ParseXxx function. The service payload and result struct fields use the
+// named type instead of the native Go type. The name of the type defaults to
+// the name of the attribute and may be overridden with the metadata value.
+// Applicable to string and number attributes only.
+//
+//        Attribute("color", String, func() {
+//                Enum("red", "green", "blue")
+//                Metadata("struct:enum", "Color")
+//        })
+//
+// `validation:aggregate`: makes the generated HTTP server code validate the
+// request parameters, headers and cookies even when the validation of the
+// request body fails so that all the violations are reported in a single
+// error. Only the error responses of these methods list the individual
+// errors.
+// Applicable to API (for global setting) or methods.
+//
+//        Metadata("validation:aggregate")
+//
+// `goa:naming:wire`: sets how the attribute names map to the field names of
+// the HTTP request and response bodies: "snake" for snake_case and "camel" for
+// camelCase. The names are used in the struct tags of the generated body
+// types and in the OpenAPI specification. Names mapped explicitly with the
+// "name:element" syntax are not affected. Applicable to API only.
+//
+//        Metadata("goa:naming:wire", "camel")
+//
+// `goa:naming:initialisms`: lists initialisms that the generated Go
+// identifiers keep upper case in addition to the common ones such as ID, HTTP
+// or URL. Applicable to API only.
+//
+//        Metadata("goa:naming:initialisms", "SKU", "GRPC")
+//
+// `transport:omit`: excludes the attribute from the transport. The attribute
+// is a field of the service payload or result type but never appears in the
+// HTTP request and response bodies, headers, parameters or in the OpenAPI
+// specification. The Internal function sets this metadata. Applicable to
+// attributes only.
+//
+//        Attribute("password_hash", String, func() {
+//                Metadata("transport:omit")
+//        })
+//
+// `http:header`: maps an attribute of an error type to a HTTP response header
+// for all the endpoints that return the error. The metadata value if any is
+// the name of the header, the name of the attribute is used otherwise. The
+// generated client code initializes the error field from the header. Headers
+// defined explicitly in the error Response DSL take precedence. Applicable to
+// error type attributes only.
+//
+//        var RateLimited = Type("RateLimited", func() {
+//                Attribute("retry_after", Int, func() {
+//                        Metadata("http:header", "Retry-After")
+//                })
+//                Attribute("message", String)
+//        })
+//
+// `http:error:problem`: makes the generated HTTP server code encode all the
+// errors returned by the service methods as RFC 7807 problem details
+// documents (application/problem+json) and the generated client code decode
+// them into goahttp.Problem values. Applicable to API only.
+//
+//        Metadata("http:error:problem")
+//
+// `http:body:fastjson`: generates MarshalJSON and UnmarshalJSON methods that
+// do not use reflection on the HTTP request and response body types. The
+// methods fall back to encoding/json for the fields whose types are not
+// supported, the body types that define custom json struct tags are not
+// affected.
+// Applicable to API and services.
+//
+//        Metadata("http:body:fastjson")
+//
+// `http:trace:otel`: makes the generated HTTP server handlers and client
+// endpoints create OpenTelemetry spans named after the service and method. The
+// generated clients propagate the W3C trace context headers. Applicable to API
+// and services.
+//
+//        Metadata("http:trace:otel")
+//
+// `http:trace:attribute`: records the value of the payload attribute as a span
+// attribute when the "http:trace:otel" metadata is set. The metadata value if
+// any is the span attribute key, the name of the attribute is used otherwise.
+// Applicable to payload attributes only.
+//
+//        Attribute("account_id", String, func() {
+//                Metadata("http:trace:attribute", "account.id")
+//        })
+//
+// `http:query:style`: sets the serialization style of a query string
+// parameter. The value is one of "form" (default, each array element or map
+// key is encoded in its own value), "pipeDelimited" or "spaceDelimited" (the
+// array elements are encoded in a single value separated with pipes or spaces)
+// and "deepObject" (the map keys are encoded in the parameter names, e.g.
+// "filter[color]=red"). The generated client and server code encode and decode
+// the parameter accordingly. Applicable to HTTP query string parameters only.
+//
+//        Param("tags", ArrayOf(String), func() {
+//                Metadata("http:query:style", "pipeDelimited")
+//        })
+//
+// `jsonschema:generate`: specifies whether the JSON Schema documents of the
+// user types should be generated. Defaults to true. Applicable to API (for
+// global setting) or individual user and result types.
+//
+//        Metadata("jsonschema:generate", "false")
+//
+// `docker:generate`: specifies whether the example generator should produce a
+// multi-stage Dockerfile and a container entrypoint for the example server.
+// Defaults to false. Applicable to API only.
+//
+//        Metadata("docker:generate", "true")
+//
 // `swagger:generate`: specifies whether Swagger specification should be
 // generated. Defaults to true.
 // Applicable to services, methods and file servers.
@@ -104,13 +222,15 @@ import (
 //        Metadata("swagger:tag:Backend:url", "http://example.com")
 //        Metadata("swagger:tag:Backend:url:desc", "See more docs here")
 //
-// `swagger:extension:xxx`: sets the Swagger extensions xxx. It can have any
-// valid JSON format value.
+// `swagger:extension:xxx` or `openapi:extension:xxx`: sets the Swagger
+// extensions xxx. It can have any valid JSON format value.
 // Applicable to:
-// api as within the info and tag object,
-// service within the paths object,
+// api as within the root, info and tag object,
+// service within the tag and operation objects,
+// method within the operation object,
 // endpoint as within the path-item object,
 // route as within the operation object,
+// attribute as within the schema object,
 // param as within the parameter object,
 // response as within the response object
 // and security as within the security-scheme object.
@@ -2,6 +2,7 @@ package dsl_test
 
 import (
 	"testing"
+	"time"
 
 	"goa.design/goa/design"
 	. "goa.design/goa/dsl"
@@ -91,6 +92,55 @@ func TestMethod(t *testing.T) {
 				}
 			},
 		},
+		"timeout": {
+			func() {
+				Method("timeout", func() {
+					Timeout(5 * time.Second)
+				})
+			},
+			func(t *testing.T, methods []*design.MethodExpr) {
+				if len(methods) != 1 {
+					t.Fatalf("timeout: expected 1 method, got %d", len(methods))
+				}
+				if methods[0].Timeout != 5*time.Second {
+					t.Errorf("timeout: expected timeout to be %s, got %s", 5*time.Second, methods[0].Timeout)
+				}
+			},
+		},
+		"interceptor": {
+			func() {
+				Method("interceptor", func() {
+					Interceptor("auth")
+					Interceptor("logging")
+				})
+			},
+			func(t *testing.T, methods []*design.MethodExpr) {
+				if len(methods) != 1 {
+					t.Fatalf("interceptor: expected 1 method, got %d", len(methods))
+				}
+				ics := methods[0].Interceptors
+				if len(ics) != 2 || ics[0] != "auth" || ics[1] != "logging" {
+					t.Errorf("interceptor: expected interceptors to be [auth logging], got %v", ics)
+				}
+			},
+		},
+		"rate limit": {
+			func() {
+				RateLimit(100, time.Minute)
+				Method("rate limit", func() {
+					RateLimit(5, time.Second)
+				})
+			},
+			func(t *testing.T, methods []*design.MethodExpr) {
+				if len(methods) != 1 {
+					t.Fatalf("rate limit: expected 1 method, got %d", len(methods))
+				}
+				rl := methods[0].RateLimit
+				if rl == nil || rl.Requests != 5 || rl.Per != time.Second {
+					t.Errorf("rate limit: expected rate limit to be 5 per %s, got %v", time.Second, rl)
+				}
+			},
+		},
 	}
 	//Run our tests
 	for k, tc := range cases {
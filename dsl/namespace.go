@@ -0,0 +1,195 @@
+package dsl
+
+import (
+	"strings"
+
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+// namespace is the name of the namespace whose DSL is being executed if any.
+var namespace string
+
+// Namespace defines types and security schemes that may be shared by multiple
+// designs. A shared design package typically defines a namespace in a
+// package-level variable declaration. The designs making use of the namespace
+// import the package and refer to its types and security schemes either via
+// the package variables or by name.
+//
+// The names of the types and security schemes defined in the namespace are
+// qualified with the namespace name, for example the type "UUID" defined in the
+// namespace "common" is named "common.UUID". This makes it possible for
+// multiple shared design packages to define types or security schemes with the
+// same name. The DSL of the types defined in a namespace may refer to the other
+// types of the namespace using their unqualified names.
+//
+// Namespace is a top level DSL. Namespace may be called multiple times with
+// the same name, for example in different files of the same package, the types
+// and security schemes are added to the same namespace. Contrary to most DSL
+// functions the namespace DSL is executed right away so that the types and
+// security schemes may be assigned to package variables.
+//
+// Namespace takes two arguments: the namespace name and the DSL defining the
+// types and security schemes. The name may not contain the "." character.
+//
+// Example:
+//
+//    package design // import "acme.com/common/design"
+//
+//    var (
+//        UUID    design.UserType
+//        JWTAuth *design.SchemeExpr
+//    )
+//
+//    var _ = Namespace("common", func() {
+//        UUID = Type("UUID", String, func() {
+//            Format(FormatUUID)
+//        })
+//        JWTAuth = JWTSecurity("jwt", func() {
+//            Scope("api:read")
+//        })
+//    })
+//
+func Namespace(name string, fn func()) *design.NamespaceExpr {
+	if _, ok := eval.Current().(eval.TopExpr); !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if name == "" || design.IsQualified(name) {
+		eval.ReportError("invalid namespace name %#v", name)
+		return nil
+	}
+	if namespace != "" {
+		eval.ReportError("namespace %#v cannot be defined in namespace %#v", name, namespace)
+		return nil
+	}
+	ns := design.Root.Namespace(name)
+	if ns == nil {
+		ns = &design.NamespaceExpr{Name: name}
+		design.Root.Namespaces = append(design.Root.Namespaces, ns)
+	}
+	namespace = name
+	defer func() { namespace = "" }()
+	fn()
+	return ns
+}
+
+// Import makes it possible to refer to the types and security schemes of the
+// given namespaces using their unqualified names. The types and security
+// schemes defined in the design take precedence over the imported ones, a name
+// defined in more than one imported namespace must be qualified.
+//
+// Import must appear in API and should be called prior to any other DSL that
+// refers to the imported types or security schemes. The Go packages defining
+// the namespaces must be imported by the design package, see Namespace.
+//
+// Import accepts one or more namespace names as argument.
+//
+// Example:
+//
+//    import (
+//        common "acme.com/common/design"
+//        . "goa.design/goa/dsl"
+//    )
+//
+//    var _ = API("orders", func() {
+//        Import("common")
+//        Security("jwt") // refers to "common.jwt"
+//    })
+//
+//    var Order = Type("Order", func() {
+//        Attribute("id", "UUID")         // refers to "common.UUID"
+//        Attribute("owner", common.UUID) // same
+//    })
+//
+func Import(namespaces ...string) {
+	api, ok := eval.Current().(*design.APIExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(namespaces) == 0 {
+		eval.ReportError("missing namespace name")
+		return
+	}
+	for _, n := range namespaces {
+		if design.Root.Namespace(n) == nil {
+			eval.ReportError("namespace %#v not found, make sure the package defining it is imported", n)
+			continue
+		}
+		api.Imports = append(api.Imports, n)
+	}
+}
+
+// inNamespace returns a function that executes fn with the current namespace
+// if any so that the DSL of the types defined in the namespace may refer to
+// the other types of the namespace using their unqualified names.
+func inNamespace(fn func()) func() {
+	if fn == nil || namespace == "" {
+		return fn
+	}
+	ns := namespace
+	return func() {
+		prev := namespace
+		namespace = ns
+		defer func() { namespace = prev }()
+		fn()
+	}
+}
+
+// addScheme registers the security scheme with the design and the current
+// namespace if any.
+func addScheme(s *design.SchemeExpr) {
+	design.Root.Schemes = append(design.Root.Schemes, s)
+	if ns := design.Root.Namespace(namespace); ns != nil {
+		ns.Schemes = append(ns.Schemes, s)
+	}
+}
+
+// userType returns the user type with the given name, nil if there isn't one.
+// See resolve for the name resolution rules.
+func userType(name string) design.UserType {
+	n := resolve("type", name, func(n string) bool { return design.Root.UserType(n) != nil })
+	return design.Root.UserType(n)
+}
+
+// scheme returns the security scheme with the given name, nil if there isn't
+// one. See resolve for the name resolution rules.
+func scheme(name string) *design.SchemeExpr {
+	n := resolve("security scheme", name, func(n string) bool { return design.Root.Scheme(n) != nil })
+	return design.Root.Scheme(n)
+}
+
+// resolve returns the qualified name of the type or security scheme with the
+// given name. Unqualified names are looked up in the current namespace first,
+// then in the design and finally in the namespaces imported by the API.
+// resolve reports an error if the name is found in more than one imported
+// namespace.
+func resolve(kind, name string, exists func(string) bool) string {
+	if design.IsQualified(name) {
+		return name
+	}
+	if namespace != "" {
+		if n := design.QualifiedName(namespace, name); exists(n) {
+			return n
+		}
+	}
+	if exists(name) || design.Root.API == nil {
+		return name
+	}
+	var found []string
+	for _, ns := range design.Root.API.Imports {
+		if n := design.QualifiedName(ns, name); exists(n) {
+			found = append(found, n)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return name
+	case 1:
+		return found[0]
+	default:
+		eval.ReportError("%s %#v is ambiguous, use one of %s", kind, name, strings.Join(found, ", "))
+		return found[0]
+	}
+}
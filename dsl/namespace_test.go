@@ -0,0 +1,166 @@
+package dsl_test
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/design"
+	. "goa.design/goa/dsl"
+	"goa.design/goa/eval"
+)
+
+func TestNamespace(t *testing.T) {
+	common := func() {
+		Namespace("common", func() {
+			Type("UUID", design.String)
+			Type("Page", func() {
+				Attribute("cursor", "UUID")
+			})
+			JWTSecurity("jwt")
+		})
+	}
+	cases := map[string]struct {
+		DSL    func()
+		Error  string
+		Assert func(t *testing.T, r *design.RootExpr)
+	}{
+		"qualified": {
+			func() {
+				common()
+				Type("Bottle", func() {
+					Attribute("id", "common.UUID")
+				})
+			},
+			"",
+			func(t *testing.T, r *design.RootExpr) {
+				ns := r.Namespace("common")
+				if ns == nil {
+					t.Fatal("namespace common not found")
+				}
+				if len(ns.Types) != 2 || ns.Types[0].Name() != "common.UUID" || ns.Types[1].Name() != "common.Page" {
+					t.Errorf("got namespace types %v, expected common.UUID and common.Page", ns.Types)
+				}
+				if len(ns.Schemes) != 1 || ns.Schemes[0].SchemeName != "common.jwt" {
+					t.Errorf("got namespace schemes %v, expected common.jwt", ns.Schemes)
+				}
+				page := design.AsObject(r.UserType("common.Page").Attribute().Type)
+				if typ := page.Attribute("cursor").Type; typ != r.UserType("common.UUID") {
+					t.Errorf("got cursor type %s, expected common.UUID", typ.Name())
+				}
+				bottle := design.AsObject(r.UserType("Bottle").Attribute().Type)
+				if typ := bottle.Attribute("id").Type; typ != r.UserType("common.UUID") {
+					t.Errorf("got id type %s, expected common.UUID", typ.Name())
+				}
+			},
+		},
+		"import": {
+			func() {
+				common()
+				API("test", func() {
+					Import("common")
+					Security("jwt")
+				})
+				Type("Bottle", func() {
+					Attribute("id", "UUID")
+				})
+			},
+			"",
+			func(t *testing.T, r *design.RootExpr) {
+				if len(r.API.Requirements) != 1 || r.API.Requirements[0].Schemes[0].SchemeName != "common.jwt" {
+					t.Errorf("got requirements %v, expected common.jwt", r.API.Requirements)
+				}
+				bottle := design.AsObject(r.UserType("Bottle").Attribute().Type)
+				if typ := bottle.Attribute("id").Type; typ != r.UserType("common.UUID") {
+					t.Errorf("got id type %s, expected common.UUID", typ.Name())
+				}
+			},
+		},
+		"design-first": {
+			func() {
+				common()
+				API("test", func() {
+					Import("common")
+				})
+				Type("UUID", design.Int)
+				Type("Bottle", func() {
+					Attribute("id", "UUID")
+				})
+			},
+			"",
+			func(t *testing.T, r *design.RootExpr) {
+				bottle := design.AsObject(r.UserType("Bottle").Attribute().Type)
+				if typ := bottle.Attribute("id").Type; typ != r.UserType("UUID") {
+					t.Errorf("got id type %s, expected UUID", typ.Name())
+				}
+			},
+		},
+		"ambiguous": {
+			func() {
+				common()
+				Namespace("billing", func() {
+					Type("UUID", design.String)
+				})
+				API("test", func() {
+					Import("common", "billing")
+				})
+				Type("Bottle", func() {
+					Attribute("id", "UUID")
+				})
+			},
+			`type "UUID" is ambiguous, use one of common.UUID, billing.UUID`,
+			nil,
+		},
+		"unknown-import": {
+			func() {
+				API("test", func() {
+					Import("common")
+				})
+			},
+			`namespace "common" not found`,
+			nil,
+		},
+		"nested": {
+			func() {
+				Namespace("common", func() {
+					Namespace("billing", func() {})
+				})
+			},
+			`namespace "billing" cannot be defined in namespace "common"`,
+			nil,
+		},
+		"invalid-name": {
+			func() {
+				Namespace("acme.common", func() {})
+			},
+			`invalid namespace name "acme.common"`,
+			nil,
+		},
+	}
+	for k, tc := range cases {
+		t.Run(k, func(t *testing.T) {
+			err := runNamespaceDSL(tc.DSL)
+			if tc.Error != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.Error) {
+					t.Fatalf("got error %v, expected %q", err, tc.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			tc.Assert(t, design.Root)
+		})
+	}
+}
+
+func runNamespaceDSL(dsl func()) error {
+	eval.Reset()
+	design.Root = &design.RootExpr{GeneratedTypes: &design.GeneratedRoot{}}
+	eval.Register(design.Root)
+	eval.Register(design.Root.GeneratedTypes)
+	design.Root.API = &design.APIExpr{Name: "test"}
+	if !eval.Execute(dsl, nil) {
+		return eval.Context.Errors
+	}
+	return eval.RunDSL()
+}
@@ -0,0 +1,61 @@
+package dsl
+
+import (
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+// Output sets the directory where the code generated for the services is
+// written, making it possible to use layouts such as "services/<name>/gen"
+// instead of a single "gen" directory. The service specific packages (service,
+// views, transport server and client packages etc.) are generated under the
+// given directory using the same structure as the "gen" directory. The files
+// shared by all services such as the CLI and the OpenAPI specification are
+// still generated in the "gen" directory.
+//
+// Output may appear in API or Service. A service uses the output directory of
+// the API if it does not define one.
+//
+// Output accepts one or two arguments. The first argument is the directory
+// relative to the output directory. The second optional argument is the import
+// path of the directory. It only needs to be set when the import path cannot be
+// computed from the import path of the "gen" directory, for example when the
+// generated code belongs to a different Go module. Both arguments may contain
+// the "{service}" placeholder which is replaced with the snake case name of the
+// service.
+//
+// Example:
+//
+//    var _ = API("monorepo", func() {
+//        Output("services/{service}/gen")
+//    })
+//
+//    var _ = Service("billing", func() {
+//        Output("billing/gen", "example.com/billing/gen")
+//    })
+//
+func Output(dir string, importPath ...string) {
+	if dir == "" || filepath.IsAbs(dir) || strings.HasPrefix(filepath.Clean(dir), "..") {
+		eval.InvalidArgError("relative directory inside the output directory", dir)
+		return
+	}
+	if len(importPath) > 1 {
+		eval.ReportError("too many arguments given to Output")
+		return
+	}
+	o := &design.OutputExpr{Dir: filepath.ToSlash(filepath.Clean(dir))}
+	if len(importPath) > 0 {
+		o.ImportPath = importPath[0]
+	}
+	switch actual := eval.Current().(type) {
+	case *design.APIExpr:
+		actual.Output = o
+	case *design.ServiceExpr:
+		actual.Output = o
+	default:
+		eval.IncompatibleDSL()
+	}
+}
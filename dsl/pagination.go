@@ -0,0 +1,128 @@
+package dsl
+
+import (
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+// Paginate describes how the results of a method are split into pages. The
+// generated service client exposes an iterator that fetches the subsequent
+// pages transparently and the OpenAPI specification documents the pagination
+// attributes.
+//
+// Paginate must appear in a Method expression.
+//
+// Paginate takes a single argument which is the defining DSL. The DSL must
+// use Items and either Cursor or Offset.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Payload(func() {
+//            Attribute("cursor", String, "Cursor of page to retrieve")
+//            Attribute("limit", Int, "Maximum number of items in page")
+//        })
+//        Result(func() {
+//            Attribute("bottles", ArrayOf(Bottle))
+//            Attribute("next", String, "Cursor of next page")
+//        })
+//        Paginate(func() {
+//            Cursor("cursor", "next")
+//            Items("bottles")
+//        })
+//    })
+//
+func Paginate(fn func()) {
+	m, ok := eval.Current().(*design.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p := &design.PaginationExpr{Method: m}
+	if !eval.Execute(fn, p) {
+		return
+	}
+	m.Pagination = p
+}
+
+// Cursor defines the attributes holding the page cursors.
+//
+// Cursor must appear in a Paginate expression.
+//
+// Cursor takes two arguments: the name of the String payload attribute holding
+// the cursor of the requested page and the name of the String result attribute
+// holding the cursor of the next page. The last page is reached when the next
+// page cursor is empty.
+//
+// Example:
+//
+//    Paginate(func() {
+//        Cursor("cursor", "next")
+//        Items("bottles")
+//    })
+//
+func Cursor(param, next string) {
+	p, ok := eval.Current().(*design.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.CursorParam = param
+	p.NextCursor = next
+}
+
+// Offset defines the attributes holding the index of the first item of a page
+// and optionally the maximum number of items in a page.
+//
+// Offset must appear in a Paginate expression.
+//
+// Offset takes one or two arguments: the name of the Int payload attribute
+// holding the index of the first item of the requested page and optionally the
+// name of the Int payload attribute holding the maximum number of items. The
+// last page is reached when a page contains no item or fewer items than the
+// limit.
+//
+// Example:
+//
+//    Paginate(func() {
+//        Offset("offset", "limit")
+//        Items("bottles")
+//    })
+//
+func Offset(offset string, limit ...string) {
+	p, ok := eval.Current().(*design.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(limit) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	p.OffsetParam = offset
+	if len(limit) == 1 {
+		p.LimitParam = limit[0]
+	}
+}
+
+// Items defines the result attribute listing the items of a page.
+//
+// Items must appear in a Paginate expression.
+//
+// Items takes one argument: the name of the result array attribute.
+//
+// Example:
+//
+//    Paginate(func() {
+//        Cursor("cursor", "next")
+//        Items("bottles")
+//    })
+//
+func Items(name string) {
+	p, ok := eval.Current().(*design.PaginationExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	p.Items = name
+}
@@ -0,0 +1,56 @@
+package dsl
+
+import (
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+// Proxy implements the method by forwarding the requests to a method of a
+// service defined in another design. This makes it possible to build an API
+// gateway or a backend-for-frontend whose methods aggregate the services of
+// other designs without writing any proxy code.
+//
+// The code generator produces a Proxy struct in the service package that
+// implements the proxied methods by calling the typed client generated for
+// the remote service. The client encodes the requests using the transport and
+// paths defined in the remote design. The payload is copied into the remote
+// method payload and the remote result into the method result, the attributes
+// are matched by name so that security attributes such as the token defined
+// with Token are propagated to the remote service. The errors returned by the
+// remote service are returned as is. The compatibility of the payload and
+// result types with the remote method is not checked at compile time, a
+// method that has a payload may only proxy a remote method that also has one.
+// Embed the Proxy struct in the service implementation to provide the proxied
+// methods. The struct is named ProxyService if the service package defines a
+// type named Proxy.
+//
+// Proxy must appear in a Method expression. The method may not stream.
+//
+// Proxy takes three arguments: the import path of the package containing the
+// code generated for the remote design, the name of the remote service and the
+// name of the remote method.
+//
+// Example:
+//
+//    var _ = Service("gateway", func() {
+//        Method("show_user", func() {
+//            Payload(func() {
+//                Token("token", String)
+//                Attribute("id", String)
+//            })
+//            Result(User)
+//            Proxy("acme.com/users/gen", "users", "show")
+//            HTTP(func() {
+//                GET("/users/{id}")
+//            })
+//        })
+//    })
+//
+func Proxy(pkg, service, method string) {
+	m, ok := eval.Current().(*design.MethodExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	m.Proxy = &design.ProxyExpr{Method: m, Package: pkg, Service: service, RemoteMethod: method}
+}
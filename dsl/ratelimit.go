@@ -0,0 +1,51 @@
+package dsl
+
+import (
+	"time"
+
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+// RateLimit sets the maximum number of requests accepted by the methods over a
+// period of time. The generated HTTP server defines a UseRateLimiter method
+// that wraps the handlers of the rate limited endpoints with a middleware
+// enforcing the limits using a pluggable goahttp.RateLimiter. Requests that
+// exceed the limit receive a 429 Too Many Requests response with a Retry-After
+// header. The limits are documented in the OpenAPI specification with the
+// "x-rate-limit" extension.
+//
+// RateLimit may appear in an API, Service or Method expression. A method
+// inherits the rate limit of its service if it does not define one and a
+// service inherits the rate limit of the API.
+//
+// RateLimit takes two arguments: the positive maximum number of requests and
+// the positive duration of the period.
+//
+// Example:
+//
+//    var _ = Service("divider", func() {
+//        RateLimit(1000, time.Hour)
+//
+//        Method("divide", func() {
+//            RateLimit(10, time.Second)
+//        })
+//    })
+//
+func RateLimit(n int, per time.Duration) {
+	if n <= 0 || per <= 0 {
+		eval.ReportError("RateLimit requires a positive number of requests and a positive duration")
+		return
+	}
+	r := &design.RateLimitExpr{Requests: n, Per: per}
+	switch actual := eval.Current().(type) {
+	case *design.APIExpr:
+		actual.RateLimit = r
+	case *design.ServiceExpr:
+		actual.RateLimit = r
+	case *design.MethodExpr:
+		actual.RateLimit = r
+	default:
+		eval.IncompatibleDSL()
+	}
+}
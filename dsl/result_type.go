@@ -107,8 +107,11 @@ func ResultType(identifier string, fn func()) *design.ResultTypeExpr {
 		typeName = fmt.Sprintf("ResultType%d", resultTypeCount)
 	}
 	// Now save the type in the API result types map
-	mt := design.NewResultTypeExpr(typeName, identifier, fn)
+	mt := design.NewResultTypeExpr(design.QualifiedName(namespace, typeName), identifier, inNamespace(fn))
 	design.Root.ResultTypes = append(design.Root.ResultTypes, mt)
+	if ns := design.Root.Namespace(namespace); ns != nil {
+		ns.Types = append(ns.Types, mt)
+	}
 
 	return mt
 }
@@ -24,6 +24,7 @@ func BasicAuthSecurity(name string, fn ...func()) *design.SchemeExpr {
 		return nil
 	}
 
+	name = design.QualifiedName(namespace, name)
 	if securitySchemeRedefined(name) {
 		return nil
 	}
@@ -39,7 +40,7 @@ func BasicAuthSecurity(name string, fn ...func()) *design.SchemeExpr {
 		}
 	}
 
-	design.Root.Schemes = append(design.Root.Schemes, expr)
+	addScheme(expr)
 
 	return expr
 }
@@ -64,6 +65,7 @@ func APIKeySecurity(name string, fn ...func()) *design.SchemeExpr {
 		return nil
 	}
 
+	name = design.QualifiedName(namespace, name)
 	if securitySchemeRedefined(name) {
 		return nil
 	}
@@ -79,7 +81,7 @@ func APIKeySecurity(name string, fn ...func()) *design.SchemeExpr {
 		}
 	}
 
-	design.Root.Schemes = append(design.Root.Schemes, expr)
+	addScheme(expr)
 
 	return expr
 }
@@ -109,6 +111,7 @@ func OAuth2Security(name string, fn ...func()) *design.SchemeExpr {
 		return nil
 	}
 
+	name = design.QualifiedName(namespace, name)
 	if securitySchemeRedefined(name) {
 		return nil
 	}
@@ -124,7 +127,7 @@ func OAuth2Security(name string, fn ...func()) *design.SchemeExpr {
 		}
 	}
 
-	design.Root.Schemes = append(design.Root.Schemes, expr)
+	addScheme(expr)
 
 	return expr
 }
@@ -157,6 +160,7 @@ func JWTSecurity(name string, fn ...func()) *design.SchemeExpr {
 		return nil
 	}
 
+	name = design.QualifiedName(namespace, name)
 	if securitySchemeRedefined(name) {
 		return nil
 	}
@@ -174,7 +178,7 @@ func JWTSecurity(name string, fn ...func()) *design.SchemeExpr {
 		}
 	}
 
-	design.Root.Schemes = append(design.Root.Schemes, expr)
+	addScheme(expr)
 
 	return expr
 }
@@ -251,11 +255,8 @@ func Security(args ...interface{}) {
 		for i, arg := range args {
 			switch val := arg.(type) {
 			case string:
-				for _, s := range design.Root.Schemes {
-					if s.SchemeName == val {
-						schemes[i] = design.DupScheme(s)
-						break
-					}
+				if s := scheme(val); s != nil {
+					schemes[i] = design.DupScheme(s)
 				}
 				if schemes[i] == nil {
 					eval.ReportError("security scheme %q not found", val)
@@ -405,6 +406,18 @@ func Password(name string, args ...interface{}) {
 //        })
 //    })
 //
+//    Method("secured_delete", func() {
+//        Security(APIKeyAuth)
+//        Payload(func() {
+//            APIKey("api_key", "key", String, "API key used to perform authorization")
+//            Required("key")
+//        })
+//        HTTP(func() {
+//            DELETE("/")
+//            Cookie("key:api_key") // Provide the key in cookie "api_key"
+//        })
+//    })
+//
 func APIKey(scheme, name string, args ...interface{}) {
 	args = useDSL(args, func() { Metadata("security:apikey:"+scheme, scheme) })
 	Attribute(name, args...)
@@ -53,6 +53,7 @@ func Type(name string, args ...interface{}) design.UserType {
 		eval.ReportError("too many arguments")
 		return nil
 	}
+	name = design.QualifiedName(namespace, name)
 	if t := design.Root.UserType(name); t != nil {
 		eval.ReportError("type %#v defined twice", name)
 		return nil
@@ -96,9 +97,12 @@ func Type(name string, args ...interface{}) design.UserType {
 
 	t := &design.UserTypeExpr{
 		TypeName:      name,
-		AttributeExpr: &design.AttributeExpr{Type: base, DSLFunc: fn},
+		AttributeExpr: &design.AttributeExpr{Type: base, DSLFunc: inNamespace(fn)},
 	}
 	design.Root.Types = append(design.Root.Types, t)
+	if ns := design.Root.Namespace(namespace); ns != nil {
+		ns.Types = append(ns.Types, t)
+	}
 	return t
 }
 
@@ -132,7 +136,7 @@ func ArrayOf(v interface{}, fn ...func()) *design.Array {
 	t, ok = v.(design.DataType)
 	if !ok {
 		if name, ok := v.(string); ok {
-			t = design.Root.UserType(name)
+			t = userType(name)
 		}
 	}
 	// never return nil to avoid panics, errors are reported after DSL execution
@@ -178,13 +182,13 @@ func MapOf(k, v interface{}, fn ...func()) *design.Map {
 	tk, ok = k.(design.DataType)
 	if !ok {
 		if name, ok := k.(string); ok {
-			tk = design.Root.UserType(name)
+			tk = userType(name)
 		}
 	}
 	tv, ok = v.(design.DataType)
 	if !ok {
 		if name, ok := v.(string); ok {
-			tv = design.Root.UserType(name)
+			tv = userType(name)
 		}
 	}
 	// never return nil to avoid panics, errors are reported after DSL execution
@@ -85,6 +85,20 @@ func Enum(vals ...interface{}) {
 //
 // FormatRFC1123: RFC1123 date time
 //
+// Custom formats registered with goa.RegisterFormat may also be used, the
+// generated code validates the values using the registered function:
+//
+//    func init() {
+//        goa.RegisterFormat("ulid", func(v string) error {
+//            _, err := ulid.Parse(v)
+//            return err
+//        })
+//    }
+//
+//    Attribute("id", String, func() {
+//        Format("ulid")
+//    })
+//
 func Format(f design.ValidationFormat) {
 	if a, ok := eval.Current().(*design.AttributeExpr); ok {
 		if !a.IsSupportedValidationFormat(f) {
@@ -260,6 +274,106 @@ func Required(names ...string) {
 	}
 }
 
+// RequiredIf makes the attribute required when the sibling attribute with the
+// given name has the given value. RequiredIf must appear in the DSL of an
+// attribute of an object.
+//
+// Example:
+//
+//    Attribute("payment", func() {
+//        Attribute("method", String, func() {
+//            Enum("card", "cash")
+//        })
+//        Attribute("card_number", String, func() {
+//            RequiredIf("method", "card")
+//        })
+//    })
+//
+func RequiredIf(name string, value interface{}) {
+	a, ok := eval.Current().(*design.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.InvalidArgError("non empty attribute name", name)
+		return
+	}
+	if a.Validation == nil {
+		a.Validation = &design.ValidationExpr{}
+	}
+	a.Validation.RequiredIf = &design.RequiredIfExpr{Attribute: name, Value: value}
+}
+
+// LessThan validates that the attribute value is lesser than the value of the
+// sibling attribute with the given name. Both attributes must be strings or
+// numbers of the same type. The validation only applies when both attributes
+// are set. LessThan must appear in the DSL of an attribute of an object.
+//
+// Example:
+//
+//    Attribute("range", func() {
+//        Attribute("min", Int, func() {
+//            LessThan("max")
+//        })
+//        Attribute("max", Int)
+//    })
+//
+func LessThan(name string) {
+	if a := comparedAttribute(name); a != nil {
+		a.Validation.LessThan = name
+	}
+}
+
+// GreaterThan validates that the attribute value is greater than the value of
+// the sibling attribute with the given name. Both attributes must be strings
+// or numbers of the same type. The validation only applies when both
+// attributes are set. GreaterThan must appear in the DSL of an attribute of an
+// object.
+//
+// Example:
+//
+//    Attribute("period", func() {
+//        Attribute("start", String)
+//        Attribute("end", String, func() {
+//            Format(FormatDateTime)
+//            GreaterThan("start")
+//        })
+//    })
+//
+func GreaterThan(name string) {
+	if a := comparedAttribute(name); a != nil {
+		a.Validation.GreaterThan = name
+	}
+}
+
+// comparedAttribute returns the current attribute with an initialized
+// validation if it can be compared to the sibling attribute with the given
+// name, nil otherwise.
+func comparedAttribute(name string) *design.AttributeExpr {
+	a, ok := eval.Current().(*design.AttributeExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return nil
+	}
+	if name == "" {
+		eval.InvalidArgError("non empty attribute name", name)
+		return nil
+	}
+	if a.Type != nil {
+		switch a.Type.Kind() {
+		case design.BooleanKind, design.BytesKind, design.AnyKind, design.ArrayKind, design.MapKind,
+			design.ObjectKind, design.UserTypeKind, design.ResultTypeKind:
+			incompatibleAttributeType("comparison", a.Type.Name(), "a string or a number")
+			return nil
+		}
+	}
+	if a.Validation == nil {
+		a.Validation = &design.ValidationExpr{}
+	}
+	return a
+}
+
 // incompatibleAttributeType reports an error for validations defined on
 // incompatible attributes (e.g. max value on string).
 func incompatibleAttributeType(validation, actual, expected string) {
@@ -1,6 +1,9 @@
 package goa
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 const (
 	// MethodKey is the request context key used to store the name of the
@@ -12,6 +15,13 @@ const (
 	// service as defined in the design. The generated transport code
 	// initializes the corresponding value prior to invoking the endpoint.
 	ServiceKey
+
+	// ViewKey is the request context key used to store the name of the
+	// result view requested by the client. The generated transport code
+	// initializes the corresponding value when the request specifies a
+	// view and the generated endpoints render the result with it instead
+	// of the view returned by the service method.
+	ViewKey
 )
 
 type (
@@ -22,3 +32,31 @@ type (
 // Endpoint exposes service methods to remote clients independently of the
 // underlying transport.
 type Endpoint func(ctx context.Context, request interface{}) (response interface{}, err error)
+
+// TimeoutEndpoint returns an endpoint that calls e with a context that is
+// canceled once the duration d has elapsed.
+func TimeoutEndpoint(e Endpoint, d time.Duration) Endpoint {
+	return func(ctx context.Context, request interface{}) (interface{}, error) {
+		ctx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		return e(ctx, request)
+	}
+}
+
+// Interceptor is a function invoked prior to an endpoint, it is given the name
+// of the intercepted method as defined in the design and the endpoint request.
+// An interceptor invokes next to carry on with the request or returns early to
+// interrupt it.
+type Interceptor func(ctx context.Context, method string, request interface{}, next Endpoint) (interface{}, error)
+
+// Intercept returns an endpoint that invokes the given interceptors in order
+// prior to calling e.
+func Intercept(method string, e Endpoint, interceptors ...Interceptor) Endpoint {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		ic, next := interceptors[i], e
+		e = func(ctx context.Context, request interface{}) (interface{}, error) {
+			return ic(ctx, method, request, next)
+		}
+	}
+	return e
+}
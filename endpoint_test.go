@@ -0,0 +1,32 @@
+package goa
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestIntercept(t *testing.T) {
+	var calls []string
+	record := func(name string) Interceptor {
+		return func(ctx context.Context, method string, req interface{}, next Endpoint) (interface{}, error) {
+			calls = append(calls, name+":"+method)
+			return next(ctx, req)
+		}
+	}
+	e := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls = append(calls, "endpoint")
+		return req, nil
+	}
+	res, err := Intercept("divide", e, record("auth"), record("logging"))(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if res != 42 {
+		t.Errorf("got result %v, expected 42", res)
+	}
+	expected := []string{"auth:divide", "logging:divide", "endpoint"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Errorf("got calls %v, expected %v", calls, expected)
+	}
+}
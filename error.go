@@ -24,6 +24,12 @@ type (
 		Temporary bool
 		// Is the error a server-side fault?
 		Fault bool
+		// Errors lists the individual errors merged with MergeErrors
+		// once the error is aggregated with AggregateErrors.
+		Errors []*ServiceError
+
+		// merged lists the individual errors merged with MergeErrors.
+		merged []*ServiceError
 	}
 )
 
@@ -120,6 +126,17 @@ func InvalidRangeError(name string, target interface{}, value interface{}, min b
 	return PermanentError("invalid_range", "%s must be %s than %d but got value %#v", name, comp, value, target)
 }
 
+// InvalidComparisonError is the error produced by the generated code when the
+// value of a payload field is not lesser (less is true) or greater (less is
+// false) than the value of the field named other.
+func InvalidComparisonError(name string, target interface{}, other string, value interface{}, less bool) error {
+	comp := "greater"
+	if less {
+		comp = "lesser"
+	}
+	return PermanentError("invalid_range", "%s must be %s than %s (%#v) but got value %#v", name, comp, other, value, target)
+}
+
 // InvalidLengthError is the error produced by the generated code when the value
 // of a payload field does not match the length validation defined in the
 // design.
@@ -144,6 +161,21 @@ func NewErrorID() string {
 	return base64.RawURLEncoding.EncodeToString(b)
 }
 
+// AggregateErrors sets the Errors field of err to the list of the individual
+// errors merged into it with MergeErrors so that the transports render them in
+// the error response. The code generated for the methods that set the
+// "validation:aggregate" metadata aggregates the validation errors this way.
+// AggregateErrors returns err unchanged if it is not a ServiceError or if it
+// does not result from merging errors.
+func AggregateErrors(err error) error {
+	e, ok := err.(*ServiceError)
+	if !ok || len(e.merged) == 0 {
+		return err
+	}
+	e.Errors = e.merged
+	return e
+}
+
 // MergeErrors updates an error by merging another into it. It first converts
 // other into a ServiceError if not already one. The merge algorithm then:
 //
@@ -153,6 +185,8 @@ func NewErrorID() string {
 //
 // * computes Timeout and Temporary by "and"ing the fields of both errors.
 //
+// * records both errors so that AggregateErrors can list them.
+//
 // Merge returns the updated error. This makes it possible to return other when
 // err is nil.
 func MergeErrors(err, other error) error {
@@ -167,6 +201,15 @@ func MergeErrors(err, other error) error {
 	}
 	e := asError(err)
 	o := asError(other)
+	if len(e.merged) == 0 {
+		first := *e
+		e.merged = []*ServiceError{&first}
+	}
+	if len(o.merged) > 0 {
+		e.merged = append(e.merged, o.merged...)
+	} else {
+		e.merged = append(e.merged, o)
+	}
 	if e.Name == "error" {
 		e.Name = o.Name
 	}
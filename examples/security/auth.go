@@ -51,6 +51,13 @@ func SecuredServiceJWTAuth(ctx context.Context, token string, s *security.JWTSch
 	//
 	//    return ctx, goa.PermanentError("unauthorized", "invalid token")
 	//
+	// Record the scopes granted by the token so that the endpoint
+	// returns a 403 error when the required scopes are missing, e.g.:
+	//
+	//    ctx = security.ContextWithScopes(ctx, claims.Scopes)
+	//
+	// The required scopes are not enforced if no scopes are recorded.
+	//
 	return ctx, fmt.Errorf("not implemented")
 }
 
@@ -91,5 +98,12 @@ func SecuredServiceOAuth2Auth(ctx context.Context, token string, s *security.OAu
 	//
 	//    return ctx, goa.PermanentError("unauthorized", "invalid token")
 	//
+	// Record the scopes granted by the token so that the endpoint
+	// returns a 403 error when the required scopes are missing, e.g.:
+	//
+	//    ctx = security.ContextWithScopes(ctx, claims.Scopes)
+	//
+	// The required scopes are not enforced if no scopes are recorded.
+	//
 	return ctx, fmt.Errorf("not implemented")
 }
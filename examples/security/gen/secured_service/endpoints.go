@@ -76,6 +76,9 @@ func NewSecureEndpoint(s Service, authJWTFn security.AuthJWTFunc) goa.Endpoint {
 			token = *p.Token
 		}
 		ctx, err = authJWTFn(ctx, token, &sc)
+		if err == nil {
+			err = security.CheckScopes(ctx, sc.RequiredScopes)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -99,6 +102,9 @@ func NewDoublySecureEndpoint(s Service, authJWTFn security.AuthJWTFunc, authAPIK
 			token = *p.Token
 		}
 		ctx, err = authJWTFn(ctx, token, &sc)
+		if err == nil {
+			err = security.CheckScopes(ctx, sc.RequiredScopes)
+		}
 		if err == nil {
 			sc := security.APIKeyScheme{
 				Name: "api_key",
@@ -132,6 +138,9 @@ func NewAlsoDoublySecureEndpoint(s Service, authJWTFn security.AuthJWTFunc, auth
 			token = *p.Token
 		}
 		ctx, err = authJWTFn(ctx, token, &sc)
+		if err == nil {
+			err = security.CheckScopes(ctx, sc.RequiredScopes)
+		}
 		if err == nil {
 			sc := security.APIKeyScheme{
 				Name: "api_key",
@@ -161,6 +170,9 @@ func NewAlsoDoublySecureEndpoint(s Service, authJWTFn security.AuthJWTFunc, auth
 				token = *p.OauthToken
 			}
 			ctx, err = authOAuth2Fn(ctx, token, &sc)
+			if err == nil {
+				err = security.CheckScopes(ctx, sc.RequiredScopes)
+			}
 			if err == nil {
 				sc := security.BasicScheme{
 					Name: "basic",
@@ -0,0 +1,134 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	// Breaker is the circuit breaker interface invoked by the generated
+	// clients around each request. Use the UseBreaker method of the
+	// generated clients to install a breaker, adapters for libraries such as
+	// gobreaker or hystrix only need to implement this interface.
+	Breaker interface {
+		// Allow returns a non-nil error if requests to the given service
+		// method must not be made. The error is returned to the caller
+		// of the endpoint.
+		Allow(service, method string) error
+		// Record records the outcome of a request made to the given
+		// service method. failed is true if the request could not be
+		// sent or if the server responded with a 5xx status code.
+		Record(service, method string, failed bool)
+	}
+
+	// CircuitOpenError is the error returned by the default Breaker
+	// implementation when the circuit of a method is open.
+	CircuitOpenError struct {
+		// Service is the name of the service.
+		Service string
+		// Method is the name of the service method.
+		Method string
+	}
+
+	// breaker is the default Breaker implementation.
+	breaker struct {
+		threshold int
+		cooldown  time.Duration
+		mu        sync.Mutex
+		circuits  map[string]*circuit
+	}
+
+	// circuit is the state of the breaker for a single method.
+	circuit struct {
+		// failures is the number of consecutive failures.
+		failures int
+		// openedAt is the time the circuit was opened, zero if closed.
+		openedAt time.Time
+		// probing is true while a request is made with a half-open
+		// circuit.
+		probing bool
+	}
+
+	// breakerDoer is a Doer that invokes a breaker around requests.
+	breakerDoer struct {
+		Doer
+		breaker Breaker
+		service string
+		method  string
+	}
+)
+
+// NewBreaker returns a Breaker that opens the circuit of a method after
+// threshold consecutive failed requests. Requests made while the circuit is
+// open fail with a CircuitOpenError. Once cooldown has elapsed a single request
+// is allowed through: the circuit closes if it succeeds and opens again
+// otherwise.
+func NewBreaker(threshold int, cooldown time.Duration) Breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown, circuits: make(map[string]*circuit)}
+}
+
+// NewBreakerDoer wraps the given doer and invokes b around each request. The
+// generated clients use NewBreakerDoer to implement UseBreaker.
+func NewBreakerDoer(b Breaker, service, method string, d Doer) Doer {
+	return &breakerDoer{Doer: d, breaker: b, service: service, method: method}
+}
+
+// Allow returns a CircuitOpenError if the circuit of the method is open.
+func (b *breaker) Allow(service, method string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.circuits[service+"#"+method]
+	if !ok || c.openedAt.IsZero() {
+		return nil
+	}
+	if c.probing || time.Since(c.openedAt) < b.cooldown {
+		return &CircuitOpenError{Service: service, Method: method}
+	}
+	c.probing = true
+	return nil
+}
+
+// Record updates the state of the circuit of the method.
+func (b *breaker) Record(service, method string, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	key := service + "#" + method
+	c, ok := b.circuits[key]
+	if !ok {
+		c = &circuit{}
+		b.circuits[key] = c
+	}
+	c.probing = false
+	if !failed {
+		c.failures = 0
+		c.openedAt = time.Time{}
+		return
+	}
+	c.failures++
+	if c.failures >= b.threshold {
+		c.openedAt = time.Now()
+	}
+}
+
+// Do makes the request if the breaker allows it and records the outcome.
+func (d *breakerDoer) Do(req *http.Request) (*http.Response, error) {
+	if err := d.breaker.Allow(d.service, d.method); err != nil {
+		return nil, err
+	}
+	resp, err := d.Doer.Do(req)
+	d.breaker.Record(d.service, d.method, err != nil || resp.StatusCode >= http.StatusInternalServerError)
+	return resp, err
+}
+
+// Error returns the error message.
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit open for %s %s", e.Service, e.Method)
+}
+
+// Timeout returns false, it makes CircuitOpenError implement net.Error.
+func (e *CircuitOpenError) Timeout() bool { return false }
+
+// Temporary returns true: the request may succeed once the circuit closes.
+func (e *CircuitOpenError) Temporary() bool { return true }
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestBreakerDoer(t *testing.T) {
+	var (
+		status int
+		err    error
+		calls  int
+	)
+	doer := doerFunc(func(*http.Request) (*http.Response, error) {
+		calls++
+		if err != nil {
+			return nil, err
+		}
+		return &http.Response{StatusCode: status}, nil
+	})
+	b := NewBreaker(2, 10*time.Millisecond)
+	d := NewBreakerDoer(b, "svc", "method", doer)
+	req, _ := http.NewRequest("GET", "http://localhost", nil)
+	do := func() error {
+		_, e := d.Do(req)
+		return e
+	}
+
+	status = http.StatusInternalServerError
+	do()
+	err = errors.New("connection refused")
+	do()
+	if calls != 2 {
+		t.Fatalf("got %d calls, expected 2", calls)
+	}
+	e := do()
+	if _, ok := e.(*CircuitOpenError); !ok {
+		t.Fatalf("got error %v, expected circuit open error", e)
+	}
+	if calls != 2 {
+		t.Errorf("got %d calls with open circuit, expected 2", calls)
+	}
+
+	err, status = nil, http.StatusOK
+	if _, e := NewBreakerDoer(b, "svc", "other", doer).Do(req); e != nil {
+		t.Errorf("got error %v for other method, expected none", e)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if e := do(); e != nil {
+		t.Fatalf("got error %v after cooldown, expected none", e)
+	}
+	if e := do(); e != nil {
+		t.Errorf("got error %v after success, expected circuit to close", e)
+	}
+}
@@ -0,0 +1,220 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/codegen"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// BenchmarkFiles returns one file per service containing benchmarks of the
+// generated HTTP encoders and decoders. The benchmarks encode and decode the
+// requests and responses built from the design examples so that regressions
+// in the generated code can be tracked per endpoint. Streaming, multipart and
+// raw body endpoints are not benchmarked.
+func BenchmarkFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.HTTPServices {
+		if f := benchmarkFile(genpkg, svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// benchmarkFile returns the file containing the benchmarks of the given
+// service endpoints, nil if no endpoint can be benchmarked.
+func benchmarkFile(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
+	ptrs := make(map[string]string)
+	data := exampleTestsData(HTTPServices.Get(svc.Name()), ptrs)
+	if data == nil {
+		return nil
+	}
+	var (
+		pkg     = data.Service.Service.PkgName
+		svcPath = codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/http/" + codegen.SnakeCase(svc.Name())
+		path    = filepath.Join(codegen.ServiceGendir(svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "benchmark_test.go")
+		title   = fmt.Sprintf("%s HTTP encoder and decoder benchmarks", svc.Name())
+		specs   = []*codegen.ImportSpec{
+			{Path: "bytes"},
+			{Path: "context"},
+			{Path: "io/ioutil"},
+			{Path: "net/http"},
+			{Path: "net/http/httptest"},
+			{Path: "testing"},
+			{Path: "goa.design/goa/http", Name: "goahttp"},
+		}
+	)
+	for _, t := range data.Tests {
+		if strings.Contains(t.PayloadCode+t.ResultCode, pkg+".") || t.Endpoint.Method.ViewedResult != nil {
+			// Primitive payloads and results do not reference the
+			// service package.
+			specs = append(specs, &codegen.ImportSpec{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()), Name: pkg})
+			break
+		}
+	}
+	specs = append(specs,
+		&codegen.ImportSpec{Path: svcPath + "/server", Name: data.ServerPkg},
+		&codegen.ImportSpec{Path: svcPath + "/client", Name: data.ClientPkg},
+	)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, pkg+"_test", specs),
+		&codegen.SectionTemplate{
+			Name:    "benchmark-client",
+			Source:  benchmarkClientT,
+			Data:    data,
+			FuncMap: map[string]interface{}{"streamingEndpointExists": streamingEndpointExists, "binaryStreamExists": binaryStreamExists},
+		},
+	}
+	for _, t := range data.Tests {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "benchmark-encode",
+			Source: benchmarkEncodeT + benchmarkValuesT,
+			Data:   t,
+		}, &codegen.SectionTemplate{
+			Name:   "benchmark-decode",
+			Source: benchmarkDecodeT + benchmarkValuesT,
+			Data:   t,
+		})
+	}
+	for _, name := range sortedKeys(ptrs) {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "benchmark-pointer",
+			Source: exampleTestsPointerT,
+			Data:   map[string]string{"Name": name, "TypeRef": ptrs[name]},
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: ExampleTestsData
+const benchmarkClientT = `{{ printf "new%sBenchmarkClient returns a client of the %q service used to encode requests and decode responses." .Service.Service.StructName .Service.Service.Name | comment }}
+func new{{ .Service.Service.StructName }}BenchmarkClient() *{{ .ClientPkg }}.{{ .Service.ClientStruct }} {
+	return {{ .ClientPkg }}.New{{ .Service.ClientStruct }}("http", "localhost", http.DefaultClient, goahttp.RequestEncoder, goahttp.ResponseDecoder, false{{ if streamingEndpointExists .Service }}, nil, nil{{ end }}{{ if binaryStreamExists .Service }}, nil{{ end }})
+}
+
+// benchmarkMuxer is a muxer that returns the path parameters captured when the
+// benchmarked request was routed so that the request decoders can be run
+// without routing the request.
+type benchmarkMuxer struct {
+	goahttp.Muxer
+	vars map[string]string
+}
+
+// Vars returns the captured path parameters.
+func (m benchmarkMuxer) Vars(*http.Request) map[string]string {
+	return m.vars
+}
+`
+
+// input: EndpointTestData
+const benchmarkEncodeT = `{{ printf "Benchmark%s%sEncode measures the encoding of the %q endpoint requests by the client and responses by the server initialized with the design examples." .ServiceStruct .Endpoint.Method.VarName .Endpoint.Method.Name | comment }}
+func Benchmark{{ .ServiceStruct }}{{ .Endpoint.Method.VarName }}Encode(b *testing.B) {
+{{- template "values" . }}
+	ctx := context.Background()
+	b.Run("request", func(b *testing.B) {
+		cli := new{{ .ServiceStruct }}BenchmarkClient()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+	{{- if .Endpoint.RequestEncoder }}
+			req, err := cli.{{ .Endpoint.RequestInit.Name }}(ctx{{ if .Endpoint.RequestInit.ClientArgs }}, payload{{ end }})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := {{ .ClientPkg }}.{{ .Endpoint.RequestEncoder }}(goahttp.RequestEncoder)(req, payload); err != nil {
+				b.Fatal(err)
+			}
+	{{- else }}
+			if _, err := cli.{{ .Endpoint.RequestInit.Name }}(ctx{{ if .Endpoint.RequestInit.ClientArgs }}, payload{{ end }}); err != nil {
+				b.Fatal(err)
+			}
+	{{- end }}
+		}
+	})
+	b.Run("response", func(b *testing.B) {
+		encode := {{ .ServerPkg }}.{{ .Endpoint.ResponseEncoder }}(goahttp.ResponseEncoder)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := encode(ctx, httptest.NewRecorder(), {{ if .Endpoint.Result.Ref }}res{{ else }}nil{{ end }}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+`
+
+// input: EndpointTestData
+const benchmarkDecodeT = `{{ printf "Benchmark%s%sDecode measures the decoding of the %q endpoint requests by the server and responses by the client initialized with the design examples." .ServiceStruct .Endpoint.Method.VarName .Endpoint.Method.Name | comment }}
+func Benchmark{{ .ServiceStruct }}{{ .Endpoint.Method.VarName }}Decode(b *testing.B) {
+{{- template "values" . }}
+	ctx := context.Background()
+{{- if .Endpoint.Payload.Ref }}
+	b.Run("request", func(b *testing.B) {
+		req, err := new{{ .ServiceStruct }}BenchmarkClient().{{ .Endpoint.RequestInit.Name }}(ctx{{ if .Endpoint.RequestInit.ClientArgs }}, payload{{ end }})
+		if err != nil {
+			b.Fatal(err)
+		}
+	{{- if .Endpoint.RequestEncoder }}
+		if err := {{ .ClientPkg }}.{{ .Endpoint.RequestEncoder }}(goahttp.RequestEncoder)(req, payload); err != nil {
+			b.Fatal(err)
+		}
+	{{- end }}
+		var body []byte
+		if req.Body != nil {
+			if body, err = ioutil.ReadAll(req.Body); err != nil {
+				b.Fatal(err)
+			}
+		}
+		mux := goahttp.NewMuxer()
+		var vars map[string]string
+		{{ .ServerPkg }}.{{ .Endpoint.MountHandler }}(mux, http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			vars = mux.Vars(r)
+		}))
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+		decode := {{ .ServerPkg }}.{{ .Endpoint.RequestDecoder }}(benchmarkMuxer{mux, vars}, goahttp.RequestDecoder)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			if _, err := decode(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+{{- end }}
+	b.Run("response", func(b *testing.B) {
+		w := httptest.NewRecorder()
+		if err := {{ .ServerPkg }}.{{ .Endpoint.ResponseEncoder }}(goahttp.ResponseEncoder)(ctx, w, {{ if .Endpoint.Result.Ref }}res{{ else }}nil{{ end }}); err != nil {
+			b.Fatal(err)
+		}
+		resp := w.Result()
+		body := w.Body.Bytes()
+		decode := {{ .ClientPkg }}.{{ .Endpoint.ResponseDecoder }}(goahttp.ResponseDecoder, false)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+			if _, err := decode(resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+`
+
+// input: EndpointTestData
+const benchmarkValuesT = `
+{{- define "values" }}
+	{{- if .Endpoint.Payload.Ref }}
+	payload := {{ .PayloadCode }}
+	{{- end }}
+	{{- if .Endpoint.Result.Ref }}
+		{{- if .Endpoint.Method.ViewedResult }}
+	res := {{ .Endpoint.ServicePkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}({{ .ResultCode }}, {{ printf "%q" (or .Endpoint.Method.ViewedResult.ViewName "default") }})
+		{{- else }}
+	res := {{ .ResultCode }}
+		{{- end }}
+	{{- end }}
+{{- end }}`
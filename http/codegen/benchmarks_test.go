@@ -0,0 +1,47 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestBenchmarkFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.ExampleTestsDSL)
+	fs := BenchmarkFiles("goa.design/goa/gen", httpdesign.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	f := fs[0]
+	if path := filepath.Join("gen", "http", "storage", "benchmark_test.go"); f.Path != path {
+		t.Errorf("got path %q, expected %q", f.Path, path)
+	}
+	cases := []struct {
+		Name  string
+		Index int
+		Code  string
+	}{
+		{"benchmark-client", 0, testdata.BenchmarkClientCode},
+		{"benchmark-encode", 0, testdata.BenchmarkShowEncodeCode},
+		{"benchmark-decode", 0, testdata.BenchmarkShowDecodeCode},
+		{"benchmark-encode", 2, testdata.BenchmarkRemoveEncodeCode},
+		{"benchmark-decode", 2, testdata.BenchmarkRemoveDecodeCode},
+	}
+	for _, c := range cases {
+		sections := f.Section(c.Name)
+		if len(sections) <= c.Index {
+			t.Errorf("got %d %s sections, expected at least %d", len(sections), c.Name, c.Index+1)
+			continue
+		}
+		code := codegen.SectionCode(t, sections[c.Index])
+		if code != c.Code {
+			t.Errorf("invalid code for %s section %d, got:\n%s\ngot vs. expected:\n%s", c.Name, c.Index, code, codegen.Diff(t, code, c.Code))
+		}
+	}
+	if n := len(f.Section("benchmark-encode")); n != 3 {
+		t.Errorf("got %d benchmarked endpoints, expected 3", n)
+	}
+}
@@ -24,24 +24,28 @@ func ClientFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
 
 // client returns the client HTTP transport file
 func client(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
-	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "client", "client.go")
+	path := filepath.Join(codegen.ServiceGendir(svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "client", "client.go")
 	data := HTTPServices.Get(svc.Name())
 	title := fmt.Sprintf("%s client HTTP transport", svc.Name())
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "client", []*codegen.ImportSpec{
 			{Path: "context"},
+			{Path: "encoding/json"},
 			{Path: "fmt"},
 			{Path: "io"},
 			{Path: "mime/multipart"},
 			{Path: "net/http"},
+			{Path: "net/url"},
 			{Path: "strconv"},
 			{Path: "strings"},
 			{Path: "sync"},
+			{Path: "time"},
 			{Path: "github.com/gorilla/websocket"},
 			{Path: "goa.design/goa", Name: "goa"},
 			{Path: "goa.design/goa/http", Name: "goahttp"},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()), Name: data.Service.PkgName},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: data.Service.ViewsPkg},
+			{Path: "goa.design/goa/http/otel", Name: "goaotel"},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()), Name: data.Service.PkgName},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: data.Service.ViewsPkg},
 		}),
 	}
 	sections = append(sections, &codegen.SectionTemplate{
@@ -50,15 +54,27 @@ func client(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 		Data:   data,
 		FuncMap: map[string]interface{}{
 			"streamingEndpointExists": streamingEndpointExists,
+			"binaryStreamExists":      binaryStreamExists,
 		},
 	})
 	for _, e := range data.Endpoints {
 		if e.ClientStream != nil {
+			src := streamStructTypeT
+			if e.ClientStream.NDJSON {
+				src = ndjsonStreamStructTypeT
+			}
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "client-stream-struct-type",
-				Source: streamStructTypeT,
+				Source: src,
 				Data:   e.ClientStream,
 			})
+			if e.ClientStream.Resume != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-resumable-stream-struct-type",
+					Source: resumableStreamStructTypeT,
+					Data:   e.ClientStream,
+				})
+			}
 		}
 	}
 
@@ -78,9 +94,30 @@ func client(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 		Data:   data,
 		FuncMap: map[string]interface{}{
 			"streamingEndpointExists": streamingEndpointExists,
+			"binaryStreamExists":      binaryStreamExists,
 		},
 	})
 
+	if hasServerVariables(data.Servers) {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-server-url",
+			Source: clientServerURLT,
+			Data:   data,
+		})
+	}
+
+	for _, e := range data.Endpoints {
+		if usesDoer(e) {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "client-breaker",
+				Source:  clientBreakerT,
+				Data:    data,
+				FuncMap: map[string]interface{}{"usesDoer": usesDoer},
+			})
+			break
+		}
+	}
+
 	for _, e := range data.Endpoints {
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "client-endpoint-init",
@@ -88,10 +125,15 @@ func client(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 			Data:   e,
 		})
 		if e.ClientStream != nil {
+			recv := streamRecvT
+			if e.ClientStream.NDJSON {
+				recv = ndjsonStreamRecvT
+			}
 			sections = append(sections, &codegen.SectionTemplate{
-				Name:   "client-stream-recv",
-				Source: streamRecvT,
-				Data:   e.ClientStream,
+				Name:    "client-stream-recv",
+				Source:  recv,
+				Data:    e.ClientStream,
+				FuncMap: map[string]interface{}{"hasErrorInit": hasErrorInit},
 			})
 			if e.Method.ViewedResult != nil {
 				sections = append(sections, &codegen.SectionTemplate{
@@ -100,6 +142,18 @@ func client(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 					Data:   e.ClientStream,
 				})
 			}
+			if e.ClientStream.Resume != nil {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-resumable-endpoint-init",
+					Source: resumableEndpointInitT,
+					Data:   e,
+				})
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "client-resumable-stream-recv",
+					Source: resumableStreamRecvT,
+					Data:   e.ClientStream,
+				})
+			}
 		}
 	}
 
@@ -109,7 +163,7 @@ func client(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 // clientEncodeDecode returns the file containing the HTTP client encoding and
 // decoding logic.
 func clientEncodeDecode(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
-	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "client", "encode_decode.go")
+	path := filepath.Join(codegen.ServiceGendir(svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "client", "encode_decode.go")
 	data := HTTPServices.Get(svc.Name())
 	title := fmt.Sprintf("%s HTTP client encoders and decoders", svc.Name())
 	sections := []*codegen.SectionTemplate{
@@ -127,8 +181,9 @@ func clientEncodeDecode(genpkg string, svc *httpdesign.ServiceExpr) *codegen.Fil
 			{Path: "unicode/utf8"},
 			{Path: "goa.design/goa", Name: "goa"},
 			{Path: "goa.design/goa/http", Name: "goahttp"},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()), Name: data.Service.PkgName},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: data.Service.ViewsPkg},
+			{Path: "goa.design/goa/http/otel", Name: "goaotel"},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()), Name: data.Service.PkgName},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: data.Service.ViewsPkg},
 		}),
 	}
 
@@ -163,6 +218,13 @@ func clientEncodeDecode(genpkg string, svc *httpdesign.ServiceExpr) *codegen.Fil
 				Data:   e,
 			})
 		}
+		if e.TrailersDecoder != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "trailers-decoder",
+				Source: trailersDecoderT,
+				Data:   e,
+			})
+		}
 	}
 	for _, h := range data.ClientTransformHelpers {
 		sections = append(sections, &codegen.SectionTemplate{
@@ -198,6 +260,40 @@ func isBearer(schemes []*service.SchemeData) bool {
 	return false
 }
 
+// usesDoer returns true if the endpoint client makes requests with its Doer,
+// false if it uses a websocket connection.
+func usesDoer(e *EndpointData) bool {
+	return e.ClientStream == nil || e.ClientStream.NDJSON
+}
+
+// hasErrorInit returns true if at least one of the errors uses the default
+// error type and thus can be built with the service package error functions.
+func hasErrorInit(errs []*ErrorGroupData) bool {
+	for _, gerr := range errs {
+		for _, er := range gerr.Errors {
+			if er.Init != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasServerVariables returns true if one of the given servers defines URL
+// variables or if there is more than one server so that the generated client
+// may expose the function that builds the server URLs.
+func hasServerVariables(servers []*ServerData) bool {
+	if len(servers) > 1 {
+		return true
+	}
+	for _, s := range servers {
+		if len(s.Variables) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // input: ServiceData
 const clientStructT = `{{ printf "%s lists the %s service endpoint HTTP clients." .ClientStruct .Service.Name | comment }}
 type {{ .ClientStruct }} struct {
@@ -217,6 +313,100 @@ type {{ .ClientStruct }} struct {
 	dialer goahttp.Dialer
 	connConfigFn goahttp.ConnConfigureFunc
 	{{- end }}
+	{{- if binaryStreamExists . }}
+	codec goahttp.StreamCodec
+	{{- end }}
+}
+`
+
+// input: StreamData
+const resumableStreamStructTypeT = `{{ printf "%s implements the %s interface. It re-dials the %q endpoint when the websocket connection fails." .Resume.VarName .Interface .Endpoint.Method.Name | comment }}
+type {{ .Resume.VarName }} struct {
+	{{ comment "ctx is the context used to dial the endpoint." }}
+	ctx context.Context
+	{{ comment "endpoint dials the endpoint." }}
+	endpoint goa.Endpoint
+	{{ comment "payload is the payload used to dial the endpoint." }}
+	payload interface{}
+	{{ comment "stream is the stream of the current websocket connection." }}
+	stream *{{ .VarName }}
+	{{ comment "token is the resume token of the last received result." }}
+	token string
+	{{ comment "maxRetries is the maximum number of consecutive attempts to re-dial the endpoint." }}
+	maxRetries int
+	{{ comment "retries is the number of consecutive attempts to re-dial the endpoint." }}
+	retries int
+}
+`
+
+// input: EndpointData
+const resumableEndpointInitT = `{{ printf "%s returns an endpoint that makes HTTP requests to the %s service %s server. The stream returned by the endpoint re-dials the server up to maxRetries consecutive times when the websocket connection fails and sets the %q header to the resume token of the last received result so that the server may resume the stream." .ClientStream.Resume.EndpointInit .ServiceName .Method.Name .ClientStream.Resume.Header | comment }}
+func (c *{{ .ClientStruct }}) {{ .ClientStream.Resume.EndpointInit }}(maxRetries int) goa.Endpoint {
+	endpoint := c.{{ .EndpointInit }}()
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		res, err := endpoint(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		return &{{ .ClientStream.Resume.VarName }}{
+			ctx:        ctx,
+			endpoint:   endpoint,
+			payload:    v,
+			stream:     res.(*{{ .ClientStream.VarName }}),
+			maxRetries: maxRetries,
+		}, nil
+	}
+}
+`
+
+// input: StreamData
+const resumableStreamRecvT = `{{ printf "Recv receives a %s type from the %q endpoint websocket connection, it re-dials the endpoint if the connection fails." .RecvName .Endpoint.Method.Name | comment }}
+func (s *{{ .Resume.VarName }}) Recv() ({{ .RecvRef }}, error) {
+	for {
+		res, err := s.stream.Recv()
+		if err == nil {
+			{{- if .Resume.TokenPointer }}
+			if res.{{ .Resume.TokenField }} != nil {
+				s.token = *res.{{ .Resume.TokenField }}
+			}
+			{{- else }}
+			s.token = res.{{ .Resume.TokenField }}
+			{{- end }}
+			s.retries = 0
+			return res, nil
+		}
+		if !goahttp.IsTransientStreamError(err) {
+			return nil, err
+		}
+		s.stream.conn.Close()
+		if err := s.redial(err); err != nil {
+			return nil, err
+		}
+	}
+}
+
+{{ printf "redial re-dials the %q endpoint until the connection succeeds or the maximum number of retries is reached." .Endpoint.Method.Name | comment }}
+func (s *{{ .Resume.VarName }}) redial(err error) error {
+	for s.retries < s.maxRetries {
+		s.retries++
+		if err := goahttp.WaitRedial(s.ctx, s.retries); err != nil {
+			return err
+		}
+		ctx := s.ctx
+		if s.token != "" {
+			ctx = goahttp.WithRequestOptions(ctx, goahttp.WithHeader({{ printf "%q" .Resume.Header }}, s.token))
+		}
+		var res interface{}
+		res, err = s.endpoint(ctx, s.payload)
+		if err == nil {
+			s.stream = res.(*{{ .VarName }})
+			return nil
+		}
+		if !goahttp.IsTransientStreamError(err) {
+			return err
+		}
+	}
+	return err
 }
 `
 
@@ -233,6 +423,9 @@ func New{{ .ClientStruct }}(
 	dialer goahttp.Dialer,
 	connConfigFn goahttp.ConnConfigureFunc,
 	{{- end }}
+	{{- if binaryStreamExists . }}
+	codec goahttp.StreamCodec,
+	{{- end }}
 ) *{{ .ClientStruct }} {
 	return &{{ .ClientStruct }}{
 		{{- range .Endpoints }}
@@ -247,16 +440,35 @@ func New{{ .ClientStruct }}(
 		dialer: dialer,
 		connConfigFn: connConfigFn,
 		{{- end }}
+		{{- if binaryStreamExists . }}
+		codec: codec,
+		{{- end }}
 	}
 }
 `
 
+// input: ServiceData
+const clientBreakerT = `{{ printf "UseBreaker configures the client to invoke the circuit breaker b around the requests made to the %s service endpoints. Websocket streaming endpoints are not affected." .Service.Name | comment }}
+func (c *{{ .ClientStruct }}) UseBreaker(b goahttp.Breaker) {
+	{{- range .Endpoints }}
+		{{- if usesDoer . }}
+	c.{{ .Method.VarName }}Doer = goahttp.NewBreakerDoer(b, {{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, c.{{ .Method.VarName }}Doer)
+		{{- end }}
+	{{- end }}
+}
+`
+
 // input: EndpointData
 const endpointInitT = `{{ printf "%s returns an endpoint that makes HTTP requests to the %s service %s server." .EndpointInit .ServiceName .Method.Name | comment }}
+{{- if and .ClientStream .ClientStream.Compression }}
+// The endpoint negotiates the websocket permessage-deflate compression
+// extension when the client dialer is a *websocket.Dialer, other dialers must
+// enable compression themselves.
+{{- end }}
 func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder }}{{ .MultipartRequestEncoder.VarName }} {{ .MultipartRequestEncoder.FuncName }}{{ end }}) goa.Endpoint {
 	var (
 		{{- if .ClientStream }}
-			{{- if not .ClientStream.SendRef }}
+			{{- if and (not .ClientStream.SendRef) .RequestEncoder }}
 		encodeRequest  = {{ .RequestEncoder }}({{ if .MultipartRequestEncoder }}{{ .MultipartRequestEncoder.InitName }}({{ .MultipartRequestEncoder.VarName }}){{ else }}c.encoder{{ end }})
 			{{- end }}
 		{{- else }}
@@ -266,7 +478,30 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 		{{- end }}
 		decodeResponse = {{ .ResponseDecoder }}(c.decoder, c.RestoreResponseBody)
 	)
-	return func(ctx context.Context, v interface{}) (interface{}, error) {
+	return func(ctx context.Context, v interface{}) ({{ if .Tracing }}_ interface{}, err error{{ else }}interface{}, error{{ end }}) {
+	{{- if .Tracing }}
+		ctx, span := goaotel.StartClientSpan(ctx, {{ printf "%q" .Tracing.SpanName }})
+		defer func() { goaotel.EndSpan(span, err) }()
+	{{- end }}
+	{{- if .Method.SkipRequestBodyEncodeDecode }}
+		data, ok := v.(*{{ .ServicePkgName }}.{{ .Method.RequestStruct }})
+		if !ok {
+			return nil, goahttp.ErrInvalidType("{{ .ServiceName }}", "{{ .Method.Name }}", "*{{ .ServicePkgName }}.{{ .Method.RequestStruct }}", v)
+		}
+		{{- if .Payload.Ref }}
+		v = data.Payload
+		{{- end }}
+	{{- end }}
+	{{- if and (not .ClientStream) (not .Method.SkipResponseBodyEncodeDecode) }}
+		ctx, cancel := goahttp.ContextWithRequestTimeout(ctx)
+		defer cancel()
+	{{- end }}
+	{{- if and .Method.Timeout (not .ClientStream) (not .Method.SkipResponseBodyEncodeDecode) }}
+		if _, ok := ctx.Deadline(); !ok {
+			ctx, cancel = context.WithTimeout(ctx, {{ .Method.Timeout }})
+			defer cancel()
+		}
+	{{- end }}
 		req, err := c.{{ .RequestInit.Name }}(ctx, {{ range .RequestInit.ClientArgs }}{{ .Ref }}{{ end }})
 		if err != nil {
 			return nil, err
@@ -277,9 +512,27 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 			return nil, err
 		}
 	{{- end }}
+	{{- if .Method.SkipRequestBodyEncodeDecode }}
+		req.Body = data.Body
+	{{- end }}
+		goahttp.ApplyRequestOptions(ctx, req)
 
-	{{- if .ClientStream }}
-		conn, resp, err := c.dialer.Dial(req.URL.String(), req.Header)
+	{{- if and .ClientStream .ClientStream.NDJSON }}
+		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		if resp.StatusCode != {{ .ClientStream.Response.StatusCode }} {
+			return decodeResponse(resp)
+		}
+		stream := &{{ .ClientStream.VarName }}{body: resp.Body, dec: json.NewDecoder(resp.Body)}
+		{{- if .Method.ViewedResult }}
+		view := resp.Header.Get("goa-view")
+		stream.SetView(view)
+		{{- end }}
+		return stream, nil
+	{{- else if .ClientStream }}
+		conn, resp, err := {{ if .ClientStream.Compression }}goahttp.CompressionDialer(c.dialer){{ else }}c.dialer{{ end }}.Dial(req.URL.String(), req.Header)
 		if err != nil {
 			if resp != nil {
 				return decodeResponse(resp)
@@ -289,19 +542,48 @@ func (c *{{ .ClientStruct }}) {{ .EndpointInit }}({{ if .MultipartRequestEncoder
 		if c.connConfigFn != nil {
 			conn = c.connConfigFn(conn)
 		}
-		stream := &{{ .ClientStream.VarName }}{conn: conn}
+		{{- if .ClientStream.KeepAlive }}
+		goahttp.KeepAlive(conn, {{ .ClientStream.KeepAlive.PingInterval }}, {{ .ClientStream.KeepAlive.PongWait }}, {{ .ClientStream.KeepAlive.WriteWait }})
+		{{- end }}
+		stream := &{{ .ClientStream.VarName }}{conn: conn{{ if .ClientStream.Binary }}, codec: c.codec{{ end }}}
 		{{- if .Method.ViewedResult }}
 		view := resp.Header.Get("goa-view")
 		stream.SetView(view)
 		{{- end }}
 		return stream, nil
 	{{- else }}
+		{{- if .Compress }}
+		req.Header.Set("Accept-Encoding", "gzip, deflate")
+		{{- end }}
 		resp, err := c.{{ .Method.VarName }}Doer.Do(req)
 
 		if err != nil {
 			return nil, goahttp.ErrRequestError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
 		}
+		{{- if .Compress }}
+		if err := goahttp.DecompressResponse(resp); err != nil {
+			resp.Body.Close()
+			return nil, goahttp.ErrDecodingError("{{ .ServiceName }}", "{{ .Method.Name }}", err)
+		}
+		{{- end }}
+		{{- if .Method.SkipResponseBodyEncodeDecode }}
+		{{ if .Result.Ref }}res, err :={{ else }}_, err ={{ end }} decodeResponse(resp)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		{{- if .TrailersDecoder }}
+		r := res.({{ .Result.Ref }})
+		body := goahttp.TrailerReader(resp.Body, func() error {
+			return {{ .TrailersDecoder }}(resp, r)
+		})
+		return &{{ .ServicePkgName }}.{{ .Method.ResponseStruct }}{Result: r, Body: body}, nil
+		{{- else }}
+		return &{{ .ServicePkgName }}.{{ .Method.ResponseStruct }}{ {{ if .Result.Ref }}Result: res.({{ .Result.Ref }}), {{ end }}Body: resp.Body}, nil
+		{{- end }}
+		{{- else }}
 		return decodeResponse(resp)
+		{{- end }}
 	{{- end }}
 	}
 }
@@ -323,16 +605,20 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			return goahttp.ErrInvalidType("{{ .ServiceName }}", "{{ .Method.Name }}", "{{ .Payload.Ref }}", v)
 		}
 	{{- range .Payload.Request.Headers }}
-		{{- if .FieldName }}
+		{{- if .Nested }}
+		if {{ range $i, $p := .Nested.Parents }}{{ if $i }} && {{ end }}p.{{ $p.FieldName }} != nil{{ end }}{{ if .Nested.FieldPointer }} && p.{{ .FieldName }} != nil{{ end }} {
+			req.Header.Set({{ printf "%q" .Name }}, {{ if .EnumType }}{{ .TypeName }}({{ end }}{{ if .Nested.FieldPointer }}*{{ end }}p.{{ .FieldName }}{{ if .EnumType }}){{ end }})
+		}
+		{{- else if .FieldName }}
 			{{- if .Pointer }}
 		if p.{{ .FieldName }} != nil {
 			{{- end }}
 			{{- if (and (eq .Name "Authorization") (isBearer $.HeaderSchemes)) }}
-		if !strings.Contains({{ if .Pointer }}*{{ end }}p.{{ .FieldName }}, " ") {
-			req.Header.Set({{ printf "%q" .Name }}, "Bearer "+{{ if .Pointer }}*{{ end }}p.{{ .FieldName }})
+		if !strings.Contains({{ if .EnumType }}{{ .TypeName }}({{ end }}{{ if .Pointer }}*{{ end }}p.{{ .FieldName }}{{ if .EnumType }}){{ end }}, " ") {
+			req.Header.Set({{ printf "%q" .Name }}, "Bearer "+{{ if .EnumType }}{{ .TypeName }}({{ end }}{{ if .Pointer }}*{{ end }}p.{{ .FieldName }}{{ if .EnumType }}){{ end }})
 		} else {
 			{{- end }}
-			req.Header.Set({{ printf "%q" .Name }}, {{ if .Pointer }}*{{ end }}p.{{ .FieldName }})
+			req.Header.Set({{ printf "%q" .Name }}, {{ if .EnumType }}{{ .TypeName }}({{ end }}{{ if .Pointer }}*{{ end }}p.{{ .FieldName }}{{ if .EnumType }}){{ end }})
 			{{- if (and (eq .Name "Authorization") (isBearer $.HeaderSchemes)) }}
 		}
 			{{- end }}
@@ -341,6 +627,26 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- end }}
 		{{- end }}
 	{{- end }}
+	{{- range .Payload.Request.Cookies }}
+		{{- if .FieldName }}
+			{{- if .Pointer }}
+		if p.{{ .FieldName }} != nil {
+			{{- end }}
+		req.AddCookie(&http.Cookie{
+			Name: {{ printf "%q" .Name }},
+			Value:
+			{{- if eq .Type.Name "bytes" }} string(
+			{{- else if not (eq .Type.Name "string") }} fmt.Sprintf("%v", 
+			{{- end }}
+			{{- if .EnumType }}{{ .TypeName }}({{ end }}{{ if .Pointer }}*{{ end }}p.{{ .FieldName }}{{ if .EnumType }}){{ end }}
+			{{- if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) }})
+			{{- end }},
+		})
+			{{- if .Pointer }}
+		}
+			{{- end }}
+		{{- end }}
+	{{- end }}
 	{{- if or .Payload.Request.QueryParams }}
 		values := req.URL.Query()
 	{{- end }}
@@ -358,6 +664,32 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			values.Add(keyStr, valueStr)
 			{{- end }}
     }
+		{{- else if .DeepObject }}
+		for key, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
+			{{ template "type_conversion" (typeConversionData .Type.KeyType.Type "keyStr" "key") }}
+			{{- if eq .Type.ElemType.Type.Name "array" }}
+			for _, val := range value {
+				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type.ElemType.Type "valStr" "val") }}
+				values.Add(goahttp.DeepObjectQueryKey({{ printf "%q" .Name }}, keyStr), valStr)
+			}
+			{{- else }}
+			{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value") }}
+			values.Add(goahttp.DeepObjectQueryKey({{ printf "%q" .Name }}, keyStr), valueStr)
+			{{- end }}
+		}
+		{{- else if and .Delimiter .StringSlice }}
+		if p{{ if .FieldName }}.{{ .FieldName }}{{ end }} != nil {
+			values.Add({{ printf "%q" .Name }}, strings.Join(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}, {{ printf "%q" .Delimiter }}))
+		}
+		{{- else if .Delimiter }}
+		if p{{ if .FieldName }}.{{ .FieldName }}{{ end }} != nil {
+			vals := make([]string, len(p{{ if .FieldName }}.{{ .FieldName }}{{ end }}))
+			for i, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
+				{{ template "type_conversion" (typeConversionData .Type.ElemType.Type "valueStr" "value") }}
+				vals[i] = valueStr
+			}
+			values.Add({{ printf "%q" .Name }}, strings.Join(vals, {{ printf "%q" .Delimiter }}))
+		}
 		{{- else if .StringSlice }}
 			for _, value := range p{{ if .FieldName }}.{{ .FieldName }}{{ end }} {
 				values.Add("{{ .Name }}", value)
@@ -375,7 +707,7 @@ func {{ .RequestEncoder }}(encoder func(*http.Request) goahttp.Encoder) func(*ht
 			{{- if eq .Type.Name "bytes" }} string(
 			{{- else if not (eq .Type.Name "string") }} fmt.Sprintf("%v", 
 			{{- end }}
-			{{- if .Pointer }}*{{ end }}p.{{ .FieldName }}
+			{{- if .EnumType }}{{ .TypeName }}({{ end }}{{ if .Pointer }}*{{ end }}p.{{ .FieldName }}{{ if .EnumType }}){{ end }}
 			{{- if or (eq .Type.Name "bytes") (not (eq .Type.Name "string")) }})
 			{{- end }})
 			{{- if .Pointer }}
@@ -456,13 +788,14 @@ const responseDecoderT = `{{ printf "%s returns a decoder for responses returned
 {{ printf "%s may return the following errors:" .ResponseDecoder | comment }}
 	{{- range $gerr := .Errors }}
 	{{- range $errors := .Errors }}
-//	- {{ printf "%q" .Name }} (type {{ .Ref }}): {{ .Response.StatusCode }}{{ if .Response.Description }}, {{ .Response.Description }}{{ end }}
+//	- {{ printf "%q" .Name }} (type {{ if $.ProblemErrors }}*goahttp.Problem{{ else }}{{ .Ref }}{{ end }}): {{ .Response.StatusCode }}{{ if .Response.Description }}, {{ .Response.Description }}{{ end }}
 	{{- end }}
 	{{- end }}
 //	- error: internal error
 {{- end }}
 func {{ .ResponseDecoder }}(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
 	return func(resp *http.Response) (interface{}, error) {
+	{{- if not .Method.SkipResponseBodyEncodeDecode }}
 		if restoreBody {
 			b, err := ioutil.ReadAll(resp.Body)
 			if err != nil {
@@ -475,6 +808,7 @@ func {{ .ResponseDecoder }}(decoder func(*http.Response) goahttp.Decoder, restor
 		} else {
 			defer resp.Body.Close()
 		}
+	{{- end }}
 		switch resp.StatusCode {
 	{{- range .Result.Responses }}
 		case {{ .StatusCode }}:
@@ -505,7 +839,9 @@ func {{ .ResponseDecoder }}(decoder func(*http.Response) goahttp.Decoder, restor
 	{{- end }}
 	{{- range .Errors }}
 		case {{ .StatusCode }}:
-		{{- if gt (len .Errors) 1 }}
+		{{- if $.ProblemErrors }}
+			return nil, goahttp.DecodeProblem({{ printf "%q" $.ServiceName }}, {{ printf "%q" $.Method.Name }}, resp)
+		{{- else if gt (len .Errors) 1 }}
 		en := resp.Header.Get("goa-error")
 		switch en {
 			{{- range .Errors }}
@@ -544,7 +880,53 @@ func {{ .ResponseDecoder }}(decoder func(*http.Response) goahttp.Decoder, restor
 		}
 	}
 }
-`
+
+{{- define "default_assignment" }}
+	{{- if .Pointer }}
+				var tmp {{ .TypeName }} = {{ printf "%#v" .DefaultValue }}
+				{{ .VarName }} = &tmp
+	{{- else }}
+				{{ .VarName }} = {{ printf "%#v" .DefaultValue }}
+	{{- end }}
+{{- end }}
+` + typeConversionT
+
+// input: EndpointData
+const trailersDecoderT = `{{ printf "%s sets the fields of the %s %s endpoint result mapped to the response trailers. The trailers are only available once the response body has been read to EOF." .TrailersDecoder .ServiceName .Method.Name | comment }}
+func {{ .TrailersDecoder }}(resp *http.Response, res {{ .Result.Ref }}) error {
+	var err error
+	switch resp.StatusCode {
+	{{- range .Result.Responses }}
+		{{- if .Trailers }}
+	case {{ .StatusCode }}:
+			{{- range .Trailers }}
+		{
+			var {{ .VarName }} {{ .TypeRef }}
+			{{ .VarName }}Raw := resp.Trailer.Get({{ printf "%q" .Name }})
+			if {{ .VarName }}Raw != "" {
+			{{- if eq .Type.Name "string" }}
+				{{ .VarName }} = {{ if .Pointer }}&{{ end }}{{ .VarName }}Raw
+			{{- else if eq .Type.Name "any" }}
+				{{ .VarName }} = {{ .VarName }}Raw
+			{{- else }}
+				{{- template "type_conversion" . }}
+			{{- end }}
+			}
+			{{- if .Validate }}
+			{{ .Validate }}
+			{{- end }}
+			res.{{ .FieldName }} = {{ if .EnumType }}{{ if .Pointer }}(*{{ .EnumType }}){{ else }}{{ .EnumType }}{{ end }}({{ end }}{{ .VarName }}{{ if .EnumType }}){{ end }}
+		}
+			{{- end }}
+		{{- end }}
+	{{- end }}
+	}
+	if err != nil {
+		return goahttp.ErrValidationError({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, err)
+	}
+	return nil
+}
+` + typeConversionT
 
 // input: ResponseData
 const singleResponseT = ` {{- if .ClientBody }}
@@ -589,7 +971,7 @@ const singleResponseT = ` {{- if .ClientBody }}
 					{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
 				}
 				{{- if .DefaultValue }} else {
-					{{ .VarName }} = {{ if eq .Type.Name "string" }}{{ printf "%q" .DefaultValue }}{{ else }}{{ printf "%#v" .DefaultValue }}{{ end }}
+					{{- template "default_assignment" . }}
 				}
 				{{- end }}
 			{{- end }}
@@ -634,7 +1016,7 @@ const singleResponseT = ` {{- if .ClientBody }}
 			}
 			{{- else if .DefaultValue }}
 			if {{ .VarName }}Raw == "" {
-				{{ .VarName }} = {{ printf "%#v" .DefaultValue }}
+				{{- template "default_assignment" . }}
 			}
 			{{- end }}
 
@@ -653,6 +1035,55 @@ const singleResponseT = ` {{- if .ClientBody }}
 		{{- end }}{{/* range .Headers */}}
 	{{- end }}
 
+	{{- if .Cookies }}
+			var (
+		{{- range .Cookies }}
+				{{ .VarName }} {{ .TypeRef }}
+				{{ .VarName }}Raw string
+		{{- end }}
+		{{- if not .ClientBody }}
+			{{- if and .MustValidate (not .Headers) }}
+				err error
+			{{- end }}
+		{{- end }}
+			)
+			for _, c := range resp.Cookies() {
+				switch c.Name {
+		{{- range .Cookies }}
+				case {{ printf "%q" .Name }}:
+					{{ .VarName }}Raw = c.Value
+		{{- end }}
+				}
+			}
+		{{- range .Cookies }}
+			{{- if .Required }}
+			if {{ .VarName }}Raw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "cookie"))
+			}
+			{{- else if .DefaultValue }}
+			if {{ .VarName }}Raw == "" {
+				{{- template "default_assignment" . }}
+			}
+			{{- end }}
+
+			{{- if .DefaultValue }}else {
+			{{- else if not .Required }}
+			if {{ .VarName }}Raw != "" {
+			{{- end }}
+			{{- if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
+				{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
+			{{- else }}
+				{{- template "type_conversion" . }}
+			{{- end }}
+			{{- if or .DefaultValue (not .Required) }}
+			}
+			{{- end }}
+		{{- if .Validate }}
+			{{ .Validate }}
+		{{- end }}
+		{{- end }}{{/* range .Cookies */}}
+	{{- end }}
+
 	{{- if .MustValidate }}
 			if err != nil {
 				return nil, goahttp.ErrValidationError("{{ $.ServiceName }}", "{{ $.Method.Name }}", err)
@@ -709,3 +1140,63 @@ func (c *{{ .ClientStream.VarName }}) Recv() ({{ .Result.Ref }}, error) {
 	{{- end }}
 }
 `
+
+// input: ServiceData
+const clientServerURLT = `{{ printf "Servers lists the URLs of the %s service hosts. The URLs may contain variables using the \"{name}\" syntax, use ServerURL to build the URL of a host." .Service.Name | comment }}
+var Servers = []string{
+{{- range .Servers }}
+	{{ printf "%q" .URL }},{{ if .Description }} // {{ .Description }}{{ end }}
+{{- end }}
+}
+
+{{ printf "ServerURL returns the URL of the %s service host at the given index in Servers. The URL variables are set with the values given in vars or with their default values. The scheme and host of the URL may be given to New%s." .Service.Name .ClientStruct | comment }}
+func ServerURL(index int, vars map[string]string) (*url.URL, error) {
+	var (
+		u        string
+		defaults map[string]string
+		values   map[string][]string
+	)
+	switch index {
+{{- range $i, $s := .Servers }}
+	case {{ $i }}:
+		u = Servers[{{ $i }}]
+	{{- if .Variables }}
+		defaults = map[string]string{
+		{{- range .Variables }}
+			{{ printf "%q" .Name }}: {{ printf "%q" .DefaultValue }},
+		{{- end }}
+		}
+		{{- $enums := false }}{{ range .Variables }}{{ if .Values }}{{ $enums = true }}{{ end }}{{ end }}
+		{{- if $enums }}
+		values = map[string][]string{
+		{{- range .Variables }}{{ if .Values }}
+			{{ printf "%q" .Name }}: { {{- range $j, $v := .Values }}{{ if $j }}, {{ end }}{{ printf "%q" $v }}{{ end -}} },
+		{{- end }}{{ end }}
+		}
+		{{- end }}
+	{{- end }}
+{{- end }}
+	default:
+		return nil, fmt.Errorf("invalid server index %d, must be between 0 and %d", index, len(Servers)-1)
+	}
+	for name, def := range defaults {
+		v, ok := vars[name]
+		if !ok {
+			v = def
+		} else if vals, ok := values[name]; ok {
+			valid := false
+			for _, val := range vals {
+				if v == val {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return nil, fmt.Errorf("invalid value %q for server variable %q, must be one of %s", v, name, strings.Join(vals, ", "))
+			}
+		}
+		u = strings.Replace(u, "{"+name+"}", v, -1)
+	}
+	return url.Parse(u)
+}
+`
@@ -18,6 +18,9 @@ func TestBodyTypeDecl(t *testing.T) {
 	}{
 		{"body-user-inner", testdata.PayloadBodyUserInnerDSL, BodyUserInnerDeclCode},
 		{"body-path-user-validate", testdata.PayloadBodyPathUserValidateDSL, BodyPathUserValidateDeclCode},
+		{"body-struct-tags", testdata.PayloadBodyStructTagsDSL, BodyStructTagsDeclCode},
+		{"body-wire-naming", testdata.PayloadBodyWireNamingDSL, BodyWireNamingDeclCode},
+		{"body-internal", testdata.PayloadBodyInternalDSL, BodyInternalDeclCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -43,6 +46,7 @@ func TestBodyTypeInit(t *testing.T) {
 		{"body-user-inner", testdata.PayloadBodyUserInnerDSL, 3, BodyUserInnerInitCode},
 		{"body-path-user-validate", testdata.PayloadBodyPathUserValidateDSL, 2, BodyPathUserValidateInitCode},
 		{"body-primitive-array-user-validate", testdata.PayloadBodyPrimitiveArrayUserValidateDSL, 2, BodyPrimitiveArrayUserValidateInitCode},
+		{"body-internal", testdata.PayloadBodyInternalDSL, 3, BodyInternalInitCode},
 		{"result-body-user", testdata.ResultBodyObjectHeaderDSL, 2, ResultBodyObjectHeaderInitCode},
 		{"result-explicit-body-primitive", testdata.ExplicitBodyPrimitiveResultMultipleViewsDSL, 1, ExplicitBodyPrimitiveResultMultipleViewsInitCode},
 		{"result-explicit-body-user-type", testdata.ExplicitBodyUserResultMultipleViewsDSL, 2, ExplicitBodyUserResultMultipleViewsInitCode},
@@ -75,6 +79,31 @@ type MethodUserBodyPathValidateRequestBody struct {
 }
 `
 
+const BodyStructTagsDeclCode = `// MethodBodyStructTagsRequestBody is the type of the "ServiceBodyStructTags"
+// service "MethodBodyStructTags" endpoint HTTP request body.
+type MethodBodyStructTagsRequestBody struct {
+	A string  ` + "`" + `db:"a_column" form:"a" json:"a" xml:"a"` + "`" + `
+	B *string ` + "`" + `form:"b,omitempty" json:"bee,omitempty" xml:"b,omitempty"` + "`" + `
+}
+`
+
+const BodyWireNamingDeclCode = `// MethodBodyWireNamingRequestBody is the type of the "ServiceBodyWireNaming"
+// service "MethodBodyWireNaming" endpoint HTTP request body.
+type MethodBodyWireNamingRequestBody struct {
+	FirstName string            ` + "`" + `form:"firstName" json:"firstName" xml:"firstName"` + "`" + `
+	ItemSKU   *string           ` + "`" + `form:"itemSku,omitempty" json:"itemSku,omitempty" xml:"itemSku,omitempty"` + "`" + `
+	Tags      map[string]string ` + "`" + `form:"tags,omitempty" json:"tags,omitempty" xml:"tags,omitempty"` + "`" + `
+}
+`
+
+const BodyInternalDeclCode = `// MethodBodyInternalRequestBody is the type of the "ServiceBodyInternal"
+// service "MethodBodyInternal" endpoint HTTP request body.
+type MethodBodyInternalRequestBody struct {
+	Account *AccountRequestBody ` + "`" + `form:"account" json:"account" xml:"account"` + "`" + `
+	Note    *string             ` + "`" + `form:"note,omitempty" json:"note,omitempty" xml:"note,omitempty"` + "`" + `
+}
+`
+
 const BodyPrimitiveArrayUserValidateInitCode = `// NewPayloadTypeRequestBody builds the HTTP request body from the payload of
 // the "MethodBodyPrimitiveArrayUserValidate" endpoint of the
 // "ServiceBodyPrimitiveArrayUserValidate" service.
@@ -153,3 +182,17 @@ func NewMethodExplicitBodyUserResultMultipleViewResulttypemultipleviewsOK(body *
 	return res
 }
 `
+
+const BodyInternalInitCode = `// NewMethodBodyInternalRequestBody builds the HTTP request body from the
+// payload of the "MethodBodyInternal" endpoint of the "ServiceBodyInternal"
+// service.
+func NewMethodBodyInternalRequestBody(p *servicebodyinternal.MethodBodyInternalPayload) *MethodBodyInternalRequestBody {
+	body := &MethodBodyInternalRequestBody{
+		Note: p.Note,
+	}
+	if p.Account != nil {
+		body.Account = marshalAccountToAccountRequestBody(p.Account)
+	}
+	return body
+}
+`
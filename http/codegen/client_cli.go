@@ -34,6 +34,9 @@ type (
 		PkgName string
 		// NeedStream if true passes websocket specific arguments to the CLI.
 		NeedStream bool
+		// NeedCodec if true passes the websocket binary stream codec to
+		// the CLI.
+		NeedCodec bool
 	}
 
 	subcommandData struct {
@@ -59,6 +62,29 @@ type (
 		// MultipartRequestEncoder is the data necessary to render
 		// multipart request encoder.
 		MultipartRequestEncoder *MultipartData
+		// RequestStruct is the fully qualified name of the endpoint
+		// input struct used when the request body is streamed from
+		// stdin, empty otherwise.
+		RequestStruct string
+		// PayloadRef is the fully qualified reference to the payload
+		// type if any.
+		PayloadRef string
+		// Deprecated is the reason given to the Deprecated DSL if the
+		// endpoint is deprecated, the empty string otherwise.
+		Deprecated string
+		// Timeout is the default value of the "timeout" flag, e.g. "30s".
+		// It is empty if the method does not define a timeout.
+		Timeout string
+		// PayloadExamples lists the named examples of the payload
+		// defined in the design if any.
+		PayloadExamples []*payloadExampleData
+	}
+
+	payloadExampleData struct {
+		// Summary is the example summary, e.g. "expired token".
+		Summary string
+		// Value is the JSON serialized example value.
+		Value string
 	}
 
 	flagData struct {
@@ -76,6 +102,13 @@ type (
 		Required bool
 		// Example returns a JSON serialized example value.
 		Example string
+		// Enum lists the values allowed by the design if any, used to
+		// generate the shell completions.
+		Enum []string
+		// Secret is true if the flag holds a password or a credential
+		// as defined by the endpoint security schemes, its value is
+		// then read without echo when prompted.
+		Secret bool
 	}
 
 	buildFunctionData struct {
@@ -104,6 +137,25 @@ type (
 		Args []*InitArgData
 	}
 
+	tableData struct {
+		// TypeRef is the fully qualified reference to the result type,
+		// e.g. "*storage.StoredBottle".
+		TypeRef string
+		// Collection is true if the result type is an array in which
+		// case the table contains one row per element.
+		Collection bool
+		// Columns lists the table columns.
+		Columns []*columnData
+	}
+
+	columnData struct {
+		// Header is the column header, e.g. "VINTAGE".
+		Header string
+		// Field is the name of the result type field rendered in the
+		// column, e.g. "Vintage".
+		Field string
+	}
+
 	fieldData struct {
 		// Name is the field name, e.g. "Vintage"
 		Name string
@@ -147,22 +199,38 @@ func endpointParser(genpkg string, root *httpdesign.RootExpr, data []*commandDat
 	path := filepath.Join(codegen.Gendir, "http", "cli", "cli.go")
 	title := fmt.Sprintf("%s HTTP client CLI support package", root.Design.API.Name)
 	specs := []*codegen.ImportSpec{
+		{Path: "bufio"},
+		{Path: "bytes"},
 		{Path: "encoding/json"},
 		{Path: "flag"},
 		{Path: "fmt"},
+		{Path: "io"},
+		{Path: "io/ioutil"},
+		{Path: "mime/multipart"},
 		{Path: "net/http"},
 		{Path: "os"},
+		{Path: "reflect"},
+		{Path: "sort"},
 		{Path: "strconv"},
+		{Path: "strings"},
+		{Path: "text/tabwriter"},
+		{Path: "time"},
 		{Path: "unicode/utf8"},
 		{Path: "goa.design/goa", Name: "goa"},
 		{Path: "goa.design/goa/http", Name: "goahttp"},
+		{Path: "golang.org/x/crypto/ssh/terminal"},
+		{Path: "gopkg.in/yaml.v2"},
 	}
 	for _, svc := range root.HTTPServices {
 		sd := HTTPServices.Get(svc.Name())
 		specs = append(specs, &codegen.ImportSpec{
-			Path: genpkg + "/http/" + codegen.SnakeCase(sd.Service.Name) + "/client",
+			Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/http/" + codegen.SnakeCase(sd.Service.Name) + "/client",
 			Name: sd.Service.PkgName + "c",
 		})
+		specs = append(specs, &codegen.ImportSpec{
+			Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(sd.Service.Name),
+			Name: sd.Service.PkgName,
+		})
 	}
 	usages := make([]string, len(data))
 	var examples []string
@@ -193,6 +261,7 @@ func endpointParser(genpkg string, root *httpdesign.RootExpr, data []*commandDat
 		Data:   data,
 		FuncMap: map[string]interface{}{
 			"streamingCmdExists": streamingCmdExists,
+			"binaryCmdExists":    binaryCmdExists,
 		},
 	})
 	for _, cmd := range data {
@@ -203,10 +272,102 @@ func endpointParser(genpkg string, root *httpdesign.RootExpr, data []*commandDat
 			FuncMap: map[string]interface{}{"printDescription": printDescription},
 		})
 	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "cli-print-result",
+		Source: printResultT,
+		Data:   buildTablesData(root),
+	})
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "cli-bind-flags",
+		Source: bindFlagsT,
+	})
+	var secrets []string
+	for _, cmd := range data {
+		for _, sub := range cmd.Subcommands {
+			for _, f := range sub.Flags {
+				if f.Secret {
+					secrets = append(secrets, cmd.Name+" "+sub.Name+" "+f.Name)
+				}
+			}
+		}
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "cli-prompt",
+		Source: promptT,
+		Data:   secrets,
+	})
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "cli-completion",
+		Source: completionT,
+		Data:   data,
+	})
+
+	var streams []*StreamData
+	for _, svc := range root.HTTPServices {
+		for _, e := range HTTPServices.Get(svc.Name()).Endpoints {
+			if e.ClientStream != nil {
+				streams = append(streams, e.ClientStream)
+			}
+		}
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:   "cli-stream",
+		Source: streamT,
+		Data:   streams,
+	})
 
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
+// buildTablesData returns the data needed to render the tables of the results
+// of the given HTTP services endpoints. The table columns correspond to the
+// primitive attributes of the result types. Results that are not objects or
+// arrays of objects are not rendered as tables.
+func buildTablesData(root *httpdesign.RootExpr) []*tableData {
+	var (
+		tables []*tableData
+		seen   = make(map[string]struct{})
+	)
+	for _, svc := range root.HTTPServices {
+		sd := HTTPServices.Get(svc.Name())
+		for _, e := range svc.HTTPEndpoints {
+			m := e.MethodExpr
+			if m.Result.Type == design.Empty || m.IsStreaming() {
+				continue
+			}
+			obj := design.AsObject(m.Result.Type)
+			collection := false
+			if arr := design.AsArray(m.Result.Type); arr != nil {
+				obj = design.AsObject(arr.ElemType.Type)
+				collection = true
+			}
+			if obj == nil {
+				continue
+			}
+			var cols []*columnData
+			for _, nat := range *obj {
+				if !design.IsPrimitive(nat.Attribute.Type) || nat.Attribute.Type.Kind() == design.BytesKind {
+					continue
+				}
+				cols = append(cols, &columnData{
+					Header: strings.ToUpper(codegen.SnakeCase(nat.Name)),
+					Field:  codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+				})
+			}
+			if len(cols) == 0 {
+				continue
+			}
+			ref := sd.Service.Scope.GoFullTypeRef(m.Result, sd.Service.PkgName)
+			if _, ok := seen[ref]; ok {
+				continue
+			}
+			seen[ref] = struct{}{}
+			tables = append(tables, &tableData{TypeRef: ref, Collection: collection, Columns: cols})
+		}
+	}
+	return tables
+}
+
 func printDescription(desc string) string {
 	res := strings.Replace(desc, "`", "`+\"`\"+`", -1)
 	res = strings.Replace(res, "\n", "\n\t", -1)
@@ -216,7 +377,7 @@ func printDescription(desc string) string {
 // payloadBuilders returns the file that contains the payload constructors that
 // use flag values as arguments.
 func payloadBuilders(genpkg string, svc *httpdesign.ServiceExpr, data *commandData) *codegen.File {
-	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "client", "cli.go")
+	path := filepath.Join(codegen.ServiceGendir(svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "client", "cli.go")
 	title := fmt.Sprintf("%s HTTP client CLI support package", svc.Name())
 	sd := HTTPServices.Get(svc.Name())
 	specs := []*codegen.ImportSpec{
@@ -228,7 +389,7 @@ func payloadBuilders(genpkg string, svc *httpdesign.ServiceExpr, data *commandDa
 		{Path: "unicode/utf8"},
 		{Path: "goa.design/goa", Name: "goa"},
 		{Path: "goa.design/goa/http", Name: "goahttp"},
-		{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()), Name: sd.Service.PkgName},
+		{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()), Name: sd.Service.PkgName},
 	}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "client", specs),
@@ -236,9 +397,10 @@ func payloadBuilders(genpkg string, svc *httpdesign.ServiceExpr, data *commandDa
 	for _, sub := range data.Subcommands {
 		if sub.BuildFunction != nil {
 			sections = append(sections, &codegen.SectionTemplate{
-				Name:   "cli-build-payload",
-				Source: buildPayloadT,
-				Data:   sub.BuildFunction,
+				Name:    "cli-build-payload",
+				Source:  buildPayloadT,
+				FuncMap: map[string]interface{}{"nestedFieldInitData": nestedFieldInitData},
+				Data:    sub.BuildFunction,
 			})
 		}
 	}
@@ -277,6 +439,7 @@ func buildCommandData(svc *ServiceData) *commandData {
 		Example:     example,
 		PkgName:     svc.Service.PkgName + "c",
 		NeedStream:  streamingEndpointExists(svc),
+		NeedCodec:   binaryStreamExists(svc),
 	}
 }
 
@@ -354,16 +517,100 @@ func buildSubcommandData(svc *ServiceData, e *EndpointData) *subcommandData {
 		MethodVarName: e.Method.VarName,
 		BuildFunction: buildFunction,
 		Conversion:    conversion,
+		Deprecated:    e.Method.Deprecated,
 	}
 	if e.MultipartRequestEncoder != nil {
 		sub.MultipartRequestEncoder = e.MultipartRequestEncoder
 	}
+	if e.Method.Timeout != "" && e.ClientStream == nil && !e.Method.SkipResponseBodyEncodeDecode {
+		sub.Timeout = methodTimeout(svc.Service.Name, e.Method.Name)
+		for _, f := range flags {
+			if f.Name == "timeout" {
+				sub.Timeout = ""
+				break
+			}
+		}
+	}
+	if e.Method.SkipRequestBodyEncodeDecode {
+		sub.RequestStruct = e.ServicePkgName + "." + e.Method.RequestStruct
+		if buildFunction != nil || conversion != "" {
+			sub.PayloadRef = e.Payload.Ref
+		}
+	}
+	if m := design.Root.Service(svc.Service.Name).Method(e.Method.Name); m != nil {
+		for _, f := range flags {
+			f.Enum = flagEnum(m.Payload, f.Name)
+		}
+		if len(flags) > 0 {
+			for _, ex := range m.Payload.NamedExamples() {
+				sub.PayloadExamples = append(sub.PayloadExamples, &payloadExampleData{
+					Summary: ex.Summary,
+					Value:   jsonExample(ex.Value),
+				})
+			}
+		}
+	}
+	for _, r := range e.Method.Requirements {
+		for _, sc := range r.Schemes {
+			secret := sc.KeyAttr
+			if sc.Type == "Basic" {
+				secret = sc.PasswordAttr
+			}
+			if secret == "" {
+				continue
+			}
+			for _, f := range flags {
+				if f.Name == codegen.KebabCase(secret) {
+					f.Secret = true
+				}
+			}
+		}
+	}
 	generateExample(sub, svc.Service.Name)
 	cmds[fullName] = sub
 
 	return sub
 }
 
+// flagEnum returns the values allowed by the payload attribute corresponding
+// to the flag with the given name if any.
+func flagEnum(payload *design.AttributeExpr, name string) []string {
+	att := payload
+	if obj := design.AsObject(payload.Type); obj != nil {
+		att = nil
+		for _, nat := range *obj {
+			if codegen.KebabCase(nat.Name) == name {
+				att = nat.Attribute
+				break
+			}
+		}
+	} else if name != "p" {
+		att = nil
+	}
+	if att == nil || att.Validation == nil || len(att.Validation.Values) == 0 {
+		return nil
+	}
+	enum := make([]string, len(att.Validation.Values))
+	for i, v := range att.Validation.Values {
+		enum[i] = fmt.Sprint(v)
+	}
+	return enum
+}
+
+// methodTimeout returns the string representation of the timeout of the given
+// service method, e.g. "30s".
+func methodTimeout(svc, m string) string {
+	s := design.Root.Service(svc)
+	if s == nil {
+		return ""
+	}
+	me := s.Method(m)
+	if me == nil || me.Timeout == 0 {
+		return ""
+	}
+	return me.Timeout.String()
+}
+
 func generateExample(sub *subcommandData, svc string) {
 	ex := codegen.KebabCase(svc) + " " + codegen.KebabCase(sub.Name)
 	for _, f := range sub.Flags {
@@ -633,6 +880,17 @@ func streamingCmdExists(data []*commandData) bool {
 	return false
 }
 
+// binaryCmdExists returns true if at least one command in the list of commands
+// uses a websocket stream with binary frames.
+func binaryCmdExists(data []*commandData) bool {
+	for _, c := range data {
+		if c.NeedCodec {
+			return true
+		}
+	}
+	return false
+}
+
 // input: []string
 const usageT = `// UsageCommands returns the set of commands and sub-commands using the format
 //
@@ -665,6 +923,9 @@ func ParseEndpoint(
 	dialer goahttp.Dialer,
 	connConfigFn goahttp.ConnConfigureFunc,
 	{{- end }}
+	{{- if binaryCmdExists . }}
+	codec goahttp.StreamCodec,
+	{{- end }}
 	{{- range $c := . }}
 	{{- range .Subcommands }}
 		{{- if .MultipartRequestEncoder }}
@@ -682,6 +943,12 @@ func ParseEndpoint(
 		{{- range .Flags }}
 		{{ .FullName }}Flag = {{ $sub.FullName }}Flags.String("{{ .Name }}", "{{ if .Required }}REQUIRED{{ end }}", {{ printf "%q" .Description }})
 		{{- end }}
+		{{- if .Timeout }}
+		{{ .FullName }}TimeoutFlag = {{ .FullName }}Flags.String("timeout", "{{ .Timeout }}", "request timeout")
+		{{- end }}
+		{{- if .MultipartRequestEncoder }}
+		{{ .FullName }}FileFlag = {{ .FullName }}Flags.String("file", "", "Comma separated list of NAME=PATH multipart parts read from files")
+		{{- end }}
 		{{ end }}
 		{{- end }}
 	)
@@ -747,6 +1014,14 @@ func ParseEndpoint(
 			return nil, nil, err
 		}
 	}
+	if err := bindFlags(epf, svcn, epn); err != nil {
+		return nil, nil, err
+	}
+	if Interactive {
+		if err := promptFlags(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	var (
 		data     interface{}
@@ -757,11 +1032,33 @@ func ParseEndpoint(
 		switch svcn {
 	{{- range . }}
 		case "{{ .Name }}":
-			c := {{ .PkgName }}.NewClient(scheme, host, doer, enc, dec, restore{{ if .NeedStream }}, dialer, connConfigFn{{- end }})
+			c := {{ .PkgName }}.NewClient(scheme, host, doer, enc, dec, restore{{ if .NeedStream }}, dialer, connConfigFn{{- end }}{{ if .NeedCodec }}, codec{{ end }})
 			switch epn {
-		{{- $pkgName := .PkgName }}{{ range .Subcommands }}
+		{{- $pkgName := .PkgName }}{{ $svcName := .Name }}{{ range .Subcommands }}
 			case "{{ .Name }}":
-				endpoint = c.{{ .MethodVarName }}({{ if .MultipartRequestEncoder }}{{ .MultipartRequestEncoder.VarName }}{{ end }})
+			{{- if .Deprecated }}
+				fmt.Fprintln(os.Stderr, {{ printf "warning: %s %s is deprecated: %s" $svcName .Name .Deprecated | printf "%q" }})
+			{{- end }}
+			{{- if .MultipartRequestEncoder }}
+				endpoint = c.{{ .MethodVarName }}(func(mw *multipart.Writer, p {{ .MultipartRequestEncoder.Payload.Ref }}) error {
+					if {{ .MultipartRequestEncoder.VarName }} != nil {
+						if err := {{ .MultipartRequestEncoder.VarName }}(mw, p); err != nil {
+							return err
+						}
+					}
+					return goahttp.WriteMultipartFiles(mw, *{{ .FullName }}FileFlag)
+				})
+			{{- else }}
+				endpoint = c.{{ .MethodVarName }}()
+			{{- end }}
+			{{- if .Timeout }}
+				var timeout time.Duration
+				timeout, err = time.ParseDuration(*{{ .FullName }}TimeoutFlag)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid value for {{ .FullName }}TimeoutFlag, must be DURATION")
+				}
+				endpoint = goa.TimeoutEndpoint(endpoint, timeout)
+			{{- end }}
 			{{- if .BuildFunction }}
 				data, err = {{ $pkgName}}.{{ .BuildFunction.Name }}({{ range .BuildFunction.ActualParams }}*{{ . }}Flag, {{ end }})
 			{{- else if .Conversion }}
@@ -769,6 +1066,11 @@ func ParseEndpoint(
 			{{- else }}
 				data = nil
 			{{- end }}
+			{{- if .RequestStruct }}
+				if err == nil {
+					data = &{{ .RequestStruct }}{ {{ if .PayloadRef }}Payload: data.({{ .PayloadRef }}), {{ end }}Body: os.Stdin}
+				}
+			{{- end }}
 		{{- end }}
 			}
 	{{- end }}
@@ -812,8 +1114,10 @@ func {{ .Name }}({{ range .FormalParams }}{{ . }} string, {{ end }}) ({{ .Result
 			{{- end }}
 			{{- if .ReturnIsStruct }}
 				{{- range $.Args }}
-					{{- if .FieldName }}
-	{{ if $.PayloadInit.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ .Name }}
+					{{- if .Nested }}
+						{{- template "nested_field_init" (nestedFieldInitData (or (and $.PayloadInit.ReturnTypeAttribute "res") "v") .) }}
+					{{- else if .FieldName }}
+	{{ if $.PayloadInit.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ if .EnumTypeRef }}{{ .EnumTypeRef }}({{ .Name }}){{ else }}{{ .Name }}{{ end }}
        				{{- end }}
        			{{- end }}
        		{{- end }}
@@ -824,18 +1128,23 @@ func {{ .Name }}({{ range .FormalParams }}{{ . }} string, {{ end }}) ({{ .Result
 			{{- if .ReturnIsStruct }}
 	payload := &{{ .ReturnTypeName }}{
 				{{- range $.Args }}
-					{{- if .FieldName }}
-		{{ .FieldName }}: {{ .Name }},
+					{{- if and .FieldName (not .Nested) }}
+		{{ .FieldName }}: {{ if .EnumTypeRef }}{{ .EnumTypeRef }}({{ .Name }}){{ else }}{{ .Name }}{{ end }},
 					{{- end }}
 				{{- end }}
         }
+				{{- range $.Args }}
+					{{- if .Nested }}
+						{{- template "nested_field_init" (nestedFieldInitData "payload" .) }}
+					{{- end }}
+				{{- end }}
 	return payload, nil
 			{{-  end }}
 
 		{{- end }}
 	{{- end }}
 }
-`
+` + nestedFieldInitT
 
 // input: commandData
 const commandUsageT = `{{ printf "%sUsage displays the usage of the %s command and its subcommands." .Name .Name | comment }}
@@ -846,7 +1155,7 @@ Usage:
 
 COMMAND:
     {{- range .Subcommands }}
-    {{ .Name }}: {{ printDescription .Description }}
+    {{ .Name }}: {{ printDescription .Description }}{{ if .Deprecated }} (deprecated){{ end }}
     {{- end }}
 
 Additional help:
@@ -856,16 +1165,409 @@ Additional help:
 
 {{- range .Subcommands }}
 func {{ .FullName }}Usage() {
-	fmt.Fprintf(os.Stderr, ` + "`" + `%s [flags] {{ $.Name }} {{ .Name }}{{range .Flags }} -{{ .Name }} {{ .Type }}{{ end }}
+	fmt.Fprintf(os.Stderr, ` + "`" + `%s [flags] {{ $.Name }} {{ .Name }}{{range .Flags }} -{{ .Name }} {{ .Type }}{{ end }}{{ if .Timeout }} -timeout DURATION{{ end }}
 
 {{ printDescription .Description}}
+	{{- if .Deprecated }}
+
+Deprecated: {{ printDescription .Deprecated }}
+	{{- end }}
 	{{- range .Flags }}
     -{{ .Name }} {{ .Type }}: {{ .Description }}
 	{{- end }}
+	{{- if .Timeout }}
+    -timeout DURATION: request timeout, defaults to {{ .Timeout }}
+	{{- end }}
 
 Example:
     ` + "`+os.Args[0]+" + "`" + ` {{ .Example }}
+	{{- if .PayloadExamples }}
+
+Payload examples:
+		{{- range .PayloadExamples }}
+    {{ .Summary }}: {{ .Value }}
+		{{- end }}
+	{{- end }}
 ` + "`" + `, os.Args[0])
 }
 {{ end }}
 `
+
+// input: []*tableData
+const printResultT = `// PrintResult writes the result of an endpoint to w using the given format,
+// one of "json", "yaml" or "table". Results that cannot be rendered as tables
+// are written as JSON.
+func PrintResult(w io.Writer, data interface{}, format string) error {
+	switch format {
+	case "json":
+		m, err := json.MarshalIndent(data, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(m))
+		return err
+	case "yaml":
+		m, err := yaml.Marshal(data)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(m)
+		return err
+	case "table":
+		return printTable(w, data)
+	default:
+		return fmt.Errorf("invalid output format %#v, must be one of json, yaml or table", format)
+	}
+}
+
+// printTable writes data to w as a table with one column per primitive
+// attribute of the result type.
+func printTable(w io.Writer, data interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	switch res := data.(type) {
+{{- range . }}
+	case {{ .TypeRef }}:
+		fmt.Fprintln(tw, tableRow({{ range $i, $c := .Columns }}{{ if $i }}, {{ end }}{{ printf "%q" $c.Header }}{{ end }}))
+	{{- if .Collection }}
+		for _, r := range res {
+			fmt.Fprintln(tw, tableRow({{ range $i, $c := .Columns }}{{ if $i }}, {{ end }}r.{{ $c.Field }}{{ end }}))
+		}
+	{{- else }}
+		fmt.Fprintln(tw, tableRow({{ range $i, $c := .Columns }}{{ if $i }}, {{ end }}res.{{ $c.Field }}{{ end }}))
+	{{- end }}
+{{- end }}
+	default:
+		return PrintResult(w, res, "json")
+	}
+	return tw.Flush()
+}
+
+// tableRow returns the tab separated string representation of the given
+// values, nil pointers are rendered as empty cells.
+func tableRow(vals ...interface{}) string {
+	cells := make([]string, len(vals))
+	for i, v := range vals {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				continue
+			}
+			v = rv.Elem().Interface()
+		}
+		cells[i] = fmt.Sprint(v)
+	}
+	return strings.Join(cells, "\t")
+}
+`
+
+// input: nil
+const bindFlagsT = `// config holds the flag values read from the config file indexed by the name
+// of the corresponding environment variables.
+var config = make(map[string]string)
+
+// LoadConfig reads flag values from the file at the given path. Each line of
+// the file has the form KEY=VALUE where KEY is the name of the environment
+// variable corresponding to the flag, e.g. STORAGE_ADD_VINTAGE=2018. Empty
+// lines and lines starting with # are ignored.
+func LoadConfig(path string) error {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	for i, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		idx := strings.Index(line, "=")
+		if idx < 1 {
+			return fmt.Errorf("%s:%d: invalid line, must be of the form KEY=VALUE", path, i+1)
+		}
+		config[strings.TrimSpace(line[:idx])] = strings.TrimSpace(line[idx+1:])
+	}
+	return nil
+}
+
+// bindFlags sets the flags of fs that are not given on the command line using
+// the corresponding environment variables or config file entries. The
+// environment variable corresponding to the flag "flag" of the endpoint
+// "method" of the service "service" is SERVICE_METHOD_FLAG. Command line
+// values take precedence over environment variables which take precedence
+// over config file entries.
+func bindFlags(fs *flag.FlagSet, svcn, epn string) error {
+	set := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	var err error
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || set[f.Name] {
+			return
+		}
+		key := strings.ToUpper(strings.Replace(svcn+"_"+epn+"_"+f.Name, "-", "_", -1))
+		val, ok := os.LookupEnv(key)
+		if !ok {
+			val, ok = config[key]
+		}
+		if !ok {
+			return
+		}
+		if serr := fs.Set(f.Name, val); serr != nil {
+			err = fmt.Errorf("invalid value %#v for %s: %s", val, key, serr)
+		}
+	})
+	return err
+}
+`
+
+// input: []*commandData
+const completionT = `// completions maps the service commands to their endpoint subcommands and the
+// endpoint subcommands to their flags and the values allowed by the flags if
+// enumerated in the design.
+var completions = map[string]map[string]map[string][]string{
+{{- range . }}
+	{{ printf "%q" .Name }}: {
+	{{- range .Subcommands }}
+		{{ printf "%q" .Name }}: {
+		{{- range .Flags }}
+			{{ printf "%q" .Name }}: {{ if .Enum }}{ {{- range $i, $v := .Enum }}{{ if $i }}, {{ end }}{{ printf "%q" $v }}{{ end -}} }{{ else }}nil{{ end }},
+		{{- end }}
+		{{- if .Timeout }}
+			"timeout": nil,
+		{{- end }}
+		{{- if .MultipartRequestEncoder }}
+			"file": nil,
+		{{- end }}
+		},
+	{{- end }}
+	},
+{{- end }}
+}
+
+// Completion returns the script that enables the completion of the commands,
+// subcommands, flags and enumerated flag values of the CLI in the given shell,
+// one of "bash", "zsh" or "fish". prog is the name of the CLI executable. The
+// script relies on the "__complete" command which must print the result of
+// Complete.
+func Completion(shell, prog string) (string, error) {
+	switch shell {
+	case "bash":
+		return fmt.Sprintf(bashCompletion, prog), nil
+	case "zsh":
+		return fmt.Sprintf(zshCompletion, prog), nil
+	case "fish":
+		return fmt.Sprintf(fishCompletion, prog), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %#v, must be one of bash, zsh or fish", shell)
+	}
+}
+
+// Complete returns the completion candidates of the last word in words given
+// the preceding words. words excludes the name of the executable.
+func Complete(words []string) []string {
+	if len(words) == 0 {
+		return nil
+	}
+	var (
+		cur   = words[len(words)-1]
+		args  []string
+		flagn string
+	)
+	for i := 0; i < len(words)-1; i++ {
+		w := words[i]
+		if !strings.HasPrefix(w, "-") {
+			args = append(args, w)
+			continue
+		}
+		name := strings.TrimLeft(w, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		if len(args) == 0 {
+			if f := flag.Lookup(name); f != nil {
+				if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+					continue
+				}
+			}
+		}
+		if i == len(words)-2 {
+			flagn = name
+		}
+		i++
+	}
+	var cands []string
+	switch {
+	case flagn != "":
+		if len(args) == 2 {
+			cands = completions[args[0]][args[1]][flagn]
+		}
+	case len(args) == 0 && strings.HasPrefix(cur, "-"):
+		flag.VisitAll(func(f *flag.Flag) { cands = append(cands, "-"+f.Name) })
+	case len(args) == 0:
+		for svc := range completions {
+			cands = append(cands, svc)
+		}
+	case len(args) == 1:
+		for ep := range completions[args[0]] {
+			cands = append(cands, ep)
+		}
+	case len(args) == 2 && strings.HasPrefix(cur, "-"):
+		for f := range completions[args[0]][args[1]] {
+			cands = append(cands, "-"+f)
+		}
+	}
+	var res []string
+	for _, c := range cands {
+		if strings.HasPrefix(c, cur) {
+			res = append(res, c)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+const bashCompletion = ` + "`" + `_%[1]s_completion() {
+    COMPREPLY=($(%[1]s __complete "${COMP_WORDS[@]:1:$COMP_CWORD}"))
+}
+complete -F _%[1]s_completion %[1]s
+` + "`" + `
+
+const zshCompletion = ` + "`" + `#compdef %[1]s
+_%[1]s() {
+    local -a candidates
+    candidates=(${(f)"$(%[1]s __complete "${(@)words[2,$CURRENT]}")"})
+    compadd -- $candidates
+}
+compdef _%[1]s %[1]s
+` + "`" + `
+
+const fishCompletion = ` + "`" + `complete -c %[1]s -f -a '(%[1]s __complete (commandline -opc)[2..-1] (commandline -ct))'
+` + "`" + `
+`
+
+// input: []string
+const promptT = `// Interactive enables prompting for the values of the required endpoint flags
+// that are not set on the command line, in the environment or in the config
+// file.
+var Interactive bool
+
+// secretFlags lists the flags holding passwords or credentials indexed by
+// service, endpoint and flag names. The values of these flags are read without
+// echo when prompted.
+var secretFlags = map[string]bool{
+{{- range . }}
+	{{ printf "%q" . }}: true,
+{{- end }}
+}
+
+// promptFlags prompts for the values of the required flags of fs that are not
+// set.
+func promptFlags(fs *flag.FlagSet, svcn, epn string) error {
+	var (
+		in  = bufio.NewReader(os.Stdin)
+		err error
+	)
+	fs.VisitAll(func(f *flag.Flag) {
+		if err != nil || f.Value.String() != "REQUIRED" {
+			return
+		}
+		if f.Usage != "" {
+			fmt.Fprintf(os.Stderr, "%s (%s): ", f.Name, f.Usage)
+		} else {
+			fmt.Fprintf(os.Stderr, "%s: ", f.Name)
+		}
+		var val string
+		if secretFlags[svcn+" "+epn+" "+f.Name] {
+			var b []byte
+			b, err = terminal.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Fprintln(os.Stderr)
+			val = string(b)
+		} else {
+			val, err = in.ReadString('\n')
+			if err == io.EOF && val != "" {
+				err = nil
+			}
+			val = strings.TrimSpace(val)
+		}
+		if err != nil {
+			err = fmt.Errorf("failed to read value of flag %s: %s", f.Name, err)
+			return
+		}
+		err = fs.Set(f.Name, val)
+	})
+	return err
+}
+`
+
+// input: []*StreamData
+const streamT = `// Stream exchanges data with the stream returned by a streaming endpoint. It
+// sends the JSON values read from in, one per line, if the endpoint streams
+// its payload and writes the results received from the stream to out using
+// the given format until in is exhausted and the server closes the stream.
+// Stream returns false if data is not a stream.
+func Stream(data interface{}, in io.Reader, out io.Writer, format string) (bool, error) {
+	switch stream := data.(type) {
+{{- range . }}
+	case {{ .Interface }}:
+	{{- if and .SendRef .RecvRef }}
+		errc := make(chan error, 1)
+		go func() {
+			errc <- recvAll(func() (interface{}, error) { return stream.Recv() }, out, format)
+		}()
+	{{- end }}
+	{{- if .SendRef }}
+		err := sendAll(in, func(b []byte) error {
+			var v {{ .SendRef }}
+			if err := json.Unmarshal(b, &v); err != nil {
+				return fmt.Errorf("invalid JSON value %s: %s", string(b), err)
+			}
+			return stream.Send(v)
+		})
+		if cerr := stream.Close(); err == nil {
+			err = cerr
+		}
+		{{- if .RecvRef }}
+		if rerr := <-errc; err == nil {
+			err = rerr
+		}
+		{{- end }}
+		return true, err
+	{{- else }}
+		return true, recvAll(func() (interface{}, error) { return stream.Recv() }, out, format)
+	{{- end }}
+{{- end }}
+	default:
+		return false, nil
+	}
+}
+
+// sendAll calls send with each non empty line read from in.
+func sendAll(in io.Reader, send func([]byte) error) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := send(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// recvAll writes the values returned by recv to out using the given format
+// until recv returns io.EOF.
+func recvAll(recv func() (interface{}, error), out io.Writer, format string) error {
+	for {
+		v, err := recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := PrintResult(out, v, format); err != nil {
+			return err
+		}
+	}
+}
+`
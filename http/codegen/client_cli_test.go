@@ -1,6 +1,7 @@
 package codegen
 
 import (
+	"strings"
 	"testing"
 
 	"goa.design/goa/codegen"
@@ -37,6 +38,9 @@ func TestClientCLIFiles(t *testing.T) {
 		{"map-query", testdata.PayloadMapQueryPrimitiveArrayDSL, testdata.MapQueryParseCode, 0, 3},
 		{"map-query-object", testdata.PayloadMapQueryObjectDSL, testdata.MapQueryObjectBuildCode, 1, 1},
 		{"empty-body-build", testdata.PayloadBodyPrimitiveFieldEmptyDSL, testdata.EmptyBodyBuildCode, 1, 1},
+		{"result-table-print", testdata.ResultTableDSL, testdata.ResultTablePrintCode, 0, 5},
+		{"completion", testdata.CompletionDSL, testdata.CompletionCode, 0, 8},
+		{"streaming-result-stream", testdata.StreamingResultDSL, testdata.StreamingResultStreamCode, 0, 9},
 	}
 
 	for _, c := range cases {
@@ -51,3 +55,23 @@ func TestClientCLIFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestClientCLINamedExamples(t *testing.T) {
+	RunHTTPDSL(t, testdata.NamedExamplesDSL)
+	fs := ClientCLIFiles("", httpdesign.Root)
+	var code string
+	for _, s := range fs[0].SectionTemplates {
+		if s.Name == "cli-command-usage" {
+			code = codegen.SectionCode(t, s)
+		}
+	}
+	expected := []string{
+		"Payload examples:\n    valid token: '{\n      \"value\": \"abc\"\n   }'",
+		"    expired token: '{\n      \"value\": \"xyz\"\n   }'",
+	}
+	for _, e := range expected {
+		if !strings.Contains(code, e) {
+			t.Errorf("missing %q in:\n%s", e, code)
+		}
+	}
+}
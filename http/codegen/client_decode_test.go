@@ -19,6 +19,12 @@ func TestClientDecode(t *testing.T) {
 		{"empty-body-result-multiple-views", testdata.EmptyBodyResultMultipleViewsDSL, testdata.EmptyBodyResultMultipleViewsDecodeCode},
 		{"explicit-body-result-multiple-views", testdata.ExplicitBodyUserResultMultipleViewsDSL, testdata.ExplicitBodyUserResultMultipleViewsDecodeCode},
 		{"tag-result-multiple-views", testdata.ResultMultipleViewsTagDSL, testdata.ResultMultipleViewsTagDecodeCode},
+		{"cookie", testdata.ResultCookieDSL, testdata.ResultCookieDecodeCode},
+		{"header-cookie-default", testdata.ResultHeaderCookieDefaultDSL, testdata.ResultHeaderCookieDefaultDecodeCode},
+		{"header-default-result-multiple-views", testdata.ResultHeaderDefaultMultipleViewsDSL, testdata.ResultHeaderDefaultMultipleViewsDecodeCode},
+		{"skip-response-body-encode-decode", testdata.ResultSkipResponseBodyEncodeDecodeDSL, testdata.ResultSkipResponseBodyEncodeDecodeDecodeCode},
+		{"problem-error-response", testdata.ProblemErrorResponseDSL, testdata.ProblemErrorResponseDecodeCode},
+		{"header-error-response", testdata.HeaderErrorResponseDSL, testdata.HeaderErrorResponseDecodeCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -38,3 +44,20 @@ func TestClientDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestClientTrailersDecode(t *testing.T) {
+	RunHTTPDSL(t, testdata.ResultTrailersDSL)
+	fs := ClientFiles("", httpdesign.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	var code string
+	for _, s := range fs[1].SectionTemplates {
+		if s.Name == "trailers-decoder" {
+			code = codegen.SectionCode(t, s)
+		}
+	}
+	if code != testdata.ResultTrailersDecodeCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ResultTrailersDecodeCode))
+	}
+}
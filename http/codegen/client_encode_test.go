@@ -63,6 +63,9 @@ func TestClientEncode(t *testing.T) {
 		{"query-array-bytes-validate", testdata.PayloadQueryArrayBytesValidateDSL, testdata.PayloadQueryArrayBytesValidateEncodeCode},
 		{"query-array-any", testdata.PayloadQueryArrayAnyDSL, testdata.PayloadQueryArrayAnyEncodeCode},
 		{"query-array-any-validate", testdata.PayloadQueryArrayAnyValidateDSL, testdata.PayloadQueryArrayAnyValidateEncodeCode},
+		{"query-array-string-pipe-delimited", testdata.PayloadQueryArrayStringPipeDelimitedDSL, testdata.PayloadQueryArrayStringPipeDelimitedEncodeCode},
+		{"query-array-int-space-delimited", testdata.PayloadQueryArrayIntSpaceDelimitedDSL, testdata.PayloadQueryArrayIntSpaceDelimitedEncodeCode},
+		{"query-map-deep-object", testdata.PayloadQueryMapDeepObjectDSL, testdata.PayloadQueryMapDeepObjectEncodeCode},
 		{"query-map-string-string", testdata.PayloadQueryMapStringStringDSL, testdata.PayloadQueryMapStringStringEncodeCode},
 		{"query-map-string-string-validate", testdata.PayloadQueryMapStringStringValidateDSL, testdata.PayloadQueryMapStringStringValidateEncodeCode},
 		{"query-map-string-bool", testdata.PayloadQueryMapStringBoolDSL, testdata.PayloadQueryMapStringBoolEncodeCode},
@@ -95,6 +98,8 @@ func TestClientEncode(t *testing.T) {
 
 		{"header-string", testdata.PayloadHeaderStringDSL, testdata.PayloadHeaderStringEncodeCode},
 		{"header-string-validate", testdata.PayloadHeaderStringValidateDSL, testdata.PayloadHeaderStringValidateEncodeCode},
+		{"header-nested", testdata.PayloadHeaderNestedDSL, testdata.PayloadHeaderNestedEncodeCode},
+		{"header-nested-required", testdata.PayloadHeaderNestedRequiredDSL, testdata.PayloadHeaderNestedRequiredEncodeCode},
 		{"header-array-string", testdata.PayloadHeaderArrayStringDSL, testdata.PayloadHeaderArrayStringEncodeCode},
 		{"header-array-string-validate", testdata.PayloadHeaderArrayStringValidateDSL, testdata.PayloadHeaderArrayStringValidateEncodeCode},
 
@@ -106,6 +111,8 @@ func TestClientEncode(t *testing.T) {
 		{"header-string-default", testdata.PayloadHeaderStringDefaultDSL, testdata.PayloadHeaderStringDefaultEncodeCode},
 		{"header-primitive-string-default", testdata.PayloadHeaderPrimitiveStringDefaultDSL, testdata.PayloadHeaderPrimitiveStringDefaultEncodeCode},
 
+		{"cookie-string", testdata.PayloadCookieStringDSL, testdata.PayloadCookieStringEncodeCode},
+
 		{"body-string", testdata.PayloadBodyStringDSL, testdata.PayloadBodyStringEncodeCode},
 		{"body-string-validate", testdata.PayloadBodyStringValidateDSL, testdata.PayloadBodyStringValidateEncodeCode},
 		{"body-user", testdata.PayloadBodyUserDSL, testdata.PayloadBodyUserEncodeCode},
@@ -150,6 +157,7 @@ func TestClientEncode(t *testing.T) {
 		{"multipart-body-user-type", testdata.PayloadMultipartUserTypeDSL, testdata.PayloadMultipartBodyUserTypeEncodeCode},
 		{"multipart-body-array-type", testdata.PayloadMultipartArrayTypeDSL, testdata.PayloadMultipartBodyArrayTypeEncodeCode},
 		{"multipart-body-map-type", testdata.PayloadMultipartMapTypeDSL, testdata.PayloadMultipartBodyMapTypeEncodeCode},
+		{"query-header-enum-type", testdata.PayloadQueryHeaderEnumTypeDSL, testdata.PayloadQueryHeaderEnumTypeEncodeCode},
 	}
 	golden := makeGolden(t, "testdata/payload_encode_functions.go")
 	if golden != nil {
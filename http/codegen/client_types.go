@@ -47,20 +47,23 @@ func clientType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 		path  string
 		rdata = HTTPServices.Get(svc.Name())
 	)
-	path = filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "client", "types.go")
+	path = filepath.Join(codegen.ServiceGendir(svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "client", "types.go")
 	sd := HTTPServices.Get(svc.Name())
 	header := codegen.Header(svc.Name()+" HTTP client types", "client",
 		[]*codegen.ImportSpec{
+			{Path: "sort"},
 			{Path: "unicode/utf8"},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()), Name: sd.Service.PkgName},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: sd.Service.ViewsPkg},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()), Name: sd.Service.PkgName},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: sd.Service.ViewsPkg},
 			{Path: "goa.design/goa", Name: "goa"},
+			{Path: "goa.design/goa/http", Name: "goahttp"},
 		},
 	)
 
 	var (
 		initData       []*InitData
 		validatedTypes []*TypeData
+		jsonTypes      []*TypeData
 
 		sections = []*codegen.SectionTemplate{header}
 	)
@@ -82,6 +85,9 @@ func clientType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 			if data.ValidateDef != "" {
 				validatedTypes = append(validatedTypes, data)
 			}
+			if data.EncodeJSONDef != "" {
+				jsonTypes = append(jsonTypes, data)
+			}
 		}
 	}
 
@@ -100,6 +106,9 @@ func clientType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 				if data.ValidateDef != "" {
 					validatedTypes = append(validatedTypes, data)
 				}
+				if data.EncodeJSONDef != "" {
+					jsonTypes = append(jsonTypes, data)
+				}
 			}
 		}
 	}
@@ -120,6 +129,9 @@ func clientType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 					if data.ValidateDef != "" {
 						validatedTypes = append(validatedTypes, data)
 					}
+					if data.EncodeJSONDef != "" {
+						jsonTypes = append(jsonTypes, data)
+					}
 				}
 			}
 		}
@@ -137,6 +149,9 @@ func clientType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 		if data.ValidateDef != "" {
 			validatedTypes = append(validatedTypes, data)
 		}
+		if data.EncodeJSONDef != "" {
+			jsonTypes = append(jsonTypes, data)
+		}
 	}
 
 	// body constructors
@@ -183,6 +198,15 @@ func clientType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 			Data:   data,
 		})
 	}
+	// JSON encoding and decoding methods
+	for _, data := range jsonTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "client-fastjson",
+			Source: fastJSONT,
+			Data:   data,
+		})
+	}
+
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
@@ -207,7 +231,7 @@ func {{ .Name }}({{- range .ClientArgs }}{{ .Name }} {{ .TypeRef }}, {{ end }})
 		{{- if .ReturnIsStruct }}
 			{{- range .ClientArgs }}
 				{{- if .FieldName }}
-			{{ if $.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ if .Pointer }}&{{ end }}{{ .Name }}
+			{{ if $.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ if .EnumTypeRef }}{{ .EnumTypeRef }}({{ end }}{{ if .Pointer }}&{{ end }}{{ .Name }}{{ if .EnumTypeRef }}){{ end }}
 				{{- end }}
 			{{- end }}
 		{{- end }}
@@ -217,7 +241,7 @@ func {{ .Name }}({{- range .ClientArgs }}{{ .Name }} {{ .TypeRef }}, {{ end }})
 			return &{{ .ReturnTypeName }}{
 			{{- range .ClientArgs }}
 				{{- if .FieldName }}
-				{{ .FieldName }}: {{ if .Pointer }}&{{ end }}{{ .Name }},
+				{{ .FieldName }}: {{ if .EnumTypeRef }}{{ .EnumTypeRef }}({{ end }}{{ if .Pointer }}&{{ end }}{{ .Name }}{{ if .EnumTypeRef }}){{ end }},
 				{{- end }}
 			{{- end }}
 			}
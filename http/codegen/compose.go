@@ -0,0 +1,258 @@
+package codegen
+
+import (
+	"encoding/json"
+	"path"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/openapi"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// ComposeFiles returns the file of the compose package that mounts the HTTP
+// servers of all the API services on a single muxer. The servers share the
+// request decoder, response encoder, error handler and middlewares and the
+// package may serve the OpenAPI specification aggregating all the services.
+// ComposeFiles returns nil if the design defines less than two HTTP services.
+func ComposeFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	if len(root.HTTPServices) < 2 {
+		return nil
+	}
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "net/http"},
+		{Path: "github.com/gorilla/websocket"},
+		{Path: "goa.design/goa/http", Name: "goahttp"},
+	}
+	svcs := make([]*ServiceData, len(root.HTTPServices))
+	for i, svc := range root.HTTPServices {
+		data := HTTPServices.Get(svc.Name())
+		svcs[i] = data
+		specs = append(specs, &codegen.ImportSpec{
+			Path: path.Join(codegen.ServiceGenpkg(genpkg, svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "server"),
+			Name: data.Service.PkgName + "svr",
+		})
+		specs = append(specs, &codegen.ImportSpec{
+			Path: path.Join(codegen.ServiceGenpkg(genpkg, svc.ServiceExpr), codegen.SnakeCase(svc.Name())),
+			Name: data.Service.PkgName,
+		})
+	}
+	var spec string
+	if s, err := openapi.NewV2(root); err == nil && s != nil {
+		if b, err := json.Marshal(s); err == nil {
+			spec = string(b)
+		}
+	}
+	data := map[string]interface{}{
+		"APIName":  root.Design.API.Name,
+		"Services": svcs,
+		"OpenAPI":  spec,
+	}
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(root.Design.API.Name+" HTTP server hosting all the services", "compose", specs),
+		{
+			Name:   "compose-server",
+			Source: composeServerT,
+			Data:   data,
+			FuncMap: map[string]interface{}{
+				"needStream":              needStream,
+				"needBinaryStream":        needBinaryStream,
+				"streamingEndpointExists": streamingEndpointExists,
+				"binaryStreamExists":      binaryStreamExists,
+			},
+		},
+	}
+	if spec != "" {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "compose-openapi",
+			Source: composeOpenAPIT,
+			Data:   spec,
+		})
+	}
+	return []*codegen.File{{
+		Path:             filepath.Join(codegen.Gendir, "http", "compose", "server.go"),
+		SectionTemplates: sections,
+	}}
+}
+
+// needBinaryStream returns true if at least one method in the list of services
+// streams binary messages.
+func needBinaryStream(data []*ServiceData) bool {
+	for _, svc := range data {
+		if binaryStreamExists(svc) {
+			return true
+		}
+	}
+	return false
+}
+
+// input: map[string]interface{}{"APIName": string, "Services": []*ServiceData, "OpenAPI": string}
+const composeServerT = `{{ printf "Endpoints lists the endpoints of the %s services." .APIName | comment }}
+type Endpoints struct {
+{{- range .Services }}
+	{{- if .Endpoints }}
+	{{ printf "%s contains the %s service endpoints." .Service.StructName .Service.Name | comment }}
+	{{ .Service.StructName }} *{{ .Service.PkgName }}.Endpoints
+	{{- end }}
+{{- end }}
+}
+
+// Options contains the settings shared by the HTTP servers of the services.
+// The zero value uses the default goa request decoder and response encoder.
+type Options struct {
+	// Decoder creates the request decoders, defaults to
+	// goahttp.RequestDecoder.
+	Decoder func(*http.Request) goahttp.Decoder
+	// Encoder creates the response encoders, defaults to
+	// goahttp.ResponseEncoder.
+	Encoder func(context.Context, http.ResponseWriter) goahttp.Encoder
+	// ErrorHandler handles the errors that occur while encoding the
+	// responses of all the services, defaults to ErrorHandler.
+	ErrorHandler func(context.Context, http.ResponseWriter, error)
+	// Middlewares lists the middlewares that wrap the muxer and thus apply
+	// to all the requests. The first middleware is the outermost.
+	Middlewares []func(http.Handler) http.Handler
+{{- if .OpenAPI }}
+	// OpenAPIPath is the path of the OpenAPI specification aggregating
+	// all the services, for example "/openapi.json". The specification is
+	// not served if empty.
+	OpenAPIPath string
+{{- end }}
+{{- if needStream .Services }}
+	// Upgrader upgrades the connections of the streaming endpoints to
+	// websocket connections, defaults to a websocket.Upgrader with the
+	// default settings.
+	Upgrader goahttp.Upgrader
+	// ConnConfigFn configures the websocket connections if not nil.
+	ConnConfigFn goahttp.ConnConfigureFunc
+{{- end }}
+{{- if needBinaryStream .Services }}
+	// Codec encodes and decodes the binary stream messages, defaults to
+	// goahttp.RawStreamCodec.
+	Codec goahttp.StreamCodec
+{{- end }}
+{{- range $svc := .Services }}
+	{{- range .Endpoints }}
+		{{- if .MultipartRequestDecoder }}
+	{{ printf "%s%sDecoder decodes the multipart requests of the %s service %s endpoint.%s" $svc.Service.StructName .Method.VarName $svc.Service.Name .Method.Name (or (and .MultipartRequestDecoder.DefaultFuncName " The generated decoder is used if nil.") "") | comment }}
+	{{ $svc.Service.StructName }}{{ .Method.VarName }}Decoder {{ $svc.Service.PkgName }}svr.{{ .MultipartRequestDecoder.FuncName }}
+		{{- end }}
+	{{- end }}
+{{- end }}
+}
+
+{{ printf "Server is the HTTP server hosting all the %s services." .APIName | comment }}
+type Server struct {
+{{- range .Services }}
+	{{ printf "%s is the %s service HTTP server." .Service.StructName .Service.Name | comment }}
+	{{ .Service.StructName }} *{{ .Service.PkgName }}svr.Server
+{{- end }}
+
+	handler http.Handler
+}
+
+// MountPoint describes a HTTP handler mounted on the muxer.
+type MountPoint struct {
+	// Service is the name of the service.
+	Service string
+	// Method is the name of the service method served by the handler.
+	Method string
+	// Verb is the HTTP method used to match requests to the handler.
+	Verb string
+	// Pattern is the HTTP request path pattern used to match requests to
+	// the handler.
+	Pattern string
+}
+
+// New instantiates the HTTP servers of all the services, mounts them on mux and
+// wraps mux with the middlewares listed in opts. The servers share the request
+// decoder, response encoder and error handler given in opts.
+func New(e *Endpoints, mux goahttp.Muxer, opts *Options) *Server {
+	if opts == nil {
+		opts = &Options{}
+	}
+	var (
+		dec = opts.Decoder
+		enc = opts.Encoder
+		eh  = opts.ErrorHandler
+	)
+	if dec == nil {
+		dec = goahttp.RequestDecoder
+	}
+	if enc == nil {
+		enc = goahttp.ResponseEncoder
+	}
+	if eh == nil {
+		eh = ErrorHandler
+	}
+{{- if needStream .Services }}
+	up := opts.Upgrader
+	if up == nil {
+		up = &websocket.Upgrader{}
+	}
+{{- end }}
+{{- if needBinaryStream .Services }}
+	codec := opts.Codec
+	if codec == nil {
+		codec = goahttp.RawStreamCodec
+	}
+{{- end }}
+	s := &Server{
+{{- range $svc := .Services }}
+	{{- if .Endpoints }}
+		{{ .Service.StructName }}: {{ .Service.PkgName }}svr.New(e.{{ .Service.StructName }}, mux, dec, enc, eh{{ if streamingEndpointExists . }}, up, opts.ConnConfigFn{{ end }}{{ if binaryStreamExists . }}, codec{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, opts.{{ $svc.Service.StructName }}{{ .Method.VarName }}Decoder{{ end }}{{ end }}),
+	{{- else }}
+		{{ .Service.StructName }}: {{ .Service.PkgName }}svr.New(nil, mux, dec, enc, eh),
+	{{- end }}
+{{- end }}
+	}
+{{- range .Services }}
+	{{ .Service.PkgName }}svr.Mount(mux{{ if .Endpoints }}, s.{{ .Service.StructName }}{{ end }})
+{{- end }}
+{{- if .OpenAPI }}
+	if opts.OpenAPIPath != "" {
+		mux.Handle("GET", opts.OpenAPIPath, serveOpenAPI)
+	}
+{{- end }}
+	var h http.Handler = mux
+	for i := len(opts.Middlewares) - 1; i >= 0; i-- {
+		h = opts.Middlewares[i](h)
+	}
+	s.handler = h
+	return s
+}
+
+// ServeHTTP serves the requests made to the endpoints of all the services.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// Mounts returns the handlers mounted by the servers of all the services.
+func (s *Server) Mounts() []*MountPoint {
+	var mounts []*MountPoint
+{{- range .Services }}
+	for _, m := range s.{{ .Service.StructName }}.Mounts {
+		mounts = append(mounts, &MountPoint{ {{- printf "%q" .Service.Name }}, m.Method, m.Verb, m.Pattern})
+	}
+{{- end }}
+	return mounts
+}
+
+// ErrorHandler is the default error handler shared by the servers. It responds
+// with a 500 status code.
+func ErrorHandler(ctx context.Context, w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+`
+
+// input: string
+const composeOpenAPIT = `// serveOpenAPI writes the OpenAPI specification aggregating all the services.
+func serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(openAPISpec))
+}
+
+// openAPISpec is the OpenAPI specification aggregating all the services.
+const openAPISpec = {{ printf "%q" . }}
+`
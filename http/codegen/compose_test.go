@@ -0,0 +1,61 @@
+package codegen
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestComposeFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.ServerNoPayloadNoResultDSL)
+	if fs := ComposeFiles("", httpdesign.Root); len(fs) != 0 {
+		t.Fatalf("got %d files for a single service, expected none", len(fs))
+	}
+
+	RunHTTPDSL(t, testdata.ComposeDSL)
+	fs := ComposeFiles("", httpdesign.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected one", len(fs))
+	}
+	if expected := filepath.Join("gen", "http", "compose", "server.go"); fs[0].Path != expected {
+		t.Errorf("got path %q, expected %q", fs[0].Path, expected)
+	}
+	sections := fs[0].Section("compose-server")
+	if len(sections) != 1 {
+		t.Fatalf("got %d compose server sections, expected one", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.ComposeServerCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ComposeServerCode))
+	}
+
+	sections = fs[0].Section("compose-openapi")
+	if len(sections) != 1 {
+		t.Fatalf("got %d compose OpenAPI sections, expected one", len(sections))
+	}
+	code = codegen.SectionCode(t, sections[0])
+	idx := strings.Index(code, "const openAPISpec = ")
+	if idx < 0 {
+		t.Fatalf("OpenAPI specification constant not found in:\n%s", code)
+	}
+	js, err := strconv.Unquote(strings.TrimSpace(code[idx+len("const openAPISpec = "):]))
+	if err != nil {
+		t.Fatalf("invalid OpenAPI specification constant: %s", err)
+	}
+	var spec struct {
+		Paths map[string]interface{}
+	}
+	if err := json.Unmarshal([]byte(js), &spec); err != nil {
+		t.Fatalf("failed to unmarshal spec: %s", err)
+	}
+	if ps := sortedKeys(spec.Paths); !reflect.DeepEqual(ps, []string{"/compose/{a}", "/docs"}) {
+		t.Errorf("got paths %v, expected the paths of both services", ps)
+	}
+}
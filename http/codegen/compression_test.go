@@ -0,0 +1,33 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestServerCompression(t *testing.T) {
+	cases := []*testCase{
+		{"compression", testdata.CompressionDSL, []*sectionExpectation{
+			{"server-mount", &testdata.CompressionServerMountCode},
+		}},
+		{"compression-cors", testdata.CompressionCORSDSL, []*sectionExpectation{
+			{"server-mount", &testdata.CompressionCORSServerMountCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestClientCompression(t *testing.T) {
+	cases := []*testCase{
+		{"compression", testdata.CompressionDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.CompressionClientEndpointCode},
+			{"client-breaker", &testdata.CompressionClientBreakerCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
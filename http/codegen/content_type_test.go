@@ -0,0 +1,55 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestClientRequestContentType(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected []string
+	}{
+		{"msgpack", testdata.MsgpackContentTypeDSL, []string{testdata.MsgpackContentTypeRequestBuilderCode, testdata.MsgpackContentTypeNoBodyRequestBuilderCode}},
+		{"cbor", testdata.CborContentTypeDSL, []string{testdata.CborContentTypeRequestBuilderCode, testdata.CborContentTypeOverrideRequestBuilderCode}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ClientFiles("", httpdesign.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			sections := fs[1].Section("request-builder")
+			if len(sections) != len(c.Expected) {
+				t.Fatalf("got %d request builder sections, expected %d", len(sections), len(c.Expected))
+			}
+			for i, s := range sections {
+				code := codegen.SectionCode(t, s)
+				if code != c.Expected[i] {
+					t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Expected[i]))
+				}
+			}
+		})
+	}
+}
+
+func TestServerRequestContentTypes(t *testing.T) {
+	RunHTTPDSL(t, testdata.MultipleContentTypesDSL)
+	fs := ServerFiles("", httpdesign.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	sections := fs[1].Section("request-decoder")
+	if len(sections) != 1 {
+		t.Fatalf("got %d request decoder sections, expected one", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	if code != testdata.MultipleContentTypesRequestDecoderCode {
+		t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.MultipleContentTypesRequestDecoderCode))
+	}
+}
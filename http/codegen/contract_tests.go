@@ -0,0 +1,155 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa/codegen"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// ContractTestsFile returns a file containing tests that replay the design
+// examples against a running implementation of the API and validate the
+// requests and responses against the generated OpenAPI specification. The
+// tests are skipped unless the GOA_CONTRACT_URL environment variable is set
+// to the URL of the implementation. Streaming, multipart and raw body
+// endpoints are not tested. ContractTestsFile returns nil if there is no
+// endpoint to test.
+func ContractTestsFile(genpkg string, root *httpdesign.RootExpr) *codegen.File {
+	path := filepath.Join(codegen.Gendir, "http", "contract", "contract_test.go")
+	title := fmt.Sprintf("%s HTTP contract tests", root.Design.API.Name)
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "net/http"},
+		{Path: "net/url"},
+		{Path: "os"},
+		{Path: "path/filepath"},
+		{Path: "testing"},
+		{Path: "goa.design/goa/http", Name: "goahttp"},
+		{Path: "goa.design/goa/http/contract"},
+	}
+	var (
+		sections []*codegen.SectionTemplate
+		ptrs     = make(map[string]string)
+		payloads []string
+	)
+	for _, svc := range root.HTTPServices {
+		data := exampleTestsData(HTTPServices.Get(svc.Name()), ptrs)
+		if data == nil {
+			continue
+		}
+		pkg := data.Service.Service.PkgName
+		for _, t := range data.Tests {
+			if strings.Contains(t.PayloadCode, pkg+".") {
+				// Primitive payloads do not reference the service package.
+				specs = append(specs, &codegen.ImportSpec{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()), Name: pkg})
+				break
+			}
+		}
+		specs = append(specs, &codegen.ImportSpec{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/http/" + codegen.SnakeCase(svc.Name()) + "/client", Name: data.ClientPkg})
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "contract-tests-client",
+			Source:  contractTestsClientT,
+			Data:    data,
+			FuncMap: map[string]interface{}{"streamingEndpointExists": streamingEndpointExists, "binaryStreamExists": binaryStreamExists},
+		})
+		for _, t := range data.Tests {
+			payloads = append(payloads, t.PayloadCode)
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "contract-tests-endpoint",
+				Source:  contractTestsEndpointT,
+				Data:    t,
+				FuncMap: map[string]interface{}{"contractTestName": contractTestName},
+			})
+		}
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	code := strings.Join(payloads, "\n")
+	for _, name := range sortedKeys(ptrs) {
+		if !strings.Contains(code, name+"(") {
+			continue // only used by the example results
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "contract-tests-pointer",
+			Source: exampleTestsPointerT,
+			Data:   map[string]string{"Name": name, "TypeRef": ptrs[name]},
+		})
+	}
+	sections = append([]*codegen.SectionTemplate{
+		codegen.Header(title, "contract_test", specs),
+		&codegen.SectionTemplate{Name: "contract-tests-init", Source: contractTestsInitT},
+	}, sections...)
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// contractTestName returns the name of the contract test of the endpoint
+// described by data.
+func contractTestName(data *EndpointTestData) string {
+	return strings.Replace(data.TestName, "Test", "TestContract", 1)
+}
+
+// input: none
+const contractTestsInitT = `// contractURLVar is the name of the environment variable that holds the URL of
+// the running implementation of the API validated by the contract tests.
+const contractURLVar = "GOA_CONTRACT_URL"
+
+// newContractDoer returns the URL of the implementation under test and a doer
+// that validates the requests and responses against the OpenAPI specification
+// generated from the design. newContractDoer skips the test if the
+// GOA_CONTRACT_URL environment variable is not set.
+func newContractDoer(t *testing.T) (*url.URL, *contract.Doer) {
+	t.Helper()
+	u := os.Getenv(contractURLVar)
+	if u == "" {
+		t.Skipf("%s is not set", contractURLVar)
+	}
+	target, err := url.Parse(u)
+	if err != nil {
+		t.Fatalf("invalid %s: %s", contractURLVar, err)
+	}
+	spec, err := contract.LoadFile(filepath.Join("..", "openapi.json"))
+	if err != nil {
+		t.Fatalf("failed to load OpenAPI specification: %s", err)
+	}
+	return target, contract.NewDoer(spec, http.DefaultClient)
+}
+
+// checkContract fails the test if doer recorded contract violations or failed
+// to send requests. err is the error returned by the client endpoint, it is
+// only logged as the error responses described in the design comply with the
+// contract.
+func checkContract(t *testing.T, doer *contract.Doer, err error) {
+	t.Helper()
+	if err != nil {
+		t.Logf("endpoint returned an error: %s", err)
+	}
+	for _, err := range doer.Errors() {
+		t.Error(err)
+	}
+}
+`
+
+// input: ExampleTestsData
+const contractTestsClientT = `{{ printf "new%sContractClient returns a client of the %q service that sends requests to the implementation at u through doer." .Service.Service.StructName .Service.Service.Name | comment }}
+func new{{ .Service.Service.StructName }}ContractClient(u *url.URL, doer goahttp.Doer) *{{ .ClientPkg }}.{{ .Service.ClientStruct }} {
+	return {{ .ClientPkg }}.New{{ .Service.ClientStruct }}(u.Scheme, u.Host, doer, goahttp.RequestEncoder, goahttp.ResponseDecoder, false{{ if streamingEndpointExists .Service }}, nil, nil{{ end }}{{ if binaryStreamExists .Service }}, nil{{ end }})
+}
+`
+
+// input: EndpointTestData
+const contractTestsEndpointT = `{{ printf "%s replays the design example of the %q endpoint of the %q service and validates the exchange against the OpenAPI specification." (contractTestName .) .Endpoint.Method.Name .Endpoint.ServiceName | comment }}
+func {{ contractTestName . }}(t *testing.T) {
+	u, doer := newContractDoer(t)
+	c := new{{ .ServiceStruct }}ContractClient(u, doer)
+	{{- if .Endpoint.Payload.Ref }}
+	payload := {{ .PayloadCode }}
+	_, err := c.{{ .Endpoint.Method.VarName }}()(context.Background(), payload)
+	{{- else }}
+	_, err := c.{{ .Endpoint.Method.VarName }}()(context.Background(), nil)
+	{{- end }}
+	checkContract(t, doer, err)
+}
+`
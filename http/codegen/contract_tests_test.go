@@ -0,0 +1,78 @@
+package codegen
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestContractTestsFile(t *testing.T) {
+	RunHTTPDSL(t, testdata.ExampleTestsDSL)
+	f := ContractTestsFile("goa.design/goa/gen", httpdesign.Root)
+	if f == nil {
+		t.Fatal("got nil file")
+	}
+	if path := filepath.Join("gen", "http", "contract", "contract_test.go"); f.Path != path {
+		t.Errorf("got path %q, expected %q", f.Path, path)
+	}
+	cases := []struct {
+		Name string
+		Code string
+	}{
+		{"contract-tests-init", testdata.ContractTestsInitCode},
+		{"contract-tests-client", testdata.ContractTestsClientCode},
+		{"contract-tests-endpoint", testdata.ContractTestsShowCode},
+		{"contract-tests-endpoint", testdata.ContractTestsAddCode},
+		{"contract-tests-endpoint", testdata.ContractTestsRemoveCode},
+		{"contract-tests-pointer", testdata.ExampleTestsBoolPtrCode},
+		{"contract-tests-pointer", testdata.ExampleTestsIntPtrCode},
+		{"contract-tests-pointer", testdata.ExampleTestsStringPtrCode},
+	}
+	sections := f.SectionTemplates[1:]
+	if len(sections) != len(cases) {
+		t.Fatalf("got %d sections, expected %d", len(sections), len(cases))
+	}
+	for i, c := range cases {
+		if sections[i].Name != c.Name {
+			t.Errorf("section %d: got %q, expected %q", i, sections[i].Name, c.Name)
+			continue
+		}
+		code := codegen.SectionCode(t, sections[i])
+		if code != c.Code {
+			t.Errorf("invalid code for section %d, got:\n%s\ngot vs. expected:\n%s", i, code, codegen.Diff(t, code, c.Code))
+		}
+	}
+}
+
+func TestContractTestsFileImports(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Imports []string
+	}{
+		{"service-types", testdata.ExampleTestsDSL, []string{"goa.design/goa/gen/storage", "goa.design/goa/gen/http/storage/client"}},
+		{"primitives", testdata.PrimitiveExampleTestsDSL, []string{"goa.design/goa/gen/http/echo/client"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			f := ContractTestsFile("goa.design/goa/gen", httpdesign.Root)
+			if f == nil {
+				t.Fatal("got nil file")
+			}
+			var imports []string
+			for _, spec := range f.SectionTemplates[0].Data.(map[string]interface{})["Imports"].([]*codegen.ImportSpec) {
+				if strings.HasPrefix(spec.Path, "goa.design/goa/gen/") {
+					imports = append(imports, spec.Path)
+				}
+			}
+			if strings.Join(imports, " ") != strings.Join(c.Imports, " ") {
+				t.Errorf("got service imports %v, expected %v", imports, c.Imports)
+			}
+		})
+	}
+}
@@ -23,12 +23,12 @@ func ExampleCLI(genpkg string, root *httpdesign.RootExpr) *codegen.File {
 	apiPkg := strings.ToLower(codegen.Goify(root.Design.API.Name, false))
 	specs := []*codegen.ImportSpec{
 		{Path: "context"},
-		{Path: "encoding/json"},
 		{Path: "flag"},
 		{Path: "fmt"},
 		{Path: "net/http"},
 		{Path: "net/url"},
 		{Path: "os"},
+		{Path: "path/filepath"},
 		{Path: "strings"},
 		{Path: "time"},
 		{Path: "goa.design/goa/http", Name: "goahttp"},
@@ -62,10 +62,44 @@ const mainCLIT = `func main() {
 		verbose = flag.Bool("verbose", false, "Print request and response details")
 		v       = flag.Bool("v", false, "Print request and response details")
 		timeout = flag.Int("timeout", 30, "Maximum number of ` + "`" + `seconds` + "`" + ` to wait for response")
+		output  = flag.String("output", "json", "Output ` + "`" + `format` + "`" + ` of the response: json, yaml or table")
+		config  = flag.String("config", "", "Path to ` + "`" + `file` + "`" + ` defining flag values")
+		prompt  = flag.Bool("prompt", false, "Prompt for the values of missing required flags")
 	)
 	flag.Usage = usage
 	flag.Parse()
 
+	switch flag.Arg(0) {
+	case "completion":
+		script, err := cli.Completion(flag.Arg(1), filepath.Base(os.Args[0]))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		fmt.Print(script)
+		os.Exit(0)
+	case "__complete":
+		for _, c := range cli.Complete(flag.Args()[1:]) {
+			fmt.Println(c)
+		}
+		os.Exit(0)
+	}
+
+	switch *output {
+	case "json", "yaml", "table":
+	default:
+		fmt.Fprintf(os.Stderr, "invalid output format %#v, must be one of json, yaml or table\n", *output)
+		os.Exit(1)
+	}
+
+	if *config != "" {
+		if err := cli.LoadConfig(*config); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to load config file: %s\n", err)
+			os.Exit(1)
+		}
+	}
+	cli.Interactive = *prompt
+
 	var (
 		scheme string
 		host   string
@@ -130,9 +164,19 @@ const mainCLIT = `func main() {
 		os.Exit(1)
 	}
 
+	if ok, err := cli.Stream(data, os.Stdin, os.Stdout, *output); ok {
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		return
+	}
+
 	if data != nil && !debug {
-		m, _ := json.MarshalIndent(data, "", "    ")
-		fmt.Println(string(m))
+		if err := cli.PrintResult(os.Stdout, data, *output); err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
 	}
 }
 
@@ -140,20 +184,28 @@ func usage() {
 	fmt.Fprintf(os.Stderr, ` + "`" + `%s is a command line client for the {{ .APIName }} API.
 
 Usage:
-    %s [-url URL][-timeout SECONDS][-verbose|-v] SERVICE ENDPOINT [flags]
+    %s [-url URL][-timeout SECONDS][-output FORMAT][-config FILE][-prompt][-verbose|-v] SERVICE ENDPOINT [flags]
+    %s completion bash|zsh|fish
 
     -url URL:    specify service URL (http://localhost:8080)
     -timeout:    maximum number of seconds to wait for response (30)
+    -output:     output format of the response: json, yaml or table (json)
+    -config:     path to file defining flag values as KEY=VALUE lines
+    -prompt:     prompt for the values of missing required flags (false)
     -verbose|-v: print request and response details (false)
 
 Commands:
 %s
+Endpoint flags not given on the command line are read from the environment
+variables SERVICE_ENDPOINT_FLAG (e.g. STORAGE_ADD_VINTAGE) then from the config
+file.
+
 Additional help:
     %s SERVICE [ENDPOINT] --help
 
 Example:
 %s
-` + "`" + `, os.Args[0], os.Args[0], indent(cli.UsageCommands()), os.Args[0], indent(cli.UsageExamples()))
+` + "`" + `, os.Args[0], os.Args[0], os.Args[0], indent(cli.UsageCommands()), os.Args[0], indent(cli.UsageExamples()))
 }
 
 func indent(s string) string {
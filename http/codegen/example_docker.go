@@ -0,0 +1,98 @@
+package codegen
+
+import (
+	"os"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// ExampleDockerFiles returns the multi-stage Dockerfile and the container
+// entrypoint script that build and run the example server. The files are only
+// generated if the API defines the "docker:generate" metadata with value
+// "true". The image listens on the port of the first server defined in the
+// design.
+func ExampleDockerFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	if root.Design == nil || root.Design.API == nil || !generateDocker(root.Design.API) {
+		return nil
+	}
+	var (
+		api   = root.Design.API
+		cmd   = codegen.SnakeCase(codegen.Goify(api.Name, true)) + "_svc"
+		_, pt = serverHostPort(api)
+		data  = map[string]interface{}{
+			"Cmd":       cmd,
+			"CmdDir":    "cmd/" + cmd,
+			"EnvPrefix": envPrefix(api),
+			"Port":      pt,
+		}
+		fw []*codegen.File
+	)
+	for _, f := range []struct{ name, section, source string }{
+		{"Dockerfile", "dockerfile", dockerfileT},
+		{"entrypoint.sh", "docker-entrypoint", dockerEntrypointT},
+	} {
+		p := filepath.Join("cmd", cmd, f.name)
+		if _, err := os.Stat(p); !os.IsNotExist(err) {
+			continue // file already exists, skip it.
+		}
+		fw = append(fw, &codegen.File{
+			Path: p,
+			SectionTemplates: []*codegen.SectionTemplate{
+				{Name: f.section, Source: f.source, Data: data},
+			},
+		})
+	}
+	return fw
+}
+
+// generateDocker returns true if the API defines the "docker:generate"
+// metadata with value "true".
+func generateDocker(api *design.APIExpr) bool {
+	v, ok := api.Metadata["docker:generate"]
+	return ok && len(v) > 0 && v[0] == "true"
+}
+
+// input: map[string]interface{}{"Cmd": string, "CmdDir": string, "EnvPrefix": string, "Port": string}
+const dockerfileT = `# Multi-stage Dockerfile that builds and runs the {{ .Cmd }} server.
+# Build the image from the root of the Go module:
+#
+#    docker build -f {{ .CmdDir }}/Dockerfile -t {{ .Cmd }} .
+#
+# The server is configured with the environment variables listed in
+# {{ .CmdDir }}/config.go.
+
+ARG GO_VERSION=1.22
+
+# Build stage: compile a static binary.
+FROM golang:${GO_VERSION}-alpine AS build
+WORKDIR /src
+COPY go.mod go.sum* ./
+RUN go mod download
+COPY . .
+RUN CGO_ENABLED=0 go build -trimpath -ldflags="-s -w" -o /out/{{ .Cmd }} ./{{ .CmdDir }}
+
+# Run stage: copy the binary and the entrypoint in a minimal image.
+FROM alpine:3.20
+RUN apk add --no-cache ca-certificates && adduser -D -H -u 10001 app
+COPY --from=build /out/{{ .Cmd }} /usr/local/bin/{{ .Cmd }}
+COPY {{ .CmdDir }}/entrypoint.sh /usr/local/bin/entrypoint.sh
+ENV {{ .EnvPrefix }}_HOST=0.0.0.0 \
+    {{ .EnvPrefix }}_PORT={{ .Port }}
+EXPOSE {{ .Port }}
+USER app
+ENTRYPOINT ["/bin/sh", "/usr/local/bin/entrypoint.sh"]
+`
+
+// input: map[string]interface{}{"Cmd": string, "CmdDir": string, "EnvPrefix": string, "Port": string}
+const dockerEntrypointT = `#!/bin/sh
+# Container entrypoint of the {{ .Cmd }} server. The server reads its
+# configuration from the {{ .EnvPrefix }}_* environment variables, see config.go. The
+# arguments given to the container are passed to the server as command line
+# flags. Add any initialization step required before starting the server here.
+set -e
+
+exec /usr/local/bin/{{ .Cmd }} "$@"
+`
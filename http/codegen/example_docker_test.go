@@ -0,0 +1,39 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestExampleDockerFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.NamedExamplesDSL)
+	if fs := ExampleDockerFiles("", httpdesign.Root); len(fs) != 0 {
+		t.Fatalf("got %d files without docker:generate metadata, expected none", len(fs))
+	}
+
+	RunHTTPDSL(t, testdata.DockerDSL)
+	fs := ExampleDockerFiles("", httpdesign.Root)
+	expected := []struct {
+		Path string
+		Code string
+	}{
+		{filepath.Join("cmd", "calc_svc", "Dockerfile"), testdata.DockerfileCode},
+		{filepath.Join("cmd", "calc_svc", "entrypoint.sh"), testdata.DockerEntrypointCode},
+	}
+	if len(fs) != len(expected) {
+		t.Fatalf("got %d files, expected %d", len(fs), len(expected))
+	}
+	for i, f := range fs {
+		if f.Path != expected[i].Path {
+			t.Errorf("got path %q, expected %q", f.Path, expected[i].Path)
+		}
+		code := renderFile(t, f)
+		if code != expected[i].Code {
+			t.Errorf("invalid content for %s, got:\n%s\ngot vs. expected:\n%s", f.Path, code, codegen.Diff(t, code, expected[i].Code))
+		}
+	}
+}
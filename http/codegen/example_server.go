@@ -1,15 +1,26 @@
 package codegen
 
 import (
+	"net/url"
 	"os"
 	"path"
 	"path/filepath"
 	"strings"
 
 	"goa.design/goa/codegen"
+	"goa.design/goa/design"
 	httpdesign "goa.design/goa/http/design"
 )
 
+// dummyEndpointData contains the data needed to render a dummy endpoint
+// implementation.
+type dummyEndpointData struct {
+	*EndpointData
+	// ResultCode is the code initializing the result with the first named
+	// example of the design if any.
+	ResultCode string
+}
+
 // ExampleServerFiles returns and example main and dummy service
 // implementations.
 func ExampleServerFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
@@ -23,6 +34,9 @@ func ExampleServerFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.Fil
 	if m := exampleMain(genpkg, root); m != nil {
 		fw = append(fw, m)
 	}
+	if c := exampleConfig(root); c != nil {
+		fw = append(fw, c)
+	}
 	return fw
 }
 
@@ -37,9 +51,14 @@ func dummyServiceFile(genpkg string, root *httpdesign.RootExpr, svc *httpdesign.
 	sections := []*codegen.SectionTemplate{
 		codegen.Header("", apiPkg, []*codegen.ImportSpec{
 			{Path: "context"},
+			{Path: "io"},
+			{Path: "io/ioutil"},
 			{Path: "log"},
 			{Path: "mime/multipart"},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()), Name: data.Service.PkgName},
+			{Path: "strings"},
+			{Path: "goa.design/goa", Name: "goa"},
+			{Path: "goa.design/goa/http", Name: "goahttp"},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()), Name: data.Service.PkgName},
 		}),
 		{
 			Name:   "dummy-service",
@@ -47,13 +66,21 @@ func dummyServiceFile(genpkg string, root *httpdesign.RootExpr, svc *httpdesign.
 			Data:   data,
 		},
 	}
+	if len(data.Service.Interceptors) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "dummy-interceptors",
+			Source: dummyInterceptorsT,
+			Data:   data,
+		})
+	}
+	ptrs := make(map[string]string)
 	for _, e := range data.Endpoints {
 		sections = append(sections, &codegen.SectionTemplate{
 			Name:   "dummy-endpoint",
 			Source: dummyEndpointImplT,
-			Data:   e,
+			Data:   &dummyEndpointData{EndpointData: e, ResultCode: dummyResultCode(data, e, ptrs)},
 		})
-		if e.MultipartRequestDecoder != nil {
+		if e.MultipartRequestDecoder != nil && e.MultipartRequestDecoder.DefaultFuncName == "" {
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "dummy-multipart-request-decoder",
 				Source: dummyMultipartRequestDecoderImplT,
@@ -68,6 +95,13 @@ func dummyServiceFile(genpkg string, root *httpdesign.RootExpr, svc *httpdesign.
 			})
 		}
 	}
+	for _, name := range sortedKeys(ptrs) {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "dummy-pointer",
+			Source: exampleTestsPointerT,
+			Data:   map[string]string{"Name": name, "TypeRef": ptrs[name]},
+		})
+	}
 
 	return &codegen.File{
 		Path:             path,
@@ -75,6 +109,26 @@ func dummyServiceFile(genpkg string, root *httpdesign.RootExpr, svc *httpdesign.
 	}
 }
 
+// dummyResultCode returns the code initializing the result of the dummy
+// implementation of e with the first named example of the method result, the
+// empty string if there is none. The names of the pointer helper functions
+// recorded in ptrs are prefixed with the service name as the dummy
+// implementations of all the services share the same package.
+func dummyResultCode(data *ServiceData, e *EndpointData, ptrs map[string]string) string {
+	if e.Result.Ref == "" || e.ServerStream != nil {
+		return ""
+	}
+	m := design.Root.Service(data.Service.Name).Method(e.Method.Name)
+	if m == nil {
+		return ""
+	}
+	exs := m.Result.NamedExamples()
+	if len(exs) == 0 {
+		return ""
+	}
+	return exampleValueCode(m.Result, exs[0].Value, data.Service.Scope, data.Service.PkgName, data.Service.VarName, ptrs)
+}
+
 func exampleMain(genpkg string, root *httpdesign.RootExpr) *codegen.File {
 	mainPath := filepath.Join("cmd", codegen.SnakeCase(codegen.Goify(root.Design.API.Name, true))+"_svc", "main.go")
 	if _, err := os.Stat(mainPath); !os.IsNotExist(err) {
@@ -94,21 +148,24 @@ func exampleMain(genpkg string, root *httpdesign.RootExpr) *codegen.File {
 		{Path: "net/http"},
 		{Path: "os"},
 		{Path: "os/signal"},
+		{Path: "syscall"},
 		{Path: "time"},
 		{Path: "goa.design/goa", Name: "goa"},
 		{Path: "goa.design/goa/http", Name: "goahttp"},
 		{Path: "goa.design/goa/http/middleware"},
+		{Path: "goa.design/goa/http/middleware/prometheus", Name: "goaprometheus"},
 		{Path: "github.com/gorilla/websocket"},
+		{Path: "github.com/prometheus/client_golang/prometheus"},
 		{Path: rootPath, Name: apiPkg},
 	}
 	for _, svc := range root.HTTPServices {
 		pkgName := HTTPServices.Get(svc.Name()).Service.PkgName
 		specs = append(specs, &codegen.ImportSpec{
-			Path: path.Join(genpkg, "http", codegen.SnakeCase(svc.Name()), "server"),
+			Path: path.Join(codegen.ServiceGenpkg(genpkg, svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "server"),
 			Name: pkgName + "svr",
 		})
 		specs = append(specs, &codegen.ImportSpec{
-			Path: path.Join(genpkg, codegen.SnakeCase(svc.Name())),
+			Path: path.Join(codegen.ServiceGenpkg(genpkg, svc.ServiceExpr), codegen.SnakeCase(svc.Name())),
 			Name: pkgName,
 		})
 	}
@@ -121,21 +178,81 @@ func exampleMain(genpkg string, root *httpdesign.RootExpr) *codegen.File {
 		specs = append(specs, &codegen.ImportSpec{Path: "github.com/gorilla/websocket"})
 	}
 	data := map[string]interface{}{
-		"Services": svcdata,
-		"APIPkg":   apiPkg,
+		"Services":    svcdata,
+		"APIPkg":      apiPkg,
+		"HealthCheck": root.HealthCheck,
 	}
 	sections = append(sections, &codegen.SectionTemplate{
 		Name:   "service-main",
 		Source: mainT,
 		Data:   data,
 		FuncMap: map[string]interface{}{
-			"needStream": needStream,
+			"needStream":         needStream,
+			"binaryStreamExists": binaryStreamExists,
 		},
 	})
 
 	return &codegen.File{Path: mainPath, SectionTemplates: sections}
 }
 
+// exampleConfig returns the file that loads the example server configuration
+// from the environment. The default values of the configuration are derived
+// from the first server defined in the design.
+func exampleConfig(root *httpdesign.RootExpr) *codegen.File {
+	configPath := filepath.Join("cmd", codegen.SnakeCase(codegen.Goify(root.Design.API.Name, true))+"_svc", "config.go")
+	if _, err := os.Stat(configPath); !os.IsNotExist(err) {
+		return nil // file already exists, skip it.
+	}
+	host, port := serverHostPort(root.Design.API)
+	data := map[string]interface{}{
+		"EnvPrefix": envPrefix(root.Design.API),
+		"Host":      host,
+		"Port":      port,
+	}
+	specs := []*codegen.ImportSpec{
+		{Path: "fmt"},
+		{Path: "net"},
+		{Path: "os"},
+		{Path: "strconv"},
+	}
+	return &codegen.File{
+		Path: configPath,
+		SectionTemplates: []*codegen.SectionTemplate{
+			codegen.Header("", "main", specs),
+			{Name: "service-config", Source: configT, Data: data},
+		},
+	}
+}
+
+// serverHostPort returns the host and port of the first server defined in the
+// design. The port defaults to the port of the server URL scheme.
+func serverHostPort(api *design.APIExpr) (host, port string) {
+	host, port = "localhost", "8080"
+	if len(api.Servers) == 0 {
+		return
+	}
+	u, err := url.Parse(api.Servers[0].DefaultURL())
+	if err != nil || u.Host == "" {
+		return
+	}
+	host = u.Hostname()
+	switch {
+	case u.Port() != "":
+		port = u.Port()
+	case u.Scheme == "https":
+		port = "443"
+	default:
+		port = "80"
+	}
+	return
+}
+
+// envPrefix returns the prefix of the environment variables that configure the
+// example server.
+func envPrefix(api *design.APIExpr) string {
+	return strings.ToUpper(codegen.SnakeCase(codegen.Goify(api.Name, true)))
+}
+
 // needStream returns true if at least one method in the list of services
 // uses stream for sending payload/result.
 func needStream(data []*ServiceData) bool {
@@ -159,14 +276,39 @@ func New{{ .Service.StructName }}(logger *log.Logger) {{ .Service.PkgName }}.Ser
 }
 `
 
-// input: EndpointData
+// input: ServiceData
+const dummyInterceptorsT = `{{ printf "%s service example interceptors implementation.\nThe example interceptors log the requests and invoke the next endpoint." .Service.Name | comment }}
+type {{ .Service.VarName }}Interceptors struct {
+	logger *log.Logger
+}
+
+{{ printf "New%sInterceptors returns the %s service interceptors implementation." .Service.StructName .Service.Name | comment }}
+func New{{ .Service.StructName }}Interceptors(logger *log.Logger) {{ .Service.PkgName }}.Interceptors {
+	return &{{ .Service.VarName }}Interceptors{logger}
+}
+{{- range .Service.Interceptors }}
+
+{{ printf "%s implements the %q interceptor." .VarName .Name | comment }}
+func (i *{{ $.Service.VarName }}Interceptors) {{ .VarName }}(ctx context.Context, method string, req interface{}, next goa.Endpoint) (interface{}, error) {
+	i.logger.Printf("{{ $.Service.Name }}.%s: {{ .Name }}", method)
+	return next(ctx, req)
+}
+{{- end }}
+`
+
+// input: dummyEndpointData
 const dummyEndpointImplT = `{{ comment .Method.Description }}
 {{- if .ServerStream }}
 func (s *{{ .ServiceVarName }}Svc) {{ .Method.VarName }}(ctx context.Context{{ if and .Payload.Ref (not .ServerStream.RecvRef) }}, p {{ .Payload.Ref }}{{ end }}, stream {{ .ServerStream.Interface }}) (err error) {
 {{- else }}
-func (s *{{ .ServiceVarName }}Svc) {{ .Method.VarName }}(ctx context.Context{{ if .Payload.Ref }}, p {{ .Payload.Ref }}{{ end }}) ({{ if .Result.Ref }}res {{ .Result.Ref }}, {{ if .Method.ViewedResult }}{{ if not .Method.ViewedResult.ViewName }}view string, {{ end }}{{ end }} {{ end }}err error) {
+func (s *{{ .ServiceVarName }}Svc) {{ .Method.VarName }}(ctx context.Context{{ if .Payload.Ref }}, p {{ .Payload.Ref }}{{ end }}{{ if .Method.SkipRequestBodyEncodeDecode }}, req io.ReadCloser{{ end }}) ({{ if .Result.Ref }}res {{ .Result.Ref }}, {{ if .Method.ViewedResult }}{{ if not .Method.ViewedResult.ViewName }}view string, {{ end }}{{ end }} {{ end }}{{ if .Method.SkipResponseBodyEncodeDecode }}body io.ReadCloser, {{ end }}err error) {
+{{- end }}
+{{- if .Method.SkipRequestBodyEncodeDecode }}
+	defer req.Close()
 {{- end }}
-{{- if and (and .Result.Ref .Result.IsStruct) (not .ServerStream) }}
+{{- if .ResultCode }}
+	res = {{ .ResultCode }}
+{{- else if and (and .Result.Ref .Result.IsStruct) (not .ServerStream) }}
 	res = &{{ .Result.Name }}{}
 {{- end }}
 {{- if .Method.ViewedResult }}
@@ -175,6 +317,9 @@ func (s *{{ .ServiceVarName }}Svc) {{ .Method.VarName }}(ctx context.Context{{ i
 	{{- else if not .Method.ViewedResult.ViewName }}
 	view = {{ printf "%q" .Result.View }}
 	{{- end }}
+{{- end }}
+{{- if .Method.SkipResponseBodyEncodeDecode }}
+	body = ioutil.NopCloser(strings.NewReader(""))
 {{- end }}
 	s.logger.Print("{{ .ServiceVarName }}.{{ .Method.Name }}")
 	return
@@ -183,7 +328,7 @@ func (s *{{ .ServiceVarName }}Svc) {{ .Method.VarName }}(ctx context.Context{{ i
 
 // input: MultipartData
 const dummyMultipartRequestDecoderImplT = `{{ printf "%s implements the multipart decoder for service %q endpoint %q. The decoder must populate the argument p after encoding." .FuncName .ServiceName .MethodName | comment }}
-func {{ .FuncName }}(mr *multipart.Reader, p *{{ .Payload.Ref }}) error {
+func {{ .FuncName }}(mr {{ if .Streaming }}*goahttp.MultipartReader{{ else }}*multipart.Reader{{ end }}, p *{{ .Payload.Ref }}) error {
 	// Add multipart request decoder logic here
 	return nil
 }
@@ -197,15 +342,34 @@ func {{ .FuncName }}(mw *multipart.Writer, p {{ .Payload.Ref }}) error {
 }
 `
 
-// input: map[string]interface{}{"Services":[]ServiceData, "APIPkg": string}
+// input: map[string]interface{}{"Services":[]ServiceData, "APIPkg": string, "HealthCheck": *httpdesign.HealthCheckExpr}
 const mainT = `func main() {
+	// Load the configuration from the environment, see config.go. The
+	// command line flags take precedence over the environment.
+	cfg, err := LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration: %s\n", err)
+		os.Exit(1)
+	}
+
 	// Define command line flags, add any other flag required to configure
 	// the service.
 	var (
-		addr = flag.String("listen", ":8080", "HTTP listen ` + "`" + `address` + "`" + `")
-		dbg  = flag.Bool("debug", false, "Log request and response bodies")
+		addr            = flag.String("listen", cfg.Addr(), "HTTP listen ` + "`" + `address` + "`" + `")
+		dbg             = flag.Bool("debug", cfg.LogLevel == "debug", "Log request and response bodies")
+		metrics         = flag.Bool("metrics", false, "Record Prometheus metrics and serve them on /metrics")
+		readTimeout     = flag.Duration("read-timeout", 15*time.Second, "Maximum duration for reading an entire request including the body")
+		writeTimeout    = flag.Duration("write-timeout", 15*time.Second, "Maximum duration before timing out writes of the response")
+		idleTimeout     = flag.Duration("idle-timeout", 60*time.Second, "Maximum amount of time to wait for the next request when keep-alives are enabled")
+		shutdownTimeout = flag.Duration("shutdown-timeout", 30*time.Second, "Maximum amount of time to wait for the in-flight requests to complete on shutdown")
+		tlsCert         = flag.String("tls-cert", cfg.TLSCert, "TLS certificate ` + "`" + `file` + "`" + `, serve HTTPS if set together with -tls-key")
+		tlsKey          = flag.String("tls-key", cfg.TLSKey, "TLS private key ` + "`" + `file` + "`" + `, serve HTTPS if set together with -tls-cert")
 	)
 	flag.Parse()
+	if (*tlsCert == "") != (*tlsKey == "") {
+		fmt.Fprintln(os.Stderr, "both -tls-cert and -tls-key must be set to serve HTTPS")
+		os.Exit(1)
+	}
 
 	// Setup logger and goa log adapter. Replace logger with your own using
 	// your log package of choice. The goa.design/middleware/logging/...
@@ -247,7 +411,7 @@ const mainT = `func main() {
 	{
 	{{- range .Services }}{{ $svc := . }}
 		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Endpoints = {{ .Service.PkgName }}.NewEndpoints({{ .Service.VarName }}Svc{{ range .Service.Schemes }}, {{ $.APIPkg }}.{{ $svc.Service.StructName }}{{ .Type }}Auth{{ end }})
+		{{ .Service.VarName }}Endpoints = {{ .Service.PkgName }}.NewEndpoints({{ .Service.VarName }}Svc{{ range .Service.Schemes }}, {{ $.APIPkg }}.{{ $svc.Service.StructName }}{{ .Type }}Auth{{ end }}{{ if .Service.Interceptors }}, {{ $.APIPkg }}.New{{ .Service.StructName }}Interceptors(logger){{ end }})
 		{{-  end }}
 	{{- end }}
 	}
@@ -284,17 +448,41 @@ const mainT = `func main() {
 	{{- end }}
 	{{- range .Services }}
 		{{-  if .Endpoints }}
-		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh{{ if needStream $.Services }}, upgrader, nil{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }})
+		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New({{ .Service.VarName }}Endpoints, mux, dec, enc, eh{{ if needStream $.Services }}, upgrader, nil{{ end }}{{ if binaryStreamExists . }}, goahttp.RawStreamCodec{{ end }}{{ range .Endpoints }}{{ if .MultipartRequestDecoder }}, {{ if .MultipartRequestDecoder.DefaultFuncName }}nil{{ else }}{{ $.APIPkg }}.{{ .MultipartRequestDecoder.FuncName }}{{ end }}{{ end }}{{ end }})
 		{{-  else }}
 		{{ .Service.VarName }}Server = {{ .Service.PkgName }}svr.New(nil, mux, dec, enc, eh)
 		{{-  end }}
 	{{- end }}
 	}
 
+	// Record the request counts, durations and number of requests in
+	// flight of each endpoint if metrics are enabled.
+	if *metrics {
+		m, err := goaprometheus.New(prometheus.DefaultRegisterer)
+		if err != nil {
+			logger.Fatalf("failed to register metrics: %s", err)
+		}
+		{{- range .Services }}
+			{{- if .Endpoints }}
+		{{ .Service.VarName }}Server.Instrument(m.Middleware)
+			{{- end }}
+		{{- end }}
+	}
+
 	// Configure the mux.
 	{{- range .Services }}
 	{{ .Service.PkgName }}svr.Mount(mux{{ if .Endpoints }}, {{ .Service.VarName }}Server{{ end }})
 	{{- end }}
+	if *metrics {
+		goaprometheus.Mount(mux, "/metrics", prometheus.DefaultGatherer)
+	}
+	{{- if .HealthCheck }}
+
+	// Mount the health check handlers. Add the functions checking the
+	// dependencies of the service (databases, queues etc.) to the readiness
+	// checkers.
+	goahttp.MountHealthChecks(mux, {{ printf "%q" .HealthCheck.LivenessPath }}, {{ printf "%q" .HealthCheck.ReadinessPath }}, nil, nil)
+	{{- end }}
 
 	// Wrap the multiplexer with additional middlewares. Middlewares mounted
 	// here apply to all the service endpoints.
@@ -307,21 +495,27 @@ const mainT = `func main() {
 		handler = middleware.RequestID()(handler)
 	}
 
-	// Create channel used by both the signal handler and server goroutines
-	// to notify the main goroutine when to stop the server.
-	errc := make(chan error)
-
-	// Setup interrupt handler. This optional step configures the process so
-	// that SIGINT and SIGTERM signals cause the service to stop gracefully.
+	// Create the context canceled when the process receives a SIGINT or
+	// SIGTERM signal so that the server stops gracefully.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	go func() {
 		c := make(chan os.Signal, 1)
-		signal.Notify(c, os.Interrupt)
-		errc <- fmt.Errorf("%s", <-c)
+		signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+		logger.Printf("received signal %s", <-c)
+		cancel()
 	}()
 
-	// Start HTTP server using default configuration, change the code to
-	// configure the server as required by your service.
-	srv := &http.Server{Addr: *addr, Handler: handler}
+	// Start HTTP server, change the code to configure the server as
+	// required by your service.
+	srv := &http.Server{
+		Addr:         *addr,
+		Handler:      handler,
+		ReadTimeout:  *readTimeout,
+		WriteTimeout: *writeTimeout,
+		IdleTimeout:  *idleTimeout,
+	}
+	errc := make(chan error, 1)
 	go func() {
 		{{- range .Services }}
 		for _, m := range {{ .Service.VarName }}Server.Mounts {
@@ -332,17 +526,30 @@ const mainT = `func main() {
 			{{- end }}
 		}
 		{{- end }}
+		if *tlsCert != "" {
+			logger.Printf("listening on %s (HTTPS)", *addr)
+			errc <- srv.ListenAndServeTLS(*tlsCert, *tlsKey)
+			return
+		}
 		logger.Printf("listening on %s", *addr)
 		errc <- srv.ListenAndServe()
 	}()
 
-	// Wait for signal.
-	logger.Printf("exiting (%v)", <-errc)
+	// Wait for the signal or for the server to fail.
+	select {
+	case err := <-errc:
+		logger.Fatalf("server failed: %s", err)
+	case <-ctx.Done():
+	}
 
-	// Shutdown gracefully with a 30s timeout.
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-	srv.Shutdown(ctx)
+	// Shutdown gracefully, the in-flight requests are given up to the
+	// shutdown timeout to complete.
+	logger.Printf("shutting down (waiting up to %s)", *shutdownTimeout)
+	sctx, scancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer scancel()
+	if err := srv.Shutdown(sctx); err != nil {
+		logger.Printf("failed to shutdown gracefully: %s", err)
+	}
 
 	logger.Println("exited")
 }
@@ -358,3 +565,59 @@ func ErrorHandler(logger *log.Logger) func(context.Context, http.ResponseWriter,
 	}
 }
 `
+
+// input: map[string]interface{}{"EnvPrefix": string, "Host": string, "Port": string}
+const configT = `// Config contains the server configuration. LoadConfig initializes the
+// configuration from the environment, the default values are derived from
+// the first server defined in the design.
+type Config struct {
+	// Host is the host the server listens on, set with {{ .EnvPrefix }}_HOST.
+	// Set it to "0.0.0.0" to listen on all the interfaces, for example
+	// when running in a container.
+	Host string
+	// Port is the port the server listens on, set with {{ .EnvPrefix }}_PORT.
+	Port string
+	// LogLevel is the log level, "info" or "debug", set with
+	// {{ .EnvPrefix }}_LOG_LEVEL. The server logs the request and response
+	// bodies at the debug level.
+	LogLevel string
+	// TLSCert is the path to the TLS certificate file, set with
+	// {{ .EnvPrefix }}_TLS_CERT.
+	TLSCert string
+	// TLSKey is the path to the TLS private key file, set with
+	// {{ .EnvPrefix }}_TLS_KEY.
+	TLSKey string
+}
+
+// LoadConfig loads the server configuration from the environment.
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
+		Host:     getenv("{{ .EnvPrefix }}_HOST", {{ printf "%q" .Host }}),
+		Port:     getenv("{{ .EnvPrefix }}_PORT", {{ printf "%q" .Port }}),
+		LogLevel: getenv("{{ .EnvPrefix }}_LOG_LEVEL", "info"),
+		TLSCert:  getenv("{{ .EnvPrefix }}_TLS_CERT", ""),
+		TLSKey:   getenv("{{ .EnvPrefix }}_TLS_KEY", ""),
+	}
+	if _, err := strconv.ParseUint(cfg.Port, 10, 16); err != nil {
+		return nil, fmt.Errorf("invalid port %q set with {{ .EnvPrefix }}_PORT", cfg.Port)
+	}
+	if cfg.LogLevel != "info" && cfg.LogLevel != "debug" {
+		return nil, fmt.Errorf("invalid log level %q set with {{ .EnvPrefix }}_LOG_LEVEL, must be \"info\" or \"debug\"", cfg.LogLevel)
+	}
+	return cfg, nil
+}
+
+// Addr returns the address the server listens on.
+func (c *Config) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// getenv returns the value of the environment variable with the given name,
+// def if the variable is not set.
+func getenv(name, def string) string {
+	if v, ok := os.LookupEnv(name); ok {
+		return v
+	}
+	return def
+}
+`
@@ -0,0 +1,100 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestDummyServiceNamedExamples(t *testing.T) {
+	RunHTTPDSL(t, testdata.NamedExamplesDSL)
+	f := dummyServiceFile("goa.design/goa/gen", httpdesign.Root, httpdesign.Root.HTTPServices[0])
+	if f == nil {
+		t.Fatal("got nil file")
+	}
+	sections := f.SectionTemplates[1:]
+	expected := []struct {
+		Name string
+		Code string
+	}{
+		{"dummy-service", ""},
+		{"dummy-endpoint", testdata.DummyNamedExamplesLoginCode},
+		{"dummy-pointer", testdata.DummyNamedExamplesIntPtrCode},
+	}
+	if len(sections) != len(expected) {
+		t.Fatalf("got %d sections, expected %d", len(sections), len(expected))
+	}
+	for i, s := range sections {
+		if s.Name != expected[i].Name {
+			t.Errorf("section %d: got %q, expected %q", i, s.Name, expected[i].Name)
+			continue
+		}
+		if expected[i].Code == "" {
+			continue
+		}
+		code := codegen.SectionCode(t, s)
+		if code != expected[i].Code {
+			t.Errorf("invalid code for section %d, got:\n%s\ngot vs. expected:\n%s", i, code, codegen.Diff(t, code, expected[i].Code))
+		}
+	}
+}
+
+func TestExampleMainGracefulShutdown(t *testing.T) {
+	RunHTTPDSL(t, testdata.NamedExamplesDSL)
+	f := exampleMain("goa.design/goa/gen", httpdesign.Root)
+	if f == nil {
+		t.Fatal("got nil file")
+	}
+	sections := f.Section("service-main")
+	if len(sections) != 1 {
+		t.Fatalf("got %d main sections, expected one", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[0])
+	for _, s := range []string{
+		`flag.Duration("read-timeout", 15*time.Second`,
+		`flag.Duration("write-timeout", 15*time.Second`,
+		`flag.Duration("idle-timeout", 60*time.Second`,
+		`flag.Duration("shutdown-timeout", 30*time.Second`,
+		`flag.String("tls-cert", cfg.TLSCert`,
+		`flag.String("tls-key", cfg.TLSKey`,
+		"signal.Notify(c, os.Interrupt, syscall.SIGTERM)",
+		"ReadTimeout:  *readTimeout,",
+		"errc <- srv.ListenAndServeTLS(*tlsCert, *tlsKey)",
+		"srv.Shutdown(sctx)",
+	} {
+		if !strings.Contains(code, s) {
+			t.Errorf("main does not contain %q, got:\n%s", s, code)
+		}
+	}
+}
+
+func TestExampleConfig(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"default-server", testdata.NamedExamplesDSL, testdata.ExampleConfigDefaultServerCode},
+		{"server-variables", testdata.ServerVariablesDSL, testdata.ExampleConfigServerVariablesCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			f := exampleConfig(httpdesign.Root)
+			if f == nil {
+				t.Fatal("got nil file")
+			}
+			sections := f.Section("service-config")
+			if len(sections) != 1 {
+				t.Fatalf("got %d config sections, expected one", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
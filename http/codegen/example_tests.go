@@ -0,0 +1,542 @@
+package codegen
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/service"
+	"goa.design/goa/design"
+	httpdesign "goa.design/goa/http/design"
+)
+
+type (
+	// ExampleTestsData contains the data needed to render the HTTP test
+	// scaffolding of a service.
+	ExampleTestsData struct {
+		// Service is the HTTP service data.
+		Service *ServiceData
+		// ServerPkg is the name of the server package.
+		ServerPkg string
+		// ClientPkg is the name of the client package.
+		ClientPkg string
+		// MockStruct is the name of the service mock struct.
+		MockStruct string
+		// Tests lists the endpoint tests.
+		Tests []*EndpointTestData
+	}
+
+	// EndpointTestData contains the data needed to render the test of a
+	// single endpoint.
+	EndpointTestData struct {
+		// Endpoint is the endpoint data.
+		Endpoint *EndpointData
+		// ServiceStruct is the service struct name.
+		ServiceStruct string
+		// MockStruct is the name of the service mock struct.
+		MockStruct string
+		// ServerPkg is the name of the server package.
+		ServerPkg string
+		// ClientPkg is the name of the client package.
+		ClientPkg string
+		// TestName is the name of the test function.
+		TestName string
+		// StubParams lists the parameters of the service method stub.
+		StubParams string
+		// StubResults lists the results of the service method stub.
+		StubResults string
+		// StubReturn lists the values returned by the service method
+		// stub.
+		StubReturn string
+		// PayloadCode is the code initializing the example payload if
+		// any.
+		PayloadCode string
+		// ResultCode is the code initializing the example result if any.
+		ResultCode string
+		// StatusCode is the status code of the successful response.
+		StatusCode string
+		// CompareResult is true if the decoded result can be compared
+		// with the stub result, i.e. the result is not rendered with a
+		// view.
+		CompareResult bool
+		// Cases lists the test cases initialized with the design
+		// examples.
+		Cases []*TestCaseData
+	}
+
+	// TestCaseData contains the data needed to render a test case of an
+	// endpoint test.
+	TestCaseData struct {
+		// Name is the name of the test case, the summary of the named
+		// example used to initialize it or "example".
+		Name string
+		// PayloadCode is the code initializing the case payload if any.
+		PayloadCode string
+		// ResultCode is the code initializing the case result if any.
+		ResultCode string
+		// ErrCode is the code initializing the case error if any.
+		ErrCode string
+		// StatusCode is the status code of the response.
+		StatusCode string
+	}
+)
+
+// ExampleTestsFile returns a file containing table driven tests for the HTTP
+// endpoints of all the services. The tests mount the HTTP servers with mock
+// services and make requests built with the generated clients. The test cases
+// are initialized with the design examples. Streaming, multipart and raw body
+// endpoints are not tested. ExampleTestsFile returns nil if the file already
+// exists or if there is no endpoint to test.
+func ExampleTestsFile(genpkg string, root *httpdesign.RootExpr) *codegen.File {
+	path := "http_test.go"
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		return nil // file already exists, skip it.
+	}
+	apiPkg := strings.ToLower(codegen.Goify(root.Design.API.Name, false))
+	specs := []*codegen.ImportSpec{
+		{Path: "context"},
+		{Path: "net/http"},
+		{Path: "net/http/httptest"},
+		{Path: "net/url"},
+		{Path: "reflect"},
+		{Path: "testing"},
+		{Path: "goa.design/goa", Name: "goa"},
+		{Path: "goa.design/goa/http", Name: "goahttp"},
+		{Path: "goa.design/goa/security"},
+	}
+	var (
+		sections []*codegen.SectionTemplate
+		ptrs     = make(map[string]string)
+		schemes  = make(map[string]struct{})
+	)
+	for _, svc := range root.HTTPServices {
+		data := exampleTestsData(HTTPServices.Get(svc.Name()), ptrs)
+		if data == nil {
+			continue
+		}
+		svcPath := codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/http/" + codegen.SnakeCase(svc.Name())
+		specs = append(specs,
+			&codegen.ImportSpec{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()), Name: data.Service.Service.PkgName},
+			&codegen.ImportSpec{Path: svcPath + "/server", Name: data.ServerPkg},
+			&codegen.ImportSpec{Path: svcPath + "/client", Name: data.ClientPkg},
+		)
+		for _, s := range data.Service.Service.Schemes {
+			schemes[s.Type] = struct{}{}
+		}
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "example-tests-server",
+			Source:  exampleTestsServerT,
+			Data:    data,
+			FuncMap: map[string]interface{}{"streamingEndpointExists": streamingEndpointExists, "binaryStreamExists": binaryStreamExists},
+		})
+		for _, t := range data.Tests {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "example-tests-endpoint",
+				Source: exampleTestsEndpointT,
+				Data:   t,
+			})
+		}
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	for _, typ := range sortedKeys(schemes) {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "example-tests-auth",
+			Source: exampleTestsAuthT,
+			Data:   typ,
+		})
+	}
+	for _, name := range sortedKeys(ptrs) {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "example-tests-pointer",
+			Source: exampleTestsPointerT,
+			Data:   map[string]string{"Name": name, "TypeRef": ptrs[name]},
+		})
+	}
+	sections = append([]*codegen.SectionTemplate{codegen.Header("", apiPkg+"_test", specs)}, sections...)
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// exampleTestsData builds the data needed to render the tests of the given
+// service endpoints. ptrs records the pointer helper functions used by the
+// example values code. exampleTestsData returns nil if no endpoint can be
+// tested.
+func exampleTestsData(data *ServiceData, ptrs map[string]string) *ExampleTestsData {
+	var (
+		svc   = data.Service
+		tests []*EndpointTestData
+	)
+	for _, e := range data.Endpoints {
+		m := e.Method
+		if e.ServerStream != nil || e.ClientStream != nil || e.MultipartRequestDecoder != nil ||
+			m.SkipRequestBodyEncodeDecode || m.SkipResponseBodyEncodeDecode {
+			continue
+		}
+		var (
+			method  = design.Root.Service(svc.Name).Method(m.Name)
+			scope   = svc.Scope
+			params  = []string{"ctx context.Context"}
+			results []string
+			returns []string
+			payload string
+			result  string
+		)
+		if e.Payload.Ref != "" {
+			params = append(params, "p "+e.Payload.Ref)
+			payload = exampleValueCode(method.Payload, m.PayloadEx, scope, svc.PkgName, "", ptrs)
+		}
+		if e.Result.Ref != "" {
+			results = append(results, "res "+e.Result.Ref)
+			returns = append(returns, "c.Result")
+			if m.ViewedResult != nil && m.ViewedResult.ViewName == "" {
+				results = append(results, "view string")
+				returns = append(returns, `"default"`)
+			}
+			result = exampleValueCode(method.Result, m.ResultEx, scope, svc.PkgName, "", ptrs)
+		}
+		results = append(results, "err error")
+		returns = append(returns, "c.Err")
+		status := "http.StatusOK"
+		for _, r := range e.Result.Responses {
+			if r.TagName == "" {
+				status = r.StatusCode
+				break
+			}
+		}
+		cases := exampleTestCases(e, method, payload, result, status, scope, svc.PkgName, ptrs)
+		tests = append(tests, &EndpointTestData{
+			Endpoint:      e,
+			ServiceStruct: svc.StructName,
+			MockStruct:    "Mock" + service.ServiceInterfaceName,
+			ServerPkg:     svc.PkgName + "svr",
+			ClientPkg:     svc.PkgName + "c",
+			TestName:      "Test" + svc.StructName + m.VarName,
+			StubParams:    strings.Join(params, ", "),
+			StubResults:   strings.Join(results, ", "),
+			StubReturn:    strings.Join(returns, ", "),
+			PayloadCode:   payload,
+			ResultCode:    result,
+			StatusCode:    status,
+			CompareResult: m.ViewedResult == nil,
+			Cases:         cases,
+		})
+	}
+	if len(tests) == 0 {
+		return nil
+	}
+	return &ExampleTestsData{
+		Service:    data,
+		ServerPkg:  svc.PkgName + "svr",
+		ClientPkg:  svc.PkgName + "c",
+		MockStruct: "Mock" + service.ServiceInterfaceName,
+		Tests:      tests,
+	}
+}
+
+// exampleTestCases returns the test cases of the endpoint e. The first case is
+// initialized with the first named examples of the payload and result if any
+// or with the given payload and result code otherwise. The other cases use the
+// remaining named examples of the payload, result and errors.
+func exampleTestCases(e *EndpointData, method *design.MethodExpr, payload, result, status string, scope *codegen.NameScope, pkg string, ptrs map[string]string) []*TestCaseData {
+	var (
+		name   = "example"
+		pexs   []*design.ExampleExpr
+		rexs   []*design.ExampleExpr
+		others []*TestCaseData
+	)
+	if e.Payload.Ref != "" {
+		if pexs = method.Payload.NamedExamples(); len(pexs) > 0 {
+			name = caseName(pexs[0], name)
+			payload = exampleValueCode(method.Payload, pexs[0].Value, scope, pkg, "", ptrs)
+		}
+	}
+	if e.Result.Ref != "" {
+		if rexs = method.Result.NamedExamples(); len(rexs) > 0 {
+			if name == "example" {
+				name = caseName(rexs[0], name)
+			}
+			result = exampleValueCode(method.Result, rexs[0].Value, scope, pkg, "", ptrs)
+		}
+	}
+	for i, ex := range pexs {
+		if i == 0 {
+			continue
+		}
+		others = append(others, &TestCaseData{
+			Name:        caseName(ex, "example"),
+			PayloadCode: exampleValueCode(method.Payload, ex.Value, scope, pkg, "", ptrs),
+			ResultCode:  result,
+			StatusCode:  status,
+		})
+	}
+	for i, ex := range rexs {
+		if i == 0 {
+			continue
+		}
+		others = append(others, &TestCaseData{
+			Name:        caseName(ex, "example"),
+			PayloadCode: payload,
+			ResultCode:  exampleValueCode(method.Result, ex.Value, scope, pkg, "", ptrs),
+			StatusCode:  status,
+		})
+	}
+	for _, grp := range e.Errors {
+		for _, er := range grp.Errors {
+			ee := method.Error(er.Name)
+			if ee == nil || ee.Type == design.ErrorResult {
+				// The built-in error type example is not specific to
+				// the endpoint.
+				continue
+			}
+			for _, ex := range ee.NamedExamples() {
+				others = append(others, &TestCaseData{
+					Name:        caseName(ex, er.Name),
+					PayloadCode: payload,
+					ErrCode:     exampleValueCode(ee.AttributeExpr, ex.Value, scope, pkg, "", ptrs),
+					StatusCode:  grp.StatusCode,
+				})
+			}
+		}
+	}
+	first := &TestCaseData{Name: name, PayloadCode: payload, ResultCode: result, StatusCode: status}
+	return append([]*TestCaseData{first}, others...)
+}
+
+// caseName returns the name of the test case initialized with ex, def if ex
+// does not have a summary.
+func caseName(ex *design.ExampleExpr, def string) string {
+	if ex.Summary == "" || ex.Summary == "default" {
+		return def
+	}
+	return ex.Summary
+}
+
+// exampleValueCode returns the Go code that initializes a value of the type
+// of att defined in the package pkg with the example value v. ptrs records
+// the helper functions used to initialize primitive pointers indexed by name,
+// the names of the helper functions start with prefix if not empty.
+func exampleValueCode(att *design.AttributeExpr, v interface{}, scope *codegen.NameScope, pkg, prefix string, ptrs map[string]string) string {
+	if v == nil {
+		return "nil"
+	}
+	val := reflect.ValueOf(v)
+	switch actual := att.Type.(type) {
+	case design.Primitive:
+		return primitiveCode(actual, v)
+	case *design.Array:
+		var elems []string
+		if val.Kind() == reflect.Slice {
+			for i := 0; i < val.Len(); i++ {
+				elems = append(elems, exampleValueCode(actual.ElemType, val.Index(i).Interface(), scope, pkg, prefix, ptrs))
+			}
+		}
+		return fmt.Sprintf("%s{%s}", scope.GoFullTypeRef(att, pkg), strings.Join(elems, ", "))
+	case *design.Map:
+		var elems []string
+		if val.Kind() == reflect.Map {
+			for _, k := range val.MapKeys() {
+				elems = append(elems, fmt.Sprintf("%s: %s",
+					exampleValueCode(actual.KeyType, k.Interface(), scope, pkg, prefix, ptrs),
+					exampleValueCode(actual.ElemType, val.MapIndex(k).Interface(), scope, pkg, prefix, ptrs)))
+			}
+		}
+		sort.Strings(elems)
+		return fmt.Sprintf("%s{%s}", scope.GoFullTypeRef(att, pkg), strings.Join(elems, ", "))
+	case design.UserType:
+		ref := scope.GoFullTypeRef(att, pkg)
+		if !design.IsObject(actual) {
+			return fmt.Sprintf("%s(%s)", ref, exampleValueCode(actual.Attribute(), v, scope, pkg, prefix, ptrs))
+		}
+		if val.Kind() != reflect.Map {
+			return "nil"
+		}
+		var fields []string
+		for _, nat := range *design.AsObject(actual) {
+			fv := val.MapIndex(reflect.ValueOf(nat.Name))
+			if !fv.IsValid() || fv.Interface() == nil {
+				continue
+			}
+			code := exampleValueCode(nat.Attribute, fv.Interface(), scope, pkg, prefix, ptrs)
+			if att.IsPrimitivePointer(nat.Name, true) {
+				tref := scope.GoFullTypeRef(nat.Attribute, pkg)
+				if en := codegen.GoEnumTypeName(nat.Attribute, nat.Name); en != "" {
+					tref = pkg + "." + en
+				}
+				name := strings.Replace(tref, ".", "_", -1) + "_ptr"
+				if prefix != "" {
+					name = prefix + "_" + name
+				}
+				name = codegen.Goify(name, false)
+				ptrs[name] = tref
+				code = fmt.Sprintf("%s(%s)", name, code)
+			}
+			fields = append(fields, fmt.Sprintf("\n%s: %s,", codegen.GoifyAtt(nat.Attribute, nat.Name, true), code))
+		}
+		if len(fields) > 0 {
+			fields = append(fields, "\n")
+		}
+		return fmt.Sprintf("&%s{%s}", strings.TrimPrefix(ref, "*"), strings.Join(fields, ""))
+	default:
+		// Inline object, leave it to the test author.
+		return scope.GoFullTypeRef(att, pkg) + "{}"
+	}
+}
+
+// primitiveCode returns the Go literal for the value v of the primitive type
+// p.
+func primitiveCode(p design.Primitive, v interface{}) string {
+	switch p.Kind() {
+	case design.StringKind:
+		return fmt.Sprintf("%q", fmt.Sprint(v))
+	case design.BytesKind:
+		if b, ok := v.([]byte); ok {
+			return fmt.Sprintf("[]byte(%q)", string(b))
+		}
+		return fmt.Sprintf("[]byte(%q)", fmt.Sprint(v))
+	case design.AnyKind:
+		if s, ok := v.(string); ok {
+			return fmt.Sprintf("%q", s)
+		}
+		return fmt.Sprintf("%#v", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// sortedKeys returns the keys of m in lexical order.
+func sortedKeys(m interface{}) []string {
+	keys := reflect.ValueOf(m).MapKeys()
+	res := make([]string, len(keys))
+	for i, k := range keys {
+		res[i] = k.String()
+	}
+	sort.Strings(res)
+	return res
+}
+
+// input: ExampleTestsData
+const exampleTestsServerT = `{{ printf "new%sTestServer starts a test HTTP server that serves the %q service endpoints implemented by svc." .Service.Service.StructName .Service.Service.Name | comment }}
+func new{{ .Service.Service.StructName }}TestServer(t *testing.T, svc *{{ .Service.Service.PkgName }}.{{ .MockStruct }}) *httptest.Server {
+	endpoints := {{ .Service.Service.PkgName }}.NewEndpoints(svc{{ range .Service.Service.Schemes }}, allow{{ .Type }}{{ end }}{{ if .Service.Service.Interceptors }}, {{ .Service.Service.VarName }}TestInterceptors{}{{ end }})
+	mux := goahttp.NewMuxer()
+	eh := func(ctx context.Context, w http.ResponseWriter, err error) {
+		t.Errorf("failed to encode response: %s", err)
+	}
+	server := {{ .ServerPkg }}.{{ .Service.ServerInit }}(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, eh{{ if streamingEndpointExists .Service }}, nil, nil{{ end }}{{ if binaryStreamExists .Service }}, nil{{ end }}{{ range .Service.Endpoints }}{{ if .MultipartRequestDecoder }}, nil{{ end }}{{ end }})
+	{{ .ServerPkg }}.{{ .Service.MountServer }}(mux, server)
+	return httptest.NewServer(mux)
+}
+
+{{ printf "new%sTestClient returns a client of the %q service that makes requests to srv." .Service.Service.StructName .Service.Service.Name | comment }}
+func new{{ .Service.Service.StructName }}TestClient(srv *httptest.Server) *{{ .ClientPkg }}.{{ .Service.ClientStruct }} {
+	u, _ := url.Parse(srv.URL)
+	return {{ .ClientPkg }}.New{{ .Service.ClientStruct }}(u.Scheme, u.Host, srv.Client(), goahttp.RequestEncoder, goahttp.ResponseDecoder, false{{ if streamingEndpointExists .Service }}, nil, nil{{ end }}{{ if binaryStreamExists .Service }}, nil{{ end }})
+}
+{{- if .Service.Service.Interceptors }}
+
+{{ printf "%sTestInterceptors implements the %q service interceptors by calling the next endpoint." .Service.Service.VarName .Service.Service.Name | comment }}
+type {{ .Service.Service.VarName }}TestInterceptors struct{}
+	{{- range .Service.Service.Interceptors }}
+
+{{ printf "%s calls next." .VarName | comment }}
+func ({{ $.Service.Service.VarName }}TestInterceptors) {{ .VarName }}(ctx context.Context, method string, req interface{}, next goa.Endpoint) (interface{}, error) {
+	return next(ctx, req)
+}
+	{{- end }}
+{{- end }}
+`
+
+// input: EndpointTestData
+const exampleTestsEndpointT = `{{ printf "%s tests the %q endpoint of the %q service. The first case is initialized with the design examples, add cases to cover the other behaviors of the endpoint." .TestName .Endpoint.Method.Name .Endpoint.ServiceName | comment }}
+func {{ .TestName }}(t *testing.T) {
+	cases := []struct {
+		Name    string
+	{{- if .Endpoint.Payload.Ref }}
+		Payload {{ .Endpoint.Payload.Ref }}
+	{{- end }}
+	{{- if .Endpoint.Result.Ref }}
+		Result  {{ .Endpoint.Result.Ref }}
+	{{- end }}
+		Err     error
+		Status  int
+	}{
+	{{- range .Cases }}
+		{
+			Name:    {{ printf "%q" .Name }},
+		{{- if $.Endpoint.Payload.Ref }}
+			Payload: {{ .PayloadCode }},
+		{{- end }}
+		{{- if and $.Endpoint.Result.Ref (not .ErrCode) }}
+			Result:  {{ .ResultCode }},
+		{{- end }}
+		{{- if .ErrCode }}
+			Err:     {{ .ErrCode }},
+		{{- end }}
+			Status:  {{ .StatusCode }},
+		},
+	{{- end }}
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			svc := &{{ .Endpoint.ServicePkgName }}.{{ .MockStruct }}{
+				{{ .Endpoint.Method.VarName }}Func: func({{ .StubParams }}) ({{ .StubResults }}) {
+					return {{ .StubReturn }}
+				},
+			}
+			srv := new{{ .ServiceStruct }}TestServer(t, svc)
+			defer srv.Close()
+			cli := new{{ .ServiceStruct }}TestClient(srv)
+
+			req, err := cli.{{ .Endpoint.RequestInit.Name }}(context.Background(){{ if .Endpoint.RequestInit.ClientArgs }}, c.Payload{{ end }})
+			if err != nil {
+				t.Fatalf("failed to build request: %s", err)
+			}
+	{{- if .Endpoint.RequestEncoder }}
+			if err := {{ .ClientPkg }}.{{ .Endpoint.RequestEncoder }}(goahttp.RequestEncoder)(req, c.Payload); err != nil {
+				t.Fatalf("failed to encode request: %s", err)
+			}
+	{{- end }}
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %s", err)
+			}
+			if resp.StatusCode != c.Status {
+				t.Errorf("got status %d, expected %d", resp.StatusCode, c.Status)
+			}
+			{{ if and .Endpoint.Result.Ref .CompareResult }}res{{ else }}_{{ end }}, err {{ if and .Endpoint.Result.Ref .CompareResult }}:{{ end }}= {{ .ClientPkg }}.{{ .Endpoint.ResponseDecoder }}(goahttp.ResponseDecoder, false)(resp)
+			if c.Err != nil {
+				if err == nil {
+					t.Errorf("got no error, expected %v", c.Err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to decode response: %s", err)
+			}
+	{{- if and .Endpoint.Result.Ref .CompareResult }}
+			if !reflect.DeepEqual(res, c.Result) {
+				t.Errorf("got result %#v, expected %#v", res, c.Result)
+			}
+	{{- end }}
+		})
+	}
+}
+`
+
+// input: string
+const exampleTestsAuthT = `{{ printf "allow%s is a security.Auth%sFunc that accepts all requests." . . | comment }}
+func allow{{ . }}(ctx context.Context, {{ if eq . "Basic" }}user, pass string{{ else if eq . "APIKey" }}key string{{ else }}token string{{ end }}, s *security.{{ . }}Scheme) (context.Context, error) {
+	return ctx, nil
+}
+`
+
+// input: map[string]string{"Name": string, "TypeRef": string}
+const exampleTestsPointerT = `{{ printf "%s returns a pointer to v." .Name | comment }}
+func {{ .Name }}(v {{ .TypeRef }}) *{{ .TypeRef }} {
+	return &v
+}
+`
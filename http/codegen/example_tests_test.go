@@ -0,0 +1,50 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestExampleTestsFile(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Sections []string
+		Code     []string
+	}{
+		{"example-tests", testdata.ExampleTestsDSL,
+			[]string{"example-tests-server", "example-tests-endpoint", "example-tests-endpoint", "example-tests-endpoint", "example-tests-pointer", "example-tests-pointer", "example-tests-pointer"},
+			[]string{testdata.ExampleTestsServerCode, testdata.ExampleTestsShowCode, testdata.ExampleTestsAddCode, testdata.ExampleTestsRemoveCode, testdata.ExampleTestsBoolPtrCode, testdata.ExampleTestsIntPtrCode, testdata.ExampleTestsStringPtrCode},
+		},
+		{"named-examples", testdata.NamedExamplesDSL,
+			[]string{"example-tests-server", "example-tests-endpoint", "example-tests-pointer"},
+			[]string{testdata.ExampleTestsAuthServerCode, testdata.ExampleTestsNamedExamplesCode, testdata.ExampleTestsIntPtrCode},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			f := ExampleTestsFile("goa.design/goa/gen", httpdesign.Root)
+			if f == nil {
+				t.Fatal("got nil file")
+			}
+			sections := f.SectionTemplates[1:]
+			if len(sections) != len(c.Sections) {
+				t.Fatalf("got %d sections, expected %d", len(sections), len(c.Sections))
+			}
+			for i, s := range sections {
+				if s.Name != c.Sections[i] {
+					t.Errorf("section %d: got %q, expected %q", i, s.Name, c.Sections[i])
+					continue
+				}
+				code := codegen.SectionCode(t, s)
+				if code != c.Code[i] {
+					t.Errorf("invalid code for section %d, got:\n%s\ngot vs. expected:\n%s", i, code, codegen.Diff(t, code, c.Code[i]))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+)
+
+// fastJSON returns true if the "http:body:fastjson" metadata is set on the
+// service with the given name or on the API.
+func fastJSON(svc string) bool {
+	if s := design.Root.Service(svc); s != nil {
+		if _, ok := s.Metadata["http:body:fastjson"]; ok {
+			return true
+		}
+	}
+	if design.Root.API == nil {
+		return false
+	}
+	_, ok := design.Root.API.Metadata["http:body:fastjson"]
+	return ok
+}
+
+// fastJSONType returns true if the JSON encoding and decoding methods can be
+// generated for the body type ut, that is if ut is an object that does not
+// define custom json field tags.
+func fastJSONType(ut design.UserType) bool {
+	obj := design.AsObject(ut)
+	if obj == nil {
+		return false
+	}
+	for _, nat := range *obj {
+		if _, ok := codegen.StructTags(nat.Attribute)["json"]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// fastJSONDefs returns the bodies of the methods that encode and decode the
+// body type ut to and from JSON without using reflection. ptr and useDefault
+// must be the values given to goTypeDef to generate the type definition. Both
+// returned strings are empty if the methods cannot be generated for ut.
+func fastJSONDefs(scope *codegen.NameScope, ut design.UserType, ptr, useDefault bool) (string, string) {
+	if !fastJSONType(ut) {
+		return "", ""
+	}
+	var (
+		enc = &strings.Builder{}
+		dec = &strings.Builder{}
+	)
+	ma := design.NewMappedAttributeExpr(ut.Attribute())
+	mat := ma.Attribute()
+	enc.WriteString("w.ObjectStart()\n")
+	dec.WriteString("r.ObjectStart()\nfor r.More() {\nswitch r.Field() {\n")
+	codegen.WalkMappedAttr(ma, func(name, elem string, required bool, at *design.AttributeExpr) error {
+		if elem == name {
+			elem = codegen.WireName(name)
+		}
+		var (
+			field   = "body." + codegen.GoifyAtt(at, name, true)
+			pointer = design.IsObject(at.Type) || design.IsPrimitive(at.Type) && (ptr || mat.IsPrimitivePointer(name, useDefault))
+			omit    = ptr || !required
+		)
+		key := fmt.Sprintf("w.Field(%q)\n", elem)
+		switch {
+		case omit && (pointer || isNillable(at.Type)):
+			cond := field + " != nil"
+			if !pointer && !design.IsObject(at.Type) && at.Type != design.Any {
+				cond = "len(" + field + ") > 0"
+			}
+			fmt.Fprintf(enc, "if %s {\n%s%s}\n", cond, key, encodeJSONCode(scope, at, field, pointer, true, ptr, useDefault, 1))
+		case omit:
+			fmt.Fprintf(enc, "if %s {\n%s%s}\n", zeroCheck(at.Type, field), key, encodeJSONCode(scope, at, field, false, true, ptr, useDefault, 1))
+		default:
+			enc.WriteString(key + encodeJSONCode(scope, at, field, pointer, false, ptr, useDefault, 1))
+		}
+		fmt.Fprintf(dec, "case %q:\n%s", elem, decodeJSONCode(scope, at, field, pointer, ptr, useDefault, 1))
+		return nil
+	})
+	enc.WriteString("w.ObjectEnd()")
+	dec.WriteString("default:\nr.Skip()\n}\n}\nr.ObjectEnd()")
+	return enc.String(), dec.String()
+}
+
+// encodeJSONCode returns the code that writes the value of type att held in
+// the variable target to the JSON writer w. pointer indicates whether target
+// holds a pointer, nonNil whether target is known not to be nil.
+func encodeJSONCode(scope *codegen.NameScope, att *design.AttributeExpr, target string, pointer, nonNil, ptr, useDefault bool, depth int) string {
+	if ut, ok := att.Type.(design.UserType); ok && fastJSONType(ut) {
+		if nonNil {
+			return target + ".encodeJSON(w)\n"
+		}
+		return fmt.Sprintf("if %s == nil {\nw.Null()\n} else {\n%s.encodeJSON(w)\n}\n", target, target)
+	}
+	if p, ok := att.Type.(design.Primitive); ok {
+		val := target
+		if pointer {
+			val = "*" + target
+		}
+		code := encodePrimitiveCode(p, val)
+		if pointer && !nonNil {
+			return fmt.Sprintf("if %s == nil {\nw.Null()\n} else {\n%s}\n", target, code)
+		}
+		return code
+	}
+	var code string
+	switch actual := att.Type.(type) {
+	case *design.Array:
+		e := suffix("e", depth)
+		code = fmt.Sprintf("w.ArrayStart()\nfor _, %s := range %s {\n%s}\nw.ArrayEnd()\n",
+			e, target, encodeJSONCode(scope, actual.ElemType, e, design.IsObject(actual.ElemType.Type), false, ptr, useDefault, depth+1))
+	case *design.Map:
+		if actual.KeyType.Type != design.String {
+			return "w.Value(" + target + ")\n"
+		}
+		// Sort the keys like encoding/json does so that the output is
+		// deterministic.
+		ks, k, e := suffix("keys", depth), suffix("k", depth), suffix("e", depth)
+		code = fmt.Sprintf("%s := make([]string, 0, len(%s))\nfor %s := range %s {\n%s = append(%s, %s)\n}\nsort.Strings(%s)\n", ks, target, k, target, ks, ks, k, ks)
+		code += fmt.Sprintf("w.ObjectStart()\nfor _, %s := range %s {\n%s := %s[%s]\nw.Field(%s)\n%s}\nw.ObjectEnd()\n",
+			k, ks, e, target, k, k, encodeJSONCode(scope, actual.ElemType, e, design.IsObject(actual.ElemType.Type), false, ptr, useDefault, depth+1))
+	default:
+		return "w.Value(" + target + ")\n"
+	}
+	if nonNil {
+		return code
+	}
+	return fmt.Sprintf("if %s == nil {\nw.Null()\n} else {\n%s}\n", target, code)
+}
+
+// encodePrimitiveCode returns the code that writes the primitive value val to
+// the JSON writer w.
+func encodePrimitiveCode(p design.Primitive, val string) string {
+	switch p.Kind() {
+	case design.BooleanKind:
+		return "w.Bool(" + val + ")\n"
+	case design.IntKind, design.Int32Kind:
+		return "w.Int(int64(" + val + "))\n"
+	case design.Int64Kind:
+		return "w.Int(" + val + ")\n"
+	case design.UIntKind, design.UInt32Kind:
+		return "w.Uint(uint64(" + val + "))\n"
+	case design.UInt64Kind:
+		return "w.Uint(" + val + ")\n"
+	case design.Float32Kind:
+		return "w.Float(float64(" + val + "), 32)\n"
+	case design.Float64Kind:
+		return "w.Float(" + val + ", 64)\n"
+	case design.StringKind:
+		return "w.String(" + val + ")\n"
+	case design.BytesKind:
+		return "w.Base64(" + val + ")\n"
+	default:
+		return "w.Value(" + val + ")\n"
+	}
+}
+
+// decodeJSONCode returns the code that reads a value of type att from the JSON
+// reader r and stores it in target. pointer indicates whether target holds a
+// pointer. depth is greater than one when target is a newly declared array or
+// map element variable that does not need to be reset on null.
+func decodeJSONCode(scope *codegen.NameScope, att *design.AttributeExpr, target string, pointer, ptr, useDefault bool, depth int) string {
+	if ut, ok := att.Type.(design.UserType); ok && fastJSONType(ut) {
+		return decodeNullable(target, fmt.Sprintf("%s = &%s{}\n%s.decodeJSON(r)\n",
+			target, scope.GoTypeName(att), target), depth)
+	}
+	if p, ok := att.Type.(design.Primitive); ok {
+		read := decodePrimitiveCode(p)
+		switch {
+		case read == "":
+			return "r.Value(&" + target + ")\n"
+		case pointer:
+			return decodeNullable(target, fmt.Sprintf("v := %s\n%s = &v\n", read, target), depth)
+		case p == design.Bytes:
+			return decodeNullable(target, fmt.Sprintf("%s = %s\n", target, read), depth)
+		default:
+			return fmt.Sprintf("if !r.Null() {\n%s = %s\n}\n", target, read)
+		}
+	}
+	var code string
+	switch actual := att.Type.(type) {
+	case *design.Array:
+		e := suffix("e", depth)
+		code = fmt.Sprintf("%s = %s{}\nr.ArrayStart()\nfor r.More() {\nvar %s %s\n%s%s = append(%s, %s)\n}\nr.ArrayEnd()\n",
+			target, goTypeDef(scope, att, ptr, useDefault), e, elemTypeDef(scope, actual.ElemType, ptr, useDefault),
+			decodeJSONCode(scope, actual.ElemType, e, design.IsObject(actual.ElemType.Type), ptr, useDefault, depth+1),
+			target, target, e)
+	case *design.Map:
+		if actual.KeyType.Type != design.String {
+			return "r.Value(&" + target + ")\n"
+		}
+		k, e := suffix("k", depth), suffix("e", depth)
+		code = fmt.Sprintf("%s = make(%s)\nr.ObjectStart()\nfor r.More() {\n%s := r.Field()\nvar %s %s\n%s%s[%s] = %s\n}\nr.ObjectEnd()\n",
+			target, goTypeDef(scope, att, ptr, useDefault), k, e, elemTypeDef(scope, actual.ElemType, ptr, useDefault),
+			decodeJSONCode(scope, actual.ElemType, e, design.IsObject(actual.ElemType.Type), ptr, useDefault, depth+1),
+			target, k, e)
+	default:
+		return "r.Value(&" + target + ")\n"
+	}
+	return decodeNullable(target, code, depth)
+}
+
+// decodeNullable returns the code that runs the decoding code if the next JSON
+// value is not null and that sets target to nil otherwise.
+func decodeNullable(target, code string, depth int) string {
+	if depth > 1 {
+		return fmt.Sprintf("if !r.Null() {\n%s}\n", code)
+	}
+	return fmt.Sprintf("if r.Null() {\n%s = nil\n} else {\n%s}\n", target, code)
+}
+
+// decodePrimitiveCode returns the expression that reads a value of the given
+// primitive type from the JSON reader r, the empty string if the type is not
+// supported by the reader.
+func decodePrimitiveCode(p design.Primitive) string {
+	switch p.Kind() {
+	case design.BooleanKind:
+		return "r.Bool()"
+	case design.IntKind:
+		return "int(r.Int(0))"
+	case design.Int32Kind:
+		return "int32(r.Int(32))"
+	case design.Int64Kind:
+		return "r.Int(64)"
+	case design.UIntKind:
+		return "uint(r.Uint(0))"
+	case design.UInt32Kind:
+		return "uint32(r.Uint(32))"
+	case design.UInt64Kind:
+		return "r.Uint(64)"
+	case design.Float32Kind:
+		return "float32(r.Float(32))"
+	case design.Float64Kind:
+		return "r.Float(64)"
+	case design.StringKind:
+		return "r.String()"
+	case design.BytesKind:
+		return "r.Base64()"
+	default:
+		return ""
+	}
+}
+
+// elemTypeDef returns the Go type of the elements of an array or map whose
+// element type is att.
+func elemTypeDef(scope *codegen.NameScope, att *design.AttributeExpr, ptr, useDefault bool) string {
+	d := goTypeDef(scope, att, ptr, useDefault)
+	if design.IsObject(att.Type) {
+		d = "*" + d
+	}
+	return d
+}
+
+// zeroCheck returns the condition that checks that the non-pointer value
+// target of type dt is not the zero value.
+func zeroCheck(dt design.DataType, target string) string {
+	if ut, ok := dt.(design.UserType); ok {
+		dt = ut.Attribute().Type
+	}
+	switch dt {
+	case design.Boolean:
+		return target
+	case design.String:
+		return target + ` != ""`
+	case design.Any:
+		return target + " != nil"
+	}
+	return target + " != 0"
+}
+
+// isNillable returns true if the Go values of type dt may be nil.
+func isNillable(dt design.DataType) bool {
+	if ut, ok := dt.(design.UserType); ok {
+		dt = ut.Attribute().Type
+	}
+	return dt == design.Bytes || dt == design.Any || design.IsArray(dt) || design.IsMap(dt)
+}
+
+// suffix returns the name of a variable declared at the given depth.
+func suffix(name string, depth int) string {
+	if depth == 1 {
+		return name
+	}
+	return fmt.Sprintf("%s%d", name, depth)
+}
@@ -0,0 +1,41 @@
+package codegen
+
+import (
+	"strings"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestFastJSON(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		FilesFn func(string, *httpdesign.RootExpr) []*codegen.File
+		Section string
+		Code    string
+	}{
+		{"server", testdata.FastJSONDSL, ServerTypeFiles, "server-fastjson", testdata.FastJSONServerTypesCode},
+		{"client", testdata.FastJSONDSL, ClientTypeFiles, "client-fastjson", testdata.FastJSONClientTypesCode},
+		{"disabled", testdata.PayloadBodyQueryObjectDSL, ServerTypeFiles, "server-fastjson", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := c.FilesFn("", httpdesign.Root)
+			if len(fs) != 1 {
+				t.Fatalf("got %d files, expected one", len(fs))
+			}
+			var codes []string
+			for _, s := range fs[0].Section(c.Section) {
+				codes = append(codes, codegen.SectionCode(t, s))
+			}
+			code := strings.Join(codes, "\n")
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
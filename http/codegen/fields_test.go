@@ -0,0 +1,19 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestServerFieldSelection(t *testing.T) {
+	cases := []*testCase{
+		{"field-selection", testdata.FieldSelectionDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.FieldSelectionHandlerInitCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
@@ -20,6 +20,7 @@ func TestHandlerInit(t *testing.T) {
 		{"no payload result", testdata.ServerNoPayloadResultDSL, testdata.ServerNoPayloadResultHandlerConstructorCode},
 		{"payload result", testdata.ServerPayloadResultDSL, testdata.ServerPayloadResultHandlerConstructorCode},
 		{"payload result error", testdata.ServerPayloadResultErrorDSL, testdata.ServerPayloadResultErrorHandlerConstructorCode},
+		{"panic error", testdata.ServerPanicErrorDSL, testdata.ServerPanicErrorHandlerConstructorCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -29,10 +30,10 @@ func TestHandlerInit(t *testing.T) {
 				t.Fatalf("got %d files, expected two", len(fs))
 			}
 			sections := fs[0].SectionTemplates
-			if len(sections) < 7 {
-				t.Fatalf("got %d sections, expected at least 6", len(sections))
+			if len(sections) < 10 {
+				t.Fatalf("got %d sections, expected at least 10", len(sections))
 			}
-			code := codegen.SectionCode(t, sections[8])
+			code := codegen.SectionCode(t, sections[9])
 			if code != c.Code {
 				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
 			}
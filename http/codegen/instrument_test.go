@@ -0,0 +1,20 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestServerInstrument(t *testing.T) {
+	cases := []*testCase{
+		{"route-table", testdata.RouteTableDSL, []*sectionExpectation{
+			{"server-instrument", &testdata.ServerInstrumentCode},
+			{"server-access-log", &testdata.ServerAccessLogCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
@@ -0,0 +1,19 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestServerMaxBody(t *testing.T) {
+	cases := []*testCase{
+		{"max-body", testdata.MaxBodyDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.MaxBodyServerHandlerInitCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
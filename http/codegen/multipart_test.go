@@ -19,6 +19,7 @@ func TestServerMultipartFuncType(t *testing.T) {
 		{"multipart-body-user-type", testdata.PayloadMultipartUserTypeDSL, testdata.MultipartUserTypeDecoderFuncTypeCode},
 		{"multipart-body-array-type", testdata.PayloadMultipartArrayTypeDSL, testdata.MultipartArrayTypeDecoderFuncTypeCode},
 		{"multipart-body-map-type", testdata.PayloadMultipartMapTypeDSL, testdata.MultipartMapTypeDecoderFuncTypeCode},
+		{"streaming-multipart", testdata.PayloadStreamingMultipartDSL, testdata.StreamingMultipartDecoderFuncTypeCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -82,6 +83,7 @@ func TestServerMultipartNewFunc(t *testing.T) {
 		{"multipart-body-array-type", testdata.PayloadMultipartArrayTypeDSL, testdata.MultipartArrayTypeDecoderFuncCode},
 		{"multipart-body-map-type", testdata.PayloadMultipartMapTypeDSL, testdata.MultipartMapTypeDecoderFuncCode},
 		{"multipart-with-params", testdata.PayloadMultipartWithParams, testdata.MultipartWithParamsDecoderFuncCode},
+		{"streaming-multipart", testdata.PayloadStreamingMultipartDSL, testdata.StreamingMultipartDecoderFuncCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -133,3 +135,33 @@ func TestClientMultipartNewFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestServerMultipartDefaultDecoder(t *testing.T) {
+	const genpkg = "gen"
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"multipart-body-user-type", testdata.PayloadMultipartUserTypeDSL, testdata.MultipartUserTypeDefaultDecoderCode},
+		{"multipart-default-decoder", testdata.PayloadMultipartDefaultDecoderDSL, testdata.MultipartDefaultDecoderCode},
+		{"streaming-multipart", testdata.PayloadStreamingMultipartDSL, testdata.StreamingMultipartDefaultDecoderCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ServerFiles(genpkg, httpdesign.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			sections := fs[1].Section("multipart-request-default-decoder")
+			if len(sections) != 1 {
+				t.Fatalf("got %d default decoder sections, expected 1", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
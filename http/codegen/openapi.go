@@ -19,43 +19,98 @@ type (
 	}
 )
 
-// OpenAPIFiles returns the files for the OpenAPIFile spec of the given HTTP API.
+// OpenAPIFiles returns the files for the OpenAPIFile spec of the given HTTP
+// API. If the API defines the "openapi:split" metadata then the definitions
+// are written to separate files referenced by the spec. If the API services
+// are versioned then the spec of the services of each version is also written
+// to the gen/http/<version> directory.
 func OpenAPIFiles(root *httpdesign.RootExpr) ([]*codegen.File, error) {
 	jsonPath := filepath.Join(codegen.Gendir, "http", "openapi.json")
 	yamlPath := filepath.Join(codegen.Gendir, "http", "openapi.yaml")
 	var (
-		jsonSection *codegen.SectionTemplate
-		yamlSection *codegen.SectionTemplate
+		jsonSpec *openapi.V2
+		yamlSpec *openapi.V2
 	)
 	{
-		spec, err := openapi.NewV2(root)
-		if err != nil {
+		var err error
+		if jsonSpec, err = openapi.NewV2(root); err != nil {
 			return nil, err
 		}
-		jsonSection = &codegen.SectionTemplate{
-			Name:    "openapi",
-			FuncMap: template.FuncMap{"toJSON": toJSON},
-			Source:  "{{ toJSON .}}",
-			Data:    spec,
+		yamlSpec = jsonSpec
+		if splitDefinitions(root) {
+			if yamlSpec, err = openapi.NewV2(root); err != nil {
+				return nil, err
+			}
 		}
-		yamlSection = &codegen.SectionTemplate{
-			Name:    "openapi",
-			FuncMap: template.FuncMap{"toYAML": toYAML},
-			Source:  "{{ toYAML .}}",
-			Data:    spec,
+	}
+
+	var files []*codegen.File
+	if splitDefinitions(root) {
+		jsonDefsPath := filepath.Join(codegen.Gendir, "http", "openapi_definitions.json")
+		yamlDefsPath := filepath.Join(codegen.Gendir, "http", "openapi_definitions.yaml")
+		jsonDefs := openapi.SplitDefinitions(jsonSpec, filepath.Base(jsonDefsPath))
+		yamlDefs := openapi.SplitDefinitions(yamlSpec, filepath.Base(yamlDefsPath))
+		files = []*codegen.File{
+			openAPIFile(jsonDefsPath, "openapi-definitions", jsonDefs),
+			openAPIFile(yamlDefsPath, "openapi-definitions", yamlDefs),
+		}
+	}
+
+	for _, ver := range versions(root) {
+		spec, err := openapi.NewV2Version(root, ver)
+		if err != nil {
+			return nil, err
 		}
+		dir := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(ver))
+		files = append(files,
+			openAPIFile(filepath.Join(dir, "openapi.json"), "openapi", spec),
+			openAPIFile(filepath.Join(dir, "openapi.yaml"), "openapi", spec),
+		)
+	}
+
+	return append([]*codegen.File{
+		openAPIFile(jsonPath, "openapi", jsonSpec),
+		openAPIFile(yamlPath, "openapi", yamlSpec),
+	}, files...), nil
+}
+
+// openAPIFile returns the file with the given path rendering data in JSON or
+// YAML depending on the path extension.
+func openAPIFile(path, name string, data interface{}) *codegen.File {
+	section := &codegen.SectionTemplate{
+		Name:    name,
+		FuncMap: template.FuncMap{"toJSON": toJSON},
+		Source:  "{{ toJSON .}}",
+		Data:    data,
+	}
+	if filepath.Ext(path) == ".yaml" {
+		section.FuncMap = template.FuncMap{"toYAML": toYAML}
+		section.Source = "{{ toYAML .}}"
+	}
+	return &codegen.File{
+		Path:             path,
+		SectionTemplates: []*codegen.SectionTemplate{section},
+	}
+}
+
+// splitDefinitions returns true if the "openapi:split" metadata is set to
+// "true" on the API.
+func splitDefinitions(root *httpdesign.RootExpr) bool {
+	if root == nil {
+		return false
+	}
+	if m, ok := root.Design.API.Metadata["openapi:split"]; ok {
+		return len(m) > 0 && m[0] == "true"
 	}
+	return false
+}
 
-	return []*codegen.File{
-		&codegen.File{
-			Path:             jsonPath,
-			SectionTemplates: []*codegen.SectionTemplate{jsonSection},
-		},
-		&codegen.File{
-			Path:             yamlPath,
-			SectionTemplates: []*codegen.SectionTemplate{yamlSection},
-		},
-	}, nil
+// versions returns the versions of the API services.
+func versions(root *httpdesign.RootExpr) []string {
+	if root == nil {
+		return nil
+	}
+	return root.Versions()
 }
 
 func toJSON(d interface{}) string {
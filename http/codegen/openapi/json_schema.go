@@ -27,6 +27,7 @@ type (
 		Description  string             `json:"description,omitempty" yaml:"description,omitempty"`
 		DefaultValue interface{}        `json:"default,omitempty" yaml:"default,omitempty"`
 		Example      interface{}        `json:"example,omitempty" yaml:"example,omitempty"`
+		Deprecated   bool               `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
 
 		// Hyper schema
 		Media     *Media  `json:"media,omitempty" yaml:"media,omitempty"`
@@ -50,8 +51,15 @@ type (
 
 		// Union
 		AnyOf []*Schema `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+
+		// Extensions defines the swagger extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
 	}
 
+	// _Schema is used in MarshalJSON and MarshalYAML to avoid recursive
+	// calls.
+	_Schema Schema
+
 	// Type is the JSON type enum.
 	Type string
 
@@ -126,12 +134,22 @@ func (s *Schema) JSON() ([]byte, error) {
 	return json.Marshal(s)
 }
 
+// MarshalJSON returns the JSON encoding of s.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_Schema(s), s.Extensions)
+}
+
+// MarshalYAML returns the value encoded in YAML in place of s.
+func (s Schema) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_Schema(s), s.Extensions)
+}
+
 // APISchema produces the API JSON hyper schema.
 func APISchema(api *design.APIExpr, r *httpdesign.RootExpr) *Schema {
 	for _, res := range r.HTTPServices {
 		GenerateServiceDefinition(api, res)
 	}
-	href := api.Servers[0].URL
+	href := api.Servers[0].DefaultURL()
 	links := []*Link{
 		{
 			Href: href,
@@ -330,7 +348,7 @@ func TypeSchemaWithPrefix(api *design.APIExpr, t design.DataType, prefix string)
 		for _, nat := range *actual {
 			prop := NewSchema()
 			buildAttributeSchema(api, prop, nat.Attribute)
-			s.Properties[nat.Name] = prop
+			s.Properties[codegen.WireName(nat.Name)] = prop
 		}
 	case *design.Map:
 		s.Type = Object
@@ -364,6 +382,7 @@ func (s *Schema) createMergeItems(other *Schema) mergeItems {
 		{&s.Title, other.Title, s.Title == ""},
 		{&s.Media, other.Media, s.Media == nil},
 		{&s.ReadOnly, other.ReadOnly, s.ReadOnly == false},
+		{&s.Deprecated, other.Deprecated, s.Deprecated == false},
 		{&s.PathStart, other.PathStart, s.PathStart == ""},
 		{&s.Enum, other.Enum, s.Enum == nil},
 		{&s.Format, other.Format, s.Format == ""},
@@ -411,14 +430,19 @@ func (s *Schema) Merge(other *Schema) {
 	}
 }
 
-// Dup creates a shallow clone of the given schema.
+// Dup creates a clone of the given schema, the nested schemas are cloned as
+// well.
 func (s *Schema) Dup() *Schema {
 	js := Schema{
+		Properties:           make(map[string]*Schema, len(s.Properties)),
+		Definitions:          make(map[string]*Schema, len(s.Definitions)),
 		ID:                   s.ID,
 		Description:          s.Description,
 		Schema:               s.Schema,
 		Type:                 s.Type,
 		DefaultValue:         s.DefaultValue,
+		Example:              s.Example,
+		Deprecated:           s.Deprecated,
 		Title:                s.Title,
 		Media:                s.Media,
 		ReadOnly:             s.ReadOnly,
@@ -436,6 +460,7 @@ func (s *Schema) Dup() *Schema {
 		MaxItems:             s.MaxItems,
 		Required:             s.Required,
 		AdditionalProperties: s.AdditionalProperties,
+		Extensions:           s.Extensions,
 	}
 	for n, p := range s.Properties {
 		js.Properties[n] = p.Dup()
@@ -446,6 +471,9 @@ func (s *Schema) Dup() *Schema {
 	for n, d := range s.Definitions {
 		js.Definitions[n] = d.Dup()
 	}
+	for _, a := range s.AnyOf {
+		js.AnyOf = append(js.AnyOf, a.Dup())
+	}
 	return &js
 }
 
@@ -459,7 +487,9 @@ func buildAttributeSchema(api *design.APIExpr, s *Schema, at *design.AttributeEx
 	}
 	s.DefaultValue = toStringMap(at.DefaultValue)
 	s.Description = at.Description
-	s.Example = at.Example(api.Random())
+	s.Example = codegen.WireExample(at, at.Example(api.Random()))
+	s.Deprecated = codegen.DeprecationReason(at.Metadata) != ""
+	s.Extensions = ExtensionsFromExpr(at.Metadata)
 	initAttributeValidation(s, at)
 
 	return s
@@ -494,7 +524,9 @@ func initAttributeValidation(s *Schema, at *design.AttributeExpr) {
 			s.MaxLength = val.MaxLength
 		}
 	}
-	s.Required = val.Required
+	for _, r := range val.Required {
+		s.Required = append(s.Required, codegen.WireName(r))
+	}
 }
 
 // AttributeTypeSchema produces the JSON schema corresponding to the given attribute.
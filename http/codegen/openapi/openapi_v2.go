@@ -2,6 +2,9 @@ package openapi
 
 import (
 	"encoding/json"
+	"sort"
+
+	"gopkg.in/yaml.v2"
 
 	"goa.design/goa/design"
 )
@@ -24,6 +27,12 @@ type (
 		SecurityDefinitions map[string]*SecurityDefinition `json:"securityDefinitions,omitempty" yaml:"securityDefinitions,omitempty"`
 		Tags                []*Tag                         `json:"tags,omitempty" yaml:"tags,omitempty"`
 		ExternalDocs        *ExternalDocs                  `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+		// Extensions defines the swagger extensions.
+		Extensions map[string]interface{} `json:"-" yaml:"-"`
+
+		// origins records the design types the definitions were derived
+		// from indexed by definition name.
+		origins map[string]*definitionOrigin
 	}
 
 	// Info provides metadata about the API. The metadata can be used by the clients if needed,
@@ -38,6 +47,24 @@ type (
 		Extensions     map[string]interface{} `json:"-" yaml:"-"`
 	}
 
+	// Server describes a host in the format of the OpenAPI v3 Server object.
+	// The servers are listed in the "x-servers" extension of the
+	// specification when the design defines more than one server or
+	// servers with URL variables.
+	Server struct {
+		URL         string                     `json:"url" yaml:"url"`
+		Description string                     `json:"description,omitempty" yaml:"description,omitempty"`
+		Variables   map[string]*ServerVariable `json:"variables,omitempty" yaml:"variables,omitempty"`
+	}
+
+	// ServerVariable describes a server URL variable in the format of the
+	// OpenAPI v3 Server Variable object.
+	ServerVariable struct {
+		Enum        []string `json:"enum,omitempty" yaml:"enum,omitempty"`
+		Default     string   `json:"default" yaml:"default"`
+		Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	}
+
 	// Path holds the relative paths to the individual endpoints.
 	Path struct {
 		// Ref allows for an external definition of this path item.
@@ -160,6 +187,8 @@ type (
 		Schema *Schema `json:"schema,omitempty" yaml:"schema,omitempty"`
 		// Headers is a list of headers that are sent with the response.
 		Headers map[string]*Header `json:"headers,omitempty" yaml:"headers,omitempty"`
+		// Examples lists examples of the response body indexed by mime type.
+		Examples map[string]interface{} `json:"examples,omitempty" yaml:"examples,omitempty"`
 		// Ref references a global API response.
 		// This field is exclusive with the other fields of Response.
 		Ref string `json:"$ref,omitempty" yaml:"$ref,omitempty"`
@@ -286,7 +315,9 @@ type (
 		Extensions map[string]interface{} `json:"-" yaml:"-"`
 	}
 
-	// These types are used in marshalJSON() to avoid recursive call of json.Marshal().
+	// These types are used in marshalJSON() and marshalYAML() to avoid
+	// recursive call of json.Marshal() and yaml.Marshal().
+	_V2                 V2
 	_Info               Info
 	_Path               Path
 	_Operation          Operation
@@ -319,6 +350,34 @@ func marshalJSON(v interface{}, extensions map[string]interface{}) ([]byte, erro
 	return merged, nil
 }
 
+func marshalYAML(v interface{}, extensions map[string]interface{}) (interface{}, error) {
+	if len(extensions) == 0 {
+		return v, nil
+	}
+	marshaled, err := yaml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var unmarshaled yaml.MapSlice
+	if err := yaml.Unmarshal(marshaled, &unmarshaled); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(extensions))
+	for k := range extensions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		unmarshaled = append(unmarshaled, yaml.MapItem{Key: k, Value: extensions[k]})
+	}
+	return unmarshaled, nil
+}
+
+// MarshalJSON returns the JSON encoding of v.
+func (v V2) MarshalJSON() ([]byte, error) {
+	return marshalJSON(_V2(v), v.Extensions)
+}
+
 // MarshalJSON returns the JSON encoding of i.
 func (i Info) MarshalJSON() ([]byte, error) {
 	return marshalJSON(_Info(i), i.Extensions)
@@ -353,3 +412,43 @@ func (s SecurityDefinition) MarshalJSON() ([]byte, error) {
 func (t Tag) MarshalJSON() ([]byte, error) {
 	return marshalJSON(_Tag(t), t.Extensions)
 }
+
+// MarshalYAML returns the value encoded in YAML in place of v.
+func (v V2) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_V2(v), v.Extensions)
+}
+
+// MarshalYAML returns the value encoded in YAML in place of i.
+func (i Info) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_Info(i), i.Extensions)
+}
+
+// MarshalYAML returns the value encoded in YAML in place of p.
+func (p Path) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_Path(p), p.Extensions)
+}
+
+// MarshalYAML returns the value encoded in YAML in place of o.
+func (o Operation) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_Operation(o), o.Extensions)
+}
+
+// MarshalYAML returns the value encoded in YAML in place of p.
+func (p Parameter) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_Parameter(p), p.Extensions)
+}
+
+// MarshalYAML returns the value encoded in YAML in place of r.
+func (r Response) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_Response(r), r.Extensions)
+}
+
+// MarshalYAML returns the value encoded in YAML in place of s.
+func (s SecurityDefinition) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_SecurityDefinition(s), s.Extensions)
+}
+
+// MarshalYAML returns the value encoded in YAML in place of t.
+func (t Tag) MarshalYAML() (interface{}, error) {
+	return marshalYAML(_Tag(t), t.Extensions)
+}
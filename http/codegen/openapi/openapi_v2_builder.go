@@ -17,11 +17,35 @@ import (
 
 // NewV2 returns the OpenAPI v2 specification for the given API.
 func NewV2(root *httpdesign.RootExpr) (*V2, error) {
+	return newV2(root, "")
+}
+
+// NewV2Version returns the OpenAPI v2 specification of the services of the
+// given API that have the given version, see httpdesign.ServiceExpr.Versioning.
+// The specification only includes the definitions of the types used by these
+// services.
+func NewV2Version(root *httpdesign.RootExpr, version string) (*V2, error) {
+	s, err := newV2(root, version)
+	if err != nil || s == nil {
+		return s, err
+	}
+	s.Info.Version = version
+	return s, nil
+}
+
+// newV2 returns the OpenAPI v2 specification for the given API. The
+// specification only includes the services with the given version if not
+// empty. The definitions are computed from scratch so that the specification
+// does not depend on the specifications built previously.
+func newV2(root *httpdesign.RootExpr, version string) (*V2, error) {
 	if root == nil {
 		return nil, nil
 	}
+	saved := Definitions
+	Definitions = make(map[string]*Schema)
+	defer func() { Definitions = saved }()
 	tags := tagsFromExpr(root.Metadata)
-	u, err := url.Parse(root.Design.API.Servers[0].URL)
+	u, err := url.Parse(root.Design.API.Servers[0].DefaultURL())
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse server URL: %s", err)
 	}
@@ -62,6 +86,13 @@ func NewV2(root *httpdesign.RootExpr) (*V2, error) {
 		Tags:                tags,
 		SecurityDefinitions: securitySpecFromExpr(root),
 		ExternalDocs:        docsFromExpr(root.Design.API.Docs),
+		Extensions:          ExtensionsFromExpr(root.Design.API.Metadata),
+	}
+	if servers := serversFromExpr(root.Design.API.Servers); servers != nil {
+		if s.Extensions == nil {
+			s.Extensions = make(map[string]interface{})
+		}
+		s.Extensions["x-servers"] = servers
 	}
 
 	for _, he := range root.HTTPErrors {
@@ -69,6 +100,7 @@ func NewV2(root *httpdesign.RootExpr) (*V2, error) {
 		if err != nil {
 			return nil, err
 		}
+		s.addOrigin(res.Schema, he.ErrorExpr.Type, responseView(he.Response))
 		if s.Responses == nil {
 			s.Responses = make(map[string]*Response)
 		}
@@ -79,6 +111,11 @@ func NewV2(root *httpdesign.RootExpr) (*V2, error) {
 		if !mustGenerate(res.Metadata) || !mustGenerate(res.ServiceExpr.Metadata) {
 			continue
 		}
+		if version != "" {
+			if v := res.Versioning(); v == nil || v.Version != version {
+				continue
+			}
+		}
 		for k, v := range ExtensionsFromExpr(res.Metadata) {
 			s.Paths[k] = v
 		}
@@ -101,6 +138,9 @@ func NewV2(root *httpdesign.RootExpr) (*V2, error) {
 			}
 		}
 	}
+	if root.HealthCheck != nil {
+		buildPathsFromHealthCheck(s, root.HealthCheck)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -112,20 +152,67 @@ func NewV2(root *httpdesign.RootExpr) (*V2, error) {
 			d.Links = nil
 			s.Definitions[n] = d
 		}
+		reuseDefinitions(s, root)
 	}
 	return s, nil
 }
 
 // ExtensionsFromExpr generates swagger extensions from the given metadata
-// expression.
-func ExtensionsFromExpr(mdata design.MetadataExpr) map[string]interface{} {
+// expressions. The extensions are defined with metadata keys of the form
+// "openapi:extension:x-name" or "swagger:extension:x-name" whose values are
+// decoded as JSON if possible. The extensions defined in the last expressions
+// take precedence.
+func ExtensionsFromExpr(mdatas ...design.MetadataExpr) map[string]interface{} {
 	extensions := make(map[string]interface{})
+	for _, mdata := range mdatas {
+		extensionsFromExpr(mdata, extensions)
+	}
+	if len(extensions) == 0 {
+		return nil
+	}
+	return extensions
+}
+
+// serversFromExpr returns the OpenAPI v3 descriptions of the given servers. It
+// returns nil if there is a single server that does not define URL variables
+// as the server is then completely described by the host and schemes of the
+// specification.
+func serversFromExpr(servers []*design.ServerExpr) []*Server {
+	if len(servers) == 0 || len(servers) == 1 && len(design.URLParams(servers[0].URL)) == 0 {
+		return nil
+	}
+	res := make([]*Server, len(servers))
+	for i, s := range servers {
+		srv := &Server{URL: s.URL, Description: s.Description}
+		for _, name := range design.URLParams(s.URL) {
+			att := s.Param(name)
+			if att == nil {
+				continue
+			}
+			v := &ServerVariable{Default: fmt.Sprint(att.DefaultValue), Description: att.Description}
+			if att.Validation != nil {
+				for _, val := range att.Validation.Values {
+					v.Enum = append(v.Enum, fmt.Sprint(val))
+				}
+			}
+			if srv.Variables == nil {
+				srv.Variables = make(map[string]*ServerVariable)
+			}
+			srv.Variables[name] = v
+		}
+		res[i] = srv
+	}
+	return res
+}
+
+// extensionsFromExpr adds the extensions defined in mdata to extensions.
+func extensionsFromExpr(mdata design.MetadataExpr, extensions map[string]interface{}) {
 	for key, value := range mdata {
 		chunks := strings.Split(key, ":")
 		if len(chunks) != 3 {
 			continue
 		}
-		if chunks[0] != "swagger" || chunks[1] != "extension" {
+		if chunks[0] != "swagger" && chunks[0] != "openapi" || chunks[1] != "extension" {
 			continue
 		}
 		if strings.HasPrefix(chunks[2], "x-") != true {
@@ -139,10 +226,6 @@ func ExtensionsFromExpr(mdata design.MetadataExpr) map[string]interface{} {
 		}
 		extensions[chunks[2]] = ival
 	}
-	if len(extensions) == 0 {
-		return nil
-	}
-	return extensions
 }
 
 // mustGenerate returns true if the metadata indicates that a OpenAPI specification should be
@@ -172,6 +255,13 @@ func securitySpecFromExpr(root *httpdesign.RootExpr) map[string]*SecurityDefinit
 			sd.Type = "apiKey"
 			sd.In = s.In
 			sd.Name = s.Name
+			if s.In == "cookie" {
+				// OpenAPI V2 spec does not support API keys in
+				// cookies. Hence we document the Cookie header.
+				sd.In = "header"
+				sd.Name = "Cookie"
+				sd.Description += fmt.Sprintf("\n**Cookie**: `%s`", s.Name)
+			}
 		case design.JWTKind:
 			sd.Type = "apiKey"
 			// OpenAPI V2 spec does not support JWT scheme. Hence we add the scheme
@@ -194,29 +284,57 @@ func securitySpecFromExpr(root *httpdesign.RootExpr) map[string]*SecurityDefinit
 			}
 		}
 		if len(s.Flows) > 0 {
-			switch s.Flows[0].Kind {
-			case design.AuthorizationCodeFlowKind:
-				sd.Flow = "accessCode"
-			case design.ImplicitFlowKind:
-				sd.Flow = "implicit"
-			case design.PasswordFlowKind:
-				sd.Flow = "password"
-			case design.ClientCredentialsFlowKind:
-				sd.Flow = "application"
-			}
+			sd.Flow = flowType(s.Flows[0])
 			sd.AuthorizationURL = s.Flows[0].AuthorizationURL
 			sd.TokenURL = s.Flows[0].TokenURL
 		}
 		sds[s.SchemeName] = &sd
+
+		// OpenAPI v2 security definitions describe a single flow, add
+		// one definition per additional flow.
+		for i, f := range s.Flows {
+			if i == 0 {
+				continue
+			}
+			fsd := sd
+			fsd.Flow = flowType(f)
+			fsd.AuthorizationURL = f.AuthorizationURL
+			fsd.TokenURL = f.TokenURL
+			sds[flowSchemeName(s, f)] = &fsd
+		}
 	}
 	return sds
 }
 
+// flowType returns the OpenAPI v2 name of the given OAuth2 flow.
+func flowType(f *design.FlowExpr) string {
+	switch f.Kind {
+	case design.AuthorizationCodeFlowKind:
+		return "accessCode"
+	case design.ImplicitFlowKind:
+		return "implicit"
+	case design.PasswordFlowKind:
+		return "password"
+	case design.ClientCredentialsFlowKind:
+		return "application"
+	}
+	return ""
+}
+
+// flowSchemeName returns the name of the security definition describing the
+// given flow of an OAuth2 scheme that defines multiple flows.
+func flowSchemeName(s *design.SchemeExpr, f *design.FlowExpr) string {
+	return s.SchemeName + "_" + flowType(f)
+}
+
 // hasAbsoluteRoutes returns true if any endpoint exposed by the API uses an
 // absolute route of if the API has file servers. This is needed as OpenAPI does
 // not support exceptions to the base path so if the API has any absolute route
 // the base path must be "/" and all routes must be absolutes.
 func hasAbsoluteRoutes(root *httpdesign.RootExpr) bool {
+	if root.HealthCheck != nil {
+		return true
+	}
 	hasAbsoluteRoutes := false
 	for _, res := range root.HTTPServices {
 		if !mustGenerate(res.Metadata) || !mustGenerate(res.ServiceExpr.Metadata) {
@@ -332,6 +450,12 @@ func summaryFromMetadata(name string, metadata design.MetadataExpr) string {
 	return name
 }
 
+// pathTemplate returns the OpenAPI path template that corresponds to the given
+// route path. Catch-all wildcards such as "{*path}" become "{path}".
+func pathTemplate(path string) string {
+	return httpdesign.WildcardRegex.ReplaceAllString(path, "/{$1}")
+}
+
 func paramsFromExpr(params *design.MappedAttributeExpr, path string) ([]*Parameter, error) {
 	if params == nil {
 		return nil, nil
@@ -399,6 +523,16 @@ func paramFor(at *design.AttributeExpr, name, in string, required bool) *Paramet
 	if design.IsArray(at.Type) {
 		p.Items = itemsFromExpr(design.AsArray(at.Type).ElemType)
 		p.CollectionFormat = "multi"
+		if in == "query" {
+			if s, ok := at.Metadata["http:query:style"]; ok && len(s) > 0 {
+				switch s[0] {
+				case httpdesign.QueryStylePipeDelimited:
+					p.CollectionFormat = "pipes"
+				case httpdesign.QueryStyleSpaceDelimited:
+					p.CollectionFormat = "ssv"
+				}
+			}
+		}
 	}
 	switch at.Type {
 	case design.Int, design.UInt, design.UInt32, design.UInt64:
@@ -433,12 +567,8 @@ func itemsFromExpr(at *design.AttributeExpr) *Items {
 func responseSpecFromExpr(s *V2, root *httpdesign.RootExpr, r *httpdesign.HTTPResponseExpr, typeNamePrefix string) (*Response, error) {
 	var schema *Schema
 	if mt, ok := r.Body.Type.(*design.ResultTypeExpr); ok {
-		view := design.DefaultView
-		if v, ok := r.Body.Metadata["view"]; ok {
-			view = v[0]
-		}
 		schema = NewSchema()
-		schema.Ref = ResultTypeRefWithPrefix(root.Design.API, mt, view, typeNamePrefix)
+		schema.Ref = ResultTypeRefWithPrefix(root.Design.API, mt, responseView(r), typeNamePrefix)
 	} else if r.Body.Type != design.Empty {
 		schema = AttributeTypeSchema(root.Design.API, r.Body)
 	}
@@ -446,6 +576,12 @@ func responseSpecFromExpr(s *V2, root *httpdesign.RootExpr, r *httpdesign.HTTPRe
 	if err != nil {
 		return nil, err
 	}
+	for n, v := range r.ConstantHeaders {
+		if headers == nil {
+			headers = make(map[string]*Header)
+		}
+		headers[n] = &Header{Type: "string", Enum: []interface{}{v}}
+	}
 	desc := r.Description
 	if desc == "" {
 		desc = fmt.Sprintf("%s response.", http.StatusText(r.StatusCode))
@@ -458,6 +594,72 @@ func responseSpecFromExpr(s *V2, root *httpdesign.RootExpr, r *httpdesign.HTTPRe
 	}, nil
 }
 
+// addResponseExamples adds the named examples of att to resp. The first example
+// is listed in the standard "examples" field for each mime type produced by
+// the response, all the examples are listed in the "x-examples" extension
+// indexed by summary.
+func addResponseExamples(resp *Response, att *design.AttributeExpr, r *httpdesign.HTTPResponseExpr) {
+	exs := examplesFromExpr(att, r.Body)
+	if exs == nil {
+		return
+	}
+	produces := r.Produces
+	if len(produces) == 0 {
+		produces = []string{"application/json"}
+	}
+	first := exs[att.NamedExamples()[0].Summary].(map[string]interface{})["value"]
+	resp.Examples = make(map[string]interface{}, len(produces))
+	for _, mt := range produces {
+		resp.Examples[mt] = first
+	}
+	if resp.Extensions == nil {
+		resp.Extensions = make(map[string]interface{})
+	}
+	resp.Extensions["x-examples"] = exs
+}
+
+// examplesFromExpr returns the value of the "x-examples" extension listing the
+// named examples of att indexed by summary, nil if att does not define named
+// examples or if body is empty. The example values only retain the attributes
+// of body as the other attributes are mapped to headers or parameters.
+func examplesFromExpr(att, body *design.AttributeExpr) map[string]interface{} {
+	exs := att.NamedExamples()
+	if len(exs) == 0 || body.Type == design.Empty {
+		return nil
+	}
+	obj := design.AsObject(body.Type)
+	res := make(map[string]interface{}, len(exs))
+	for _, ex := range exs {
+		v := ex.Value
+		if m, ok := v.(map[string]interface{}); ok && obj != nil {
+			bv := make(map[string]interface{}, len(m))
+			for k, val := range m {
+				if obj.Attribute(k) != nil {
+					bv[k] = val
+				}
+			}
+			v = bv
+		}
+		e := map[string]interface{}{
+			"summary": ex.Summary,
+			"value":   codegen.WireExample(body, v),
+		}
+		if ex.Description != "" {
+			e["description"] = ex.Description
+		}
+		res[ex.Summary] = e
+	}
+	return res
+}
+
+// responseView returns the view used to render the response body.
+func responseView(r *httpdesign.HTTPResponseExpr) string {
+	if v, ok := r.Body.Metadata["view"]; ok {
+		return v[0]
+	}
+	return design.DefaultView
+}
+
 func headersFromExpr(headers *design.MappedAttributeExpr) (map[string]*Header, error) {
 	if headers == nil {
 		return nil, nil
@@ -507,6 +709,16 @@ func buildPathFromFileServer(s *V2, root *httpdesign.RootExpr, fs *httpdesign.Fi
 			schema := TypeSchema(root.Design.API, design.ErrorResult)
 			responses["404"] = &Response{Description: "File not found", Schema: schema}
 		}
+		if fs.AcceptRanges {
+			responses["206"] = &Response{
+				Description: "Partial file content",
+				Schema:      &Schema{Type: File},
+				Headers: map[string]*Header{
+					"Content-Range": {Description: "Range of the file content returned", Type: "string"},
+				},
+			}
+			responses["416"] = &Response{Description: "Requested range not satisfiable"}
+		}
 
 		operationID := fmt.Sprintf("%s#%s", fs.Service.Name(), path)
 		schemes := root.Design.API.Schemes()
@@ -521,12 +733,7 @@ func buildPathFromFileServer(s *V2, root *httpdesign.RootExpr, fs *httpdesign.Fi
 			Schemes:      schemes,
 		}
 
-		key := httpdesign.WildcardRegex.ReplaceAllStringFunc(
-			path,
-			func(w string) string {
-				return fmt.Sprintf("/{%s}", w[2:])
-			},
-		)
+		key := pathTemplate(path)
 		if key == "" {
 			key = "/"
 		}
@@ -544,6 +751,28 @@ func buildPathFromFileServer(s *V2, root *httpdesign.RootExpr, fs *httpdesign.Fi
 	return nil
 }
 
+// buildPathsFromHealthCheck adds the liveness and readiness paths mounted by
+// the health check handlers to the spec.
+func buildPathsFromHealthCheck(s *V2, hc *httpdesign.HealthCheckExpr) {
+	checks := []struct{ name, path, summary string }{
+		{"liveness", hc.LivenessPath, "Check that the service is running"},
+		{"readiness", hc.ReadinessPath, "Check that the service is ready to accept requests"},
+	}
+	for _, c := range checks {
+		s.Paths[c.path] = &Path{
+			Get: &Operation{
+				Summary:     c.summary,
+				OperationID: "health#" + c.name,
+				Produces:    []string{"application/json"},
+				Responses: map[string]*Response{
+					"200": {Description: "OK response."},
+					"503": {Description: "Service Unavailable response."},
+				},
+			},
+		}
+	}
+}
+
 func buildPathFromExpr(s *V2, root *httpdesign.RootExpr, route *httpdesign.RouteExpr, basePath string) error {
 	endpoint := route.Endpoint
 
@@ -558,9 +787,48 @@ func buildPathFromExpr(s *V2, root *httpdesign.RootExpr, route *httpdesign.Route
 			return err
 		}
 		params = append(params, paramsFromHeaders(endpoint)...)
+		if endpoint.FieldsParam != "" {
+			fields := endpoint.SelectableFields()
+			enum := make([]interface{}, len(fields))
+			for i, f := range fields {
+				enum[i] = f
+			}
+			params = append(params, &Parameter{
+				Name:             endpoint.FieldsParam,
+				In:               "query",
+				Description:      "Result fields returned in the response body, nested fields are separated with dots.",
+				Type:             "array",
+				Items:            &Items{Type: "string", Enum: enum},
+				CollectionFormat: "csv",
+			})
+		}
+		versioning := endpoint.Service.Versioning()
+		if versioning != nil && versioning.Strategy == httpdesign.VersionHeader {
+			params = append(params, &Parameter{
+				Name:        versioning.Header,
+				In:          "header",
+				Description: "Version of the service.",
+				Required:    true,
+				Type:        "string",
+				Enum:        []interface{}{versioning.Value},
+			})
+		}
 
+		var produces []string
 		responses := make(map[string]*Response, len(endpoint.Responses))
 		for _, r := range endpoint.Responses {
+			for _, mt := range r.Produces {
+				found := false
+				for _, p := range produces {
+					if p == mt {
+						found = true
+						break
+					}
+				}
+				if !found {
+					produces = append(produces, mt)
+				}
+			}
 			if endpoint.MethodExpr.IsStreaming() {
 				// A streaming endpoint allows at most one successful response
 				// definition. So it is okay to change the first successful
@@ -574,13 +842,24 @@ func buildPathFromExpr(s *V2, root *httpdesign.RootExpr, route *httpdesign.Route
 			if err != nil {
 				return err
 			}
+			s.addOrigin(resp.Schema, endpoint.MethodExpr.Result.Type, responseView(r))
+			addResponseExamples(resp, endpoint.MethodExpr.Result, r)
 			responses[strconv.Itoa(r.StatusCode)] = resp
 		}
+		if versioning != nil && versioning.Strategy == httpdesign.VersionMediaType {
+			// The version is requested via the vendor media type.
+			produces = []string{versioning.Value}
+		}
 		for _, er := range endpoint.HTTPErrors {
 			resp, err := responseSpecFromExpr(s, root, er.Response, endpoint.Service.Name())
 			if err != nil {
 				return err
 			}
+			s.addOrigin(resp.Schema, er.ErrorExpr.Type, responseView(er.Response))
+			if er.ErrorExpr.Type != design.ErrorResult {
+				// Do not list the example of the built-in error type.
+				addResponseExamples(resp, er.ErrorExpr.AttributeExpr, er.Response)
+			}
 			responses[strconv.Itoa(er.Response.StatusCode)] = resp
 		}
 
@@ -592,6 +871,13 @@ func buildPathFromExpr(s *V2, root *httpdesign.RootExpr, route *httpdesign.Route
 				Required:    true,
 				Schema:      AttributeTypeSchemaWithPrefix(root.Design.API, endpoint.Body, codegen.Goify(endpoint.Service.Name(), true)),
 			}
+			s.addOrigin(pp.Schema, endpoint.MethodExpr.Payload.Type, "")
+			if exs := examplesFromExpr(endpoint.MethodExpr.Payload, endpoint.Body); exs != nil {
+				if pp.Extensions == nil {
+					pp.Extensions = make(map[string]interface{})
+				}
+				pp.Extensions["x-examples"] = exs
+			}
 			params = append(params, pp)
 		}
 
@@ -638,6 +924,33 @@ func buildPathFromExpr(s *V2, root *httpdesign.RootExpr, route *httpdesign.Route
 			}
 			requirements[i] = requirement
 		}
+		// Requests may be authorized with any of the flows of an
+		// OAuth2 scheme, list the definitions of the additional flows
+		// as alternative requirements.
+		for _, req := range reqs {
+			for _, s := range req.Schemes {
+				if s.Kind != design.OAuth2Kind {
+					continue
+				}
+				for i, f := range s.Flows {
+					if i == 0 {
+						continue
+					}
+					alt := make(map[string][]string)
+					for _, s2 := range req.Schemes {
+						name := s2.SchemeName
+						if s2 == s {
+							name = flowSchemeName(s, f)
+						}
+						alt[name] = []string{}
+						if s2.Kind == design.OAuth2Kind {
+							alt[name] = append(alt[name], req.Scopes...)
+						}
+					}
+					requirements = append(requirements, alt)
+				}
+			}
+		}
 
 		operation := &Operation{
 			Tags:         tagNames,
@@ -645,23 +958,42 @@ func buildPathFromExpr(s *V2, root *httpdesign.RootExpr, route *httpdesign.Route
 			Summary:      summaryFromExpr(endpoint.Name()+" "+endpoint.Service.Name(), endpoint),
 			ExternalDocs: docsFromExpr(endpoint.MethodExpr.Docs),
 			OperationID:  operationID,
+			Consumes:     endpoint.Consumes,
+			Produces:     produces,
 			Parameters:   params,
 			Responses:    responses,
 			Schemes:      schemes,
-			Deprecated:   false,
-			Extensions:   ExtensionsFromExpr(route.Metadata),
+			Deprecated:   codegen.DeprecationReason(endpoint.MethodExpr.Metadata) != "",
+			Extensions:   ExtensionsFromExpr(endpoint.MethodExpr.Service.Metadata, endpoint.MethodExpr.Metadata, route.Metadata),
 			Security:     requirements,
 		}
+		if pag := paginationFromExpr(endpoint); pag != nil {
+			if operation.Extensions == nil {
+				operation.Extensions = make(map[string]interface{})
+			}
+			operation.Extensions["x-pagination"] = pag
+		}
+		if rl := endpoint.MethodExpr.RateLimit; rl != nil {
+			if operation.Extensions == nil {
+				operation.Extensions = make(map[string]interface{})
+			}
+			operation.Extensions["x-rate-limit"] = map[string]interface{}{
+				"requests": rl.Requests,
+				"per":      rl.Per.String(),
+			}
+		}
+		if cbs := callbacksFromExpr(root, endpoint); cbs != nil {
+			if operation.Extensions == nil {
+				operation.Extensions = make(map[string]interface{})
+			}
+			operation.Extensions["x-callbacks"] = cbs
+		}
 
+		key = pathTemplate(key)
 		if key == "" {
 			key = "/"
 		}
-		bp := httpdesign.WildcardRegex.ReplaceAllStringFunc(
-			basePath,
-			func(w string) string {
-				return fmt.Sprintf("/{%s}", w[2:])
-			},
-		)
+		bp := pathTemplate(basePath)
 		if bp != "/" {
 			key = strings.TrimPrefix(key, bp)
 		}
@@ -693,6 +1025,90 @@ func buildPathFromExpr(s *V2, root *httpdesign.RootExpr, route *httpdesign.Route
 	return nil
 }
 
+// paginationFromExpr returns the value of the "x-pagination" extension that
+// documents the pagination attributes of the given endpoint, nil if the
+// endpoint method is not paginated. Payload attributes are identified with the
+// name of the corresponding HTTP parameter or header.
+func paginationFromExpr(endpoint *httpdesign.EndpointExpr) map[string]string {
+	p := endpoint.MethodExpr.Pagination
+	if p == nil {
+		return nil
+	}
+	param := func(name string) string {
+		for _, ma := range []*design.MappedAttributeExpr{endpoint.Params, endpoint.Headers} {
+			if design.AsObject(ma.Type).Attribute(name) != nil {
+				return ma.ElemName(name)
+			}
+		}
+		return name
+	}
+	pag := map[string]string{"items": p.Items}
+	if p.IsCursor() {
+		pag["cursor"] = param(p.CursorParam)
+		pag["next"] = p.NextCursor
+		return pag
+	}
+	pag["offset"] = param(p.OffsetParam)
+	if p.LimitParam != "" {
+		pag["limit"] = param(p.LimitParam)
+	}
+	return pag
+}
+
+// callbacksFromExpr returns the value of the "x-callbacks" extension that
+// documents the webhooks of the given endpoint, nil if the endpoint does not
+// declare webhooks. The extension follows the structure of the OpenAPI 3
+// callbacks object: the webhooks are indexed by name and describe the POST
+// request sent to the URL given by the runtime expression that identifies the
+// callback URL in the endpoint request.
+func callbacksFromExpr(root *httpdesign.RootExpr, endpoint *httpdesign.EndpointExpr) map[string]map[string]*Path {
+	if len(endpoint.Webhooks) == 0 {
+		return nil
+	}
+	cbs := make(map[string]map[string]*Path, len(endpoint.Webhooks))
+	for _, w := range endpoint.Webhooks {
+		op := &Operation{
+			Description: w.Description,
+			Consumes:    []string{"application/json"},
+			Parameters: []*Parameter{
+				{
+					Name:     "X-Webhook-Event",
+					In:       "header",
+					Required: true,
+					Type:     "string",
+					Enum:     []interface{}{w.Name},
+				},
+				{
+					Name:     w.Payload.Type.Name(),
+					In:       "body",
+					Required: true,
+					Schema:   AttributeTypeSchema(root.Design.API, w.Payload),
+				},
+			},
+			Responses: map[string]*Response{
+				"200": {Description: "The webhook was received."},
+			},
+		}
+		cbs[w.Name] = map[string]*Path{callbackURLExpr(endpoint, w.URLAttribute): {Post: op}}
+	}
+	return cbs
+}
+
+// callbackURLExpr returns the OpenAPI runtime expression that identifies the
+// value of the payload attribute with the given name in the endpoint requests.
+func callbackURLExpr(endpoint *httpdesign.EndpointExpr, name string) string {
+	if design.AsObject(endpoint.Headers.Type).Attribute(name) != nil {
+		return "{$request.header." + endpoint.Headers.ElemName(name) + "}"
+	}
+	if design.AsObject(endpoint.PathParams().Type).Attribute(name) != nil {
+		return "{$request.path." + endpoint.Params.ElemName(name) + "}"
+	}
+	if design.AsObject(endpoint.Params.Type).Attribute(name) != nil {
+		return "{$request.query." + endpoint.Params.ElemName(name) + "}"
+	}
+	return "{$request.body#/" + name + "}"
+}
+
 func scopesList(scopes []string) string {
 	sort.Strings(scopes)
 
@@ -0,0 +1,279 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+
+	"goa.design/goa/design"
+	httpdesign "goa.design/goa/http/design"
+)
+
+type (
+	// DefinitionsFile is the content of the file holding the definitions of
+	// a spec split with SplitDefinitions.
+	DefinitionsFile struct {
+		// Definitions contains the schemas moved out of the spec.
+		Definitions map[string]*Schema `json:"definitions" yaml:"definitions"`
+	}
+
+	// definitionOrigin records the design type a definition was derived
+	// from.
+	definitionOrigin struct {
+		// Type is the design type.
+		Type design.UserType
+		// View is the view used to render the type if it is a result
+		// type.
+		View string
+	}
+)
+
+// definitionsPrefix is the prefix of the references to the spec definitions.
+const definitionsPrefix = "#/definitions/"
+
+// SplitDefinitions moves the definitions of s to a separate file. The
+// references to the definitions in s are rewritten to point to the given
+// file name.
+func SplitDefinitions(s *V2, filename string) *DefinitionsFile {
+	defs := s.Definitions
+	s.Definitions = nil
+	walkSpecSchemas(s, func(sc *Schema) {
+		if strings.HasPrefix(sc.Ref, definitionsPrefix) {
+			sc.Ref = filename + sc.Ref
+		}
+	})
+	return &DefinitionsFile{Definitions: defs}
+}
+
+// addOrigin records that the definition referenced by schema was derived from
+// the design type dt rendered with the given view.
+func (v *V2) addOrigin(schema *Schema, dt design.DataType, view string) {
+	ut, ok := dt.(design.UserType)
+	if !ok || schema == nil || !strings.HasPrefix(schema.Ref, definitionsPrefix) {
+		return
+	}
+	if v.origins == nil {
+		v.origins = make(map[string]*definitionOrigin)
+	}
+	v.origins[strings.TrimPrefix(schema.Ref, definitionsPrefix)] = &definitionOrigin{Type: ut, View: view}
+}
+
+// reuseDefinitions replaces the definitions computed for the request and
+// response bodies with definitions named after the design types they were
+// derived from when the schemas are identical. This makes it possible for
+// operations using the same design types to share the same definitions.
+func reuseDefinitions(s *V2, root *httpdesign.RootExpr) {
+	origins := make(map[string]*definitionOrigin, len(s.origins))
+	for n, o := range s.origins {
+		origins[n] = o
+	}
+	// The types used by the body types are renamed with a suffix.
+	for n := range s.Definitions {
+		if _, ok := origins[n]; ok {
+			continue
+		}
+		for _, suffix := range []string{"RequestBody", "ResponseBody"} {
+			if !strings.HasSuffix(n, suffix) {
+				continue
+			}
+			if ut := designType(root.Design, strings.TrimSuffix(n, suffix)); ut != nil {
+				origins[n] = &definitionOrigin{Type: ut}
+			}
+		}
+	}
+	if len(origins) == 0 {
+		return
+	}
+
+	// Compute the schemas of the design types separately so that the
+	// existing definitions are left untouched.
+	var (
+		names = make(map[string]string, len(origins))
+		defs  map[string]*Schema
+	)
+	{
+		saved := Definitions
+		Definitions = make(map[string]*Schema)
+		for n, o := range origins {
+			var ref string
+			switch actual := o.Type.(type) {
+			case *design.ResultTypeExpr:
+				view := o.View
+				if view == "" {
+					view = design.DefaultView
+				}
+				ref = ResultTypeRef(root.Design.API, actual, view)
+			case *design.UserTypeExpr:
+				ref = TypeRef(root.Design.API, actual)
+			default:
+				continue
+			}
+			names[n] = strings.TrimPrefix(ref, definitionsPrefix)
+		}
+		defs = Definitions
+		Definitions = saved
+	}
+
+	// Only reuse the design type definitions that are identical to the
+	// computed definitions, note that excluding a definition may change
+	// the comparison of the definitions that reference it.
+	for {
+		excluded := false
+		for n, c := range names {
+			d, ok := s.Definitions[n]
+			if !ok {
+				delete(names, n)
+				excluded = true
+				continue
+			}
+			if existing, ok := s.Definitions[c]; ok && names[c] == "" {
+				if schemaKey(existing, names) != schemaKey(defs[c], names) {
+					delete(names, n)
+					excluded = true
+					continue
+				}
+			}
+			if schemaKey(d, names) != schemaKey(defs[c], names) {
+				delete(names, n)
+				excluded = true
+			}
+		}
+		if !excluded {
+			break
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	// The definitions are shared with the global Definitions, clone them
+	// prior to rewriting the references.
+	for n, d := range s.Definitions {
+		s.Definitions[n] = d.Dup()
+	}
+	for n := range names {
+		delete(s.Definitions, n)
+	}
+	for _, c := range names {
+		addDefinition(s, defs, c)
+	}
+	walkSpecSchemas(s, func(sc *Schema) {
+		if c, ok := names[strings.TrimPrefix(sc.Ref, definitionsPrefix)]; ok && strings.HasPrefix(sc.Ref, definitionsPrefix) {
+			sc.Ref = definitionsPrefix + c
+		}
+	})
+}
+
+// addDefinition adds the definition with the given name and the definitions
+// it references to s if not already present.
+func addDefinition(s *V2, defs map[string]*Schema, name string) {
+	if _, ok := s.Definitions[name]; ok {
+		return
+	}
+	d, ok := defs[name]
+	if !ok {
+		return
+	}
+	d.Media = nil
+	d.Links = nil
+	s.Definitions[name] = d
+	walkSchema(d, func(sc *Schema) {
+		if strings.HasPrefix(sc.Ref, definitionsPrefix) {
+			addDefinition(s, defs, strings.TrimPrefix(sc.Ref, definitionsPrefix))
+		}
+	})
+}
+
+// designType returns the user or result type with the given name, nil if
+// there is none.
+func designType(root *design.RootExpr, name string) design.UserType {
+	for _, t := range root.Types {
+		if t.Name() == name {
+			return t
+		}
+	}
+	for _, t := range root.ResultTypes {
+		if t.Name() == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// schemaKey returns a string used to compare schemas. The titles,
+// descriptions and examples are ignored and the references are renamed using
+// names.
+func schemaKey(s *Schema, names map[string]string) string {
+	if s == nil {
+		return ""
+	}
+	d := s.Dup()
+	walkSchema(d, func(sc *Schema) {
+		sc.Title = ""
+		sc.Description = ""
+		sc.Example = nil
+		sc.Media = nil
+		sc.Links = nil
+		if c, ok := names[strings.TrimPrefix(sc.Ref, definitionsPrefix)]; ok && strings.HasPrefix(sc.Ref, definitionsPrefix) {
+			sc.Ref = definitionsPrefix + c
+		}
+	})
+	b, err := json.Marshal(d)
+	if err != nil {
+		panic("openapi: " + err.Error()) // bug
+	}
+	return string(b)
+}
+
+// walkSchema calls fn on s and all its nested schemas.
+func walkSchema(s *Schema, fn func(*Schema)) {
+	if s == nil {
+		return
+	}
+	fn(s)
+	walkSchema(s.Items, fn)
+	for _, p := range s.Properties {
+		walkSchema(p, fn)
+	}
+	for _, d := range s.Definitions {
+		walkSchema(d, fn)
+	}
+	for _, a := range s.AnyOf {
+		walkSchema(a, fn)
+	}
+}
+
+// walkSpecSchemas calls fn on all the schemas used by the paths, parameters,
+// responses and definitions of s.
+func walkSpecSchemas(s *V2, fn func(*Schema)) {
+	walkParams := func(params []*Parameter) {
+		for _, p := range params {
+			walkSchema(p.Schema, fn)
+		}
+	}
+	walkResponses := func(responses map[string]*Response) {
+		for _, r := range responses {
+			walkSchema(r.Schema, fn)
+		}
+	}
+	for _, p := range s.Paths {
+		path, ok := p.(*Path)
+		if !ok {
+			continue
+		}
+		walkParams(path.Parameters)
+		for _, o := range []*Operation{path.Get, path.Put, path.Post, path.Delete, path.Options, path.Head, path.Patch} {
+			if o == nil {
+				continue
+			}
+			walkParams(o.Parameters)
+			walkResponses(o.Responses)
+		}
+	}
+	for _, p := range s.Parameters {
+		walkSchema(p.Schema, fn)
+	}
+	walkResponses(s.Responses)
+	for _, d := range s.Definitions {
+		walkSchema(d, fn)
+	}
+}
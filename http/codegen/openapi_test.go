@@ -8,11 +8,14 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 	"text/template"
 
 	"github.com/go-openapi/loads"
+	"goa.design/goa/codegen"
 	"goa.design/goa/http/codegen/openapi"
+	"goa.design/goa/http/codegen/testdata"
 
 	"goa.design/goa/design"
 	httpdesign "goa.design/goa/http/design"
@@ -358,3 +361,504 @@ func newEndpointComplexValidation(validation *design.ValidationExpr) *httpdesign
 	route.Endpoint = ep
 	return ep
 }
+
+func TestDeprecated(t *testing.T) {
+	var (
+		deprecated = design.MetadataExpr{"goa:deprecated": []string{"use bar instead"}}
+		route      = &httpdesign.RouteExpr{Method: "POST", Path: "/"}
+		ep         = &httpdesign.EndpointExpr{
+			MethodExpr: &design.MethodExpr{
+				Name:     "testEndpoint",
+				Payload:  &design.AttributeExpr{},
+				Result:   &design.AttributeExpr{Type: design.String},
+				Metadata: deprecated,
+			},
+			Body: &design.AttributeExpr{
+				Type: &design.Object{
+					{Name: "foo", Attribute: &design.AttributeExpr{Type: design.String, Metadata: deprecated, UserExamples: []*design.ExampleExpr{{}}}},
+					{Name: "bar", Attribute: &design.AttributeExpr{Type: design.String, UserExamples: []*design.ExampleExpr{{}}}},
+				},
+				UserExamples: []*design.ExampleExpr{{}},
+			},
+			Routes:    []*httpdesign.RouteExpr{route},
+			Responses: []*httpdesign.HTTPResponseExpr{},
+		}
+	)
+	route.Endpoint = ep
+	oFiles, err := OpenAPIFiles(newDesign(newService(ep)))
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	s := oFiles[0].SectionTemplates[0]
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	if err := validateSwagger(buf.Bytes()); err != nil {
+		t.Fatalf("invalid swagger: %s", err)
+	}
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Deprecated bool
+			Parameters []struct {
+				Schema struct {
+					Properties map[string]struct {
+						Deprecated bool
+					}
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("failed to unmarshal spec: %s", err)
+	}
+	op := spec.Paths["/"]["post"]
+	if !op.Deprecated {
+		t.Errorf("operation is not deprecated")
+	}
+	if len(op.Parameters) != 1 {
+		t.Fatalf("got %d parameters, expected 1", len(op.Parameters))
+	}
+	props := op.Parameters[0].Schema.Properties
+	if !props["foo"].Deprecated {
+		t.Errorf("property foo is not deprecated")
+	}
+	if props["bar"].Deprecated {
+		t.Errorf("property bar is deprecated")
+	}
+}
+
+func TestWireNaming(t *testing.T) {
+	RunHTTPDSL(t, testdata.PayloadBodyWireNamingDSL)
+	oFiles, err := OpenAPIFiles(httpdesign.Root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	s := oFiles[0].SectionTemplates[0]
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	var spec struct {
+		Definitions map[string]struct {
+			Properties map[string]interface{}
+			Required   []string
+			Example    map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("failed to unmarshal spec: %s", err)
+	}
+	def, ok := spec.Definitions["ServiceBodyWireNamingMethodBodyWireNamingRequestBody"]
+	if !ok {
+		t.Fatalf("missing request body definition, got %v", spec.Definitions)
+	}
+	for _, p := range []string{"firstName", "itemSku", "tags"} {
+		if _, ok := def.Properties[p]; !ok {
+			t.Errorf("missing property %q, got %v", p, def.Properties)
+		}
+		if _, ok := def.Example[p]; !ok {
+			t.Errorf("missing example field %q, got %v", p, def.Example)
+		}
+	}
+	if len(def.Required) != 1 || def.Required[0] != "firstName" {
+		t.Errorf("got required %v, expected [firstName]", def.Required)
+	}
+	if tags, ok := def.Example["tags"].(map[string]interface{}); !ok || tags["some_key"] == nil {
+		t.Errorf("map keys must not be renamed, got %v", def.Example["tags"])
+	}
+}
+
+func TestInternalAttributes(t *testing.T) {
+	RunHTTPDSL(t, testdata.PayloadBodyInternalDSL)
+	oFiles, err := OpenAPIFiles(httpdesign.Root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	s := oFiles[0].SectionTemplates[0]
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	spec := buf.String()
+	if !strings.Contains(spec, `"note"`) {
+		t.Errorf("missing note property, got %s", spec)
+	}
+	for _, n := range []string{"password_hash", "trace"} {
+		if strings.Contains(spec, `"`+n+`"`) {
+			t.Errorf("internal attribute %q exposed, got %s", n, spec)
+		}
+	}
+}
+
+func TestConsumes(t *testing.T) {
+	RunHTTPDSL(t, testdata.MultipleContentTypesDSL)
+	oFiles, err := OpenAPIFiles(httpdesign.Root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	s := oFiles[0].SectionTemplates[0]
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Consumes []string
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("failed to unmarshal spec: %s", err)
+	}
+	expected := []string{"application/json", "application/x-www-form-urlencoded", "multipart/form-data"}
+	consumes := spec.Paths["/"]["post"].Consumes
+	if len(consumes) != len(expected) {
+		t.Fatalf("got consumes %v, expected %v", consumes, expected)
+	}
+	for i, c := range consumes {
+		if c != expected[i] {
+			t.Errorf("got consumes %v, expected %v", consumes, expected)
+		}
+	}
+}
+
+func TestQueryCollectionFormat(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Expected string
+	}{
+		{"form", testdata.PayloadQueryArrayStringDSL, "multi"},
+		{"pipe-delimited", testdata.PayloadQueryArrayStringPipeDelimitedDSL, "pipes"},
+		{"space-delimited", testdata.PayloadQueryArrayIntSpaceDelimitedDSL, "ssv"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			oFiles, err := OpenAPIFiles(httpdesign.Root)
+			if err != nil {
+				t.Fatalf("OpenAPI failed with %s", err)
+			}
+			s := oFiles[0].SectionTemplates[0]
+			var buf bytes.Buffer
+			tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+			if err := tmpl.Execute(&buf, s.Data); err != nil {
+				t.Fatalf("failed to render template: %s", err)
+			}
+			var spec struct {
+				Paths map[string]map[string]struct {
+					Parameters []struct {
+						Name             string
+						CollectionFormat string
+					}
+				}
+			}
+			if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+				t.Fatalf("failed to unmarshal spec: %s", err)
+			}
+			params := spec.Paths["/"]["get"].Parameters
+			if len(params) != 1 {
+				t.Fatalf("got %d parameters, expected 1", len(params))
+			}
+			if params[0].CollectionFormat != c.Expected {
+				t.Errorf("got collection format %q, expected %q", params[0].CollectionFormat, c.Expected)
+			}
+		})
+	}
+}
+
+func TestCatchAllPath(t *testing.T) {
+	RunHTTPDSL(t, testdata.PathCatchAllDSL)
+	oFiles, err := OpenAPIFiles(httpdesign.Root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	s := oFiles[0].SectionTemplates[0]
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name string
+				In   string
+			}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("failed to unmarshal spec: %s", err)
+	}
+	path, ok := spec.Paths["/one/{a}/files/{path}"]
+	if !ok {
+		t.Fatalf("path not found, got paths %v", spec.Paths)
+	}
+	var found bool
+	for _, p := range path["get"].Parameters {
+		if p.Name == "path" {
+			found = true
+			if p.In != "path" {
+				t.Errorf("got parameter location %q, expected %q", p.In, "path")
+			}
+		}
+	}
+	if !found {
+		t.Errorf("catch-all parameter not found")
+	}
+}
+
+func TestConstantHeader(t *testing.T) {
+	RunHTTPDSL(t, testdata.ResultConstantHeaderDSL)
+	oFiles, err := OpenAPIFiles(httpdesign.Root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	s := oFiles[0].SectionTemplates[0]
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Headers map[string]struct {
+					Type string
+					Enum []interface{}
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("failed to unmarshal spec: %s", err)
+	}
+	headers := spec.Paths["/"]["get"].Responses["200"].Headers
+	cases := map[string]string{"X-Api-Version": "2", "Cache-Control": "max-age=60"}
+	for name, value := range cases {
+		h, ok := headers[name]
+		if !ok {
+			t.Errorf("header %q not found, got headers %v", name, headers)
+			continue
+		}
+		if h.Type != "string" || len(h.Enum) != 1 || h.Enum[0] != value {
+			t.Errorf("header %q: got type %q and enum %v, expected string and [%s]", name, h.Type, h.Enum, value)
+		}
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	RunHTTPDSL(t, testdata.ExtensionDSL)
+	oFiles, err := OpenAPIFiles(httpdesign.Root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	s := oFiles[0].SectionTemplates[0]
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	var spec struct {
+		XAPI        map[string]interface{} `json:"x-api"`
+		Paths       map[string]map[string]map[string]interface{}
+		Definitions map[string]struct {
+			Properties map[string]map[string]interface{}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("failed to unmarshal spec: %s", err)
+	}
+	if spec.XAPI["gateway"] != "kong" {
+		t.Errorf("got API extension %v, expected gateway kong", spec.XAPI)
+	}
+	op := spec.Paths["/"]["post"]
+	if op["x-service"] != true {
+		t.Errorf("got service extension %v, expected true", op["x-service"])
+	}
+	if m, ok := op["x-method"].(map[string]interface{}); !ok || m["timeout"] != float64(30) {
+		t.Errorf("got method extension %v, expected timeout 30", op["x-method"])
+	}
+	if op["x-override"] != "method" {
+		t.Errorf("got extension %v, expected method to override service", op["x-override"])
+	}
+	var found bool
+	for _, def := range spec.Definitions {
+		if p, ok := def.Properties["name"]; ok {
+			found = true
+			if p["x-attribute"] != "masked" {
+				t.Errorf("got attribute extension %v, expected masked", p["x-attribute"])
+			}
+		}
+	}
+	if !found {
+		t.Errorf("name property not found in %v", spec.Definitions)
+	}
+}
+
+func TestDefinitionsReuse(t *testing.T) {
+	RunHTTPDSL(t, testdata.DefinitionsReuseDSL)
+	oFiles, err := OpenAPIFiles(httpdesign.Root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	s := oFiles[0].SectionTemplates[0]
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	if err := validateSwagger(buf.Bytes()); err != nil {
+		t.Fatalf("invalid swagger: %s", err)
+	}
+	type schema struct {
+		Ref string `json:"$ref"`
+	}
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				In     string
+				Schema schema
+			}
+			Responses map[string]struct {
+				Schema schema
+			}
+		}
+		Definitions map[string]struct {
+			Properties map[string]schema
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("failed to unmarshal spec: %s", err)
+	}
+	for _, m := range []string{"post", "put"} {
+		op := spec.Paths["/"][m]
+		if len(op.Parameters) != 1 || op.Parameters[0].Schema.Ref != "#/definitions/Pet" {
+			t.Errorf("%s: got parameters %v, expected a body referencing Pet", m, op.Parameters)
+		}
+		if ref := op.Responses["200"].Schema.Ref; ref != "#/definitions/Pet" {
+			t.Errorf("%s: got response reference %q, expected Pet", m, ref)
+		}
+	}
+	pet, ok := spec.Definitions["Pet"]
+	if !ok {
+		t.Fatalf("missing Pet definition, got %v", spec.Definitions)
+	}
+	if ref := pet.Properties["owner"].Ref; ref != "#/definitions/Owner" {
+		t.Errorf("got owner reference %q, expected Owner", ref)
+	}
+	if _, ok := spec.Definitions["Owner"]; !ok {
+		t.Errorf("missing Owner definition, got %v", spec.Definitions)
+	}
+	if ref := spec.Paths["/login"]["post"].Parameters[0].Schema.Ref; ref == "#/definitions/Secret" {
+		t.Errorf("body with internal attributes must not reference the design type")
+	}
+	for n := range spec.Definitions {
+		if strings.HasPrefix(n, "Pet") && n != "Pet" || strings.HasPrefix(n, "Owner") && n != "Owner" {
+			t.Errorf("unexpected definition %q", n)
+		}
+	}
+}
+
+func TestSplitDefinitions(t *testing.T) {
+	RunHTTPDSL(t, testdata.SplitDefinitionsDSL)
+	oFiles, err := OpenAPIFiles(httpdesign.Root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	paths := []string{"openapi.json", "openapi.yaml", "openapi_definitions.json", "openapi_definitions.yaml"}
+	if len(oFiles) != len(paths) {
+		t.Fatalf("got %d files, expected %d", len(oFiles), len(paths))
+	}
+	for i, p := range paths {
+		if oFiles[i].Path != filepath.Join("gen", "http", p) {
+			t.Errorf("got path %q, expected %q", oFiles[i].Path, p)
+		}
+	}
+	render := func(f *codegen.File) string {
+		s := f.SectionTemplates[0]
+		var buf bytes.Buffer
+		tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+		if err := tmpl.Execute(&buf, s.Data); err != nil {
+			t.Fatalf("failed to render template: %s", err)
+		}
+		return buf.String()
+	}
+	spec := render(oFiles[0])
+	if strings.Contains(spec, `"definitions"`) {
+		t.Errorf("spec should not contain definitions, got %s", spec)
+	}
+	if !strings.Contains(spec, `"$ref":"openapi_definitions.json#/definitions/Pet"`) {
+		t.Errorf("missing reference to definitions file, got %s", spec)
+	}
+	if spec := render(oFiles[1]); !strings.Contains(spec, "openapi_definitions.yaml#/definitions/Pet") {
+		t.Errorf("missing reference to YAML definitions file, got %s", spec)
+	}
+	var defs struct {
+		Definitions map[string]interface{}
+	}
+	if err := json.Unmarshal([]byte(render(oFiles[2])), &defs); err != nil {
+		t.Fatalf("failed to unmarshal definitions: %s", err)
+	}
+	if _, ok := defs.Definitions["Pet"]; !ok {
+		t.Errorf("missing Pet definition, got %v", defs.Definitions)
+	}
+}
+
+func TestNamedExamples(t *testing.T) {
+	RunHTTPDSL(t, testdata.NamedExamplesDSL)
+	oFiles, err := OpenAPIFiles(httpdesign.Root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	s := oFiles[0].SectionTemplates[0]
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	type examples map[string]struct {
+		Summary     string
+		Description string
+		Value       map[string]interface{}
+	}
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				In        string
+				XExamples examples `json:"x-examples"`
+			}
+			Responses map[string]struct {
+				Examples  map[string]map[string]interface{}
+				XExamples examples `json:"x-examples"`
+			}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("failed to unmarshal spec: %s", err)
+	}
+	op := spec.Paths["/login"]["post"]
+	if len(op.Parameters) != 1 || op.Parameters[0].In != "body" {
+		t.Fatalf("got parameters %v, expected a single body parameter", op.Parameters)
+	}
+	if ex := op.Parameters[0].XExamples["expired token"]; ex.Summary != "expired token" || ex.Value["value"] != "xyz" {
+		t.Errorf("got body examples %v, expected expired token example", op.Parameters[0].XExamples)
+	}
+	ok := op.Responses["200"]
+	if len(ok.XExamples) != 2 {
+		t.Errorf("got %d response examples, expected 2", len(ok.XExamples))
+	}
+	if ex := ok.XExamples["expiring session"]; ex.Description != "The session expires in 10 seconds." || ex.Value["ttl"] != float64(10) {
+		t.Errorf("got response example %v, expected expiring session example", ex)
+	}
+	if ex := ok.Examples["application/json"]; ex["id"] != "s1" {
+		t.Errorf("got response example %v, expected first example", ex)
+	}
+	if ex := op.Responses["401"].XExamples["expired token"]; ex.Value["reason"] != "token expired" {
+		t.Errorf("got error examples %v, expected expired token example", op.Responses["401"].XExamples)
+	}
+}
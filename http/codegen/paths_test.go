@@ -18,6 +18,7 @@ func TestPaths(t *testing.T) {
 		{"single-path-one-param", testdata.PathOneParamDSL, testdata.PathOneParamCode},
 		{"single-path-multiple-params", testdata.PathMultipleParamsDSL, testdata.PathMultipleParamsCode},
 		{"alternative-paths", testdata.PathAlternativesDSL, testdata.PathAlternativesCode},
+		{"catch-all", testdata.PathCatchAllDSL, testdata.PathCatchAllCode},
 		{"path-with-string-slice-param", testdata.PathStringSliceParamDSL, testdata.PathStringSliceParamCode},
 		{"path-with-int-slice-param", testdata.PathIntSliceParamDSL, testdata.PathIntSliceParamCode},
 		{"path-with-int32-slice-param", testdata.PathInt32SliceParamDSL, testdata.PathInt32SliceParamCode},
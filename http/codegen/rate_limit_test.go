@@ -0,0 +1,19 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestServerRateLimit(t *testing.T) {
+	cases := []*testCase{
+		{"rate-limit", testdata.RateLimitDSL, []*sectionExpectation{
+			{"server-rate-limit", &testdata.RateLimitServerCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
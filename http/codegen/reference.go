@@ -0,0 +1,591 @@
+package codegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+	httpdesign "goa.design/goa/http/design"
+)
+
+type (
+	// ReferenceData contains the data used to render the API reference.
+	ReferenceData struct {
+		// Title is the API title.
+		Title string
+		// Description is the API description.
+		Description string
+		// Version is the API version.
+		Version string
+		// Servers lists the API server URLs.
+		Servers []string
+		// Services lists the API services.
+		Services []*ReferenceServiceData
+		// Types lists the user types used by the service methods sorted
+		// by name.
+		Types []*ReferenceTypeData
+	}
+
+	// ReferenceServiceData contains the data used to render the reference
+	// of a service.
+	ReferenceServiceData struct {
+		// Name is the service name.
+		Name string
+		// Description is the service description.
+		Description string
+		// Methods lists the service methods.
+		Methods []*ReferenceMethodData
+	}
+
+	// ReferenceMethodData contains the data used to render the reference
+	// of a service method.
+	ReferenceMethodData struct {
+		// Name is the method name.
+		Name string
+		// Description is the method description.
+		Description string
+		// Routes lists the HTTP routes of the method, e.g. "GET /accounts".
+		Routes []string
+		// Payload describes the method payload, nil if the method has
+		// no payload.
+		Payload *ReferenceTypeData
+		// Result describes the method result, nil if the method has no
+		// result.
+		Result *ReferenceTypeData
+		// Responses lists the HTTP responses of the method.
+		Responses []*ReferenceResponseData
+		// Errors lists the errors returned by the method.
+		Errors []*ReferenceResponseData
+	}
+
+	// ReferenceResponseData contains the data used to render a HTTP
+	// response or error of a method.
+	ReferenceResponseData struct {
+		// Name is the error name, empty for successful responses.
+		Name string
+		// Status is the HTTP status code.
+		Status int
+		// Description is the response or error description.
+		Description string
+		// Type is the name of the error type, empty for successful
+		// responses.
+		Type string
+	}
+
+	// ReferenceTypeData contains the data used to render the reference of
+	// a type.
+	ReferenceTypeData struct {
+		// Name is the type name.
+		Name string
+		// Description is the type description.
+		Description string
+		// Type is the name of the underlying type of user types, the
+		// type name otherwise.
+		Type string
+		// Attributes lists the attributes of object types.
+		Attributes []*ReferenceAttributeData
+		// Example is the JSON example of the type if any.
+		Example string
+	}
+
+	// ReferenceAttributeData contains the data used to render the
+	// reference of an object attribute.
+	ReferenceAttributeData struct {
+		// Name is the attribute name.
+		Name string
+		// Type is the name of the attribute type.
+		Type string
+		// Description is the attribute description.
+		Description string
+		// Required is true if the attribute is required.
+		Required bool
+		// Validations describes the attribute validations.
+		Validations string
+	}
+)
+
+// ReferenceFiles returns the files that render the API reference in Markdown
+// and HTML. The files are made of one section per service, per method and per
+// type so that plugins may override the templates of the sections to
+// customize the output: the sections are named "reference-header",
+// "reference-service", "reference-method", "reference-types", "reference-type"
+// and "reference-footer".
+func ReferenceFiles(root *httpdesign.RootExpr) []*codegen.File {
+	data := referenceData(root)
+	return []*codegen.File{
+		referenceFile(filepath.Join(codegen.Gendir, "docs", "reference.md"), data, referenceMarkdownTs, referenceMarkdownFuncs),
+		referenceFile(filepath.Join(codegen.Gendir, "docs", "reference.html"), data, referenceHTMLTs, referenceHTMLFuncs),
+	}
+}
+
+// referenceFile returns the file with the given path that renders the
+// reference using the given templates indexed by section name.
+func referenceFile(path string, data *ReferenceData, ts map[string]string, funcs template.FuncMap) *codegen.File {
+	sections := []*codegen.SectionTemplate{
+		{Name: "reference-header", Source: ts["reference-header"], Data: data, FuncMap: funcs},
+	}
+	for _, svc := range data.Services {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "reference-service",
+			Source:  ts["reference-service"],
+			Data:    svc,
+			FuncMap: funcs,
+		})
+		for _, m := range svc.Methods {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "reference-method",
+				Source:  ts["reference-method"],
+				Data:    m,
+				FuncMap: funcs,
+			})
+		}
+	}
+	if len(data.Types) > 0 {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "reference-types",
+			Source:  ts["reference-types"],
+			Data:    data,
+			FuncMap: funcs,
+		})
+		for _, t := range data.Types {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:    "reference-type",
+				Source:  ts["reference-type"],
+				Data:    t,
+				FuncMap: funcs,
+			})
+		}
+	}
+	sections = append(sections, &codegen.SectionTemplate{
+		Name:    "reference-footer",
+		Source:  ts["reference-footer"],
+		Data:    data,
+		FuncMap: funcs,
+	})
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// referenceData builds the data needed to render the reference of the given
+// HTTP API.
+func referenceData(root *httpdesign.RootExpr) *ReferenceData {
+	api := root.Design.API
+	data := &ReferenceData{
+		Title:       api.Title,
+		Description: api.Description,
+		Version:     api.Version,
+	}
+	if data.Title == "" {
+		data.Title = api.Name
+	}
+	for _, s := range api.Servers {
+		data.Servers = append(data.Servers, s.URL)
+	}
+	var (
+		types = make(map[string]design.UserType)
+		seen  = make(map[string]struct{})
+		walk  = func(att *design.AttributeExpr) {
+			if att == nil {
+				return
+			}
+			collectUserTypes(att.Type, func(ut design.UserType) {
+				types[ut.Name()] = ut
+			}, seen)
+		}
+	)
+	for _, svc := range root.HTTPServices {
+		sd := &ReferenceServiceData{Name: svc.Name(), Description: svc.Description()}
+		for _, e := range svc.HTTPEndpoints {
+			m := e.MethodExpr
+			md := &ReferenceMethodData{
+				Name:        m.Name,
+				Description: m.Description,
+				Payload:     referenceTypeData(m.Payload, api),
+				Result:      referenceTypeData(m.Result, api),
+			}
+			for _, r := range e.Routes {
+				for _, p := range r.FullPaths() {
+					md.Routes = append(md.Routes, r.Method+" "+p)
+				}
+			}
+			for _, r := range e.Responses {
+				md.Responses = append(md.Responses, &ReferenceResponseData{
+					Status:      r.StatusCode,
+					Description: r.Description,
+				})
+			}
+			for _, er := range e.HTTPErrors {
+				var (
+					desc = er.Response.Description
+					typ  string
+				)
+				if er.ErrorExpr != nil {
+					att := errorAttribute(er.ErrorExpr)
+					if desc == "" {
+						desc = att.Description
+					}
+					typ = referenceTypeName(att)
+					walk(att)
+				}
+				md.Errors = append(md.Errors, &ReferenceResponseData{
+					Name:        er.Name,
+					Status:      er.Response.StatusCode,
+					Description: desc,
+					Type:        typ,
+				})
+			}
+			walk(m.Payload)
+			walk(m.Result)
+			sd.Methods = append(sd.Methods, md)
+		}
+		data.Services = append(data.Services, sd)
+	}
+	names := make([]string, 0, len(types))
+	for n := range types {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	for _, n := range names {
+		ut := types[n]
+		td := referenceTypeData(ut.Attribute(), api)
+		td.Name = n
+		data.Types = append(data.Types, td)
+	}
+	return data
+}
+
+// errorAttribute returns the attribute describing the given error. The user
+// type created to wrap the errors defined with a primitive or inline type is
+// not part of the design so the wrapped attribute is returned in this case.
+func errorAttribute(e *design.ErrorExpr) *design.AttributeExpr {
+	if ut, ok := e.AttributeExpr.Type.(*design.UserTypeExpr); ok && ut.TypeName == e.Name {
+		return ut.AttributeExpr
+	}
+	return e.AttributeExpr
+}
+
+// referenceTypeData builds the reference data of the type of the given
+// attribute, nil if the attribute is nil or empty.
+func referenceTypeData(att *design.AttributeExpr, api *design.APIExpr) *ReferenceTypeData {
+	if att == nil || att.Type == design.Empty {
+		return nil
+	}
+	td := &ReferenceTypeData{
+		Name:        referenceTypeName(att),
+		Description: att.Description,
+		Type:        referenceTypeName(att),
+	}
+	if ut, ok := att.Type.(design.UserType); ok {
+		if td.Description == "" {
+			td.Description = ut.Attribute().Description
+		}
+		td.Type = referenceTypeName(ut.Attribute())
+	}
+	if obj := design.AsObject(att.Type); obj != nil {
+		for _, nat := range *obj {
+			td.Attributes = append(td.Attributes, &ReferenceAttributeData{
+				Name:        nat.Name,
+				Type:        referenceTypeName(nat.Attribute),
+				Description: nat.Attribute.Description,
+				Required:    att.IsRequired(nat.Name),
+				Validations: referenceValidations(nat.Attribute),
+			})
+		}
+	}
+	if b, err := json.MarshalIndent(att.Example(api.Random()), "", "  "); err == nil {
+		td.Example = string(b)
+	}
+	return td
+}
+
+// referenceTypeName returns the name of the type of the given attribute used
+// in the reference, the empty string if there is no type.
+func referenceTypeName(att *design.AttributeExpr) string {
+	if att == nil || att.Type == nil || att.Type == design.Empty {
+		return ""
+	}
+	if _, ok := att.Type.(design.UserType); ok {
+		return att.Type.Name()
+	}
+	return design.QualifiedTypeName(att.Type)
+}
+
+// referenceValidations returns a human readable description of the
+// validations of the given attribute.
+func referenceValidations(att *design.AttributeExpr) string {
+	v := att.Validation
+	if v == nil {
+		return ""
+	}
+	var vs []string
+	if len(v.Values) > 0 {
+		vals := make([]string, len(v.Values))
+		for i, val := range v.Values {
+			vals[i] = fmt.Sprintf("%v", val)
+		}
+		vs = append(vs, "one of "+strings.Join(vals, ", "))
+	}
+	if v.Format != "" {
+		vs = append(vs, "format "+string(v.Format))
+	}
+	if v.Pattern != "" {
+		vs = append(vs, "pattern "+v.Pattern)
+	}
+	if v.Minimum != nil {
+		vs = append(vs, fmt.Sprintf("minimum %v", *v.Minimum))
+	}
+	if v.Maximum != nil {
+		vs = append(vs, fmt.Sprintf("maximum %v", *v.Maximum))
+	}
+	if v.MinLength != nil {
+		vs = append(vs, fmt.Sprintf("minimum length %d", *v.MinLength))
+	}
+	if v.MaxLength != nil {
+		vs = append(vs, fmt.Sprintf("maximum length %d", *v.MaxLength))
+	}
+	return strings.Join(vs, ", ")
+}
+
+// referenceMarkdownFuncs are the functions used by the Markdown templates.
+var referenceMarkdownFuncs = template.FuncMap{
+	"cell": func(s string) string {
+		return strings.Replace(strings.Replace(s, "|", `\|`, -1), "\n", " ", -1)
+	},
+}
+
+// referenceHTMLFuncs are the functions used by the HTML templates.
+var referenceHTMLFuncs = template.FuncMap{
+	"anchor": func(s string) string {
+		return strings.ToLower(codegen.Goify(s, false))
+	},
+}
+
+// referenceMarkdownTs lists the Markdown templates indexed by section name.
+var referenceMarkdownTs = map[string]string{
+	"reference-header":  referenceMarkdownHeaderT,
+	"reference-service": referenceMarkdownServiceT,
+	"reference-method":  referenceMarkdownMethodT,
+	"reference-types":   referenceMarkdownTypesT,
+	"reference-type":    referenceMarkdownTypeT,
+	"reference-footer":  "",
+}
+
+// referenceHTMLTs lists the HTML templates indexed by section name.
+var referenceHTMLTs = map[string]string{
+	"reference-header":  referenceHTMLHeaderT,
+	"reference-service": referenceHTMLServiceT,
+	"reference-method":  referenceHTMLMethodT,
+	"reference-types":   referenceHTMLTypesT,
+	"reference-type":    referenceHTMLTypeT,
+	"reference-footer":  referenceHTMLFooterT,
+}
+
+// input: ReferenceData
+const referenceMarkdownHeaderT = `# {{ .Title }}{{ if .Version }} {{ .Version }}{{ end }}
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+{{- if .Servers }}
+
+Servers:
+{{ range .Servers }}
+* {{ . }}
+{{- end }}
+{{- end }}
+`
+
+// input: ReferenceServiceData
+const referenceMarkdownServiceT = `
+## Service {{ .Name }}
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+`
+
+// input: ReferenceMethodData
+const referenceMarkdownMethodT = `
+### {{ .Name }}
+{{- if .Description }}
+
+{{ .Description }}
+{{- end }}
+{{- if .Routes }}
+{{ range .Routes }}
+    {{ . }}
+{{- end }}
+{{- end }}
+{{- with .Payload }}
+
+#### Payload
+{{ template "partial-reference-markdown-type" . }}
+{{- end }}
+{{- with .Result }}
+
+#### Result
+{{ template "partial-reference-markdown-type" . }}
+{{- end }}
+{{- if .Responses }}
+
+#### Responses
+
+| Status | Description |
+|--------|-------------|
+{{- range .Responses }}
+| {{ .Status }} | {{ cell .Description }} |
+{{- end }}
+{{- end }}
+{{- if .Errors }}
+
+#### Errors
+
+| Name | Status | Description | Body |
+|------|--------|-------------|------|
+{{- range .Errors }}
+| {{ .Name }} | {{ .Status }} | {{ cell .Description }} | {{ cell .Type }} |
+{{- end }}
+{{- end }}
+` + referenceMarkdownTypePartialT
+
+// input: ReferenceData
+const referenceMarkdownTypesT = `
+## Types
+`
+
+// input: ReferenceTypeData
+const referenceMarkdownTypeT = `
+### {{ .Name }}
+{{ template "partial-reference-markdown-type" . }}
+` + referenceMarkdownTypePartialT
+
+// input: ReferenceTypeData
+const referenceMarkdownTypePartialT = `{{ define "partial-reference-markdown-type" }}
+{{- if .Description }}
+{{ .Description }}
+{{ end }}
+{{- if .Attributes }}
+| Attribute | Type | Required | Description | Validations |
+|-----------|------|----------|-------------|-------------|
+{{- range .Attributes }}
+| {{ .Name }} | {{ cell .Type }} | {{ if .Required }}yes{{ else }}no{{ end }} | {{ cell .Description }} | {{ cell .Validations }} |
+{{- end }}
+{{ else }}
+Type: {{ .Type }}
+{{ end }}
+{{- if .Example }}
+Example:
+
+` + "```json" + `
+{{ .Example }}
+` + "```" + `
+{{- end }}
+{{- end }}`
+
+// input: ReferenceData
+const referenceHTMLHeaderT = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{ html .Title }}</title>
+<style>
+body { font-family: sans-serif; max-width: 60em; margin: auto; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+pre { background: #f5f5f5; padding: 0.6em; }
+</style>
+</head>
+<body>
+<h1>{{ html .Title }}{{ if .Version }} {{ html .Version }}{{ end }}</h1>
+{{- if .Description }}
+<p>{{ html .Description }}</p>
+{{- end }}
+{{- if .Servers }}
+<ul>
+{{- range .Servers }}
+<li><code>{{ html . }}</code></li>
+{{- end }}
+</ul>
+{{- end }}
+`
+
+// input: ReferenceServiceData
+const referenceHTMLServiceT = `<h2 id="service-{{ anchor .Name }}">Service {{ html .Name }}</h2>
+{{- if .Description }}
+<p>{{ html .Description }}</p>
+{{- end }}
+`
+
+// input: ReferenceMethodData
+const referenceHTMLMethodT = `<h3>{{ html .Name }}</h3>
+{{- if .Description }}
+<p>{{ html .Description }}</p>
+{{- end }}
+{{- range .Routes }}
+<pre>{{ html . }}</pre>
+{{- end }}
+{{- with .Payload }}
+<h4>Payload</h4>
+{{ template "partial-reference-html-type" . }}
+{{- end }}
+{{- with .Result }}
+<h4>Result</h4>
+{{ template "partial-reference-html-type" . }}
+{{- end }}
+{{- if .Responses }}
+<h4>Responses</h4>
+<table>
+<tr><th>Status</th><th>Description</th></tr>
+{{- range .Responses }}
+<tr><td>{{ .Status }}</td><td>{{ html .Description }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- if .Errors }}
+<h4>Errors</h4>
+<table>
+<tr><th>Name</th><th>Status</th><th>Description</th><th>Body</th></tr>
+{{- range .Errors }}
+<tr><td>{{ html .Name }}</td><td>{{ .Status }}</td><td>{{ html .Description }}</td><td>{{ html .Type }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+` + referenceHTMLTypePartialT
+
+// input: ReferenceData
+const referenceHTMLTypesT = `<h2 id="types">Types</h2>
+`
+
+// input: ReferenceTypeData
+const referenceHTMLTypeT = `<h3 id="type-{{ anchor .Name }}">{{ html .Name }}</h3>
+{{ template "partial-reference-html-type" . }}
+` + referenceHTMLTypePartialT
+
+// input: ReferenceTypeData
+const referenceHTMLTypePartialT = `{{ define "partial-reference-html-type" }}
+{{- if .Description }}
+<p>{{ html .Description }}</p>
+{{- end }}
+{{- if .Attributes }}
+<table>
+<tr><th>Attribute</th><th>Type</th><th>Required</th><th>Description</th><th>Validations</th></tr>
+{{- range .Attributes }}
+<tr><td>{{ html .Name }}</td><td>{{ html .Type }}</td><td>{{ if .Required }}yes{{ else }}no{{ end }}</td><td>{{ html .Description }}</td><td>{{ html .Validations }}</td></tr>
+{{- end }}
+</table>
+{{- else }}
+<p>Type: <code>{{ html .Type }}</code></p>
+{{- end }}
+{{- if .Example }}
+<pre>{{ html .Example }}</pre>
+{{- end }}
+{{- end }}`
+
+// input: ReferenceData
+const referenceHTMLFooterT = `</body>
+</html>
+`
@@ -0,0 +1,109 @@
+package codegen
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestReferenceData(t *testing.T) {
+	RunHTTPDSL(t, testdata.ReferenceDSL)
+	data := referenceData(httpdesign.Root)
+	if data.Title != "test api" {
+		t.Errorf("got title %q, expected %q", data.Title, "test api")
+	}
+	if len(data.Services) != 1 {
+		t.Fatalf("got %d services, expected 1", len(data.Services))
+	}
+	svc := data.Services[0]
+	if svc.Name != "accounts" || svc.Description != "Manage accounts." {
+		t.Errorf("got service %q (%q), expected %q (%q)", svc.Name, svc.Description, "accounts", "Manage accounts.")
+	}
+	if len(svc.Methods) != 1 {
+		t.Fatalf("got %d methods, expected 1", len(svc.Methods))
+	}
+	m := svc.Methods[0]
+	if len(m.Routes) != 1 || m.Routes[0] != "GET /accounts/{id}" {
+		t.Errorf("got routes %v, expected [GET /accounts/{id}]", m.Routes)
+	}
+	if m.Payload == nil || len(m.Payload.Attributes) != 1 {
+		t.Fatalf("got payload %+v, expected one attribute", m.Payload)
+	}
+	if a := m.Payload.Attributes[0]; a.Name != "id" || a.Type != "string" || !a.Required || a.Description != "Account ID" {
+		t.Errorf("got payload attribute %+v", a)
+	}
+	if m.Result == nil || m.Result.Name != "Account" || m.Result.Description != "Account is a customer account." {
+		t.Fatalf("got result %+v, expected Account", m.Result)
+	}
+	validations := []string{"format uuid", "minimum length 1, maximum length 20"}
+	for i, a := range m.Result.Attributes {
+		if a.Validations != validations[i] {
+			t.Errorf("got validations %q for %s, expected %q", a.Validations, a.Name, validations[i])
+		}
+	}
+	if len(m.Responses) != 1 || m.Responses[0].Status != 200 {
+		t.Errorf("got responses %+v, expected one 200 response", m.Responses)
+	}
+	if len(m.Errors) != 1 {
+		t.Fatalf("got %d errors, expected 1", len(m.Errors))
+	}
+	if e := m.Errors[0]; e.Name != "not_found" || e.Status != 404 || e.Description != "Account not found" || e.Type != "string" {
+		t.Errorf("got error %+v", e)
+	}
+	if len(data.Types) != 1 || data.Types[0].Name != "Account" {
+		t.Errorf("got types %+v, expected Account", data.Types)
+	}
+}
+
+func TestReferenceFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.ReferenceDSL)
+	fs := ReferenceFiles(httpdesign.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected 2", len(fs))
+	}
+	cases := []struct {
+		Path     string
+		Expected []string
+	}{
+		{filepath.Join("gen", "docs", "reference.md"), []string{
+			"# test api",
+			"## Service accounts",
+			"### show",
+			"    GET /accounts/{id}",
+			"| id | string | yes | Account ID |  |",
+			"| not_found | 404 | Account not found | string |",
+			"## Types",
+			"### Account",
+			"| id | string | yes | Account ID | format uuid |",
+		}},
+		{filepath.Join("gen", "docs", "reference.html"), []string{
+			"<title>test api</title>",
+			`<h2 id="service-accounts">Service accounts</h2>`,
+			"<pre>GET /accounts/{id}</pre>",
+			"<tr><td>not_found</td><td>404</td><td>Account not found</td><td>string</td></tr>",
+			`<h3 id="type-account">Account</h3>`,
+			"</html>",
+		}},
+	}
+	for i, c := range cases {
+		f := fs[i]
+		if f.Path != c.Path {
+			t.Errorf("got path %q, expected %q", f.Path, c.Path)
+		}
+		var buf bytes.Buffer
+		for _, s := range f.SectionTemplates {
+			if err := s.Write(&buf); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, e := range c.Expected {
+			if !strings.Contains(buf.String(), e) {
+				t.Errorf("%s: missing %q in:\n%s", c.Path, e, buf.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestServerRoutes(t *testing.T) {
+	cases := []*testCase{
+		{"route-table", testdata.RouteTableDSL, []*sectionExpectation{
+			{"server-routes", &testdata.RouteTableCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
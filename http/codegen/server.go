@@ -2,6 +2,8 @@ package codegen
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -21,20 +23,30 @@ func ServerFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
 	for i, r := range root.HTTPServices {
 		fw[i+len(root.HTTPServices)] = serverEncodeDecode(genpkg, r)
 	}
+	for _, svc := range root.HTTPServices {
+		fw = append(fw, embeddedFiles(svc)...)
+	}
 	return fw
 }
 
+// embedDir is the name of the directory of the generated server package that
+// contains the embedded assets.
+const embedDir = "files"
+
 // server returns the files defining the HTTP server.
 func server(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
-	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "server", "server.go")
+	path := filepath.Join(codegen.ServiceGendir(svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "server", "server.go")
 	data := HTTPServices.Get(svc.Name())
 	title := fmt.Sprintf("%s HTTP server", svc.Name())
 	funcs := map[string]interface{}{"join": func(ss []string, s string) string { return strings.Join(ss, s) }}
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "server", []*codegen.ImportSpec{
 			{Path: "context"},
+			{Path: "embed"},
+			{Path: "encoding/json"},
 			{Path: "fmt"},
 			{Path: "io"},
+			{Path: "io/fs"},
 			{Path: "mime/multipart"},
 			{Path: "net/http"},
 			{Path: "sync"},
@@ -42,8 +54,10 @@ func server(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 			{Path: "github.com/gorilla/websocket"},
 			{Path: "goa.design/goa", Name: "goa"},
 			{Path: "goa.design/goa/http", Name: "goahttp"},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()), Name: data.Service.PkgName},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: data.Service.ViewsPkg},
+			{Path: "goa.design/goa/http/middleware"},
+			{Path: "goa.design/goa/http/otel", Name: "goaotel"},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()), Name: data.Service.PkgName},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: data.Service.ViewsPkg},
 		}),
 	}
 
@@ -59,9 +73,13 @@ func server(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 			})
 		}
 		if e.ServerStream != nil {
+			src := streamStructTypeT
+			if e.ServerStream.NDJSON {
+				src = ndjsonStreamStructTypeT
+			}
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "server-stream-struct-type",
-				Source: streamStructTypeT,
+				Source: src,
 				Data:   e.ServerStream,
 			})
 		}
@@ -73,24 +91,46 @@ func server(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 		Data:   data,
 		FuncMap: map[string]interface{}{
 			"streamingEndpointExists": streamingEndpointExists,
+			"binaryStreamExists":      binaryStreamExists,
 		},
 	})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-service", Source: serverServiceT, Data: data})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-use", Source: serverUseT, Data: data})
 	sections = append(sections, &codegen.SectionTemplate{Name: "server-mount", Source: serverMountT, Data: data})
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-routes", Source: serverRoutesT, Data: data})
 
 	for _, e := range data.Endpoints {
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler", Source: serverHandlerT, Data: e})
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-handler-init", Source: serverHandlerInitT, Data: e})
 	}
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-instrument", Source: serverInstrumentT, Data: data})
+	sections = append(sections, &codegen.SectionTemplate{Name: "server-access-log", Source: serverAccessLogT, Data: data})
+	if rateLimitExists(data) {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-rate-limit", Source: serverRateLimitT, Data: data})
+	}
 	for _, s := range data.FileServers {
 		sections = append(sections, &codegen.SectionTemplate{Name: "server-files", Source: fileServerT, FuncMap: funcs, Data: s})
 	}
+	if embeddedFileServerExists(data) {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:    "server-embed",
+			Source:  serverEmbedT,
+			Data:    data,
+			FuncMap: map[string]interface{}{"embeddedDirExists": embeddedDirExists},
+		})
+	}
+	if data.CORS != nil {
+		sections = append(sections, &codegen.SectionTemplate{Name: "server-cors", Source: serverCORST, Data: data})
+	}
 	for _, e := range data.Endpoints {
 		if e.ServerStream != nil {
+			sendSrc, closeSrc := streamSendT, streamCloseT
+			if e.ServerStream.NDJSON {
+				sendSrc, closeSrc = ndjsonStreamSendT, ndjsonStreamCloseT
+			}
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "server-stream-send",
-				Source: streamSendT,
+				Source: sendSrc,
 				Data:   e.ServerStream,
 			})
 			if e.Method.ViewedResult != nil {
@@ -102,9 +142,16 @@ func server(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 			}
 			sections = append(sections, &codegen.SectionTemplate{
 				Name:   "server-stream-close",
-				Source: streamCloseT,
+				Source: closeSrc,
 				Data:   e.ServerStream,
 			})
+			if !e.ServerStream.NDJSON {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "server-stream-close-error",
+					Source: streamCloseErrorT,
+					Data:   e.ServerStream,
+				})
+			}
 		}
 	}
 
@@ -114,24 +161,26 @@ func server(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
 // serverEncodeDecode returns the file defining the HTTP server encoding and
 // decoding logic.
 func serverEncodeDecode(genpkg string, svc *httpdesign.ServiceExpr) *codegen.File {
-	path := filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "server", "encode_decode.go")
+	path := filepath.Join(codegen.ServiceGendir(svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "server", "encode_decode.go")
 	data := HTTPServices.Get(svc.Name())
 	title := fmt.Sprintf("%s HTTP server encoders and decoders", svc.Name())
 	sections := []*codegen.SectionTemplate{
 		codegen.Header(title, "server", []*codegen.ImportSpec{
 			{Path: "context"},
+			{Path: "errors"},
 			{Path: "fmt"},
 			{Path: "io"},
 			{Path: "net/http"},
 			{Path: "strconv"},
 			{Path: "strings"},
 			{Path: "encoding/json"},
+			{Path: "io/ioutil"},
 			{Path: "mime/multipart"},
 			{Path: "unicode/utf8"},
 			{Path: "goa.design/goa", Name: "goa"},
 			{Path: "goa.design/goa/http", Name: "goahttp"},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()), Name: data.Service.PkgName},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: data.Service.ViewsPkg},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()), Name: data.Service.PkgName},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: data.Service.ViewsPkg},
 		}),
 	}
 
@@ -159,6 +208,13 @@ func serverEncodeDecode(genpkg string, svc *httpdesign.ServiceExpr) *codegen.Fil
 				FuncMap: transTmplFuncs(svc),
 				Data:    e.MultipartRequestDecoder,
 			})
+			if e.MultipartRequestDecoder.DefaultFuncName != "" {
+				sections = append(sections, &codegen.SectionTemplate{
+					Name:   "multipart-request-default-decoder",
+					Source: multipartRequestDefaultDecoderT,
+					Data:   e.MultipartRequestDecoder,
+				})
+			}
 		}
 
 		if len(e.Errors) > 0 {
@@ -182,16 +238,94 @@ func serverEncodeDecode(genpkg string, svc *httpdesign.ServiceExpr) *codegen.Fil
 }
 
 // streamingEndpointExists returns true if at least one of the endpoints in
-// the service defines a streaming payload or result.
+// the service streams its payload or result over a websocket connection.
 func streamingEndpointExists(sd *ServiceData) bool {
 	for _, e := range sd.Endpoints {
-		if e.ServerStream != nil || e.ClientStream != nil {
+		if e.ServerStream != nil && !e.ServerStream.NDJSON {
+			return true
+		}
+	}
+	return false
+}
+
+// binaryStreamExists returns true if at least one of the endpoints in the
+// service uses a websocket stream with binary frames.
+func binaryStreamExists(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.ServerStream != nil && e.ServerStream.Binary {
+			return true
+		}
+	}
+	return false
+}
+
+// embeddedFileServerExists returns true if at least one of the file servers of
+// the service serves embedded assets.
+func embeddedFileServerExists(sd *ServiceData) bool {
+	for _, s := range sd.FileServers {
+		if s.Embed {
+			return true
+		}
+	}
+	return false
+}
+
+// rateLimitExists returns true if at least one of the service endpoints
+// defines a rate limit.
+func rateLimitExists(sd *ServiceData) bool {
+	for _, e := range sd.Endpoints {
+		if e.Method.RateLimit != nil {
 			return true
 		}
 	}
 	return false
 }
 
+// embeddedDirExists returns true if at least one of the file servers of the
+// service serves an embedded directory.
+func embeddedDirExists(sd *ServiceData) bool {
+	for _, s := range sd.FileServers {
+		if s.Embed && s.IsDir {
+			return true
+		}
+	}
+	return false
+}
+
+// embeddedFiles returns the files that copy the assets embedded in the
+// generated server package of the given service. The assets are read from
+// disk, their existence is checked when the design is validated.
+func embeddedFiles(svc *httpdesign.ServiceExpr) []*codegen.File {
+	var (
+		fw   []*codegen.File
+		seen = make(map[string]bool)
+		dir  = filepath.Join(codegen.ServiceGendir(svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "server")
+	)
+	for _, s := range HTTPServices.Get(svc.Name()).FileServers {
+		if !s.Embed {
+			continue
+		}
+		filepath.Walk(s.FilePath, func(p string, fi os.FileInfo, err error) error {
+			if err != nil || fi.IsDir() || seen[p] {
+				return nil
+			}
+			seen[p] = true
+			content, err := ioutil.ReadFile(p)
+			if err != nil {
+				return nil
+			}
+			fw = append(fw, &codegen.File{
+				Path: filepath.Join(dir, embedDir, p),
+				SectionTemplates: []*codegen.SectionTemplate{
+					{Name: "embedded-file", Source: "{{ . }}", Data: string(content)},
+				},
+			})
+			return nil
+		})
+	}
+	return fw
+}
+
 func transTmplFuncs(s *httpdesign.ServiceExpr) map[string]interface{} {
 	return map[string]interface{}{
 		"goTypeRef": func(dt design.DataType) string {
@@ -282,6 +416,9 @@ func {{ .ServerInit }}(
 	up goahttp.Upgrader,
 	connConfigFn goahttp.ConnConfigureFunc,
 	{{- end }}
+	{{- if binaryStreamExists . }}
+	codec goahttp.StreamCodec,
+	{{- end }}
 	{{- range .Endpoints }}
 		{{- if .MultipartRequestDecoder }}
 	{{ .MultipartRequestDecoder.VarName }} {{ .MultipartRequestDecoder.FuncName }},
@@ -295,6 +432,11 @@ func {{ .ServerInit }}(
 			{"{{ $e.Method.VarName }}", "{{ .Verb }}", "{{ .Path }}"},
 				{{- end }}
 			{{- end }}
+			{{- if .CORS }}
+				{{- range .CORS.Paths }}
+			{"CORS", "OPTIONS", "{{ . }}"},
+				{{- end }}
+			{{- end }}
 			{{- range .FileServers }}
 				{{- $filepath := .FilePath }}
 				{{- range .RequestPaths }}
@@ -303,7 +445,7 @@ func {{ .ServerInit }}(
 			{{- end }}
 		},
 		{{- range .Endpoints }}
-		{{ .Method.VarName }}: {{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}dec{{ end }}, enc, eh{{ if .ServerStream }}, up, connConfigFn{{ end }}),
+		{{ .Method.VarName }}: {{ .HandlerInit }}(e.{{ .Method.VarName }}, mux, {{ if .MultipartRequestDecoder }}{{ .MultipartRequestDecoder.InitName }}(mux, {{ .MultipartRequestDecoder.VarName }}){{ else }}dec{{ end }}, enc, eh{{ if and .ServerStream (not .ServerStream.NDJSON) }}, up, connConfigFn{{ if .ServerStream.Binary }}, codec{{ end }}{{ end }}),
 		{{- end }}
 	}
 }
@@ -323,37 +465,154 @@ func (s *{{ .ServerStruct }}) Use(m func(http.Handler) http.Handler) {
 }
 `
 
+// input: ServiceData
+const serverInstrumentT = `{{ printf "Instrument wraps each server handler with the middleware returned by m for the handler service and endpoint names, for example to record metrics labeled by endpoint." | comment }}
+func (s *{{ .ServerStruct }}) Instrument(m func(service, endpoint string) func(http.Handler) http.Handler) {
+{{- range .Endpoints }}
+	s.{{ .Method.VarName }} = m({{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }})(s.{{ .Method.VarName }})
+{{- end }}
+}
+`
+
+// input: ServiceData
+const serverAccessLogT = `{{ printf "AccessLog wraps each server handler with a middleware that logs the requests using l. fields lists the fields of the log entries, see middleware.AccessLog." | comment }}
+func (s *{{ .ServerStruct }}) AccessLog(l middleware.AccessLogger, fields ...middleware.AccessLogField) {
+{{- range .Endpoints }}
+	s.{{ .Method.VarName }} = middleware.AccessLog(l, {{ printf "%q" .Method.Name }}, []string{ {{- range $i, $r := .Routes }}{{ if $i }}, {{ end }}{{ printf "%q" (printf "%s %s" $r.Verb $r.Path) }}{{ end }} }, fields...)(s.{{ .Method.VarName }})
+{{- end }}
+}
+`
+
+// input: ServiceData
+const serverRateLimitT = `{{ printf "UseRateLimiter wraps the handlers of the endpoints that define a rate limit with a middleware that enforces the limit using l. Requests that exceed the limit receive a 429 Too Many Requests response with a Retry-After header." | comment }}
+func (s *{{ .ServerStruct }}) UseRateLimiter(l goahttp.RateLimiter) {
+{{- range .Endpoints }}
+	{{- if .Method.RateLimit }}
+	s.{{ .Method.VarName }} = goahttp.RateLimit(l, {{ printf "%q" .ServiceName }}, {{ printf "%q" .Method.Name }}, {{ .Method.RateLimit.Requests }}, {{ .Method.RateLimit.Per }})(s.{{ .Method.VarName }})
+	{{- end }}
+{{- end }}
+}
+`
+
 // input: ServiceData
 const serverMountT = `{{ printf "%s configures the mux to serve the %s endpoints." .MountServer .Service.Name | comment }}
 func {{ .MountServer }}(mux goahttp.Muxer{{ if .Endpoints }}, h *{{ .ServerStruct }}{{ end }}) {
 	{{- range .Endpoints }}
-	{{ .MountHandler }}(mux, h.{{ .Method.VarName }})
+	{{ .MountHandler }}(mux, {{ if $.CORS }}{{ $.CORS.OriginHandler }}({{ end }}{{ if .Compress }}goahttp.Compress(h.{{ .Method.VarName }}, {{ $.CompressMinSize }}){{ else }}h.{{ .Method.VarName }}{{ end }}{{ if $.CORS }}){{ end }})
+	{{- end }}
+	{{- if .CORS }}
+	{{ .CORS.MountHandler }}(mux, {{ .CORS.HandlerInit }}())
 	{{- end }}
 	{{- range .FileServers }}
 		{{- if .IsDir }}
-	{{ .MountHandler }}(mux, http.FileServer(http.Dir({{ printf "%q" .FilePath }})))
+			{{- if .AcceptRanges }}
+	{{ .MountHandler }}(mux, goahttp.FileServer({{ if .Embed }}embeddedDir({{ printf "%q" .EmbedPath }}){{ else }}http.Dir({{ printf "%q" .FilePath }}){{ end }}, {{ .ChunkSize }}))
+			{{- else }}
+	{{ .MountHandler }}(mux, http.FileServer({{ if .Embed }}embeddedDir({{ printf "%q" .EmbedPath }}){{ else }}http.Dir({{ printf "%q" .FilePath }}){{ end }}))
+			{{- end }}
 		{{- else }}
 	{{ .MountHandler }}(mux, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			{{- if .AcceptRanges }}
+				{{- if .Embed }}
+			goahttp.ServeFileFS(w, r, http.FS(embeddedFS), {{ printf "%q" .EmbedPath }}, {{ .ChunkSize }})
+				{{- else }}
+			goahttp.ServeFile(w, r, {{ printf "%q" .FilePath }}, {{ .ChunkSize }})
+				{{- end }}
+			{{- else if .Embed }}
+			http.ServeFileFS(w, r, embeddedFS, {{ printf "%q" .EmbedPath }})
+			{{- else }}
 			http.ServeFile(w, r, {{ printf "%q" .FilePath }})
+			{{- end }}
 		}))
 		{{- end }}
 	{{- end }}
 }
 `
 
+// input: ServiceData
+const serverRoutesT = `{{ printf "Routes lists the routes mounted by %s with their path segments and the indices of their wildcard segments. goahttp.NewRouteMuxer uses the list to initialize its routing tree." .MountServer | comment }}
+var Routes = []*goahttp.Route{
+	{{- range .RouteTable }}
+	{Verb: {{ printf "%q" .Verb }}, Pattern: {{ printf "%q" .Path }}, Segments: {{ printf "%#v" .Segments }}{{ if .Wildcards }}, Wildcards: {{ printf "%#v" .Wildcards }}{{ end }}},
+	{{- end }}
+}
+`
+
 // input: EndpointData
 const serverHandlerT = `{{ printf "%s configures the mux to serve the %q service %q endpoint." .MountHandler .ServiceName .Method.Name | comment }}
 func {{ .MountHandler }}(mux goahttp.Muxer, h http.Handler) {
+	f := goahttp.RequestIDHandler(h)
+	{{- range .Routes }}
+	mux.Handle("{{ .Verb }}", "{{ .Path }}", f)
+	{{- end }}
+}
+`
+
+// input: ServiceData
+const serverCORST = `{{ printf "%s configures the mux to serve the CORS preflight requests made to the %s service endpoints." .CORS.MountHandler .Service.Name | comment }}
+func {{ .CORS.MountHandler }}(mux goahttp.Muxer, h http.Handler) {
+	h = {{ .CORS.OriginHandler }}(h)
 	f, ok := h.(http.HandlerFunc)
 	if !ok {
 		f = func(w http.ResponseWriter, r *http.Request) {
 			h.ServeHTTP(w, r)
 		}
 	}
-	{{- range .Routes }}
-	mux.Handle("{{ .Verb }}", "{{ .Path }}", f)
+	{{- range .CORS.Paths }}
+	mux.Handle("OPTIONS", "{{ . }}", f)
 	{{- end }}
 }
+
+{{ printf "%s creates a HTTP handler which returns a simple 200 response." .CORS.HandlerInit | comment }}
+func {{ .CORS.HandlerInit }}() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+{{ printf "%s applies the CORS response headers corresponding to the request origin for the %s service." .CORS.OriginHandler .Service.Name | comment }}
+func {{ .CORS.OriginHandler }}(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if !goahttp.MatchOrigin(origin, {{ range .CORS.Origins }}{{ printf "%q" . }}, {{ end }}) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		if acrm := r.Header.Get("Access-Control-Request-Method"); r.Method == "OPTIONS" && acrm != "" {
+			// We are handling a preflight request
+			w.Header().Set("Access-Control-Allow-Methods", {{ printf "%q" .CORS.Methods }})
+			{{- if .CORS.Headers }}
+			w.Header().Set("Access-Control-Allow-Headers", {{ printf "%q" .CORS.Headers }})
+			{{- end }}
+			{{- if .CORS.MaxAge }}
+			w.Header().Set("Access-Control-Max-Age", {{ printf "%q" .CORS.MaxAge }})
+			{{- end }}
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+`
+
+// input: ServiceData
+const serverEmbedT = `{{ printf "embeddedFS contains the static assets served by the %s service." .Service.Name | comment }}
+//
+//go:embed{{ range .FileServers }}{{ if .Embed }} {{ .EmbedPath }}{{ end }}{{ end }}
+var embeddedFS embed.FS
+{{- if embeddedDirExists . }}
+
+{{ comment "embeddedDir returns the embedded file system rooted at the given directory." }}
+func embeddedDir(dir string) http.FileSystem {
+	sub, err := fs.Sub(embeddedFS, dir)
+	if err != nil {
+		panic(err) // bug: embedded paths are validated by the design
+	}
+	return http.FS(sub)
+}
+{{- end }}
 `
 
 // input: FileServerData
@@ -373,9 +632,12 @@ func {{ .HandlerInit }}(
 	dec func(*http.Request) goahttp.Decoder,
 	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
 	eh func(context.Context, http.ResponseWriter, error),
-	{{- if .ServerStream }}
+	{{- if and .ServerStream (not .ServerStream.NDJSON) }}
 	up goahttp.Upgrader,
 	connConfigFn goahttp.ConnConfigureFunc,
+		{{- if .ServerStream.Binary }}
+	codec goahttp.StreamCodec,
+		{{- end }}
 	{{- end }}
 ) http.Handler {
 	var (
@@ -392,26 +654,87 @@ func {{ .HandlerInit }}(
 			{{- end }}
 		encodeResponse = {{ .ResponseEncoder }}(enc)
 		{{- end }}
-		encodeError    = {{ if .Errors }}{{ .ErrorEncoder }}{{ else }}goahttp.ErrorEncoder{{ end }}(enc)
+		encodeError    = {{ if .Errors }}{{ .ErrorEncoder }}(enc){{ else if .ProblemErrors }}goahttp.ProblemErrorEncoder(){{ else }}goahttp.ErrorEncoder(enc){{ end }}
+		{{- if and .Method.ViewedResult (not .Method.ViewedResult.ViewName) }}
+		views          = []string{ {{- range $i, $v := .Method.ViewedResult.Views }}{{ if $i }}, {{ end }}{{ printf "%q" $v.Name }}{{ end }} }
+		{{- end }}
+		{{- if .FieldsParam }}
+		fields         = []string{ {{- range $i, $f := .Fields }}{{ if $i }}, {{ end }}{{ printf "%q" $f }}{{ end }} }
+		{{- end }}
 	)
+	{{- if and .ServerStream .ServerStream.Compression }}
+	up = goahttp.CompressionUpgrader(up)
+	{{- end }}
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	{{- if .MaxBodySize }}
+		r.Body = http.MaxBytesReader(w, r.Body, {{ .MaxBodySize }})
+	{{- end }}
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
 		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+		defer goahttp.Recover(ctx, w, {{ printf "%q" .PanicErrorName }}, encodeError, eh)
+	{{- if .Tracing }}
+		ctx, span := goaotel.StartServerSpan(ctx, r, {{ printf "%q" .Tracing.SpanName }})
+		defer span.End()
+	{{- end }}
+	{{- if and .Method.Timeout (not .ServerStream) }}
+		ctx, cancel := context.WithTimeout(ctx, {{ .Method.Timeout }})
+		defer cancel()
+	{{- end }}
+	{{- if and .Method.ViewedResult (not .Method.ViewedResult.ViewName) }}
+		viewCtx, viewErr := goahttp.NegotiateView(ctx, r, views)
+		if viewErr != nil {
+		{{- if .Tracing }}
+			goaotel.RecordError(span, viewErr)
+		{{- end }}
+			if err := encodeError(ctx, w, viewErr); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		ctx = viewCtx
+	{{- end }}
+	{{- if .FieldsParam }}
+		ctx, err := goahttp.WithFields(ctx, r.URL.Query().Get({{ printf "%q" .FieldsParam }}), fields)
+		if err != nil {
+		{{- if .Tracing }}
+			goaotel.RecordError(span, err)
+		{{- end }}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+	{{- end }}
 
 	{{- if .Payload.Ref }}
 		payload, err := decodeRequest(r)
 		if err != nil {
+		{{- if .Tracing }}
+			goaotel.RecordError(span, err)
+		{{- end }}
 			eh(ctx, w, err)
 			return
 		}
+		{{- if and .Tracing .Tracing.Attributes }}
+		if p, ok := payload.({{ .Payload.Ref }}); ok {
+			{{- range .Tracing.Attributes }}
+			goaotel.SetAttribute(span, {{ printf "%q" .Key }}, p.{{ .FieldName }})
+			{{- end }}
+		}
+		{{- end }}
 	{{- end }}
 
 	{{ if .ServerStream }}
 		v := &{{ .ServicePkgName }}.{{ .Method.ServerStream.EndpointStruct }}{
 			Stream: &{{ .ServerStream.VarName }}{
+			{{- if not .ServerStream.NDJSON }}
 				upgrader: up,
 				connConfigFn: connConfigFn,
+			{{- end }}
+			{{- if .ServerStream.Binary }}
+				codec: codec,
+			{{- end }}
 				w: w,
 				r: r,
 			},
@@ -419,16 +742,39 @@ func {{ .HandlerInit }}(
 			Payload: payload.({{ .Payload.Ref }}),
 		{{- end }}
 		}
-		_, err = endpoint(ctx, v)
+		_, err {{ if not .Payload.Ref }}:{{ end }}= endpoint(ctx, v)
+	{{- else if .Method.SkipRequestBodyEncodeDecode }}
+		data := &{{ .ServicePkgName }}.{{ .Method.RequestStruct }}{ {{ if .Payload.Ref }}Payload: payload.({{ .Payload.Ref }}), {{ end }}Body: r.Body}
+		res, err := endpoint(ctx, data)
 	{{- else }}
 		res, err := endpoint(ctx, {{ if .Payload.Ref }}payload{{ else }}nil{{ end }})
 	{{- end }}
 
 		if err != nil {
+			{{- if .Tracing }}
+			goaotel.RecordError(span, err)
+			{{- end }}
 			{{- if .ServerStream }}
+				{{- if .ServerStream.NDJSON }}
+			if v.Stream.(*{{ .ServerStream.VarName }}).started {
+				// The response status and headers were already
+				// written, the error cannot be sent to the client.
+				eh(ctx, w, err)
+				return
+			}
+				{{- else }}
 			if _, ok := err.(websocket.HandshakeError); ok {
 				return
 			}
+			if s := v.Stream.(*{{ .ServerStream.VarName }}); s.conn != nil {
+				// The connection was upgraded, report the error with a
+				// websocket close control message.
+				if err := s.closeWithError(err); err != nil {
+					eh(ctx, w, err)
+				}
+				return
+			}
+				{{- end }}
 			{{- end }}
 			if err := encodeError(ctx, w, err); err != nil {
 				eh(ctx, w, err)
@@ -459,6 +805,12 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 {{- if .MultipartRequestDecoder }}
 		var payload {{ .Payload.Ref }}
 		if err := decoder(r).Decode(&payload); err != nil {
+		{{- if .MaxBodySize }}
+			var mbe *http.MaxBytesError
+			if errors.As(err, &mbe) {
+				return nil, goahttp.ErrRequestBodyTooLarge({{ .MaxBodySize }})
+			}
+		{{- end }}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
 {{- else if .Payload.Request.ServerBody }}
@@ -466,25 +818,48 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 			body {{ .Payload.Request.ServerBody.VarName }}
 			err  error
 		)
+		{{- if .Consumes }}
+		switch ct := goahttp.RequestContentType(r, {{ printf "%q" .Consumes.Default }}); ct {
+			{{- if .Consumes.Form }}
+		case {{ range $i, $c := .Consumes.Form }}{{ if $i }}, {{ end }}{{ printf "%q" $c }}{{ end }}:
+			err = goahttp.FormDecoder(r).Decode(&body)
+			{{- end }}
+			{{- if .Consumes.Decoder }}
+		case {{ range $i, $c := .Consumes.Decoder }}{{ if $i }}, {{ end }}{{ printf "%q" $c }}{{ end }}:
+			err = decoder(r).Decode(&body)
+			{{- end }}
+		default:
+			return nil, goahttp.ErrUnsupportedMediaType(ct{{ range .Consumes.All }}, {{ printf "%q" . }}{{ end }})
+		}
+		{{- else }}
 		err = decoder(r).Decode(&body)
+		{{- end }}
 		if err != nil {
 			if err == io.EOF {
 				return nil, goa.MissingPayloadError()
 			}
+		{{- if .MaxBodySize }}
+			var mbe *http.MaxBytesError
+			if errors.As(err, &mbe) {
+				return nil, goahttp.ErrRequestBodyTooLarge({{ .MaxBodySize }})
+			}
+		{{- end }}
 			return nil, goa.DecodePayloadError(err.Error())
 		}
 		{{- if .Payload.Request.ServerBody.ValidateRef }}
 		{{ .Payload.Request.ServerBody.ValidateRef }}
+		{{- if not .Payload.Request.AggregateValidation }}
 		if err != nil {
 			return nil, err
 		}
 		{{- end }}
+		{{- end }}
 {{- end }}
 {{- if not .MultipartRequestDecoder }}
 	{{- template "request_params_headers" .Payload.Request }}
 	{{- if .Payload.Request.MustValidate }}
 		if err != nil {
-			return nil, err
+			return nil, {{ if .Payload.Request.AggregateValidation }}goa.AggregateErrors(err){{ else }}err{{ end }}
 		}
 	{{- end }}
 	{{- if .Payload.Request.PayloadInit }}
@@ -526,7 +901,7 @@ func {{ .RequestDecoder }}(mux goahttp.Muxer, decoder func(*http.Request) goahtt
 
 // input: RequestData
 const requestParamsHeadersT = `{{- define "request_params_headers" }}
-{{- if or .PathParams .QueryParams .Headers }}
+{{- if or .PathParams .QueryParams .Headers .Cookies }}
 {{- if .ServerBody }}{{/* we want a newline only if there was code before */}}
 {{ end }}
 		var (
@@ -539,6 +914,9 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{- range .Headers }}
 			{{ .VarName }} {{ .TypeRef }}
 		{{- end }}
+		{{- range .Cookies }}
+			{{ .VarName }} {{ .TypeRef }}
+		{{- end }}
 		{{- if not .ServerBody }}
 		{{- if .MustValidate }}
 			err error
@@ -584,7 +962,7 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{- end }}
 
 	{{- else if .StringSlice }}
-		{{ .VarName }} = r.URL.Query()["{{ .Name }}"]
+		{{ .VarName }} = {{ if .Delimiter }}goahttp.SplitQueryParam(r.URL.Query(), {{ printf "%q" .Name }}, {{ printf "%q" .Delimiter }}){{ else }}r.URL.Query()["{{ .Name }}"]{{ end }}
 		{{- if .Required }}
 		if {{ .VarName }} == nil {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -597,10 +975,10 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 
 	{{- else if .Slice }}
 	{
-		{{ .VarName }}Raw := r.URL.Query()["{{ .Name }}"]
+		{{ .VarName }}Raw := {{ if .Delimiter }}goahttp.SplitQueryParam(r.URL.Query(), {{ printf "%q" .Name }}, {{ printf "%q" .Delimiter }}){{ else }}r.URL.Query()["{{ .Name }}"]{{ end }}
 		{{- if .Required }}
 		if {{ .VarName }}Raw == nil {
-			return goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
+			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
 		}
 		{{- else if .DefaultValue }}
 		if {{ .VarName }}Raw == nil {
@@ -619,7 +997,7 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 	}
 
 	{{- else if .MapStringSlice }}
-		{{ .VarName }} = r.URL.Query()
+		{{ .VarName }} = {{ if .DeepObject }}goahttp.DeepObjectQueryParam(r.URL.Query(), {{ printf "%q" .Name }}){{ else }}r.URL.Query(){{ end }}
 		{{- if .Required }}
 		if len({{ .VarName }}) == 0 {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -632,7 +1010,7 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 
 	{{- else if .Map }}
 	{
-		{{ .VarName }}Raw := r.URL.Query()
+		{{ .VarName }}Raw := {{ if .DeepObject }}goahttp.DeepObjectQueryParam(r.URL.Query(), {{ printf "%q" .Name }}){{ else }}r.URL.Query(){{ end }}
 		{{- if .Required }}
 		if len({{ .VarName }}Raw) == 0 {
 			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "query string"))
@@ -799,6 +1177,40 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		{{ .Validate }}
 	{{- end }}
 {{- end }}
+
+{{- range .Cookies }}
+	{
+		{{ .VarName }}Raw := ""
+		if cookie, err2 := r.Cookie("{{ .Name }}"); err2 == nil {
+			{{ .VarName }}Raw = cookie.Value
+		}
+		{{- if .Required }}
+		if {{ .VarName }}Raw == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("{{ .Name }}", "cookie"))
+		}
+		{{- else if .DefaultValue }}
+		if {{ .VarName }}Raw == "" {
+			{{ .VarName }} = {{ if eq .Type.Name "string" }}{{ printf "%q" .DefaultValue }}{{ else }}{{ printf "%#v" .DefaultValue }}{{ end }}
+		}
+		{{- end }}
+
+		{{- if .DefaultValue }}else {
+		{{- else if not .Required }}
+		if {{ .VarName }}Raw != "" {
+		{{- end }}
+		{{- if (or (eq .Type.Name "string") (eq .Type.Name "any")) }}
+			{{ .VarName }} = {{ if and (eq .Type.Name "string") .Pointer }}&{{ end }}{{ .VarName }}Raw
+		{{- else }}
+		{{- template "type_conversion" . }}
+		{{- end }}
+		{{- if or .DefaultValue (not .Required) }}
+		}
+		{{- end }}
+	}
+	{{- if .Validate }}
+		{{ .Validate }}
+	{{- end }}
+{{- end }}
 {{- end }}
 {{- end }}
 
@@ -877,7 +1289,75 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 	}
 {{- end }}
 
-{{- define "type_conversion" }}
+{{- define "slice_item_conversion" }}
+		{{- if eq .Type.ElemType.Type.Name "string" }}
+			{{ .VarName }}[i] = rv
+		{{- else if eq .Type.ElemType.Type.Name "bytes" }}
+			{{ .VarName }}[i] = []byte(rv)
+		{{- else if eq .Type.ElemType.Type.Name "int" }}
+			v, err2 := strconv.ParseInt(rv, 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of integers"))
+			}
+			{{ .VarName }}[i] = int(v)
+		{{- else if eq .Type.ElemType.Type.Name "int32" }}
+			v, err2 := strconv.ParseInt(rv, 10, 32)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of integers"))
+			}
+			{{ .VarName }}[i] = int32(v)
+		{{- else if eq .Type.ElemType.Type.Name "int64" }}
+			v, err2 := strconv.ParseInt(rv, 10, 64)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of integers"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "uint" }}
+			v, err2 := strconv.ParseUint(rv, 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of unsigned integers"))
+			}
+			{{ .VarName }}[i] = uint(v)
+		{{- else if eq .Type.ElemType.Type.Name "uint32" }}
+			v, err2 := strconv.ParseUint(rv, 10, 32)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of unsigned integers"))
+			}
+			{{ .VarName }}[i] = int32(v)
+		{{- else if eq .Type.ElemType.Type.Name "uint64" }}
+			v, err2 := strconv.ParseUint(rv, 10, 64)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of unsigned integers"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "float32" }}
+			v, err2 := strconv.ParseFloat(rv, 32)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of floats"))
+			}
+			{{ .VarName }}[i] = float32(v)
+		{{- else if eq .Type.ElemType.Type.Name "float64" }}
+			v, err2 := strconv.ParseFloat(rv, 64)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of floats"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "boolean" }}
+			v, err2 := strconv.ParseBool(rv)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of booleans"))
+			}
+			{{ .VarName }}[i] = v
+		{{- else if eq .Type.ElemType.Type.Name "any" }}
+			{{ .VarName }}[i] = rv
+		{{- else }}
+			// unsupported slice type {{ .Type.ElemType.Type.Name }} for var {{ .VarName }}
+		{{- end }}
+{{- end }}
+` + typeConversionT
+
+// input: HeaderData, ParamData or CookieData
+const typeConversionT = `{{- define "type_conversion" }}
 	{{- if eq .Type.Name "bytes" }}
 		{{ .VarName }} = []byte({{.VarName}}Raw)
 	{{- else if eq .Type.Name "int" }}
@@ -963,79 +1443,20 @@ const requestParamsHeadersT = `{{- define "request_params_headers" }}
 		// unsupported type {{ .Type.Name }} for var {{ .VarName }}
 	{{- end }}
 {{- end }}
-{{- define "slice_item_conversion" }}
-		{{- if eq .Type.ElemType.Type.Name "string" }}
-			{{ .VarName }}[i] = rv
-		{{- else if eq .Type.ElemType.Type.Name "bytes" }}
-			{{ .VarName }}[i] = []byte(rv)
-		{{- else if eq .Type.ElemType.Type.Name "int" }}
-			v, err2 := strconv.ParseInt(rv, 10, strconv.IntSize)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of integers"))
-			}
-			{{ .VarName }}[i] = int(v)
-		{{- else if eq .Type.ElemType.Type.Name "int32" }}
-			v, err2 := strconv.ParseInt(rv, 10, 32)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of integers"))
-			}
-			{{ .VarName }}[i] = int32(v)
-		{{- else if eq .Type.ElemType.Type.Name "int64" }}
-			v, err2 := strconv.ParseInt(rv, 10, 64)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of integers"))
-			}
-			{{ .VarName }}[i] = v
-		{{- else if eq .Type.ElemType.Type.Name "uint" }}
-			v, err2 := strconv.ParseUint(rv, 10, strconv.IntSize)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of unsigned integers"))
-			}
-			{{ .VarName }}[i] = uint(v)
-		{{- else if eq .Type.ElemType.Type.Name "uint32" }}
-			v, err2 := strconv.ParseUint(rv, 10, 32)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of unsigned integers"))
-			}
-			{{ .VarName }}[i] = int32(v)
-		{{- else if eq .Type.ElemType.Type.Name "uint64" }}
-			v, err2 := strconv.ParseUint(rv, 10, 64)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of unsigned integers"))
-			}
-			{{ .VarName }}[i] = v
-		{{- else if eq .Type.ElemType.Type.Name "float32" }}
-			v, err2 := strconv.ParseFloat(rv, 32)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of floats"))
-			}
-			{{ .VarName }}[i] = float32(v)
-		{{- else if eq .Type.ElemType.Type.Name "float64" }}
-			v, err2 := strconv.ParseFloat(rv, 64)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of floats"))
-			}
-			{{ .VarName }}[i] = v
-		{{- else if eq .Type.ElemType.Type.Name "boolean" }}
-			v, err2 := strconv.ParseBool(rv)
-			if err2 != nil {
-				err = goa.MergeErrors(err, goa.InvalidFieldTypeError({{ printf "%q" .VarName }}, {{ .VarName}}Raw, "array of booleans"))
-			}
-			{{ .VarName }}[i] = v
-		{{- else if eq .Type.ElemType.Type.Name "any" }}
-			{{ .VarName }}[i] = rv
-		{{- else }}
-			// unsupported slice type {{ .Type.ElemType.Type.Name }} for var {{ .VarName }}
-		{{- end }}
-{{- end }}
 `
 
 // input: EndpointData
 const responseEncoderT = `{{ printf "%s returns an encoder for responses returned by the %s %s endpoint." .ResponseEncoder .ServiceName .Method.Name | comment }}
 func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
 	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+	{{- if .Method.SkipResponseBodyEncodeDecode }}
+		o := v.(*{{ .ServicePkgName }}.{{ .Method.ResponseStruct }})
+		defer o.Body.Close()
+	{{- end }}
 	{{- if and .Result.Ref .NeedServerResponse }}
-		{{- if .Method.ViewedResult }}
+		{{- if .Method.SkipResponseBodyEncodeDecode }}
+		res := o.Result
+		{{- else if .Method.ViewedResult }}
 		res := v.({{ .Method.ViewedResult.FullRef }})
 			{{- if not .Method.ViewedResult.ViewName }}
 		w.Header().Set("goa-view", res.View)
@@ -1052,8 +1473,23 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 			{{- end }}
 			{{- end -}}
 			{{ template "response" . }}
-			{{- if .ServerBody }}
+			{{- if and .ServerBody $.FieldsParam }}
+			selected, err := goahttp.SelectFields(ctx, body)
+			if err != nil {
+				return err
+			}
+			return enc.Encode(selected)
+			{{- else if .ServerBody }}
 			return enc.Encode(body)
+			{{- else if and $.Method.SkipResponseBodyEncodeDecode .Trailers }}
+			if _, err := io.Copy(w, o.Body); err != nil {
+				return err
+			}
+			{{- template "trailers" . }}
+			return nil
+			{{- else if $.Method.SkipResponseBodyEncodeDecode }}
+			_, err := io.Copy(w, o.Body)
+			return err
 			{{- else }}
 			return nil
 			{{- end }}
@@ -1068,7 +1504,12 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 
 		{{- with (index .Result.Responses 0) }}
 		w.WriteHeader({{ .StatusCode }})
+			{{- if $.Method.SkipResponseBodyEncodeDecode }}
+		_, err := io.Copy(w, o.Body)
+		return err
+			{{- else }}
 		return nil
+			{{- end }}
 
 		{{- end }}
 
@@ -1080,7 +1521,7 @@ func {{ .ResponseEncoder }}(encoder func(context.Context, http.ResponseWriter) g
 // input: EndpointData
 const errorEncoderT = `{{ printf "%s returns an encoder for errors returned by the %s %s endpoint." .ErrorEncoder .Method.Name .ServiceName | comment }}
 func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
-	encodeError := goahttp.ErrorEncoder(encoder)
+	encodeError := {{ if .ProblemErrors }}goahttp.ProblemErrorEncoder(){{ else }}goahttp.ErrorEncoder(encoder){{ end }}
 	return func(ctx context.Context, w http.ResponseWriter, v error) error {
 		en, ok := v.(ErrorNamer)
 		if !ok {
@@ -1090,6 +1531,9 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 	{{- range $gerr := .Errors }}
 	{{- range $err := .Errors }}
 		case {{ printf "%q" .Name }}:
+			{{- if $.ProblemErrors }}
+			return goahttp.EncodeProblem(w, goahttp.NewProblem({{ printf "%q" .Name }}, {{ $gerr.StatusCode }}, v))
+			{{- else }}
 			res := v.({{ $err.Ref }})
 			{{- with .Response}}
 				{{- template "response" . }}
@@ -1097,6 +1541,7 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 				return enc.Encode(body)
 				{{- end }}
 			{{- end }}
+			{{- end }}
 	{{- end }}
 	{{- end }}
 		default:
@@ -1109,6 +1554,9 @@ func {{ .ErrorEncoder }}(encoder func(context.Context, http.ResponseWriter) goah
 // input: ResponseData
 const responseT = `{{ define "response" -}}
 	{{- if .ServerBody }}
+		{{- if .Produces }}
+	ctx = context.WithValue(ctx, goahttp.AcceptTypeKey, goahttp.NegotiateContentType(ctx, {{ range .Produces }}{{ printf "%q" . }}, {{ end }}))
+		{{- end }}
 	enc := encoder(ctx, w)
 	{{- end }}
 	{{- if .ServerBody }}
@@ -1119,17 +1567,18 @@ const responseT = `{{ define "response" -}}
 		{{- end }}
 	{{- end }}
 	{{- range .Headers }}
+		{{- $byValue := not (or (not .DefaultValue) .Pointer .Slice) }}
 		{{- $initDef := and (or .Pointer .Slice) .DefaultValue (not $.TagName) }}
-		{{- $checkNil := and (or (not .Required) $initDef) (not $.TagName) }}
+		{{- $checkNil := and (or (not .Required) $initDef) (not $.TagName) (not $byValue) }}
 		{{- if $checkNil }}
 	if res.{{ if $.ViewedResult }}Projected.{{ end }}{{ .FieldName }} != nil {
 		{{- end }}
 
 		{{- if eq .Type.Name "string" }}
-	w.Header().Set("{{ .Name }}", {{ if or (not .Required) $.ViewedResult }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }})
+	w.Header().Set("{{ .Name }}", {{ if .EnumType }}{{ .TypeName }}({{ end }}{{ if and (or (not .Required) $.ViewedResult) (not $byValue) }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }}{{ if .EnumType }}){{ end }})
 		{{- else }}
-	val := res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }}
-	{{ template "header_conversion" (headerConversionData .Type (printf "%ss" .VarName) .Required "val") }}
+	val := {{ if .EnumType }}{{ if and (not .Required) (not $byValue) }}(*{{ .TypeName }}){{ else }}{{ .TypeName }}{{ end }}({{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }}{{ if .EnumType }}){{ end }}
+	{{ template "header_conversion" (headerConversionData .Type (printf "%ss" .VarName) (or .Required $byValue) "val") }}
 	w.Header().Set("{{ .Name }}", {{ .VarName }}s)
 		{{- end }}
 
@@ -1144,12 +1593,67 @@ const responseT = `{{ define "response" -}}
 
 	{{- end }}
 
+	{{- range .ConstantHeaders }}
+	w.Header().Set({{ printf "%q" .Name }}, {{ printf "%q" .Value }})
+	{{- end }}
+
+	{{- range .Cookies }}
+		{{- $byValue := not (or (not .DefaultValue) .Pointer) }}
+		{{- $checkNil := and (or (not .Required) $.ViewedResult) (not $byValue) }}
+		{{- if $checkNil }}
+	if res{{ if $.ViewedResult }}.Projected{{ end }}.{{ .FieldName }} != nil {
+		{{- end }}
+
+		{{- if eq .Type.Name "string" }}
+	{{ .VarName }} := {{ if .EnumType }}{{ .TypeName }}({{ end }}{{ if $checkNil }}*{{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }}{{ if .EnumType }}){{ end }}
+		{{- else }}
+	{{ .VarName }}Raw := {{ if .EnumType }}{{ if $checkNil }}(*{{ .TypeName }}){{ else }}{{ .TypeName }}{{ end }}({{ end }}res{{ if $.ViewedResult }}.Projected{{ end }}{{ if .FieldName }}.{{ .FieldName }}{{ end }}{{ if .EnumType }}){{ end }}
+	{{ template "header_conversion" (headerConversionData .Type .VarName (not $checkNil) (printf "%sRaw" .VarName)) }}
+		{{- end }}
+	http.SetCookie(w, &http.Cookie{
+		Name:  {{ printf "%q" .Name }},
+		Value: {{ .VarName }},
+		{{- if .MaxAge }}
+		MaxAge: {{ .MaxAge }},
+		{{- end }}
+		{{- if .Secure }}
+		Secure: true,
+		{{- end }}
+		{{- if .HTTPOnly }}
+		HttpOnly: true,
+		{{- end }}
+	})
+
+		{{- if $checkNil }}
+	}
+		{{- end }}
+
+	{{- end }}
+
+	{{- range .Trailers }}
+	w.Header().Add("Trailer", {{ printf "%q" .Name }})
+	{{- end }}
+
 	{{- if .ErrorHeader }}
 	w.Header().Set("goa-error", {{ printf "%q" .ErrorHeader }})
 	{{- end }}
 	w.WriteHeader({{ .StatusCode }})
 {{- end }}
 
+{{- define "trailers" }}
+	{{- range .Trailers }}
+	if res.{{ .FieldName }} != nil {
+		{{- if eq .Type.Name "string" }}
+		w.Header().Set({{ printf "%q" .Name }}, {{ if .EnumType }}{{ .TypeName }}({{ end }}{{ if .Pointer }}*{{ end }}res.{{ .FieldName }}{{ if .EnumType }}){{ end }})
+		{{- else }}
+		val := {{ if .EnumType }}{{ if .Pointer }}(*{{ .TypeName }}){{ else }}{{ .TypeName }}{{ end }}({{ end }}res.{{ .FieldName }}{{ if .EnumType }}){{ end }}
+		{{ template "header_conversion" (headerConversionData .Type (printf "%ss" .VarName) (not .Pointer) "val") }}
+		w.Header().Set({{ printf "%q" .Name }}, {{ .VarName }}s)
+		{{- end }}
+	}
+	{{- end }}
+{{- end }}
+
 {{- define "header_conversion" }}
 	{{- if eq .Type.Name "boolean" -}}
 		{{ .VarName }} := strconv.FormatBool({{ if not .Required }}*{{ end }}{{ .Target }})
@@ -1194,15 +1698,93 @@ const responseT = `{{ define "response" -}}
 
 // input: multipartData
 const multipartRequestDecoderTypeT = `{{ printf "%s is the type to decode multipart request for the %q service %q endpoint." .FuncName .ServiceName .MethodName | comment }}
-type {{ .FuncName }} func(*multipart.Reader, *{{ .Payload.Ref }}) error
+type {{ .FuncName }} func({{ if .Streaming }}*goahttp.MultipartReader{{ else }}*multipart.Reader{{ end }}, *{{ .Payload.Ref }}) error
 `
 
+// input: multipartData
+const multipartRequestDefaultDecoderT = `{{ printf "%s decodes the multipart request for the %q service %q endpoint by mapping the form fields and files to the payload attributes with the same name. It is used when no user decoder function is provided." .DefaultFuncName .ServiceName .MethodName | comment }}
+func {{ .DefaultFuncName }}(mr {{ if .Streaming }}*goahttp.MultipartReader{{ else }}*multipart.Reader{{ end }}, p *{{ .Payload.Ref }}) error {
+	var (
+		payload = {{ .PayloadInit }}
+		err     error
+		{{- range .Parts }}{{ if .Required }}
+		{{ .VarName }}Found bool
+		{{- end }}{{ end }}
+	)
+	for {
+		part, err2 := mr.NextPart()
+		if err2 == io.EOF {
+			break
+		}
+		if err2 != nil {
+			return err2
+		}
+		switch part.FormName() {
+		{{- range .Parts }}
+		case {{ printf "%q" .Name }}:
+			{{- if .JSON }}
+			if err2 := json.NewDecoder(part).Decode(&payload.{{ .FieldName }}); err2 != nil {
+				err = goa.MergeErrors(err, goa.DecodePayloadError(err2.Error()))
+			}
+			{{- else }}
+			raw, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return err2
+			}
+				{{- if .Elem }}
+					{{- template "multipart_part_conversion" .Elem }}
+			payload.{{ .FieldName }} = append(payload.{{ .FieldName }}, {{ .Elem.VarName }})
+				{{- else }}
+					{{- template "multipart_part_conversion" . }}
+			payload.{{ .FieldName }} = {{ .VarName }}
+				{{- end }}
+			{{- end }}
+			{{- if .Required }}
+			{{ .VarName }}Found = true
+			{{- end }}
+		{{- end }}
+		}
+	}
+	{{- range .Parts }}{{ if .Required }}
+	if !{{ .VarName }}Found {
+		err = goa.MergeErrors(err, goa.MissingFieldError({{ printf "%q" .Name }}, "multipart request"))
+	}
+	{{- end }}{{ end }}
+	if err != nil {
+		return err
+	}
+	*p = payload
+	return nil
+}
+
+{{- define "multipart_part_conversion" }}
+	{{- if eq .Type.Name "bytes" }}
+			{{ .VarName }} := raw
+	{{- else if eq .Type.Name "string" }}
+			{{ .VarName }} := {{ if .Pointer }}&{{ end }}string(raw)
+	{{- else }}
+			{{ .VarName }}Raw := string(raw)
+			var {{ .VarName }} {{ .TypeRef }}
+			{{- template "type_conversion" . }}
+	{{- end }}
+{{- end }}
+` + typeConversionT
+
 // input: multipartData
 const multipartRequestDecoderT = `{{ printf "%s returns a decoder to decode the multipart request for the %q service %q endpoint." .InitName .ServiceName .MethodName | comment }}
 func {{ .InitName }}(mux goahttp.Muxer, {{ .VarName }} {{ .FuncName }}) func(r *http.Request) goahttp.Decoder {
+	{{- if .DefaultFuncName }}
+	if {{ .VarName }} == nil {
+		{{ .VarName }} = {{ .DefaultFuncName }}
+	}
+	{{- end }}
 	return func(r *http.Request) goahttp.Decoder {
 		return goahttp.EncodingFunc(func(v interface{}) error {
+			{{- if .Streaming }}
+			mr, err := goahttp.NewMultipartReader(r, {{ .MaxPartSize }}, {{ .MaxSize }})
+			{{- else }}
 			mr, err := r.MultipartReader()
+			{{- end }}
 			if err != nil {
 				return err
 			}
@@ -1219,7 +1801,7 @@ func {{ .InitName }}(mux goahttp.Muxer, {{ .VarName }} {{ .FuncName }}) func(r *
 			{{- if .Payload.Request.PayloadInit }}
 				{{- range .Payload.Request.PayloadInit.ServerArgs }}
 					{{- if .FieldName }}
-			(*p).{{ .FieldName }} = {{ if .Pointer }}&{{ end }}{{ .Name }}
+			(*p).{{ .FieldName }} = {{ if .EnumTypeRef }}{{ .EnumTypeRef }}({{ end }}{{ if .Pointer }}&{{ end }}{{ .Name }}{{ if .EnumTypeRef }}){{ end }}
 					{{- end }}
 				{{- end }}
 			{{- end }}
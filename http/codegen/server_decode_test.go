@@ -64,6 +64,9 @@ func TestDecode(t *testing.T) {
 		{"query-array-bytes-validate", testdata.PayloadQueryArrayBytesValidateDSL, testdata.PayloadQueryArrayBytesValidateDecodeCode},
 		{"query-array-any", testdata.PayloadQueryArrayAnyDSL, testdata.PayloadQueryArrayAnyDecodeCode},
 		{"query-array-any-validate", testdata.PayloadQueryArrayAnyValidateDSL, testdata.PayloadQueryArrayAnyValidateDecodeCode},
+		{"query-array-string-pipe-delimited", testdata.PayloadQueryArrayStringPipeDelimitedDSL, testdata.PayloadQueryArrayStringPipeDelimitedDecodeCode},
+		{"query-array-int-space-delimited", testdata.PayloadQueryArrayIntSpaceDelimitedDSL, testdata.PayloadQueryArrayIntSpaceDelimitedDecodeCode},
+		{"query-map-deep-object", testdata.PayloadQueryMapDeepObjectDSL, testdata.PayloadQueryMapDeepObjectDecodeCode},
 		{"query-map-string-string", testdata.PayloadQueryMapStringStringDSL, testdata.PayloadQueryMapStringStringDecodeCode},
 		{"query-map-string-string-validate", testdata.PayloadQueryMapStringStringValidateDSL, testdata.PayloadQueryMapStringStringValidateDecodeCode},
 		{"query-map-string-bool", testdata.PayloadQueryMapStringBoolDSL, testdata.PayloadQueryMapStringBoolDecodeCode},
@@ -107,6 +110,8 @@ func TestDecode(t *testing.T) {
 
 		{"header-string", testdata.PayloadHeaderStringDSL, testdata.PayloadHeaderStringDecodeCode},
 		{"header-string-validate", testdata.PayloadHeaderStringValidateDSL, testdata.PayloadHeaderStringValidateDecodeCode},
+		{"header-nested", testdata.PayloadHeaderNestedDSL, testdata.PayloadHeaderNestedDecodeCode},
+		{"header-nested-required", testdata.PayloadHeaderNestedRequiredDSL, testdata.PayloadHeaderNestedRequiredDecodeCode},
 		{"header-array-string", testdata.PayloadHeaderArrayStringDSL, testdata.PayloadHeaderArrayStringDecodeCode},
 		{"header-array-string-validate", testdata.PayloadHeaderArrayStringValidateDSL, testdata.PayloadHeaderArrayStringValidateDecodeCode},
 
@@ -119,6 +124,9 @@ func TestDecode(t *testing.T) {
 		{"header-string-default-validate", testdata.PayloadHeaderStringDefaultValidateDSL, testdata.PayloadHeaderStringDefaultValidateDecodeCode},
 		{"header-primitive-string-default", testdata.PayloadHeaderPrimitiveStringDefaultDSL, testdata.PayloadHeaderPrimitiveStringDefaultDecodeCode},
 
+		{"cookie-string", testdata.PayloadCookieStringDSL, testdata.PayloadCookieStringDecodeCode},
+		{"cookie-int-validate", testdata.PayloadCookieIntValidateDSL, testdata.PayloadCookieIntValidateDecodeCode},
+
 		{"body-string", testdata.PayloadBodyStringDSL, testdata.PayloadBodyStringDecodeCode},
 		{"body-string-validate", testdata.PayloadBodyStringValidateDSL, testdata.PayloadBodyStringValidateDecodeCode},
 		{"body-user", testdata.PayloadBodyUserDSL, testdata.PayloadBodyUserDecodeCode},
@@ -143,6 +151,7 @@ func TestDecode(t *testing.T) {
 
 		{"body-query-object", testdata.PayloadBodyQueryObjectDSL, testdata.PayloadBodyQueryObjectDecodeCode},
 		{"body-query-object-validate", testdata.PayloadBodyQueryObjectValidateDSL, testdata.PayloadBodyQueryObjectValidateDecodeCode},
+		{"body-query-object-aggregate-validate", testdata.PayloadBodyQueryObjectAggregateValidateDSL, testdata.PayloadBodyQueryObjectAggregateValidateDecodeCode},
 		{"body-query-user", testdata.PayloadBodyQueryUserDSL, testdata.PayloadBodyQueryUserDecodeCode},
 		{"body-query-user-validate", testdata.PayloadBodyQueryUserValidateDSL, testdata.PayloadBodyQueryUserValidateDecodeCode},
 
@@ -163,6 +172,8 @@ func TestDecode(t *testing.T) {
 		{"multipart-body-user-type", testdata.PayloadMultipartUserTypeDSL, testdata.PayloadMultipartUserTypeDecodeCode},
 		{"multipart-body-array-type", testdata.PayloadMultipartArrayTypeDSL, testdata.PayloadMultipartArrayTypeDecodeCode},
 		{"multipart-body-map-type", testdata.PayloadMultipartMapTypeDSL, testdata.PayloadMultipartMapTypeDecodeCode},
+		{"max-body", testdata.MaxBodyDSL, testdata.MaxBodyRequestDecoderCode},
+		{"max-body-multipart", testdata.MaxBodyMultipartDSL, testdata.MaxBodyMultipartRequestDecoderCode},
 	}
 	golden := makeGolden(t, "testdata/payload_decode_functions.go")
 	if golden != nil {
@@ -62,6 +62,11 @@ func TestEncode(t *testing.T) {
 		{"body-primitive-array-string", testdata.ResultBodyPrimitiveArrayStringDSL, testdata.ResultBodyPrimitiveArrayStringEncodeCode},
 		{"body-primitive-array-bool", testdata.ResultBodyPrimitiveArrayBoolDSL, testdata.ResultBodyPrimitiveArrayBoolEncodeCode},
 		{"body-primitive-array-user", testdata.ResultBodyPrimitiveArrayUserDSL, testdata.ResultBodyPrimitiveArrayUserEncodeCode},
+		{"body-produces", testdata.ResultBodyProducesDSL, testdata.ResultBodyProducesEncodeCode},
+		{"cookie", testdata.ResultCookieDSL, testdata.ResultCookieEncodeCode},
+		{"constant-header", testdata.ResultConstantHeaderDSL, testdata.ResultConstantHeaderEncodeCode},
+		{"skip-response-body-encode-decode", testdata.ResultSkipResponseBodyEncodeDecodeDSL, testdata.ResultSkipResponseBodyEncodeDecodeEncodeCode},
+		{"trailers", testdata.ResultTrailersDSL, testdata.ResultTrailersEncodeCode},
 
 		{"body-header-object", testdata.ResultBodyHeaderObjectDSL, testdata.ResultBodyHeaderObjectEncodeCode},
 		{"body-header-user", testdata.ResultBodyHeaderUserDSL, testdata.ResultBodyHeaderUserEncodeCode},
@@ -74,6 +79,8 @@ func TestEncode(t *testing.T) {
 		{"tag-result-multiple-views", testdata.ResultMultipleViewsTagDSL, testdata.ResultMultipleViewsTagEncodeCode},
 
 		{"empty-server-response", testdata.EmptyServerResponseDSL, testdata.EmptyServerResponseEncodeCode},
+
+		{"field-selection", testdata.FieldSelectionDSL, testdata.FieldSelectionResponseEncoderCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
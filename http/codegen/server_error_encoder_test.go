@@ -17,6 +17,9 @@ func TestEncodeError(t *testing.T) {
 		{"primitive-error-response", testdata.PrimitiveErrorResponseDSL, testdata.PrimitiveErrorResponseEncoderCode},
 		{"default-error-response", testdata.DefaultErrorResponseDSL, testdata.DefaultErrorResponseEncoderCode},
 		{"service-error-response", testdata.ServiceErrorResponseDSL, testdata.ServiceErrorResponseEncoderCode},
+		{"problem-error-response", testdata.ProblemErrorResponseDSL, testdata.ProblemErrorResponseEncoderCode},
+		{"header-error-response", testdata.HeaderErrorResponseDSL, testdata.HeaderErrorResponseEncoderCode},
+		{"constant-header-error-response", testdata.ConstantHeaderErrorResponseDSL, testdata.ConstantHeaderErrorResponseEncoderCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
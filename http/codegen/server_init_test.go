@@ -21,6 +21,7 @@ func TestServerInit(t *testing.T) {
 		{"file server", testdata.ServerFileServerDSL, testdata.ServerFileServerConstructorCode, 3},
 		{"mixed", testdata.ServerMixedDSL, testdata.ServerMixedConstructorCode, 3},
 		{"multipart", testdata.ServerMultipartDSL, testdata.ServerMultipartConstructorCode, 4},
+		{"cors", testdata.ServerCORSDSL, testdata.ServerCORSConstructorCode, 3},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -40,3 +41,71 @@ func TestServerInit(t *testing.T) {
 		})
 	}
 }
+
+func TestServerCORS(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.ServerCORSDSL)
+	fs := ServerFiles(genpkg, httpdesign.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	sections := fs[0].SectionTemplates
+	if len(sections) < 7 {
+		t.Fatalf("got %d sections, expected at least 7", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[6])
+	if code != testdata.ServerCORSMountCode {
+		t.Errorf("invalid mount code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerCORSMountCode))
+	}
+	code = codegen.SectionCode(t, sections[len(sections)-1])
+	if code != testdata.ServerCORSHandlersCode {
+		t.Errorf("invalid CORS handlers code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerCORSHandlersCode))
+	}
+}
+
+func TestServerFileServerAcceptRanges(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.ServerFileServerAcceptRangesDSL)
+	fs := ServerFiles(genpkg, httpdesign.Root)
+	if len(fs) != 2 {
+		t.Fatalf("got %d files, expected two", len(fs))
+	}
+	sections := fs[0].SectionTemplates
+	if len(sections) < 7 {
+		t.Fatalf("got %d sections, expected at least 7", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[6])
+	if code != testdata.ServerFileServerAcceptRangesMountCode {
+		t.Errorf("invalid mount code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerFileServerAcceptRangesMountCode))
+	}
+}
+
+func TestServerFileServerEmbed(t *testing.T) {
+	const genpkg = "gen"
+	RunHTTPDSL(t, testdata.ServerFileServerEmbedDSL)
+	fs := ServerFiles(genpkg, httpdesign.Root)
+	if len(fs) != 4 {
+		t.Fatalf("got %d files, expected four", len(fs))
+	}
+	sections := fs[0].SectionTemplates
+	if len(sections) < 7 {
+		t.Fatalf("got %d sections, expected at least 7", len(sections))
+	}
+	code := codegen.SectionCode(t, sections[6])
+	if code != testdata.ServerFileServerEmbedMountCode {
+		t.Errorf("invalid mount code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerFileServerEmbedMountCode))
+	}
+	code = codegen.SectionCode(t, sections[len(sections)-1])
+	if code != testdata.ServerFileServerEmbedCode {
+		t.Errorf("invalid embed code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, testdata.ServerFileServerEmbedCode))
+	}
+	expected := []string{
+		"gen/http/service_file_server_embed/server/files/testdata/assets/file.json",
+		"gen/http/service_file_server_embed/server/files/testdata/assets/public/index.html",
+	}
+	for i, f := range fs[2:] {
+		if f.Path != expected[i] {
+			t.Errorf("got embedded file path %q, expected %q", f.Path, expected[i])
+		}
+	}
+}
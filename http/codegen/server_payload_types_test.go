@@ -88,6 +88,8 @@ func TestPayloadConstructor(t *testing.T) {
 
 		{"header-string", testdata.PayloadHeaderStringDSL, testdata.PayloadHeaderStringConstructorCode},
 		{"header-string-validate", testdata.PayloadHeaderStringValidateDSL, testdata.PayloadHeaderStringValidateConstructorCode},
+		{"header-nested", testdata.PayloadHeaderNestedDSL, testdata.PayloadHeaderNestedConstructorCode},
+		{"header-nested-required", testdata.PayloadHeaderNestedRequiredDSL, testdata.PayloadHeaderNestedRequiredConstructorCode},
 		{"header-array-string", testdata.PayloadHeaderArrayStringDSL, testdata.PayloadHeaderArrayStringConstructorCode},
 		{"header-array-string-validate", testdata.PayloadHeaderArrayStringValidateDSL, testdata.PayloadHeaderArrayStringValidateConstructorCode},
 
@@ -111,6 +113,7 @@ func TestPayloadConstructor(t *testing.T) {
 		{"body-inline-array-user", testdata.PayloadBodyInlineArrayUserDSL, testdata.PayloadBodyInlineArrayUserConstructorCode},
 		{"body-inline-map-user", testdata.PayloadBodyInlineMapUserDSL, testdata.PayloadBodyInlineMapUserConstructorCode},
 		{"body-inline-recursive-user", testdata.PayloadBodyInlineRecursiveUserDSL, testdata.PayloadBodyInlineRecursiveUserConstructorCode},
+		{"query-header-enum-type", testdata.PayloadQueryHeaderEnumTypeDSL, testdata.PayloadQueryHeaderEnumTypeConstructorCode},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
@@ -47,20 +47,23 @@ func serverType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 		path  string
 		rdata = HTTPServices.Get(svc.Name())
 	)
-	path = filepath.Join(codegen.Gendir, "http", codegen.SnakeCase(svc.Name()), "server", "types.go")
+	path = filepath.Join(codegen.ServiceGendir(svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "server", "types.go")
 	sd := HTTPServices.Get(svc.Name())
 	header := codegen.Header(svc.Name()+" HTTP server types", "server",
 		[]*codegen.ImportSpec{
+			{Path: "sort"},
 			{Path: "unicode/utf8"},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()), Name: sd.Service.PkgName},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()), Name: sd.Service.PkgName},
 			{Path: "goa.design/goa", Name: "goa"},
-			{Path: genpkg + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: sd.Service.ViewsPkg},
+			{Path: "goa.design/goa/http", Name: "goahttp"},
+			{Path: codegen.ServiceGenpkg(genpkg, svc.ServiceExpr) + "/" + codegen.SnakeCase(svc.Name()) + "/" + "views", Name: sd.Service.ViewsPkg},
 		},
 	)
 
 	var (
 		initData       []*InitData
 		validatedTypes []*TypeData
+		jsonTypes      []*TypeData
 
 		sections = []*codegen.SectionTemplate{header}
 	)
@@ -79,6 +82,9 @@ func serverType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 			if data.ValidateDef != "" {
 				validatedTypes = append(validatedTypes, data)
 			}
+			if data.EncodeJSONDef != "" {
+				jsonTypes = append(jsonTypes, data)
+			}
 		}
 	}
 
@@ -100,6 +106,9 @@ func serverType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 				if data.ValidateDef != "" {
 					validatedTypes = append(validatedTypes, data)
 				}
+				if data.EncodeJSONDef != "" {
+					jsonTypes = append(jsonTypes, data)
+				}
 			}
 		}
 	}
@@ -123,6 +132,9 @@ func serverType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 					if data.ValidateDef != "" {
 						validatedTypes = append(validatedTypes, data)
 					}
+					if data.EncodeJSONDef != "" {
+						jsonTypes = append(jsonTypes, data)
+					}
 				}
 			}
 		}
@@ -141,6 +153,9 @@ func serverType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 		if data.ValidateDef != "" {
 			validatedTypes = append(validatedTypes, data)
 		}
+		if data.EncodeJSONDef != "" {
+			jsonTypes = append(jsonTypes, data)
+		}
 	}
 
 	// body constructors
@@ -156,9 +171,10 @@ func serverType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 		// request to method payload
 		if init := adata.Payload.Request.PayloadInit; init != nil {
 			sections = append(sections, &codegen.SectionTemplate{
-				Name:   "server-payload-init",
-				Source: serverTypeInitT,
-				Data:   init,
+				Name:    "server-payload-init",
+				Source:  serverTypeInitT,
+				FuncMap: map[string]interface{}{"nestedFieldInitData": nestedFieldInitData},
+				Data:    init,
 			})
 		}
 	}
@@ -172,9 +188,27 @@ func serverType(genpkg string, svc *httpdesign.ServiceExpr, seen map[string]stru
 		})
 	}
 
+	// JSON encoding and decoding methods
+	for _, data := range jsonTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "server-fastjson",
+			Source: fastJSONT,
+			Data:   data,
+		})
+	}
+
 	return &codegen.File{Path: path, SectionTemplates: sections}
 }
 
+// nestedFieldInitData returns the data needed to render the nested_field_init
+// template that initializes the nested field of target described by arg.
+func nestedFieldInitData(target string, arg *InitArgData) map[string]interface{} {
+	return map[string]interface{}{
+		"Target": target,
+		"Arg":    arg,
+	}
+}
+
 // input: TypeData
 const typeDeclT = `{{ comment .Description }}
 type {{ .VarName }} {{ .Def }}
@@ -192,25 +226,35 @@ func {{ .Name }}({{- range .ServerArgs }}{{ .Name }} {{ .TypeRef }}, {{ end }})
 		{{- end }}
 		{{- if .ReturnIsStruct }}
 			{{- range .ServerArgs }}
-				{{- if .FieldName }}
-			{{ if $.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ if .Pointer }}&{{ end }}{{ .Name }}
+				{{- if .Nested }}
+					{{- template "nested_field_init" (nestedFieldInitData (or (and $.ReturnTypeAttribute "res") "v") .) }}
+				{{- else if .FieldName }}
+			{{ if $.ReturnTypeAttribute }}res{{ else }}v{{ end }}.{{ .FieldName }} = {{ if .EnumTypeRef }}{{ .EnumTypeRef }}({{ end }}{{ if .Pointer }}&{{ end }}{{ .Name }}{{ if .EnumTypeRef }}){{ end }}
 				{{- end }}
 			{{- end }}
 		{{- end }}
 		return {{ if .ReturnTypeAttribute }}res{{ else }}v{{ end }}
 	{{- else }}
 		{{- if .ReturnIsStruct }}
-			return &{{ .ReturnTypeName }}{
+			{{ if .NestedArgs }}v := {{ else }}return {{ end }}&{{ .ReturnTypeName }}{
 			{{- range .ServerArgs }}
-				{{- if .FieldName }}
-				{{ .FieldName }}: {{ if .Pointer }}&{{ end }}{{ .Name }},
+				{{- if and .FieldName (not .Nested) }}
+				{{ .FieldName }}: {{ if .EnumTypeRef }}{{ .EnumTypeRef }}({{ end }}{{ if .Pointer }}&{{ end }}{{ .Name }}{{ if .EnumTypeRef }}){{ end }},
 				{{- end }}
 			{{- end }}
 			}
+			{{- if .NestedArgs }}
+				{{- range .ServerArgs }}
+					{{- if .Nested }}
+						{{- template "nested_field_init" (nestedFieldInitData "v" .) }}
+					{{- end }}
+				{{- end }}
+			return v
+			{{- end }}
 		{{- end }}
 	{{ end -}}
 }
-`
+` + nestedFieldInitT
 
 // input: InitData
 const serverBodyInitT = `{{ comment .Description }}
@@ -227,3 +271,50 @@ func (body {{ .Ref }}) Validate() (err error) {
 	return
 }
 `
+
+// input: TypeData
+const fastJSONT = `{{ printf "MarshalJSON encodes %s to JSON without using reflection." .VarName | comment }}
+func (body {{ .Ref }}) MarshalJSON() ([]byte, error) {
+	w := goahttp.NewJSONWriter()
+	body.encodeJSON(w)
+	return w.Bytes(), w.Err()
+}
+
+{{ printf "UnmarshalJSON decodes %s from JSON without using reflection." .VarName | comment }}
+func (body {{ .Ref }}) UnmarshalJSON(data []byte) error {
+	r := goahttp.NewJSONReader(data)
+	if !r.Null() {
+		body.decodeJSON(r)
+	}
+	return r.Err()
+}
+
+// encodeJSON writes body to w.
+func (body {{ .Ref }}) encodeJSON(w *goahttp.JSONWriter) {
+	{{ .EncodeJSONDef }}
+}
+
+// decodeJSON reads body from r.
+func (body {{ .Ref }}) decodeJSON(r *goahttp.JSONReader) {
+	{{ .DecodeJSONDef }}
+}
+`
+
+// input: map[string]interface{}{"Target": string, "Arg": *InitArgData}
+const nestedFieldInitT = `{{- define "nested_field_init" }}
+	{{- with .Arg }}
+		{{- if .Nested.CheckNil }}
+	if {{ .Name }} != nil {
+		{{- end }}
+		{{- range .Nested.Parents }}
+	if {{ $.Target }}.{{ .FieldName }} == nil {
+		{{ $.Target }}.{{ .FieldName }} = &{{ .TypeName }}{}
+	}
+		{{- end }}
+	{{ $.Target }}.{{ .FieldName }} = {{ if .Nested.Deref }}*{{ end }}{{ .Name }}
+		{{- if .Nested.CheckNil }}
+	}
+		{{- end }}
+	{{- end }}
+{{- end }}
+`
@@ -0,0 +1,80 @@
+package codegen
+
+import (
+	"encoding/json"
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/openapi"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestClientServerURL(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"api-servers", testdata.ServerVariablesDSL, testdata.ServerVariablesServerURLCode},
+		{"service-server", testdata.ServiceServerVariablesDSL, testdata.ServiceServerVariablesServerURLCode},
+		{"single-server", testdata.ServerNoPayloadNoResultDSL, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ClientFiles("", httpdesign.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			sections := fs[0].Section("client-server-url")
+			if c.Code == "" {
+				if len(sections) != 0 {
+					t.Fatalf("got %d server URL sections, expected none", len(sections))
+				}
+				return
+			}
+			if len(sections) != 1 {
+				t.Fatalf("got %d server URL sections, expected one", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestServerURLOpenAPI(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Host    string
+		Servers string
+	}{
+		{"variables", testdata.ServerVariablesDSL, "prod.us-east-1.calc.com", `[{"url":"https://{environment}.{region}.calc.com","description":"Calc hosts","variables":{"environment":{"enum":["prod","staging"],"default":"prod","description":"Deployment environment"},"region":{"default":"us-east-1"}}},{"url":"http://localhost:8080","description":"Development host"}]`},
+		{"single-server", testdata.ServerNoPayloadNoResultDSL, "localhost", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			spec, err := openapi.NewV2(httpdesign.Root)
+			if err != nil {
+				t.Fatalf("OpenAPI failed with %s", err)
+			}
+			if spec.Host != c.Host {
+				t.Errorf("got host %q, expected %q", spec.Host, c.Host)
+			}
+			servers, ok := spec.Extensions["x-servers"]
+			if c.Servers == "" {
+				if ok {
+					t.Errorf("got x-servers extension %v, expected none", servers)
+				}
+				return
+			}
+			if js, _ := json.Marshal(servers); string(js) != c.Servers {
+				t.Errorf("got x-servers %s, expected %s", js, c.Servers)
+			}
+		})
+	}
+}
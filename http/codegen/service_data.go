@@ -3,10 +3,14 @@ package codegen
 import (
 	"bytes"
 	"fmt"
+	"mime"
 	"net/http"
+	"path"
+	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"text/template"
 
 	"goa.design/goa/codegen"
@@ -17,7 +21,7 @@ import (
 
 // HTTPServices holds the data computed from the design needed to generate the
 // transport code of the services.
-var HTTPServices = make(ServicesData)
+var HTTPServices = NewServicesData()
 
 var (
 	// pathInitTmpl is the template used to render path constructors code.
@@ -28,7 +32,24 @@ var (
 
 type (
 	// ServicesData encapsulates the data computed from the design.
-	ServicesData map[string]*ServiceData
+	// ServicesData is safe for concurrent use, the data of each service is
+	// computed once.
+	ServicesData struct {
+		// mu protects services.
+		mu sync.Mutex
+		// services lists the analyzed services indexed by name.
+		services map[string]*analyzedService
+	}
+
+	// analyzedService records the data computed for a service.
+	analyzedService struct {
+		// once makes sure the service is analyzed once.
+		once sync.Once
+		// expr is the service expression.
+		expr *httpdesign.ServiceExpr
+		// data is the data computed from expr.
+		data *ServiceData
+	}
 
 	// ServiceData contains the data used to render the code related to a
 	// single service.
@@ -39,6 +60,19 @@ type (
 		Endpoints []*EndpointData
 		// FileServers lists the file servers for this service.
 		FileServers []*FileServerData
+		// Servers lists the hosts of the service, the hosts of the API
+		// if the service does not define any.
+		Servers []*ServerData
+		// CORS describes the CORS policy applied to the service
+		// endpoints if any.
+		CORS *CORSData
+		// RouteTable lists all the routes mounted by the server
+		// including the CORS preflight and file server routes.
+		RouteTable []*RouteData
+		// CompressMinSize is the minimum size in bytes of the response
+		// bodies compressed by the endpoints whose Compress field is
+		// true.
+		CompressMinSize int
 		// ServerStruct is the name of the HTTP server struct.
 		ServerStruct string
 		// MountPointStruct is the name of the mount point struct.
@@ -119,9 +153,20 @@ type (
 		ResponseEncoder string
 		// ErrorEncoder is the name of the error encoder function.
 		ErrorEncoder string
+		// ProblemErrors is true if the errors are encoded as RFC 7807
+		// problem details documents.
+		ProblemErrors bool
+		// PanicErrorName is the name of the error returned to the client
+		// when the handler recovers from a panic: the first error with
+		// the default error type mapped to status code 500 if any,
+		// "fault" otherwise.
+		PanicErrorName string
 		// MultipartRequestDecoder indicates the request decoder for multipart
 		// content type.
 		MultipartRequestDecoder *MultipartData
+		// Consumes describes the MIME types the request body may be
+		// encoded with if the design lists them with Consumes.
+		Consumes *ConsumesData
 		// ServerStream holds the data to render the server struct which
 		// implements the server stream interface.
 		ServerStream *StreamData
@@ -139,12 +184,52 @@ type (
 		RequestEncoder string
 		// ResponseDecoder is the name of the response decoder function.
 		ResponseDecoder string
+		// TrailersDecoder is the name of the function that sets the
+		// result fields mapped to response trailers, empty if the
+		// responses do not define trailers.
+		TrailersDecoder string
 		// MultipartRequestEncoder indicates the request encoder for multipart
 		// content type.
 		MultipartRequestEncoder *MultipartData
 		// ClientStream holds the data to render the client struct which
 		// implements the client stream interface.
 		ClientStream *StreamData
+		// Compress is true if the server compresses the endpoint
+		// response bodies and the client requests compressed responses.
+		Compress bool
+		// MaxBodySize is the maximum size in bytes of the request body,
+		// zero means there is no limit.
+		MaxBodySize int64
+		// Tracing describes the OpenTelemetry spans created by the server
+		// handler and the client endpoint if any.
+		Tracing *TracingData
+		// FieldsParam is the name of the query string parameter used
+		// to select the result fields returned in the response body if
+		// any.
+		FieldsParam string
+		// Fields lists the paths of the result fields that may be
+		// selected with FieldsParam.
+		Fields []string
+	}
+
+	// TracingData describes the OpenTelemetry instrumentation of an
+	// endpoint.
+	TracingData struct {
+		// SpanName is the name of the server and client spans.
+		SpanName string
+		// Attributes lists the payload attributes recorded as span
+		// attributes.
+		Attributes []*SpanAttributeData
+	}
+
+	// SpanAttributeData describes a payload attribute recorded as a span
+	// attribute.
+	SpanAttributeData struct {
+		// Key is the span attribute key.
+		Key string
+		// FieldName is the name of the payload struct field holding the
+		// attribute value.
+		FieldName string
 	}
 
 	// FileServerData lists the data needed to generate file servers.
@@ -158,6 +243,69 @@ type (
 		// Dir is true if the file server servers files under a
 		// directory, false if it serves a single file.
 		IsDir bool
+		// AcceptRanges is true if the file server handles range
+		// requests explicitly.
+		AcceptRanges bool
+		// ChunkSize is the maximum number of bytes returned in a
+		// partial response, zero means there is no limit.
+		ChunkSize int64
+		// Embed is true if the assets are embedded in the generated
+		// server package.
+		Embed bool
+		// EmbedPath is the path to the embedded assets relative to the
+		// generated server package.
+		EmbedPath string
+	}
+
+	// ServerData describes a host of the service.
+	ServerData struct {
+		// URL is the server URL, it may contain variables using the
+		// "{name}" syntax.
+		URL string
+		// Description is the server description.
+		Description string
+		// Variables lists the server URL variables.
+		Variables []*ServerVariableData
+	}
+
+	// ServerVariableData describes a server URL variable.
+	ServerVariableData struct {
+		// Name is the variable name.
+		Name string
+		// Description is the variable description.
+		Description string
+		// DefaultValue is the variable default value.
+		DefaultValue string
+		// Values lists the values allowed by the design if any.
+		Values []string
+	}
+
+	// CORSData contains the data needed to render the CORS handlers of a
+	// service.
+	CORSData struct {
+		// OriginHandler is the name of the function that wraps the
+		// endpoint handlers to set the CORS response headers.
+		OriginHandler string
+		// HandlerInit is the name of the constructor of the preflight
+		// request handler.
+		HandlerInit string
+		// MountHandler is the name of the function that mounts the
+		// preflight request handler.
+		MountHandler string
+		// Origins lists the allowed origins.
+		Origins []string
+		// Methods is the value of the Access-Control-Allow-Methods
+		// header.
+		Methods string
+		// Headers is the value of the Access-Control-Allow-Headers
+		// header if any.
+		Headers string
+		// MaxAge is the value of the Access-Control-Max-Age header if
+		// any.
+		MaxAge string
+		// Paths lists the request paths that handle preflight
+		// requests.
+		Paths []string
 	}
 
 	// PayloadData contains the payload information required to generate the
@@ -211,6 +359,10 @@ type (
 		Name string
 		// Ref is a reference to the error type.
 		Ref string
+		// Init is the name of the service package function that builds
+		// the error from a Go error, empty if the error does not use the
+		// default error type.
+		Init string
 		// Response is the error response data.
 		Response *ResponseData
 	}
@@ -226,6 +378,9 @@ type (
 		// Headers contains the HTTP request headers used to build the
 		// method payload.
 		Headers []*HeaderData
+		// Cookies contains the HTTP request cookies used to build the
+		// method payload.
+		Cookies []*CookieData
 		// ServerBody describes the request body type used by server
 		// code. The type is generated using pointers for all fields so
 		// that it can be validated.
@@ -238,8 +393,13 @@ type (
 		// payload constructor used by server code if any.
 		PayloadInit *InitData
 		// MustValidate is true if the request body or at least one
-		// parameter or header requires validation.
+		// parameter, header or cookie requires validation.
 		MustValidate bool
+		// AggregateValidation is true if the errors resulting from the
+		// validation of the request body must be aggregated with the
+		// errors resulting from the validation of the parameters,
+		// headers and cookies rather than returned right away.
+		AggregateValidation bool
 	}
 
 	// ResponseData describes a response.
@@ -251,6 +411,9 @@ type (
 		// Headers provides information about the headers in the
 		// response.
 		Headers []*HeaderData
+		// Cookies provides information about the cookies set by the
+		// response.
+		Cookies []*CookieData
 		// ErrorHeader contains the value of the response "goa-error"
 		// header if any.
 		ErrorHeader string
@@ -273,7 +436,8 @@ type (
 		TagValue string
 		// TagRequired is true if the tag attribute is required.
 		TagRequired bool
-		// MustValidate is true if at least one header requires validation.
+		// MustValidate is true if at least one header or cookie
+		// requires validation.
 		MustValidate bool
 		// ResultAttr sets the response body from the specified result type
 		// attribute. This field is set when the design uses Body("name") syntax
@@ -282,6 +446,24 @@ type (
 		// ViewedResult indicates whether the response body type is a result type
 		// with multiple views.
 		ViewedResult bool
+		// Produces lists the MIME types the response body may be
+		// encoded with if any.
+		Produces []string
+		// ConstantHeaders lists the headers set to constant values
+		// sorted by name.
+		ConstantHeaders []*ConstantHeaderData
+		// Trailers provides information about the trailers written
+		// after the response body.
+		Trailers []*HeaderData
+	}
+
+	// ConstantHeaderData describes a response header set to a constant
+	// value.
+	ConstantHeaderData struct {
+		// Name is the name of the header.
+		Name string
+		// Value is the value of the header.
+		Value string
 	}
 
 	// InitData contains the data required to render a constructor.
@@ -313,6 +495,9 @@ type (
 		ReturnTypeAttribute string
 		// ReturnIsStruct is true if the return type is a struct.
 		ReturnIsStruct bool
+		// NestedArgs is true if some of the arguments initialize
+		// fields nested in parent struct fields.
+		NestedArgs bool
 		// ReturnIsPrimitivePointer indicates whether the return type is
 		// a primitive pointer.
 		ReturnIsPrimitivePointer bool
@@ -342,6 +527,10 @@ type (
 		TypeRef string
 		// Pointer is true if a pointer to the arg should be used.
 		Pointer bool
+		// EnumTypeRef is the conversion applied to the argument when
+		// initializing a field that uses a service enum type if any,
+		// e.g. "svc.Kind" or "(*svc.Kind)".
+		EnumTypeRef string
 		// Required is true if the arg is required to build the payload.
 		Required bool
 		// DefaultValue is the default value of the arg.
@@ -351,6 +540,9 @@ type (
 		Validate string
 		// Example is a example value
 		Example interface{}
+		// Nested describes the field initialized with the argument
+		// if it is nested in a parent struct field.
+		Nested *NestedFieldData
 	}
 
 	// RouteData describes a route.
@@ -359,6 +551,11 @@ type (
 		Verb string
 		// Path is the fullpath including wildcards.
 		Path string
+		// Segments lists the path segments (without the leading
+		// slash) including wildcards.
+		Segments []string
+		// Wildcards lists the indices of the wildcard segments.
+		Wildcards []int
 		// PathInit contains the information needed to render and call
 		// the path constructor for the route.
 		PathInit *InitData
@@ -391,6 +588,9 @@ type (
 		Required bool
 		// Pointer is true if and only the param variable is a pointer.
 		Pointer bool
+		// EnumType is the qualified name of the service enum type used
+		// by the corresponding service field if any.
+		EnumType string
 		// StringSlice is true if the param type is array of strings.
 		StringSlice bool
 		// Slice is true if the param type is an array.
@@ -410,6 +610,13 @@ type (
 		// to the entire payload (empty string) or a payload attribute
 		// (attribute name).
 		MapQueryParams *string
+		// Delimiter is the separator used to encode the elements of an
+		// array query param in a single value, empty if each element
+		// is encoded in its own value.
+		Delimiter string
+		// DeepObject is true if the keys of a map query param are
+		// encoded in the parameter names, e.g. "filter[key]=value".
+		DeepObject bool
 	}
 
 	// HeaderData describes a HTTP request or response header.
@@ -436,6 +643,9 @@ type (
 		Required bool
 		// Pointer is true if and only the param variable is a pointer.
 		Pointer bool
+		// EnumType is the qualified name of the service enum type used
+		// by the corresponding service field if any.
+		EnumType string
 		// StringSlice is true if the param type is array of strings.
 		StringSlice bool
 		// Slice is true if the param type is an array.
@@ -448,6 +658,75 @@ type (
 		DefaultValue interface{}
 		// Example is an example value.
 		Example interface{}
+		// Nested describes the payload field nested in a payload
+		// attribute of type object that holds the header value if any.
+		Nested *NestedFieldData
+	}
+
+	// NestedFieldData describes a struct field nested in the fields of
+	// parent structs, e.g. "Tenant.ID".
+	NestedFieldData struct {
+		// Parents lists the parent struct fields from the outermost to
+		// the innermost.
+		Parents []*NestedParentData
+		// CheckNil is true if the value may be missing, the field is
+		// initialized only if the value is not nil.
+		CheckNil bool
+		// FieldPointer is true if the field is a pointer.
+		FieldPointer bool
+		// Deref is true if the value is a pointer but the field is not.
+		Deref bool
+	}
+
+	// NestedParentData describes a parent struct field of a nested field.
+	NestedParentData struct {
+		// FieldName is the selector of the parent field, e.g. "Tenant"
+		// or "Tenant.Org".
+		FieldName string
+		// TypeName is the qualified name of the parent struct type.
+		TypeName string
+	}
+
+	// CookieData describes a HTTP request or response cookie.
+	CookieData struct {
+		// Name is the name of the cookie.
+		Name string
+		// AttributeName is the name of the corresponding attribute.
+		AttributeName string
+		// Description is the cookie description.
+		Description string
+		// FieldName is the name of the struct field that holds the
+		// cookie value if any, empty string otherwise.
+		FieldName string
+		// VarName is the name of the Go variable used to read or
+		// convert the cookie value.
+		VarName string
+		// TypeName is the name of the type.
+		TypeName string
+		// TypeRef is the reference to the type.
+		TypeRef string
+		// Required is true if the cookie is required.
+		Required bool
+		// Pointer is true if and only the cookie variable is a pointer.
+		Pointer bool
+		// EnumType is the qualified name of the service enum type used
+		// by the corresponding service field if any.
+		EnumType string
+		// Type describes the datatype of the variable value. Mainly
+		// used for conversion.
+		Type design.DataType
+		// Validate contains the validation code if any.
+		Validate string
+		// DefaultValue contains the default value if any.
+		DefaultValue interface{}
+		// Example is an example value.
+		Example interface{}
+		// MaxAge is the cookie "Max-Age" attribute value if any.
+		MaxAge string
+		// Secure is true if the cookie "Secure" attribute is set.
+		Secure bool
+		// HTTPOnly is true if the cookie "HttpOnly" attribute is set.
+		HTTPOnly bool
 	}
 
 	// TypeData contains the data needed to render a type definition.
@@ -469,10 +748,33 @@ type (
 		ValidateDef string
 		// ValidateRef contains the call to the validation code.
 		ValidateRef string
+		// EncodeJSONDef contains the code that writes the type to JSON
+		// without using reflection if the "http:body:fastjson" metadata
+		// is set.
+		EncodeJSONDef string
+		// DecodeJSONDef contains the code that reads the type from JSON
+		// without using reflection if the "http:body:fastjson" metadata
+		// is set.
+		DecodeJSONDef string
 		// Example is an example value for the type.
 		Example interface{}
 	}
 
+	// ConsumesData contains the data needed to render the request decoder of
+	// endpoints that accept multiple request content types.
+	ConsumesData struct {
+		// Default is the MIME type of the requests that do not set
+		// the Content-Type header.
+		Default string
+		// Form lists the MIME types decoded with goahttp.FormDecoder.
+		Form []string
+		// Decoder lists the MIME types decoded with the server
+		// decoder.
+		Decoder []string
+		// All lists all the MIME types.
+		All []string
+	}
+
 	// MultipartData contains the data needed to render multipart encoder/decoder.
 	MultipartData struct {
 		// FuncName is the name used to generate function type.
@@ -487,6 +789,49 @@ type (
 		MethodName string
 		// Payload is the payload data required to generate encoder/decoder.
 		Payload *PayloadData
+		// Streaming is true if the decoder streams the parts to the
+		// user function using a goahttp.MultipartReader.
+		Streaming bool
+		// MaxPartSize is the maximum size of a streamed part.
+		MaxPartSize int64
+		// MaxSize is the maximum size of a streamed request body.
+		MaxSize int64
+		// DefaultFuncName is the name of the generated decoder function
+		// used when no user function is provided, empty if the payload
+		// cannot be decoded automatically.
+		DefaultFuncName string
+		// PayloadInit is the code that initializes the payload in the
+		// generated decoder function.
+		PayloadInit string
+		// Parts lists the multipart parts mapped to payload attributes
+		// by the generated decoder function.
+		Parts []*MultipartPartData
+	}
+
+	// MultipartPartData describes a multipart part mapped to a payload
+	// attribute by name.
+	MultipartPartData struct {
+		// Name is the form name of the part.
+		Name string
+		// FieldName is the name of the payload struct field.
+		FieldName string
+		// VarName is the name of the variable holding the decoded value.
+		VarName string
+		// TypeRef is the reference to the attribute type.
+		TypeRef string
+		// Type is the attribute type.
+		Type design.DataType
+		// Pointer is true if the payload field is a pointer.
+		Pointer bool
+		// Required is true if the attribute is required.
+		Required bool
+		// Elem describes the array elements if the attribute is an
+		// array of primitive values, each part with the same name
+		// appends an element.
+		Elem *MultipartPartData
+		// JSON is true if the part content is decoded as JSON, i.e.
+		// if the attribute type is not primitive.
+		JSON bool
 	}
 
 	// StreamData contains the data needed to render struct type that implements
@@ -515,22 +860,87 @@ type (
 		RecvRef string
 		// PkgName is the service package name.
 		PkgName string
+		// Binary is true if the stream uses binary frames encoded with
+		// a user provided codec instead of JSON text frames.
+		Binary bool
+		// NDJSON is true if the stream sends newline delimited JSON
+		// over a chunked HTTP response instead of using a websocket
+		// connection.
+		NDJSON bool
+		// KeepAlive describes the ping control messages sent over
+		// the websocket connection, nil if the stream does not send
+		// pings.
+		KeepAlive *StreamKeepAliveData
+		// Compression is true if the websocket connection negotiates
+		// the permessage-deflate compression extension.
+		Compression bool
+		// Resume describes the resumable client stream, nil if the
+		// endpoint does not define a resume token.
+		Resume *StreamResumeData
+	}
+
+	// StreamResumeData contains the data needed to render the resumable
+	// client stream of a websocket streaming endpoint.
+	StreamResumeData struct {
+		// VarName is the name of the resumable client stream struct.
+		VarName string
+		// EndpointInit is the name of the client method that returns
+		// the endpoint whose streams are resumable.
+		EndpointInit string
+		// Header is the name of the request header that carries the
+		// resume token.
+		Header string
+		// TokenField is the name of the result struct field that holds
+		// the resume token.
+		TokenField string
+		// TokenPointer is true if the result struct field is a pointer.
+		TokenPointer bool
+	}
+
+	// StreamKeepAliveData contains the Go expressions of the durations
+	// that configure the keepalive of a websocket stream.
+	StreamKeepAliveData struct {
+		// PingInterval is the interval between two pings.
+		PingInterval string
+		// PongWait is the maximum duration without control messages
+		// from the peer after which reads fail.
+		PongWait string
+		// WriteWait is the maximum duration of a write.
+		WriteWait string
 	}
 )
 
+// NewServicesData returns an empty ServicesData.
+func NewServicesData() *ServicesData {
+	return &ServicesData{services: make(map[string]*analyzedService)}
+}
+
 // Get retrieves the transport data for the service with the given name
 // computing it if needed. It returns nil if there is no service with the given
 // name.
-func (d ServicesData) Get(name string) *ServiceData {
-	if data, ok := d[name]; ok {
-		return data
-	}
-	service := httpdesign.Root.Service(name)
-	if service == nil {
-		return nil
-	}
-	d[name] = d.analyze(service)
-	return d[name]
+func (d *ServicesData) Get(name string) *ServiceData {
+	d.mu.Lock()
+	as, ok := d.services[name]
+	if !ok {
+		service := httpdesign.Root.Service(name)
+		if service == nil {
+			d.mu.Unlock()
+			return nil
+		}
+		as = &analyzedService{expr: service}
+		d.services[name] = as
+	}
+	d.mu.Unlock()
+	as.once.Do(func() { as.data = d.analyze(as.expr) })
+	return as.data
+}
+
+// Analyze computes the transport data of the given services concurrently so
+// that subsequent calls to Get do not need to.
+func (d *ServicesData) Analyze(services []*httpdesign.ServiceExpr) {
+	codegen.RunParallel(len(services), func(i int) {
+		d.Get(services[i].Name())
+	})
 }
 
 // Endpoint returns the service method transport data for the endpoint with the
@@ -544,8 +954,9 @@ func (svc *ServiceData) Endpoint(name string) *EndpointData {
 	return nil
 }
 
-// NeedServerResponse returns true if server response has a body or a header.
-// It is used when initializing the result in the server response encoding.
+// NeedServerResponse returns true if server response has a body, a header, a
+// cookie or a trailer. It is used when initializing the result in the server
+// response encoding.
 func (e *EndpointData) NeedServerResponse() bool {
 	if e.Result == nil {
 		return false
@@ -557,13 +968,19 @@ func (e *EndpointData) NeedServerResponse() bool {
 		if len(r.Headers) > 0 {
 			return true
 		}
+		if len(r.Cookies) > 0 {
+			return true
+		}
+		if len(r.Trailers) > 0 {
+			return true
+		}
 	}
 	return false
 }
 
 // analyze creates the data necessary to render the code of the given service.
 // It records the user types needed by the service definition in userTypes.
-func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
+func (d *ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 	svc := service.Services.Get(hs.ServiceExpr.Name)
 
 	rd := &ServiceData{
@@ -592,12 +1009,20 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 		}
 	}
 
+	rd.Servers = buildServersData(hs)
+
 	for _, s := range hs.FileServers {
 		data := &FileServerData{
 			MountHandler: fmt.Sprintf("Mount%s", codegen.Goify(s.FilePath, true)),
 			RequestPaths: s.RequestPaths,
 			FilePath:     s.FilePath,
 			IsDir:        s.IsDir(),
+			AcceptRanges: s.AcceptRanges,
+			ChunkSize:    s.ChunkSize,
+			Embed:        s.Embed,
+		}
+		if s.Embed {
+			data.EmbedPath = path.Join(embedDir, filepath.ToSlash(s.FilePath))
 		}
 		rd.FileServers = append(rd.FileServers, data)
 	}
@@ -638,18 +1063,24 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 							TypeName:    svc.Scope.GoTypeName(att),
 							TypeRef:     svc.Scope.GoTypeRef(att),
 							Pointer:     pointer,
+							EnumTypeRef: enumTypeRef(serviceEnumType(a.MethodExpr.Payload, arg, svc.PkgName), "", false),
 							Required:    true,
-							Example:     att.Example(design.Root.API.Random()),
+							Example:     att.Example(svc.Random),
 							Validate:    vcode,
 						}
 					}
 
+					var catchAll string
+					if ca := httpdesign.ExtractRouteCatchAll(rpath); ca != "" {
+						catchAll = initArgs[len(initArgs)-1].Name
+					}
 					var buffer bytes.Buffer
 					pf := httpdesign.WildcardRegex.ReplaceAllString(rpath, "/%v")
 					err := pathInitTmpl.Execute(&buffer, map[string]interface{}{
 						"Args":       initArgs,
 						"PathParams": pathParamsObj,
 						"PathFormat": pf,
+						"CatchAll":   catchAll,
 					})
 					if err != nil {
 						panic(err)
@@ -666,11 +1097,9 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 					}
 				}
 
-				routes = append(routes, &RouteData{
-					Verb:     strings.ToUpper(r.Method),
-					Path:     rpath,
-					PathInit: init,
-				})
+				route := newRouteData(strings.ToUpper(r.Method), rpath)
+				route.PathInit = init
+				routes = append(routes, route)
 			}
 		}
 
@@ -694,6 +1123,9 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 							qsch = appendUnique(qsch, s)
 						case "header":
 							hsch = appendUnique(hsch, s)
+						case "cookie":
+							// Cookies are decoded with the
+							// other request cookies.
 						default:
 							bosch = appendUnique(bosch, s)
 						}
@@ -704,7 +1136,7 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 
 		var requestEncoder string
 		{
-			if payload.Request.ClientBody != nil || len(payload.Request.Headers) > 0 || len(payload.Request.QueryParams) > 0 || basch != nil {
+			if payload.Request.ClientBody != nil || len(payload.Request.Headers) > 0 || len(payload.Request.Cookies) > 0 || len(payload.Request.QueryParams) > 0 || basch != nil {
 				requestEncoder = fmt.Sprintf("Encode%sRequest", ep.VarName)
 			}
 		}
@@ -729,17 +1161,42 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 			if len(pathInit.ClientArgs) > 0 && a.MethodExpr.Payload.Type != design.Empty {
 				payloadRef = svc.Scope.GoFullTypeRef(a.MethodExpr.Payload, svc.PkgName)
 			}
-			if ep.ServerStream != nil || ep.ClientStream != nil {
+			var contentType, accept string
+			if (ep.ServerStream != nil || ep.ClientStream != nil) && !a.NDJSONStream {
 				scheme = wsscheme
+			} else {
+				// The first MIME type listed in the API Produces
+				// expression is used to request the response
+				// encoding.
+				if payload.Request.ClientBody != nil && !a.MultipartRequest {
+					contentType = a.RequestContentType()
+				}
+				if len(httpdesign.Root.Produces) > 0 && !a.NDJSONStream {
+					accept = httpdesign.Root.Produces[0]
+				}
+			}
+			var versionHeader, version string
+			if v := hs.Versioning(); v != nil {
+				switch v.Strategy {
+				case httpdesign.VersionHeader:
+					versionHeader, version = v.Header, v.Value
+				case httpdesign.VersionMediaType:
+					accept = v.Value
+				}
 			}
 			data := map[string]interface{}{
-				"PayloadRef":   payloadRef,
-				"ServiceName":  svc.Name,
-				"EndpointName": ep.Name,
-				"Args":         args,
-				"PathInit":     pathInit,
-				"Verb":         routes[0].Verb,
-				"Scheme":       scheme,
+				"PayloadRef":    payloadRef,
+				"ServiceName":   svc.Name,
+				"EndpointName":  ep.Name,
+				"Args":          args,
+				"PathInit":      pathInit,
+				"Verb":          routes[0].Verb,
+				"Scheme":        scheme,
+				"ContentType":   contentType,
+				"Accept":        accept,
+				"VersionHeader": versionHeader,
+				"Version":       version,
+				"Tracing":       otelTracing(svc.Name),
 			}
 			if err := requestInitTmpl.Execute(&buf, data); err != nil {
 				panic(err) // bug
@@ -774,11 +1231,27 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 			RequestDecoder:  fmt.Sprintf("Decode%sRequest", ep.VarName),
 			ResponseEncoder: fmt.Sprintf("Encode%sResponse", ep.VarName),
 			ErrorEncoder:    fmt.Sprintf("Encode%sError", ep.VarName),
+			ProblemErrors:   problemErrors(),
+			PanicErrorName:  panicErrorName(a),
 			ClientStruct:    "Client",
 			EndpointInit:    ep.VarName,
 			RequestInit:     requestInit,
 			RequestEncoder:  requestEncoder,
 			ResponseDecoder: fmt.Sprintf("Decode%sResponse", ep.VarName),
+			MaxBodySize:     a.RequestMaxBodySize(),
+			Tracing:         buildTracingData(a, svc),
+			FieldsParam:     a.FieldsParam,
+		}
+		if a.FieldsParam != "" {
+			ad.Fields = a.SelectableFields()
+		}
+		if ad.Result != nil {
+			for _, r := range ad.Result.Responses {
+				if len(r.Trailers) > 0 {
+					ad.TrailersDecoder = fmt.Sprintf("Decode%sTrailers", ep.VarName)
+					break
+				}
+			}
 		}
 
 		if a.MultipartRequest {
@@ -789,6 +1262,14 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 				ServiceName: svc.Name,
 				MethodName:  ep.Name,
 				Payload:     ad.Payload,
+				Streaming:   a.StreamingMultipart,
+				MaxPartSize: a.MultipartMaxPartSize,
+				MaxSize:     a.MultipartMaxSize,
+			}
+			if design.IsObject(a.MethodExpr.Payload.Type) {
+				ad.MultipartRequestDecoder.DefaultFuncName = fmt.Sprintf("Default%s%sDecoder", svc.StructName, ep.VarName)
+				ad.MultipartRequestDecoder.PayloadInit = "&" + strings.TrimPrefix(ad.Payload.Ref, "*") + "{}"
+				ad.MultipartRequestDecoder.Parts = buildMultipartPartsData(a, svc)
 			}
 			ad.MultipartRequestEncoder = &MultipartData{
 				FuncName:    fmt.Sprintf("%s%sEncoderFunc", svc.StructName, ep.VarName),
@@ -799,24 +1280,43 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 				Payload:     ad.Payload,
 			}
 		}
+		if len(a.Consumes) > 0 && payload.Request.ServerBody != nil {
+			ad.Consumes = buildConsumesData(a)
+		}
 		if ep.ServerStream != nil || ep.ClientStream != nil {
+			var keepAlive *StreamKeepAliveData
+			if ka := a.StreamKeepAlive; ka != nil {
+				keepAlive = &StreamKeepAliveData{
+					PingInterval: codegen.DurationCode(ka.PingInterval),
+					PongWait:     codegen.DurationCode(ka.PongWait),
+					WriteWait:    codegen.DurationCode(ka.WriteWait),
+				}
+			}
 			ad.ServerStream = &StreamData{
-				VarName:   ep.ServerStream.VarName,
-				Interface: fmt.Sprintf("%s.%s", svc.PkgName, ep.ServerStream.Interface),
-				Endpoint:  ad,
-				Response:  ad.Result.Responses[0],
-				PkgName:   svc.PkgName,
-				Scheme:    wsscheme,
-				Type:      "server",
+				VarName:     ep.ServerStream.VarName,
+				Interface:   fmt.Sprintf("%s.%s", svc.PkgName, ep.ServerStream.Interface),
+				Endpoint:    ad,
+				Response:    ad.Result.Responses[0],
+				PkgName:     svc.PkgName,
+				Scheme:      wsscheme,
+				Type:        "server",
+				Binary:      a.BinaryStream,
+				NDJSON:      a.NDJSONStream,
+				KeepAlive:   keepAlive,
+				Compression: a.StreamCompression,
 			}
 			ad.ClientStream = &StreamData{
-				VarName:   ep.ClientStream.VarName,
-				Interface: fmt.Sprintf("%s.%s", svc.PkgName, ep.ClientStream.Interface),
-				Endpoint:  ad,
-				Response:  ad.Result.Responses[0],
-				PkgName:   svc.PkgName,
-				Scheme:    wsscheme,
-				Type:      "client",
+				VarName:     ep.ClientStream.VarName,
+				Interface:   fmt.Sprintf("%s.%s", svc.PkgName, ep.ClientStream.Interface),
+				Endpoint:    ad,
+				Response:    ad.Result.Responses[0],
+				PkgName:     svc.PkgName,
+				Scheme:      wsscheme,
+				Type:        "client",
+				Binary:      a.BinaryStream,
+				NDJSON:      a.NDJSONStream,
+				KeepAlive:   keepAlive,
+				Compression: a.StreamCompression,
 			}
 			if ep.ServerStream.SendRef != "" {
 				// server streaming result
@@ -825,6 +1325,15 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 				ad.ClientStream.RecvName = ad.Result.Name
 				ad.ClientStream.RecvRef = ad.Result.Ref
 			}
+			if r := a.StreamResume; r != nil {
+				ad.ClientStream.Resume = &StreamResumeData{
+					VarName:      fmt.Sprintf("%sResumableClientStream", ep.VarName),
+					EndpointInit: fmt.Sprintf("%sResumable", ep.VarName),
+					Header:       r.Header,
+					TokenField:   codegen.GoifyAtt(a.MethodExpr.Result.Find(r.Attribute), r.Attribute, true),
+					TokenPointer: a.MethodExpr.Result.IsPrimitivePointer(r.Attribute, true),
+				}
+			}
 			if ep.ServerStream.RecvRef != "" {
 				// client streaming payload
 				ad.ServerStream.RecvName = ad.Payload.Name
@@ -837,6 +1346,41 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 		rd.Endpoints = append(rd.Endpoints, ad)
 	}
 
+	if len(rd.Endpoints) > 0 {
+		cors := hs.CORS
+		if cors == nil {
+			cors = httpdesign.Root.CORS
+		}
+		if cors != nil {
+			rd.CORS = buildCORSData(cors, rd)
+		}
+		comp := hs.Compression
+		if comp == nil {
+			comp = httpdesign.Root.Compression
+		}
+		if comp != nil {
+			rd.CompressMinSize = comp.MinSize
+			for _, ed := range rd.Endpoints {
+				// Streaming endpoints use websocket connections.
+				ed.Compress = ed.ServerStream == nil
+			}
+		}
+	}
+
+	for _, ed := range rd.Endpoints {
+		rd.RouteTable = append(rd.RouteTable, ed.Routes...)
+	}
+	if rd.CORS != nil {
+		for _, p := range rd.CORS.Paths {
+			rd.RouteTable = append(rd.RouteTable, newRouteData("OPTIONS", p))
+		}
+	}
+	for _, fs := range rd.FileServers {
+		for _, p := range fs.RequestPaths {
+			rd.RouteTable = append(rd.RouteTable, newRouteData("GET", p))
+		}
+	}
+
 	for _, a := range hs.HTTPEndpoints {
 		collectUserTypes(a.Body.Type, func(ut design.UserType) {
 			if d := attributeTypeData(ut, true, true, true, svc.Scope, rd); d != nil {
@@ -875,6 +1419,112 @@ func (d ServicesData) analyze(hs *httpdesign.ServiceExpr) *ServiceData {
 	return rd
 }
 
+// newRouteData returns the data for the route with the given verb and path.
+func newRouteData(verb, path string) *RouteData {
+	segs := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	var wcs []int
+	for i, s := range segs {
+		if httpdesign.WildcardRegex.MatchString("/" + s) {
+			wcs = append(wcs, i)
+		}
+	}
+	return &RouteData{Verb: verb, Path: path, Segments: segs, Wildcards: wcs}
+}
+
+// buildCORSData returns the data structure used to render the CORS handlers of
+// the service described by sd. The methods default to the HTTP methods used by
+// the service endpoints if not set explicitly in the design.
+func buildCORSData(cors *httpdesign.CORSExpr, sd *ServiceData) *CORSData {
+	var (
+		paths   []string
+		methods = cors.Methods
+		seen    = make(map[string]bool)
+		verbs   = make(map[string]bool)
+		options = make(map[string]bool)
+	)
+	for _, e := range sd.Endpoints {
+		for _, r := range e.Routes {
+			if r.Verb == "OPTIONS" {
+				options[r.Path] = true
+			}
+		}
+	}
+	for _, e := range sd.Endpoints {
+		for _, r := range e.Routes {
+			if !seen[r.Path] && !options[r.Path] {
+				paths = append(paths, r.Path)
+			}
+			seen[r.Path] = true
+			if len(cors.Methods) == 0 && !verbs[r.Verb] {
+				methods = append(methods, r.Verb)
+				verbs[r.Verb] = true
+			}
+		}
+	}
+	var maxAge string
+	if cors.MaxAge > 0 {
+		maxAge = strconv.FormatUint(uint64(cors.MaxAge), 10)
+	}
+	return &CORSData{
+		OriginHandler: fmt.Sprintf("handle%sOrigin", sd.Service.StructName),
+		HandlerInit:   "NewCORSHandler",
+		MountHandler:  "MountCORSHandler",
+		Origins:       cors.Origins,
+		Methods:       strings.Join(methods, ", "),
+		Headers:       strings.Join(cors.Headers, ", "),
+		MaxAge:        maxAge,
+		Paths:         paths,
+	}
+}
+
+// buildMultipartPartsData returns the data needed to map the parts of a
+// multipart request to the attributes of the payload that are not mapped to
+// params, headers or cookies.
+func buildMultipartPartsData(e *httpdesign.EndpointExpr, svc *service.Data) []*MultipartPartData {
+	var (
+		parts   []*MultipartPartData
+		payload = e.MethodExpr.Payload
+		body    = design.AsObject(httpdesign.RequestBody(e).Type)
+	)
+	if body == nil {
+		return nil
+	}
+	for _, nat := range *body {
+		att := payload.Find(nat.Name)
+		if att == nil {
+			continue
+		}
+		part := &MultipartPartData{
+			Name:      nat.Name,
+			FieldName: codegen.GoifyAtt(att, nat.Name, true),
+			VarName:   codegen.Goify(nat.Name, false),
+			TypeRef:   svc.Scope.GoFullTypeRef(att, svc.PkgName),
+			Type:      att.Type,
+			Pointer:   payload.IsPrimitivePointer(nat.Name, true),
+			Required:  payload.IsRequired(nat.Name),
+		}
+		if part.Pointer {
+			part.TypeRef = "*" + part.TypeRef
+		}
+		switch {
+		case design.IsPrimitive(att.Type) && att.Type != design.Any:
+		case design.IsArray(att.Type) && design.IsPrimitive(design.AsArray(att.Type).ElemType.Type) &&
+			design.AsArray(att.Type).ElemType.Type != design.Any:
+			elem := design.AsArray(att.Type).ElemType
+			part.Elem = &MultipartPartData{
+				Name:    nat.Name,
+				VarName: part.VarName,
+				TypeRef: svc.Scope.GoTypeRef(elem),
+				Type:    elem.Type,
+			}
+		default:
+			part.JSON = true
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
 // buildPayloadData returns the data structure used to describe the endpoint
 // payload including the HTTP request details. It also returns the user types
 // used by the request body type recursively if any.
@@ -890,14 +1540,21 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 	)
 	{
 		ep = svc.Method(e.MethodExpr.Name)
-		body = e.Body.Type
+		bodyAtt := e.Body
+		if e.SkipRequestBodyEncodeDecode {
+			// The service method reads the raw request body, there is
+			// no body type to generate.
+			bodyAtt = &design.AttributeExpr{Type: design.Empty}
+		}
+		body = bodyAtt.Type
 
 		var (
-			serverBodyData = buildBodyType(sd, e, e.Body, payload, true, true, false, svc.PkgName)
-			clientBodyData = buildBodyType(sd, e, e.Body, payload, true, false, false, svc.PkgName)
-			paramsData     = extractPathParams(e.PathParams(), payload, svc.Scope)
-			queryData      = extractQueryParams(e.QueryParams(), payload, svc.Scope)
-			headersData    = extractHeaders(e.Headers, payload, true, svc.Scope)
+			serverBodyData = buildBodyType(sd, e, bodyAtt, payload, true, true, false, svc.PkgName)
+			clientBodyData = buildBodyType(sd, e, bodyAtt, payload, true, false, false, svc.PkgName)
+			paramsData     = extractPathParams(e.PathParams(), payload, svc.PkgName, svc.Scope, svc.Random)
+			queryData      = extractQueryParams(e.QueryParams(), payload, svc.PkgName, svc.Scope, svc.Random)
+			headersData    = extractHeaders(e.Headers, payload, svc.PkgName, svc.Scope, svc.Random)
+			cookiesData    = extractCookies(e.Cookies, payload, svc.PkgName, svc.Scope, svc.Random)
 
 			mustValidate bool
 		)
@@ -929,7 +1586,7 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 					Map:            design.AsMap(payload.Type) != nil,
 					Validate:       codegen.RecursiveValidationCode(payload, required, false, false, varn),
 					DefaultValue:   pAtt.DefaultValue,
-					Example:        pAtt.Example(design.Root.API.Random()),
+					Example:        pAtt.Example(sd.Service.Random),
 					MapQueryParams: e.MapQueryParams,
 				}
 				queryData = append(queryData, mapQueryParam)
@@ -960,15 +1617,27 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 					}
 				}
 			}
+			if !mustValidate {
+				for _, c := range cookiesData {
+					if c.Validate != "" || c.Required || needConversion(c.Type) {
+						mustValidate = true
+						break
+					}
+				}
+			}
 		}
 		request = &RequestData{
 			PathParams:   paramsData,
 			QueryParams:  queryData,
 			Headers:      headersData,
+			Cookies:      cookiesData,
 			ServerBody:   serverBodyData,
 			ClientBody:   clientBodyData,
 			MustValidate: mustValidate,
 		}
+		if mustValidate && serverBodyData != nil && serverBodyData.ValidateRef != "" {
+			request.AggregateValidation = aggregateValidation(e.MethodExpr)
+		}
 	}
 
 	var (
@@ -1016,7 +1685,7 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 				TypeName: svc.Scope.GoTypeName(&design.AttributeExpr{Type: body}),
 				TypeRef:  svc.Scope.GoTypeRef(&design.AttributeExpr{Type: body}),
 				Required: true,
-				Example:  e.Body.Example(design.Root.API.Random()),
+				Example:  e.Body.Example(sd.Service.Random),
 				Validate: svcode,
 			}}
 			clientArgs = []*InitArgData{{
@@ -1025,12 +1694,16 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 				TypeName: svc.Scope.GoTypeName(&design.AttributeExpr{Type: body}),
 				TypeRef:  svc.Scope.GoTypeRef(&design.AttributeExpr{Type: body}),
 				Required: true,
-				Example:  e.Body.Example(design.Root.API.Random()),
+				Example:  codegen.WireExample(e.Body, e.Body.Example(sd.Service.Random)),
 				Validate: cvcode,
 			}}
 		}
 		var args []*InitArgData
 		for _, p := range request.PathParams {
+			// special case for path params that are not pointers
+			// (because path params never are) but assigned to fields
+			// that are.
+			pointer := !p.Required && !p.Pointer && payload.IsPrimitivePointer(p.Name, true)
 			args = append(args, &InitArgData{
 				Name:        p.VarName,
 				Description: p.Description,
@@ -1038,13 +1711,11 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 				FieldName:   p.FieldName,
 				TypeName:    p.TypeName,
 				TypeRef:     p.TypeRef,
-				// special case for path params that are not
-				// pointers (because path params never are) but
-				// assigned to fields that are.
-				Pointer:  !p.Required && !p.Pointer && payload.IsPrimitivePointer(p.Name, true),
-				Required: p.Required,
-				Validate: p.Validate,
-				Example:  p.Example,
+				Pointer:     pointer,
+				EnumTypeRef: enumTypeRef(p.EnumType, p.TypeRef, pointer),
+				Required:    p.Required,
+				Validate:    p.Validate,
+				Example:     p.Example,
 			})
 		}
 		for _, p := range request.QueryParams {
@@ -1054,12 +1725,14 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 				FieldName:    p.FieldName,
 				TypeName:     p.TypeName,
 				TypeRef:      p.TypeRef,
+				EnumTypeRef:  enumTypeRef(p.EnumType, p.TypeRef, false),
 				Required:     p.Required,
 				DefaultValue: p.DefaultValue,
 				Validate:     p.Validate,
 				Example:      p.Example,
 			})
 		}
+		var nestedArgs bool
 		for _, h := range request.Headers {
 			args = append(args, &InitArgData{
 				Name:         h.VarName,
@@ -1067,10 +1740,27 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 				FieldName:    h.FieldName,
 				TypeName:     h.TypeName,
 				TypeRef:      h.TypeRef,
+				EnumTypeRef:  enumTypeRef(h.EnumType, h.TypeRef, false),
 				Required:     h.Required,
 				DefaultValue: h.DefaultValue,
 				Validate:     h.Validate,
 				Example:      h.Example,
+				Nested:       h.Nested,
+			})
+			nestedArgs = nestedArgs || h.Nested != nil
+		}
+		for _, c := range request.Cookies {
+			args = append(args, &InitArgData{
+				Name:         c.VarName,
+				Ref:          c.VarName,
+				FieldName:    c.FieldName,
+				TypeName:     c.TypeName,
+				TypeRef:      c.TypeRef,
+				EnumTypeRef:  enumTypeRef(c.EnumType, c.TypeRef, false),
+				Required:     c.Required,
+				DefaultValue: c.DefaultValue,
+				Validate:     c.Validate,
+				Example:      c.Example,
 			})
 		}
 		serverArgs = append(serverArgs, args...)
@@ -1094,7 +1784,7 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 						TypeRef:     svc.Scope.GoTypeRef(uatt),
 						Pointer:     sc.UsernamePointer,
 						Validate:    codegen.RecursiveValidationCode(uatt, true, false, false, sc.UsernameAttr),
-						Example:     uatt.Example(design.Root.API.Random()),
+						Example:     uatt.Example(sd.Service.Random),
 					}
 					patt := e.MethodExpr.Payload.Find(sc.PasswordAttr)
 					parg := &InitArgData{
@@ -1107,7 +1797,7 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 						TypeRef:     svc.Scope.GoTypeRef(patt),
 						Pointer:     sc.PasswordPointer,
 						Validate:    codegen.RecursiveValidationCode(uatt, true, false, false, sc.PasswordAttr),
-						Example:     patt.Example(design.Root.API.Random()),
+						Example:     patt.Example(sd.Service.Random),
 					}
 					cliArgs = []*InitArgData{uarg, parg}
 					done = true
@@ -1174,6 +1864,7 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 			ReturnTypeName:      svc.Scope.GoFullTypeName(payload, svc.PkgName),
 			ReturnTypeRef:       svc.Scope.GoFullTypeRef(payload, svc.PkgName),
 			ReturnIsStruct:      isObject,
+			NestedArgs:          nestedArgs,
 			ReturnTypeAttribute: codegen.Goify(origin, true),
 			ServerCode:          serverCode,
 			ClientCode:          clientCode,
@@ -1195,6 +1886,8 @@ func buildPayloadData(e *httpdesign.EndpointExpr, sd *ServiceData) *PayloadData
 			returnValue = codegen.Goify((*o)[0].Name, false)
 		} else if o := design.AsObject(e.Headers.Type); o != nil && len(*o) > 0 {
 			returnValue = codegen.Goify((*o)[0].Name, false)
+		} else if o := design.AsObject(e.Cookies.Type); o != nil && len(*o) > 0 {
+			returnValue = codegen.Goify((*o)[0].Name, false)
 		} else if e.MapQueryParams != nil && *e.MapQueryParams == "" {
 			returnValue = mapQueryParam.Name
 		}
@@ -1250,6 +1943,8 @@ func buildResultData(e *httpdesign.EndpointExpr, sd *ServiceData) *ResultData {
 			var (
 				responseData   *ResponseData
 				headersData    []*HeaderData
+				cookiesData    []*CookieData
+				trailersData   []*HeaderData
 				serverBodyData *TypeData
 				clientBodyData *TypeData
 				init           *InitData
@@ -1263,9 +1958,15 @@ func buildResultData(e *httpdesign.EndpointExpr, sd *ServiceData) *ResultData {
 				if needInit(result.Type) {
 					init = buildResponseResultInit(v, e, sd)
 				}
-				headersData = extractHeaders(v.Headers, result, false, svc.Scope)
-				serverBodyData = buildBodyType(sd, e, v.Body, result, false, true, viewed, pkg)
-				clientBodyData = buildBodyType(sd, e, v.Body, result, false, false, viewed, pkg)
+				headersData = extractHeaders(v.Headers, result, pkg, svc.Scope, svc.Random)
+				cookiesData = extractCookies(v.Cookies, result, pkg, svc.Scope, svc.Random)
+				trailersData = extractHeaders(v.Trailers, result, pkg, svc.Scope, svc.Random)
+				if !e.SkipResponseBodyEncodeDecode {
+					// Endpoints that skip the response body encoding
+					// copy the reader returned by the service method.
+					serverBodyData = buildBodyType(sd, e, v.Body, result, false, true, viewed, pkg)
+					clientBodyData = buildBodyType(sd, e, v.Body, result, false, false, viewed, pkg)
+				}
 				if clientBodyData != nil {
 					sd.ServerTypeNames[clientBodyData.Name] = struct{}{}
 					sd.ClientTypeNames[clientBodyData.Name] = struct{}{}
@@ -1276,19 +1977,31 @@ func buildResultData(e *httpdesign.EndpointExpr, sd *ServiceData) *ResultData {
 						break
 					}
 				}
+				if !mustValidate {
+					for _, c := range cookiesData {
+						if c.Validate != "" || c.Required || needConversion(c.Type) {
+							mustValidate = true
+							break
+						}
+					}
+				}
 				responseData = &ResponseData{
-					StatusCode:   statusCodeToHTTPConst(v.StatusCode),
-					Description:  v.Description,
-					Headers:      headersData,
-					ServerBody:   serverBodyData,
-					ClientBody:   clientBodyData,
-					ResultInit:   init,
-					TagName:      codegen.Goify(v.Tag[0], true),
-					TagValue:     v.Tag[1],
-					TagRequired:  result.IsRequired(v.Tag[0]) && !viewed,
-					MustValidate: mustValidate,
-					ResultAttr:   codegen.Goify(origin, true),
-					ViewedResult: viewed,
+					StatusCode:      statusCodeToHTTPConst(v.StatusCode),
+					Description:     v.Description,
+					Headers:         headersData,
+					Cookies:         cookiesData,
+					ServerBody:      serverBodyData,
+					ClientBody:      clientBodyData,
+					ResultInit:      init,
+					TagName:         codegen.Goify(v.Tag[0], true),
+					TagValue:        v.Tag[1],
+					TagRequired:     result.IsRequired(v.Tag[0]) && !viewed,
+					MustValidate:    mustValidate,
+					ResultAttr:      codegen.Goify(origin, true),
+					ViewedResult:    viewed,
+					Produces:        v.Produces,
+					ConstantHeaders: constantHeadersData(v.ConstantHeaders),
+					Trailers:        trailersData,
 				}
 			}
 			responses = append(responses, responseData)
@@ -1383,14 +2096,26 @@ func buildResponseResultInit(resp *httpdesign.HTTPResponseExpr, e *httpdesign.En
 	if err != nil {
 		fmt.Println(err.Error()) // TBD validate DSL so errors are not possible
 	}
-	for _, h := range extractHeaders(resp.Headers, result, false, svc.Scope) {
+	for _, h := range extractHeaders(resp.Headers, result, pkg, svc.Scope, svc.Random) {
+		clientArgs = append(clientArgs, &InitArgData{
+			Name:        h.VarName,
+			Ref:         h.VarName,
+			FieldName:   h.FieldName,
+			TypeRef:     h.TypeRef,
+			EnumTypeRef: enumTypeRef(h.EnumType, h.TypeRef, false),
+			Validate:    h.Validate,
+			Example:     h.Example,
+		})
+	}
+	for _, c := range extractCookies(resp.Cookies, result, pkg, svc.Scope, svc.Random) {
 		clientArgs = append(clientArgs, &InitArgData{
-			Name:      h.VarName,
-			Ref:       h.VarName,
-			FieldName: h.FieldName,
-			TypeRef:   h.TypeRef,
-			Validate:  h.Validate,
-			Example:   h.Example,
+			Name:        c.VarName,
+			Ref:         c.VarName,
+			FieldName:   c.FieldName,
+			TypeRef:     c.TypeRef,
+			EnumTypeRef: enumTypeRef(c.EnumType, c.TypeRef, false),
+			Validate:    c.Validate,
+			Example:     c.Example,
 		})
 	}
 	status := codegen.Goify(http.StatusText(resp.StatusCode), true)
@@ -1449,14 +2174,15 @@ func buildErrorsData(e *httpdesign.EndpointExpr, sd *ServiceData) []*ErrorGroupD
 					}
 					args = []*InitArgData{{Name: "body", Ref: ref, TypeRef: svc.Scope.GoTypeRef(&design.AttributeExpr{Type: body})}}
 				}
-				for _, h := range extractHeaders(v.Response.Headers, v.ErrorExpr.AttributeExpr, false, svc.Scope) {
+				for _, h := range extractHeaders(v.Response.Headers, v.ErrorExpr.AttributeExpr, svc.PkgName, svc.Scope, svc.Random) {
 					args = append(args, &InitArgData{
-						Name:      h.VarName,
-						Ref:       h.VarName,
-						FieldName: h.FieldName,
-						TypeRef:   h.TypeRef,
-						Validate:  h.Validate,
-						Example:   h.Example,
+						Name:        h.VarName,
+						Ref:         h.VarName,
+						FieldName:   h.FieldName,
+						TypeRef:     h.TypeRef,
+						EnumTypeRef: enumTypeRef(h.EnumType, h.TypeRef, false),
+						Validate:    h.Validate,
+						Example:     h.Example,
 					})
 				}
 			}
@@ -1533,22 +2259,29 @@ func buildErrorsData(e *httpdesign.EndpointExpr, sd *ServiceData) []*ErrorGroupD
 			}
 
 			headers := extractHeaders(v.Response.Headers,
-				v.ErrorExpr.AttributeExpr, false, svc.Scope)
+				v.ErrorExpr.AttributeExpr, svc.PkgName, svc.Scope, svc.Random)
 			responseData = &ResponseData{
-				StatusCode:  statusCodeToHTTPConst(v.Response.StatusCode),
-				Headers:     headers,
-				ErrorHeader: v.Name,
-				ServerBody:  serverBodyData,
-				ClientBody:  clientBodyData,
-				ResultInit:  init,
+				StatusCode:      statusCodeToHTTPConst(v.Response.StatusCode),
+				Headers:         headers,
+				ErrorHeader:     v.Name,
+				ServerBody:      serverBodyData,
+				ClientBody:      clientBodyData,
+				ResultInit:      init,
+				Produces:        v.Response.Produces,
+				ConstantHeaders: constantHeadersData(v.Response.ConstantHeaders),
 			}
 		}
 
+		var errInit string
+		if v.ErrorExpr.Type == design.ErrorResult {
+			errInit = fmt.Sprintf("Make%s", codegen.Goify(v.Name, true))
+		}
 		ref := svc.Scope.GoFullTypeRef(v.ErrorExpr.AttributeExpr, svc.PkgName)
 		data[ref] = append(data[ref], &ErrorData{
 			Name:     v.Name,
 			Response: responseData,
 			Ref:      ref,
+			Init:     errInit,
 		})
 	}
 	keys := make([]string, len(data))
@@ -1620,6 +2353,8 @@ func buildBodyType(sd *ServiceData, e *httpdesign.EndpointExpr, body, att *desig
 		ref         string
 		validateDef string
 		validateRef string
+		encodeJSON  string
+		decodeJSON  string
 	)
 	{
 		name = body.Type.Name()
@@ -1627,6 +2362,9 @@ func buildBodyType(sd *ServiceData, e *httpdesign.EndpointExpr, body, att *desig
 		if ut, ok := body.Type.(design.UserType); ok {
 			varname = codegen.Goify(ut.Name(), true)
 			def = goTypeDef(svc.Scope, ut.Attribute(), !marshaled, marshaled)
+			if fastJSON(svc.Name) {
+				encodeJSON, decodeJSON = fastJSONDefs(svc.Scope, ut, !marshaled, marshaled)
+			}
 			ctx := "request"
 			if !req {
 				ctx = "response"
@@ -1706,7 +2444,7 @@ func buildBodyType(sd *ServiceData, e *httpdesign.EndpointExpr, body, att *desig
 			Ref:      ref,
 			TypeRef:  svc.Scope.GoFullTypeRef(att, pkg),
 			Validate: validateDef,
-			Example:  att.Example(design.Root.API.Random()),
+			Example:  att.Example(sd.Service.Random),
 		}
 		if svr {
 			init.ServerCode = code
@@ -1717,19 +2455,21 @@ func buildBodyType(sd *ServiceData, e *httpdesign.EndpointExpr, body, att *desig
 		}
 	}
 	return &TypeData{
-		Name:        name,
-		VarName:     varname,
-		Description: desc,
-		Init:        init,
-		Def:         def,
-		Ref:         ref,
-		ValidateDef: validateDef,
-		ValidateRef: validateRef,
-		Example:     body.Example(design.Root.API.Random()),
+		Name:          name,
+		VarName:       varname,
+		Description:   desc,
+		Init:          init,
+		Def:           def,
+		Ref:           ref,
+		ValidateDef:   validateDef,
+		ValidateRef:   validateRef,
+		EncodeJSONDef: encodeJSON,
+		DecodeJSONDef: decodeJSON,
+		Example:       body.Example(sd.Service.Random),
 	}
 }
 
-func extractPathParams(a *design.MappedAttributeExpr, serviceType *design.AttributeExpr, scope *codegen.NameScope) []*ParamData {
+func extractPathParams(a *design.MappedAttributeExpr, serviceType *design.AttributeExpr, pkg string, scope *codegen.NameScope, random *design.Random) []*ParamData {
 	var params []*ParamData
 	codegen.WalkMappedAttr(a, func(name, elem string, required bool, c *design.AttributeExpr) error {
 		var (
@@ -1751,13 +2491,14 @@ func extractPathParams(a *design.MappedAttributeExpr, serviceType *design.Attrib
 			TypeName:       scope.GoTypeName(c),
 			TypeRef:        scope.GoTypeRef(c),
 			Pointer:        false,
+			EnumType:       serviceEnumType(serviceType, name, pkg),
 			Slice:          arr != nil,
 			StringSlice:    arr != nil && arr.ElemType.Type.Kind() == design.StringKind,
 			Map:            false,
 			MapStringSlice: false,
 			Validate:       codegen.RecursiveValidationCode(c, true, false, false, varn),
 			DefaultValue:   c.DefaultValue,
-			Example:        c.Example(design.Root.API.Random()),
+			Example:        c.Example(random),
 		})
 		return nil
 	})
@@ -1765,7 +2506,7 @@ func extractPathParams(a *design.MappedAttributeExpr, serviceType *design.Attrib
 	return params
 }
 
-func extractQueryParams(a *design.MappedAttributeExpr, serviceType *design.AttributeExpr, scope *codegen.NameScope) []*ParamData {
+func extractQueryParams(a *design.MappedAttributeExpr, serviceType *design.AttributeExpr, pkg string, scope *codegen.NameScope, random *design.Random) []*ParamData {
 	var params []*ParamData
 	codegen.WalkMappedAttr(a, func(name, elem string, required bool, c *design.AttributeExpr) error {
 		var (
@@ -1792,6 +2533,7 @@ func extractQueryParams(a *design.MappedAttributeExpr, serviceType *design.Attri
 			TypeName:      scope.GoTypeName(c),
 			TypeRef:       typeRef,
 			Pointer:       a.IsPrimitivePointer(name, true),
+			EnumType:      serviceEnumType(serviceType, name, pkg),
 			Slice:         arr != nil,
 			StringSlice:   arr != nil && arr.ElemType.Type.Kind() == design.StringKind,
 			Map:           mp != nil,
@@ -1801,7 +2543,9 @@ func extractQueryParams(a *design.MappedAttributeExpr, serviceType *design.Attri
 				design.AsArray(mp.ElemType.Type).ElemType.Type.Kind() == design.StringKind,
 			Validate:     codegen.RecursiveValidationCode(c, required, false, c.DefaultValue != nil, varn),
 			DefaultValue: c.DefaultValue,
-			Example:      c.Example(design.Root.API.Random()),
+			Example:      c.Example(random),
+			Delimiter:    queryDelimiter(c),
+			DeepObject:   queryStyle(c) == httpdesign.QueryStyleDeepObject,
 		})
 		return nil
 	})
@@ -1809,7 +2553,47 @@ func extractQueryParams(a *design.MappedAttributeExpr, serviceType *design.Attri
 	return params
 }
 
-func extractHeaders(a *design.MappedAttributeExpr, serviceType *design.AttributeExpr, req bool, scope *codegen.NameScope) []*HeaderData {
+// queryStyle returns the serialization style of the query param described by
+// att as defined by the "http:query:style" metadata.
+func queryStyle(att *design.AttributeExpr) string {
+	if s, ok := att.Metadata["http:query:style"]; ok && len(s) > 0 {
+		return s[0]
+	}
+	return httpdesign.QueryStyleForm
+}
+
+// queryDelimiter returns the separator used to encode the elements of the
+// array query param described by att, empty if the param uses the default
+// style.
+func queryDelimiter(att *design.AttributeExpr) string {
+	switch queryStyle(att) {
+	case httpdesign.QueryStylePipeDelimited:
+		return "|"
+	case httpdesign.QueryStyleSpaceDelimited:
+		return " "
+	}
+	return ""
+}
+
+// constantHeadersData returns the data describing the given constant headers
+// sorted by name.
+func constantHeadersData(headers map[string]string) []*ConstantHeaderData {
+	if len(headers) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(headers))
+	for n := range headers {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	res := make([]*ConstantHeaderData, len(names))
+	for i, n := range names {
+		res[i] = &ConstantHeaderData{Name: n, Value: headers[n]}
+	}
+	return res
+}
+
+func extractHeaders(a *design.MappedAttributeExpr, serviceType *design.AttributeExpr, pkg string, scope *codegen.NameScope, random *design.Random) []*HeaderData {
 	var headers []*HeaderData
 	for _, nat := range *design.AsObject(a.Type) {
 		var (
@@ -1821,12 +2605,18 @@ func extractHeaders(a *design.MappedAttributeExpr, serviceType *design.Attribute
 			fieldName string
 			pointer   bool
 			hattr     *design.AttributeExpr
+			nested    *NestedFieldData
 		)
 		if design.IsObject(serviceType.Type) {
 			hattr = serviceType.Find(name) // this should not be nil because we validated
-			required = serviceType.IsRequired(name)
-			fieldName = codegen.Goify(name, true)
-			pointer = serviceType.IsPrimitivePointer(name, req)
+			if hattr == nil {
+				hattr, required, fieldName, nested = nestedHeader(serviceType, name, pkg, scope)
+				pointer = nested.CheckNil && design.IsPrimitive(hattr.Type)
+			} else {
+				required = serviceType.IsRequired(name)
+				fieldName = codegen.Goify(name, true)
+				pointer = serviceType.IsPrimitivePointer(name, true)
+			}
 		} else {
 			hattr = serviceType
 		}
@@ -1846,17 +2636,142 @@ func extractHeaders(a *design.MappedAttributeExpr, serviceType *design.Attribute
 			TypeRef:       typeRef,
 			Required:      required,
 			Pointer:       pointer,
+			EnumType:      serviceEnumType(serviceType, name, pkg),
 			Slice:         arr != nil,
 			StringSlice:   arr != nil && arr.ElemType.Type.Kind() == design.StringKind,
 			Type:          hattr.Type,
 			Validate:      codegen.RecursiveValidationCode(hattr, required, false, hattr.DefaultValue != nil, varn),
 			DefaultValue:  hattr.DefaultValue,
-			Example:       hattr.Example(design.Root.API.Random()),
+			Example:       hattr.Example(random),
+			Nested:        nested,
 		})
 	}
 	return headers
 }
 
+// nestedHeader returns the payload attribute nested in the object attributes of
+// serviceType that holds the value of the header mapped to the given dot
+// separated path, whether the header is required, the selector of the
+// corresponding struct field and the data describing the parent fields.
+func nestedHeader(serviceType *design.AttributeExpr, path, pkg string, scope *codegen.NameScope) (*design.AttributeExpr, bool, string, *NestedFieldData) {
+	var (
+		parents  []*NestedParentData
+		fields   []string
+		required = true
+		parent   = serviceType
+		segments = strings.Split(path, ".")
+	)
+	for i, seg := range segments {
+		att := design.AsObject(parent.Type).Attribute(seg)
+		fields = append(fields, codegen.GoifyAtt(att, seg, true))
+		required = required && parent.IsRequired(seg)
+		if i == len(segments)-1 {
+			pointer := parent.IsPrimitivePointer(seg, true)
+			checkNil := !required && att.DefaultValue == nil
+			return att, required, strings.Join(fields, "."), &NestedFieldData{
+				Parents:      parents,
+				CheckNil:     checkNil,
+				FieldPointer: pointer,
+				Deref:        checkNil && design.IsPrimitive(att.Type) && !pointer,
+			}
+		}
+		parents = append(parents, &NestedParentData{
+			FieldName: strings.Join(fields, "."),
+			TypeName:  scope.GoFullTypeName(att, pkg),
+		})
+		parent = att
+	}
+	return nil, false, "", nil
+}
+
+func extractCookies(a *design.MappedAttributeExpr, serviceType *design.AttributeExpr, pkg string, scope *codegen.NameScope, random *design.Random) []*CookieData {
+	var (
+		cookies  []*CookieData
+		maxAge   string
+		secure   bool
+		httpOnly bool
+	)
+	if v, ok := a.Metadata["cookie:max-age"]; ok {
+		maxAge = v[0]
+	}
+	_, secure = a.Metadata["cookie:secure"]
+	_, httpOnly = a.Metadata["cookie:http-only"]
+	for _, nat := range *design.AsObject(a.Type) {
+		var (
+			name     = nat.Name
+			elem     = a.ElemName(nat.Name)
+			varn     = scope.Unique(codegen.Goify(name, false))
+			required = true
+
+			fieldName string
+			pointer   bool
+			cattr     *design.AttributeExpr
+		)
+		if design.IsObject(serviceType.Type) {
+			cattr = serviceType.Find(name) // this should not be nil because we validated
+			required = serviceType.IsRequired(name)
+			fieldName = codegen.Goify(name, true)
+			pointer = serviceType.IsPrimitivePointer(name, true)
+		} else {
+			cattr = serviceType
+		}
+		typeRef := scope.GoTypeRef(cattr)
+		if pointer {
+			typeRef = "*" + typeRef
+		}
+		cookies = append(cookies, &CookieData{
+			Name:          elem,
+			AttributeName: name,
+			Description:   cattr.Description,
+			FieldName:     fieldName,
+			VarName:       varn,
+			TypeName:      scope.GoTypeName(cattr),
+			TypeRef:       typeRef,
+			Required:      required,
+			Pointer:       pointer,
+			EnumType:      serviceEnumType(serviceType, name, pkg),
+			Type:          cattr.Type,
+			Validate:      codegen.RecursiveValidationCode(cattr, required, false, cattr.DefaultValue != nil, varn),
+			DefaultValue:  cattr.DefaultValue,
+			Example:       cattr.Example(random),
+			MaxAge:        maxAge,
+			Secure:        secure,
+			HTTPOnly:      httpOnly,
+		})
+	}
+	return cookies
+}
+
+// serviceEnumType returns the qualified name of the enum type used by the field
+// of serviceType that corresponds to the attribute with the given name, the
+// empty string if the field does not use an enum type.
+func serviceEnumType(serviceType *design.AttributeExpr, name, pkg string) string {
+	if !design.IsObject(serviceType.Type) {
+		return ""
+	}
+	att := serviceType.Find(name)
+	if att == nil {
+		return ""
+	}
+	if n := codegen.GoEnumTypeName(att, name); n != "" {
+		return pkg + "." + n
+	}
+	return ""
+}
+
+// enumTypeRef returns the conversion used to assign a value of the given type
+// to a field that uses the given enum type, the empty string if enumType is
+// empty.
+func enumTypeRef(enumType, typeRef string, pointer bool) string {
+	if enumType == "" {
+		return ""
+	}
+	if pointer || strings.HasPrefix(typeRef, "*") {
+		return "(*" + enumType + ")"
+	}
+	return enumType
+}
+
 // collectUserTypes traverses the given data type recursively and calls back the
 // given function for each attribute using a user type.
 func collectUserTypes(dt design.DataType, cb func(design.UserType), seen ...map[string]struct{}) {
@@ -1909,6 +2824,8 @@ func attributeTypeData(ut design.UserType, req, ptr, server bool, scope *codegen
 		def         string
 		validate    string
 		validateRef string
+		encodeJSON  string
+		decodeJSON  string
 	)
 	{
 		name = scope.GoTypeName(att)
@@ -1924,20 +2841,25 @@ func attributeTypeData(ut design.UserType, req, ptr, server bool, scope *codegen
 		useDefault := !req && server || req && !server
 
 		def = goTypeDef(scope, ut.Attribute(), ptr, useDefault)
+		if fastJSON(rd.Service.Name) {
+			encodeJSON, decodeJSON = fastJSONDefs(scope, ut, ptr, useDefault)
+		}
 		validate = codegen.RecursiveValidationCode(ut.Attribute(), true, ptr, useDefault, "body")
 		if validate != "" {
 			validateRef = "err = v.Validate()"
 		}
 	}
 	return &TypeData{
-		Name:        ut.Name(),
-		VarName:     name,
-		Description: desc,
-		Def:         def,
-		Ref:         scope.GoTypeRef(att),
-		ValidateDef: validate,
-		ValidateRef: validateRef,
-		Example:     att.Example(design.Root.API.Random()),
+		Name:          ut.Name(),
+		VarName:       name,
+		Description:   desc,
+		Def:           def,
+		Ref:           scope.GoTypeRef(att),
+		ValidateDef:   validate,
+		ValidateRef:   validateRef,
+		EncodeJSONDef: encodeJSON,
+		DecodeJSONDef: decodeJSON,
+		Example:       att.Example(rd.Service.Random),
 	}
 }
 
@@ -1955,6 +2877,110 @@ func appendUnique(s []*service.SchemeData, d *service.SchemeData) []*service.Sch
 	return append(s, d)
 }
 
+// problemErrors returns true if the "http:error:problem" metadata is set on the
+// API.
+func problemErrors() bool {
+	if design.Root.API == nil {
+		return false
+	}
+	_, ok := design.Root.API.Metadata["http:error:problem"]
+	return ok
+}
+
+// panicErrorName returns the name of the error used to report panics recovered
+// by the endpoint handler.
+func panicErrorName(a *httpdesign.EndpointExpr) string {
+	for _, v := range a.HTTPErrors {
+		if v.Response.StatusCode == http.StatusInternalServerError && v.ErrorExpr.Type == design.ErrorResult {
+			return v.ErrorExpr.Name
+		}
+	}
+	return "fault"
+}
+
+// otelTracing returns true if the "http:trace:otel" metadata is set on the
+// service with the given name or on the API.
+func otelTracing(svc string) bool {
+	if s := design.Root.Service(svc); s != nil {
+		if _, ok := s.Metadata["http:trace:otel"]; ok {
+			return true
+		}
+	}
+	if design.Root.API == nil {
+		return false
+	}
+	_, ok := design.Root.API.Metadata["http:trace:otel"]
+	return ok
+}
+
+// buildConsumesData returns the data needed to render the request decoder of
+// an endpoint that lists the request content types with Consumes.
+func buildConsumesData(a *httpdesign.EndpointExpr) *ConsumesData {
+	var data ConsumesData
+	for _, c := range a.Consumes {
+		if mt, _, err := mime.ParseMediaType(c); err == nil {
+			c = mt
+		}
+		if data.Default == "" {
+			data.Default = c
+		}
+		if c == "application/x-www-form-urlencoded" || c == "multipart/form-data" {
+			data.Form = append(data.Form, c)
+		} else {
+			data.Decoder = append(data.Decoder, c)
+		}
+		data.All = append(data.All, c)
+	}
+	return &data
+}
+
+// buildTracingData returns the data needed to create the OpenTelemetry spans
+// of the endpoint, nil if tracing is not enabled for the service. The payload
+// attributes with the "http:trace:attribute" metadata are recorded as span
+// attributes using the metadata value as key if any, the attribute name
+// otherwise.
+func buildTracingData(a *httpdesign.EndpointExpr, svc *service.Data) *TracingData {
+	if !otelTracing(svc.Name) {
+		return nil
+	}
+	td := &TracingData{SpanName: svc.Name + "." + a.MethodExpr.Name}
+	if _, ok := a.MethodExpr.Payload.Type.(design.UserType); !ok {
+		return td
+	}
+	obj := design.AsObject(a.MethodExpr.Payload.Type)
+	if obj == nil {
+		return td
+	}
+	for _, nat := range *obj {
+		vals, ok := nat.Attribute.Metadata["http:trace:attribute"]
+		if !ok {
+			continue
+		}
+		key := nat.Name
+		if len(vals) > 0 && vals[0] != "" {
+			key = vals[0]
+		}
+		td.Attributes = append(td.Attributes, &SpanAttributeData{
+			Key:       key,
+			FieldName: codegen.GoifyAtt(nat.Attribute, nat.Name, true),
+		})
+	}
+	return td
+}
+
+// aggregateValidation returns true if the "validation:aggregate" metadata is
+// set on the method or on the API.
+func aggregateValidation(m *design.MethodExpr) bool {
+	if _, ok := m.Metadata["validation:aggregate"]; ok {
+		return true
+	}
+	if design.Root.API == nil {
+		return false
+	}
+	_, ok := design.Root.API.Metadata["validation:aggregate"]
+	return ok
+}
+
 // needConversion returns true if the type needs to be converted from a string.
 func needConversion(dt design.DataType) bool {
 	if dt == design.Empty {
@@ -2021,6 +3047,7 @@ const (
 	{{- end }}
 	return fmt.Sprintf("{{ .PathFormat }}", {{ range $i, $arg := .Args }}
 	{{- if eq (index $.PathParams $i).Attribute.Type.Name "array" }}strings.Join({{ .Name }}Slice, ", ")
+	{{- else if eq .Name $.CatchAll }}strings.TrimPrefix({{ .Name }}, "/")
 	{{- else }}{{ .Name }}
 	{{- end }}, {{ end }})
 {{- else }}
@@ -2061,7 +3088,7 @@ const (
 		{{- if .Pointer }}
 		if p.{{ .FieldName }} != nil {
 		{{- end }}
-			{{ .Name }} = {{ if .Pointer }}*{{ end }}p.{{ .FieldName }}
+			{{ .Name }} = {{ if .EnumTypeRef }}{{ .TypeName }}({{ end }}{{ if .Pointer }}*{{ end }}p.{{ .FieldName }}{{ if .EnumTypeRef }}){{ end }}
 		{{- if .Pointer }}
 		}
 		{{- end }}
@@ -2075,7 +3102,19 @@ const (
 	}
 	if ctx != nil {
 		req = req.WithContext(ctx)
+{{- if .Tracing }}
+		goaotel.Inject(ctx, req)
+{{- end }}
 	}
+{{- if .ContentType }}
+	req.Header.Set("Content-Type", {{ printf "%q" .ContentType }})
+{{- end }}
+{{- if .Accept }}
+	req.Header.Set("Accept", {{ printf "%q" .Accept }})
+{{- end }}
+{{- if .VersionHeader }}
+	req.Header.Set({{ printf "%q" .VersionHeader }}, {{ printf "%q" .Version }})
+{{- end }}
 
 	return req, nil`
 
@@ -2097,10 +3136,18 @@ type {{ .VarName }} struct {
 {{- end }}
 	{{ comment "conn is the underlying websocket connection." }}
 	conn *websocket.Conn
+	{{- if .Binary }}
+	{{ comment "codec marshals and unmarshals the binary websocket messages." }}
+	codec goahttp.StreamCodec
+	{{- end }}
 	{{- if .Endpoint.Method.ViewedResult }}
 	{{ printf "view is the view to render %s result type before sending to the websocket connection." .SendName | comment }}
 	view string
 	{{- end }}
+	{{- if and .KeepAlive (eq .Type "server") }}
+	{{ comment "stopKeepAlive stops sending pings over the websocket connection." }}
+	stopKeepAlive func()
+	{{- end }}
 }
 `
 
@@ -2125,6 +3172,9 @@ func (s *{{ .VarName }}) Send(v {{ .SendRef }}) error {
 			conn = s.connConfigFn(conn)
 		}
 		s.conn = conn
+	{{- if .KeepAlive }}
+		s.stopKeepAlive = goahttp.KeepAlive(conn, {{ .KeepAlive.PingInterval }}, {{ .KeepAlive.PongWait }}, {{ .KeepAlive.WriteWait }})
+	{{- end }}
 	})
 	if err != nil {
 		s.Close()
@@ -2136,7 +3186,20 @@ func (s *{{ .VarName }}) Send(v {{ .SendRef }}) error {
 	res := v
 	{{- end }}
 	body := {{ .Response.ServerBody.Init.Name }}({{ range .Response.ServerBody.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+	{{- if .KeepAlive }}
+	if err := s.conn.SetWriteDeadline(time.Now().Add({{ .KeepAlive.WriteWait }})); err != nil {
+		return err
+	}
+	{{- end }}
+	{{- if .Binary }}
+	msg, err := s.codec.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return s.conn.WriteMessage(websocket.BinaryMessage, msg)
+	{{- else }}
 	return s.conn.WriteJSON(body)
+	{{- end }}
 }
 `
 
@@ -2146,13 +3209,37 @@ func (s *{{ .VarName }}) Send(v {{ .SendRef }}) error {
 	streamRecvT = `{{ printf "Recv receives a %s type from the %q endpoint websocket connection." .RecvName .Endpoint.Method.Name | comment }}
 func (s *{{ .VarName }}) Recv() ({{ .RecvRef }}, error) {
 	var body {{ .Response.ClientBody.VarName }}
+	{{- if .Binary }}
+	_, msg, err := s.conn.ReadMessage()
+	{{- else }}
 	err := s.conn.ReadJSON(&body)
+	{{- end }}
 	if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
 		return nil, io.EOF
 	}
+	if serr := goahttp.DecodeStreamError(err); serr != nil {
+	{{- if hasErrorInit .Endpoint.Errors }}
+		switch serr.Name {
+		{{- range .Endpoint.Errors }}
+			{{- range .Errors }}
+				{{- if .Init }}
+		case {{ printf "%q" .Name }}:
+			return nil, {{ $.PkgName }}.{{ .Init }}(serr)
+				{{- end }}
+			{{- end }}
+		{{- end }}
+		}
+	{{- end }}
+		return nil, serr
+	}
 	if err != nil {
 		return nil, err
 	}
+	{{- if .Binary }}
+	if err = s.codec.Unmarshal(msg, &body); err != nil {
+		return nil, err
+	}
+	{{- end }}
 	{{- if and .Response.ClientBody.ValidateRef (not .Endpoint.Method.ViewedResult) }}
 	{{ .Response.ClientBody.ValidateRef }}
 	if err != nil {
@@ -2180,6 +3267,9 @@ func (s *{{ .VarName }}) Close() error {
 	if s.conn == nil {
 		return nil
 	}
+	{{- if .KeepAlive }}
+	s.stopKeepAlive()
+	{{- end }}
 	err := s.conn.WriteControl(
 		websocket.CloseMessage,
 		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "end of message"),
@@ -2193,14 +3283,190 @@ func (s *{{ .VarName }}) Close() error {
 	}
 	return s.conn.Close()
 }
+`
+
+	// streamCloseErrorT renders the function that closes the server stream
+	// after reporting an error returned by the service method.
+	// input: StreamData
+	streamCloseErrorT = `{{ printf "closeWithError closes the %q endpoint websocket connection after sending a close control message that reports err." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) closeWithError(err error) error {
+	{{- if .KeepAlive }}
+	s.stopKeepAlive()
+	{{- end }}
+	resp := goahttp.NewErrorResponse(err)
+	status, name := resp.StatusCode(), resp.Name
+	{{- if .Endpoint.Errors }}
+	if en, ok := err.(ErrorNamer); ok {
+		switch en.ErrorName() {
+		{{- range $gerr := .Endpoint.Errors }}
+			{{- range .Errors }}
+		case {{ printf "%q" .Name }}:
+			status, name = {{ $gerr.StatusCode }}, {{ printf "%q" .Name }}
+			{{- end }}
+		{{- end }}
+		}
+	}
+	{{- end }}
+	werr := s.conn.WriteControl(
+		websocket.CloseMessage,
+		goahttp.FormatStreamError(status, name, err.Error()),
+		time.Now().Add(time.Second),
+	)
+	if werr != nil && werr != websocket.ErrCloseSent {
+		s.conn.Close()
+		return werr
+	}
+	return s.conn.Close()
+}
 `
 
 	// streamSetViewT renders the function implementing the SetView method in
 	// server stream interface.
 	// input: StreamData
-	streamSetViewT = `{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint websocket connection." .SendName .Endpoint.Method.Name | comment }}
+	streamSetViewT = `{{ if .NDJSON }}{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint HTTP response." .SendName .Endpoint.Method.Name | comment }}{{ else }}{{ printf "SetView sets the view to render the %s type before sending to the %q endpoint websocket connection." .SendName .Endpoint.Method.Name | comment }}{{ end }}
 func (s *{{ .VarName }}) SetView(view string) {
+{{- if and (eq .Type "server") (not .Endpoint.Method.ViewedResult.ViewName) }}
+	if v := goahttp.RequestedView(s.r); v != "" {
+		// The view requested by the client takes precedence.
+		view = v
+	}
+{{- end }}
 	s.view = view
 }
+`
+
+	// ndjsonStreamStructTypeT renders the server and client struct types
+	// that implement the stream interfaces using newline delimited JSON.
+	// input: StreamData
+	ndjsonStreamStructTypeT = `{{ printf "%s implements the %s interface." .VarName .Interface | comment }}
+type {{ .VarName }} struct {
+{{- if eq .Type "server" }}
+	{{ comment "w is the HTTP response writer the results are written to." }}
+	w http.ResponseWriter
+	{{ comment "r is the HTTP request." }}
+	r *http.Request
+	{{ comment "started is true once the response status and headers are written." }}
+	started bool
+{{- else }}
+	{{ comment "body is the HTTP response body the results are read from." }}
+	body io.ReadCloser
+	{{ comment "dec decodes the newline delimited JSON results." }}
+	dec *json.Decoder
+{{- end }}
+	{{- if .Endpoint.Method.ViewedResult }}
+	{{ comment "view is the view used to render the results." }}
+	view string
+	{{- end }}
+}
+`
+
+	// ndjsonStreamSendT renders the function implementing the Send method
+	// in server stream interface using newline delimited JSON.
+	// input: StreamData
+	ndjsonStreamSendT = `{{ printf "Send writes %s type to the %q endpoint HTTP response as a line of newline delimited JSON." .SendName .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Send(v {{ .SendRef }}) error {
+	s.writeHeader()
+	{{- if .Endpoint.Method.ViewedResult }}
+	res := {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.Init.Name }}(v, s.view)
+	{{- else }}
+	res := v
+	{{- end }}
+	body := {{ .Response.ServerBody.Init.Name }}({{ range .Response.ServerBody.Init.ServerArgs }}{{ .Ref }}, {{ end }})
+	if err := json.NewEncoder(s.w).Encode(body); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+{{ comment "writeHeader writes the response status and headers before the first result is sent." }}
+func (s *{{ .VarName }}) writeHeader() {
+	if s.started {
+		return
+	}
+	s.started = true
+	s.w.Header().Set("Content-Type", "application/x-ndjson")
+	{{- if .Endpoint.Method.ViewedResult }}
+	s.w.Header().Set("goa-view", s.view)
+	{{- end }}
+	s.w.WriteHeader({{ .Response.StatusCode }})
+}
+`
+
+	// ndjsonStreamRecvT renders the function implementing the Recv method
+	// in client stream interface using newline delimited JSON.
+	// input: StreamData
+	ndjsonStreamRecvT = `{{ printf "Recv reads a %s type from the %q endpoint newline delimited JSON HTTP response." .RecvName .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Recv() ({{ .RecvRef }}, error) {
+	var body {{ .Response.ClientBody.VarName }}
+	err := s.dec.Decode(&body)
+	if err == io.EOF {
+		s.body.Close()
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	{{- if and .Response.ClientBody.ValidateRef (not .Endpoint.Method.ViewedResult) }}
+	{{ .Response.ClientBody.ValidateRef }}
+	if err != nil {
+		return nil, goahttp.ErrValidationError("{{ .Endpoint.ServiceName }}", "{{ .Endpoint.Method.Name }}", err)
+	}
+	{{- end }}
+	res := {{ .Response.ResultInit.Name }}({{ range .Response.ResultInit.ClientArgs }}{{ .Ref }},{{ end }})
+	{{- if .Endpoint.Method.ViewedResult }}
+	vres := {{ if not .Endpoint.Method.ViewedResult.IsCollection }}&{{ end }}{{ .Endpoint.Method.ViewedResult.ViewsPkg }}.{{ .Endpoint.Method.ViewedResult.VarName }}{res, s.view}
+	if err := vres.Validate(); err != nil {
+		return nil, goahttp.ErrValidationError("{{ .Endpoint.ServiceName }}", "{{ .Endpoint.Method.Name }}", err)
+	}
+	return {{ .PkgName }}.{{ .Endpoint.Method.ViewedResult.ResultInit.Name }}(vres), nil
+	{{- else }}
+	return res, nil
+	{{- end }}
+}
+`
+
+	// ndjsonStreamCloseT renders the function implementing the Close method
+	// in server stream interface using newline delimited JSON.
+	// input: StreamData
+	ndjsonStreamCloseT = `{{ printf "Close ends the %q endpoint stream, the response status and headers are written if no result was sent." .Endpoint.Method.Name | comment }}
+func (s *{{ .VarName }}) Close() error {
+	s.writeHeader()
+	return nil
+}
 `
 )
+
+// buildServersData returns the data describing the hosts of the given service.
+// The service hosts default to the API hosts.
+func buildServersData(svc *httpdesign.ServiceExpr) []*ServerData {
+	servers := svc.ServiceExpr.Servers
+	if len(servers) == 0 && design.Root.API != nil {
+		servers = design.Root.API.Servers
+	}
+	data := make([]*ServerData, len(servers))
+	for i, s := range servers {
+		sd := &ServerData{URL: s.URL, Description: s.Description}
+		for _, name := range design.URLParams(s.URL) {
+			att := s.Param(name)
+			if att == nil {
+				continue
+			}
+			v := &ServerVariableData{
+				Name:         name,
+				Description:  att.Description,
+				DefaultValue: fmt.Sprint(att.DefaultValue),
+			}
+			if att.Validation != nil {
+				for _, val := range att.Validation.Values {
+					v.Values = append(v.Values, fmt.Sprint(val))
+				}
+			}
+			sd.Variables = append(sd.Variables, v)
+		}
+		data[i] = sd
+	}
+	return data
+}
@@ -0,0 +1,72 @@
+package codegen
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestServicesDataGet(t *testing.T) {
+	RunHTTPDSL(t, testdata.PayloadBodyUserInnerDefaultDSL)
+	var (
+		d    = NewServicesData()
+		wg   sync.WaitGroup
+		data = make([]*ServiceData, 10)
+	)
+	for i := range data {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			data[i] = d.Get("ServiceBodyUserInnerDefault")
+		}(i)
+	}
+	wg.Wait()
+	if data[0] == nil {
+		t.Fatal("got no data")
+	}
+	for i, sd := range data {
+		if sd != data[0] {
+			t.Errorf("got different data for call %d", i)
+		}
+	}
+	if sd := d.Get("unknown"); sd != nil {
+		t.Errorf("got data for unknown service")
+	}
+}
+
+func TestServicesDataAnalyze(t *testing.T) {
+	examples := func(root *httpdesign.RootExpr, d *ServicesData) []interface{} {
+		var res []interface{}
+		for _, s := range root.HTTPServices {
+			res = append(res, d.Get(s.Name()).Endpoints[0].Payload.Request.ServerBody.Example)
+		}
+		return res
+	}
+	root := RunHTTPDSL(t, testdata.MultiSharedTypesDSL)
+	HTTPServices.Analyze(root.HTTPServices)
+	concurrent := examples(root, HTTPServices)
+
+	// Analyze the services sequentially in reverse order.
+	RunHTTPDSL(t, testdata.MultiSharedTypesDSL)
+	for i := len(root.HTTPServices) - 1; i >= 0; i-- {
+		HTTPServices.Get(root.HTTPServices[i].Name())
+	}
+	sequential := examples(root, HTTPServices)
+
+	for i, ex := range concurrent {
+		if ex == nil {
+			t.Fatalf("got no example for service %d", i)
+		}
+		if !reflect.DeepEqual(ex, sequential[i]) {
+			t.Errorf("service %d: got example %v when analyzed concurrently, %v when analyzed sequentially", i, ex, sequential[i])
+		}
+		// The services are identical and each uses its own generator
+		// so they get the same examples.
+		if !reflect.DeepEqual(ex, concurrent[0]) {
+			t.Errorf("service %d: got example %v, expected %v", i, ex, concurrent[0])
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestServerSkipBodyEncodeDecode(t *testing.T) {
+	cases := []*testCase{
+		{"skip-body-encode-decode", testdata.SkipBodyEncodeDecodeDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.SkipBodyEncodeDecodeServerHandlerInitCode},
+		}},
+		{"skip-body-encode-decode-no-payload", testdata.SkipBodyEncodeDecodeNoPayloadDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.SkipBodyEncodeDecodeNoPayloadServerHandlerInitCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestClientSkipBodyEncodeDecode(t *testing.T) {
+	cases := []*testCase{
+		{"skip-body-encode-decode", testdata.SkipBodyEncodeDecodeDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.SkipBodyEncodeDecodeClientEndpointCode},
+		}},
+		{"skip-body-encode-decode-no-payload", testdata.SkipBodyEncodeDecodeNoPayloadDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.SkipBodyEncodeDecodeNoPayloadClientEndpointCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
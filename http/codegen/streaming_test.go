@@ -34,6 +34,7 @@ func TestServerStreaming(t *testing.T) {
 			{"server-handler-init", &testdata.StreamingResultServerHandlerInitCode},
 			{"server-stream-send", &testdata.StreamingResultServerStreamSendCode},
 			{"server-stream-close", &testdata.StreamingResultServerStreamCloseCode},
+			{"server-stream-close-error", &testdata.StreamingResultServerStreamCloseErrorCode},
 			{"server-stream-set-view", nil},
 		}},
 		{"streaming-result-with-views", testdata.StreamingResultWithViewsDSL, []*sectionExpectation{
@@ -45,6 +46,35 @@ func TestServerStreaming(t *testing.T) {
 		{"streaming-result-no-payload", testdata.StreamingResultNoPayloadDSL, []*sectionExpectation{
 			{"server-handler-init", &testdata.StreamingResultNoPayloadServerHandlerInitCode},
 		}},
+		{"streaming-result-binary", testdata.StreamingResultBinaryDSL, []*sectionExpectation{
+			{"server-stream-struct-type", &testdata.StreamingResultBinaryServerStructTypeCode},
+			{"server-init", &testdata.StreamingResultBinaryServerInitCode},
+			{"server-handler-init", &testdata.StreamingResultBinaryServerHandlerInitCode},
+			{"server-stream-send", &testdata.StreamingResultBinaryServerStreamSendCode},
+		}},
+		{"streaming-result-ndjson", testdata.StreamingResultNDJSONDSL, []*sectionExpectation{
+			{"server-stream-struct-type", &testdata.StreamingResultNDJSONServerStructTypeCode},
+			{"server-init", &testdata.StreamingResultNDJSONServerInitCode},
+			{"server-handler-init", &testdata.StreamingResultNDJSONServerHandlerInitCode},
+			{"server-stream-send", &testdata.StreamingResultNDJSONServerStreamSendCode},
+			{"server-stream-close", &testdata.StreamingResultNDJSONServerStreamCloseCode},
+			{"server-stream-close-error", nil},
+		}},
+		{"streaming-result-ndjson-with-views", testdata.StreamingResultNDJSONWithViewsDSL, []*sectionExpectation{
+			{"server-stream-send", &testdata.StreamingResultNDJSONWithViewsServerStreamSendCode},
+			{"server-stream-set-view", &testdata.StreamingResultNDJSONWithViewsServerStreamSetViewCode},
+		}},
+		{"streaming-result-keep-alive", testdata.StreamingResultKeepAliveDSL, []*sectionExpectation{
+			{"server-stream-struct-type", &testdata.StreamingResultKeepAliveServerStructTypeCode},
+			{"server-stream-send", &testdata.StreamingResultKeepAliveServerStreamSendCode},
+			{"server-stream-close", &testdata.StreamingResultKeepAliveServerStreamCloseCode},
+		}},
+		{"streaming-result-compression", testdata.StreamingResultCompressionDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.StreamingResultCompressionServerHandlerInitCode},
+		}},
+		{"streaming-result-errors", testdata.StreamingResultErrorsDSL, []*sectionExpectation{
+			{"server-stream-close-error", &testdata.StreamingResultErrorsServerStreamCloseErrorCode},
+		}},
 	}
 	filesFn := func() []*codegen.File { return ServerFiles("", httpdesign.Root) }
 	runTests(t, cases, filesFn)
@@ -56,12 +86,42 @@ func TestClientStreaming(t *testing.T) {
 			{"client-endpoint-init", &testdata.StreamingResultClientEndpointCode},
 			{"client-stream-recv", &testdata.StreamingResultClientStreamRecvCode},
 			{"client-stream-set-view", nil},
+			{"client-resumable-endpoint-init", nil},
 		}},
 		{"streaming-result-with-views", testdata.StreamingResultWithViewsDSL, []*sectionExpectation{
 			{"client-endpoint-init", &testdata.StreamingResultWithViewsClientEndpointCode},
 			{"client-stream-recv", &testdata.StreamingResultWithViewsClientStreamRecvCode},
 			{"client-stream-set-view", &testdata.StreamingResultWithViewsClientStreamSetViewCode},
 		}},
+		{"streaming-result-binary", testdata.StreamingResultBinaryDSL, []*sectionExpectation{
+			{"client-init", &testdata.StreamingResultBinaryClientInitCode},
+			{"client-endpoint-init", &testdata.StreamingResultBinaryClientEndpointCode},
+			{"client-stream-recv", &testdata.StreamingResultBinaryClientStreamRecvCode},
+		}},
+		{"streaming-result-ndjson", testdata.StreamingResultNDJSONDSL, []*sectionExpectation{
+			{"client-stream-struct-type", &testdata.StreamingResultNDJSONClientStructTypeCode},
+			{"client-init", &testdata.StreamingResultNDJSONClientInitCode},
+			{"client-endpoint-init", &testdata.StreamingResultNDJSONClientEndpointCode},
+			{"client-stream-recv", &testdata.StreamingResultNDJSONClientStreamRecvCode},
+		}},
+		{"streaming-result-ndjson-with-views", testdata.StreamingResultNDJSONWithViewsDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.StreamingResultNDJSONWithViewsClientEndpointCode},
+			{"client-stream-recv", &testdata.StreamingResultNDJSONWithViewsClientStreamRecvCode},
+		}},
+		{"streaming-result-keep-alive", testdata.StreamingResultKeepAliveDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.StreamingResultKeepAliveClientEndpointCode},
+		}},
+		{"streaming-result-compression", testdata.StreamingResultCompressionDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.StreamingResultCompressionClientEndpointCode},
+		}},
+		{"streaming-result-errors", testdata.StreamingResultErrorsDSL, []*sectionExpectation{
+			{"client-stream-recv", &testdata.StreamingResultErrorsClientStreamRecvCode},
+		}},
+		{"streaming-result-resume", testdata.StreamingResultResumeDSL, []*sectionExpectation{
+			{"client-resumable-stream-struct-type", &testdata.StreamingResultResumeClientStructTypeCode},
+			{"client-resumable-endpoint-init", &testdata.StreamingResultResumeClientEndpointCode},
+			{"client-resumable-stream-recv", &testdata.StreamingResultResumeClientStreamRecvCode},
+		}},
 	}
 	filesFn := func() []*codegen.File { return ClientFiles("", httpdesign.Root) }
 	runTests(t, cases, filesFn)
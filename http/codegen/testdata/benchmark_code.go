@@ -0,0 +1,210 @@
+package testdata
+
+var BenchmarkClientCode = `// newStorageBenchmarkClient returns a client of the "Storage" service used to
+// encode requests and decode responses.
+func newStorageBenchmarkClient() *storagec.Client {
+	return storagec.NewClient("http", "localhost", http.DefaultClient, goahttp.RequestEncoder, goahttp.ResponseDecoder, false, nil, nil)
+}
+
+// benchmarkMuxer is a muxer that returns the path parameters captured when the
+// benchmarked request was routed so that the request decoders can be run
+// without routing the request.
+type benchmarkMuxer struct {
+	goahttp.Muxer
+	vars map[string]string
+}
+
+// Vars returns the captured path parameters.
+func (m benchmarkMuxer) Vars(*http.Request) map[string]string {
+	return m.vars
+}
+`
+
+var BenchmarkShowEncodeCode = `// BenchmarkStorageShowEncode measures the encoding of the "show" endpoint
+// requests by the client and responses by the server initialized with the
+// design examples.
+func BenchmarkStorageShowEncode(b *testing.B) {
+	payload := &storage.ShowPayload{
+		ID:      "Quia molestias.",
+		Verbose: boolPtr(true),
+	}
+	res := &storage.Bottle{
+		Name:    "Qui quia inventore et tempora.",
+		Vintage: intPtr(4170793618430505438),
+		Color:   stringPtr("red"),
+		Tags:    []string{"Inventore optio quia ullam aut iste iste.", "Repellendus harum.", "Est neque nisi."},
+	}
+	ctx := context.Background()
+	b.Run("request", func(b *testing.B) {
+		cli := newStorageBenchmarkClient()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			req, err := cli.BuildShowRequest(ctx, payload)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if err := storagec.EncodeShowRequest(goahttp.RequestEncoder)(req, payload); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("response", func(b *testing.B) {
+		encode := storagesvr.EncodeShowResponse(goahttp.ResponseEncoder)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := encode(ctx, httptest.NewRecorder(), res); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+`
+
+var BenchmarkShowDecodeCode = `// BenchmarkStorageShowDecode measures the decoding of the "show" endpoint
+// requests by the server and responses by the client initialized with the
+// design examples.
+func BenchmarkStorageShowDecode(b *testing.B) {
+	payload := &storage.ShowPayload{
+		ID:      "Quia molestias.",
+		Verbose: boolPtr(true),
+	}
+	res := &storage.Bottle{
+		Name:    "Qui quia inventore et tempora.",
+		Vintage: intPtr(4170793618430505438),
+		Color:   stringPtr("red"),
+		Tags:    []string{"Inventore optio quia ullam aut iste iste.", "Repellendus harum.", "Est neque nisi."},
+	}
+	ctx := context.Background()
+	b.Run("request", func(b *testing.B) {
+		req, err := newStorageBenchmarkClient().BuildShowRequest(ctx, payload)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := storagec.EncodeShowRequest(goahttp.RequestEncoder)(req, payload); err != nil {
+			b.Fatal(err)
+		}
+		var body []byte
+		if req.Body != nil {
+			if body, err = ioutil.ReadAll(req.Body); err != nil {
+				b.Fatal(err)
+			}
+		}
+		mux := goahttp.NewMuxer()
+		var vars map[string]string
+		storagesvr.MountShowHandler(mux, http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			vars = mux.Vars(r)
+		}))
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+		decode := storagesvr.DecodeShowRequest(benchmarkMuxer{mux, vars}, goahttp.RequestDecoder)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			if _, err := decode(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("response", func(b *testing.B) {
+		w := httptest.NewRecorder()
+		if err := storagesvr.EncodeShowResponse(goahttp.ResponseEncoder)(ctx, w, res); err != nil {
+			b.Fatal(err)
+		}
+		resp := w.Result()
+		body := w.Body.Bytes()
+		decode := storagec.DecodeShowResponse(goahttp.ResponseDecoder, false)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+			if _, err := decode(resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+`
+
+var BenchmarkRemoveEncodeCode = `// BenchmarkStorageRemoveEncode measures the encoding of the "remove" endpoint
+// requests by the client and responses by the server initialized with the
+// design examples.
+func BenchmarkStorageRemoveEncode(b *testing.B) {
+	payload := &storage.RemovePayload{
+		ID: "Assumenda fuga est sint maxime.",
+	}
+	ctx := context.Background()
+	b.Run("request", func(b *testing.B) {
+		cli := newStorageBenchmarkClient()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := cli.BuildRemoveRequest(ctx, payload); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("response", func(b *testing.B) {
+		encode := storagesvr.EncodeRemoveResponse(goahttp.ResponseEncoder)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := encode(ctx, httptest.NewRecorder(), nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+`
+
+var BenchmarkRemoveDecodeCode = `// BenchmarkStorageRemoveDecode measures the decoding of the "remove" endpoint
+// requests by the server and responses by the client initialized with the
+// design examples.
+func BenchmarkStorageRemoveDecode(b *testing.B) {
+	payload := &storage.RemovePayload{
+		ID: "Assumenda fuga est sint maxime.",
+	}
+	ctx := context.Background()
+	b.Run("request", func(b *testing.B) {
+		req, err := newStorageBenchmarkClient().BuildRemoveRequest(ctx, payload)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var body []byte
+		if req.Body != nil {
+			if body, err = ioutil.ReadAll(req.Body); err != nil {
+				b.Fatal(err)
+			}
+		}
+		mux := goahttp.NewMuxer()
+		var vars map[string]string
+		storagesvr.MountRemoveHandler(mux, http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+			vars = mux.Vars(r)
+		}))
+		mux.ServeHTTP(httptest.NewRecorder(), req)
+		decode := storagesvr.DecodeRemoveRequest(benchmarkMuxer{mux, vars}, goahttp.RequestDecoder)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+			if _, err := decode(req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("response", func(b *testing.B) {
+		w := httptest.NewRecorder()
+		if err := storagesvr.EncodeRemoveResponse(goahttp.ResponseEncoder)(ctx, w, nil); err != nil {
+			b.Fatal(err)
+		}
+		resp := w.Result()
+		body := w.Body.Bytes()
+		decode := storagec.DecodeRemoveResponse(goahttp.ResponseDecoder, false)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+			if _, err := decode(resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+`
@@ -0,0 +1,113 @@
+package testdata
+
+var ComposeServerCode = `// Endpoints lists the endpoints of the test api services.
+type Endpoints struct {
+	// ServiceCompose contains the ServiceCompose service endpoints.
+	ServiceCompose *servicecompose.Endpoints
+}
+
+// Options contains the settings shared by the HTTP servers of the services.
+// The zero value uses the default goa request decoder and response encoder.
+type Options struct {
+	// Decoder creates the request decoders, defaults to
+	// goahttp.RequestDecoder.
+	Decoder func(*http.Request) goahttp.Decoder
+	// Encoder creates the response encoders, defaults to
+	// goahttp.ResponseEncoder.
+	Encoder func(context.Context, http.ResponseWriter) goahttp.Encoder
+	// ErrorHandler handles the errors that occur while encoding the
+	// responses of all the services, defaults to ErrorHandler.
+	ErrorHandler func(context.Context, http.ResponseWriter, error)
+	// Middlewares lists the middlewares that wrap the muxer and thus apply
+	// to all the requests. The first middleware is the outermost.
+	Middlewares []func(http.Handler) http.Handler
+	// OpenAPIPath is the path of the OpenAPI specification aggregating
+	// all the services, for example "/openapi.json". The specification is
+	// not served if empty.
+	OpenAPIPath string
+}
+
+// Server is the HTTP server hosting all the test api services.
+type Server struct {
+	// ServiceComposeFiles is the ServiceComposeFiles service HTTP server.
+	ServiceComposeFiles *servicecomposefilessvr.Server
+	// ServiceCompose is the ServiceCompose service HTTP server.
+	ServiceCompose *servicecomposesvr.Server
+
+	handler http.Handler
+}
+
+// MountPoint describes a HTTP handler mounted on the muxer.
+type MountPoint struct {
+	// Service is the name of the service.
+	Service string
+	// Method is the name of the service method served by the handler.
+	Method string
+	// Verb is the HTTP method used to match requests to the handler.
+	Verb string
+	// Pattern is the HTTP request path pattern used to match requests to
+	// the handler.
+	Pattern string
+}
+
+// New instantiates the HTTP servers of all the services, mounts them on mux and
+// wraps mux with the middlewares listed in opts. The servers share the request
+// decoder, response encoder and error handler given in opts.
+func New(e *Endpoints, mux goahttp.Muxer, opts *Options) *Server {
+	if opts == nil {
+		opts = &Options{}
+	}
+	var (
+		dec = opts.Decoder
+		enc = opts.Encoder
+		eh  = opts.ErrorHandler
+	)
+	if dec == nil {
+		dec = goahttp.RequestDecoder
+	}
+	if enc == nil {
+		enc = goahttp.ResponseEncoder
+	}
+	if eh == nil {
+		eh = ErrorHandler
+	}
+	s := &Server{
+		ServiceComposeFiles: servicecomposefilessvr.New(nil, mux, dec, enc, eh),
+		ServiceCompose:      servicecomposesvr.New(e.ServiceCompose, mux, dec, enc, eh),
+	}
+	servicecomposefilessvr.Mount(mux)
+	servicecomposesvr.Mount(mux, s.ServiceCompose)
+	if opts.OpenAPIPath != "" {
+		mux.Handle("GET", opts.OpenAPIPath, serveOpenAPI)
+	}
+	var h http.Handler = mux
+	for i := len(opts.Middlewares) - 1; i >= 0; i-- {
+		h = opts.Middlewares[i](h)
+	}
+	s.handler = h
+	return s
+}
+
+// ServeHTTP serves the requests made to the endpoints of all the services.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// Mounts returns the handlers mounted by the servers of all the services.
+func (s *Server) Mounts() []*MountPoint {
+	var mounts []*MountPoint
+	for _, m := range s.ServiceComposeFiles.Mounts {
+		mounts = append(mounts, &MountPoint{"ServiceComposeFiles", m.Method, m.Verb, m.Pattern})
+	}
+	for _, m := range s.ServiceCompose.Mounts {
+		mounts = append(mounts, &MountPoint{"ServiceCompose", m.Method, m.Verb, m.Pattern})
+	}
+	return mounts
+}
+
+// ErrorHandler is the default error handler shared by the servers. It responds
+// with a 500 status code.
+func ErrorHandler(ctx context.Context, w http.ResponseWriter, err error) {
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+`
@@ -0,0 +1,23 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var ComposeDSL = func() {
+	Service("ServiceCompose", func() {
+		Method("MethodCompose", func() {
+			Payload(func() {
+				Attribute("a", Int)
+			})
+			Result(String)
+			HTTP(func() {
+				GET("/compose/{a}")
+			})
+		})
+	})
+	Service("ServiceComposeFiles", func() {
+		Files("/docs", "docs/index.html")
+	})
+}
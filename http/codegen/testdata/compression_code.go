@@ -0,0 +1,52 @@
+package testdata
+
+var CompressionServerMountCode = `// Mount configures the mux to serve the ServiceCompression endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountMethodCompressionHandler(mux, goahttp.Compress(h.MethodCompression, 1024))
+	MountMethodCompressionStreamingHandler(mux, h.MethodCompressionStreaming)
+}
+`
+
+var CompressionCORSServerMountCode = `// Mount configures the mux to serve the ServiceCompressionCORS endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountMethodCompressionCORSHandler(mux, handleServiceCompressionCORSOrigin(goahttp.Compress(h.MethodCompressionCORS, 0)))
+	MountCORSHandler(mux, NewCORSHandler())
+}
+`
+
+var CompressionClientEndpointCode = `// MethodCompression returns an endpoint that makes HTTP requests to the
+// ServiceCompression service MethodCompression server.
+func (c *Client) MethodCompression() goa.Endpoint {
+	var (
+		decodeResponse = DecodeMethodCompressionResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		ctx, cancel := goahttp.ContextWithRequestTimeout(ctx)
+		defer cancel()
+		req, err := c.BuildMethodCompressionRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.ApplyRequestOptions(ctx, req)
+		req.Header.Set("Accept-Encoding", "gzip, deflate")
+		resp, err := c.MethodCompressionDoer.Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceCompression", "MethodCompression", err)
+		}
+		if err := goahttp.DecompressResponse(resp); err != nil {
+			resp.Body.Close()
+			return nil, goahttp.ErrDecodingError("ServiceCompression", "MethodCompression", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
+
+var CompressionClientBreakerCode = `// UseBreaker configures the client to invoke the circuit breaker b around the
+// requests made to the ServiceCompression service endpoints. Websocket
+// streaming endpoints are not affected.
+func (c *Client) UseBreaker(b goahttp.Breaker) {
+	c.MethodCompressionDoer = goahttp.NewBreakerDoer(b, "ServiceCompression", "MethodCompression", c.MethodCompressionDoer)
+}
+`
@@ -0,0 +1,50 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var CompressionDSL = func() {
+	Service("ServiceCompression", func() {
+		HTTP(func() {
+			Compress(1024)
+		})
+		Method("MethodCompression", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(String)
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+		Method("MethodCompressionStreaming", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var CompressionCORSDSL = func() {
+	API("CompressionCORS", func() {
+		HTTP(func() {
+			Compress(0)
+		})
+	})
+	Service("ServiceCompressionCORS", func() {
+		HTTP(func() {
+			CORS(func() {
+				AllowOrigins("https://goa.design")
+			})
+		})
+		Method("MethodCompressionCORS", func() {
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
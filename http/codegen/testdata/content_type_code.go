@@ -0,0 +1,131 @@
+package testdata
+
+var MsgpackContentTypeRequestBuilderCode = `// BuildMethodMsgpackContentTypeRequest instantiates a HTTP request object with
+// method and path set to call the "ServiceMsgpackContentType" service
+// "MethodMsgpackContentType" endpoint
+func (c *Client) BuildMethodMsgpackContentTypeRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	var (
+		id string
+	)
+	{
+		p, ok := v.(*servicemsgpackcontenttype.MethodMsgpackContentTypePayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("ServiceMsgpackContentType", "MethodMsgpackContentType", "*servicemsgpackcontenttype.MethodMsgpackContentTypePayload", v)
+		}
+		if p.ID != nil {
+			id = *p.ID
+		}
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: MethodMsgpackContentTypeServiceMsgpackContentTypePath(id)}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServiceMsgpackContentType", "MethodMsgpackContentType", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+	req.Header.Set("Content-Type", "application/msgpack")
+	req.Header.Set("Accept", "application/msgpack")
+
+	return req, nil
+}
+`
+
+var MsgpackContentTypeNoBodyRequestBuilderCode = `// BuildMethodMsgpackContentTypeNoBodyRequest instantiates a HTTP request
+// object with method and path set to call the "ServiceMsgpackContentType"
+// service "MethodMsgpackContentTypeNoBody" endpoint
+func (c *Client) BuildMethodMsgpackContentTypeNoBodyRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	var (
+		id string
+	)
+	{
+		p, ok := v.(*servicemsgpackcontenttype.MethodMsgpackContentTypeNoBodyPayload)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("ServiceMsgpackContentType", "MethodMsgpackContentTypeNoBody", "*servicemsgpackcontenttype.MethodMsgpackContentTypeNoBodyPayload", v)
+		}
+		if p.ID != nil {
+			id = *p.ID
+		}
+	}
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: MethodMsgpackContentTypeNoBodyServiceMsgpackContentTypePath(id)}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServiceMsgpackContentType", "MethodMsgpackContentTypeNoBody", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+	req.Header.Set("Accept", "application/msgpack")
+
+	return req, nil
+}
+`
+
+var CborContentTypeRequestBuilderCode = `// BuildMethodCborContentTypeRequest instantiates a HTTP request object with
+// method and path set to call the "ServiceCborContentType" service
+// "MethodCborContentType" endpoint
+func (c *Client) BuildMethodCborContentTypeRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: MethodCborContentTypeServiceCborContentTypePath()}
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServiceCborContentType", "MethodCborContentType", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+	req.Header.Set("Content-Type", "application/cbor")
+
+	return req, nil
+}
+`
+
+var CborContentTypeOverrideRequestBuilderCode = `// BuildMethodCborContentTypeOverrideRequest instantiates a HTTP request object
+// with method and path set to call the "ServiceCborContentType" service
+// "MethodCborContentTypeOverride" endpoint
+func (c *Client) BuildMethodCborContentTypeOverrideRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: MethodCborContentTypeOverrideServiceCborContentTypePath()}
+	req, err := http.NewRequest("PUT", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("ServiceCborContentType", "MethodCborContentTypeOverride", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
+`
+
+var MultipleContentTypesRequestDecoderCode = `// DecodeMethodMultipleContentTypesRequest returns a decoder for requests sent
+// to the ServiceMultipleContentTypes MethodMultipleContentTypes endpoint.
+func DecodeMethodMultipleContentTypesRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodMultipleContentTypesRequestBody
+			err  error
+		)
+		switch ct := goahttp.RequestContentType(r, "application/json"); ct {
+		case "application/x-www-form-urlencoded", "multipart/form-data":
+			err = goahttp.FormDecoder(r).Decode(&body)
+		case "application/json":
+			err = decoder(r).Decode(&body)
+		default:
+			return nil, goahttp.ErrUnsupportedMediaType(ct, "application/json", "application/x-www-form-urlencoded", "multipart/form-data")
+		}
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = body.Validate()
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodMultipleContentTypesPayload(&body)
+
+		return payload, nil
+	}
+}
+`
@@ -0,0 +1,79 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var MsgpackContentTypeDSL = func() {
+	API("MsgpackContentType", func() {
+		HTTP(func() {
+			Consumes("application/msgpack", "application/json")
+			Produces("application/msgpack", "application/json")
+		})
+	})
+	Service("ServiceMsgpackContentType", func() {
+		Method("MethodMsgpackContentType", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("name", String)
+			})
+			Result(String)
+			HTTP(func() {
+				POST("/{id}")
+			})
+		})
+		Method("MethodMsgpackContentTypeNoBody", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+				Response(StatusNoContent)
+			})
+		})
+	})
+}
+
+var CborContentTypeDSL = func() {
+	API("CborContentType", func() {
+		HTTP(func() {
+			ContentType("application/cbor")
+		})
+	})
+	Service("ServiceCborContentType", func() {
+		Method("MethodCborContentType", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("MethodCborContentTypeOverride", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				PUT("/")
+				ContentType("application/json")
+			})
+		})
+	})
+}
+
+var MultipleContentTypesDSL = func() {
+	Service("ServiceMultipleContentTypes", func() {
+		Method("MethodMultipleContentTypes", func() {
+			Payload(func() {
+				Attribute("name", String)
+				Attribute("tags", ArrayOf(String))
+				Required("name")
+			})
+			HTTP(func() {
+				POST("/")
+				Consumes("application/json", "application/x-www-form-urlencoded", "multipart/form-data")
+			})
+		})
+	})
+}
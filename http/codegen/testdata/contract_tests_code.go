@@ -0,0 +1,94 @@
+package testdata
+
+var ContractTestsInitCode = `// contractURLVar is the name of the environment variable that holds the URL of
+// the running implementation of the API validated by the contract tests.
+const contractURLVar = "GOA_CONTRACT_URL"
+
+// newContractDoer returns the URL of the implementation under test and a doer
+// that validates the requests and responses against the OpenAPI specification
+// generated from the design. newContractDoer skips the test if the
+// GOA_CONTRACT_URL environment variable is not set.
+func newContractDoer(t *testing.T) (*url.URL, *contract.Doer) {
+	t.Helper()
+	u := os.Getenv(contractURLVar)
+	if u == "" {
+		t.Skipf("%s is not set", contractURLVar)
+	}
+	target, err := url.Parse(u)
+	if err != nil {
+		t.Fatalf("invalid %s: %s", contractURLVar, err)
+	}
+	spec, err := contract.LoadFile(filepath.Join("..", "openapi.json"))
+	if err != nil {
+		t.Fatalf("failed to load OpenAPI specification: %s", err)
+	}
+	return target, contract.NewDoer(spec, http.DefaultClient)
+}
+
+// checkContract fails the test if doer recorded contract violations or failed
+// to send requests. err is the error returned by the client endpoint, it is
+// only logged as the error responses described in the design comply with the
+// contract.
+func checkContract(t *testing.T, doer *contract.Doer, err error) {
+	t.Helper()
+	if err != nil {
+		t.Logf("endpoint returned an error: %s", err)
+	}
+	for _, err := range doer.Errors() {
+		t.Error(err)
+	}
+}
+`
+
+var ContractTestsClientCode = `// newStorageContractClient returns a client of the "Storage" service that
+// sends requests to the implementation at u through doer.
+func newStorageContractClient(u *url.URL, doer goahttp.Doer) *storagec.Client {
+	return storagec.NewClient(u.Scheme, u.Host, doer, goahttp.RequestEncoder, goahttp.ResponseDecoder, false, nil, nil)
+}
+`
+
+var ContractTestsShowCode = `// TestContractStorageShow replays the design example of the "show" endpoint of
+// the "Storage" service and validates the exchange against the OpenAPI
+// specification.
+func TestContractStorageShow(t *testing.T) {
+	u, doer := newContractDoer(t)
+	c := newStorageContractClient(u, doer)
+	payload := &storage.ShowPayload{
+		ID:      "Quia molestias.",
+		Verbose: boolPtr(true),
+	}
+	_, err := c.Show()(context.Background(), payload)
+	checkContract(t, doer, err)
+}
+`
+
+var ContractTestsAddCode = `// TestContractStorageAdd replays the design example of the "add" endpoint of
+// the "Storage" service and validates the exchange against the OpenAPI
+// specification.
+func TestContractStorageAdd(t *testing.T) {
+	u, doer := newContractDoer(t)
+	c := newStorageContractClient(u, doer)
+	payload := &storage.Bottle{
+		Name:    "Qui quia inventore et tempora.",
+		Vintage: intPtr(4170793618430505438),
+		Color:   stringPtr("red"),
+		Tags:    []string{"Inventore optio quia ullam aut iste iste.", "Repellendus harum.", "Est neque nisi."},
+	}
+	_, err := c.Add()(context.Background(), payload)
+	checkContract(t, doer, err)
+}
+`
+
+var ContractTestsRemoveCode = `// TestContractStorageRemove replays the design example of the "remove"
+// endpoint of the "Storage" service and validates the exchange against the
+// OpenAPI specification.
+func TestContractStorageRemove(t *testing.T) {
+	u, doer := newContractDoer(t)
+	c := newStorageContractClient(u, doer)
+	payload := &storage.RemovePayload{
+		ID: "Assumenda fuga est sint maxime.",
+	}
+	_, err := c.Remove()(context.Background(), payload)
+	checkContract(t, doer, err)
+}
+`
@@ -0,0 +1,41 @@
+package testdata
+
+var DockerfileCode = `# Multi-stage Dockerfile that builds and runs the calc_svc server.
+# Build the image from the root of the Go module:
+#
+#    docker build -f cmd/calc_svc/Dockerfile -t calc_svc .
+#
+# The server is configured with the environment variables listed in
+# cmd/calc_svc/config.go.
+
+ARG GO_VERSION=1.22
+
+# Build stage: compile a static binary.
+FROM golang:${GO_VERSION}-alpine AS build
+WORKDIR /src
+COPY go.mod go.sum* ./
+RUN go mod download
+COPY . .
+RUN CGO_ENABLED=0 go build -trimpath -ldflags="-s -w" -o /out/calc_svc ./cmd/calc_svc
+
+# Run stage: copy the binary and the entrypoint in a minimal image.
+FROM alpine:3.20
+RUN apk add --no-cache ca-certificates && adduser -D -H -u 10001 app
+COPY --from=build /out/calc_svc /usr/local/bin/calc_svc
+COPY cmd/calc_svc/entrypoint.sh /usr/local/bin/entrypoint.sh
+ENV CALC_HOST=0.0.0.0 \
+    CALC_PORT=8443
+EXPOSE 8443
+USER app
+ENTRYPOINT ["/bin/sh", "/usr/local/bin/entrypoint.sh"]
+`
+
+var DockerEntrypointCode = `#!/bin/sh
+# Container entrypoint of the calc_svc server. The server reads its
+# configuration from the CALC_* environment variables, see config.go. The
+# arguments given to the container are passed to the server as command line
+# flags. Add any initialization step required before starting the server here.
+set -e
+
+exec /usr/local/bin/calc_svc "$@"
+`
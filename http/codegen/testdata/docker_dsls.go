@@ -0,0 +1,19 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/dsl"
+)
+
+var DockerDSL = func() {
+	API("calc", func() {
+		Metadata("docker:generate", "true")
+		Server("https://calc.example.com:8443")
+	})
+	Service("ServiceDocker", func() {
+		Method("MethodDocker", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -86,3 +86,81 @@ func EncodeMethodServiceErrorResponseError(encoder func(context.Context, http.Re
 	}
 }
 `
+
+var ProblemErrorResponseEncoderCode = `// EncodeMethodProblemErrorResponseError returns an encoder for errors returned
+// by the MethodProblemErrorResponse ServiceProblemErrorResponse endpoint.
+func EncodeMethodProblemErrorResponseError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ProblemErrorEncoder()
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "bad_request":
+			return goahttp.EncodeProblem(w, goahttp.NewProblem("bad_request", http.StatusBadRequest, v))
+		case "internal_error":
+			return goahttp.EncodeProblem(w, goahttp.NewProblem("internal_error", http.StatusInternalServerError, v))
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
+
+var HeaderErrorResponseEncoderCode = `// EncodeMethodHeaderErrorResponseError returns an encoder for errors returned
+// by the MethodHeaderErrorResponse ServiceHeaderErrorResponse endpoint.
+func EncodeMethodHeaderErrorResponseError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "rate_limited":
+			res := v.(*serviceheadererrorresponse.RateLimited)
+			enc := encoder(ctx, w)
+			body := NewMethodHeaderErrorResponseRateLimitedResponseBody(res)
+			val := res.RetryAfter
+			retryAfters := strconv.Itoa(val)
+			w.Header().Set("Retry-After", retryAfters)
+			if res.RequestID != nil {
+				w.Header().Set("request_id", *res.RequestID)
+			}
+			w.Header().Set("goa-error", "rate_limited")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
+
+var ConstantHeaderErrorResponseEncoderCode = `// EncodeMethodConstantHeaderErrorResponseError returns an encoder for errors
+// returned by the MethodConstantHeaderErrorResponse
+// ServiceConstantHeaderErrorResponse endpoint.
+func EncodeMethodConstantHeaderErrorResponseError(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, error) error {
+	encodeError := goahttp.ErrorEncoder(encoder)
+	return func(ctx context.Context, w http.ResponseWriter, v error) error {
+		en, ok := v.(ErrorNamer)
+		if !ok {
+			return encodeError(ctx, w, v)
+		}
+		switch en.ErrorName() {
+		case "bad_request":
+			res := v.(*goa.ServiceError)
+			enc := encoder(ctx, w)
+			body := NewMethodConstantHeaderErrorResponseBadRequestResponseBody(res)
+			w.Header().Set("Cache-Control", "no-store")
+			w.Header().Set("X-Api-Version", "2")
+			w.Header().Set("goa-error", "bad_request")
+			w.WriteHeader(http.StatusBadRequest)
+			return enc.Encode(body)
+		default:
+			return encodeError(ctx, w, v)
+		}
+	}
+}
+`
@@ -46,3 +46,57 @@ var ServiceErrorResponseDSL = func() {
 		})
 	})
 }
+
+var ProblemErrorResponseDSL = func() {
+	API("ProblemErrorResponse", func() {
+		Metadata("http:error:problem")
+	})
+	Service("ServiceProblemErrorResponse", func() {
+		Method("MethodProblemErrorResponse", func() {
+			Error("bad_request", String)
+			Error("internal_error", String)
+			HTTP(func() {
+				GET("/one/two")
+				Response("bad_request", StatusBadRequest)
+				Response("internal_error", StatusInternalServerError)
+			})
+		})
+	})
+}
+
+var HeaderErrorResponseDSL = func() {
+	var RateLimited = Type("RateLimited", func() {
+		Attribute("retry_after", Int, func() {
+			Metadata("http:header", "Retry-After")
+		})
+		Attribute("request_id", String, func() {
+			Metadata("http:header")
+		})
+		Attribute("message", String)
+		Required("retry_after", "message")
+	})
+	Service("ServiceHeaderErrorResponse", func() {
+		Method("MethodHeaderErrorResponse", func() {
+			Error("rate_limited", RateLimited)
+			HTTP(func() {
+				GET("/one/two")
+				Response("rate_limited", StatusTooManyRequests)
+			})
+		})
+	})
+}
+
+var ConstantHeaderErrorResponseDSL = func() {
+	Service("ServiceConstantHeaderErrorResponse", func() {
+		Method("MethodConstantHeaderErrorResponse", func() {
+			Error("bad_request")
+			HTTP(func() {
+				GET("/one/two")
+				ConstantHeader("X-API-Version", "2")
+				Response("bad_request", StatusBadRequest, func() {
+					ConstantHeader("Cache-Control", "no-store")
+				})
+			})
+		})
+	})
+}
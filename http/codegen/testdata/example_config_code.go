@@ -0,0 +1,111 @@
+package testdata
+
+var ExampleConfigDefaultServerCode = `// Config contains the server configuration. LoadConfig initializes the
+// configuration from the environment, the default values are derived from
+// the first server defined in the design.
+type Config struct {
+	// Host is the host the server listens on, set with TESTAPI_HOST.
+	// Set it to "0.0.0.0" to listen on all the interfaces, for example
+	// when running in a container.
+	Host string
+	// Port is the port the server listens on, set with TESTAPI_PORT.
+	Port string
+	// LogLevel is the log level, "info" or "debug", set with
+	// TESTAPI_LOG_LEVEL. The server logs the request and response
+	// bodies at the debug level.
+	LogLevel string
+	// TLSCert is the path to the TLS certificate file, set with
+	// TESTAPI_TLS_CERT.
+	TLSCert string
+	// TLSKey is the path to the TLS private key file, set with
+	// TESTAPI_TLS_KEY.
+	TLSKey string
+}
+
+// LoadConfig loads the server configuration from the environment.
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
+		Host:     getenv("TESTAPI_HOST", "localhost"),
+		Port:     getenv("TESTAPI_PORT", "80"),
+		LogLevel: getenv("TESTAPI_LOG_LEVEL", "info"),
+		TLSCert:  getenv("TESTAPI_TLS_CERT", ""),
+		TLSKey:   getenv("TESTAPI_TLS_KEY", ""),
+	}
+	if _, err := strconv.ParseUint(cfg.Port, 10, 16); err != nil {
+		return nil, fmt.Errorf("invalid port %q set with TESTAPI_PORT", cfg.Port)
+	}
+	if cfg.LogLevel != "info" && cfg.LogLevel != "debug" {
+		return nil, fmt.Errorf("invalid log level %q set with TESTAPI_LOG_LEVEL, must be \"info\" or \"debug\"", cfg.LogLevel)
+	}
+	return cfg, nil
+}
+
+// Addr returns the address the server listens on.
+func (c *Config) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// getenv returns the value of the environment variable with the given name,
+// def if the variable is not set.
+func getenv(name, def string) string {
+	if v, ok := os.LookupEnv(name); ok {
+		return v
+	}
+	return def
+}
+`
+
+var ExampleConfigServerVariablesCode = `// Config contains the server configuration. LoadConfig initializes the
+// configuration from the environment, the default values are derived from
+// the first server defined in the design.
+type Config struct {
+	// Host is the host the server listens on, set with TEST_HOST.
+	// Set it to "0.0.0.0" to listen on all the interfaces, for example
+	// when running in a container.
+	Host string
+	// Port is the port the server listens on, set with TEST_PORT.
+	Port string
+	// LogLevel is the log level, "info" or "debug", set with
+	// TEST_LOG_LEVEL. The server logs the request and response
+	// bodies at the debug level.
+	LogLevel string
+	// TLSCert is the path to the TLS certificate file, set with
+	// TEST_TLS_CERT.
+	TLSCert string
+	// TLSKey is the path to the TLS private key file, set with
+	// TEST_TLS_KEY.
+	TLSKey string
+}
+
+// LoadConfig loads the server configuration from the environment.
+func LoadConfig() (*Config, error) {
+	cfg := &Config{
+		Host:     getenv("TEST_HOST", "prod.us-east-1.calc.com"),
+		Port:     getenv("TEST_PORT", "443"),
+		LogLevel: getenv("TEST_LOG_LEVEL", "info"),
+		TLSCert:  getenv("TEST_TLS_CERT", ""),
+		TLSKey:   getenv("TEST_TLS_KEY", ""),
+	}
+	if _, err := strconv.ParseUint(cfg.Port, 10, 16); err != nil {
+		return nil, fmt.Errorf("invalid port %q set with TEST_PORT", cfg.Port)
+	}
+	if cfg.LogLevel != "info" && cfg.LogLevel != "debug" {
+		return nil, fmt.Errorf("invalid log level %q set with TEST_LOG_LEVEL, must be \"info\" or \"debug\"", cfg.LogLevel)
+	}
+	return cfg, nil
+}
+
+// Addr returns the address the server listens on.
+func (c *Config) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+// getenv returns the value of the environment variable with the given name,
+// def if the variable is not set.
+func getenv(name, def string) string {
+	if v, ok := os.LookupEnv(name); ok {
+		return v
+	}
+	return def
+}
+`
@@ -0,0 +1,366 @@
+package testdata
+
+var ExampleTestsServerCode = `// newStorageTestServer starts a test HTTP server that serves the "Storage"
+// service endpoints implemented by svc.
+func newStorageTestServer(t *testing.T, svc *storage.MockService) *httptest.Server {
+	endpoints := storage.NewEndpoints(svc)
+	mux := goahttp.NewMuxer()
+	eh := func(ctx context.Context, w http.ResponseWriter, err error) {
+		t.Errorf("failed to encode response: %s", err)
+	}
+	server := storagesvr.New(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, eh, nil, nil)
+	storagesvr.Mount(mux, server)
+	return httptest.NewServer(mux)
+}
+
+// newStorageTestClient returns a client of the "Storage" service that makes
+// requests to srv.
+func newStorageTestClient(srv *httptest.Server) *storagec.Client {
+	u, _ := url.Parse(srv.URL)
+	return storagec.NewClient(u.Scheme, u.Host, srv.Client(), goahttp.RequestEncoder, goahttp.ResponseDecoder, false, nil, nil)
+}
+`
+
+var ExampleTestsShowCode = `// TestStorageShow tests the "show" endpoint of the "Storage" service. The
+// first case is initialized with the design examples, add cases to cover the
+// other behaviors of the endpoint.
+func TestStorageShow(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Payload *storage.ShowPayload
+		Result  *storage.Bottle
+		Err     error
+		Status  int
+	}{
+		{
+			Name: "example",
+			Payload: &storage.ShowPayload{
+				ID:      "Quia molestias.",
+				Verbose: boolPtr(true),
+			},
+			Result: &storage.Bottle{
+				Name:    "Qui quia inventore et tempora.",
+				Vintage: intPtr(4170793618430505438),
+				Color:   stringPtr("red"),
+				Tags:    []string{"Inventore optio quia ullam aut iste iste.", "Repellendus harum.", "Est neque nisi."},
+			},
+			Status: http.StatusOK,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			svc := &storage.MockService{
+				ShowFunc: func(ctx context.Context, p *storage.ShowPayload) (res *storage.Bottle, err error) {
+					return c.Result, c.Err
+				},
+			}
+			srv := newStorageTestServer(t, svc)
+			defer srv.Close()
+			cli := newStorageTestClient(srv)
+
+			req, err := cli.BuildShowRequest(context.Background(), c.Payload)
+			if err != nil {
+				t.Fatalf("failed to build request: %s", err)
+			}
+			if err := storagec.EncodeShowRequest(goahttp.RequestEncoder)(req, c.Payload); err != nil {
+				t.Fatalf("failed to encode request: %s", err)
+			}
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %s", err)
+			}
+			if resp.StatusCode != c.Status {
+				t.Errorf("got status %d, expected %d", resp.StatusCode, c.Status)
+			}
+			res, err := storagec.DecodeShowResponse(goahttp.ResponseDecoder, false)(resp)
+			if c.Err != nil {
+				if err == nil {
+					t.Errorf("got no error, expected %v", c.Err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to decode response: %s", err)
+			}
+			if !reflect.DeepEqual(res, c.Result) {
+				t.Errorf("got result %#v, expected %#v", res, c.Result)
+			}
+		})
+	}
+}
+`
+
+var ExampleTestsAddCode = `// TestStorageAdd tests the "add" endpoint of the "Storage" service. The first
+// case is initialized with the design examples, add cases to cover the other
+// behaviors of the endpoint.
+func TestStorageAdd(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Payload *storage.Bottle
+		Result  string
+		Err     error
+		Status  int
+	}{
+		{
+			Name: "example",
+			Payload: &storage.Bottle{
+				Name:    "Qui quia inventore et tempora.",
+				Vintage: intPtr(4170793618430505438),
+				Color:   stringPtr("red"),
+				Tags:    []string{"Inventore optio quia ullam aut iste iste.", "Repellendus harum.", "Est neque nisi."},
+			},
+			Result: "Nisi sint sunt beatae quia.",
+			Status: http.StatusCreated,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			svc := &storage.MockService{
+				AddFunc: func(ctx context.Context, p *storage.Bottle) (res string, err error) {
+					return c.Result, c.Err
+				},
+			}
+			srv := newStorageTestServer(t, svc)
+			defer srv.Close()
+			cli := newStorageTestClient(srv)
+
+			req, err := cli.BuildAddRequest(context.Background(), c.Payload)
+			if err != nil {
+				t.Fatalf("failed to build request: %s", err)
+			}
+			if err := storagec.EncodeAddRequest(goahttp.RequestEncoder)(req, c.Payload); err != nil {
+				t.Fatalf("failed to encode request: %s", err)
+			}
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %s", err)
+			}
+			if resp.StatusCode != c.Status {
+				t.Errorf("got status %d, expected %d", resp.StatusCode, c.Status)
+			}
+			res, err := storagec.DecodeAddResponse(goahttp.ResponseDecoder, false)(resp)
+			if c.Err != nil {
+				if err == nil {
+					t.Errorf("got no error, expected %v", c.Err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to decode response: %s", err)
+			}
+			if !reflect.DeepEqual(res, c.Result) {
+				t.Errorf("got result %#v, expected %#v", res, c.Result)
+			}
+		})
+	}
+}
+`
+
+var ExampleTestsRemoveCode = `// TestStorageRemove tests the "remove" endpoint of the "Storage" service. The
+// first case is initialized with the design examples, add cases to cover the
+// other behaviors of the endpoint.
+func TestStorageRemove(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Payload *storage.RemovePayload
+		Err     error
+		Status  int
+	}{
+		{
+			Name: "example",
+			Payload: &storage.RemovePayload{
+				ID: "Assumenda fuga est sint maxime.",
+			},
+			Status: http.StatusNoContent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			svc := &storage.MockService{
+				RemoveFunc: func(ctx context.Context, p *storage.RemovePayload) (err error) {
+					return c.Err
+				},
+			}
+			srv := newStorageTestServer(t, svc)
+			defer srv.Close()
+			cli := newStorageTestClient(srv)
+
+			req, err := cli.BuildRemoveRequest(context.Background(), c.Payload)
+			if err != nil {
+				t.Fatalf("failed to build request: %s", err)
+			}
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %s", err)
+			}
+			if resp.StatusCode != c.Status {
+				t.Errorf("got status %d, expected %d", resp.StatusCode, c.Status)
+			}
+			_, err = storagec.DecodeRemoveResponse(goahttp.ResponseDecoder, false)(resp)
+			if c.Err != nil {
+				if err == nil {
+					t.Errorf("got no error, expected %v", c.Err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to decode response: %s", err)
+			}
+		})
+	}
+}
+`
+
+var ExampleTestsBoolPtrCode = `// boolPtr returns a pointer to v.
+func boolPtr(v bool) *bool {
+	return &v
+}
+`
+
+var ExampleTestsIntPtrCode = `// intPtr returns a pointer to v.
+func intPtr(v int) *int {
+	return &v
+}
+`
+
+var ExampleTestsStringPtrCode = `// stringPtr returns a pointer to v.
+func stringPtr(v string) *string {
+	return &v
+}
+`
+
+var ExampleTestsAuthServerCode = `// newAuthTestServer starts a test HTTP server that serves the "Auth" service
+// endpoints implemented by svc.
+func newAuthTestServer(t *testing.T, svc *auth.MockService) *httptest.Server {
+	endpoints := auth.NewEndpoints(svc)
+	mux := goahttp.NewMuxer()
+	eh := func(ctx context.Context, w http.ResponseWriter, err error) {
+		t.Errorf("failed to encode response: %s", err)
+	}
+	server := authsvr.New(endpoints, mux, goahttp.RequestDecoder, goahttp.ResponseEncoder, eh)
+	authsvr.Mount(mux, server)
+	return httptest.NewServer(mux)
+}
+
+// newAuthTestClient returns a client of the "Auth" service that makes requests
+// to srv.
+func newAuthTestClient(srv *httptest.Server) *authc.Client {
+	u, _ := url.Parse(srv.URL)
+	return authc.NewClient(u.Scheme, u.Host, srv.Client(), goahttp.RequestEncoder, goahttp.ResponseDecoder, false)
+}
+`
+
+var ExampleTestsNamedExamplesCode = `// TestAuthLogin tests the "login" endpoint of the "Auth" service. The first
+// case is initialized with the design examples, add cases to cover the other
+// behaviors of the endpoint.
+func TestAuthLogin(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Payload *auth.Token
+		Result  *auth.Session
+		Err     error
+		Status  int
+	}{
+		{
+			Name: "valid token",
+			Payload: &auth.Token{
+				Value: "abc",
+			},
+			Result: &auth.Session{
+				ID:  "s1",
+				TTL: intPtr(3600),
+			},
+			Status: http.StatusOK,
+		},
+		{
+			Name: "expired token",
+			Payload: &auth.Token{
+				Value: "xyz",
+			},
+			Result: &auth.Session{
+				ID:  "s1",
+				TTL: intPtr(3600),
+			},
+			Status: http.StatusOK,
+		},
+		{
+			Name: "expiring session",
+			Payload: &auth.Token{
+				Value: "abc",
+			},
+			Result: &auth.Session{
+				ID:  "s2",
+				TTL: intPtr(10),
+			},
+			Status: http.StatusOK,
+		},
+		{
+			Name: "expired token",
+			Payload: &auth.Token{
+				Value: "abc",
+			},
+			Err: &auth.Expired{
+				Reason: "token expired",
+			},
+			Status: http.StatusUnauthorized,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			svc := &auth.MockService{
+				LoginFunc: func(ctx context.Context, p *auth.Token) (res *auth.Session, err error) {
+					return c.Result, c.Err
+				},
+			}
+			srv := newAuthTestServer(t, svc)
+			defer srv.Close()
+			cli := newAuthTestClient(srv)
+
+			req, err := cli.BuildLoginRequest(context.Background(), c.Payload)
+			if err != nil {
+				t.Fatalf("failed to build request: %s", err)
+			}
+			if err := authc.EncodeLoginRequest(goahttp.RequestEncoder)(req, c.Payload); err != nil {
+				t.Fatalf("failed to encode request: %s", err)
+			}
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatalf("request failed: %s", err)
+			}
+			if resp.StatusCode != c.Status {
+				t.Errorf("got status %d, expected %d", resp.StatusCode, c.Status)
+			}
+			res, err := authc.DecodeLoginResponse(goahttp.ResponseDecoder, false)(resp)
+			if c.Err != nil {
+				if err == nil {
+					t.Errorf("got no error, expected %v", c.Err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to decode response: %s", err)
+			}
+			if !reflect.DeepEqual(res, c.Result) {
+				t.Errorf("got result %#v, expected %#v", res, c.Result)
+			}
+		})
+	}
+}
+`
+
+var DummyNamedExamplesLoginCode = `// Login implements login.
+func (s *authSvc) Login(ctx context.Context, p *auth.Token) (res *auth.Session, err error) {
+	res = &auth.Session{
+		ID:  "s1",
+		TTL: authIntPtr(3600),
+	}
+	s.logger.Print("auth.login")
+	return
+}
+`
+
+var DummyNamedExamplesIntPtrCode = `// authIntPtr returns a pointer to v.
+func authIntPtr(v int) *int {
+	return &v
+}
+`
@@ -0,0 +1,103 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var ExampleTestsDSL = func() {
+	var Bottle = Type("Bottle", func() {
+		Attribute("name", String)
+		Attribute("vintage", Int)
+		Attribute("color", String, func() {
+			Enum("red", "white")
+		})
+		Attribute("tags", ArrayOf(String))
+		Required("name")
+	})
+	Service("Storage", func() {
+		Method("show", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("verbose", Boolean)
+				Required("id")
+			})
+			Result(Bottle)
+			HTTP(func() {
+				GET("/{id}")
+				Param("verbose")
+			})
+		})
+		Method("add", func() {
+			Payload(Bottle)
+			Result(String)
+			HTTP(func() {
+				POST("/")
+				Response(StatusCreated)
+			})
+		})
+		Method("remove", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Required("id")
+			})
+			HTTP(func() {
+				DELETE("/{id}")
+				Response(StatusNoContent)
+			})
+		})
+		Method("watch", func() {
+			StreamingResult(Bottle)
+			HTTP(func() {
+				GET("/watch")
+			})
+		})
+	})
+}
+
+var PrimitiveExampleTestsDSL = func() {
+	Service("Echo", func() {
+		Method("echo", func() {
+			Payload(String)
+			Result(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var NamedExamplesDSL = func() {
+	var Token = Type("Token", func() {
+		Attribute("value", String)
+		Required("value")
+		Example("valid token", Val{"value": "abc"})
+		Example("expired token", Val{"value": "xyz"})
+	})
+	var Session = Type("Session", func() {
+		Attribute("id", String)
+		Attribute("ttl", Int)
+		Required("id")
+		Example("new session", Val{"id": "s1", "ttl": 3600})
+		Example("expiring session", func() {
+			Description("The session expires in 10 seconds.")
+			Value(Val{"id": "s2", "ttl": 10})
+		})
+	})
+	var Expired = Type("Expired", func() {
+		Attribute("reason", String)
+		Required("reason")
+		Example("expired token", Val{"reason": "token expired"})
+	})
+	Service("Auth", func() {
+		Method("login", func() {
+			Payload(Token)
+			Result(Session)
+			Error("expired", Expired)
+			HTTP(func() {
+				POST("/login")
+				Response("expired", StatusUnauthorized)
+			})
+		})
+	})
+}
@@ -0,0 +1,702 @@
+package testdata
+
+var FastJSONServerTypesCode = `// MarshalJSON encodes MethodFastJSONRequestBody to JSON without using
+// reflection.
+func (body *MethodFastJSONRequestBody) MarshalJSON() ([]byte, error) {
+	w := goahttp.NewJSONWriter()
+	body.encodeJSON(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalJSON decodes MethodFastJSONRequestBody from JSON without using
+// reflection.
+func (body *MethodFastJSONRequestBody) UnmarshalJSON(data []byte) error {
+	r := goahttp.NewJSONReader(data)
+	if !r.Null() {
+		body.decodeJSON(r)
+	}
+	return r.Err()
+}
+
+// encodeJSON writes body to w.
+func (body *MethodFastJSONRequestBody) encodeJSON(w *goahttp.JSONWriter) {
+	w.ObjectStart()
+	if body.String != nil {
+		w.Field("string")
+		w.String(*body.String)
+	}
+	if body.Int != nil {
+		w.Field("int")
+		w.Int(int64(*body.Int))
+	}
+	if body.Float32 != nil {
+		w.Field("float32")
+		w.Float(float64(*body.Float32), 32)
+	}
+	if body.Bytes != nil {
+		w.Field("bytes")
+		w.Base64(*body.Bytes)
+	}
+	if body.Any != nil {
+		w.Field("any")
+		w.Value(*body.Any)
+	}
+	if len(body.Strings) > 0 {
+		w.Field("strings")
+		w.ArrayStart()
+		for _, e := range body.Strings {
+			w.String(e)
+		}
+		w.ArrayEnd()
+	}
+	if len(body.Children) > 0 {
+		w.Field("children")
+		w.ArrayStart()
+		for _, e := range body.Children {
+			if e == nil {
+				w.Null()
+			} else {
+				e.encodeJSON(w)
+			}
+		}
+		w.ArrayEnd()
+	}
+	if len(body.Map) > 0 {
+		w.Field("map")
+		keys := make([]string, 0, len(body.Map))
+		for k := range body.Map {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		w.ObjectStart()
+		for _, k := range keys {
+			e := body.Map[k]
+			w.Field(k)
+			if e == nil {
+				w.Null()
+			} else {
+				w.ArrayStart()
+				for _, e2 := range e {
+					w.Int(int64(e2))
+				}
+				w.ArrayEnd()
+			}
+		}
+		w.ObjectEnd()
+	}
+	if body.Child != nil {
+		w.Field("child")
+		body.Child.encodeJSON(w)
+	}
+	w.ObjectEnd()
+}
+
+// decodeJSON reads body from r.
+func (body *MethodFastJSONRequestBody) decodeJSON(r *goahttp.JSONReader) {
+	r.ObjectStart()
+	for r.More() {
+		switch r.Field() {
+		case "string":
+			if r.Null() {
+				body.String = nil
+			} else {
+				v := r.String()
+				body.String = &v
+			}
+		case "int":
+			if r.Null() {
+				body.Int = nil
+			} else {
+				v := int(r.Int(0))
+				body.Int = &v
+			}
+		case "float32":
+			if r.Null() {
+				body.Float32 = nil
+			} else {
+				v := float32(r.Float(32))
+				body.Float32 = &v
+			}
+		case "bytes":
+			if r.Null() {
+				body.Bytes = nil
+			} else {
+				v := r.Base64()
+				body.Bytes = &v
+			}
+		case "any":
+			r.Value(&body.Any)
+		case "strings":
+			if r.Null() {
+				body.Strings = nil
+			} else {
+				body.Strings = []string{}
+				r.ArrayStart()
+				for r.More() {
+					var e string
+					if !r.Null() {
+						e = r.String()
+					}
+					body.Strings = append(body.Strings, e)
+				}
+				r.ArrayEnd()
+			}
+		case "children":
+			if r.Null() {
+				body.Children = nil
+			} else {
+				body.Children = []*ChildRequestBody{}
+				r.ArrayStart()
+				for r.More() {
+					var e *ChildRequestBody
+					if !r.Null() {
+						e = &ChildRequestBody{}
+						e.decodeJSON(r)
+					}
+					body.Children = append(body.Children, e)
+				}
+				r.ArrayEnd()
+			}
+		case "map":
+			if r.Null() {
+				body.Map = nil
+			} else {
+				body.Map = make(map[string][]int)
+				r.ObjectStart()
+				for r.More() {
+					k := r.Field()
+					var e []int
+					if !r.Null() {
+						e = []int{}
+						r.ArrayStart()
+						for r.More() {
+							var e2 int
+							if !r.Null() {
+								e2 = int(r.Int(0))
+							}
+							e = append(e, e2)
+						}
+						r.ArrayEnd()
+					}
+					body.Map[k] = e
+				}
+				r.ObjectEnd()
+			}
+		case "child":
+			if r.Null() {
+				body.Child = nil
+			} else {
+				body.Child = &ChildRequestBody{}
+				body.Child.decodeJSON(r)
+			}
+		default:
+			r.Skip()
+		}
+	}
+	r.ObjectEnd()
+}
+
+// MarshalJSON encodes MethodFastJSONResponseBody to JSON without using
+// reflection.
+func (body *MethodFastJSONResponseBody) MarshalJSON() ([]byte, error) {
+	w := goahttp.NewJSONWriter()
+	body.encodeJSON(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalJSON decodes MethodFastJSONResponseBody from JSON without using
+// reflection.
+func (body *MethodFastJSONResponseBody) UnmarshalJSON(data []byte) error {
+	r := goahttp.NewJSONReader(data)
+	if !r.Null() {
+		body.decodeJSON(r)
+	}
+	return r.Err()
+}
+
+// encodeJSON writes body to w.
+func (body *MethodFastJSONResponseBody) encodeJSON(w *goahttp.JSONWriter) {
+	w.ObjectStart()
+	if body.Count != nil {
+		w.Field("count")
+		w.Int(int64(*body.Count))
+	}
+	if body.OK != nil {
+		w.Field("ok")
+		w.Bool(*body.OK)
+	}
+	if body.Child != nil {
+		w.Field("child")
+		body.Child.encodeJSON(w)
+	}
+	w.ObjectEnd()
+}
+
+// decodeJSON reads body from r.
+func (body *MethodFastJSONResponseBody) decodeJSON(r *goahttp.JSONReader) {
+	r.ObjectStart()
+	for r.More() {
+		switch r.Field() {
+		case "count":
+			if r.Null() {
+				body.Count = nil
+			} else {
+				v := int(r.Int(0))
+				body.Count = &v
+			}
+		case "ok":
+			if r.Null() {
+				body.OK = nil
+			} else {
+				v := r.Bool()
+				body.OK = &v
+			}
+		case "child":
+			if r.Null() {
+				body.Child = nil
+			} else {
+				body.Child = &ChildResponseBody{}
+				body.Child.decodeJSON(r)
+			}
+		default:
+			r.Skip()
+		}
+	}
+	r.ObjectEnd()
+}
+
+// MarshalJSON encodes ChildRequestBody to JSON without using reflection.
+func (body *ChildRequestBody) MarshalJSON() ([]byte, error) {
+	w := goahttp.NewJSONWriter()
+	body.encodeJSON(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalJSON decodes ChildRequestBody from JSON without using reflection.
+func (body *ChildRequestBody) UnmarshalJSON(data []byte) error {
+	r := goahttp.NewJSONReader(data)
+	if !r.Null() {
+		body.decodeJSON(r)
+	}
+	return r.Err()
+}
+
+// encodeJSON writes body to w.
+func (body *ChildRequestBody) encodeJSON(w *goahttp.JSONWriter) {
+	w.ObjectStart()
+	if body.Name != nil {
+		w.Field("name")
+		w.String(*body.Name)
+	}
+	w.ObjectEnd()
+}
+
+// decodeJSON reads body from r.
+func (body *ChildRequestBody) decodeJSON(r *goahttp.JSONReader) {
+	r.ObjectStart()
+	for r.More() {
+		switch r.Field() {
+		case "name":
+			if r.Null() {
+				body.Name = nil
+			} else {
+				v := r.String()
+				body.Name = &v
+			}
+		default:
+			r.Skip()
+		}
+	}
+	r.ObjectEnd()
+}
+
+// MarshalJSON encodes ChildResponseBody to JSON without using reflection.
+func (body *ChildResponseBody) MarshalJSON() ([]byte, error) {
+	w := goahttp.NewJSONWriter()
+	body.encodeJSON(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalJSON decodes ChildResponseBody from JSON without using reflection.
+func (body *ChildResponseBody) UnmarshalJSON(data []byte) error {
+	r := goahttp.NewJSONReader(data)
+	if !r.Null() {
+		body.decodeJSON(r)
+	}
+	return r.Err()
+}
+
+// encodeJSON writes body to w.
+func (body *ChildResponseBody) encodeJSON(w *goahttp.JSONWriter) {
+	w.ObjectStart()
+	if body.Name != nil {
+		w.Field("name")
+		w.String(*body.Name)
+	}
+	w.ObjectEnd()
+}
+
+// decodeJSON reads body from r.
+func (body *ChildResponseBody) decodeJSON(r *goahttp.JSONReader) {
+	r.ObjectStart()
+	for r.More() {
+		switch r.Field() {
+		case "name":
+			if r.Null() {
+				body.Name = nil
+			} else {
+				v := r.String()
+				body.Name = &v
+			}
+		default:
+			r.Skip()
+		}
+	}
+	r.ObjectEnd()
+}
+`
+
+var FastJSONClientTypesCode = `// MarshalJSON encodes MethodFastJSONRequestBody to JSON without using
+// reflection.
+func (body *MethodFastJSONRequestBody) MarshalJSON() ([]byte, error) {
+	w := goahttp.NewJSONWriter()
+	body.encodeJSON(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalJSON decodes MethodFastJSONRequestBody from JSON without using
+// reflection.
+func (body *MethodFastJSONRequestBody) UnmarshalJSON(data []byte) error {
+	r := goahttp.NewJSONReader(data)
+	if !r.Null() {
+		body.decodeJSON(r)
+	}
+	return r.Err()
+}
+
+// encodeJSON writes body to w.
+func (body *MethodFastJSONRequestBody) encodeJSON(w *goahttp.JSONWriter) {
+	w.ObjectStart()
+	w.Field("string")
+	w.String(body.String)
+	if body.Int != nil {
+		w.Field("int")
+		w.Int(int64(*body.Int))
+	}
+	if body.Float32 != nil {
+		w.Field("float32")
+		w.Float(float64(*body.Float32), 32)
+	}
+	if len(body.Bytes) > 0 {
+		w.Field("bytes")
+		w.Base64(body.Bytes)
+	}
+	if body.Any != nil {
+		w.Field("any")
+		w.Value(body.Any)
+	}
+	if len(body.Strings) > 0 {
+		w.Field("strings")
+		w.ArrayStart()
+		for _, e := range body.Strings {
+			w.String(e)
+		}
+		w.ArrayEnd()
+	}
+	if len(body.Children) > 0 {
+		w.Field("children")
+		w.ArrayStart()
+		for _, e := range body.Children {
+			if e == nil {
+				w.Null()
+			} else {
+				e.encodeJSON(w)
+			}
+		}
+		w.ArrayEnd()
+	}
+	if len(body.Map) > 0 {
+		w.Field("map")
+		keys := make([]string, 0, len(body.Map))
+		for k := range body.Map {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		w.ObjectStart()
+		for _, k := range keys {
+			e := body.Map[k]
+			w.Field(k)
+			if e == nil {
+				w.Null()
+			} else {
+				w.ArrayStart()
+				for _, e2 := range e {
+					w.Int(int64(e2))
+				}
+				w.ArrayEnd()
+			}
+		}
+		w.ObjectEnd()
+	}
+	if body.Child != nil {
+		w.Field("child")
+		body.Child.encodeJSON(w)
+	}
+	w.ObjectEnd()
+}
+
+// decodeJSON reads body from r.
+func (body *MethodFastJSONRequestBody) decodeJSON(r *goahttp.JSONReader) {
+	r.ObjectStart()
+	for r.More() {
+		switch r.Field() {
+		case "string":
+			if !r.Null() {
+				body.String = r.String()
+			}
+		case "int":
+			if r.Null() {
+				body.Int = nil
+			} else {
+				v := int(r.Int(0))
+				body.Int = &v
+			}
+		case "float32":
+			if r.Null() {
+				body.Float32 = nil
+			} else {
+				v := float32(r.Float(32))
+				body.Float32 = &v
+			}
+		case "bytes":
+			if r.Null() {
+				body.Bytes = nil
+			} else {
+				body.Bytes = r.Base64()
+			}
+		case "any":
+			r.Value(&body.Any)
+		case "strings":
+			if r.Null() {
+				body.Strings = nil
+			} else {
+				body.Strings = []string{}
+				r.ArrayStart()
+				for r.More() {
+					var e string
+					if !r.Null() {
+						e = r.String()
+					}
+					body.Strings = append(body.Strings, e)
+				}
+				r.ArrayEnd()
+			}
+		case "children":
+			if r.Null() {
+				body.Children = nil
+			} else {
+				body.Children = []*ChildRequestBody{}
+				r.ArrayStart()
+				for r.More() {
+					var e *ChildRequestBody
+					if !r.Null() {
+						e = &ChildRequestBody{}
+						e.decodeJSON(r)
+					}
+					body.Children = append(body.Children, e)
+				}
+				r.ArrayEnd()
+			}
+		case "map":
+			if r.Null() {
+				body.Map = nil
+			} else {
+				body.Map = make(map[string][]int)
+				r.ObjectStart()
+				for r.More() {
+					k := r.Field()
+					var e []int
+					if !r.Null() {
+						e = []int{}
+						r.ArrayStart()
+						for r.More() {
+							var e2 int
+							if !r.Null() {
+								e2 = int(r.Int(0))
+							}
+							e = append(e, e2)
+						}
+						r.ArrayEnd()
+					}
+					body.Map[k] = e
+				}
+				r.ObjectEnd()
+			}
+		case "child":
+			if r.Null() {
+				body.Child = nil
+			} else {
+				body.Child = &ChildRequestBody{}
+				body.Child.decodeJSON(r)
+			}
+		default:
+			r.Skip()
+		}
+	}
+	r.ObjectEnd()
+}
+
+// MarshalJSON encodes MethodFastJSONResponseBody to JSON without using
+// reflection.
+func (body *MethodFastJSONResponseBody) MarshalJSON() ([]byte, error) {
+	w := goahttp.NewJSONWriter()
+	body.encodeJSON(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalJSON decodes MethodFastJSONResponseBody from JSON without using
+// reflection.
+func (body *MethodFastJSONResponseBody) UnmarshalJSON(data []byte) error {
+	r := goahttp.NewJSONReader(data)
+	if !r.Null() {
+		body.decodeJSON(r)
+	}
+	return r.Err()
+}
+
+// encodeJSON writes body to w.
+func (body *MethodFastJSONResponseBody) encodeJSON(w *goahttp.JSONWriter) {
+	w.ObjectStart()
+	if body.Count != nil {
+		w.Field("count")
+		w.Int(int64(*body.Count))
+	}
+	if body.OK != nil {
+		w.Field("ok")
+		w.Bool(*body.OK)
+	}
+	if body.Child != nil {
+		w.Field("child")
+		body.Child.encodeJSON(w)
+	}
+	w.ObjectEnd()
+}
+
+// decodeJSON reads body from r.
+func (body *MethodFastJSONResponseBody) decodeJSON(r *goahttp.JSONReader) {
+	r.ObjectStart()
+	for r.More() {
+		switch r.Field() {
+		case "count":
+			if r.Null() {
+				body.Count = nil
+			} else {
+				v := int(r.Int(0))
+				body.Count = &v
+			}
+		case "ok":
+			if r.Null() {
+				body.OK = nil
+			} else {
+				v := r.Bool()
+				body.OK = &v
+			}
+		case "child":
+			if r.Null() {
+				body.Child = nil
+			} else {
+				body.Child = &ChildResponseBody{}
+				body.Child.decodeJSON(r)
+			}
+		default:
+			r.Skip()
+		}
+	}
+	r.ObjectEnd()
+}
+
+// MarshalJSON encodes ChildRequestBody to JSON without using reflection.
+func (body *ChildRequestBody) MarshalJSON() ([]byte, error) {
+	w := goahttp.NewJSONWriter()
+	body.encodeJSON(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalJSON decodes ChildRequestBody from JSON without using reflection.
+func (body *ChildRequestBody) UnmarshalJSON(data []byte) error {
+	r := goahttp.NewJSONReader(data)
+	if !r.Null() {
+		body.decodeJSON(r)
+	}
+	return r.Err()
+}
+
+// encodeJSON writes body to w.
+func (body *ChildRequestBody) encodeJSON(w *goahttp.JSONWriter) {
+	w.ObjectStart()
+	w.Field("name")
+	w.String(body.Name)
+	w.ObjectEnd()
+}
+
+// decodeJSON reads body from r.
+func (body *ChildRequestBody) decodeJSON(r *goahttp.JSONReader) {
+	r.ObjectStart()
+	for r.More() {
+		switch r.Field() {
+		case "name":
+			if !r.Null() {
+				body.Name = r.String()
+			}
+		default:
+			r.Skip()
+		}
+	}
+	r.ObjectEnd()
+}
+
+// MarshalJSON encodes ChildResponseBody to JSON without using reflection.
+func (body *ChildResponseBody) MarshalJSON() ([]byte, error) {
+	w := goahttp.NewJSONWriter()
+	body.encodeJSON(w)
+	return w.Bytes(), w.Err()
+}
+
+// UnmarshalJSON decodes ChildResponseBody from JSON without using reflection.
+func (body *ChildResponseBody) UnmarshalJSON(data []byte) error {
+	r := goahttp.NewJSONReader(data)
+	if !r.Null() {
+		body.decodeJSON(r)
+	}
+	return r.Err()
+}
+
+// encodeJSON writes body to w.
+func (body *ChildResponseBody) encodeJSON(w *goahttp.JSONWriter) {
+	w.ObjectStart()
+	if body.Name != nil {
+		w.Field("name")
+		w.String(*body.Name)
+	}
+	w.ObjectEnd()
+}
+
+// decodeJSON reads body from r.
+func (body *ChildResponseBody) decodeJSON(r *goahttp.JSONReader) {
+	r.ObjectStart()
+	for r.More() {
+		switch r.Field() {
+		case "name":
+			if r.Null() {
+				body.Name = nil
+			} else {
+				v := r.String()
+				body.Name = &v
+			}
+		default:
+			r.Skip()
+		}
+	}
+	r.ObjectEnd()
+}
+`
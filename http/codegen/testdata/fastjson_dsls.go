@@ -0,0 +1,41 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var FastJSONDSL = func() {
+	var Child = Type("Child", func() {
+		Attribute("name", String)
+		Required("name")
+	})
+	Service("ServiceFastJSON", func() {
+		Metadata("http:body:fastjson")
+		Method("MethodFastJSON", func() {
+			Payload(func() {
+				Attribute("string", String)
+				Attribute("int", Int)
+				Attribute("float32", Float32)
+				Attribute("bytes", Bytes)
+				Attribute("any", Any)
+				Attribute("strings", ArrayOf(String))
+				Attribute("children", ArrayOf(Child))
+				Attribute("map", MapOf(String, ArrayOf(Int)))
+				Attribute("child", Child)
+				Required("string")
+			})
+			Result(func() {
+				Attribute("count", Int, func() {
+					Default(1)
+				})
+				Attribute("ok", Boolean)
+				Attribute("child", Child)
+				Required("child")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
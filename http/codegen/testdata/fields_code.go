@@ -0,0 +1,61 @@
+package testdata
+
+var FieldSelectionHandlerInitCode = `// NewMethodFieldSelectionHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceFieldSelection" service "MethodFieldSelection"
+// endpoint.
+func NewMethodFieldSelectionHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodFieldSelectionResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+		fields         = []string{"id", "title", "authors", "authors.name", "authors.email"}
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodFieldSelection")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceFieldSelection")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
+		ctx, err := goahttp.WithFields(ctx, r.URL.Query().Get("fields"), fields)
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+
+		res, err := endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var FieldSelectionResponseEncoderCode = `// EncodeMethodFieldSelectionResponse returns an encoder for responses returned
+// by the ServiceFieldSelection MethodFieldSelection endpoint.
+func EncodeMethodFieldSelectionResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*servicefieldselectionviews.Book)
+		enc := encoder(ctx, w)
+		body := NewMethodFieldSelectionResponseBody(res.Projected)
+		w.WriteHeader(http.StatusOK)
+		selected, err := goahttp.SelectFields(ctx, body)
+		if err != nil {
+			return err
+		}
+		return enc.Encode(selected)
+	}
+}
+`
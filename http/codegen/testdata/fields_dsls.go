@@ -0,0 +1,31 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var FieldSelectionDSL = func() {
+	var Author = Type("Author", func() {
+		Attribute("name", String)
+		Attribute("email", String)
+	})
+	var Book = ResultType("application/vnd.goa.book", func() {
+		TypeName("Book")
+		Attributes(func() {
+			Attribute("id", Int)
+			Attribute("title", String)
+			Attribute("authors", ArrayOf(Author))
+		})
+	})
+	Service("ServiceFieldSelection", func() {
+		Method("MethodFieldSelection", func() {
+			Result(Book)
+			HTTP(func() {
+				GET("/")
+				FieldSelection()
+				Response(StatusOK)
+			})
+		})
+	})
+}
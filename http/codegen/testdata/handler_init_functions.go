@@ -18,6 +18,7 @@ func NewMethodNoPayloadNoResultHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodNoPayloadNoResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceNoPayloadNoResult")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
 
 		res, err := endpoint(ctx, nil)
 
@@ -53,6 +54,7 @@ func NewMethodPayloadNoResultHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPayloadNoResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePayloadNoResult")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
 		payload, err := decodeRequest(r)
 		if err != nil {
 			eh(ctx, w, err)
@@ -92,6 +94,7 @@ func NewMethodNoPayloadResultHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodNoPayloadResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceNoPayloadResult")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
 
 		res, err := endpoint(ctx, nil)
 
@@ -127,6 +130,7 @@ func NewMethodPayloadResultHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPayloadResult")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePayloadResult")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
 		payload, err := decodeRequest(r)
 		if err != nil {
 			eh(ctx, w, err)
@@ -167,6 +171,7 @@ func NewMethodPayloadResultErrorHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPayloadResultError")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePayloadResultError")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
 		payload, err := decodeRequest(r)
 		if err != nil {
 			eh(ctx, w, err)
@@ -187,3 +192,38 @@ func NewMethodPayloadResultErrorHandler(
 	})
 }
 `
+
+var ServerPanicErrorHandlerConstructorCode = `// NewMethodPanicErrorHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServicePanicError" service "MethodPanicError"
+// endpoint.
+func NewMethodPanicErrorHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodPanicErrorResponse(enc)
+		encodeError    = EncodeMethodPanicErrorError(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodPanicError")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServicePanicError")
+		defer goahttp.Recover(ctx, w, "internal", encodeError, eh)
+
+		res, err := endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
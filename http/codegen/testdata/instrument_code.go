@@ -0,0 +1,19 @@
+package testdata
+
+var ServerInstrumentCode = `// Instrument wraps each server handler with the middleware returned by m for
+// the handler service and endpoint names, for example to record metrics
+// labeled by endpoint.
+func (s *Server) Instrument(m func(service, endpoint string) func(http.Handler) http.Handler) {
+	s.MethodRouteTable = m("ServiceRouteTable", "MethodRouteTable")(s.MethodRouteTable)
+	s.MethodRoot = m("ServiceRouteTable", "MethodRoot")(s.MethodRoot)
+}
+`
+
+var ServerAccessLogCode = `// AccessLog wraps each server handler with a middleware that logs the requests
+// using l. fields lists the fields of the log entries, see
+// middleware.AccessLog.
+func (s *Server) AccessLog(l middleware.AccessLogger, fields ...middleware.AccessLogField) {
+	s.MethodRouteTable = middleware.AccessLog(l, "MethodRouteTable", []string{"GET /accounts/{id}/users/{name}", "POST /accounts/{name}/{id}"}, fields...)(s.MethodRouteTable)
+	s.MethodRoot = middleware.AccessLog(l, "MethodRoot", []string{"GET /accounts"}, fields...)(s.MethodRoot)
+}
+`
@@ -0,0 +1,86 @@
+package testdata
+
+var MaxBodyServerHandlerInitCode = `// NewMethodMaxBodyHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceMaxBody" service "MethodMaxBody" endpoint.
+func NewMethodMaxBodyHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodMaxBodyRequest(mux, dec)
+		encodeResponse = EncodeMethodMaxBodyResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, 512)
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodMaxBody")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceMaxBody")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
+		payload, err := decodeRequest(r)
+		if err != nil {
+			eh(ctx, w, err)
+			return
+		}
+
+		res, err := endpoint(ctx, payload)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var MaxBodyRequestDecoderCode = `// DecodeMethodMaxBodyRequest returns a decoder for requests sent to the
+// ServiceMaxBody MethodMaxBody endpoint.
+func DecodeMethodMaxBodyRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodMaxBodyRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			var mbe *http.MaxBytesError
+			if errors.As(err, &mbe) {
+				return nil, goahttp.ErrRequestBodyTooLarge(512)
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		payload := NewMethodMaxBodyPayload(&body)
+
+		return payload, nil
+	}
+}
+`
+
+var MaxBodyMultipartRequestDecoderCode = `// DecodeMethodMaxBodyMultipartRequest returns a decoder for requests sent to
+// the ServiceMaxBodyMultipart MethodMaxBodyMultipart endpoint.
+func DecodeMethodMaxBodyMultipartRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var payload *servicemaxbodymultipart.MethodMaxBodyMultipartPayload
+		if err := decoder(r).Decode(&payload); err != nil {
+			var mbe *http.MaxBytesError
+			if errors.As(err, &mbe) {
+				return nil, goahttp.ErrRequestBodyTooLarge(512)
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		return payload, nil
+	}
+}
+`
@@ -0,0 +1,40 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var MaxBodyDSL = func() {
+	API("MaxBody", func() {
+		HTTP(func() {
+			MaxBody(1024)
+		})
+	})
+	Service("ServiceMaxBody", func() {
+		Method("MethodMaxBody", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+				MaxBody(512)
+			})
+		})
+	})
+}
+
+var MaxBodyMultipartDSL = func() {
+	Service("ServiceMaxBodyMultipart", func() {
+		Method("MethodMaxBodyMultipart", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+				MaxBody(512)
+			})
+		})
+	})
+}
@@ -138,3 +138,80 @@ var MultiDSL = func() {
 		})
 	})
 }
+
+var ResultTableDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("a", String)
+		Attribute("b", Boolean)
+	})
+	Service("ServiceResultTable", func() {
+		Method("MethodObject", func() {
+			Result(func() {
+				Attribute("id", Int)
+				Attribute("name", String)
+				Attribute("tags", ArrayOf(String))
+				Required("id")
+			})
+			HTTP(func() {
+				GET("/")
+			})
+		})
+		Method("MethodCollection", func() {
+			Result(ArrayOf(Item))
+			HTTP(func() {
+				GET("/collection")
+			})
+		})
+		Method("MethodPrimitive", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/primitive")
+			})
+		})
+	})
+}
+
+var CompletionDSL = func() {
+	Service("ServiceCompletion", func() {
+		Method("MethodEnum", func() {
+			Payload(func() {
+				Attribute("kind", String, func() {
+					Enum("a", "b")
+				})
+				Attribute("count", Int)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("kind")
+				Param("count")
+			})
+		})
+		Method("MethodNoPayload", func() {
+			HTTP(func() {
+				GET("/none")
+			})
+		})
+	})
+}
+
+var MultiSharedTypesDSL = func() {
+	var Shared = Type("Shared", func() {
+		Attribute("name", String)
+		Attribute("count", Int)
+		Attribute("tags", ArrayOf(String))
+		Attribute("inner", func() {
+			Attribute("value", Float64)
+		})
+	})
+	for _, name := range []string{"ServiceShared1", "ServiceShared2", "ServiceShared3", "ServiceShared4"} {
+		Service(name, func() {
+			Method("MethodShared", func() {
+				Payload(Shared)
+				Result(Shared)
+				HTTP(func() {
+					POST("/")
+				})
+			})
+		})
+	}
+}
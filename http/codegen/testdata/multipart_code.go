@@ -72,6 +72,9 @@ var MultipartUserTypeDecoderFuncCode = `// NewServiceMultipartUserTypeMethodMult
 // to decode the multipart request for the "ServiceMultipartUserType" service
 // "MethodMultipartUserType" endpoint.
 func NewServiceMultipartUserTypeMethodMultipartUserTypeDecoder(mux goahttp.Muxer, ServiceMultipartUserTypeMethodMultipartUserTypeDecoderFn ServiceMultipartUserTypeMethodMultipartUserTypeDecoderFunc) func(r *http.Request) goahttp.Decoder {
+	if ServiceMultipartUserTypeMethodMultipartUserTypeDecoderFn == nil {
+		ServiceMultipartUserTypeMethodMultipartUserTypeDecoderFn = DefaultServiceMultipartUserTypeMethodMultipartUserTypeDecoder
+	}
 	return func(r *http.Request) goahttp.Decoder {
 		return goahttp.EncodingFunc(func(v interface{}) error {
 			mr, err := r.MultipartReader()
@@ -132,6 +135,9 @@ var MultipartWithParamsDecoderFuncCode = `// NewServiceMultipartWithParamsMethod
 // decoder to decode the multipart request for the "ServiceMultipartWithParams"
 // service "MethodMultipartWithParams" endpoint.
 func NewServiceMultipartWithParamsMethodMultipartWithParamsDecoder(mux goahttp.Muxer, ServiceMultipartWithParamsMethodMultipartWithParamsDecoderFn ServiceMultipartWithParamsMethodMultipartWithParamsDecoderFunc) func(r *http.Request) goahttp.Decoder {
+	if ServiceMultipartWithParamsMethodMultipartWithParamsDecoderFn == nil {
+		ServiceMultipartWithParamsMethodMultipartWithParamsDecoderFn = DefaultServiceMultipartWithParamsMethodMultipartWithParamsDecoder
+	}
 	return func(r *http.Request) goahttp.Decoder {
 		return goahttp.EncodingFunc(func(v interface{}) error {
 			mr, err := r.MultipartReader()
@@ -287,3 +293,219 @@ func NewServiceMultipartWithParamsMethodMultipartWithParamsEncoder(encoderFn Ser
 	}
 }
 `
+
+var StreamingMultipartDecoderFuncTypeCode = `// ServiceStreamingMultipartMethodStreamingMultipartDecoderFunc is the type to
+// decode multipart request for the "ServiceStreamingMultipart" service
+// "MethodStreamingMultipart" endpoint.
+type ServiceStreamingMultipartMethodStreamingMultipartDecoderFunc func(*goahttp.MultipartReader, **servicestreamingmultipart.MethodStreamingMultipartPayload) error
+`
+
+var StreamingMultipartDecoderFuncCode = `// NewServiceStreamingMultipartMethodStreamingMultipartDecoder returns a
+// decoder to decode the multipart request for the "ServiceStreamingMultipart"
+// service "MethodStreamingMultipart" endpoint.
+func NewServiceStreamingMultipartMethodStreamingMultipartDecoder(mux goahttp.Muxer, ServiceStreamingMultipartMethodStreamingMultipartDecoderFn ServiceStreamingMultipartMethodStreamingMultipartDecoderFunc) func(r *http.Request) goahttp.Decoder {
+	if ServiceStreamingMultipartMethodStreamingMultipartDecoderFn == nil {
+		ServiceStreamingMultipartMethodStreamingMultipartDecoderFn = DefaultServiceStreamingMultipartMethodStreamingMultipartDecoder
+	}
+	return func(r *http.Request) goahttp.Decoder {
+		return goahttp.EncodingFunc(func(v interface{}) error {
+			mr, err := goahttp.NewMultipartReader(r, 1024, 4096)
+			if err != nil {
+				return err
+			}
+			p := v.(**servicestreamingmultipart.MethodStreamingMultipartPayload)
+			if err := ServiceStreamingMultipartMethodStreamingMultipartDecoderFn(mr, p); err != nil {
+				return err
+			}
+			return nil
+		})
+	}
+}
+`
+
+var MultipartUserTypeDefaultDecoderCode = `// DefaultServiceMultipartUserTypeMethodMultipartUserTypeDecoder decodes the
+// multipart request for the "ServiceMultipartUserType" service
+// "MethodMultipartUserType" endpoint by mapping the form fields and files to
+// the payload attributes with the same name. It is used when no user decoder
+// function is provided.
+func DefaultServiceMultipartUserTypeMethodMultipartUserTypeDecoder(mr *multipart.Reader, p **servicemultipartusertype.MethodMultipartUserTypePayload) error {
+	var (
+		payload = &servicemultipartusertype.MethodMultipartUserTypePayload{}
+		err     error
+		bFound  bool
+		cFound  bool
+	)
+	for {
+		part, err2 := mr.NextPart()
+		if err2 == io.EOF {
+			break
+		}
+		if err2 != nil {
+			return err2
+		}
+		switch part.FormName() {
+		case "b":
+			raw, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return err2
+			}
+			b := string(raw)
+			payload.B = b
+			bFound = true
+		case "c":
+			if err2 := json.NewDecoder(part).Decode(&payload.C); err2 != nil {
+				err = goa.MergeErrors(err, goa.DecodePayloadError(err2.Error()))
+			}
+			cFound = true
+		}
+	}
+	if !bFound {
+		err = goa.MergeErrors(err, goa.MissingFieldError("b", "multipart request"))
+	}
+	if !cFound {
+		err = goa.MergeErrors(err, goa.MissingFieldError("c", "multipart request"))
+	}
+	if err != nil {
+		return err
+	}
+	*p = payload
+	return nil
+}
+`
+
+var MultipartDefaultDecoderCode = `// DefaultServiceMultipartDefaultDecoderMethodMultipartDefaultDecoderDecoder
+// decodes the multipart request for the "ServiceMultipartDefaultDecoder"
+// service "MethodMultipartDefaultDecoder" endpoint by mapping the form fields
+// and files to the payload attributes with the same name. It is used when no
+// user decoder function is provided.
+func DefaultServiceMultipartDefaultDecoderMethodMultipartDefaultDecoderDecoder(mr *multipart.Reader, p **servicemultipartdefaultdecoder.MethodMultipartDefaultDecoderPayload) error {
+	var (
+		payload   = &servicemultipartdefaultdecoder.MethodMultipartDefaultDecoderPayload{}
+		err       error
+		nameFound bool
+		fileFound bool
+	)
+	for {
+		part, err2 := mr.NextPart()
+		if err2 == io.EOF {
+			break
+		}
+		if err2 != nil {
+			return err2
+		}
+		switch part.FormName() {
+		case "name":
+			raw, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return err2
+			}
+			name := string(raw)
+			payload.Name = name
+			nameFound = true
+		case "age":
+			raw, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return err2
+			}
+			ageRaw := string(raw)
+			var age *int
+			v, err2 := strconv.ParseInt(ageRaw, 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("age", ageRaw, "integer"))
+			}
+			pv := int(v)
+			age = &pv
+			payload.Age = age
+		case "tags":
+			raw, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return err2
+			}
+			tagsRaw := string(raw)
+			var tags int
+			v, err2 := strconv.ParseInt(tagsRaw, 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("tags", tagsRaw, "integer"))
+			}
+			tags = int(v)
+			payload.Tags = append(payload.Tags, tags)
+		case "file":
+			raw, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return err2
+			}
+			file := raw
+			payload.File = file
+			fileFound = true
+		case "meta":
+			if err2 := json.NewDecoder(part).Decode(&payload.Meta); err2 != nil {
+				err = goa.MergeErrors(err, goa.DecodePayloadError(err2.Error()))
+			}
+		}
+	}
+	if !nameFound {
+		err = goa.MergeErrors(err, goa.MissingFieldError("name", "multipart request"))
+	}
+	if !fileFound {
+		err = goa.MergeErrors(err, goa.MissingFieldError("file", "multipart request"))
+	}
+	if err != nil {
+		return err
+	}
+	*p = payload
+	return nil
+}
+`
+
+var StreamingMultipartDefaultDecoderCode = `// DefaultServiceStreamingMultipartMethodStreamingMultipartDecoder decodes the
+// multipart request for the "ServiceStreamingMultipart" service
+// "MethodStreamingMultipart" endpoint by mapping the form fields and files to
+// the payload attributes with the same name. It is used when no user decoder
+// function is provided.
+func DefaultServiceStreamingMultipartMethodStreamingMultipartDecoder(mr *goahttp.MultipartReader, p **servicestreamingmultipart.MethodStreamingMultipartPayload) error {
+	var (
+		payload = &servicestreamingmultipart.MethodStreamingMultipartPayload{}
+		err     error
+		bFound  bool
+		cFound  bool
+	)
+	for {
+		part, err2 := mr.NextPart()
+		if err2 == io.EOF {
+			break
+		}
+		if err2 != nil {
+			return err2
+		}
+		switch part.FormName() {
+		case "b":
+			raw, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return err2
+			}
+			b := string(raw)
+			payload.B = b
+			bFound = true
+		case "c":
+			raw, err2 := ioutil.ReadAll(part)
+			if err2 != nil {
+				return err2
+			}
+			c := raw
+			payload.C = c
+			cFound = true
+		}
+	}
+	if !bFound {
+		err = goa.MergeErrors(err, goa.MissingFieldError("b", "multipart request"))
+	}
+	if !cFound {
+		err = goa.MergeErrors(err, goa.MissingFieldError("c", "multipart request"))
+	}
+	if err != nil {
+		return err
+	}
+	*p = payload
+	return nil
+}
+`
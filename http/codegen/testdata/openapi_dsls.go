@@ -0,0 +1,87 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var ExtensionDSL = func() {
+	API("ExtensionAPI", func() {
+		Metadata("openapi:extension:x-api", `{"gateway":"kong"}`)
+	})
+	var Body = Type("ExtensionBody", func() {
+		Attribute("name", String, func() {
+			Metadata("openapi:extension:x-attribute", `"masked"`)
+		})
+	})
+	Service("ServiceExtension", func() {
+		Metadata("openapi:extension:x-service", `true`)
+		Metadata("openapi:extension:x-override", `"service"`)
+		Method("MethodExtension", func() {
+			Metadata("openapi:extension:x-method", `{"timeout":30}`)
+			Metadata("openapi:extension:x-override", `"method"`)
+			Payload(Body)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var DefinitionsReuseDSL = func() {
+	var Owner = Type("Owner", func() {
+		Attribute("name", String)
+		Required("name")
+	})
+	var Pet = Type("Pet", func() {
+		Attribute("name", String)
+		Attribute("owner", Owner)
+		Required("name")
+	})
+	var Secret = Type("Secret", func() {
+		Attribute("name", String)
+		Attribute("password", String, func() {
+			Internal()
+		})
+	})
+	Service("ServiceDefinitionsReuse", func() {
+		Method("Create", func() {
+			Payload(Pet)
+			Result(Pet)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+		Method("Update", func() {
+			Payload(Pet)
+			Result(Pet)
+			HTTP(func() {
+				PUT("/")
+			})
+		})
+		Method("Login", func() {
+			Payload(Secret)
+			HTTP(func() {
+				POST("/login")
+			})
+		})
+	})
+}
+
+var SplitDefinitionsDSL = func() {
+	API("SplitDefinitionsAPI", func() {
+		Metadata("openapi:split", "true")
+	})
+	var Pet = Type("Pet", func() {
+		Attribute("name", String)
+	})
+	Service("ServiceSplitDefinitions", func() {
+		Method("MethodSplitDefinitions", func() {
+			Payload(Pet)
+			Result(Pet)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
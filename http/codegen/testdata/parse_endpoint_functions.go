@@ -96,6 +96,14 @@ func ParseEndpoint(
 			return nil, nil, err
 		}
 	}
+	if err := bindFlags(epf, svcn, epn); err != nil {
+		return nil, nil, err
+	}
+	if Interactive {
+		if err := promptFlags(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	var (
 		data     interface{}
@@ -232,6 +240,14 @@ func ParseEndpoint(
 			return nil, nil, err
 		}
 	}
+	if err := bindFlags(epf, svcn, epn); err != nil {
+		return nil, nil, err
+	}
+	if Interactive {
+		if err := promptFlags(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	var (
 		data     interface{}
@@ -362,6 +378,14 @@ func ParseEndpoint(
 			return nil, nil, err
 		}
 	}
+	if err := bindFlags(epf, svcn, epn); err != nil {
+		return nil, nil, err
+	}
+	if Interactive {
+		if err := promptFlags(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	var (
 		data     interface{}
@@ -474,6 +498,14 @@ func ParseEndpoint(
 			return nil, nil, err
 		}
 	}
+	if err := bindFlags(epf, svcn, epn); err != nil {
+		return nil, nil, err
+	}
+	if Interactive {
+		if err := promptFlags(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	var (
 		data     interface{}
@@ -691,6 +723,14 @@ func ParseEndpoint(
 			return nil, nil, err
 		}
 	}
+	if err := bindFlags(epf, svcn, epn); err != nil {
+		return nil, nil, err
+	}
+	if Interactive {
+		if err := promptFlags(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	var (
 		data     interface{}
@@ -789,6 +829,14 @@ func ParseEndpoint(
 			return nil, nil, err
 		}
 	}
+	if err := bindFlags(epf, svcn, epn); err != nil {
+		return nil, nil, err
+	}
+	if Interactive {
+		if err := promptFlags(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	var (
 		data     interface{}
@@ -944,6 +992,14 @@ func ParseEndpoint(
 			return nil, nil, err
 		}
 	}
+	if err := bindFlags(epf, svcn, epn); err != nil {
+		return nil, nil, err
+	}
+	if Interactive {
+		if err := promptFlags(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	var (
 		data     interface{}
@@ -1143,3 +1199,227 @@ func BuildMethodBodyPrimitiveArrayUserPayload(serviceBodyPrimitiveArrayUserMetho
 	return payload, nil
 }
 `
+
+var ResultTablePrintCode = `// PrintResult writes the result of an endpoint to w using the given format,
+// one of "json", "yaml" or "table". Results that cannot be rendered as tables
+// are written as JSON.
+func PrintResult(w io.Writer, data interface{}, format string) error {
+	switch format {
+	case "json":
+		m, err := json.MarshalIndent(data, "", "    ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(m))
+		return err
+	case "yaml":
+		m, err := yaml.Marshal(data)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(m)
+		return err
+	case "table":
+		return printTable(w, data)
+	default:
+		return fmt.Errorf("invalid output format %#v, must be one of json, yaml or table", format)
+	}
+}
+
+// printTable writes data to w as a table with one column per primitive
+// attribute of the result type.
+func printTable(w io.Writer, data interface{}) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	switch res := data.(type) {
+	case *serviceresulttable.MethodObjectResult:
+		fmt.Fprintln(tw, tableRow("ID", "NAME"))
+		fmt.Fprintln(tw, tableRow(res.ID, res.Name))
+	case []*serviceresulttable.Item:
+		fmt.Fprintln(tw, tableRow("A", "B"))
+		for _, r := range res {
+			fmt.Fprintln(tw, tableRow(r.A, r.B))
+		}
+	default:
+		return PrintResult(w, res, "json")
+	}
+	return tw.Flush()
+}
+
+// tableRow returns the tab separated string representation of the given
+// values, nil pointers are rendered as empty cells.
+func tableRow(vals ...interface{}) string {
+	cells := make([]string, len(vals))
+	for i, v := range vals {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				continue
+			}
+			v = rv.Elem().Interface()
+		}
+		cells[i] = fmt.Sprint(v)
+	}
+	return strings.Join(cells, "\t")
+}
+`
+
+var CompletionCode = `// completions maps the service commands to their endpoint subcommands and the
+// endpoint subcommands to their flags and the values allowed by the flags if
+// enumerated in the design.
+var completions = map[string]map[string]map[string][]string{
+	"service-completion": {
+		"method-enum": {
+			"kind":  {"a", "b"},
+			"count": nil,
+		},
+		"method-no-payload": {},
+	},
+}
+
+// Completion returns the script that enables the completion of the commands,
+// subcommands, flags and enumerated flag values of the CLI in the given shell,
+// one of "bash", "zsh" or "fish". prog is the name of the CLI executable. The
+// script relies on the "__complete" command which must print the result of
+// Complete.
+func Completion(shell, prog string) (string, error) {
+	switch shell {
+	case "bash":
+		return fmt.Sprintf(bashCompletion, prog), nil
+	case "zsh":
+		return fmt.Sprintf(zshCompletion, prog), nil
+	case "fish":
+		return fmt.Sprintf(fishCompletion, prog), nil
+	default:
+		return "", fmt.Errorf("unsupported shell %#v, must be one of bash, zsh or fish", shell)
+	}
+}
+
+// Complete returns the completion candidates of the last word in words given
+// the preceding words. words excludes the name of the executable.
+func Complete(words []string) []string {
+	if len(words) == 0 {
+		return nil
+	}
+	var (
+		cur   = words[len(words)-1]
+		args  []string
+		flagn string
+	)
+	for i := 0; i < len(words)-1; i++ {
+		w := words[i]
+		if !strings.HasPrefix(w, "-") {
+			args = append(args, w)
+			continue
+		}
+		name := strings.TrimLeft(w, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		if len(args) == 0 {
+			if f := flag.Lookup(name); f != nil {
+				if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+					continue
+				}
+			}
+		}
+		if i == len(words)-2 {
+			flagn = name
+		}
+		i++
+	}
+	var cands []string
+	switch {
+	case flagn != "":
+		if len(args) == 2 {
+			cands = completions[args[0]][args[1]][flagn]
+		}
+	case len(args) == 0 && strings.HasPrefix(cur, "-"):
+		flag.VisitAll(func(f *flag.Flag) { cands = append(cands, "-"+f.Name) })
+	case len(args) == 0:
+		for svc := range completions {
+			cands = append(cands, svc)
+		}
+	case len(args) == 1:
+		for ep := range completions[args[0]] {
+			cands = append(cands, ep)
+		}
+	case len(args) == 2 && strings.HasPrefix(cur, "-"):
+		for f := range completions[args[0]][args[1]] {
+			cands = append(cands, "-"+f)
+		}
+	}
+	var res []string
+	for _, c := range cands {
+		if strings.HasPrefix(c, cur) {
+			res = append(res, c)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+const bashCompletion = ` + "`" + `_%[1]s_completion() {
+    COMPREPLY=($(%[1]s __complete "${COMP_WORDS[@]:1:$COMP_CWORD}"))
+}
+complete -F _%[1]s_completion %[1]s
+` + "`" + `
+
+const zshCompletion = ` + "`" + `#compdef %[1]s
+_%[1]s() {
+    local -a candidates
+    candidates=(${(f)"$(%[1]s __complete "${(@)words[2,$CURRENT]}")"})
+    compadd -- $candidates
+}
+compdef _%[1]s %[1]s
+` + "`" + `
+
+const fishCompletion = ` + "`" + `complete -c %[1]s -f -a '(%[1]s __complete (commandline -opc)[2..-1] (commandline -ct))'
+` + "`" + `
+`
+
+var StreamingResultStreamCode = `// Stream exchanges data with the stream returned by a streaming endpoint. It
+// sends the JSON values read from in, one per line, if the endpoint streams
+// its payload and writes the results received from the stream to out using
+// the given format until in is exhausted and the server closes the stream.
+// Stream returns false if data is not a stream.
+func Stream(data interface{}, in io.Reader, out io.Writer, format string) (bool, error) {
+	switch stream := data.(type) {
+	case streamingresultservice.StreamingResultMethodClientStream:
+		return true, recvAll(func() (interface{}, error) { return stream.Recv() }, out, format)
+	default:
+		return false, nil
+	}
+}
+
+// sendAll calls send with each non empty line read from in.
+func sendAll(in io.Reader, send func([]byte) error) error {
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := send(line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// recvAll writes the values returned by recv to out using the given format
+// until recv returns io.EOF.
+func recvAll(recv func() (interface{}, error), out io.Writer, format string) error {
+	for {
+		v, err := recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := PrintResult(out, v, format); err != nil {
+			return err
+		}
+	}
+}
+`
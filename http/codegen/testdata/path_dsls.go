@@ -55,6 +55,20 @@ var PathAlternativesDSL = func() {
 	})
 }
 
+var PathCatchAllDSL = func() {
+	Service("ServicePathCatchAll", func() {
+		Method("MethodPathCatchAll", func() {
+			Payload(func() {
+				Attribute("a", String)
+				Attribute("path", String)
+			})
+			HTTP(func() {
+				GET("one/{a}/files/{*path}")
+			})
+		})
+	})
+}
+
 var PathStringSliceParamDSL = func() {
 	Service("ServicePathStringSliceParam", func() {
 		Method("MethodPathStringSliceParam", func() {
@@ -29,6 +29,12 @@ func MethodPathAlternativesServicePathAlternativesPath2(b string, a string) stri
 }
 `
 
+var PathCatchAllCode = `// MethodPathCatchAllServicePathCatchAllPath returns the URL path to the ServicePathCatchAll service MethodPathCatchAll HTTP endpoint.
+func MethodPathCatchAllServicePathCatchAllPath(a string, path string) string {
+	return fmt.Sprintf("/one/%v/files/%v", a, strings.TrimPrefix(path, "/"))
+}
+`
+
 var PathStringSliceParamCode = `// MethodPathStringSliceParamServicePathStringSliceParamPath returns the URL path to the ServicePathStringSliceParam service MethodPathStringSliceParam HTTP endpoint.
 func MethodPathStringSliceParamServicePathStringSliceParamPath(a []string) string {
 	aSlice := make([]string, len(a))
@@ -661,6 +661,50 @@ func NewMethodHeaderStringValidatePayload(h *string) *serviceheaderstringvalidat
 }
 `
 
+var PayloadHeaderNestedConstructorCode = `// NewMethodHeaderNestedPayload builds a ServiceHeaderNested service
+// MethodHeaderNested endpoint payload.
+func NewMethodHeaderNestedPayload(body *MethodHeaderNestedRequestBody, tenantID *string) *serviceheadernested.MethodHeaderNestedPayload {
+	v := &serviceheadernested.MethodHeaderNestedPayload{
+		Name: body.Name,
+	}
+	if body.Tenant != nil {
+		v.Tenant = unmarshalTenantRequestBodyToTenant(body.Tenant)
+	}
+	if tenantID != nil {
+		if v.Tenant == nil {
+			v.Tenant = &serviceheadernested.Tenant{}
+		}
+		v.Tenant.ID = tenantID
+	}
+	return v
+}
+`
+
+var PayloadHeaderNestedRequiredConstructorCode = `// NewMethodHeaderNestedRequiredPayload builds a ServiceHeaderNestedRequired
+// service MethodHeaderNestedRequired endpoint payload.
+func NewMethodHeaderNestedRequiredPayload(body *MethodHeaderNestedRequiredRequestBody, tenantOrgID *string, userID string) *serviceheadernestedrequired.MethodHeaderNestedRequiredPayload {
+	v := &serviceheadernestedrequired.MethodHeaderNestedRequiredPayload{}
+	if body.Tenant != nil {
+		v.Tenant = unmarshalTenantRequestBodyToTenant(body.Tenant)
+	}
+	v.User = unmarshalOrgRequestBodyToOrg(body.User)
+	if tenantOrgID != nil {
+		if v.Tenant == nil {
+			v.Tenant = &serviceheadernestedrequired.Tenant{}
+		}
+		if v.Tenant.Org == nil {
+			v.Tenant.Org = &serviceheadernestedrequired.Org{}
+		}
+		v.Tenant.Org.ID = *tenantOrgID
+	}
+	if v.User == nil {
+		v.User = &serviceheadernestedrequired.Org{}
+	}
+	v.User.ID = userID
+	return v
+}
+`
+
 var PayloadHeaderArrayStringConstructorCode = `// NewMethodHeaderArrayStringPayload builds a ServiceHeaderArrayString service
 // MethodHeaderArrayString endpoint payload.
 func NewMethodHeaderArrayStringPayload(h []string) *serviceheaderarraystring.MethodHeaderArrayStringPayload {
@@ -889,3 +933,13 @@ func NewMethodBodyInlineRecursiveUserPayloadType(body *MethodBodyInlineRecursive
 	return v
 }
 `
+
+var PayloadQueryHeaderEnumTypeConstructorCode = `// NewMethodQueryHeaderEnumTypePayload builds a ServiceQueryHeaderEnumType
+// service MethodQueryHeaderEnumType endpoint payload.
+func NewMethodQueryHeaderEnumTypePayload(q int, h *string) *servicequeryheaderenumtype.MethodQueryHeaderEnumTypePayload {
+	return &servicequeryheaderenumtype.MethodQueryHeaderEnumTypePayload{
+		Q: servicequeryheaderenumtype.Level(q),
+		H: (*servicequeryheaderenumtype.Kind)(h),
+	}
+}
+`
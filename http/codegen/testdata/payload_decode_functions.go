@@ -750,7 +750,7 @@ func DecodeMethodQueryArrayBoolValidateRequest(mux goahttp.Muxer, decoder func(*
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]bool, len(qRaw))
 			for i, rv := range qRaw {
@@ -821,7 +821,7 @@ func DecodeMethodQueryArrayIntValidateRequest(mux goahttp.Muxer, decoder func(*h
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]int, len(qRaw))
 			for i, rv := range qRaw {
@@ -893,7 +893,7 @@ func DecodeMethodQueryArrayInt32ValidateRequest(mux goahttp.Muxer, decoder func(
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]int32, len(qRaw))
 			for i, rv := range qRaw {
@@ -965,7 +965,7 @@ func DecodeMethodQueryArrayInt64ValidateRequest(mux goahttp.Muxer, decoder func(
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]int64, len(qRaw))
 			for i, rv := range qRaw {
@@ -1037,7 +1037,7 @@ func DecodeMethodQueryArrayUIntValidateRequest(mux goahttp.Muxer, decoder func(*
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]uint, len(qRaw))
 			for i, rv := range qRaw {
@@ -1109,7 +1109,7 @@ func DecodeMethodQueryArrayUInt32ValidateRequest(mux goahttp.Muxer, decoder func
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]uint32, len(qRaw))
 			for i, rv := range qRaw {
@@ -1181,7 +1181,7 @@ func DecodeMethodQueryArrayUInt64ValidateRequest(mux goahttp.Muxer, decoder func
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]uint64, len(qRaw))
 			for i, rv := range qRaw {
@@ -1253,7 +1253,7 @@ func DecodeMethodQueryArrayFloat32ValidateRequest(mux goahttp.Muxer, decoder fun
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]float32, len(qRaw))
 			for i, rv := range qRaw {
@@ -1325,7 +1325,7 @@ func DecodeMethodQueryArrayFloat64ValidateRequest(mux goahttp.Muxer, decoder fun
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]float64, len(qRaw))
 			for i, rv := range qRaw {
@@ -1435,7 +1435,7 @@ func DecodeMethodQueryArrayBytesValidateRequest(mux goahttp.Muxer, decoder func(
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([][]byte, len(qRaw))
 			for i, rv := range qRaw {
@@ -1494,7 +1494,7 @@ func DecodeMethodQueryArrayAnyValidateRequest(mux goahttp.Muxer, decoder func(*h
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]interface{}, len(qRaw))
 			for i, rv := range qRaw {
@@ -1519,6 +1519,93 @@ func DecodeMethodQueryArrayAnyValidateRequest(mux goahttp.Muxer, decoder func(*h
 }
 `
 
+var PayloadQueryArrayStringPipeDelimitedDecodeCode = `// DecodeMethodQueryArrayStringPipeDelimitedRequest returns a decoder for
+// requests sent to the ServiceQueryArrayStringPipeDelimited
+// MethodQueryArrayStringPipeDelimited endpoint.
+func DecodeMethodQueryArrayStringPipeDelimitedRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q []string
+		)
+		q = goahttp.SplitQueryParam(r.URL.Query(), "q", "|")
+		payload := NewMethodQueryArrayStringPipeDelimitedPayload(q)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryArrayIntSpaceDelimitedDecodeCode = `// DecodeMethodQueryArrayIntSpaceDelimitedRequest returns a decoder for
+// requests sent to the ServiceQueryArrayIntSpaceDelimited
+// MethodQueryArrayIntSpaceDelimited endpoint.
+func DecodeMethodQueryArrayIntSpaceDelimitedRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			q   []int
+			err error
+		)
+		{
+			qRaw := goahttp.SplitQueryParam(r.URL.Query(), "q", " ")
+			if qRaw == nil {
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+			}
+			q = make([]int, len(qRaw))
+			for i, rv := range qRaw {
+				v, err2 := strconv.ParseInt(rv, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("q", qRaw, "array of integers"))
+				}
+				q[i] = int(v)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryArrayIntSpaceDelimitedPayload(q)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadQueryMapDeepObjectDecodeCode = `// DecodeMethodQueryMapDeepObjectRequest returns a decoder for requests sent to
+// the ServiceQueryMapDeepObject MethodQueryMapDeepObject endpoint.
+func DecodeMethodQueryMapDeepObjectRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			filter map[string]int
+			tags   map[string][]string
+			err    error
+		)
+		{
+			filterRaw := goahttp.DeepObjectQueryParam(r.URL.Query(), "filter")
+			if len(filterRaw) != 0 {
+				filter = make(map[string]int, len(filterRaw))
+				for key, va := range filterRaw {
+					var val int
+					{
+						valRaw := va[0]
+						v, err2 := strconv.ParseInt(valRaw, 10, strconv.IntSize)
+						if err2 != nil {
+							err = goa.MergeErrors(err, goa.InvalidFieldTypeError("val", valRaw, "integer"))
+						}
+						val = int(v)
+					}
+					filter[key] = val
+				}
+			}
+		}
+		tags = goahttp.DeepObjectQueryParam(r.URL.Query(), "tags")
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodQueryMapDeepObjectPayload(filter, tags)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadQueryMapStringStringDecodeCode = `// DecodeMethodQueryMapStringStringRequest returns a decoder for requests sent
 // to the ServiceQueryMapStringString MethodQueryMapStringString endpoint.
 func DecodeMethodQueryMapStringStringRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
@@ -2297,7 +2384,7 @@ func DecodeMethodQueryPrimitiveArrayBoolValidateRequest(mux goahttp.Muxer, decod
 		{
 			qRaw := r.URL.Query()["q"]
 			if qRaw == nil {
-				return goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
+				err = goa.MergeErrors(err, goa.MissingFieldError("q", "query string"))
 			}
 			q = make([]bool, len(qRaw))
 			for i, rv := range qRaw {
@@ -2837,6 +2924,78 @@ func DecodeMethodHeaderStringValidateRequest(mux goahttp.Muxer, decoder func(*ht
 }
 `
 
+var PayloadHeaderNestedDecodeCode = `// DecodeMethodHeaderNestedRequest returns a decoder for requests sent to the
+// ServiceHeaderNested MethodHeaderNested endpoint.
+func DecodeMethodHeaderNestedRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodHeaderNestedRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+
+		var (
+			tenantID *string
+		)
+		tenantIDRaw := r.Header.Get("X-Tenant")
+		if tenantIDRaw != "" {
+			tenantID = &tenantIDRaw
+		}
+		payload := NewMethodHeaderNestedPayload(&body, tenantID)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadHeaderNestedRequiredDecodeCode = `// DecodeMethodHeaderNestedRequiredRequest returns a decoder for requests sent
+// to the ServiceHeaderNestedRequired MethodHeaderNestedRequired endpoint.
+func DecodeMethodHeaderNestedRequiredRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodHeaderNestedRequiredRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = body.Validate()
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			tenantOrgID *string
+			userID      string
+		)
+		tenantOrgIDRaw := r.Header.Get("X-Org")
+		if tenantOrgIDRaw != "" {
+			tenantOrgID = &tenantOrgIDRaw
+		}
+		userID = r.Header.Get("X-User")
+		if userID == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("X-User", "header"))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodHeaderNestedRequiredPayload(&body, tenantOrgID, userID)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadHeaderArrayStringDecodeCode = `// DecodeMethodHeaderArrayStringRequest returns a decoder for requests sent to
 // the ServiceHeaderArrayString MethodHeaderArrayString endpoint.
 func DecodeMethodHeaderArrayStringRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
@@ -3077,6 +3236,64 @@ func DecodeMethodHeaderPrimitiveStringDefaultRequest(mux goahttp.Muxer, decoder
 }
 `
 
+var PayloadCookieStringDecodeCode = `// DecodeMethodCookieStringRequest returns a decoder for requests sent to the
+// ServiceCookieString MethodCookieString endpoint.
+func DecodeMethodCookieStringRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			c *string
+		)
+		{
+			cRaw := ""
+			if cookie, err2 := r.Cookie("cookie"); err2 == nil {
+				cRaw = cookie.Value
+			}
+			if cRaw != "" {
+				c = &cRaw
+			}
+		}
+		payload := NewMethodCookieStringPayload(c)
+
+		return payload, nil
+	}
+}
+`
+
+var PayloadCookieIntValidateDecodeCode = `// DecodeMethodCookieIntValidateRequest returns a decoder for requests sent to
+// the ServiceCookieIntValidate MethodCookieIntValidate endpoint.
+func DecodeMethodCookieIntValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			c   int
+			err error
+		)
+		{
+			cRaw := ""
+			if cookie, err2 := r.Cookie("c"); err2 == nil {
+				cRaw = cookie.Value
+			}
+			if cRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("c", "cookie"))
+			}
+			v, err2 := strconv.ParseInt(cRaw, 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("c", cRaw, "integer"))
+			}
+			c = int(v)
+		}
+		if c < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("c", c, 1, true))
+		}
+		if err != nil {
+			return nil, err
+		}
+		payload := NewMethodCookieIntValidatePayload(c)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadBodyStringDecodeCode = `// DecodeMethodBodyStringRequest returns a decoder for requests sent to the
 // ServiceBodyString MethodBodyString endpoint.
 func DecodeMethodBodyStringRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
@@ -3664,6 +3881,42 @@ func DecodeMethodBodyQueryObjectValidateRequest(mux goahttp.Muxer, decoder func(
 }
 `
 
+var PayloadBodyQueryObjectAggregateValidateDecodeCode = `// DecodeMethodBodyQueryObjectAggregateValidateRequest returns a decoder for
+// requests sent to the ServiceBodyQueryObjectAggregateValidate
+// MethodBodyQueryObjectAggregateValidate endpoint.
+func DecodeMethodBodyQueryObjectAggregateValidateRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
+	return func(r *http.Request) (interface{}, error) {
+		var (
+			body MethodBodyQueryObjectAggregateValidateRequestBody
+			err  error
+		)
+		err = decoder(r).Decode(&body)
+		if err != nil {
+			if err == io.EOF {
+				return nil, goa.MissingPayloadError()
+			}
+			return nil, goa.DecodePayloadError(err.Error())
+		}
+		err = body.Validate()
+
+		var (
+			b string
+		)
+		b = r.URL.Query().Get("b")
+		if b == "" {
+			err = goa.MergeErrors(err, goa.MissingFieldError("b", "query string"))
+		}
+		err = goa.MergeErrors(err, goa.ValidatePattern("b", b, "patternb"))
+		if err != nil {
+			return nil, goa.AggregateErrors(err)
+		}
+		payload := NewMethodBodyQueryObjectAggregateValidatePayload(&body, b)
+
+		return payload, nil
+	}
+}
+`
+
 var PayloadBodyQueryUserDecodeCode = `// DecodeMethodBodyQueryUserRequest returns a decoder for requests sent to the
 // ServiceBodyQueryUser MethodBodyQueryUser endpoint.
 func DecodeMethodBodyQueryUserRequest(mux goahttp.Muxer, decoder func(*http.Request) goahttp.Decoder) func(*http.Request) (interface{}, error) {
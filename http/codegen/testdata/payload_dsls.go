@@ -807,6 +807,59 @@ var PayloadQueryArrayAnyValidateDSL = func() {
 	})
 }
 
+var PayloadQueryArrayStringPipeDelimitedDSL = func() {
+	Service("ServiceQueryArrayStringPipeDelimited", func() {
+		Method("MethodQueryArrayStringPipeDelimited", func() {
+			Payload(func() {
+				Attribute("q", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q", func() {
+					Metadata("http:query:style", "pipeDelimited")
+				})
+			})
+		})
+	})
+}
+
+var PayloadQueryArrayIntSpaceDelimitedDSL = func() {
+	Service("ServiceQueryArrayIntSpaceDelimited", func() {
+		Method("MethodQueryArrayIntSpaceDelimited", func() {
+			Payload(func() {
+				Attribute("q", ArrayOf(Int))
+				Required("q")
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q", func() {
+					Metadata("http:query:style", "spaceDelimited")
+				})
+			})
+		})
+	})
+}
+
+var PayloadQueryMapDeepObjectDSL = func() {
+	Service("ServiceQueryMapDeepObject", func() {
+		Method("MethodQueryMapDeepObject", func() {
+			Payload(func() {
+				Attribute("filter", MapOf(String, Int))
+				Attribute("tags", MapOf(String, ArrayOf(String)))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("filter", func() {
+					Metadata("http:query:style", "deepObject")
+				})
+				Param("tags", func() {
+					Metadata("http:query:style", "deepObject")
+				})
+			})
+		})
+	})
+}
+
 var PayloadQueryMapStringStringDSL = func() {
 	Service("ServiceQueryMapStringString", func() {
 		Method("MethodQueryMapStringString", func() {
@@ -1420,6 +1473,50 @@ var PayloadHeaderStringDSL = func() {
 	})
 }
 
+var PayloadHeaderNestedDSL = func() {
+	var Tenant = Type("Tenant", func() {
+		Attribute("id", String)
+		Attribute("region", String)
+	})
+	Service("ServiceHeaderNested", func() {
+		Method("MethodHeaderNested", func() {
+			Payload(func() {
+				Attribute("tenant", Tenant)
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Header("tenant.id:X-Tenant")
+			})
+		})
+	})
+}
+
+var PayloadHeaderNestedRequiredDSL = func() {
+	var Org = Type("Org", func() {
+		Attribute("id", String)
+		Required("id")
+	})
+	var Tenant = Type("Tenant", func() {
+		Attribute("org", Org)
+		Required("org")
+	})
+	Service("ServiceHeaderNestedRequired", func() {
+		Method("MethodHeaderNestedRequired", func() {
+			Payload(func() {
+				Attribute("tenant", Tenant)
+				Attribute("user", Org)
+				Required("user")
+			})
+			HTTP(func() {
+				GET("/")
+				Header("tenant.org.id:X-Org")
+				Header("user.id:X-User")
+			})
+		})
+	})
+}
+
 var PayloadHeaderStringValidateDSL = func() {
 	Service("ServiceHeaderStringValidate", func() {
 		Method("MethodHeaderStringValidate", func() {
@@ -1575,6 +1672,37 @@ var PayloadHeaderPrimitiveStringDefaultDSL = func() {
 	})
 }
 
+var PayloadCookieStringDSL = func() {
+	Service("ServiceCookieString", func() {
+		Method("MethodCookieString", func() {
+			Payload(func() {
+				Attribute("c", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Cookie("c:cookie")
+			})
+		})
+	})
+}
+
+var PayloadCookieIntValidateDSL = func() {
+	Service("ServiceCookieIntValidate", func() {
+		Method("MethodCookieIntValidate", func() {
+			Payload(func() {
+				Attribute("c", Int, func() {
+					Minimum(1)
+				})
+				Required("c")
+			})
+			HTTP(func() {
+				GET("/")
+				Cookie("c")
+			})
+		})
+	})
+}
+
 var PayloadBodyStringDSL = func() {
 	Service("ServiceBodyString", func() {
 		Method("MethodBodyString", func() {
@@ -1937,6 +2065,27 @@ var PayloadBodyQueryObjectValidateDSL = func() {
 	})
 }
 
+var PayloadBodyQueryObjectAggregateValidateDSL = func() {
+	Service("ServiceBodyQueryObjectAggregateValidate", func() {
+		Method("MethodBodyQueryObjectAggregateValidate", func() {
+			Metadata("validation:aggregate")
+			Payload(func() {
+				Attribute("a", String, func() {
+					Pattern("patterna")
+				})
+				Attribute("b", String, func() {
+					Pattern("patternb")
+				})
+				Required("a", "b")
+			})
+			HTTP(func() {
+				POST("/")
+				Param("b")
+			})
+		})
+	})
+}
+
 var PayloadBodyQueryUserDSL = func() {
 	var PayloadType = Type("PayloadType", func() {
 		Attribute("a", String)
@@ -2042,6 +2191,52 @@ var PayloadBodyPathUserValidateDSL = func() {
 	})
 }
 
+var PayloadBodyStructTagsDSL = func() {
+	var PayloadType = Type("PayloadType", func() {
+		Attribute("a", String, func() {
+			Metadata("struct:tag:db", "a_column")
+		})
+		Attribute("b", String, func() {
+			Metadata("struct:tag:json", "bee", "omitempty")
+		})
+		Required("a")
+	})
+	Service("ServiceBodyStructTags", func() {
+		Method("MethodBodyStructTags", func() {
+			Payload(PayloadType)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var PayloadBodyWireNamingDSL = func() {
+	API("WireNaming", func() {
+		Metadata("goa:naming:wire", "camel")
+		Metadata("goa:naming:initialisms", "SKU")
+	})
+	Service("ServiceBodyWireNaming", func() {
+		Method("MethodBodyWireNaming", func() {
+			Payload(func() {
+				Attribute("first_name", String, func() {
+					Example("Jane")
+				})
+				Attribute("item_sku", String, func() {
+					Example("abc")
+				})
+				Attribute("tags", MapOf(String, String), func() {
+					Example(map[string]string{"some_key": "value"})
+				})
+				Required("first_name")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
 var PayloadBodyQueryPathObjectDSL = func() {
 	Service("ServiceBodyQueryPathObject", func() {
 		Method("MethodBodyQueryPathObject", func() {
@@ -2319,6 +2514,42 @@ var PayloadMultipartUserTypeDSL = func() {
 	})
 }
 
+var PayloadMultipartDefaultDecoderDSL = func() {
+	Service("ServiceMultipartDefaultDecoder", func() {
+		Method("MethodMultipartDefaultDecoder", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("name", String)
+				Attribute("age", Int)
+				Attribute("tags", ArrayOf(Int))
+				Attribute("file", Bytes)
+				Attribute("meta", MapOf(String, String))
+				Required("id", "name", "file")
+			})
+			HTTP(func() {
+				POST("/{id}")
+				MultipartRequest()
+			})
+		})
+	})
+}
+
+var PayloadStreamingMultipartDSL = func() {
+	Service("ServiceStreamingMultipart", func() {
+		Method("MethodStreamingMultipart", func() {
+			Payload(func() {
+				Attribute("b", String)
+				Attribute("c", Bytes)
+				Required("b", "c")
+			})
+			HTTP(func() {
+				POST("/")
+				StreamingMultipartRequest(1024, 4096)
+			})
+		})
+	})
+}
+
 var PayloadMultipartArrayTypeDSL = func() {
 	var PayloadType = Type("PayloadType", func() {
 		Attribute("a", String, func() {
@@ -2377,3 +2608,51 @@ var PayloadMultipartWithParams = func() {
 		})
 	})
 }
+
+var PayloadQueryHeaderEnumTypeDSL = func() {
+	Service("ServiceQueryHeaderEnumType", func() {
+		Method("MethodQueryHeaderEnumType", func() {
+			Payload(func() {
+				Attribute("q", Int, func() {
+					Enum(1, 2)
+					Metadata("struct:enum", "Level")
+				})
+				Attribute("h", String, func() {
+					Enum("val", "other")
+					Metadata("struct:enum", "Kind")
+				})
+				Required("q")
+			})
+			HTTP(func() {
+				GET("/")
+				Param("q")
+				Header("h")
+			})
+		})
+	})
+}
+
+var PayloadBodyInternalDSL = func() {
+	var Account = Type("Account", func() {
+		Attribute("name", String)
+		Attribute("password_hash", String, func() {
+			Internal()
+		})
+		Required("name", "password_hash")
+	})
+	Service("ServiceBodyInternal", func() {
+		Method("MethodBodyInternal", func() {
+			Payload(func() {
+				Attribute("account", Account)
+				Attribute("trace", String, func() {
+					Metadata("transport:omit")
+				})
+				Attribute("note", String)
+				Required("account", "trace")
+			})
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
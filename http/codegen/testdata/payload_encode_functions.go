@@ -868,6 +868,76 @@ func EncodeMethodQueryArrayAnyValidateRequest(encoder func(*http.Request) goahtt
 }
 `
 
+var PayloadQueryArrayStringPipeDelimitedEncodeCode = `// EncodeMethodQueryArrayStringPipeDelimitedRequest returns an encoder for
+// requests sent to the ServiceQueryArrayStringPipeDelimited
+// MethodQueryArrayStringPipeDelimited server.
+func EncodeMethodQueryArrayStringPipeDelimitedRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequeryarraystringpipedelimited.MethodQueryArrayStringPipeDelimitedPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryArrayStringPipeDelimited", "MethodQueryArrayStringPipeDelimited", "*servicequeryarraystringpipedelimited.MethodQueryArrayStringPipeDelimitedPayload", v)
+		}
+		values := req.URL.Query()
+		if p.Q != nil {
+			values.Add("q", strings.Join(p.Q, "|"))
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
+var PayloadQueryArrayIntSpaceDelimitedEncodeCode = `// EncodeMethodQueryArrayIntSpaceDelimitedRequest returns an encoder for
+// requests sent to the ServiceQueryArrayIntSpaceDelimited
+// MethodQueryArrayIntSpaceDelimited server.
+func EncodeMethodQueryArrayIntSpaceDelimitedRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequeryarrayintspacedelimited.MethodQueryArrayIntSpaceDelimitedPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryArrayIntSpaceDelimited", "MethodQueryArrayIntSpaceDelimited", "*servicequeryarrayintspacedelimited.MethodQueryArrayIntSpaceDelimitedPayload", v)
+		}
+		values := req.URL.Query()
+		if p.Q != nil {
+			vals := make([]string, len(p.Q))
+			for i, value := range p.Q {
+				valueStr := strconv.Itoa(value)
+				vals[i] = valueStr
+			}
+			values.Add("q", strings.Join(vals, " "))
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
+var PayloadQueryMapDeepObjectEncodeCode = `// EncodeMethodQueryMapDeepObjectRequest returns an encoder for requests sent
+// to the ServiceQueryMapDeepObject MethodQueryMapDeepObject server.
+func EncodeMethodQueryMapDeepObjectRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequerymapdeepobject.MethodQueryMapDeepObjectPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryMapDeepObject", "MethodQueryMapDeepObject", "*servicequerymapdeepobject.MethodQueryMapDeepObjectPayload", v)
+		}
+		values := req.URL.Query()
+		for key, value := range p.Filter {
+			keyStr := key
+			valueStr := strconv.Itoa(value)
+			values.Add(goahttp.DeepObjectQueryKey("filter", keyStr), valueStr)
+		}
+		for key, value := range p.Tags {
+			keyStr := key
+			for _, val := range value {
+				valStr := val
+				values.Add(goahttp.DeepObjectQueryKey("tags", keyStr), valStr)
+			}
+		}
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
+
 var PayloadQueryMapStringStringEncodeCode = `// EncodeMethodQueryMapStringStringRequest returns an encoder for requests sent
 // to the ServiceQueryMapStringString MethodQueryMapStringString server.
 func EncodeMethodQueryMapStringStringRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
@@ -1337,6 +1407,49 @@ func EncodeMethodHeaderStringValidateRequest(encoder func(*http.Request) goahttp
 }
 `
 
+var PayloadHeaderNestedEncodeCode = `// EncodeMethodHeaderNestedRequest returns an encoder for requests sent to the
+// ServiceHeaderNested MethodHeaderNested server.
+func EncodeMethodHeaderNestedRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*serviceheadernested.MethodHeaderNestedPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceHeaderNested", "MethodHeaderNested", "*serviceheadernested.MethodHeaderNestedPayload", v)
+		}
+		if p.Tenant != nil && p.Tenant.ID != nil {
+			req.Header.Set("X-Tenant", *p.Tenant.ID)
+		}
+		body := NewMethodHeaderNestedRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("ServiceHeaderNested", "MethodHeaderNested", err)
+		}
+		return nil
+	}
+}
+`
+
+var PayloadHeaderNestedRequiredEncodeCode = `// EncodeMethodHeaderNestedRequiredRequest returns an encoder for requests sent
+// to the ServiceHeaderNestedRequired MethodHeaderNestedRequired server.
+func EncodeMethodHeaderNestedRequiredRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*serviceheadernestedrequired.MethodHeaderNestedRequiredPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceHeaderNestedRequired", "MethodHeaderNestedRequired", "*serviceheadernestedrequired.MethodHeaderNestedRequiredPayload", v)
+		}
+		if p.Tenant != nil && p.Tenant.Org != nil {
+			req.Header.Set("X-Org", p.Tenant.Org.ID)
+		}
+		if p.User != nil {
+			req.Header.Set("X-User", p.User.ID)
+		}
+		body := NewMethodHeaderNestedRequiredRequestBody(p)
+		if err := encoder(req).Encode(&body); err != nil {
+			return goahttp.ErrEncodingError("ServiceHeaderNestedRequired", "MethodHeaderNestedRequired", err)
+		}
+		return nil
+	}
+}
+`
+
 var PayloadHeaderArrayStringEncodeCode = `// EncodeMethodHeaderArrayStringRequest returns an encoder for requests sent to
 // the ServiceHeaderArrayString MethodHeaderArrayString server.
 func EncodeMethodHeaderArrayStringRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
@@ -1450,6 +1563,25 @@ func EncodeMethodHeaderPrimitiveStringDefaultRequest(encoder func(*http.Request)
 }
 `
 
+var PayloadCookieStringEncodeCode = `// EncodeMethodCookieStringRequest returns an encoder for requests sent to the
+// ServiceCookieString MethodCookieString server.
+func EncodeMethodCookieStringRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicecookiestring.MethodCookieStringPayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceCookieString", "MethodCookieString", "*servicecookiestring.MethodCookieStringPayload", v)
+		}
+		if p.C != nil {
+			req.AddCookie(&http.Cookie{
+				Name:  "cookie",
+				Value: *p.C,
+			})
+		}
+		return nil
+	}
+}
+`
+
 var PayloadBodyStringEncodeCode = `// EncodeMethodBodyStringRequest returns an encoder for requests sent to the
 // ServiceBodyString MethodBodyString server.
 func EncodeMethodBodyStringRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
@@ -2152,3 +2284,22 @@ func EncodeMethodMultipartMapTypeRequest(encoder func(*http.Request) goahttp.Enc
 	}
 }
 `
+
+var PayloadQueryHeaderEnumTypeEncodeCode = `// EncodeMethodQueryHeaderEnumTypeRequest returns an encoder for requests sent
+// to the ServiceQueryHeaderEnumType MethodQueryHeaderEnumType server.
+func EncodeMethodQueryHeaderEnumTypeRequest(encoder func(*http.Request) goahttp.Encoder) func(*http.Request, interface{}) error {
+	return func(req *http.Request, v interface{}) error {
+		p, ok := v.(*servicequeryheaderenumtype.MethodQueryHeaderEnumTypePayload)
+		if !ok {
+			return goahttp.ErrInvalidType("ServiceQueryHeaderEnumType", "MethodQueryHeaderEnumType", "*servicequeryheaderenumtype.MethodQueryHeaderEnumTypePayload", v)
+		}
+		if p.H != nil {
+			req.Header.Set("h", string(*p.H))
+		}
+		values := req.URL.Query()
+		values.Add("q", fmt.Sprintf("%v", int(p.Q)))
+		req.URL.RawQuery = values.Encode()
+		return nil
+	}
+}
+`
@@ -0,0 +1,10 @@
+package testdata
+
+var RateLimitServerCode = `// UseRateLimiter wraps the handlers of the endpoints that define a rate limit
+// with a middleware that enforces the limit using l. Requests that exceed the
+// limit receive a 429 Too Many Requests response with a Retry-After header.
+func (s *Server) UseRateLimiter(l goahttp.RateLimiter) {
+	s.MethodInherited = goahttp.RateLimit(l, "ServiceRateLimit", "MethodInherited", 100, time.Minute)(s.MethodInherited)
+	s.MethodRateLimit = goahttp.RateLimit(l, "ServiceRateLimit", "MethodRateLimit", 5, time.Second)(s.MethodRateLimit)
+}
+`
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	"time"
+
+	. "goa.design/goa/http/dsl"
+)
+
+var RateLimitDSL = func() {
+	Service("ServiceRateLimit", func() {
+		RateLimit(100, time.Minute)
+		Method("MethodInherited", func() {
+			HTTP(func() {
+				GET("/inherited")
+			})
+		})
+		Method("MethodRateLimit", func() {
+			RateLimit(5, time.Second)
+			HTTP(func() {
+				GET("/limited")
+			})
+		})
+	})
+}
@@ -0,0 +1,37 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var ReferenceDSL = func() {
+	var Account = Type("Account", func() {
+		Description("Account is a customer account.")
+		Attribute("id", String, "Account ID", func() {
+			Format(FormatUUID)
+		})
+		Attribute("name", String, func() {
+			MinLength(1)
+			MaxLength(20)
+		})
+		Required("id")
+	})
+	Service("accounts", func() {
+		Description("Manage accounts.")
+		Method("show", func() {
+			Description("Show an account.")
+			Payload(func() {
+				Attribute("id", String, "Account ID")
+				Required("id")
+			})
+			Result(Account)
+			Error("not_found", String, "Account not found")
+			HTTP(func() {
+				GET("/accounts/{id}")
+				Response(StatusOK)
+				Response("not_found", StatusNotFound)
+			})
+		})
+	})
+}
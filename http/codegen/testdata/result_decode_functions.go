@@ -233,3 +233,369 @@ func DecodeMethodTagMultipleViewsResponse(decoder func(*http.Response) goahttp.D
 	}
 }
 `
+
+var ResultCookieDecodeCode = `// DecodeMethodCookieResponse returns a decoder for responses returned by the
+// ServiceCookie MethodCookie endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeMethodCookieResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body MethodCookieResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServiceCookie", "MethodCookie", err)
+			}
+			var (
+				session    string
+				sessionRaw string
+				count      *int
+				countRaw   string
+			)
+			for _, c := range resp.Cookies() {
+				switch c.Name {
+				case "SID":
+					sessionRaw = c.Value
+				case "count":
+					countRaw = c.Value
+				}
+			}
+			if sessionRaw == "" {
+				err = goa.MergeErrors(err, goa.MissingFieldError("SID", "cookie"))
+			}
+			session = sessionRaw
+			if countRaw != "" {
+				v, err2 := strconv.ParseInt(countRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("count", countRaw, "integer"))
+				}
+				pv := int(v)
+				count = &pv
+			}
+			if err != nil {
+				return nil, goahttp.ErrValidationError("ServiceCookie", "MethodCookie", err)
+			}
+			return NewMethodCookieResultOK(&body, session, count), nil
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceCookie", "MethodCookie", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+var ResultSkipResponseBodyEncodeDecodeDecodeCode = `// DecodeMethodSkipResponseBodyEncodeDecodeResponse returns a decoder for
+// responses returned by the ServiceSkipResponseBodyEncodeDecode
+// MethodSkipResponseBodyEncodeDecode endpoint. restoreBody controls whether
+// the response body should be restored after having been read.
+func DecodeMethodSkipResponseBodyEncodeDecodeResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				length int64
+				err    error
+			)
+			lengthRaw := resp.Header.Get("Content-Length")
+			if lengthRaw == "" {
+				return nil, goahttp.ErrValidationError("ServiceSkipResponseBodyEncodeDecode", "MethodSkipResponseBodyEncodeDecode", goa.MissingFieldError("Content-Length", "header"))
+			}
+			v, err2 := strconv.ParseInt(lengthRaw, 10, 64)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("length", lengthRaw, "integer"))
+			}
+			length = v
+			if err != nil {
+				return nil, goahttp.ErrValidationError("ServiceSkipResponseBodyEncodeDecode", "MethodSkipResponseBodyEncodeDecode", err)
+			}
+			return NewMethodSkipResponseBodyEncodeDecodeResultOK(length), nil
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceSkipResponseBodyEncodeDecode", "MethodSkipResponseBodyEncodeDecode", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+var ProblemErrorResponseDecodeCode = `// DecodeMethodProblemErrorResponseResponse returns a decoder for responses
+// returned by the ServiceProblemErrorResponse MethodProblemErrorResponse
+// endpoint. restoreBody controls whether the response body should be restored
+// after having been read.
+// DecodeMethodProblemErrorResponseResponse may return the following errors:
+//   - "bad_request" (type *goahttp.Problem): http.StatusBadRequest
+//   - "internal_error" (type *goahttp.Problem): http.StatusInternalServerError
+//   - error: internal error
+func DecodeMethodProblemErrorResponseResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusNoContent:
+			return nil, nil
+		case http.StatusBadRequest:
+			return nil, goahttp.DecodeProblem("ServiceProblemErrorResponse", "MethodProblemErrorResponse", resp)
+		case http.StatusInternalServerError:
+			return nil, goahttp.DecodeProblem("ServiceProblemErrorResponse", "MethodProblemErrorResponse", resp)
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceProblemErrorResponse", "MethodProblemErrorResponse", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+var HeaderErrorResponseDecodeCode = `// DecodeMethodHeaderErrorResponseResponse returns a decoder for responses
+// returned by the ServiceHeaderErrorResponse MethodHeaderErrorResponse
+// endpoint. restoreBody controls whether the response body should be restored
+// after having been read.
+// DecodeMethodHeaderErrorResponseResponse may return the following errors:
+//   - "rate_limited" (type *serviceheadererrorresponse.RateLimited): http.StatusTooManyRequests
+//   - error: internal error
+func DecodeMethodHeaderErrorResponseResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusNoContent:
+			return nil, nil
+		case http.StatusTooManyRequests:
+			var (
+				body MethodHeaderErrorResponseRateLimitedResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServiceHeaderErrorResponse", "MethodHeaderErrorResponse", err)
+			}
+			err = body.Validate()
+			if err != nil {
+				return nil, goahttp.ErrValidationError("ServiceHeaderErrorResponse", "MethodHeaderErrorResponse", err)
+			}
+			var (
+				retryAfter int
+				requestID  *string
+			)
+			retryAfterRaw := resp.Header.Get("Retry-After")
+			if retryAfterRaw == "" {
+				return nil, goahttp.ErrValidationError("ServiceHeaderErrorResponse", "MethodHeaderErrorResponse", goa.MissingFieldError("Retry-After", "header"))
+			}
+			v, err2 := strconv.ParseInt(retryAfterRaw, 10, strconv.IntSize)
+			if err2 != nil {
+				err = goa.MergeErrors(err, goa.InvalidFieldTypeError("retryAfter", retryAfterRaw, "integer"))
+			}
+			retryAfter = int(v)
+			requestIDRaw := resp.Header.Get("request_id")
+			if requestIDRaw != "" {
+				requestID = &requestIDRaw
+			}
+			return nil, NewMethodHeaderErrorResponseRateLimited(&body, retryAfter, requestID)
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceHeaderErrorResponse", "MethodHeaderErrorResponse", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+var ResultHeaderCookieDefaultDecodeCode = `// DecodeMethodHeaderCookieDefaultResponse returns a decoder for responses
+// returned by the ServiceHeaderCookieDefault MethodHeaderCookieDefault
+// endpoint. restoreBody controls whether the response body should be restored
+// after having been read.
+func DecodeMethodHeaderCookieDefaultResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				h   string
+				i   int
+				err error
+			)
+			hRaw := resp.Header.Get("h")
+			if hRaw != "" {
+				h = hRaw
+			} else {
+				h = "def"
+			}
+			iRaw := resp.Header.Get("i")
+			if iRaw == "" {
+				i = 1
+			} else {
+				v, err2 := strconv.ParseInt(iRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("i", iRaw, "integer"))
+				}
+				i = int(v)
+			}
+			var (
+				c    string
+				cRaw string
+			)
+			for _, c := range resp.Cookies() {
+				switch c.Name {
+				case "c":
+					cRaw = c.Value
+				}
+			}
+			if cRaw == "" {
+				c = "cookie"
+			} else {
+				c = cRaw
+			}
+			if err != nil {
+				return nil, goahttp.ErrValidationError("ServiceHeaderCookieDefault", "MethodHeaderCookieDefault", err)
+			}
+			return NewMethodHeaderCookieDefaultResultOK(h, i, c), nil
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceHeaderCookieDefault", "MethodHeaderCookieDefault", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+var ResultHeaderDefaultMultipleViewsDecodeCode = `// DecodeMethodHeaderDefaultMultipleViewResponse returns a decoder for
+// responses returned by the ServiceHeaderDefaultMultipleView
+// MethodHeaderDefaultMultipleView endpoint. restoreBody controls whether the
+// response body should be restored after having been read.
+func DecodeMethodHeaderDefaultMultipleViewResponse(decoder func(*http.Response) goahttp.Decoder, restoreBody bool) func(*http.Response) (interface{}, error) {
+	return func(resp *http.Response) (interface{}, error) {
+		if restoreBody {
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			defer func() {
+				resp.Body = ioutil.NopCloser(bytes.NewBuffer(b))
+			}()
+		} else {
+			defer resp.Body.Close()
+		}
+		switch resp.StatusCode {
+		case http.StatusOK:
+			var (
+				body MethodHeaderDefaultMultipleViewResponseBody
+				err  error
+			)
+			err = decoder(resp).Decode(&body)
+			if err != nil {
+				return nil, goahttp.ErrDecodingError("ServiceHeaderDefaultMultipleView", "MethodHeaderDefaultMultipleView", err)
+			}
+			var (
+				h *int
+			)
+			hRaw := resp.Header.Get("h")
+			if hRaw == "" {
+				var tmp int = 1
+				h = &tmp
+			} else {
+				v, err2 := strconv.ParseInt(hRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("h", hRaw, "integer"))
+				}
+				pv := int(v)
+				h = &pv
+			}
+			if err != nil {
+				return nil, goahttp.ErrValidationError("ServiceHeaderDefaultMultipleView", "MethodHeaderDefaultMultipleView", err)
+			}
+			p := NewMethodHeaderDefaultMultipleViewResulttypeheaderdefaultOK(&body, h)
+			view := resp.Header.Get("goa-view")
+			vres := &serviceheaderdefaultmultipleviewviews.Resulttypeheaderdefault{p, view}
+			if err = vres.Validate(); err != nil {
+				return nil, goahttp.ErrValidationError("ServiceHeaderDefaultMultipleView", "MethodHeaderDefaultMultipleView", err)
+			}
+			return serviceheaderdefaultmultipleview.NewResulttypeheaderdefault(vres), nil
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			return nil, goahttp.ErrInvalidResponse("ServiceHeaderDefaultMultipleView", "MethodHeaderDefaultMultipleView", resp.StatusCode, string(body))
+		}
+	}
+}
+`
+
+var ResultTrailersDecodeCode = `// DecodeMethodTrailersTrailers sets the fields of the ServiceTrailers
+// MethodTrailers endpoint result mapped to the response trailers. The trailers
+// are only available once the response body has been read to EOF.
+func DecodeMethodTrailersTrailers(resp *http.Response, res *servicetrailers.MethodTrailersResult) error {
+	var err error
+	switch resp.StatusCode {
+	case http.StatusOK:
+		{
+			var checksum *string
+			checksumRaw := resp.Trailer.Get("X-Checksum")
+			if checksumRaw != "" {
+				checksum = &checksumRaw
+			}
+			if checksum != nil {
+				err = goa.MergeErrors(err, goa.ValidatePattern("checksum", *checksum, "^[0-9a-f]+$"))
+			}
+			res.Checksum = checksum
+		}
+		{
+			var count *int
+			countRaw := resp.Trailer.Get("X-Record-Count")
+			if countRaw != "" {
+				v, err2 := strconv.ParseInt(countRaw, 10, strconv.IntSize)
+				if err2 != nil {
+					err = goa.MergeErrors(err, goa.InvalidFieldTypeError("count", countRaw, "integer"))
+				}
+				pv := int(v)
+				count = &pv
+			}
+			res.Count = count
+		}
+	}
+	if err != nil {
+		return goahttp.ErrValidationError("ServiceTrailers", "MethodTrailers", err)
+	}
+	return nil
+}
+`
@@ -993,3 +993,155 @@ var EmptyServerResponseDSL = func() {
 		})
 	})
 }
+
+var ResultBodyProducesDSL = func() {
+	var ResultType = Type("ResultType", func() {
+		Attribute("a", String)
+	})
+	Service("ServiceBodyProduces", func() {
+		Method("MethodBodyProduces", func() {
+			Result(ResultType)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Produces("application/json", "application/xml")
+				})
+			})
+		})
+	})
+}
+
+var ResultCookieDSL = func() {
+	Service("ServiceCookie", func() {
+		Method("MethodCookie", func() {
+			Result(func() {
+				Attribute("session", String)
+				Attribute("count", Int)
+				Attribute("b", String)
+				Required("session")
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Cookie("session:SID")
+					Cookie("count")
+					CookieMaxAge(3600)
+					CookieSecure()
+					CookieHTTPOnly()
+				})
+			})
+		})
+	})
+}
+
+var ResultConstantHeaderDSL = func() {
+	Service("ServiceConstantHeader", func() {
+		Method("MethodConstantHeader", func() {
+			Result(func() {
+				Attribute("h", String)
+			})
+			HTTP(func() {
+				GET("/")
+				ConstantHeader("X-API-Version", "2")
+				Response(StatusOK, func() {
+					Header("h")
+					ConstantHeader("cache-control", "max-age=60")
+				})
+			})
+		})
+	})
+}
+
+var ResultHeaderCookieDefaultDSL = func() {
+	Service("ServiceHeaderCookieDefault", func() {
+		Method("MethodHeaderCookieDefault", func() {
+			Result(func() {
+				Attribute("h", String, func() {
+					Default("def")
+				})
+				Attribute("i", Int, func() {
+					Default(1)
+				})
+				Attribute("c", String, func() {
+					Default("cookie")
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Header("h")
+					Header("i")
+					Cookie("c")
+				})
+			})
+		})
+	})
+}
+
+var ResultHeaderDefaultMultipleViewsDSL = func() {
+	var ResultType = ResultType("ResultTypeHeaderDefault", func() {
+		Attribute("a", String)
+		Attribute("h", Int, func() {
+			Default(1)
+		})
+		View("default", func() {
+			Attribute("a")
+			Attribute("h")
+		})
+		View("tiny", func() {
+			Attribute("h")
+		})
+	})
+	Service("ServiceHeaderDefaultMultipleView", func() {
+		Method("MethodHeaderDefaultMultipleView", func() {
+			Result(ResultType)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Header("h")
+				})
+			})
+		})
+	})
+}
+
+var ResultSkipResponseBodyEncodeDecodeDSL = func() {
+	Service("ServiceSkipResponseBodyEncodeDecode", func() {
+		Method("MethodSkipResponseBodyEncodeDecode", func() {
+			Result(func() {
+				Attribute("length", Int64)
+				Required("length")
+			})
+			HTTP(func() {
+				GET("/")
+				SkipResponseBodyEncodeDecode()
+				Response(StatusOK, func() {
+					Header("length:Content-Length")
+				})
+			})
+		})
+	})
+}
+
+var ResultTrailersDSL = func() {
+	Service("ServiceTrailers", func() {
+		Method("MethodTrailers", func() {
+			Result(func() {
+				Attribute("kind", String)
+				Attribute("checksum", String, func() {
+					Pattern("^[0-9a-f]+$")
+				})
+				Attribute("count", Int)
+			})
+			HTTP(func() {
+				GET("/")
+				SkipResponseBodyEncodeDecode()
+				Response(StatusOK, func() {
+					Header("kind:X-Kind")
+					Trailer("checksum:X-Checksum")
+					Trailer("count:X-Record-Count")
+				})
+			})
+		})
+	})
+}
@@ -442,13 +442,9 @@ var ResultHeaderBoolDefaultEncodeCode = `// EncodeMethodHeaderBoolDefaultRespons
 func EncodeMethodHeaderBoolDefaultResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
 	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
 		res := v.(*serviceheaderbooldefault.MethodHeaderBoolDefaultResult)
-		if res.H != nil {
-			val := res.H
-			hs := strconv.FormatBool(*val)
-			w.Header().Set("h", hs)
-		} else {
-			w.Header().Set("h", "true")
-		}
+		val := res.H
+		hs := strconv.FormatBool(val)
+		w.Header().Set("h", hs)
 		w.WriteHeader(http.StatusOK)
 		return nil
 	}
@@ -476,11 +472,7 @@ var ResultHeaderStringDefaultEncodeCode = `// EncodeMethodHeaderStringDefaultRes
 func EncodeMethodHeaderStringDefaultResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
 	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
 		res := v.(*serviceheaderstringdefault.MethodHeaderStringDefaultResult)
-		if res.H != nil {
-			w.Header().Set("h", *res.H)
-		} else {
-			w.Header().Set("h", "def")
-		}
+		w.Header().Set("h", res.H)
 		w.WriteHeader(http.StatusOK)
 		return nil
 	}
@@ -917,3 +909,112 @@ func EncodeMethodEmptyServerResponseResponse(encoder func(context.Context, http.
 	}
 }
 `
+
+var ResultBodyProducesEncodeCode = `// EncodeMethodBodyProducesResponse returns an encoder for responses returned
+// by the ServiceBodyProduces MethodBodyProduces endpoint.
+func EncodeMethodBodyProducesResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*servicebodyproduces.ResultType)
+		ctx = context.WithValue(ctx, goahttp.AcceptTypeKey, goahttp.NegotiateContentType(ctx, "application/json", "application/xml"))
+		enc := encoder(ctx, w)
+		body := NewMethodBodyProducesResponseBody(res)
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultCookieEncodeCode = `// EncodeMethodCookieResponse returns an encoder for responses returned by the
+// ServiceCookie MethodCookie endpoint.
+func EncodeMethodCookieResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*servicecookie.MethodCookieResult)
+		enc := encoder(ctx, w)
+		body := NewMethodCookieResponseBody(res)
+		session := res.Session
+		http.SetCookie(w, &http.Cookie{
+			Name:     "SID",
+			Value:    session,
+			MaxAge:   3600,
+			Secure:   true,
+			HttpOnly: true,
+		})
+		if res.Count != nil {
+			countRaw := res.Count
+			count := strconv.Itoa(*countRaw)
+			http.SetCookie(w, &http.Cookie{
+				Name:     "count",
+				Value:    count,
+				MaxAge:   3600,
+				Secure:   true,
+				HttpOnly: true,
+			})
+		}
+		w.WriteHeader(http.StatusOK)
+		return enc.Encode(body)
+	}
+}
+`
+
+var ResultSkipResponseBodyEncodeDecodeEncodeCode = `// EncodeMethodSkipResponseBodyEncodeDecodeResponse returns an encoder for
+// responses returned by the ServiceSkipResponseBodyEncodeDecode
+// MethodSkipResponseBodyEncodeDecode endpoint.
+func EncodeMethodSkipResponseBodyEncodeDecodeResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		o := v.(*serviceskipresponsebodyencodedecode.MethodSkipResponseBodyEncodeDecodeResponseData)
+		defer o.Body.Close()
+		res := o.Result
+		val := res.Length
+		lengths := strconv.FormatInt(val, 10)
+		w.Header().Set("Content-Length", lengths)
+		w.WriteHeader(http.StatusOK)
+		_, err := io.Copy(w, o.Body)
+		return err
+	}
+}
+`
+
+var ResultConstantHeaderEncodeCode = `// EncodeMethodConstantHeaderResponse returns an encoder for responses returned
+// by the ServiceConstantHeader MethodConstantHeader endpoint.
+func EncodeMethodConstantHeaderResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		res := v.(*serviceconstantheader.MethodConstantHeaderResult)
+		if res.H != nil {
+			w.Header().Set("h", *res.H)
+		}
+		w.Header().Set("Cache-Control", "max-age=60")
+		w.Header().Set("X-Api-Version", "2")
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+}
+`
+
+var ResultTrailersEncodeCode = `// EncodeMethodTrailersResponse returns an encoder for responses returned by
+// the ServiceTrailers MethodTrailers endpoint.
+func EncodeMethodTrailersResponse(encoder func(context.Context, http.ResponseWriter) goahttp.Encoder) func(context.Context, http.ResponseWriter, interface{}) error {
+	return func(ctx context.Context, w http.ResponseWriter, v interface{}) error {
+		o := v.(*servicetrailers.MethodTrailersResponseData)
+		defer o.Body.Close()
+		res := o.Result
+		if res.Kind != nil {
+			w.Header().Set("X-Kind", *res.Kind)
+		}
+		w.Header().Add("Trailer", "X-Checksum")
+		w.Header().Add("Trailer", "X-Record-Count")
+		w.WriteHeader(http.StatusOK)
+		if _, err := io.Copy(w, o.Body); err != nil {
+			return err
+		}
+		if res.Checksum != nil {
+			w.Header().Set("X-Checksum", *res.Checksum)
+		}
+		if res.Count != nil {
+			val := res.Count
+			counts := strconv.Itoa(*val)
+			w.Header().Set("X-Record-Count", counts)
+		}
+		return nil
+	}
+}
+`
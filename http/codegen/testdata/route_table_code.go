@@ -0,0 +1,15 @@
+package testdata
+
+var RouteTableCode = `// Routes lists the routes mounted by Mount with their path segments and the
+// indices of their wildcard segments. goahttp.NewRouteMuxer uses the list to
+// initialize its routing tree.
+var Routes = []*goahttp.Route{
+	{Verb: "GET", Pattern: "/accounts/{id}/users/{name}", Segments: []string{"accounts", "{id}", "users", "{name}"}, Wildcards: []int{1, 3}},
+	{Verb: "POST", Pattern: "/accounts/{name}/{id}", Segments: []string{"accounts", "{name}", "{id}"}, Wildcards: []int{1, 2}},
+	{Verb: "GET", Pattern: "/accounts", Segments: []string{"accounts"}},
+	{Verb: "OPTIONS", Pattern: "/accounts/{id}/users/{name}", Segments: []string{"accounts", "{id}", "users", "{name}"}, Wildcards: []int{1, 3}},
+	{Verb: "OPTIONS", Pattern: "/accounts/{name}/{id}", Segments: []string{"accounts", "{name}", "{id}"}, Wildcards: []int{1, 2}},
+	{Verb: "OPTIONS", Pattern: "/accounts", Segments: []string{"accounts"}},
+	{Verb: "GET", Pattern: "/accounts/docs/{*path}", Segments: []string{"accounts", "docs", "{*path}"}, Wildcards: []int{2}},
+}
+`
@@ -0,0 +1,33 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var RouteTableDSL = func() {
+	Service("ServiceRouteTable", func() {
+		HTTP(func() {
+			Path("/accounts")
+			CORS(func() {
+				AllowOrigins("https://goa.design")
+			})
+		})
+		Files("/docs/{*path}", "/www/docs")
+		Method("MethodRouteTable", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				GET("/{id}/users/{name}")
+				POST("/{name}/{id}")
+			})
+		})
+		Method("MethodRoot", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
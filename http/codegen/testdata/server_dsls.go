@@ -82,6 +82,18 @@ var ServerPayloadResultErrorDSL = func() {
 	})
 }
 
+var ServerPanicErrorDSL = func() {
+	Service("ServicePanicError", func() {
+		Method("MethodPanicError", func() {
+			Error("internal")
+			HTTP(func() {
+				GET("/")
+				Response("internal", StatusInternalServerError)
+			})
+		})
+	})
+}
+
 var ServerMultiBasesDSL = func() {
 	Service("ServiceMultiBases", func() {
 		HTTP(func() {
@@ -157,3 +169,59 @@ var ServerMultipartDSL = func() {
 		})
 	})
 }
+
+var ServerCORSDSL = func() {
+	Service("ServiceCORS", func() {
+		HTTP(func() {
+			CORS(func() {
+				AllowOrigins("https://goa.design", "https://*.goa.design")
+				AllowHeaders("X-Shared-Secret")
+				MaxAge(600)
+			})
+		})
+		Method("MethodCORS", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+		Method("MethodCORS2", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			HTTP(func() {
+				PUT("/{id}")
+			})
+		})
+		Method("MethodCORS3", func() {
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
+
+var ServerFileServerAcceptRangesDSL = func() {
+	Service("ServiceFileServerAcceptRanges", func() {
+		Files("/file.json", "/path/to/file.json", func() {
+			AcceptRanges(0)
+		})
+		Files("/videos/{*filepath}", "/path/to/videos", func() {
+			AcceptRanges(1048576)
+		})
+	})
+}
+
+var ServerFileServerEmbedDSL = func() {
+	Service("ServiceFileServerEmbed", func() {
+		Files("/file.json", "testdata/assets/file.json", func() {
+			Embed()
+		})
+		Files("/public/{*filepath}", "testdata/assets/public", func() {
+			Embed()
+			AcceptRanges(0)
+		})
+	})
+}
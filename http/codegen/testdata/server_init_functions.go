@@ -95,3 +95,115 @@ func New(
 	}
 }
 `
+
+var ServerCORSConstructorCode = `// New instantiates HTTP handlers for all the ServiceCORS service endpoints.
+func New(
+	e *servicecors.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"MethodCORS", "GET", "/{id}"},
+			{"MethodCORS2", "PUT", "/{id}"},
+			{"MethodCORS3", "POST", "/"},
+			{"CORS", "OPTIONS", "/{id}"},
+			{"CORS", "OPTIONS", "/"},
+		},
+		MethodCORS:  NewMethodCORSHandler(e.MethodCORS, mux, dec, enc, eh),
+		MethodCORS2: NewMethodCORS2Handler(e.MethodCORS2, mux, dec, enc, eh),
+		MethodCORS3: NewMethodCORS3Handler(e.MethodCORS3, mux, dec, enc, eh),
+	}
+}
+`
+
+var ServerCORSMountCode = `// Mount configures the mux to serve the ServiceCORS endpoints.
+func Mount(mux goahttp.Muxer, h *Server) {
+	MountMethodCORSHandler(mux, handleServiceCORSOrigin(h.MethodCORS))
+	MountMethodCORS2Handler(mux, handleServiceCORSOrigin(h.MethodCORS2))
+	MountMethodCORS3Handler(mux, handleServiceCORSOrigin(h.MethodCORS3))
+	MountCORSHandler(mux, NewCORSHandler())
+}
+`
+
+var ServerCORSHandlersCode = `// MountCORSHandler configures the mux to serve the CORS preflight requests
+// made to the ServiceCORS service endpoints.
+func MountCORSHandler(mux goahttp.Muxer, h http.Handler) {
+	h = handleServiceCORSOrigin(h)
+	f, ok := h.(http.HandlerFunc)
+	if !ok {
+		f = func(w http.ResponseWriter, r *http.Request) {
+			h.ServeHTTP(w, r)
+		}
+	}
+	mux.Handle("OPTIONS", "/{id}", f)
+	mux.Handle("OPTIONS", "/", f)
+}
+
+// NewCORSHandler creates a HTTP handler which returns a simple 200 response.
+func NewCORSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// handleServiceCORSOrigin applies the CORS response headers corresponding to
+// the request origin for the ServiceCORS service.
+func handleServiceCORSOrigin(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if !goahttp.MatchOrigin(origin, "https://goa.design", "https://*.goa.design") {
+			h.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Add("Vary", "Origin")
+		if acrm := r.Header.Get("Access-Control-Request-Method"); r.Method == "OPTIONS" && acrm != "" {
+			// We are handling a preflight request
+			w.Header().Set("Access-Control-Allow-Methods", "GET, PUT, POST")
+			w.Header().Set("Access-Control-Allow-Headers", "X-Shared-Secret")
+			w.Header().Set("Access-Control-Max-Age", "600")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+`
+
+var ServerFileServerAcceptRangesMountCode = `// Mount configures the mux to serve the ServiceFileServerAcceptRanges
+// endpoints.
+func Mount(mux goahttp.Muxer) {
+	MountPathToFileJSON(mux, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		goahttp.ServeFile(w, r, "/path/to/file.json", 0)
+	}))
+	MountPathToVideos(mux, goahttp.FileServer(http.Dir("/path/to/videos"), 1048576))
+}
+`
+
+var ServerFileServerEmbedMountCode = `// Mount configures the mux to serve the ServiceFileServerEmbed endpoints.
+func Mount(mux goahttp.Muxer) {
+	MountTestdataAssetsFileJSON(mux, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFileFS(w, r, embeddedFS, "files/testdata/assets/file.json")
+	}))
+	MountTestdataAssetsPublic(mux, goahttp.FileServer(embeddedDir("files/testdata/assets/public"), 0))
+}
+`
+
+var ServerFileServerEmbedCode = `// embeddedFS contains the static assets served by the ServiceFileServerEmbed
+// service.
+//
+//go:embed files/testdata/assets/file.json files/testdata/assets/public
+var embeddedFS embed.FS
+
+// embeddedDir returns the embedded file system rooted at the given directory.
+func embeddedDir(dir string) http.FileSystem {
+	sub, err := fs.Sub(embeddedFS, dir)
+	if err != nil {
+		panic(err) // bug: embedded paths are validated by the design
+	}
+	return http.FS(sub)
+}
+`
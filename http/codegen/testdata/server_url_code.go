@@ -0,0 +1,108 @@
+package testdata
+
+var ServerVariablesServerURLCode = `// Servers lists the URLs of the ServiceServerVariables service hosts. The URLs
+// may contain variables using the "{name}" syntax, use ServerURL to build the
+// URL of a host.
+var Servers = []string{
+	"https://{environment}.{region}.calc.com", // Calc hosts
+	"http://localhost:8080",                   // Development host
+}
+
+// ServerURL returns the URL of the ServiceServerVariables service host at the
+// given index in Servers. The URL variables are set with the values given in
+// vars or with their default values. The scheme and host of the URL may be
+// given to NewClient.
+func ServerURL(index int, vars map[string]string) (*url.URL, error) {
+	var (
+		u        string
+		defaults map[string]string
+		values   map[string][]string
+	)
+	switch index {
+	case 0:
+		u = Servers[0]
+		defaults = map[string]string{
+			"environment": "prod",
+			"region":      "us-east-1",
+		}
+		values = map[string][]string{
+			"environment": {"prod", "staging"},
+		}
+	case 1:
+		u = Servers[1]
+	default:
+		return nil, fmt.Errorf("invalid server index %d, must be between 0 and %d", index, len(Servers)-1)
+	}
+	for name, def := range defaults {
+		v, ok := vars[name]
+		if !ok {
+			v = def
+		} else if vals, ok := values[name]; ok {
+			valid := false
+			for _, val := range vals {
+				if v == val {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return nil, fmt.Errorf("invalid value %q for server variable %q, must be one of %s", v, name, strings.Join(vals, ", "))
+			}
+		}
+		u = strings.Replace(u, "{"+name+"}", v, -1)
+	}
+	return url.Parse(u)
+}
+`
+
+var ServiceServerVariablesServerURLCode = `// Servers lists the URLs of the ServiceServerVariables service hosts. The URLs
+// may contain variables using the "{name}" syntax, use ServerURL to build the
+// URL of a host.
+var Servers = []string{
+	"http://{host}:{port}",
+}
+
+// ServerURL returns the URL of the ServiceServerVariables service host at the
+// given index in Servers. The URL variables are set with the values given in
+// vars or with their default values. The scheme and host of the URL may be
+// given to NewClient.
+func ServerURL(index int, vars map[string]string) (*url.URL, error) {
+	var (
+		u        string
+		defaults map[string]string
+		values   map[string][]string
+	)
+	switch index {
+	case 0:
+		u = Servers[0]
+		defaults = map[string]string{
+			"host": "localhost",
+			"port": "8080",
+		}
+		values = map[string][]string{
+			"port": {"80", "8080"},
+		}
+	default:
+		return nil, fmt.Errorf("invalid server index %d, must be between 0 and %d", index, len(Servers)-1)
+	}
+	for name, def := range defaults {
+		v, ok := vars[name]
+		if !ok {
+			v = def
+		} else if vals, ok := values[name]; ok {
+			valid := false
+			for _, val := range vals {
+				if v == val {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return nil, fmt.Errorf("invalid value %q for server variable %q, must be one of %s", v, name, strings.Join(vals, ", "))
+			}
+		}
+		u = strings.Replace(u, "{"+name+"}", v, -1)
+	}
+	return url.Parse(u)
+}
+`
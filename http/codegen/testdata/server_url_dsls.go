@@ -0,0 +1,50 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var ServerVariablesDSL = func() {
+	API("test", func() {
+		Server("https://{environment}.{region}.calc.com", func() {
+			Description("Calc hosts")
+			Param("environment", String, "Deployment environment", func() {
+				Enum("prod", "staging")
+				Default("prod")
+			})
+			Param("region", String, func() {
+				Default("us-east-1")
+			})
+		})
+		Server("http://localhost:8080", func() {
+			Description("Development host")
+		})
+	})
+	Service("ServiceServerVariables", func() {
+		Method("MethodServerVariables", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var ServiceServerVariablesDSL = func() {
+	Service("ServiceServerVariables", func() {
+		Server("http://{host}:{port}", func() {
+			Param("host", String, func() {
+				Default("localhost")
+			})
+			Param("port", Int, func() {
+				Enum(80, 8080)
+				Default(8080)
+			})
+		})
+		Method("MethodServerVariables", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
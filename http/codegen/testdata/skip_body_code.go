@@ -0,0 +1,145 @@
+package testdata
+
+var SkipBodyEncodeDecodeServerHandlerInitCode = `// NewSkipBodyEncodeDecodeMethodHandler creates a HTTP handler which loads the
+// HTTP request and calls the "SkipBodyEncodeDecodeService" service
+// "SkipBodyEncodeDecodeMethod" endpoint.
+func NewSkipBodyEncodeDecodeMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest  = DecodeSkipBodyEncodeDecodeMethodRequest(mux, dec)
+		encodeResponse = EncodeSkipBodyEncodeDecodeMethodResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "SkipBodyEncodeDecodeMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "SkipBodyEncodeDecodeService")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
+		payload, err := decodeRequest(r)
+		if err != nil {
+			eh(ctx, w, err)
+			return
+		}
+
+		data := &skipbodyencodedecodeservice.SkipBodyEncodeDecodeMethodRequestData{Payload: payload.(*skipbodyencodedecodeservice.SkipBodyEncodeDecodeMethodPayload), Body: r.Body}
+		res, err := endpoint(ctx, data)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var SkipBodyEncodeDecodeNoPayloadServerHandlerInitCode = `// NewSkipBodyEncodeDecodeNoPayloadMethodHandler creates a HTTP handler which
+// loads the HTTP request and calls the "SkipBodyEncodeDecodeNoPayloadService"
+// service "SkipBodyEncodeDecodeNoPayloadMethod" endpoint.
+func NewSkipBodyEncodeDecodeNoPayloadMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeSkipBodyEncodeDecodeNoPayloadMethodResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "SkipBodyEncodeDecodeNoPayloadMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "SkipBodyEncodeDecodeNoPayloadService")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
+
+		data := &skipbodyencodedecodenopayloadservice.SkipBodyEncodeDecodeNoPayloadMethodRequestData{Body: r.Body}
+		res, err := endpoint(ctx, data)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var SkipBodyEncodeDecodeClientEndpointCode = `// SkipBodyEncodeDecodeMethod returns an endpoint that makes HTTP requests to
+// the SkipBodyEncodeDecodeService service SkipBodyEncodeDecodeMethod server.
+func (c *Client) SkipBodyEncodeDecodeMethod() goa.Endpoint {
+	var (
+		decodeResponse = DecodeSkipBodyEncodeDecodeMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		data, ok := v.(*skipbodyencodedecodeservice.SkipBodyEncodeDecodeMethodRequestData)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("SkipBodyEncodeDecodeService", "SkipBodyEncodeDecodeMethod", "*skipbodyencodedecodeservice.SkipBodyEncodeDecodeMethodRequestData", v)
+		}
+		v = data.Payload
+		req, err := c.BuildSkipBodyEncodeDecodeMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = data.Body
+		goahttp.ApplyRequestOptions(ctx, req)
+		resp, err := c.SkipBodyEncodeDecodeMethodDoer.Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("SkipBodyEncodeDecodeService", "SkipBodyEncodeDecodeMethod", err)
+		}
+		res, err := decodeResponse(resp)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		return &skipbodyencodedecodeservice.SkipBodyEncodeDecodeMethodResponseData{Result: res.(*skipbodyencodedecodeservice.SkipBodyEncodeDecodeMethodResult), Body: resp.Body}, nil
+	}
+}
+`
+
+var SkipBodyEncodeDecodeNoPayloadClientEndpointCode = `// SkipBodyEncodeDecodeNoPayloadMethod returns an endpoint that makes HTTP
+// requests to the SkipBodyEncodeDecodeNoPayloadService service
+// SkipBodyEncodeDecodeNoPayloadMethod server.
+func (c *Client) SkipBodyEncodeDecodeNoPayloadMethod() goa.Endpoint {
+	var (
+		decodeResponse = DecodeSkipBodyEncodeDecodeNoPayloadMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		data, ok := v.(*skipbodyencodedecodenopayloadservice.SkipBodyEncodeDecodeNoPayloadMethodRequestData)
+		if !ok {
+			return nil, goahttp.ErrInvalidType("SkipBodyEncodeDecodeNoPayloadService", "SkipBodyEncodeDecodeNoPayloadMethod", "*skipbodyencodedecodenopayloadservice.SkipBodyEncodeDecodeNoPayloadMethodRequestData", v)
+		}
+		req, err := c.BuildSkipBodyEncodeDecodeNoPayloadMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = data.Body
+		goahttp.ApplyRequestOptions(ctx, req)
+		resp, err := c.SkipBodyEncodeDecodeNoPayloadMethodDoer.Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("SkipBodyEncodeDecodeNoPayloadService", "SkipBodyEncodeDecodeNoPayloadMethod", err)
+		}
+		_, err = decodeResponse(resp)
+		if err != nil {
+			resp.Body.Close()
+			return nil, err
+		}
+		return &skipbodyencodedecodenopayloadservice.SkipBodyEncodeDecodeNoPayloadMethodResponseData{Body: resp.Body}, nil
+	}
+}
+`
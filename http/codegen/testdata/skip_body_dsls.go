@@ -0,0 +1,39 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var SkipBodyEncodeDecodeDSL = func() {
+	Service("SkipBodyEncodeDecodeService", func() {
+		Method("SkipBodyEncodeDecodeMethod", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			Result(func() {
+				Attribute("length", Int64)
+			})
+			HTTP(func() {
+				PUT("/{name}")
+				SkipRequestBodyEncodeDecode()
+				SkipResponseBodyEncodeDecode()
+				Response(StatusOK, func() {
+					Header("length:Content-Length")
+				})
+			})
+		})
+	})
+}
+
+var SkipBodyEncodeDecodeNoPayloadDSL = func() {
+	Service("SkipBodyEncodeDecodeNoPayloadService", func() {
+		Method("SkipBodyEncodeDecodeNoPayloadMethod", func() {
+			HTTP(func() {
+				POST("/")
+				SkipRequestBodyEncodeDecode()
+				SkipResponseBodyEncodeDecode()
+			})
+		})
+	})
+}
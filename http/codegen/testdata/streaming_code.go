@@ -20,6 +20,7 @@ func NewStreamingResultMethodHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultService")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
 		payload, err := decodeRequest(r)
 		if err != nil {
 			eh(ctx, w, err)
@@ -41,6 +42,14 @@ func NewStreamingResultMethodHandler(
 			if _, ok := err.(websocket.HandshakeError); ok {
 				return
 			}
+			if s := v.Stream.(*StreamingResultMethodServerStream); s.conn != nil {
+				// The connection was upgraded, report the error with a
+				// websocket close control message.
+				if err := s.closeWithError(err); err != nil {
+					eh(ctx, w, err)
+				}
+				return
+			}
 			if err := encodeError(ctx, w, err); err != nil {
 				eh(ctx, w, err)
 			}
@@ -120,6 +129,7 @@ func NewStreamingResultWithViewsMethodHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultWithViewsMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultWithViewsService")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
 		payload, err := decodeRequest(r)
 		if err != nil {
 			eh(ctx, w, err)
@@ -141,6 +151,14 @@ func NewStreamingResultWithViewsMethodHandler(
 			if _, ok := err.(websocket.HandshakeError); ok {
 				return
 			}
+			if s := v.Stream.(*StreamingResultWithViewsMethodServerStream); s.conn != nil {
+				// The connection was upgraded, report the error with a
+				// websocket close control message.
+				if err := s.closeWithError(err); err != nil {
+					eh(ctx, w, err)
+				}
+				return
+			}
 			if err := encodeError(ctx, w, err); err != nil {
 				eh(ctx, w, err)
 			}
@@ -208,6 +226,7 @@ func NewStreamingResultNoPayloadMethodHandler(
 		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
 		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultNoPayloadMethod")
 		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultNoPayloadService")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
 
 		v := &streamingresultnopayloadservice.StreamingResultNoPayloadMethodEndpointInput{
 			Stream: &StreamingResultNoPayloadMethodServerStream{
@@ -217,12 +236,20 @@ func NewStreamingResultNoPayloadMethodHandler(
 				r:            r,
 			},
 		}
-		_, err = endpoint(ctx, v)
+		_, err := endpoint(ctx, v)
 
 		if err != nil {
 			if _, ok := err.(websocket.HandshakeError); ok {
 				return
 			}
+			if s := v.Stream.(*StreamingResultNoPayloadMethodServerStream); s.conn != nil {
+				// The connection was upgraded, report the error with a
+				// websocket close control message.
+				if err := s.closeWithError(err); err != nil {
+					eh(ctx, w, err)
+				}
+				return
+			}
 			if err := encodeError(ctx, w, err); err != nil {
 				eh(ctx, w, err)
 			}
@@ -248,6 +275,7 @@ func (c *Client) StreamingResultMethod() goa.Endpoint {
 		if err != nil {
 			return nil, err
 		}
+		goahttp.ApplyRequestOptions(ctx, req)
 		conn, resp, err := c.dialer.Dial(req.URL.String(), req.Header)
 		if err != nil {
 			if resp != nil {
@@ -293,6 +321,9 @@ func (s *StreamingResultMethodClientStream) Recv() (*streamingresultservice.User
 	if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
 		return nil, io.EOF
 	}
+	if serr := goahttp.DecodeStreamError(err); serr != nil {
+		return nil, serr
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -318,6 +349,7 @@ func (c *Client) StreamingResultWithViewsMethod() goa.Endpoint {
 		if err != nil {
 			return nil, err
 		}
+		goahttp.ApplyRequestOptions(ctx, req)
 		conn, resp, err := c.dialer.Dial(req.URL.String(), req.Header)
 		if err != nil {
 			if resp != nil {
@@ -344,6 +376,9 @@ func (s *StreamingResultWithViewsMethodClientStream) Recv() (*streamingresultwit
 	if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
 		return nil, io.EOF
 	}
+	if serr := goahttp.DecodeStreamError(err); serr != nil {
+		return nil, serr
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -362,3 +397,860 @@ func (s *StreamingResultWithViewsMethodClientStream) SetView(view string) {
 	s.view = view
 }
 `
+
+var StreamingResultBinaryServerStructTypeCode = `// StreamingResultBinaryMethodServerStream implements the
+// streamingresultbinaryservice.StreamingResultBinaryMethodServerStream
+// interface.
+type StreamingResultBinaryMethodServerStream struct {
+	once sync.Once
+	// upgrader is the websocket connection upgrader.
+	upgrader goahttp.Upgrader
+	// connConfigFn is the websocket connection configurer.
+	connConfigFn goahttp.ConnConfigureFunc
+	// w is the HTTP response writer used in upgrading the connection.
+	w http.ResponseWriter
+	// r is the HTTP request.
+	r *http.Request
+	// conn is the underlying websocket connection.
+	conn *websocket.Conn
+	// codec marshals and unmarshals the binary websocket messages.
+	codec goahttp.StreamCodec
+}
+`
+
+var StreamingResultBinaryServerInitCode = `// New instantiates HTTP handlers for all the StreamingResultBinaryService
+// service endpoints.
+func New(
+	e *streamingresultbinaryservice.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	up goahttp.Upgrader,
+	connConfigFn goahttp.ConnConfigureFunc,
+	codec goahttp.StreamCodec,
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"StreamingResultBinaryMethod", "GET", "/"},
+		},
+		StreamingResultBinaryMethod: NewStreamingResultBinaryMethodHandler(e.StreamingResultBinaryMethod, mux, dec, enc, eh, up, connConfigFn, codec),
+	}
+}
+`
+
+var StreamingResultBinaryServerHandlerInitCode = `// NewStreamingResultBinaryMethodHandler creates a HTTP handler which loads the
+// HTTP request and calls the "StreamingResultBinaryService" service
+// "StreamingResultBinaryMethod" endpoint.
+func NewStreamingResultBinaryMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	up goahttp.Upgrader,
+	connConfigFn goahttp.ConnConfigureFunc,
+	codec goahttp.StreamCodec,
+) http.Handler {
+	var (
+		decodeRequest = DecodeStreamingResultBinaryMethodRequest(mux, dec)
+		encodeError   = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultBinaryMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultBinaryService")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
+		payload, err := decodeRequest(r)
+		if err != nil {
+			eh(ctx, w, err)
+			return
+		}
+
+		v := &streamingresultbinaryservice.StreamingResultBinaryMethodEndpointInput{
+			Stream: &StreamingResultBinaryMethodServerStream{
+				upgrader:     up,
+				connConfigFn: connConfigFn,
+				codec:        codec,
+				w:            w,
+				r:            r,
+			},
+			Payload: payload.(*streamingresultbinaryservice.Request),
+		}
+		_, err = endpoint(ctx, v)
+
+		if err != nil {
+			if _, ok := err.(websocket.HandshakeError); ok {
+				return
+			}
+			if s := v.Stream.(*StreamingResultBinaryMethodServerStream); s.conn != nil {
+				// The connection was upgraded, report the error with a
+				// websocket close control message.
+				if err := s.closeWithError(err); err != nil {
+					eh(ctx, w, err)
+				}
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var StreamingResultBinaryServerStreamSendCode = `// Send sends streamingresultbinaryservice.UserType type to the
+// "StreamingResultBinaryMethod" endpoint websocket connection.
+func (s *StreamingResultBinaryMethodServerStream) Send(v *streamingresultbinaryservice.UserType) error {
+	var err error
+	// Upgrade the HTTP connection to a websocket connection only once before
+	// sending result. Connection upgrade is done here so that authorization logic
+	// in the endpoint is executed before calling the actual service method which
+	// may call Send().
+	s.once.Do(func() {
+		var conn *websocket.Conn
+		conn, err = s.upgrader.Upgrade(s.w, s.r, nil)
+		if err != nil {
+			return
+		}
+		if s.connConfigFn != nil {
+			conn = s.connConfigFn(conn)
+		}
+		s.conn = conn
+	})
+	if err != nil {
+		s.Close()
+		return err
+	}
+	res := v
+	body := NewStreamingResultBinaryMethodResponseBody(res)
+	msg, err := s.codec.Marshal(body)
+	if err != nil {
+		return err
+	}
+	return s.conn.WriteMessage(websocket.BinaryMessage, msg)
+}
+`
+
+var StreamingResultBinaryClientInitCode = `// NewClient instantiates HTTP clients for all the StreamingResultBinaryService
+// service servers.
+func NewClient(
+	scheme string,
+	host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+	dialer goahttp.Dialer,
+	connConfigFn goahttp.ConnConfigureFunc,
+	codec goahttp.StreamCodec,
+) *Client {
+	return &Client{
+		StreamingResultBinaryMethodDoer: doer,
+		RestoreResponseBody:             restoreBody,
+		scheme:                          scheme,
+		host:                            host,
+		decoder:                         dec,
+		encoder:                         enc,
+		dialer:                          dialer,
+		connConfigFn:                    connConfigFn,
+		codec:                           codec,
+	}
+}
+`
+
+var StreamingResultBinaryClientEndpointCode = `// StreamingResultBinaryMethod returns an endpoint that makes HTTP requests to
+// the StreamingResultBinaryService service StreamingResultBinaryMethod server.
+func (c *Client) StreamingResultBinaryMethod() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeStreamingResultBinaryMethodRequest(c.encoder)
+		decodeResponse = DecodeStreamingResultBinaryMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamingResultBinaryMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.ApplyRequestOptions(ctx, req)
+		conn, resp, err := c.dialer.Dial(req.URL.String(), req.Header)
+		if err != nil {
+			if resp != nil {
+				return decodeResponse(resp)
+			}
+			return nil, goahttp.ErrRequestError("StreamingResultBinaryService", "StreamingResultBinaryMethod", err)
+		}
+		if c.connConfigFn != nil {
+			conn = c.connConfigFn(conn)
+		}
+		stream := &StreamingResultBinaryMethodClientStream{conn: conn, codec: c.codec}
+		return stream, nil
+	}
+}
+`
+
+var StreamingResultBinaryClientStreamRecvCode = `// Recv receives a streamingresultbinaryservice.UserType type from the
+// "StreamingResultBinaryMethod" endpoint websocket connection.
+func (s *StreamingResultBinaryMethodClientStream) Recv() (*streamingresultbinaryservice.UserType, error) {
+	var body StreamingResultBinaryMethodResponseBody
+	_, msg, err := s.conn.ReadMessage()
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		return nil, io.EOF
+	}
+	if serr := goahttp.DecodeStreamError(err); serr != nil {
+		return nil, serr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = s.codec.Unmarshal(msg, &body); err != nil {
+		return nil, err
+	}
+	res := NewStreamingResultBinaryMethodUserTypeOK(&body)
+	return res, nil
+}
+`
+
+var StreamingResultNDJSONServerStructTypeCode = `// StreamingResultNDJSONMethodServerStream implements the
+// streamingresultndjsonservice.StreamingResultNDJSONMethodServerStream
+// interface.
+type StreamingResultNDJSONMethodServerStream struct {
+	// w is the HTTP response writer the results are written to.
+	w http.ResponseWriter
+	// r is the HTTP request.
+	r *http.Request
+	// started is true once the response status and headers are written.
+	started bool
+}
+`
+
+var StreamingResultNDJSONServerInitCode = `// New instantiates HTTP handlers for all the StreamingResultNDJSONService
+// service endpoints.
+func New(
+	e *streamingresultndjsonservice.Endpoints,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) *Server {
+	return &Server{
+		Mounts: []*MountPoint{
+			{"StreamingResultNDJSONMethod", "GET", "/"},
+		},
+		StreamingResultNDJSONMethod: NewStreamingResultNDJSONMethodHandler(e.StreamingResultNDJSONMethod, mux, dec, enc, eh),
+	}
+}
+`
+
+var StreamingResultNDJSONServerHandlerInitCode = `// NewStreamingResultNDJSONMethodHandler creates a HTTP handler which loads the
+// HTTP request and calls the "StreamingResultNDJSONService" service
+// "StreamingResultNDJSONMethod" endpoint.
+func NewStreamingResultNDJSONMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest = DecodeStreamingResultNDJSONMethodRequest(mux, dec)
+		encodeError   = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultNDJSONMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultNDJSONService")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
+		payload, err := decodeRequest(r)
+		if err != nil {
+			eh(ctx, w, err)
+			return
+		}
+
+		v := &streamingresultndjsonservice.StreamingResultNDJSONMethodEndpointInput{
+			Stream: &StreamingResultNDJSONMethodServerStream{
+				w: w,
+				r: r,
+			},
+			Payload: payload.(*streamingresultndjsonservice.Request),
+		}
+		_, err = endpoint(ctx, v)
+
+		if err != nil {
+			if v.Stream.(*StreamingResultNDJSONMethodServerStream).started {
+				// The response status and headers were already
+				// written, the error cannot be sent to the client.
+				eh(ctx, w, err)
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var StreamingResultNDJSONServerStreamSendCode = `// Send writes streamingresultndjsonservice.UserType type to the
+// "StreamingResultNDJSONMethod" endpoint HTTP response as a line of newline
+// delimited JSON.
+func (s *StreamingResultNDJSONMethodServerStream) Send(v *streamingresultndjsonservice.UserType) error {
+	s.writeHeader()
+	res := v
+	body := NewStreamingResultNDJSONMethodResponseBody(res)
+	if err := json.NewEncoder(s.w).Encode(body); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// writeHeader writes the response status and headers before the first result
+// is sent.
+func (s *StreamingResultNDJSONMethodServerStream) writeHeader() {
+	if s.started {
+		return
+	}
+	s.started = true
+	s.w.Header().Set("Content-Type", "application/x-ndjson")
+	s.w.WriteHeader(http.StatusOK)
+}
+`
+
+var StreamingResultNDJSONServerStreamCloseCode = `// Close ends the "StreamingResultNDJSONMethod" endpoint stream, the response
+// status and headers are written if no result was sent.
+func (s *StreamingResultNDJSONMethodServerStream) Close() error {
+	s.writeHeader()
+	return nil
+}
+`
+
+var StreamingResultNDJSONWithViewsServerStreamSendCode = `// Send writes streamingresultndjsonwithviewsservice.Usertype type to the
+// "StreamingResultNDJSONWithViewsMethod" endpoint HTTP response as a line of
+// newline delimited JSON.
+func (s *StreamingResultNDJSONWithViewsMethodServerStream) Send(v *streamingresultndjsonwithviewsservice.Usertype) error {
+	s.writeHeader()
+	res := streamingresultndjsonwithviewsservice.NewViewedUsertype(v, s.view)
+	body := NewStreamingResultNDJSONWithViewsMethodResponseBody(res.Projected)
+	if err := json.NewEncoder(s.w).Encode(body); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// writeHeader writes the response status and headers before the first result
+// is sent.
+func (s *StreamingResultNDJSONWithViewsMethodServerStream) writeHeader() {
+	if s.started {
+		return
+	}
+	s.started = true
+	s.w.Header().Set("Content-Type", "application/x-ndjson")
+	s.w.Header().Set("goa-view", s.view)
+	s.w.WriteHeader(http.StatusOK)
+}
+`
+
+var StreamingResultNDJSONWithViewsServerStreamSetViewCode = `// SetView sets the view to render the
+// streamingresultndjsonwithviewsservice.Usertype type before sending to the
+// "StreamingResultNDJSONWithViewsMethod" endpoint HTTP response.
+func (s *StreamingResultNDJSONWithViewsMethodServerStream) SetView(view string) {
+	s.view = view
+}
+`
+
+var StreamingResultNDJSONClientStructTypeCode = `// StreamingResultNDJSONMethodClientStream implements the
+// streamingresultndjsonservice.StreamingResultNDJSONMethodClientStream
+// interface.
+type StreamingResultNDJSONMethodClientStream struct {
+	// body is the HTTP response body the results are read from.
+	body io.ReadCloser
+	// dec decodes the newline delimited JSON results.
+	dec *json.Decoder
+}
+`
+
+var StreamingResultNDJSONClientInitCode = `// NewClient instantiates HTTP clients for all the StreamingResultNDJSONService
+// service servers.
+func NewClient(
+	scheme string,
+	host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restoreBody bool,
+) *Client {
+	return &Client{
+		StreamingResultNDJSONMethodDoer: doer,
+		RestoreResponseBody:             restoreBody,
+		scheme:                          scheme,
+		host:                            host,
+		decoder:                         dec,
+		encoder:                         enc,
+	}
+}
+`
+
+var StreamingResultNDJSONClientEndpointCode = `// StreamingResultNDJSONMethod returns an endpoint that makes HTTP requests to
+// the StreamingResultNDJSONService service StreamingResultNDJSONMethod server.
+func (c *Client) StreamingResultNDJSONMethod() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeStreamingResultNDJSONMethodRequest(c.encoder)
+		decodeResponse = DecodeStreamingResultNDJSONMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamingResultNDJSONMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.ApplyRequestOptions(ctx, req)
+		resp, err := c.StreamingResultNDJSONMethodDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("StreamingResultNDJSONService", "StreamingResultNDJSONMethod", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return decodeResponse(resp)
+		}
+		stream := &StreamingResultNDJSONMethodClientStream{body: resp.Body, dec: json.NewDecoder(resp.Body)}
+		return stream, nil
+	}
+}
+`
+
+var StreamingResultNDJSONClientStreamRecvCode = `// Recv reads a streamingresultndjsonservice.UserType type from the
+// "StreamingResultNDJSONMethod" endpoint newline delimited JSON HTTP response.
+func (s *StreamingResultNDJSONMethodClientStream) Recv() (*streamingresultndjsonservice.UserType, error) {
+	var body StreamingResultNDJSONMethodResponseBody
+	err := s.dec.Decode(&body)
+	if err == io.EOF {
+		s.body.Close()
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := NewStreamingResultNDJSONMethodUserTypeOK(&body)
+	return res, nil
+}
+`
+
+var StreamingResultNDJSONWithViewsClientEndpointCode = `// StreamingResultNDJSONWithViewsMethod returns an endpoint that makes HTTP
+// requests to the StreamingResultNDJSONWithViewsService service
+// StreamingResultNDJSONWithViewsMethod server.
+func (c *Client) StreamingResultNDJSONWithViewsMethod() goa.Endpoint {
+	var (
+		decodeResponse = DecodeStreamingResultNDJSONWithViewsMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamingResultNDJSONWithViewsMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.ApplyRequestOptions(ctx, req)
+		resp, err := c.StreamingResultNDJSONWithViewsMethodDoer.Do(req)
+		if err != nil {
+			return nil, goahttp.ErrRequestError("StreamingResultNDJSONWithViewsService", "StreamingResultNDJSONWithViewsMethod", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return decodeResponse(resp)
+		}
+		stream := &StreamingResultNDJSONWithViewsMethodClientStream{body: resp.Body, dec: json.NewDecoder(resp.Body)}
+		view := resp.Header.Get("goa-view")
+		stream.SetView(view)
+		return stream, nil
+	}
+}
+`
+
+var StreamingResultNDJSONWithViewsClientStreamRecvCode = `// Recv reads a streamingresultndjsonwithviewsservice.Usertype type from the
+// "StreamingResultNDJSONWithViewsMethod" endpoint newline delimited JSON HTTP
+// response.
+func (s *StreamingResultNDJSONWithViewsMethodClientStream) Recv() (*streamingresultndjsonwithviewsservice.Usertype, error) {
+	var body StreamingResultNDJSONWithViewsMethodResponseBody
+	err := s.dec.Decode(&body)
+	if err == io.EOF {
+		s.body.Close()
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := NewStreamingResultNDJSONWithViewsMethodUsertypeOK(&body)
+	vres := &streamingresultndjsonwithviewsserviceviews.Usertype{res, s.view}
+	if err := vres.Validate(); err != nil {
+		return nil, goahttp.ErrValidationError("StreamingResultNDJSONWithViewsService", "StreamingResultNDJSONWithViewsMethod", err)
+	}
+	return streamingresultndjsonwithviewsservice.NewUsertype(vres), nil
+}
+`
+
+var StreamingResultKeepAliveServerStructTypeCode = `// StreamingResultKeepAliveMethodServerStream implements the
+// streamingresultkeepaliveservice.StreamingResultKeepAliveMethodServerStream
+// interface.
+type StreamingResultKeepAliveMethodServerStream struct {
+	once sync.Once
+	// upgrader is the websocket connection upgrader.
+	upgrader goahttp.Upgrader
+	// connConfigFn is the websocket connection configurer.
+	connConfigFn goahttp.ConnConfigureFunc
+	// w is the HTTP response writer used in upgrading the connection.
+	w http.ResponseWriter
+	// r is the HTTP request.
+	r *http.Request
+	// conn is the underlying websocket connection.
+	conn *websocket.Conn
+	// stopKeepAlive stops sending pings over the websocket connection.
+	stopKeepAlive func()
+}
+`
+
+var StreamingResultKeepAliveServerStreamSendCode = `// Send sends streamingresultkeepaliveservice.UserType type to the
+// "StreamingResultKeepAliveMethod" endpoint websocket connection.
+func (s *StreamingResultKeepAliveMethodServerStream) Send(v *streamingresultkeepaliveservice.UserType) error {
+	var err error
+	// Upgrade the HTTP connection to a websocket connection only once before
+	// sending result. Connection upgrade is done here so that authorization logic
+	// in the endpoint is executed before calling the actual service method which
+	// may call Send().
+	s.once.Do(func() {
+		var conn *websocket.Conn
+		conn, err = s.upgrader.Upgrade(s.w, s.r, nil)
+		if err != nil {
+			return
+		}
+		if s.connConfigFn != nil {
+			conn = s.connConfigFn(conn)
+		}
+		s.conn = conn
+		s.stopKeepAlive = goahttp.KeepAlive(conn, 30*time.Second, time.Minute, 10*time.Second)
+	})
+	if err != nil {
+		s.Close()
+		return err
+	}
+	res := v
+	body := NewStreamingResultKeepAliveMethodResponseBody(res)
+	if err := s.conn.SetWriteDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		return err
+	}
+	return s.conn.WriteJSON(body)
+}
+`
+
+var StreamingResultKeepAliveServerStreamCloseCode = `// Close closes the "StreamingResultKeepAliveMethod" endpoint websocket
+// connection after sending a close control message.
+func (s *StreamingResultKeepAliveMethodServerStream) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	s.stopKeepAlive()
+	err := s.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, "end of message"),
+		time.Now().Add(time.Second),
+	)
+	if err == websocket.ErrCloseSent {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.conn.Close()
+}
+`
+
+var StreamingResultKeepAliveClientEndpointCode = `// StreamingResultKeepAliveMethod returns an endpoint that makes HTTP requests
+// to the StreamingResultKeepAliveService service
+// StreamingResultKeepAliveMethod server.
+func (c *Client) StreamingResultKeepAliveMethod() goa.Endpoint {
+	var (
+		decodeResponse = DecodeStreamingResultKeepAliveMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamingResultKeepAliveMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.ApplyRequestOptions(ctx, req)
+		conn, resp, err := c.dialer.Dial(req.URL.String(), req.Header)
+		if err != nil {
+			if resp != nil {
+				return decodeResponse(resp)
+			}
+			return nil, goahttp.ErrRequestError("StreamingResultKeepAliveService", "StreamingResultKeepAliveMethod", err)
+		}
+		if c.connConfigFn != nil {
+			conn = c.connConfigFn(conn)
+		}
+		goahttp.KeepAlive(conn, 30*time.Second, time.Minute, 10*time.Second)
+		stream := &StreamingResultKeepAliveMethodClientStream{conn: conn}
+		return stream, nil
+	}
+}
+`
+
+var StreamingResultCompressionServerHandlerInitCode = `// NewStreamingResultCompressionMethodHandler creates a HTTP handler which
+// loads the HTTP request and calls the "StreamingResultCompressionService"
+// service "StreamingResultCompressionMethod" endpoint.
+func NewStreamingResultCompressionMethodHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+	up goahttp.Upgrader,
+	connConfigFn goahttp.ConnConfigureFunc,
+) http.Handler {
+	var (
+		encodeError = goahttp.ErrorEncoder(enc)
+	)
+	up = goahttp.CompressionUpgrader(up)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "StreamingResultCompressionMethod")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "StreamingResultCompressionService")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
+
+		v := &streamingresultcompressionservice.StreamingResultCompressionMethodEndpointInput{
+			Stream: &StreamingResultCompressionMethodServerStream{
+				upgrader:     up,
+				connConfigFn: connConfigFn,
+				w:            w,
+				r:            r,
+			},
+		}
+		_, err := endpoint(ctx, v)
+
+		if err != nil {
+			if _, ok := err.(websocket.HandshakeError); ok {
+				return
+			}
+			if s := v.Stream.(*StreamingResultCompressionMethodServerStream); s.conn != nil {
+				// The connection was upgraded, report the error with a
+				// websocket close control message.
+				if err := s.closeWithError(err); err != nil {
+					eh(ctx, w, err)
+				}
+				return
+			}
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+	})
+}
+`
+
+var StreamingResultCompressionClientEndpointCode = `// StreamingResultCompressionMethod returns an endpoint that makes HTTP
+// requests to the StreamingResultCompressionService service
+// StreamingResultCompressionMethod server.
+// The endpoint negotiates the websocket permessage-deflate compression
+// extension when the client dialer is a *websocket.Dialer, other dialers must
+// enable compression themselves.
+func (c *Client) StreamingResultCompressionMethod() goa.Endpoint {
+	var (
+		decodeResponse = DecodeStreamingResultCompressionMethodResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		req, err := c.BuildStreamingResultCompressionMethodRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.ApplyRequestOptions(ctx, req)
+		conn, resp, err := goahttp.CompressionDialer(c.dialer).Dial(req.URL.String(), req.Header)
+		if err != nil {
+			if resp != nil {
+				return decodeResponse(resp)
+			}
+			return nil, goahttp.ErrRequestError("StreamingResultCompressionService", "StreamingResultCompressionMethod", err)
+		}
+		if c.connConfigFn != nil {
+			conn = c.connConfigFn(conn)
+		}
+		stream := &StreamingResultCompressionMethodClientStream{conn: conn}
+		return stream, nil
+	}
+}
+`
+
+var StreamingResultServerStreamCloseErrorCode = `// closeWithError closes the "StreamingResultMethod" endpoint websocket
+// connection after sending a close control message that reports err.
+func (s *StreamingResultMethodServerStream) closeWithError(err error) error {
+	resp := goahttp.NewErrorResponse(err)
+	status, name := resp.StatusCode(), resp.Name
+	werr := s.conn.WriteControl(
+		websocket.CloseMessage,
+		goahttp.FormatStreamError(status, name, err.Error()),
+		time.Now().Add(time.Second),
+	)
+	if werr != nil && werr != websocket.ErrCloseSent {
+		s.conn.Close()
+		return werr
+	}
+	return s.conn.Close()
+}
+`
+
+var StreamingResultErrorsServerStreamCloseErrorCode = `// closeWithError closes the "StreamingResultErrorsMethod" endpoint websocket
+// connection after sending a close control message that reports err.
+func (s *StreamingResultErrorsMethodServerStream) closeWithError(err error) error {
+	resp := goahttp.NewErrorResponse(err)
+	status, name := resp.StatusCode(), resp.Name
+	if en, ok := err.(ErrorNamer); ok {
+		switch en.ErrorName() {
+		case "not_found":
+			status, name = http.StatusNotFound, "not_found"
+		case "bad_request":
+			status, name = http.StatusBadRequest, "bad_request"
+		case "conflict":
+			status, name = http.StatusConflict, "conflict"
+		}
+	}
+	werr := s.conn.WriteControl(
+		websocket.CloseMessage,
+		goahttp.FormatStreamError(status, name, err.Error()),
+		time.Now().Add(time.Second),
+	)
+	if werr != nil && werr != websocket.ErrCloseSent {
+		s.conn.Close()
+		return werr
+	}
+	return s.conn.Close()
+}
+`
+
+var StreamingResultErrorsClientStreamRecvCode = `// Recv receives a streamingresulterrorsservice.UserType type from the
+// "StreamingResultErrorsMethod" endpoint websocket connection.
+func (s *StreamingResultErrorsMethodClientStream) Recv() (*streamingresulterrorsservice.UserType, error) {
+	var body StreamingResultErrorsMethodResponseBody
+	err := s.conn.ReadJSON(&body)
+	if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		return nil, io.EOF
+	}
+	if serr := goahttp.DecodeStreamError(err); serr != nil {
+		switch serr.Name {
+		case "not_found":
+			return nil, streamingresulterrorsservice.MakeNotFound(serr)
+		case "bad_request":
+			return nil, streamingresulterrorsservice.MakeBadRequest(serr)
+		}
+		return nil, serr
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := NewStreamingResultErrorsMethodUserTypeOK(&body)
+	return res, nil
+}
+`
+
+var StreamingResultResumeClientStructTypeCode = `// StreamingResultResumeMethodResumableClientStream implements the
+// streamingresultresumeservice.StreamingResultResumeMethodClientStream
+// interface. It re-dials the "StreamingResultResumeMethod" endpoint when the
+// websocket connection fails.
+type StreamingResultResumeMethodResumableClientStream struct {
+	// ctx is the context used to dial the endpoint.
+	ctx context.Context
+	// endpoint dials the endpoint.
+	endpoint goa.Endpoint
+	// payload is the payload used to dial the endpoint.
+	payload interface{}
+	// stream is the stream of the current websocket connection.
+	stream *StreamingResultResumeMethodClientStream
+	// token is the resume token of the last received result.
+	token string
+	// maxRetries is the maximum number of consecutive attempts to re-dial the
+	// endpoint.
+	maxRetries int
+	// retries is the number of consecutive attempts to re-dial the endpoint.
+	retries int
+}
+`
+
+var StreamingResultResumeClientEndpointCode = `// StreamingResultResumeMethodResumable returns an endpoint that makes HTTP
+// requests to the StreamingResultResumeService service
+// StreamingResultResumeMethod server. The stream returned by the endpoint
+// re-dials the server up to maxRetries consecutive times when the websocket
+// connection fails and sets the "Last-Event-ID" header to the resume token of
+// the last received result so that the server may resume the stream.
+func (c *Client) StreamingResultResumeMethodResumable(maxRetries int) goa.Endpoint {
+	endpoint := c.StreamingResultResumeMethod()
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		res, err := endpoint(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		return &StreamingResultResumeMethodResumableClientStream{
+			ctx:        ctx,
+			endpoint:   endpoint,
+			payload:    v,
+			stream:     res.(*StreamingResultResumeMethodClientStream),
+			maxRetries: maxRetries,
+		}, nil
+	}
+}
+`
+
+var StreamingResultResumeClientStreamRecvCode = `// Recv receives a streamingresultresumeservice.UserType type from the
+// "StreamingResultResumeMethod" endpoint websocket connection, it re-dials the
+// endpoint if the connection fails.
+func (s *StreamingResultResumeMethodResumableClientStream) Recv() (*streamingresultresumeservice.UserType, error) {
+	for {
+		res, err := s.stream.Recv()
+		if err == nil {
+			if res.ID != nil {
+				s.token = *res.ID
+			}
+			s.retries = 0
+			return res, nil
+		}
+		if !goahttp.IsTransientStreamError(err) {
+			return nil, err
+		}
+		s.stream.conn.Close()
+		if err := s.redial(err); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// redial re-dials the "StreamingResultResumeMethod" endpoint until the
+// connection succeeds or the maximum number of retries is reached.
+func (s *StreamingResultResumeMethodResumableClientStream) redial(err error) error {
+	for s.retries < s.maxRetries {
+		s.retries++
+		if err := goahttp.WaitRedial(s.ctx, s.retries); err != nil {
+			return err
+		}
+		ctx := s.ctx
+		if s.token != "" {
+			ctx = goahttp.WithRequestOptions(ctx, goahttp.WithHeader("Last-Event-ID", s.token))
+		}
+		var res interface{}
+		res, err = s.endpoint(ctx, s.payload)
+		if err == nil {
+			s.stream = res.(*StreamingResultResumeMethodClientStream)
+			return nil
+		}
+		if !goahttp.IsTransientStreamError(err) {
+			return err
+		}
+	}
+	return err
+}
+`
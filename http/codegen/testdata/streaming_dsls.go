@@ -1,6 +1,8 @@
 package testdata
 
 import (
+	"time"
+
 	. "goa.design/goa/http/design"
 	. "goa.design/goa/http/dsl"
 )
@@ -43,6 +45,43 @@ var StreamingResultWithViewsDSL = func() {
 	})
 }
 
+var StreamingResultNDJSONDSL = func() {
+	var Request = Type("Request", func() {
+		Attribute("x", String)
+	})
+	var Result = Type("UserType", func() {
+		Attribute("a", String)
+	})
+	Service("StreamingResultNDJSONService", func() {
+		Method("StreamingResultNDJSONMethod", func() {
+			Payload(Request)
+			StreamingResult(Result)
+			HTTP(func() {
+				GET("/")
+				Param("x")
+				NDJSONStream()
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var StreamingResultNDJSONWithViewsDSL = func() {
+	var Result = ResultType("UserType", func() {
+		Attribute("a", String)
+	})
+	Service("StreamingResultNDJSONWithViewsService", func() {
+		Method("StreamingResultNDJSONWithViewsMethod", func() {
+			StreamingResult(Result)
+			HTTP(func() {
+				GET("/")
+				NDJSONStream()
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
 var StreamingResultNoPayloadDSL = func() {
 	var Result = Type("UserType", func() {
 		Attribute("a", String)
@@ -57,3 +96,96 @@ var StreamingResultNoPayloadDSL = func() {
 		})
 	})
 }
+
+var StreamingResultBinaryDSL = func() {
+	var Request = Type("Request", func() {
+		Attribute("x", String)
+	})
+	var Result = Type("UserType", func() {
+		Attribute("a", String)
+	})
+	Service("StreamingResultBinaryService", func() {
+		Method("StreamingResultBinaryMethod", func() {
+			Payload(Request)
+			StreamingResult(Result)
+			HTTP(func() {
+				GET("/")
+				BinaryStream()
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var StreamingResultKeepAliveDSL = func() {
+	var Result = Type("UserType", func() {
+		Attribute("a", String)
+	})
+	Service("StreamingResultKeepAliveService", func() {
+		Method("StreamingResultKeepAliveMethod", func() {
+			StreamingResult(Result)
+			HTTP(func() {
+				GET("/")
+				StreamKeepAlive(30*time.Second, time.Minute, 10*time.Second)
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var StreamingResultCompressionDSL = func() {
+	var Result = Type("UserType", func() {
+		Attribute("a", String)
+	})
+	Service("StreamingResultCompressionService", func() {
+		Method("StreamingResultCompressionMethod", func() {
+			StreamingResult(Result)
+			HTTP(func() {
+				GET("/")
+				StreamCompression()
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var StreamingResultErrorsDSL = func() {
+	var Result = Type("UserType", func() {
+		Attribute("a", String)
+	})
+	var Conflict = Type("Conflict", func() {
+		Attribute("reason", String)
+	})
+	Service("StreamingResultErrorsService", func() {
+		Method("StreamingResultErrorsMethod", func() {
+			StreamingResult(Result)
+			Error("not_found")
+			Error("bad_request")
+			Error("conflict", Conflict)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+				Response("not_found", StatusNotFound)
+				Response("bad_request", StatusBadRequest)
+				Response("conflict", StatusConflict)
+			})
+		})
+	})
+}
+
+var StreamingResultResumeDSL = func() {
+	var Result = Type("UserType", func() {
+		Attribute("id", String)
+		Attribute("a", String)
+	})
+	Service("StreamingResultResumeService", func() {
+		Method("StreamingResultResumeMethod", func() {
+			StreamingResult(Result)
+			HTTP(func() {
+				GET("/")
+				StreamResume("id")
+				Response(StatusOK)
+			})
+		})
+	})
+}
@@ -0,0 +1,180 @@
+package testdata
+
+var TimeoutServerHandlerInitCode = `// NewMethodTimeoutHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceTimeout" service "MethodTimeout" endpoint.
+func NewMethodTimeoutHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodTimeoutRequest(mux, dec)
+		encodeResponse = EncodeMethodTimeoutResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodTimeout")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceTimeout")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		payload, err := decodeRequest(r)
+		if err != nil {
+			eh(ctx, w, err)
+			return
+		}
+
+		res, err := endpoint(ctx, payload)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var TimeoutClientEndpointCode = `// MethodTimeout returns an endpoint that makes HTTP requests to the
+// ServiceTimeout service MethodTimeout server.
+func (c *Client) MethodTimeout() goa.Endpoint {
+	var (
+		decodeResponse = DecodeMethodTimeoutResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		ctx, cancel := goahttp.ContextWithRequestTimeout(ctx)
+		defer cancel()
+		if _, ok := ctx.Deadline(); !ok {
+			ctx, cancel = context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+		}
+		req, err := c.BuildMethodTimeoutRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.ApplyRequestOptions(ctx, req)
+		resp, err := c.MethodTimeoutDoer.Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceTimeout", "MethodTimeout", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
+
+var TimeoutParseCode = `// ParseEndpoint returns the endpoint and payload as specified on the command
+// line.
+func ParseEndpoint(
+	scheme, host string,
+	doer goahttp.Doer,
+	enc func(*http.Request) goahttp.Encoder,
+	dec func(*http.Response) goahttp.Decoder,
+	restore bool,
+) (goa.Endpoint, interface{}, error) {
+	var (
+		serviceTimeoutFlags = flag.NewFlagSet("service-timeout", flag.ContinueOnError)
+
+		serviceTimeoutMethodTimeoutFlags       = flag.NewFlagSet("method-timeout", flag.ExitOnError)
+		serviceTimeoutMethodTimeoutIDFlag      = serviceTimeoutMethodTimeoutFlags.String("id", "", "")
+		serviceTimeoutMethodTimeoutTimeoutFlag = serviceTimeoutMethodTimeoutFlags.String("timeout", "5s", "request timeout")
+	)
+	serviceTimeoutFlags.Usage = serviceTimeoutUsage
+	serviceTimeoutMethodTimeoutFlags.Usage = serviceTimeoutMethodTimeoutUsage
+
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		return nil, nil, err
+	}
+
+	if len(os.Args) < flag.NFlag()+3 {
+		return nil, nil, fmt.Errorf("not enough arguments")
+	}
+
+	var (
+		svcn string
+		svcf *flag.FlagSet
+	)
+	{
+		svcn = os.Args[1+flag.NFlag()]
+		switch svcn {
+		case "service-timeout":
+			svcf = serviceTimeoutFlags
+		default:
+			return nil, nil, fmt.Errorf("unknown service %q", svcn)
+		}
+	}
+	if err := svcf.Parse(os.Args[2+flag.NFlag():]); err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		epn string
+		epf *flag.FlagSet
+	)
+	{
+		epn = os.Args[2+flag.NFlag()+svcf.NFlag()]
+		switch svcn {
+		case "service-timeout":
+			switch epn {
+			case "method-timeout":
+				epf = serviceTimeoutMethodTimeoutFlags
+
+			}
+
+		}
+	}
+	if epf == nil {
+		return nil, nil, fmt.Errorf("unknown %q endpoint %q", svcn, epn)
+	}
+
+	// Parse endpoint flags if any
+	if len(os.Args) > 2+flag.NFlag()+svcf.NFlag() {
+		if err := epf.Parse(os.Args[3+flag.NFlag()+svcf.NFlag():]); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := bindFlags(epf, svcn, epn); err != nil {
+		return nil, nil, err
+	}
+	if Interactive {
+		if err := promptFlags(epf, svcn, epn); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var (
+		data     interface{}
+		endpoint goa.Endpoint
+		err      error
+	)
+	{
+		switch svcn {
+		case "service-timeout":
+			c := servicetimeoutc.NewClient(scheme, host, doer, enc, dec, restore)
+			switch epn {
+			case "method-timeout":
+				endpoint = c.MethodTimeout()
+				var timeout time.Duration
+				timeout, err = time.ParseDuration(*serviceTimeoutMethodTimeoutTimeoutFlag)
+				if err != nil {
+					return nil, nil, fmt.Errorf("invalid value for serviceTimeoutMethodTimeoutTimeoutFlag, must be DURATION")
+				}
+				endpoint = goa.TimeoutEndpoint(endpoint, timeout)
+				data, err = servicetimeoutc.BuildMethodTimeoutPayload(*serviceTimeoutMethodTimeoutIDFlag)
+			}
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return endpoint, data, nil
+}
+`
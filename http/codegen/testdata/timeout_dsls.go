@@ -0,0 +1,23 @@
+package testdata
+
+import (
+	"time"
+
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var TimeoutDSL = func() {
+	Service("ServiceTimeout", func() {
+		Method("MethodTimeout", func() {
+			Payload(func() {
+				Attribute("id", String)
+			})
+			Result(String)
+			Timeout(5 * time.Second)
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+	})
+}
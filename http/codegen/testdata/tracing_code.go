@@ -0,0 +1,80 @@
+package testdata
+
+var TracingServerHandlerInitCode = `// NewMethodTracingHandler creates a HTTP handler which loads the HTTP request
+// and calls the "ServiceTracing" service "MethodTracing" endpoint.
+func NewMethodTracingHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		decodeRequest  = DecodeMethodTracingRequest(mux, dec)
+		encodeResponse = EncodeMethodTracingResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodTracing")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceTracing")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
+		ctx, span := goaotel.StartServerSpan(ctx, r, "ServiceTracing.MethodTracing")
+		defer span.End()
+		payload, err := decodeRequest(r)
+		if err != nil {
+			goaotel.RecordError(span, err)
+			eh(ctx, w, err)
+			return
+		}
+		if p, ok := payload.(*servicetracing.MethodTracingPayload); ok {
+			goaotel.SetAttribute(span, "account.id", p.AccountID)
+			goaotel.SetAttribute(span, "count", p.Count)
+		}
+
+		res, err := endpoint(ctx, payload)
+
+		if err != nil {
+			goaotel.RecordError(span, err)
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var TracingClientEndpointCode = `// MethodTracing returns an endpoint that makes HTTP requests to the
+// ServiceTracing service MethodTracing server.
+func (c *Client) MethodTracing() goa.Endpoint {
+	var (
+		encodeRequest  = EncodeMethodTracingRequest(c.encoder)
+		decodeResponse = DecodeMethodTracingResponse(c.decoder, c.RestoreResponseBody)
+	)
+	return func(ctx context.Context, v interface{}) (_ interface{}, err error) {
+		ctx, span := goaotel.StartClientSpan(ctx, "ServiceTracing.MethodTracing")
+		defer func() { goaotel.EndSpan(span, err) }()
+		ctx, cancel := goahttp.ContextWithRequestTimeout(ctx)
+		defer cancel()
+		req, err := c.BuildMethodTracingRequest(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		err = encodeRequest(req, v)
+		if err != nil {
+			return nil, err
+		}
+		goahttp.ApplyRequestOptions(ctx, req)
+		resp, err := c.MethodTracingDoer.Do(req)
+
+		if err != nil {
+			return nil, goahttp.ErrRequestError("ServiceTracing", "MethodTracing", err)
+		}
+		return decodeResponse(resp)
+	}
+}
+`
@@ -0,0 +1,29 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var TracingDSL = func() {
+	API("Tracing", func() {
+		Metadata("http:trace:otel")
+	})
+	Service("ServiceTracing", func() {
+		Method("MethodTracing", func() {
+			Payload(func() {
+				Attribute("account_id", String, func() {
+					Metadata("http:trace:attribute", "account.id")
+				})
+				Attribute("count", Int, func() {
+					Metadata("http:trace:attribute")
+				})
+				Attribute("name", String)
+			})
+			Result(String)
+			HTTP(func() {
+				POST("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,35 @@
+package testdata
+
+const VersionHeaderRequestBuilderCode = `// BuildAddRequest instantiates a HTTP request object with method and path set
+// to call the "calc" service "add" endpoint
+func (c *Client) BuildAddRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: AddCalcPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("calc", "add", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+	req.Header.Set("API-Version", "v2")
+
+	return req, nil
+}
+`
+
+const VersionMediaTypeRequestBuilderCode = `// BuildAddRequest instantiates a HTTP request object with method and path set
+// to call the "calc" service "add" endpoint
+func (c *Client) BuildAddRequest(ctx context.Context, v interface{}) (*http.Request, error) {
+	u := &url.URL{Scheme: c.scheme, Host: c.host, Path: AddCalcPath()}
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, goahttp.ErrInvalidURL("calc", "add", u.String(), err)
+	}
+	if ctx != nil {
+		req = req.WithContext(ctx)
+	}
+	req.Header.Set("Accept", "application/vnd.calc.v2+json")
+
+	return req, nil
+}
+`
@@ -0,0 +1,78 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var VersionedServicesDSL = func() {
+	var Total = Type("Total", func() {
+		Attribute("value", Int)
+	})
+	var Sum = Type("Sum", func() {
+		Attribute("value", Int64)
+		Attribute("overflow", Boolean)
+	})
+	API("calc", func() {
+		Version("2.1")
+	})
+	Service("calc", func() {
+		Version("v1")
+		HTTP(func() {
+			Path("/calc")
+		})
+		Method("add", func() {
+			Result(Total)
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+	Service("calc_v2", func() {
+		Version("v2")
+		HTTP(func() {
+			Path("/calc")
+		})
+		Method("add", func() {
+			Result(Sum)
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+	Service("health", func() {
+		Method("check", func() {
+			HTTP(func() {
+				GET("/health")
+			})
+		})
+	})
+}
+
+var VersionHeaderDSL = func() {
+	Service("calc", func() {
+		Version("v2")
+		Metadata("version:strategy", "header")
+		Method("add", func() {
+			Payload(func() {
+				Attribute("a", Int)
+			})
+			HTTP(func() {
+				GET("/add")
+				Param("a")
+			})
+		})
+	})
+}
+
+var VersionMediaTypeDSL = func() {
+	Service("calc", func() {
+		Version("v2")
+		Metadata("version:strategy", "mediatype")
+		Method("add", func() {
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package testdata
+
+var ViewNegotiationServerHandlerInitCode = `// NewMethodViewNegotiationHandler creates a HTTP handler which loads the HTTP
+// request and calls the "ServiceViewNegotiation" service
+// "MethodViewNegotiation" endpoint.
+func NewMethodViewNegotiationHandler(
+	endpoint goa.Endpoint,
+	mux goahttp.Muxer,
+	dec func(*http.Request) goahttp.Decoder,
+	enc func(context.Context, http.ResponseWriter) goahttp.Encoder,
+	eh func(context.Context, http.ResponseWriter, error),
+) http.Handler {
+	var (
+		encodeResponse = EncodeMethodViewNegotiationResponse(enc)
+		encodeError    = goahttp.ErrorEncoder(enc)
+		views          = []string{"default", "tiny"}
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), goahttp.AcceptTypeKey, r.Header.Get("Accept"))
+		ctx = context.WithValue(ctx, goa.MethodKey, "MethodViewNegotiation")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "ServiceViewNegotiation")
+		defer goahttp.Recover(ctx, w, "fault", encodeError, eh)
+		viewCtx, viewErr := goahttp.NegotiateView(ctx, r, views)
+		if viewErr != nil {
+			if err := encodeError(ctx, w, viewErr); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		ctx = viewCtx
+
+		res, err := endpoint(ctx, nil)
+
+		if err != nil {
+			if err := encodeError(ctx, w, err); err != nil {
+				eh(ctx, w, err)
+			}
+			return
+		}
+		if err := encodeResponse(ctx, w, res); err != nil {
+			eh(ctx, w, err)
+		}
+	})
+}
+`
+
+var StreamingViewNegotiationServerStreamSetViewCode = `// SetView sets the view to render the streamingviewnegotiationservice.Book
+// type before sending to the "StreamingViewNegotiationMethod" endpoint
+// websocket connection.
+func (s *StreamingViewNegotiationMethodServerStream) SetView(view string) {
+	if v := goahttp.RequestedView(s.r); v != "" {
+		// The view requested by the client takes precedence.
+		view = v
+	}
+	s.view = view
+}
+`
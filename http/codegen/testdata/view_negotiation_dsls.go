@@ -0,0 +1,58 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var ViewNegotiationDSL = func() {
+	var Book = ResultType("application/vnd.goa.book", func() {
+		TypeName("Book")
+		Attributes(func() {
+			Attribute("id", Int)
+			Attribute("title", String)
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("title")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("ServiceViewNegotiation", func() {
+		Method("MethodViewNegotiation", func() {
+			Result(Book)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
+
+var StreamingViewNegotiationDSL = func() {
+	var Book = ResultType("application/vnd.goa.book", func() {
+		TypeName("Book")
+		Attributes(func() {
+			Attribute("id", Int)
+			Attribute("title", String)
+		})
+		View("default", func() {
+			Attribute("id")
+			Attribute("title")
+		})
+		View("tiny", func() {
+			Attribute("id")
+		})
+	})
+	Service("StreamingViewNegotiationService", func() {
+		Method("StreamingViewNegotiationMethod", func() {
+			StreamingResult(Book)
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK)
+			})
+		})
+	})
+}
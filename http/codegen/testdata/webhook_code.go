@@ -0,0 +1,62 @@
+package testdata
+
+var WebhookSenderStructCode = `// Sender sends the Orders service webhooks.
+type Sender struct {
+	sender *goahttp.WebhookSender
+}
+`
+
+var WebhookSenderInitCode = `// NewSender instantiates a sender of the Orders service webhooks that delivers
+// the requests with s.
+func NewSender(s *goahttp.WebhookSender) *Sender {
+	return &Sender{sender: s}
+}
+`
+
+var WebhookSendShippedCode = `// SendOrderShipped sends the "order_shipped" webhook registered with the
+// "Orders" service "subscribe" endpoint to url.
+// Sent when an order ships.
+func (s *Sender) SendOrderShipped(ctx context.Context, url string, body *Shipment) error {
+	if err := body.Validate(); err != nil {
+		return err
+	}
+	return s.sender.Send(ctx, url, "order_shipped", body)
+}
+`
+
+var WebhookSendCancelledCode = `// SendOrderCancelled sends the "order_cancelled" webhook registered with the
+// "Orders" service "subscribe" endpoint to url.
+func (s *Sender) SendOrderCancelled(ctx context.Context, url string, body *Cancellation) error {
+	return s.sender.Send(ctx, url, "order_cancelled", body)
+}
+`
+
+var WebhookShipmentTypeCode = `// Shipment is used to define the "Orders" service webhook request bodies.
+type Shipment struct {
+	ID    string  ` + "`" + `form:"id" json:"id" xml:"id"` + "`" + `
+	Items []*Item ` + "`" + `form:"items" json:"items" xml:"items"` + "`" + `
+}
+`
+
+var WebhookItemTypeCode = `// Item is used to define the "Orders" service webhook request bodies.
+type Item struct {
+	Sku      string ` + "`" + `form:"sku" json:"sku" xml:"sku"` + "`" + `
+	Quantity int    ` + "`" + `form:"quantity,omitempty" json:"quantity,omitempty" xml:"quantity,omitempty"` + "`" + `
+}
+`
+
+var WebhookShipmentValidateCode = `// Validate runs the validations defined on Shipment
+func (body *Shipment) Validate() (err error) {
+	if body.Items == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("items", "body"))
+	}
+	for _, e := range body.Items {
+		if e != nil {
+			if err2 := e.Validate(); err2 != nil {
+				err = goa.MergeErrors(err, err2)
+			}
+		}
+	}
+	return
+}
+`
@@ -0,0 +1,46 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var WebhookDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("sku", String, func() {
+			MinLength(1)
+		})
+		Attribute("quantity", Int, func() {
+			Default(1)
+		})
+		Required("sku")
+	})
+	var Shipment = Type("Shipment", func() {
+		Attribute("id", String)
+		Attribute("items", ArrayOf(Item))
+		Required("id", "items")
+	})
+	var Cancellation = Type("Cancellation", func() {
+		Attribute("reason", String)
+		Attribute("item", Item)
+	})
+	Service("Orders", func() {
+		Method("subscribe", func() {
+			Payload(func() {
+				Attribute("callback_url", String)
+				Attribute("cancel_url", String)
+			})
+			HTTP(func() {
+				POST("/subscriptions")
+				Header("cancel_url:X-Cancel-URL")
+				Webhook("order_shipped", Shipment, func() {
+					Description("Sent when an order ships.")
+					CallbackURL("callback_url")
+				})
+				Webhook("order_cancelled", Cancellation, func() {
+					CallbackURL("cancel_url")
+				})
+			})
+		})
+	})
+}
@@ -11,8 +11,8 @@ import (
 // RunHTTPDSL returns the HTTP DSL root resulting from running the given DSL.
 func RunHTTPDSL(t *testing.T, dsl func()) *httpdesign.RootExpr {
 	// reset all roots and codegen data structures
-	service.Services = make(service.ServicesData)
-	HTTPServices = make(ServicesData)
+	service.Services = service.NewServicesData()
+	HTTPServices = NewServicesData()
 	return httpdesign.RunHTTPDSL(t, dsl)
 }
 
@@ -0,0 +1,39 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestServerTimeout(t *testing.T) {
+	cases := []*testCase{
+		{"timeout", testdata.TimeoutDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.TimeoutServerHandlerInitCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestClientTimeout(t *testing.T) {
+	cases := []*testCase{
+		{"timeout", testdata.TimeoutDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.TimeoutClientEndpointCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestClientCLITimeout(t *testing.T) {
+	cases := []*testCase{
+		{"timeout", testdata.TimeoutDSL, []*sectionExpectation{
+			{"parse-endpoint", &testdata.TimeoutParseCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientCLIFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
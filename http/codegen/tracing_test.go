@@ -0,0 +1,29 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestServerTracing(t *testing.T) {
+	cases := []*testCase{
+		{"tracing", testdata.TracingDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.TracingServerHandlerInitCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
+
+func TestClientTracing(t *testing.T) {
+	cases := []*testCase{
+		{"tracing", testdata.TracingDSL, []*sectionExpectation{
+			{"client-endpoint-init", &testdata.TracingClientEndpointCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ClientFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
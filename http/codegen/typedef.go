@@ -49,6 +49,9 @@ func goTypeDef(scope *codegen.NameScope, att *design.AttributeExpr, ptr, useDefa
 		ma := design.NewMappedAttributeExpr(att)
 		mat := ma.Attribute()
 		codegen.WalkMappedAttr(ma, func(name, elem string, required bool, at *design.AttributeExpr) error {
+			if elem == name {
+				elem = codegen.WireName(name)
+			}
 			var (
 				fn   string
 				tdef string
@@ -82,14 +85,17 @@ func goTypeDef(scope *codegen.NameScope, att *design.AttributeExpr, ptr, useDefa
 	}
 }
 
-// attributeTags computes the struct field tags.
+// attributeTags computes the struct field tags. The tags set with the
+// "struct:tag:xxx" metadata are added to the form, json and xml tags and
+// override them if they have the same name.
 func attributeTags(parent, att *design.AttributeExpr, t string, optional bool) string {
-	if tags := codegen.AttributeTags(parent, att); tags != "" {
-		return tags
-	}
 	var o string
 	if optional {
 		o = ",omitempty"
 	}
-	return fmt.Sprintf(" `form:\"%s%s\" json:\"%s%s\" xml:\"%s%s\"`", t, o, t, o, t, o)
+	tags := map[string]string{"form": t + o, "json": t + o, "xml": t + o}
+	for name, value := range codegen.StructTags(att) {
+		tags[name] = value
+	}
+	return codegen.FormatTags(tags)
 }
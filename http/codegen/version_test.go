@@ -0,0 +1,137 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"text/template"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/openapi"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestVersionOpenAPIFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.VersionedServicesDSL)
+	oFiles, err := OpenAPIFiles(httpdesign.Root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	paths := []string{"openapi.json", "openapi.yaml", "v1/openapi.json", "v1/openapi.yaml", "v2/openapi.json", "v2/openapi.yaml"}
+	if len(oFiles) != len(paths) {
+		t.Fatalf("got %d files, expected %d", len(oFiles), len(paths))
+	}
+	for i, p := range paths {
+		if expected := filepath.Join("gen", "http", filepath.FromSlash(p)); oFiles[i].Path != expected {
+			t.Errorf("got path %q, expected %q", oFiles[i].Path, expected)
+		}
+	}
+	cases := []struct {
+		Name        string
+		File        *codegen.File
+		Version     string
+		Paths       []string
+		Definitions []string
+	}{
+		{"all", oFiles[0], "2.1", []string{"/health", "/v1/calc/add", "/v2/calc/add"}, nil},
+		{"v1", oFiles[2], "v1", []string{"/v1/calc/add"}, []string{"Total"}},
+		{"v2", oFiles[4], "v2", []string{"/v2/calc/add"}, []string{"Sum"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var spec struct {
+				Info struct {
+					Version string
+				}
+				Paths       map[string]interface{}
+				Definitions map[string]interface{}
+			}
+			if err := json.Unmarshal([]byte(renderFile(t, c.File)), &spec); err != nil {
+				t.Fatalf("failed to unmarshal spec: %s", err)
+			}
+			if spec.Info.Version != c.Version {
+				t.Errorf("got version %q, expected %q", spec.Info.Version, c.Version)
+			}
+			if ps := sortedKeys(spec.Paths); !reflect.DeepEqual(ps, c.Paths) {
+				t.Errorf("got paths %v, expected %v", ps, c.Paths)
+			}
+			if ds := sortedKeys(spec.Definitions); c.Definitions != nil && !reflect.DeepEqual(ds, c.Definitions) {
+				t.Errorf("got definitions %v, expected %v", ds, c.Definitions)
+			}
+		})
+	}
+}
+
+func TestVersionOpenAPIParams(t *testing.T) {
+	cases := []struct {
+		Name     string
+		DSL      func()
+		Params   string
+		Produces string
+	}{
+		{"header", testdata.VersionHeaderDSL, `[{"name":"a","in":"query","required":false,"type":"integer"},{"name":"API-Version","in":"header","description":"Version of the service.","required":true,"type":"string","enum":["v2"]}]`, ""},
+		{"mediatype", testdata.VersionMediaTypeDSL, "", `["application/vnd.calc.v2+json"]`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			spec, err := openapi.NewV2(httpdesign.Root)
+			if err != nil {
+				t.Fatalf("OpenAPI failed with %s", err)
+			}
+			op := spec.Paths["/v2/add"]
+			if op != nil {
+				t.Fatalf("unexpected versioned path for %s strategy", c.Name)
+			}
+			get := spec.Paths["/add"].(*openapi.Path).Get
+			if params, _ := json.Marshal(get.Parameters); c.Params != "" && string(params) != c.Params {
+				t.Errorf("got parameters %s, expected %s", params, c.Params)
+			}
+			if produces, _ := json.Marshal(get.Produces); c.Produces != "" && string(produces) != c.Produces {
+				t.Errorf("got produces %s, expected %s", produces, c.Produces)
+			}
+		})
+	}
+}
+
+func TestClientVersionHeader(t *testing.T) {
+	cases := []struct {
+		Name string
+		DSL  func()
+		Code string
+	}{
+		{"header", testdata.VersionHeaderDSL, testdata.VersionHeaderRequestBuilderCode},
+		{"mediatype", testdata.VersionMediaTypeDSL, testdata.VersionMediaTypeRequestBuilderCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunHTTPDSL(t, c.DSL)
+			fs := ClientFiles("", httpdesign.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected two", len(fs))
+			}
+			sections := fs[1].Section("request-builder")
+			if len(sections) != 1 {
+				t.Fatalf("got %d request builder sections, expected one", len(sections))
+			}
+			code := codegen.SectionCode(t, sections[0])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+// renderFile renders the single section of the given non Go file.
+func renderFile(t *testing.T, f *codegen.File) string {
+	s := f.SectionTemplates[0]
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New(s.Name).Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	return buf.String()
+}
@@ -0,0 +1,22 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestServerViewNegotiation(t *testing.T) {
+	cases := []*testCase{
+		{"view-negotiation", testdata.ViewNegotiationDSL, []*sectionExpectation{
+			{"server-handler-init", &testdata.ViewNegotiationServerHandlerInitCode},
+		}},
+		{"streaming-view-negotiation", testdata.StreamingViewNegotiationDSL, []*sectionExpectation{
+			{"server-stream-set-view", &testdata.StreamingViewNegotiationServerStreamSetViewCode},
+		}},
+	}
+	filesFn := func() []*codegen.File { return ServerFiles("", httpdesign.Root) }
+	runTests(t, cases, filesFn)
+}
@@ -0,0 +1,175 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+	httpdesign "goa.design/goa/http/design"
+)
+
+type (
+	// WebhookSenderData contains the data used to render the sender of the
+	// webhooks of a service.
+	WebhookSenderData struct {
+		// ServiceName is the name of the service.
+		ServiceName string
+		// Webhooks lists the service webhooks.
+		Webhooks []*WebhookData
+		// Types lists the webhook request body types.
+		Types []*TypeData
+	}
+
+	// WebhookData contains the data used to render the method that sends a
+	// webhook.
+	WebhookData struct {
+		// Name is the name of the webhook.
+		Name string
+		// Description is the description of the send method.
+		Description string
+		// SendName is the name of the send method.
+		SendName string
+		// BodyRef is the reference to the request body type.
+		BodyRef string
+		// Validate is true if the request body type defines a Validate
+		// method.
+		Validate bool
+	}
+)
+
+// WebhookFiles returns one file per service that declares webhooks. The files
+// define a sender with one method per webhook that validates the request body
+// and sends it using the goa http package WebhookSender.
+func WebhookFiles(genpkg string, root *httpdesign.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.HTTPServices {
+		if f := webhookFile(svc); f != nil {
+			fw = append(fw, f)
+		}
+	}
+	return fw
+}
+
+// webhookFile returns the file defining the sender of the given service
+// webhooks, nil if the service does not declare webhooks.
+func webhookFile(svc *httpdesign.ServiceExpr) *codegen.File {
+	data := webhookSenderData(svc)
+	if data == nil {
+		return nil
+	}
+	path := filepath.Join(codegen.ServiceGendir(svc.ServiceExpr), "http", codegen.SnakeCase(svc.Name()), "webhook", "webhook.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name()+" HTTP webhook sender", "webhook", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "unicode/utf8"},
+			{Path: "goa.design/goa", Name: "goa"},
+			{Path: "goa.design/goa/http", Name: "goahttp"},
+		}),
+		{Name: "webhook-sender-struct", Source: webhookSenderStructT, Data: data},
+		{Name: "webhook-sender-init", Source: webhookSenderInitT, Data: data},
+	}
+	for _, w := range data.Webhooks {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "webhook-send",
+			Source: webhookSendT,
+			Data:   w,
+		})
+	}
+	for _, t := range data.Types {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "webhook-type-decl",
+			Source: typeDeclT,
+			Data:   t,
+		})
+	}
+	for _, t := range data.Types {
+		if t.ValidateDef != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "webhook-validate",
+				Source: validateT,
+				Data:   t,
+			})
+		}
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// webhookSenderData builds the data needed to render the sender of the given
+// service webhooks, nil if the service does not declare webhooks. The request
+// body types are generated in the webhook package from the webhook payload
+// user types, they use values for the required fields and the fields with
+// default values.
+func webhookSenderData(svc *httpdesign.ServiceExpr) *WebhookSenderData {
+	var (
+		data  *WebhookSenderData
+		scope = codegen.NewNameScope()
+		types = make(map[string]*TypeData)
+	)
+	for _, e := range svc.HTTPEndpoints {
+		for _, w := range e.Webhooks {
+			if data == nil {
+				data = &WebhookSenderData{ServiceName: svc.Name()}
+			}
+			ut := w.Payload.Type.(design.UserType)
+			collectUserTypes(ut, func(ut design.UserType) {
+				if _, ok := types[ut.Name()]; ok {
+					return
+				}
+				att := &design.AttributeExpr{Type: ut}
+				name := scope.GoTypeName(att)
+				td := &TypeData{
+					Name:        ut.Name(),
+					VarName:     name,
+					Description: fmt.Sprintf("%s is used to define the %q service webhook request bodies.", name, svc.Name()),
+					Def:         goTypeDef(scope, ut.Attribute(), false, true),
+					Ref:         scope.GoTypeRef(att),
+					ValidateDef: codegen.RecursiveValidationCode(ut.Attribute(), true, false, true, "body"),
+				}
+				types[ut.Name()] = td
+				data.Types = append(data.Types, td)
+			})
+			var (
+				send = "Send" + codegen.Goify(w.Name, true)
+				desc = fmt.Sprintf("%s sends the %q webhook registered with the %q service %q endpoint to url.", send, w.Name, svc.Name(), e.Name())
+			)
+			if w.Description != "" {
+				desc += "\n" + w.Description
+			}
+			data.Webhooks = append(data.Webhooks, &WebhookData{
+				Name:        w.Name,
+				Description: desc,
+				SendName:    send,
+				BodyRef:     scope.GoTypeRef(w.Payload),
+				Validate:    types[ut.Name()].ValidateDef != "",
+			})
+		}
+	}
+	return data
+}
+
+// input: WebhookSenderData
+const webhookSenderStructT = `{{ printf "Sender sends the %s service webhooks." .ServiceName | comment }}
+type Sender struct {
+	sender *goahttp.WebhookSender
+}
+`
+
+// input: WebhookSenderData
+const webhookSenderInitT = `{{ printf "NewSender instantiates a sender of the %s service webhooks that delivers the requests with s." .ServiceName | comment }}
+func NewSender(s *goahttp.WebhookSender) *Sender {
+	return &Sender{sender: s}
+}
+`
+
+// input: WebhookData
+const webhookSendT = `{{ comment .Description }}
+func (s *Sender) {{ .SendName }}(ctx context.Context, url string, body {{ .BodyRef }}) error {
+{{- if .Validate }}
+	if err := body.Validate(); err != nil {
+		return err
+	}
+{{- end }}
+	return s.sender.Send(ctx, url, {{ printf "%q" .Name }}, body)
+}
+`
@@ -0,0 +1,118 @@
+package codegen
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"text/template"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/http/codegen/testdata"
+	httpdesign "goa.design/goa/http/design"
+)
+
+func TestWebhookFiles(t *testing.T) {
+	RunHTTPDSL(t, testdata.WebhookDSL)
+	fs := WebhookFiles("goa.design/goa/gen", httpdesign.Root)
+	if len(fs) != 1 {
+		t.Fatalf("got %d files, expected 1", len(fs))
+	}
+	f := fs[0]
+	if path := filepath.Join("gen", "http", "orders", "webhook", "webhook.go"); f.Path != path {
+		t.Errorf("got path %q, expected %q", f.Path, path)
+	}
+	cases := []struct {
+		Name  string
+		Index int
+		Code  string
+	}{
+		{"webhook-sender-struct", 0, testdata.WebhookSenderStructCode},
+		{"webhook-sender-init", 0, testdata.WebhookSenderInitCode},
+		{"webhook-send", 0, testdata.WebhookSendShippedCode},
+		{"webhook-send", 1, testdata.WebhookSendCancelledCode},
+		{"webhook-type-decl", 0, testdata.WebhookShipmentTypeCode},
+		{"webhook-type-decl", 1, testdata.WebhookItemTypeCode},
+		{"webhook-validate", 0, testdata.WebhookShipmentValidateCode},
+	}
+	for _, c := range cases {
+		sections := f.Section(c.Name)
+		if len(sections) <= c.Index {
+			t.Errorf("got %d %s sections, expected at least %d", len(sections), c.Name, c.Index+1)
+			continue
+		}
+		code := codegen.SectionCode(t, sections[c.Index])
+		if code != c.Code {
+			t.Errorf("invalid code for %s section %d, got:\n%s\ngot vs. expected:\n%s", c.Name, c.Index, code, codegen.Diff(t, code, c.Code))
+		}
+	}
+	if n := len(f.Section("webhook-type-decl")); n != 3 {
+		t.Errorf("got %d type sections, expected 3", n)
+	}
+}
+
+func TestWebhookFilesNoWebhook(t *testing.T) {
+	RunHTTPDSL(t, testdata.RateLimitDSL)
+	if fs := WebhookFiles("goa.design/goa/gen", httpdesign.Root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
+
+func TestWebhookCallbacks(t *testing.T) {
+	RunHTTPDSL(t, testdata.WebhookDSL)
+	oFiles, err := OpenAPIFiles(httpdesign.Root)
+	if err != nil {
+		t.Fatalf("OpenAPI failed with %s", err)
+	}
+	s := oFiles[0].SectionTemplates[0]
+	var buf bytes.Buffer
+	tmpl := template.Must(template.New("openapi").Funcs(s.FuncMap).Parse(s.Source))
+	if err := tmpl.Execute(&buf, s.Data); err != nil {
+		t.Fatalf("failed to render template: %s", err)
+	}
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Callbacks map[string]map[string]struct {
+				Post struct {
+					Description string
+					Parameters  []struct {
+						Name   string
+						In     string
+						Schema struct {
+							Ref string `json:"$ref"`
+						}
+					}
+				}
+			} `json:"x-callbacks"`
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &spec); err != nil {
+		t.Fatalf("failed to unmarshal spec: %s", err)
+	}
+	cbs := spec.Paths["/subscriptions"]["post"].Callbacks
+	cases := []struct {
+		Name        string
+		Expr        string
+		Description string
+		Ref         string
+	}{
+		{"order_shipped", "{$request.body#/callback_url}", "Sent when an order ships.", "#/definitions/Shipment"},
+		{"order_cancelled", "{$request.header.X-Cancel-URL}", "", "#/definitions/Cancellation"},
+	}
+	for _, c := range cases {
+		cb, ok := cbs[c.Name][c.Expr]
+		if !ok {
+			t.Errorf("callback %q with expression %q not found, got %v", c.Name, c.Expr, cbs[c.Name])
+			continue
+		}
+		if cb.Post.Description != c.Description {
+			t.Errorf("%s: got description %q, expected %q", c.Name, cb.Post.Description, c.Description)
+		}
+		if len(cb.Post.Parameters) != 2 {
+			t.Fatalf("%s: got %d parameters, expected 2", c.Name, len(cb.Post.Parameters))
+		}
+		if p := cb.Post.Parameters[1]; p.In != "body" || p.Schema.Ref != c.Ref {
+			t.Errorf("%s: got body parameter in %q with schema %q, expected body with %q", c.Name, p.In, p.Schema.Ref, c.Ref)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package http
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type (
+	// compressWriter is the response writer used by Compress. It buffers
+	// the response body until it is known whether the body gets compressed,
+	// that is until the body reaches the minimum size or the handler
+	// returns.
+	compressWriter struct {
+		http.ResponseWriter
+		// encoding is the negotiated content encoding.
+		encoding string
+		// minSize is the minimum size of compressed bodies.
+		minSize int
+		// status is the response status code set by the handler.
+		status int
+		// buf contains the body written so far.
+		buf []byte
+		// cw writes the compressed body once compression starts.
+		cw compressor
+		// wroteHeader is true once the response header was written.
+		wroteHeader bool
+	}
+
+	// compressor is the interface implemented by the gzip and zlib writers.
+	compressor interface {
+		io.WriteCloser
+		Flush() error
+	}
+
+	// decompressBody reads the decompressed response body and closes both
+	// the decompressor and the underlying body.
+	decompressBody struct {
+		io.ReadCloser
+		body io.Closer
+	}
+)
+
+// Compress returns a handler that compresses the JSON response bodies written
+// by h using the content encoding negotiated with the client via the request
+// Accept-Encoding header. gzip is preferred over deflate. Only the bodies whose
+// content type is application/json or has the +json suffix are compressed,
+// other bodies such as MessagePack or CBOR bodies are written as is. Bodies
+// smaller than minSize bytes are written uncompressed. Compress always sets the Vary
+// response header to Accept-Encoding so that caches store the encoded and
+// unencoded responses separately.
+func Compress(h http.Handler, minSize int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		enc := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if enc == "" || r.Method == "HEAD" || r.Header.Get("Upgrade") != "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, encoding: enc, minSize: minSize}
+		defer cw.Close()
+		h.ServeHTTP(cw, r)
+	})
+}
+
+// DecompressResponse replaces the body of resp with a reader that decompresses
+// it if the response Content-Encoding header is gzip or deflate. It is a no-op
+// for responses that are not compressed.
+func DecompressResponse(resp *http.Response) error {
+	var (
+		r   io.ReadCloser
+		err error
+	)
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		r, err = gzip.NewReader(resp.Body)
+	case "deflate":
+		r, err = zlib.NewReader(resp.Body)
+	default:
+		return nil
+	}
+	switch {
+	case err == io.EOF:
+		// Empty body
+		resp.Body.Close()
+		resp.Body = http.NoBody
+	case err != nil:
+		return err
+	default:
+		resp.Body = &decompressBody{ReadCloser: r, body: resp.Body}
+	}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// WriteHeader records the status code, the header is written once it is known
+// whether the body gets compressed.
+func (c *compressWriter) WriteHeader(status int) {
+	if c.status == 0 {
+		c.status = status
+	}
+}
+
+// Write buffers b until the body reaches the minimum size and compresses the
+// body from then on.
+func (c *compressWriter) Write(b []byte) (int, error) {
+	if c.cw != nil {
+		return c.cw.Write(b)
+	}
+	if c.wroteHeader {
+		return c.ResponseWriter.Write(b)
+	}
+	if !c.compressible() {
+		c.writeHeader()
+		return c.ResponseWriter.Write(b)
+	}
+	c.buf = append(c.buf, b...)
+	if len(c.buf) >= c.minSize {
+		if err := c.start(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush writes the buffered body and flushes the underlying writer. Bodies
+// that are flushed before reaching the minimum size are sent uncompressed.
+func (c *compressWriter) Flush() {
+	if c.cw != nil {
+		c.cw.Flush()
+	} else if !c.wroteHeader {
+		c.writeHeader()
+		c.ResponseWriter.Write(c.buf)
+		c.buf = nil
+	}
+	if f, ok := c.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Close writes the buffered body if the minimum size was not reached or
+// flushes the compressed body otherwise.
+func (c *compressWriter) Close() error {
+	if c.cw != nil {
+		return c.cw.Close()
+	}
+	if !c.wroteHeader {
+		c.writeHeader()
+		if len(c.buf) > 0 {
+			_, err := c.ResponseWriter.Write(c.buf)
+			return err
+		}
+	}
+	return nil
+}
+
+// compressible returns true if the response body may be compressed: the
+// content type must be JSON and the body must not be encoded already.
+func (c *compressWriter) compressible() bool {
+	h := c.Header()
+	if h.Get("Content-Encoding") != "" {
+		return false
+	}
+	switch c.status {
+	case http.StatusNoContent, http.StatusNotModified, http.StatusPartialContent:
+		return false
+	}
+	mt, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	return mt == "application/json" || strings.HasSuffix(mt, "+json")
+}
+
+// start writes the response header with the negotiated content encoding and
+// compresses the buffered body.
+func (c *compressWriter) start() error {
+	h := c.Header()
+	h.Set("Content-Encoding", c.encoding)
+	h.Del("Content-Length")
+	c.writeHeader()
+	if c.encoding == "gzip" {
+		c.cw = gzip.NewWriter(c.ResponseWriter)
+	} else {
+		c.cw = zlib.NewWriter(c.ResponseWriter)
+	}
+	_, err := c.cw.Write(c.buf)
+	c.buf = nil
+	return err
+}
+
+// writeHeader writes the response header using the status code set by the
+// handler, 200 if none.
+func (c *compressWriter) writeHeader() {
+	c.wroteHeader = true
+	if c.status == 0 {
+		c.status = http.StatusOK
+	}
+	c.ResponseWriter.WriteHeader(c.status)
+}
+
+// Close closes the decompressor and the response body.
+func (d *decompressBody) Close() error {
+	err := d.ReadCloser.Close()
+	if cerr := d.body.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// negotiateEncoding returns the content encoding used to compress the response
+// given the value of the request Accept-Encoding header. It returns gzip,
+// deflate or the empty string if the client does not accept either.
+func negotiateEncoding(accept string) string {
+	var gz, deflate, star float64 = -1, -1, -1
+	for _, spec := range strings.Split(accept, ",") {
+		parts := strings.Split(spec, ";")
+		name := strings.ToLower(strings.TrimSpace(parts[0]))
+		q := 1.0
+		for _, p := range parts[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "q=") {
+				if v, err := strconv.ParseFloat(p[2:], 64); err == nil {
+					q = v
+				}
+			}
+		}
+		switch name {
+		case "gzip", "x-gzip":
+			gz = q
+		case "deflate":
+			deflate = q
+		case "*":
+			star = q
+		}
+	}
+	if gz < 0 {
+		gz = star
+	}
+	if deflate < 0 {
+		deflate = star
+	}
+	switch {
+	case gz > 0 && gz >= deflate:
+		return "gzip"
+	case deflate > 0:
+		return "deflate"
+	}
+	return ""
+}
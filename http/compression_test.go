@@ -0,0 +1,118 @@
+package http
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Accept   string
+		Expected string
+	}{
+		{"none", "", ""},
+		{"identity", "identity", ""},
+		{"gzip", "gzip", "gzip"},
+		{"deflate", "deflate", "deflate"},
+		{"gzip preferred", "deflate, gzip", "gzip"},
+		{"quality", "gzip;q=0.5, deflate", "deflate"},
+		{"gzip refused", "gzip;q=0, deflate", "deflate"},
+		{"all refused", "gzip;q=0, deflate;q=0", ""},
+		{"wildcard", "*", "gzip"},
+		{"wildcard gzip refused", "*, gzip;q=0", "deflate"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if enc := negotiateEncoding(c.Accept); enc != c.Expected {
+				t.Errorf("got %q, expected %q", enc, c.Expected)
+			}
+		})
+	}
+}
+
+func TestCompress(t *testing.T) {
+	var (
+		small = `{"a":1}`
+		large = `{"a":"` + strings.Repeat("a", 100) + `"}`
+	)
+	cases := []struct {
+		Name        string
+		Accept      string
+		ContentType string
+		Body        string
+		Encoding    string
+	}{
+		{"no accept encoding", "", "application/json", large, ""},
+		{"gzip", "gzip", "application/json", large, "gzip"},
+		{"deflate", "deflate", "application/json", large, "deflate"},
+		{"vendor json", "gzip", "application/vnd.goa+json; charset=utf-8", large, "gzip"},
+		{"below min size", "gzip", "application/json", small, ""},
+		{"not json", "gzip", "text/plain", large, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			h := Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", c.ContentType)
+				w.WriteHeader(http.StatusCreated)
+				// Write in two steps to exercise buffering.
+				w.Write([]byte(c.Body[:len(c.Body)/2]))
+				w.Write([]byte(c.Body[len(c.Body)/2:]))
+			}), 50)
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.Accept != "" {
+				req.Header.Set("Accept-Encoding", c.Accept)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+			resp := rec.Result()
+
+			if resp.StatusCode != http.StatusCreated {
+				t.Errorf("got status %d, expected %d", resp.StatusCode, http.StatusCreated)
+			}
+			if v := resp.Header.Get("Vary"); v != "Accept-Encoding" {
+				t.Errorf("got Vary %q, expected %q", v, "Accept-Encoding")
+			}
+			if enc := resp.Header.Get("Content-Encoding"); enc != c.Encoding {
+				t.Errorf("got Content-Encoding %q, expected %q", enc, c.Encoding)
+			}
+			if err := DecompressResponse(resp); err != nil {
+				t.Fatalf("failed to decompress response: %s", err)
+			}
+			body, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("failed to read response: %s", err)
+			}
+			if err := resp.Body.Close(); err != nil {
+				t.Errorf("failed to close response: %s", err)
+			}
+			if string(body) != c.Body {
+				t.Errorf("got body %q, expected %q", string(body), c.Body)
+			}
+			if resp.Header.Get("Content-Encoding") != "" {
+				t.Errorf("got Content-Encoding %q after decompression, expected none", resp.Header.Get("Content-Encoding"))
+			}
+		})
+	}
+}
+
+func TestDecompressResponseUncompressed(t *testing.T) {
+	resp := &http.Response{
+		Header: http.Header{},
+		Body:   ioutil.NopCloser(bytes.NewBufferString("body")),
+	}
+	if err := DecompressResponse(resp); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.Uncompressed {
+		t.Errorf("got Uncompressed true, expected false")
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	if string(body) != "body" {
+		t.Errorf("got body %q, expected %q", string(body), "body")
+	}
+}
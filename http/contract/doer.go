@@ -0,0 +1,61 @@
+package contract
+
+import (
+	"net/http"
+	"sync"
+
+	goahttp "goa.design/goa/http"
+)
+
+// Doer is a HTTP client that validates the requests it sends and the
+// responses it receives against an OpenAPI specification. It may be given to
+// the generated clients to turn the design examples into contract tests
+// against a running implementation of the API. Doer is safe for concurrent
+// use.
+type Doer struct {
+	spec *Spec
+	doer goahttp.Doer
+	mu   sync.Mutex
+	errs []error
+}
+
+// NewDoer returns a Doer that sends requests with d and validates them with
+// spec.
+func NewDoer(spec *Spec, d goahttp.Doer) *Doer {
+	return &Doer{spec: spec, doer: d}
+}
+
+// Do validates req, sends it and validates the response. The violations are
+// recorded and do not prevent the request from being sent, the error
+// returned by the underlying doer is recorded and returned as is.
+func (d *Doer) Do(req *http.Request) (*http.Response, error) {
+	if err := d.spec.ValidateRequest(req); err != nil {
+		d.record(err)
+	}
+	resp, err := d.doer.Do(req)
+	if err != nil {
+		d.record(err)
+		return nil, err
+	}
+	if err := d.spec.ValidateResponse(resp); err != nil {
+		d.record(err)
+	}
+	return resp, nil
+}
+
+// Errors returns the contract violations and the errors returned by the
+// underlying doer recorded since the last call to Errors.
+func (d *Doer) Errors() []error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	errs := d.errs
+	d.errs = nil
+	return errs
+}
+
+// record records err.
+func (d *Doer) record(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.errs = append(d.errs, err)
+}
@@ -0,0 +1,63 @@
+package contract
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDoer(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/api/bottles/1" {
+			w.Write([]byte(`{"name":"b"}`))
+			return
+		}
+		w.Write([]byte(`{"vintage":"old"}`))
+	}))
+	defer srv.Close()
+	d := NewDoer(loadTestSpec(t), srv.Client())
+
+	req, _ := http.NewRequest("GET", srv.URL+"/api/bottles/1", nil)
+	req.Header.Set("Authorization", "token")
+	resp, err := d.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	if body, _ := ioutil.ReadAll(resp.Body); string(body) != `{"name":"b"}` {
+		t.Errorf("got body %q, expected %q", body, `{"name":"b"}`)
+	}
+	if errs := d.Errors(); len(errs) > 0 {
+		t.Errorf("got errors %v, expected none", errs)
+	}
+
+	req, _ = http.NewRequest("GET", srv.URL+"/api/bottles/2", nil)
+	if _, err := d.Do(req); err != nil {
+		t.Fatalf("request failed: %s", err)
+	}
+	errs := d.Errors()
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, expected 2: %v", len(errs), errs)
+	}
+	if !strings.Contains(errs[0].Error(), "header Authorization: missing required value") {
+		t.Errorf("got request error %q", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), "body.vintage: got string, expected integer") {
+		t.Errorf("got response error %q", errs[1])
+	}
+	if errs := d.Errors(); len(errs) > 0 {
+		t.Errorf("got errors %v after reset, expected none", errs)
+	}
+
+	srv.Close()
+	req, _ = http.NewRequest("GET", srv.URL+"/api/bottles/1", nil)
+	req.Header.Set("Authorization", "token")
+	if _, err := d.Do(req); err == nil {
+		t.Fatal("got no error from closed server")
+	}
+	if errs := d.Errors(); len(errs) != 1 {
+		t.Errorf("got %d errors, expected 1: %v", len(errs), errs)
+	}
+}
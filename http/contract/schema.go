@@ -0,0 +1,288 @@
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"goa.design/goa"
+)
+
+type (
+	// Schema is the subset of a JSON schema used by OpenAPI v2 to describe
+	// parameters, request bodies and responses.
+	Schema struct {
+		// Ref references a schema defined in the specification
+		// definitions.
+		Ref string `json:"$ref"`
+		// Type is the JSON type: "object", "array", "string",
+		// "integer", "number", "boolean" or "file".
+		Type string `json:"type"`
+		// Format further describes the type, e.g. "int32" or
+		// "date-time".
+		Format string `json:"format"`
+		// Items is the schema of the elements of arrays.
+		Items *Schema `json:"items"`
+		// Properties lists the schemas of the object properties indexed
+		// by name.
+		Properties map[string]*Schema `json:"properties"`
+		// Required lists the names of the required object properties.
+		Required []string `json:"required"`
+		// AnyOf lists alternative schemas.
+		AnyOf []*Schema `json:"anyOf"`
+		// Enum lists the allowed values.
+		Enum []interface{} `json:"enum"`
+		// Pattern is the regular expression strings must match.
+		Pattern string `json:"pattern"`
+		// Minimum is the minimum value of numbers.
+		Minimum *float64 `json:"minimum"`
+		// Maximum is the maximum value of numbers.
+		Maximum *float64 `json:"maximum"`
+		// ExclusiveMinimum is true if Minimum is excluded.
+		ExclusiveMinimum bool `json:"exclusiveMinimum"`
+		// ExclusiveMaximum is true if Maximum is excluded.
+		ExclusiveMaximum bool `json:"exclusiveMaximum"`
+		// MinLength is the minimum number of characters of strings.
+		MinLength *int `json:"minLength"`
+		// MaxLength is the maximum number of characters of strings.
+		MaxLength *int `json:"maxLength"`
+		// MinItems is the minimum number of elements of arrays.
+		MinItems *int `json:"minItems"`
+		// MaxItems is the maximum number of elements of arrays.
+		MaxItems *int `json:"maxItems"`
+	}
+
+	// Error is the error returned when a request or a response does not
+	// comply with the specification.
+	Error struct {
+		// Message describes the request or response that was
+		// validated.
+		Message string
+		// Violations lists the differences with the specification, each
+		// violation is prefixed with the location of the invalid value,
+		// e.g. "body.items[0].name".
+		Violations []string
+	}
+
+	// validator validates values against schemas and records the
+	// violations.
+	validator struct {
+		// defs lists the schemas that may be referenced indexed by
+		// name.
+		defs map[string]*Schema
+		// violations lists the violations found so far.
+		violations []string
+	}
+)
+
+// Error returns the error message listing all the violations.
+func (e *Error) Error() string {
+	return e.Message + ": " + strings.Join(e.Violations, "; ")
+}
+
+// validate validates the JSON value val decoded with json.Number numbers
+// against the schema s. ctx is the location of val used to prefix the
+// violations.
+func (v *validator) validate(ctx string, s *Schema, val interface{}) {
+	if s == nil {
+		return
+	}
+	if s.Ref != "" {
+		def, ok := v.defs[strings.TrimPrefix(s.Ref, "#/definitions/")]
+		if !ok {
+			v.errorf(ctx, "unknown schema reference %q", s.Ref)
+			return
+		}
+		v.validate(ctx, def, val)
+		return
+	}
+	if len(s.AnyOf) > 0 {
+		matched := false
+		for _, alt := range s.AnyOf {
+			sub := &validator{defs: v.defs}
+			sub.validate(ctx, alt, val)
+			if len(sub.violations) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.errorf(ctx, "value does not match any of the alternative schemas")
+		}
+	}
+	if val == nil {
+		if s.Type != "" && s.Type != "file" {
+			v.errorf(ctx, "got null, expected %s", s.Type)
+		}
+		return
+	}
+	switch s.Type {
+	case "object":
+		obj, ok := val.(map[string]interface{})
+		if !ok {
+			v.errorf(ctx, "got %s, expected object", jsonType(val))
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				v.errorf(ctx, "missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if pv, ok := obj[name]; ok {
+				v.validate(ctx+"."+name, s.Properties[name], pv)
+			}
+		}
+	case "array":
+		arr, ok := val.([]interface{})
+		if !ok {
+			v.errorf(ctx, "got %s, expected array", jsonType(val))
+			return
+		}
+		if s.MinItems != nil && len(arr) < *s.MinItems {
+			v.errorf(ctx, "got %d elements, expected at least %d", len(arr), *s.MinItems)
+		}
+		if s.MaxItems != nil && len(arr) > *s.MaxItems {
+			v.errorf(ctx, "got %d elements, expected at most %d", len(arr), *s.MaxItems)
+		}
+		for i, elem := range arr {
+			v.validate(fmt.Sprintf("%s[%d]", ctx, i), s.Items, elem)
+		}
+	case "string":
+		str, ok := val.(string)
+		if !ok {
+			v.errorf(ctx, "got %s, expected string", jsonType(val))
+			return
+		}
+		n := utf8.RuneCountInString(str)
+		if s.MinLength != nil && n < *s.MinLength {
+			v.errorf(ctx, "got %d characters, expected at least %d", n, *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			v.errorf(ctx, "got %d characters, expected at most %d", n, *s.MaxLength)
+		}
+		if s.Pattern != "" {
+			if re, err := regexp.Compile(s.Pattern); err != nil {
+				v.errorf(ctx, "invalid pattern %q: %s", s.Pattern, err)
+			} else if !re.MatchString(str) {
+				v.errorf(ctx, "value %q does not match pattern %q", str, s.Pattern)
+			}
+		}
+		if f := goa.Format(s.Format); isFormat(f) {
+			if err := goa.ValidateFormat(ctx, str, f); err != nil {
+				v.errorf(ctx, "value %q is not a valid %s", str, s.Format)
+			}
+		}
+	case "integer", "number":
+		num, ok := val.(json.Number)
+		if !ok {
+			v.errorf(ctx, "got %s, expected %s", jsonType(val), s.Type)
+			return
+		}
+		f, err := num.Float64()
+		if err != nil {
+			v.errorf(ctx, "invalid number %s", num)
+			return
+		}
+		if s.Type == "integer" && f != math.Trunc(f) {
+			v.errorf(ctx, "got %s, expected integer", num)
+		}
+		if s.Format == "int32" && (f < math.MinInt32 || f > math.MaxInt32) {
+			v.errorf(ctx, "value %s overflows int32", num)
+		}
+		if s.Minimum != nil && (f < *s.Minimum || s.ExclusiveMinimum && f == *s.Minimum) {
+			v.errorf(ctx, "value %s is lower than the minimum %v", num, *s.Minimum)
+		}
+		if s.Maximum != nil && (f > *s.Maximum || s.ExclusiveMaximum && f == *s.Maximum) {
+			v.errorf(ctx, "value %s is greater than the maximum %v", num, *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := val.(bool); !ok {
+			v.errorf(ctx, "got %s, expected boolean", jsonType(val))
+			return
+		}
+	}
+	if len(s.Enum) > 0 {
+		for _, e := range s.Enum {
+			if equal(e, val) {
+				return
+			}
+		}
+		v.errorf(ctx, "value %v is not one of %v", val, s.Enum)
+	}
+}
+
+// errorf records a violation at the location ctx.
+func (v *validator) errorf(ctx, format string, args ...interface{}) {
+	v.violations = append(v.violations, ctx+": "+fmt.Sprintf(format, args...))
+}
+
+// err returns an Error with the given message listing the violations
+// recorded so far, nil if there is none.
+func (v *validator) err(msg string) error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+	return &Error{Message: msg, Violations: v.violations}
+}
+
+// isFormat returns true if f is one of the string formats validated by goa.
+func isFormat(f goa.Format) bool {
+	switch f {
+	case goa.FormatDate, goa.FormatDateTime, goa.FormatUUID, goa.FormatEmail,
+		goa.FormatHostname, goa.FormatIPv4, goa.FormatIPv6, goa.FormatIP,
+		goa.FormatURI, goa.FormatMAC, goa.FormatCIDR, goa.FormatRegexp,
+		goa.FormatJSON, goa.FormatRFC1123:
+		return true
+	}
+	return goa.IsFormatRegistered(f)
+}
+
+// equal returns true if the JSON values a and b are equal. Numbers are
+// compared by value whether they are decoded as float64 or json.Number.
+func equal(a, b interface{}) bool {
+	if fa, ok := number(a); ok {
+		fb, ok := number(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// number returns the value of v if it is a number.
+func number(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// jsonType returns the name of the JSON type of v.
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number, float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return fmt.Sprintf("%T", v)
+}
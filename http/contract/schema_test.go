@@ -0,0 +1,60 @@
+package contract
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	defs := map[string]*Schema{
+		"Color": {Type: "string", Enum: []interface{}{"red", "white"}},
+	}
+	cases := []struct {
+		Name       string
+		Schema     string
+		Value      string
+		Violations []string
+	}{
+		{"any", `{}`, `{"a":1}`, nil},
+		{"string", `{"type":"string"}`, `"a"`, nil},
+		{"not string", `{"type":"string"}`, `1`, []string{"v: got number, expected string"}},
+		{"null", `{"type":"string"}`, `null`, []string{"v: got null, expected string"}},
+		{"max length", `{"type":"string","maxLength":2}`, `"héé"`, []string{"v: got 3 characters, expected at most 2"}},
+		{"pattern", `{"type":"string","pattern":"^a+$"}`, `"ab"`, []string{`v: value "ab" does not match pattern "^a+$"`}},
+		{"format", `{"type":"string","format":"date-time"}`, `"yesterday"`, []string{`v: value "yesterday" is not a valid date-time`}},
+		{"byte format", `{"type":"string","format":"byte"}`, `"YQ=="`, nil},
+		{"integer", `{"type":"integer"}`, `1.5`, []string{"v: got 1.5, expected integer"}},
+		{"int32", `{"type":"integer","format":"int32"}`, `2147483648`, []string{"v: value 2147483648 overflows int32"}},
+		{"exclusive maximum", `{"type":"number","maximum":1,"exclusiveMaximum":true}`, `1`, []string{"v: value 1 is greater than the maximum 1"}},
+		{"boolean", `{"type":"boolean"}`, `"true"`, []string{"v: got string, expected boolean"}},
+		{"enum number", `{"type":"integer","enum":[1,2]}`, `2`, nil},
+		{"ref", `{"$ref":"#/definitions/Color"}`, `"blue"`, []string{"v: value blue is not one of [red white]"}},
+		{"unknown ref", `{"$ref":"#/definitions/Unknown"}`, `"blue"`, []string{`v: unknown schema reference "#/definitions/Unknown"`}},
+		{"array", `{"type":"array","minItems":3,"items":{"type":"integer"}}`, `[1,"a"]`,
+			[]string{"v: got 2 elements, expected at least 3", "v[1]: got string, expected integer"}},
+		{"object", `{"type":"object","required":["a"],"properties":{"b":{"$ref":"#/definitions/Color"}}}`, `{"b":"red","c":1}`,
+			[]string{`v: missing required property "a"`}},
+		{"any of", `{"anyOf":[{"type":"string"},{"type":"integer"}]}`, `true`,
+			[]string{"v: value does not match any of the alternative schemas"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var s Schema
+			if err := json.Unmarshal([]byte(c.Schema), &s); err != nil {
+				t.Fatalf("invalid schema: %s", err)
+			}
+			var val interface{}
+			dec := json.NewDecoder(strings.NewReader(c.Value))
+			dec.UseNumber()
+			if err := dec.Decode(&val); err != nil {
+				t.Fatalf("invalid value: %s", err)
+			}
+			v := &validator{defs: defs}
+			v.validate("v", &s, val)
+			if strings.Join(v.violations, "\n") != strings.Join(c.Violations, "\n") {
+				t.Errorf("got violations:\n%s\nexpected:\n%s", strings.Join(v.violations, "\n"), strings.Join(c.Violations, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Spec is an OpenAPI v2 specification loaded to validate the HTTP
+	// requests and responses exchanged with an implementation of the API.
+	Spec struct {
+		// BasePath is the base path of the API paths.
+		BasePath string
+		// Definitions lists the schemas referenced by the operations
+		// indexed by name.
+		Definitions map[string]*Schema
+		// operations lists the operations sorted by number of path
+		// wildcards so that static paths are matched first.
+		operations []*Operation
+	}
+
+	// Operation describes a single API operation, that is a HTTP method on
+	// a path.
+	Operation struct {
+		// ID is the operation ID, goa uses "<service>#<method>".
+		ID string `json:"operationId"`
+		// Method is the HTTP method of the operation.
+		Method string `json:"-"`
+		// Path is the operation path relative to the API base path.
+		Path string `json:"-"`
+		// Parameters lists the operation parameters.
+		Parameters []*Parameter `json:"parameters"`
+		// Responses lists the operation responses indexed by status
+		// code or "default".
+		Responses map[string]*Response `json:"responses"`
+		// segments lists the path segments.
+		segments []string
+		// wildcards is the number of wildcard segments.
+		wildcards int
+	}
+
+	// Parameter describes an operation parameter.
+	Parameter struct {
+		// Name is the parameter name.
+		Name string `json:"name"`
+		// In is the location of the parameter: "query", "header",
+		// "path", "formData" or "body".
+		In string `json:"in"`
+		// Required is true if the parameter is mandatory.
+		Required bool `json:"required"`
+		// Body is the schema of the body parameter.
+		Body *Schema `json:"schema"`
+		// CollectionFormat is the format of array parameters.
+		CollectionFormat string `json:"collectionFormat"`
+		// Schema describes the type and validations of path, query and
+		// header parameters.
+		Schema
+	}
+
+	// Response describes an operation response.
+	Response struct {
+		// Schema is the schema of the response body if any.
+		Schema *Schema `json:"schema"`
+		// Headers lists the response headers indexed by name.
+		Headers map[string]*Schema `json:"headers"`
+		// Ref references a response defined at the top level of the
+		// specification.
+		Ref string `json:"$ref"`
+	}
+
+	// spec is the subset of the OpenAPI v2 document used to load a Spec.
+	spec struct {
+		BasePath    string                                `json:"basePath"`
+		Paths       map[string]map[string]json.RawMessage `json:"paths"`
+		Definitions map[string]*Schema                    `json:"definitions"`
+		Responses   map[string]*Response                  `json:"responses"`
+	}
+)
+
+// methods lists the HTTP methods of the operations that may be defined on an
+// OpenAPI path.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// LoadFile loads the OpenAPI v2 specification in JSON format stored in the
+// file with the given path, for example the file "gen/http/openapi.json"
+// generated by goa.
+func LoadFile(path string) (*Spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Load reads and loads an OpenAPI v2 specification in JSON format.
+func Load(r io.Reader) (*Spec, error) {
+	var doc spec
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI specification: %s", err)
+	}
+	s := &Spec{BasePath: strings.TrimSuffix(doc.BasePath, "/"), Definitions: doc.Definitions}
+	for path, item := range doc.Paths {
+		for _, method := range methods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			var op Operation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("invalid %s %s operation: %s", strings.ToUpper(method), path, err)
+			}
+			op.Method = strings.ToUpper(method)
+			op.Path = path
+			for code, res := range op.Responses {
+				if res.Ref == "" {
+					continue
+				}
+				ref, ok := doc.Responses[strings.TrimPrefix(res.Ref, "#/responses/")]
+				if !ok {
+					return nil, fmt.Errorf("%s %s: unknown response %q", op.Method, path, res.Ref)
+				}
+				op.Responses[code] = ref
+			}
+			op.segments = strings.Split(strings.Trim(path, "/"), "/")
+			for _, seg := range op.segments {
+				if strings.HasPrefix(seg, "{") {
+					op.wildcards++
+				}
+			}
+			s.operations = append(s.operations, &op)
+		}
+	}
+	sort.SliceStable(s.operations, func(i, j int) bool {
+		if s.operations[i].wildcards != s.operations[j].wildcards {
+			return s.operations[i].wildcards < s.operations[j].wildcards
+		}
+		return s.operations[i].Path < s.operations[j].Path
+	})
+	return s, nil
+}
+
+// Operation returns the operation that matches the method and path of req
+// and the values of the path parameters indexed by name. It returns nil if
+// no operation matches.
+func (s *Spec) Operation(req *http.Request) (*Operation, map[string]string) {
+	path := req.URL.Path
+	if s.BasePath != "" {
+		if !strings.HasPrefix(path, s.BasePath) {
+			return nil, nil
+		}
+		path = strings.TrimPrefix(path, s.BasePath)
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	for _, op := range s.operations {
+		if op.Method != req.Method {
+			continue
+		}
+		if params, ok := op.match(parts); ok {
+			return op, params
+		}
+	}
+	return nil, nil
+}
+
+// ValidateRequest validates req against the specification of the operation
+// it targets. It returns an error describing all the violations if any. The
+// request body is read and replaced so that it can be read again.
+func (s *Spec) ValidateRequest(req *http.Request) error {
+	op, params := s.Operation(req)
+	if op == nil {
+		return fmt.Errorf("%s %s: no matching operation in the OpenAPI specification", req.Method, req.URL.Path)
+	}
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = readBody(&req.Body); err != nil {
+			return fmt.Errorf("%s: failed to read request body: %s", op.ID, err)
+		}
+	}
+	v := &validator{defs: s.Definitions}
+	query := req.URL.Query()
+	for _, p := range op.Parameters {
+		switch p.In {
+		case "path":
+			v.param("path parameter "+p.Name, &p.Schema, p.values([]string{params[p.Name]}))
+		case "query":
+			if vals, ok := query[p.Name]; ok {
+				v.param("query parameter "+p.Name, &p.Schema, p.values(vals))
+			} else if p.Required {
+				v.errorf("query parameter "+p.Name, "missing required value")
+			}
+		case "header":
+			if vals, ok := req.Header[http.CanonicalHeaderKey(p.Name)]; ok {
+				v.param("header "+p.Name, &p.Schema, p.values(vals))
+			} else if p.Required {
+				v.errorf("header "+p.Name, "missing required value")
+			}
+		case "body":
+			if len(body) == 0 {
+				if p.Required {
+					v.errorf("body", "missing request body")
+				}
+				continue
+			}
+			v.body("body", p.Body, req.Header.Get("Content-Type"), body)
+		}
+	}
+	return v.err(op.ID + ": invalid request")
+}
+
+// ValidateResponse validates resp against the specification of the
+// operation targeted by the request that produced it. It returns an error
+// describing all the violations if any. The response body is read and
+// replaced so that it can be read again.
+func (s *Spec) ValidateResponse(resp *http.Response) error {
+	req := resp.Request
+	op, _ := s.Operation(req)
+	if op == nil {
+		return fmt.Errorf("%s %s: no matching operation in the OpenAPI specification", req.Method, req.URL.Path)
+	}
+	res, ok := op.Responses[strconv.Itoa(resp.StatusCode)]
+	if !ok {
+		if res, ok = op.Responses["default"]; !ok {
+			return fmt.Errorf("%s: undocumented response status code %d", op.ID, resp.StatusCode)
+		}
+	}
+	body, err := readBody(&resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: failed to read response body: %s", op.ID, err)
+	}
+	v := &validator{defs: s.Definitions}
+	for name, h := range res.Headers {
+		if vals, ok := resp.Header[http.CanonicalHeaderKey(name)]; ok {
+			v.param("header "+name, h, vals)
+		}
+	}
+	if res.Schema != nil && req.Method != "HEAD" && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotModified {
+		if len(body) == 0 {
+			v.errorf("body", "missing response body")
+		} else {
+			v.body("body", res.Schema, resp.Header.Get("Content-Type"), body)
+		}
+	}
+	return v.err(fmt.Sprintf("%s: invalid %d response", op.ID, resp.StatusCode))
+}
+
+// match returns the values of the path parameters indexed by name if the path
+// segments in parts match the operation path.
+func (op *Operation) match(parts []string) (map[string]string, bool) {
+	params := make(map[string]string)
+	for i, seg := range op.segments {
+		if strings.HasPrefix(seg, "{*") {
+			params[strings.TrimSuffix(seg[2:], "}")] = strings.Join(parts[i:], "/")
+			return params, true
+		}
+		if i >= len(parts) {
+			return nil, false
+		}
+		if strings.HasPrefix(seg, "{") {
+			if parts[i] == "" {
+				return nil, false
+			}
+			params[strings.Trim(seg, "{}")] = parts[i]
+			continue
+		}
+		if seg != parts[i] {
+			return nil, false
+		}
+	}
+	return params, len(parts) == len(op.segments)
+}
+
+// body validates the JSON document b against the schema s. Bodies that are
+// not JSON are not validated.
+func (v *validator) body(ctx string, s *Schema, contentType string, b []byte) {
+	if contentType != "" {
+		mt, _, err := mime.ParseMediaType(contentType)
+		if err != nil || (mt != "application/json" && !strings.HasSuffix(mt, "+json")) {
+			return
+		}
+	}
+	var val interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&val); err != nil {
+		v.errorf(ctx, "invalid JSON: %s", err)
+		return
+	}
+	v.validate(ctx, s, val)
+}
+
+// param validates the values of a path, query or header parameter.
+func (v *validator) param(ctx string, s *Schema, vals []string) {
+	if s.Type == "array" {
+		items := make([]interface{}, len(vals))
+		for i, val := range vals {
+			items[i] = v.scalar(s.Items, val)
+		}
+		v.validate(ctx, s, items)
+		return
+	}
+	for _, val := range vals {
+		v.validate(ctx, s, v.scalar(s, val))
+	}
+}
+
+// scalar converts the string value of a parameter to the JSON value of the
+// type described by s. Values that cannot be converted are returned as is and
+// are reported by the validation.
+func (v *validator) scalar(s *Schema, val string) interface{} {
+	if s == nil {
+		return val
+	}
+	switch s.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(val, 64); err == nil {
+			return json.Number(val)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(val); err == nil {
+			return b
+		}
+	}
+	return val
+}
+
+// values splits the values of an array parameter according to its collection
+// format. It returns vals unchanged if p is not an array.
+func (p *Parameter) values(vals []string) []string {
+	if p.Type != "array" {
+		return vals
+	}
+	sep := ""
+	switch p.CollectionFormat {
+	case "", "csv":
+		sep = ","
+	case "ssv":
+		sep = " "
+	case "tsv":
+		sep = "\t"
+	case "pipes":
+		sep = "|"
+	}
+	if sep == "" {
+		return vals
+	}
+	var res []string
+	for _, val := range vals {
+		res = append(res, strings.Split(val, sep)...)
+	}
+	return res
+}
+
+// readBody reads the content of the body b and replaces it with a reader of
+// the same content.
+func readBody(b *io.ReadCloser) ([]byte, error) {
+	if *b == nil {
+		return nil, nil
+	}
+	content, err := ioutil.ReadAll(*b)
+	(*b).Close()
+	*b = ioutil.NopCloser(bytes.NewReader(content))
+	return content, err
+}
@@ -0,0 +1,222 @@
+package contract
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testSpec is the OpenAPI specification used by the tests.
+const testSpec = `{
+	"swagger": "2.0",
+	"basePath": "/api",
+	"paths": {
+		"/bottles/{id}": {
+			"get": {
+				"operationId": "storage#show",
+				"parameters": [
+					{"name": "id", "in": "path", "required": true, "type": "integer"},
+					{"name": "view", "in": "query", "required": false, "type": "string", "enum": ["default", "tiny"]},
+					{"name": "Authorization", "in": "header", "required": true, "type": "string"}
+				],
+				"responses": {
+					"200": {"description": "OK", "schema": {"$ref": "#/definitions/Bottle"}},
+					"404": {"$ref": "#/responses/NotFound"}
+				}
+			},
+			"delete": {
+				"operationId": "storage#remove",
+				"parameters": [{"name": "id", "in": "path", "required": true, "type": "integer"}],
+				"responses": {"204": {"description": "No Content"}}
+			}
+		},
+		"/bottles/latest": {
+			"get": {
+				"operationId": "storage#latest",
+				"parameters": [{"name": "tags", "in": "query", "type": "array", "items": {"type": "string"}, "collectionFormat": "multi", "minItems": 1}],
+				"responses": {"200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/Bottle"}}}}
+			}
+		},
+		"/bottles": {
+			"post": {
+				"operationId": "storage#add",
+				"parameters": [{"name": "AddRequestBody", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Bottle"}}],
+				"responses": {"201": {"description": "Created", "headers": {"Location": {"type": "string", "format": "uri"}}}}
+			}
+		}
+	},
+	"definitions": {
+		"Bottle": {
+			"type": "object",
+			"properties": {
+				"name": {"type": "string", "minLength": 1},
+				"vintage": {"type": "integer", "format": "int64", "minimum": 1900}
+			},
+			"required": ["name"]
+		}
+	},
+	"responses": {
+		"NotFound": {"description": "Not Found", "schema": {"type": "string"}}
+	}
+}`
+
+func loadTestSpec(t *testing.T) *Spec {
+	spec, err := Load(strings.NewReader(testSpec))
+	if err != nil {
+		t.Fatalf("failed to load spec: %s", err)
+	}
+	return spec
+}
+
+func TestLoad(t *testing.T) {
+	if _, err := Load(strings.NewReader("{")); err == nil {
+		t.Error("got no error for invalid JSON")
+	}
+	if _, err := Load(strings.NewReader(`{"paths": {"/": {"get": {"responses": {"404": {"$ref": "#/responses/Unknown"}}}}}}`)); err == nil {
+		t.Error("got no error for unknown response reference")
+	}
+}
+
+func TestOperation(t *testing.T) {
+	spec := loadTestSpec(t)
+	cases := []struct {
+		Method string
+		Path   string
+		ID     string
+		Params map[string]string
+	}{
+		{"GET", "/api/bottles/42", "storage#show", map[string]string{"id": "42"}},
+		{"GET", "/api/bottles/latest", "storage#latest", map[string]string{}},
+		{"DELETE", "/api/bottles/42", "storage#remove", map[string]string{"id": "42"}},
+		{"POST", "/api/bottles", "storage#add", map[string]string{}},
+		{"POST", "/api/bottles/42", "", nil},
+		{"GET", "/bottles/42", "", nil},
+		{"GET", "/api/bottles/", "", nil},
+	}
+	for _, c := range cases {
+		op, params := spec.Operation(httptest.NewRequest(c.Method, c.Path, nil))
+		var id string
+		if op != nil {
+			id = op.ID
+		}
+		if id != c.ID {
+			t.Errorf("%s %s: got operation %q, expected %q", c.Method, c.Path, id, c.ID)
+			continue
+		}
+		if len(params) != len(c.Params) {
+			t.Errorf("%s %s: got params %v, expected %v", c.Method, c.Path, params, c.Params)
+			continue
+		}
+		for k, v := range c.Params {
+			if params[k] != v {
+				t.Errorf("%s %s: got param %s=%q, expected %q", c.Method, c.Path, k, params[k], v)
+			}
+		}
+	}
+}
+
+func TestValidateRequest(t *testing.T) {
+	spec := loadTestSpec(t)
+	cases := []struct {
+		Name   string
+		Method string
+		Path   string
+		Header map[string]string
+		Body   string
+		Error  string
+	}{
+		{"valid", "GET", "/api/bottles/42?view=tiny", map[string]string{"Authorization": "token"}, "", ""},
+		{"invalid path param", "GET", "/api/bottles/abc", map[string]string{"Authorization": "token"}, "",
+			"storage#show: invalid request: path parameter id: got string, expected integer"},
+		{"invalid query param", "GET", "/api/bottles/42?view=full", map[string]string{"Authorization": "token"}, "",
+			"storage#show: invalid request: query parameter view: value full is not one of [default tiny]"},
+		{"missing header", "GET", "/api/bottles/42", nil, "",
+			"storage#show: invalid request: header Authorization: missing required value"},
+		{"array query param", "GET", "/api/bottles/latest?tags=a&tags=b", nil, "", ""},
+		{"optional array query param", "GET", "/api/bottles/latest", nil, "", ""},
+		{"valid body", "POST", "/api/bottles", map[string]string{"Content-Type": "application/json"}, `{"name":"b","vintage":1990}`, ""},
+		{"invalid body", "POST", "/api/bottles", map[string]string{"Content-Type": "application/json"}, `{"vintage":1800}`,
+			`storage#add: invalid request: body: missing required property "name"; body.vintage: value 1800 is lower than the minimum 1900`},
+		{"missing body", "POST", "/api/bottles", nil, "",
+			"storage#add: invalid request: body: missing request body"},
+		{"non JSON body", "POST", "/api/bottles", map[string]string{"Content-Type": "application/xml"}, "<bottle/>", ""},
+		{"unknown operation", "PUT", "/api/bottles", nil, "",
+			"PUT /api/bottles: no matching operation in the OpenAPI specification"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			req := httptest.NewRequest(c.Method, c.Path, strings.NewReader(c.Body))
+			for k, v := range c.Header {
+				req.Header.Set(k, v)
+			}
+			err := spec.ValidateRequest(req)
+			if c.Error == "" {
+				if err != nil {
+					t.Fatalf("got error %q, expected none", err)
+				}
+			} else if err == nil || err.Error() != c.Error {
+				t.Fatalf("got error %v, expected %q", err, c.Error)
+			}
+			body, _ := ioutil.ReadAll(req.Body)
+			if string(body) != c.Body {
+				t.Errorf("got body %q after validation, expected %q", body, c.Body)
+			}
+		})
+	}
+}
+
+func TestValidateResponse(t *testing.T) {
+	spec := loadTestSpec(t)
+	cases := []struct {
+		Name   string
+		Method string
+		Path   string
+		Status int
+		Header map[string]string
+		Body   string
+		Error  string
+	}{
+		{"valid", "GET", "/api/bottles/42", http.StatusOK, nil, `{"name":"b"}`, ""},
+		{"referenced response", "GET", "/api/bottles/42", http.StatusNotFound, nil, `"not found"`, ""},
+		{"invalid body", "GET", "/api/bottles/42", http.StatusOK, nil, `{"name":""}`,
+			"storage#show: invalid 200 response: body.name: got 0 characters, expected at least 1"},
+		{"missing body", "GET", "/api/bottles/42", http.StatusOK, nil, "",
+			"storage#show: invalid 200 response: body: missing response body"},
+		{"undocumented status", "GET", "/api/bottles/42", http.StatusInternalServerError, nil, "",
+			"storage#show: undocumented response status code 500"},
+		{"no content", "DELETE", "/api/bottles/42", http.StatusNoContent, nil, "", ""},
+		{"valid header", "POST", "/api/bottles", http.StatusCreated, map[string]string{"Location": "http://localhost/api/bottles/1"}, "", ""},
+		{"invalid header", "POST", "/api/bottles", http.StatusCreated, map[string]string{"Location": "::"}, "",
+			`storage#add: invalid 201 response: header Location: value "::" is not a valid uri`},
+		{"array", "GET", "/api/bottles/latest", http.StatusOK, nil, `[{"name":"a"},{}]`,
+			`storage#latest: invalid 200 response: body[1]: missing required property "name"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: c.Status,
+				Header:     make(http.Header),
+				Body:       ioutil.NopCloser(bytes.NewBufferString(c.Body)),
+				Request:    httptest.NewRequest(c.Method, c.Path, nil),
+			}
+			for k, v := range c.Header {
+				resp.Header.Set(k, v)
+			}
+			err := spec.ValidateResponse(resp)
+			if c.Error == "" {
+				if err != nil {
+					t.Fatalf("got error %q, expected none", err)
+				}
+			} else if err == nil || err.Error() != c.Error {
+				t.Fatalf("got error %v, expected %q", err, c.Error)
+			}
+			body, _ := ioutil.ReadAll(resp.Body)
+			if string(body) != c.Body {
+				t.Errorf("got body %q after validation, expected %q", body, c.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package http
+
+import "strings"
+
+// MatchOrigin returns true if the given request origin matches one of the
+// allowed origins. The allowed origin "*" matches any origin, other allowed
+// origins may contain a single "*" wildcard which matches any sequence of
+// characters, for example "https://*.goa.design" matches
+// "https://api.goa.design".
+func MatchOrigin(origin string, allowed ...string) bool {
+	if origin == "" {
+		return false
+	}
+	for _, a := range allowed {
+		if a == "*" || a == origin {
+			return true
+		}
+		i := strings.Index(a, "*")
+		if i < 0 {
+			continue
+		}
+		prefix, suffix := a[:i], a[i+1:]
+		if len(origin) >= len(prefix)+len(suffix) &&
+			strings.HasPrefix(origin, prefix) &&
+			strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
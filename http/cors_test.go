@@ -0,0 +1,28 @@
+package http
+
+import "testing"
+
+func TestMatchOrigin(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Origin   string
+		Allowed  []string
+		Expected bool
+	}{
+		{"empty", "", []string{"*"}, false},
+		{"none allowed", "https://goa.design", nil, false},
+		{"any", "https://goa.design", []string{"*"}, true},
+		{"exact", "https://goa.design", []string{"https://goa.design"}, true},
+		{"exact mismatch", "http://goa.design", []string{"https://goa.design"}, false},
+		{"list", "https://goa.design", []string{"http://localhost", "https://goa.design"}, true},
+		{"wildcard", "https://api.goa.design", []string{"https://*.goa.design"}, true},
+		{"wildcard mismatch", "https://goa.design", []string{"https://*.goa.design"}, false},
+		{"wildcard port", "http://localhost:8080", []string{"http://localhost:*"}, true},
+	}
+	for _, c := range cases {
+		actual := MatchOrigin(c.Origin, c.Allowed...)
+		if actual != c.Expected {
+			t.Errorf("%s: expected %v, got %v", c.Name, c.Expected, actual)
+		}
+	}
+}
@@ -53,6 +53,12 @@ const (
 	BidirectionalStreamKind = design.BidirectionalStreamKind
 )
 
+const (
+	// NamespaceSeparator separates the namespace from the name in the qualified
+	// names of the types and security schemes defined in a namespace.
+	NamespaceSeparator = design.NamespaceSeparator
+)
+
 const (
 	// DefaultView is the name of the default result type view.
 	DefaultView = design.DefaultView
@@ -21,6 +21,7 @@ func RequestBody(a *EndpointExpr) *design.AttributeExpr {
 		payload   = a.MethodExpr.Payload
 		headers   = a.Headers
 		params    = a.Params
+		cookies   = a.Cookies
 		suffix    = "RequestBody"
 		name      = codegen.Goify(a.Name(), true) + suffix
 		userField string
@@ -43,25 +44,28 @@ func RequestBody(a *EndpointExpr) *design.AttributeExpr {
 		}
 	}
 
-	bodyOnly := headers.IsEmpty() && params.IsEmpty() && a.MapQueryParams == nil
+	bodyOnly := headers.IsEmpty() && params.IsEmpty() && cookies.IsEmpty() && a.MapQueryParams == nil
 
-	// 1. If Payload is not an object then check whether there are params or
-	// headers defined and if so return empty type (payload encoded in
-	// request params or headers) otherwise return payload type (payload
+	// 1. If Payload is not an object then check whether there are params,
+	// headers or cookies defined and if so return empty type (payload encoded in
+	// request params, headers or cookies) otherwise return payload type (payload
 	// encoded in request body).
 	if !design.IsObject(payload.Type) {
 		if bodyOnly {
 			payload = design.DupAtt(payload)
 			renameType(payload, name, "RequestBody")
+			removeEnumTypes(payload)
+			removeInternalAttributes(payload)
 			return payload
 		}
 		return &design.AttributeExpr{Type: design.Empty}
 	}
 
-	// 2. Remove header and param attributes
+	// 2. Remove header, param and cookie attributes
 	body := design.NewMappedAttributeExpr(payload)
 	removeAttributes(body, headers)
 	removeAttributes(body, params)
+	removeAttributes(body, cookies)
 	if a.MapQueryParams != nil && *a.MapQueryParams != "" {
 		removeAttribute(body, *a.MapQueryParams)
 	}
@@ -71,6 +75,7 @@ func RequestBody(a *EndpointExpr) *design.AttributeExpr {
 	if passField != "" {
 		removeAttribute(body, passField)
 	}
+	removeInternalAttributes(body.AttributeExpr)
 
 	// 3. Return empty type if no attribute left
 	if len(*design.AsObject(body.Type)) == 0 {
@@ -84,6 +89,7 @@ func RequestBody(a *EndpointExpr) *design.AttributeExpr {
 		TypeName:      name,
 	}
 	appendSuffix(ut.Attribute().Type, "RequestBody")
+	removeEnumTypes(ut.Attribute())
 
 	return &design.AttributeExpr{
 		Type:         ut,
@@ -128,22 +134,27 @@ func buildResponseBody(name string, attr *design.AttributeExpr, resp *HTTPRespon
 	}
 
 	// 1. If attribute is not an object then check whether there are headers
-	// defined and if so return empty type (attr encoded in response
-	// headers) otherwise return renamed attr type (attr encoded in
+	// or cookies defined and if so return empty type (attr encoded in
+	// response headers or cookies) otherwise return renamed attr type (attr encoded in
 	// response body).
 	if !design.IsObject(attr.Type) {
-		if resp.Headers.IsEmpty() {
+		if resp.Headers.IsEmpty() && resp.Cookies.IsEmpty() && resp.Trailers.IsEmpty() {
 			attr = design.DupAtt(attr)
 			renameType(attr, name, "ResponseBody")
 			setForcePointer(attr)
+			removeEnumTypes(attr)
+			removeInternalAttributes(attr)
 			return attr
 		}
 		return &design.AttributeExpr{Type: design.Empty}
 	}
 
-	// 2. Remove header attributes
+	// 2. Remove header, cookie and trailer attributes
 	body := design.NewMappedAttributeExpr(attr)
 	removeAttributes(body, resp.Headers)
+	removeAttributes(body, resp.Cookies)
+	removeAttributes(body, resp.Trailers)
+	removeInternalAttributes(body.AttributeExpr)
 
 	// 3. Return empty type if no attribute left
 	if len(*design.AsObject(body.Type)) == 0 {
@@ -156,6 +167,7 @@ func buildResponseBody(name string, attr *design.AttributeExpr, resp *HTTPRespon
 		TypeName:      name,
 	}
 	setForcePointer(userType.Attribute())
+	removeEnumTypes(userType.Attribute())
 	appendSuffix(userType.Attribute().Type, "ResponseBody")
 	rt, isrt := attr.Type.(*design.ResultTypeExpr)
 	if !isrt {
@@ -165,6 +177,9 @@ func buildResponseBody(name string, attr *design.AttributeExpr, resp *HTTPRespon
 	for i, v := range rt.Views {
 		mv := design.NewMappedAttributeExpr(v.AttributeExpr)
 		removeAttributes(mv, resp.Headers)
+		removeAttributes(mv, resp.Cookies)
+		removeAttributes(mv, resp.Trailers)
+		removeInternalAttributes(mv.AttributeExpr)
 		nv := &design.ViewExpr{
 			AttributeExpr: mv.Attribute(),
 			Name:          v.Name,
@@ -273,3 +288,163 @@ func removeAttribute(attr *design.MappedAttributeExpr, name string) {
 		}
 	}
 }
+
+// removeEnumTypes removes the "struct:enum" metadata from the attributes of
+// the body type so that the corresponding fields use the native Go types, the
+// named enum types are only used in the service package.
+func removeEnumTypes(att *design.AttributeExpr, seen ...map[string]struct{}) {
+	var s map[string]struct{}
+	if len(seen) > 0 {
+		s = seen[0]
+	} else {
+		s = make(map[string]struct{})
+		seen = append(seen, s)
+	}
+	switch actual := att.Type.(type) {
+	case design.Primitive:
+		if _, ok := att.Metadata["struct:enum"]; !ok {
+			return
+		}
+		// The metadata is shared with the attribute the body was
+		// duplicated from, make a copy.
+		att.Metadata = att.Metadata.Dup()
+		delete(att.Metadata, "struct:enum")
+	case design.UserType:
+		if _, ok := s[actual.ID()]; ok {
+			return
+		}
+		s[actual.ID()] = struct{}{}
+		removeEnumTypes(actual.Attribute(), seen...)
+	case *design.Object:
+		for _, nat := range *actual {
+			removeEnumTypes(nat.Attribute, seen...)
+		}
+	case *design.Array:
+		removeEnumTypes(actual.ElemType, seen...)
+	case *design.Map:
+		removeEnumTypes(actual.KeyType, seen...)
+		removeEnumTypes(actual.ElemType, seen...)
+	}
+}
+
+// removeInternalAttributes removes the attributes with the "transport:omit"
+// metadata from the body attribute and from the types it references so that
+// they are not exposed by the transport. The types must be copies of the
+// design types.
+func removeInternalAttributes(att *design.AttributeExpr, seen ...map[string]struct{}) {
+	var s map[string]struct{}
+	if len(seen) > 0 {
+		s = seen[0]
+	} else {
+		s = make(map[string]struct{})
+		seen = append(seen, s)
+	}
+	switch actual := att.Type.(type) {
+	case design.UserType:
+		if _, ok := s[actual.ID()]; ok {
+			return
+		}
+		s[actual.ID()] = struct{}{}
+		removeInternalAttributes(actual.Attribute(), seen...)
+	case *design.Object:
+		var internal []string
+		for _, nat := range *actual {
+			if isInternal(nat.Attribute) {
+				internal = append(internal, nat.Name)
+				continue
+			}
+			removeInternalAttributes(nat.Attribute, seen...)
+		}
+		if len(internal) == 0 {
+			return
+		}
+		for _, n := range internal {
+			actual.Delete(n)
+			if att.Validation != nil {
+				att.Validation.RemoveRequired(n)
+			}
+		}
+		// The examples are shared with the attribute the body was
+		// duplicated from, make copies.
+		exs := make([]*design.ExampleExpr, len(att.UserExamples))
+		for i, ex := range att.UserExamples {
+			exs[i] = &design.ExampleExpr{
+				Summary:     ex.Summary,
+				Description: ex.Description,
+				Value:       omitKeys(ex.Value, internal),
+			}
+		}
+		att.UserExamples = exs
+	case *design.Array:
+		removeInternalAttributes(actual.ElemType, seen...)
+	case *design.Map:
+		removeInternalAttributes(actual.KeyType, seen...)
+		removeInternalAttributes(actual.ElemType, seen...)
+	}
+}
+
+// hasInternalAttributes returns true if the given attribute or the types it
+// references define attributes with the "transport:omit" metadata.
+func hasInternalAttributes(att *design.AttributeExpr, seen ...map[string]struct{}) bool {
+	var s map[string]struct{}
+	if len(seen) > 0 {
+		s = seen[0]
+	} else {
+		s = make(map[string]struct{})
+		seen = append(seen, s)
+	}
+	switch actual := att.Type.(type) {
+	case design.UserType:
+		if _, ok := s[actual.ID()]; ok {
+			return false
+		}
+		s[actual.ID()] = struct{}{}
+		return hasInternalAttributes(actual.Attribute(), seen...)
+	case *design.Object:
+		for _, nat := range *actual {
+			if isInternal(nat.Attribute) || hasInternalAttributes(nat.Attribute, seen...) {
+				return true
+			}
+		}
+	case *design.Array:
+		return hasInternalAttributes(actual.ElemType, seen...)
+	case *design.Map:
+		return hasInternalAttributes(actual.KeyType, seen...) ||
+			hasInternalAttributes(actual.ElemType, seen...)
+	}
+	return false
+}
+
+// isInternal returns true if the attribute has the "transport:omit" metadata.
+func isInternal(att *design.AttributeExpr) bool {
+	if att == nil {
+		return false
+	}
+	_, ok := att.Metadata["transport:omit"]
+	return ok
+}
+
+// omitKeys returns a copy of the object example value v without the given
+// keys. Other values are returned unchanged.
+func omitKeys(v interface{}, keys []string) interface{} {
+	var m map[string]interface{}
+	switch actual := v.(type) {
+	case map[string]interface{}:
+		m = actual
+	case design.Val:
+		m = actual
+	default:
+		return v
+	}
+	res := make(map[string]interface{}, len(m))
+	for k, val := range m {
+		res[k] = val
+	}
+	for _, k := range keys {
+		delete(res, k)
+	}
+	if _, ok := v.(design.Val); ok {
+		return design.Val(res)
+	}
+	return res
+}
@@ -0,0 +1,37 @@
+package design
+
+import (
+	"fmt"
+
+	"goa.design/goa/eval"
+)
+
+type (
+	// CompressionExpr describes the response compression policy of an API
+	// or of a service.
+	CompressionExpr struct {
+		// MinSize is the minimum size in bytes of the response bodies
+		// that get compressed, smaller bodies are sent as is.
+		MinSize int
+		// Parent expression, one of ServiceExpr or RootExpr.
+		Parent eval.Expression
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (c *CompressionExpr) EvalName() string {
+	var suffix string
+	if c.Parent != nil {
+		suffix = fmt.Sprintf(" of %s", c.Parent.EvalName())
+	}
+	return "compression policy" + suffix
+}
+
+// Validate makes sure the minimum size is not negative.
+func (c *CompressionExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if c.MinSize < 0 {
+		verr.Add(c, "invalid minimum size %d, minimum size cannot be negative", c.MinSize)
+	}
+	return verr
+}
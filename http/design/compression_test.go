@@ -0,0 +1,31 @@
+package design_test
+
+import (
+	"testing"
+
+	"goa.design/goa/http/design"
+	"goa.design/goa/http/design/testdata"
+)
+
+func TestCompressionValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidCompressionDSL, ""},
+		{"negative min size", testdata.NegativeMinSizeCompressionDSL, `compression policy of service "NegativeMinSizeCompression": invalid minimum size -1, minimum size cannot be negative`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package design
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"goa.design/goa/eval"
+)
+
+type (
+	// CORSExpr describes the Cross-Origin Resource Sharing policy of an API
+	// or of a service.
+	CORSExpr struct {
+		// Origins lists the origins allowed to make cross-origin
+		// requests. An origin may be "*" to allow any origin or contain
+		// a single "*" wildcard, e.g. "https://*.goa.design".
+		Origins []string
+		// Methods lists the HTTP methods allowed in cross-origin
+		// requests. Defaults to the methods used by the service
+		// endpoints.
+		Methods []string
+		// Headers lists the request headers allowed in cross-origin
+		// requests.
+		Headers []string
+		// MaxAge is the number of seconds the result of a preflight
+		// request may be cached, zero means the value is not set.
+		MaxAge uint
+		// Parent expression, one of ServiceExpr or RootExpr.
+		Parent eval.Expression
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (c *CORSExpr) EvalName() string {
+	var suffix string
+	if c.Parent != nil {
+		suffix = fmt.Sprintf(" of %s", c.Parent.EvalName())
+	}
+	return "CORS policy" + suffix
+}
+
+// Validate makes sure the CORS policy defines at least one origin and that the
+// origins and methods are valid.
+func (c *CORSExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if len(c.Origins) == 0 {
+		verr.Add(c, "CORS policy must define at least one origin")
+	}
+	for _, o := range c.Origins {
+		if o == "*" {
+			continue
+		}
+		if strings.Count(o, "*") > 1 {
+			verr.Add(c, "invalid origin %q: at most one wildcard may be used", o)
+			continue
+		}
+		u, err := url.Parse(strings.Replace(o, "*", "x", 1))
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			verr.Add(c, "invalid origin %q: origin must be of the form scheme://host[:port]", o)
+		}
+	}
+	for _, m := range c.Methods {
+		switch m {
+		case "GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS", "TRACE", "CONNECT", "PATCH":
+		default:
+			verr.Add(c, "invalid method %q", m)
+		}
+	}
+	return verr
+}
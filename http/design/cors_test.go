@@ -0,0 +1,34 @@
+package design_test
+
+import (
+	"testing"
+
+	"goa.design/goa/http/design"
+	"goa.design/goa/http/design/testdata"
+)
+
+func TestCORSValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidCORSDSL, ""},
+		{"no origin", testdata.NoOriginCORSDSL, `CORS policy of service "NoOriginCORS": CORS policy must define at least one origin`},
+		{"invalid origin", testdata.InvalidOriginCORSDSL, `CORS policy of service "InvalidOriginCORS": invalid origin "goa.design": origin must be of the form scheme://host[:port]
+CORS policy of service "InvalidOriginCORS": invalid origin "https://*.*.goa.design": at most one wildcard may be used`},
+		{"invalid method", testdata.InvalidMethodCORSDSL, `CORS policy of service "InvalidMethodCORS": invalid method "FETCH"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
@@ -2,14 +2,34 @@ package design
 
 import (
 	"fmt"
+	"mime"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/dimfeld/httppath"
 	"goa.design/goa/design"
 	"goa.design/goa/eval"
 )
 
+const (
+	// QueryStyleForm is the default query string parameter style: each
+	// array element or map key is encoded in its own value, e.g.
+	// "tags=a&tags=b".
+	QueryStyleForm = "form"
+	// QueryStylePipeDelimited encodes the elements of an array query
+	// string parameter in a single value separated with pipes, e.g.
+	// "tags=a|b".
+	QueryStylePipeDelimited = "pipeDelimited"
+	// QueryStyleSpaceDelimited encodes the elements of an array query
+	// string parameter in a single value separated with spaces, e.g.
+	// "tags=a%20b".
+	QueryStyleSpaceDelimited = "spaceDelimited"
+	// QueryStyleDeepObject encodes the keys of a map query string
+	// parameter in the parameter names, e.g. "filter[color]=red".
+	QueryStyleDeepObject = "deepObject"
+)
+
 type (
 	// EndpointExpr describes a service endpoint. It embeds a
 	// MethodExpr and adds HTTP specific properties.
@@ -39,6 +59,8 @@ type (
 		Params *design.MappedAttributeExpr
 		// Headers defines the HTTP request headers.
 		Headers *design.MappedAttributeExpr
+		// Cookies defines the HTTP request cookies.
+		Cookies *design.MappedAttributeExpr
 		// Body describes the HTTP request body.
 		Body *design.AttributeExpr
 		// Responses is the list of all the possible success HTTP
@@ -47,14 +69,93 @@ type (
 		// HTTPErrors is the list of all the possible error HTTP
 		// responses.
 		HTTPErrors []*ErrorExpr
+		// ContentType is the content type of the request body if any.
+		ContentType string
+		// Consumes lists the MIME types the request body may be encoded
+		// with. The request decoder selects the decoder from the
+		// request Content-Type header.
+		Consumes []string
+		// ConstantHeaders lists the headers set to constant values in
+		// all the endpoint responses (including errors) indexed by name.
+		ConstantHeaders map[string]string
+		// MaxBodySize is the maximum size in bytes of the request body,
+		// zero means the limit of the service or of the API applies.
+		MaxBodySize int64
 		// MultipartRequest indicates that the request content type for
 		// the endpoint is a multipart type.
 		MultipartRequest bool
+		// StreamingMultipart indicates that the generated multipart
+		// decoder streams the request parts to the user provided
+		// function and enforces the multipart size limits.
+		StreamingMultipart bool
+		// MultipartMaxPartSize is the maximum size in bytes of a part
+		// of a streamed multipart request, zero means no limit.
+		MultipartMaxPartSize int64
+		// MultipartMaxSize is the maximum size in bytes of a streamed
+		// multipart request body, zero means no limit.
+		MultipartMaxSize int64
+		// BinaryStream indicates that the endpoint websocket stream
+		// uses binary frames encoded with a user provided codec.
+		BinaryStream bool
+		// NDJSONStream indicates that the endpoint streaming result is
+		// sent as newline delimited JSON over a chunked HTTP response
+		// instead of a websocket connection.
+		NDJSONStream bool
+		// StreamKeepAlive configures the ping control messages sent
+		// over the endpoint websocket connection, nil if the stream
+		// does not send pings.
+		StreamKeepAlive *StreamKeepAliveExpr
+		// StreamCompression indicates that the endpoint websocket
+		// connection negotiates the permessage-deflate compression
+		// extension.
+		StreamCompression bool
+		// StreamResume describes the token the generated resumable
+		// client streams send when re-dialing the endpoint, nil if
+		// the endpoint does not define one.
+		StreamResume *StreamResumeExpr
+		// Webhooks lists the webhooks sent to the URLs registered
+		// through the endpoint.
+		Webhooks []*WebhookExpr
+		// SkipRequestBodyEncodeDecode indicates that the service method
+		// receives the raw request body reader instead of having the
+		// request body decoded into the payload.
+		SkipRequestBodyEncodeDecode bool
+		// SkipResponseBodyEncodeDecode indicates that the service method
+		// returns a reader streamed as the response body instead of
+		// having the result encoded into the response body.
+		SkipResponseBodyEncodeDecode bool
+		// FieldsParam is the name of the query string parameter used
+		// by clients to select the result fields returned in the
+		// response body if any.
+		FieldsParam string
 		// Metadata is a set of key/value pairs with semantic that is
 		// specific to each generator, see dsl.Metadata.
 		Metadata design.MetadataExpr
 	}
 
+	// StreamKeepAliveExpr describes the keepalive of a websocket stream.
+	StreamKeepAliveExpr struct {
+		// PingInterval is the interval between two ping control
+		// messages.
+		PingInterval time.Duration
+		// PongWait is the maximum duration without receiving a ping
+		// or pong control message from the peer after which reads
+		// fail.
+		PongWait time.Duration
+		// WriteWait is the maximum duration of a write.
+		WriteWait time.Duration
+	}
+
+	// StreamResumeExpr describes the resume token of a websocket stream.
+	StreamResumeExpr struct {
+		// Attribute is the name of the result attribute that holds
+		// the resume token, e.g. the event ID.
+		Attribute string
+		// Header is the name of the request header used to send the
+		// token of the last received result when re-dialing.
+		Header string
+	}
+
 	// RouteExpr represents an endpoint route (HTTP endpoint).
 	RouteExpr struct {
 		// Method is the HTTP method, e.g. "GET", "POST", etc.
@@ -70,6 +171,8 @@ type (
 )
 
 // ExtractRouteWildcards returns the names of the wildcards that appear in path.
+// The names of catch-all wildcards (e.g. "{*path}") do not include the leading
+// "*".
 func ExtractRouteWildcards(path string) []string {
 	matches := WildcardRegex.FindAllStringSubmatch(path, -1)
 	wcs := make([]string, len(matches))
@@ -79,6 +182,17 @@ func ExtractRouteWildcards(path string) []string {
 	return wcs
 }
 
+// ExtractRouteCatchAll returns the name of the catch-all wildcard that ends
+// path, e.g. "path" for "/proxy/{*path}". It returns the empty string if path
+// does not end with a catch-all wildcard.
+func ExtractRouteCatchAll(path string) string {
+	matches := catchAllRegex.FindStringSubmatch(path)
+	if matches == nil {
+		return ""
+	}
+	return matches[1]
+}
+
 // Name of HTTP endpoint
 func (e *EndpointExpr) Name() string {
 	return e.MethodExpr.Name
@@ -113,6 +227,81 @@ func (e *EndpointExpr) HasAbsoluteRoutes() bool {
 	return true
 }
 
+// SelectableFields returns the paths of the result fields that clients may
+// select with the FieldSelection query string parameter. Nested fields are
+// separated with dots, e.g. "author.name".
+func (e *EndpointExpr) SelectableFields() []string {
+	att := e.MethodExpr.Result
+	if arr := design.AsArray(att.Type); arr != nil {
+		att = arr.ElemType
+	}
+	return selectableFields(att, "", make(map[string]struct{}))
+}
+
+// selectableFields returns the paths of the fields of att prefixed with
+// prefix. seen lists the user types being walked to stop on recursive types.
+func selectableFields(att *design.AttributeExpr, prefix string, seen map[string]struct{}) []string {
+	if ut, ok := att.Type.(design.UserType); ok {
+		if _, ok := seen[ut.ID()]; ok {
+			return nil
+		}
+		seen[ut.ID()] = struct{}{}
+		defer delete(seen, ut.ID())
+	}
+	obj := design.AsObject(att.Type)
+	if obj == nil {
+		return nil
+	}
+	var fields []string
+	for _, nat := range *obj {
+		path := prefix + nat.Name
+		fields = append(fields, path)
+		child := nat.Attribute
+		if arr := design.AsArray(child.Type); arr != nil {
+			child = arr.ElemType
+		}
+		fields = append(fields, selectableFields(child, path+".", seen)...)
+	}
+	return fields
+}
+
+// RequestMaxBodySize returns the maximum size in bytes of the endpoint request
+// body: the limit set on the endpoint, on its service or on the API in this
+// order. It returns zero if there is no limit.
+func (e *EndpointExpr) RequestMaxBodySize() int64 {
+	if e.MaxBodySize > 0 {
+		return e.MaxBodySize
+	}
+	if e.Service != nil && e.Service.MaxBodySize > 0 {
+		return e.Service.MaxBodySize
+	}
+	return Root.MaxBodySize
+}
+
+// RequestContentType returns the content type of the endpoint request body:
+// the content type set on the endpoint, the first MIME type listed in the
+// endpoint Consumes expression, the content type set on its service or on the
+// API in this order. It defaults to the first MIME type listed in the API Consumes
+// expression and returns the empty string if there is none.
+func (e *EndpointExpr) RequestContentType() string {
+	if e.ContentType != "" {
+		return e.ContentType
+	}
+	if len(e.Consumes) > 0 {
+		return e.Consumes[0]
+	}
+	if e.Service != nil && e.Service.ContentType != "" {
+		return e.Service.ContentType
+	}
+	if Root.ContentType != "" {
+		return Root.ContentType
+	}
+	if len(Root.Consumes) > 0 {
+		return Root.Consumes[0]
+	}
+	return ""
+}
+
 // PathParams computes a mapped attribute containing the subset of e.Params that
 // describe path parameters.
 func (e *EndpointExpr) PathParams() *design.MappedAttributeExpr {
@@ -188,6 +377,9 @@ func (e *EndpointExpr) Prepare() {
 
 	e.Headers = headers
 	e.Params = params
+	if e.Cookies == nil {
+		e.Cookies = design.NewEmptyMappedAttributeExpr()
+	}
 
 	// Initialize path params that are not defined explicitly in design.
 	for _, r := range e.Routes {
@@ -225,9 +417,11 @@ func (e *EndpointExpr) Prepare() {
 	// Prepare responses
 	for _, r := range e.Responses {
 		r.Prepare()
+		r.inheritConstantHeaders(e.ConstantHeaders)
 	}
 	for _, er := range e.HTTPErrors {
 		er.Response.Prepare()
+		er.Response.inheritConstantHeaders(e.ConstantHeaders)
 	}
 }
 
@@ -308,6 +502,131 @@ func (e *EndpointExpr) Validate() error {
 			}
 		}
 	}
+	if e.BinaryStream && !e.MethodExpr.IsStreaming() {
+		verr.Add(e, "BinaryStream is set but method does not define a streaming payload or result.")
+	}
+	if ka := e.StreamKeepAlive; ka != nil {
+		if !e.MethodExpr.IsStreaming() || e.NDJSONStream {
+			verr.Add(e, "StreamKeepAlive is set but method does not use a websocket stream.")
+		}
+		if ka.PingInterval <= 0 || ka.PongWait <= 0 || ka.WriteWait <= 0 {
+			verr.Add(e, "StreamKeepAlive durations must be positive.")
+		} else if ka.PongWait <= ka.PingInterval {
+			verr.Add(e, "StreamKeepAlive pong wait must be greater than the ping interval.")
+		}
+	}
+	if e.StreamCompression && (!e.MethodExpr.IsStreaming() || e.NDJSONStream) {
+		verr.Add(e, "StreamCompression is set but method does not use a websocket stream.")
+	}
+	if r := e.StreamResume; r != nil {
+		if e.MethodExpr.Stream != design.ServerStreamKind || e.NDJSONStream {
+			verr.Add(e, "StreamResume is set but method does not stream its result over a websocket connection.")
+		} else if att := e.MethodExpr.Result.Find(r.Attribute); att == nil {
+			verr.Add(e, "StreamResume attribute %q is not an attribute of the method result.", r.Attribute)
+		} else if att.Type != design.String {
+			verr.Add(e, "StreamResume attribute %q must be a string.", r.Attribute)
+		}
+		if r.Header == "" {
+			verr.Add(e, "StreamResume header name cannot be empty.")
+		}
+	}
+	for _, w := range e.Webhooks {
+		verr.Merge(w.Validate())
+	}
+	if e.NDJSONStream {
+		if e.MethodExpr.Stream != design.ServerStreamKind {
+			verr.Add(e, "NDJSONStream is set but method does not define a streaming result or defines a streaming payload.")
+		}
+		if e.BinaryStream {
+			verr.Add(e, "NDJSONStream and BinaryStream cannot both be set.")
+		}
+	}
+	if e.FieldsParam != "" {
+		if e.MethodExpr.IsStreaming() || e.SkipResponseBodyEncodeDecode {
+			verr.Add(e, "FieldSelection cannot be used with streaming methods or with SkipResponseBodyEncodeDecode.")
+		} else if len(e.SelectableFields()) == 0 {
+			verr.Add(e, "FieldSelection is set but the method result is not an object or an array of objects.")
+		}
+		for _, nat := range *design.AsObject(e.Params.Type) {
+			if e.Params.ElemName(nat.Name) == e.FieldsParam {
+				verr.Add(e, "FieldSelection parameter %q conflicts with the parameter of attribute %q.", e.FieldsParam, nat.Name)
+			}
+		}
+	}
+	if e.SkipRequestBodyEncodeDecode {
+		if e.MethodExpr.IsStreaming() {
+			verr.Add(e, "SkipRequestBodyEncodeDecode cannot be used with streaming methods.")
+		}
+		if e.MultipartRequest {
+			verr.Add(e, "SkipRequestBodyEncodeDecode and MultipartRequest cannot both be set.")
+		}
+		if e.Body != nil {
+			verr.Add(e, "Body cannot be defined when SkipRequestBodyEncodeDecode is set.")
+		} else if e.MethodExpr.Payload.Type != design.Empty && RequestBody(e).Type != design.Empty {
+			verr.Add(e, "SkipRequestBodyEncodeDecode is set but not all payload attributes are mapped to params, headers or cookies.")
+		}
+	}
+	if e.SkipResponseBodyEncodeDecode {
+		if e.MethodExpr.IsStreaming() {
+			verr.Add(e, "SkipResponseBodyEncodeDecode cannot be used with streaming methods.")
+		}
+		if _, ok := e.MethodExpr.Result.Type.(*design.ResultTypeExpr); ok {
+			verr.Add(e, "SkipResponseBodyEncodeDecode cannot be used with methods that return a result type.")
+		}
+		for _, r := range e.Responses {
+			if r.StatusCode < 400 && ResponseBody(e, r).Type != design.Empty {
+				verr.Add(e, "Response body must be empty when SkipResponseBodyEncodeDecode is set, map all result attributes to headers, cookies or trailers.")
+			}
+		}
+	}
+	if e.MethodExpr.Proxy != nil {
+		if e.SkipRequestBodyEncodeDecode || e.SkipResponseBodyEncodeDecode {
+			verr.Add(e, "SkipRequestBodyEncodeDecode and SkipResponseBodyEncodeDecode cannot be used with proxied methods.")
+		}
+	}
+	if e.ContentType != "" {
+		if e.MultipartRequest {
+			verr.Add(e, "ContentType cannot be used with MultipartRequest.")
+		}
+		if e.MethodExpr.IsStreaming() {
+			verr.Add(e, "ContentType cannot be used with streaming methods.")
+		}
+	}
+	if len(e.Consumes) > 0 {
+		for _, c := range e.Consumes {
+			if _, _, err := mime.ParseMediaType(c); err != nil {
+				verr.Add(e, "invalid MIME type %q in Consumes: %s", c, err)
+			}
+		}
+		if e.MultipartRequest {
+			verr.Add(e, "Consumes cannot be used with MultipartRequest, use the \"multipart/form-data\" MIME type instead.")
+		}
+		if e.MethodExpr.IsStreaming() {
+			verr.Add(e, "Consumes cannot be used with streaming methods.")
+		}
+		if e.MethodExpr.Payload.Type == design.Empty {
+			verr.Add(e, "Consumes cannot be used with methods that have no payload.")
+		}
+		if e.ContentType != "" {
+			found := false
+			for _, c := range e.Consumes {
+				if c == e.ContentType {
+					found = true
+					break
+				}
+			}
+			if !found {
+				verr.Add(e, "ContentType %q must be one of the MIME types listed in Consumes.", e.ContentType)
+			}
+		}
+	}
+	if e.StreamingMultipart {
+		if e.MultipartMaxPartSize < 0 || e.MultipartMaxSize < 0 {
+			verr.Add(e, "StreamingMultipartRequest sizes must be positive.")
+		} else if e.MultipartMaxSize > 0 && e.MultipartMaxPartSize > e.MultipartMaxSize {
+			verr.Add(e, "StreamingMultipartRequest maximum part size (%d) cannot be greater than the maximum request size (%d).", e.MultipartMaxPartSize, e.MultipartMaxSize)
+		}
+	}
 	if hasTags && allTagged {
 		verr.Add(e, "All responses define a Tag, at least one response must define no Tag.")
 	}
@@ -318,6 +637,8 @@ func (e *EndpointExpr) Validate() error {
 	// Make sure parameters and headers use compatible types
 	verr.Merge(e.validateParams())
 	verr.Merge(e.validateHeaders())
+	verr.Merge(e.validateCookies())
+	verr.Merge(e.validateInternal())
 
 	// Validate body attribute (required fields exist etc.)
 	if e.Body != nil {
@@ -482,8 +803,7 @@ func (e *EndpointExpr) Finalize() {
 			var patt *design.AttributeExpr
 			var required bool
 			if payload != nil {
-				patt = payload.Attribute(nat.Name)
-				required = e.MethodExpr.Payload.IsRequired(nat.Name)
+				patt, required = NestedAttribute(e.MethodExpr.Payload, nat.Name)
 			} else {
 				patt = e.MethodExpr.Payload
 				required = true
@@ -499,20 +819,31 @@ func (e *EndpointExpr) Finalize() {
 	}
 	init(e.Params)
 	init(e.Headers)
+	init(e.Cookies)
 	if e.Body != nil && e.Body.Type != design.Empty && design.IsObject(e.Body.Type) {
 		ma := design.NewMappedAttributeExpr(e.Body)
 		init(ma)
+		removeEnumTypes(ma.AttributeExpr)
 		e.Body = ma.AttributeExpr
 	} else {
 		// No explicit body, compute it
 		e.Body = RequestBody(e)
 	}
+	if hasInternalAttributes(e.Body) {
+		// Explicit bodies use the payload types, copy them before
+		// removing the internal attributes.
+		e.Body = design.DupAtt(e.Body)
+		removeInternalAttributes(e.Body)
+	}
 
 	// Initialize responses parent, headers and body
 	for _, r := range e.Responses {
 		r.Finalize(e, e.MethodExpr.Result)
 		if r.Body == nil {
 			r.Body = ResponseBody(e, r)
+		} else if hasInternalAttributes(r.Body) {
+			r.Body = design.DupAtt(r.Body)
+			removeInternalAttributes(r.Body)
 		}
 
 		// Initialize response content type if result is media type.
@@ -555,6 +886,11 @@ func (e *EndpointExpr) validateParams() *eval.ValidationErrors {
 			verr.Merge(nat.Attribute.Validate(ctx, e))
 		}
 	}
+	for _, nat := range pparams {
+		if _, ok := nat.Attribute.Metadata["http:query:style"]; ok {
+			verr.Add(e, "path parameter %s cannot define a query string style", nat.Name)
+		}
+	}
 	for _, nat := range qparams {
 		if design.IsObject(nat.Attribute.Type) {
 			verr.Add(e, "query parameter %s cannot be an object, query parameter types must be primitive, array or map (query string only)", nat.Name)
@@ -566,6 +902,7 @@ func (e *EndpointExpr) validateParams() *eval.ValidationErrors {
 			ctx := fmt.Sprintf("query parameter %s", nat.Name)
 			verr.Merge(nat.Attribute.Validate(ctx, e))
 		}
+		verr.Merge(e.validateQueryStyle(nat))
 	}
 	if e.MethodExpr.Payload == nil {
 		verr.Add(e, "Parameters are defined but Payload is not defined")
@@ -599,6 +936,42 @@ func (e *EndpointExpr) validateParams() *eval.ValidationErrors {
 
 // validateHeaders makes sure headers are of an allowed type and the method
 // payload contains the headers.
+// validateQueryStyle validates the "http:query:style" metadata of the query
+// string parameter nat.
+func (e *EndpointExpr) validateQueryStyle(nat *design.NamedAttributeExpr) *eval.ValidationErrors {
+	styles, ok := nat.Attribute.Metadata["http:query:style"]
+	if !ok || len(styles) == 0 {
+		return nil
+	}
+	verr := new(eval.ValidationErrors)
+	typ := nat.Attribute.Type
+	if e.MethodExpr.Payload != nil {
+		if design.IsObject(e.MethodExpr.Payload.Type) {
+			if patt := e.MethodExpr.Payload.Find(strings.Split(nat.Name, ":")[0]); patt != nil {
+				typ = patt.Type
+			}
+		} else if e.MethodExpr.Payload.Type != design.Empty {
+			typ = e.MethodExpr.Payload.Type
+		}
+	}
+	switch styles[0] {
+	case QueryStyleForm:
+	case QueryStylePipeDelimited, QueryStyleSpaceDelimited:
+		if !design.IsArray(typ) {
+			verr.Add(e, "query parameter %s uses the %s style but is not an array", nat.Name, styles[0])
+		}
+	case QueryStyleDeepObject:
+		if !design.IsMap(typ) {
+			verr.Add(e, "query parameter %s uses the %s style but is not a map", nat.Name, styles[0])
+		} else if e.MapQueryParams != nil {
+			verr.Add(e, "query parameter %s cannot use the %s style with MapParams", nat.Name, styles[0])
+		}
+	default:
+		verr.Add(e, "invalid query string style %q for query parameter %s, style must be one of %q, %q, %q or %q", styles[0], nat.Name, QueryStyleForm, QueryStylePipeDelimited, QueryStyleSpaceDelimited, QueryStyleDeepObject)
+	}
+	return verr
+}
+
 func (e *EndpointExpr) validateHeaders() *eval.ValidationErrors {
 	headers := design.AsObject(e.Headers.Type)
 	if len(*headers) == 0 {
@@ -626,8 +999,13 @@ func (e *EndpointExpr) validateHeaders() *eval.ValidationErrors {
 		case *design.Object:
 			for _, nat := range *headers {
 				name := strings.Split(nat.Name, ":")[0]
-				if e.MethodExpr.Payload.Find(name) == nil {
+				if e.MethodExpr.Payload.Find(name) != nil {
+					continue
+				}
+				if att, _ := NestedAttribute(e.MethodExpr.Payload, name); att == nil {
 					verr.Add(e, "header %q is not found in payload.", nat.Name)
+				} else if !design.IsPrimitive(att.Type) && !design.IsArray(att.Type) {
+					verr.Add(e, "header %q must map to a primitive or array payload attribute.", nat.Name)
 				}
 			}
 		case *design.Array:
@@ -643,6 +1021,89 @@ func (e *EndpointExpr) validateHeaders() *eval.ValidationErrors {
 	return verr
 }
 
+// validateCookies makes sure cookies are of an allowed type and the method
+// payload contains the cookies.
+func (e *EndpointExpr) validateCookies() *eval.ValidationErrors {
+	cookies := design.AsObject(e.Cookies.Type)
+	if len(*cookies) == 0 {
+		return nil
+	}
+	verr := new(eval.ValidationErrors)
+	for _, nat := range *cookies {
+		if !design.IsPrimitive(nat.Attribute.Type) {
+			verr.Add(e, "cookie %s must be a primitive", nat.Name)
+		} else {
+			ctx := fmt.Sprintf("cookie %s", nat.Name)
+			verr.Merge(nat.Attribute.Validate(ctx, e))
+		}
+	}
+	if e.MethodExpr.Payload == nil {
+		verr.Add(e, "Cookies are defined but Payload is not defined")
+	} else {
+		switch e.MethodExpr.Payload.Type.(type) {
+		case *design.Object:
+			for _, nat := range *cookies {
+				name := strings.Split(nat.Name, ":")[0]
+				if e.MethodExpr.Payload.Find(name) == nil {
+					verr.Add(e, "cookie %q is not found in payload.", nat.Name)
+				}
+			}
+		case *design.Array:
+			verr.Add(e, "Payload type is array but HTTP endpoint defines cookies. Array payloads cannot be decoded from HTTP request cookies.")
+		case *design.Map:
+			verr.Add(e, "Payload type is map but HTTP endpoint defines cookies. Map payloads can only be decoded from HTTP request bodies or query strings.")
+		default:
+			if len(*cookies) > 1 {
+				verr.Add(e, "Payload type is not an object but HTTP endpoint defines multiple cookies. At most one cookie must be defined.")
+			}
+		}
+	}
+	return verr
+}
+
+// validateInternal makes sure the payload attributes marked as internal with
+// the "transport:omit" metadata are not mapped to parameters, headers, cookies
+// or to the explicit request body.
+func (e *EndpointExpr) validateInternal() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if !design.IsObject(e.MethodExpr.Payload.Type) {
+		return verr
+	}
+	check := func(kind string, ma *design.MappedAttributeExpr) {
+		if ma == nil {
+			return
+		}
+		for _, nat := range *design.AsObject(ma.Type) {
+			if isInternal(e.MethodExpr.Payload.Find(nat.Name)) {
+				verr.Add(e, "%s %q is mapped to internal payload attribute.", kind, ma.ElemName(nat.Name))
+			}
+		}
+	}
+	check("parameter", e.Params)
+	check("header", e.Headers)
+	check("cookie", e.Cookies)
+	if e.MapQueryParams != nil && *e.MapQueryParams != "" {
+		if isInternal(e.MethodExpr.Payload.Find(*e.MapQueryParams)) {
+			verr.Add(e, "MapParams is set to internal payload attribute %q.", *e.MapQueryParams)
+		}
+	}
+	if e.Body != nil {
+		if att, ok := e.Body.Metadata["origin:attribute"]; ok {
+			if isInternal(e.MethodExpr.Payload.Find(att[0])) {
+				verr.Add(e, "Body %q is an internal payload attribute.", att[0])
+			}
+		} else if bObj := design.AsObject(e.Body.Type); bObj != nil {
+			for _, nat := range *bObj {
+				name := strings.Split(nat.Name, ":")[0]
+				if isInternal(e.MethodExpr.Payload.Find(name)) {
+					verr.Add(e, "Body %q is an internal payload attribute.", nat.Name)
+				}
+			}
+		}
+	}
+	return verr
+}
+
 // EvalName returns the generic definition name used in error messages.
 func (r *RouteExpr) EvalName() string {
 	return fmt.Sprintf(`route %s "%s" of %s`, r.Method, r.Path, r.Endpoint.EvalName())
@@ -687,6 +1148,26 @@ func (r *RouteExpr) Validate() *eval.ValidationErrors {
 			wcs[match[1]] = struct{}{}
 		}
 	}
+
+	// Make sure catch-all wildcards end the path and map to strings
+	for _, path := range paths {
+		catchAll := ExtractRouteCatchAll(path)
+		for _, match := range catchAllAnywhereRegex.FindAllStringSubmatch(path, -1) {
+			if match[1] != catchAll {
+				verr.Add(r, "Catch-all wildcard %q must be the last segment of full path %q", match[1], path)
+			}
+		}
+		if catchAll == "" || r.Endpoint.MethodExpr.Payload == nil {
+			continue
+		}
+		att := r.Endpoint.MethodExpr.Payload
+		if design.IsObject(att.Type) {
+			att = att.Find(catchAll)
+		}
+		if att != nil && att.Type != design.String {
+			verr.Add(r, "Catch-all wildcard %q must map to a String attribute", catchAll)
+		}
+	}
 	return verr
 }
 
@@ -737,6 +1218,32 @@ func (r *RouteExpr) IsAbsolute() bool {
 	return strings.HasPrefix(r.Path, "//")
 }
 
+// NestedAttribute returns the attribute of att with the given name. The name
+// may be a dot separated path to an attribute nested in object attributes, e.g.
+// "tenant.id". The returned boolean is true if the attribute and all its parents
+// are required. NestedAttribute returns nil if there is no such attribute.
+func NestedAttribute(att *design.AttributeExpr, name string) (*design.AttributeExpr, bool) {
+	if obj := design.AsObject(att.Type); obj != nil {
+		if child := obj.Attribute(name); child != nil {
+			return child, att.IsRequired(name)
+		}
+	}
+	required := true
+	for _, n := range strings.Split(name, ".") {
+		obj := design.AsObject(att.Type)
+		if obj == nil {
+			return nil, false
+		}
+		child := obj.Attribute(n)
+		if child == nil {
+			return nil, false
+		}
+		required = required && att.IsRequired(n)
+		att = child
+	}
+	return att, required
+}
+
 // initAttrFromDesign overrides the type of att with the one of patt and
 // initializes other non-initialized fields of att with the one of patt except
 // Metadata.
@@ -766,12 +1273,14 @@ func initAttrFromDesign(att, patt *design.AttributeExpr) {
 }
 
 // findKey finds the given key in the endpoint expression and returns the
-// transport element name and the position (header, query, or body).
+// transport element name and the position (header, query, cookie or body).
 func findKey(e *EndpointExpr, keyAtt string) (string, string) {
 	if n, exists := e.Params.FindKey(keyAtt); exists {
 		return n, "query"
 	} else if n, exists := e.Headers.FindKey(keyAtt); exists {
 		return n, "header"
+	} else if n, exists := e.Cookies.FindKey(keyAtt); exists {
+		return n, "cookie"
 	} else if e.Body == nil {
 		return "", "header"
 	}
@@ -1,6 +1,7 @@
 package design_test
 
 import (
+	"strings"
 	"testing"
 
 	"goa.design/goa/http/design"
@@ -29,3 +30,401 @@ func TestRouteValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestCatchAllValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidCatchAllDSL, ""},
+		{"not-last", testdata.NotLastCatchAllDSL, `Catch-all wildcard "key" must be the last segment of full path "/objects/{*key}/meta"`},
+		{"not-string", testdata.NotStringCatchAllDSL, `Catch-all wildcard "key" must map to a String attribute`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestBinaryStreamValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidBinaryStreamDSL, ""},
+		{"not streaming", testdata.InvalidBinaryStreamDSL, `service "InvalidBinaryStream" HTTP endpoint "Method": BinaryStream is set but method does not define a streaming payload or result.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestSkipBodyEncodeDecodeValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidSkipBodyEncodeDecodeDSL, ""},
+		{"request body", testdata.InvalidSkipRequestBodyEncodeDecodeDSL, `service "InvalidSkipRequestBodyEncodeDecode" HTTP endpoint "Method": SkipRequestBodyEncodeDecode is set but not all payload attributes are mapped to params, headers or cookies.`},
+		{"response body", testdata.InvalidSkipResponseBodyEncodeDecodeDSL, `service "InvalidSkipResponseBodyEncodeDecode" HTTP endpoint "Method": Response body must be empty when SkipResponseBodyEncodeDecode is set, map all result attributes to headers, cookies or trailers.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamingMultipartValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidStreamingMultipartDSL, ""},
+		{"part size", testdata.InvalidStreamingMultipartDSL, `service "InvalidStreamingMultipart" HTTP endpoint "Method": StreamingMultipartRequest maximum part size (4096) cannot be greater than the maximum request size (1024).`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestContentTypeValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidContentTypeDSL, ""},
+		{"multipart", testdata.MultipartContentTypeDSL, `service "MultipartContentType" HTTP endpoint "Method": ContentType cannot be used with MultipartRequest.`},
+		{"invalid", testdata.InvalidContentTypeDSL, `invalid content type "cbor", content type must be of the form type/subtype in service "InvalidContentType" HTTP endpoint "Method"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				// DSL errors are prefixed with the location
+				// of the DSL function call.
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestConsumesValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidConsumesDSL, ""},
+		{"multipart", testdata.MultipartConsumesDSL, `service "MultipartConsumes" HTTP endpoint "Method": Consumes cannot be used with MultipartRequest, use the "multipart/form-data" MIME type instead.`},
+		{"no-payload", testdata.NoPayloadConsumesDSL, `service "NoPayloadConsumes" HTTP endpoint "Method": Consumes cannot be used with methods that have no payload.`},
+		{"content-type", testdata.ContentTypeNotConsumedDSL, `service "ContentTypeNotConsumed" HTTP endpoint "Method": ContentType "application/cbor" must be one of the MIME types listed in Consumes.`},
+		{"invalid", testdata.InvalidConsumesDSL, `service "InvalidConsumes" HTTP endpoint "Method": invalid MIME type "application/json; charset" in Consumes`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestNestedHeaderValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidNestedHeaderDSL, ""},
+		{"missing", testdata.MissingNestedHeaderDSL, `service "MissingNestedHeader" HTTP endpoint "Method": header "tenant.name" is not found in payload.`},
+		{"object", testdata.ObjectNestedHeaderDSL, `service "ObjectNestedHeader" HTTP endpoint "Method": header "tenant.org" must map to a primitive or array payload attribute.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestQueryStyleValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidQueryStyleDSL, ""},
+		{"invalid", testdata.InvalidQueryStyleDSL, `service "InvalidQueryStyle" HTTP endpoint "Method": invalid query string style "commaDelimited" for query parameter tags, style must be one of "form", "pipeDelimited", "spaceDelimited" or "deepObject"`},
+		{"delimited-not-array", testdata.DelimitedNotArrayQueryStyleDSL, `service "DelimitedNotArrayQueryStyle" HTTP endpoint "Method": query parameter tag uses the spaceDelimited style but is not an array`},
+		{"deep-object-not-map", testdata.DeepObjectNotMapQueryStyleDSL, `service "DeepObjectNotMapQueryStyle" HTTP endpoint "Method": query parameter tags uses the deepObject style but is not a map`},
+		{"path", testdata.PathQueryStyleDSL, `service "PathQueryStyle" HTTP endpoint "Method": path parameter ids cannot define a query string style`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestNDJSONStreamValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidNDJSONStreamDSL, ""},
+		{"not streaming", testdata.NotStreamingNDJSONStreamDSL, `service "NotStreamingNDJSONStream" HTTP endpoint "Method": NDJSONStream is set but method does not define a streaming result or defines a streaming payload.`},
+		{"binary", testdata.BinaryNDJSONStreamDSL, `service "BinaryNDJSONStream" HTTP endpoint "Method": NDJSONStream and BinaryStream cannot both be set.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamKeepAliveValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidStreamKeepAliveDSL, ""},
+		{"ndjson", testdata.NDJSONStreamKeepAliveDSL, `service "NDJSONStreamKeepAlive" HTTP endpoint "Method": StreamKeepAlive is set but method does not use a websocket stream.`},
+		{"pong wait", testdata.PongWaitStreamKeepAliveDSL, `service "PongWaitStreamKeepAlive" HTTP endpoint "Method": StreamKeepAlive pong wait must be greater than the ping interval.`},
+		{"zero", testdata.ZeroStreamKeepAliveDSL, `service "ZeroStreamKeepAlive" HTTP endpoint "Method": StreamKeepAlive durations must be positive.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamCompressionValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidStreamCompressionDSL, ""},
+		{"not streaming", testdata.NotStreamingStreamCompressionDSL, `service "NotStreamingStreamCompression" HTTP endpoint "Method": StreamCompression is set but method does not use a websocket stream.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamResumeValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidStreamResumeDSL, ""},
+		{"ndjson", testdata.NDJSONStreamResumeDSL, `service "NDJSONStreamResume" HTTP endpoint "Method": StreamResume is set but method does not stream its result over a websocket connection.`},
+		{"missing attribute", testdata.MissingAttributeStreamResumeDSL, `service "MissingAttributeStreamResume" HTTP endpoint "Method": StreamResume attribute "seq" is not an attribute of the method result.`},
+		{"not string", testdata.NotStringStreamResumeDSL, `service "NotStringStreamResume" HTTP endpoint "Method": StreamResume attribute "id" must be a string.`},
+		{"empty header", testdata.EmptyHeaderStreamResumeDSL, `service "EmptyHeaderStreamResume" HTTP endpoint "Method": StreamResume header name cannot be empty.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestWebhookValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidWebhookDSL, ""},
+		{"duplicate", testdata.DuplicateWebhookDSL, `webhook "shipped" of service "DuplicateWebhook" HTTP endpoint "Method2": webhook is already declared by service "DuplicateWebhook" HTTP endpoint "Method".`},
+		{"primitive payload", testdata.PrimitivePayloadWebhookDSL, `webhook "shipped" of service "PrimitivePayloadWebhook" HTTP endpoint "Method": webhook payload must be an object.`},
+		{"missing callback URL", testdata.MissingCallbackURLWebhookDSL, `webhook "shipped" of service "MissingCallbackURLWebhook" HTTP endpoint "Method": callback URL attribute is not defined, use CallbackURL to define it.`},
+		{"unknown callback URL", testdata.UnknownCallbackURLWebhookDSL, `webhook "shipped" of service "UnknownCallbackURLWebhook" HTTP endpoint "Method": callback URL attribute "url" is not an attribute of the method payload.`},
+		{"not string callback URL", testdata.NotStringCallbackURLWebhookDSL, `webhook "shipped" of service "NotStringCallbackURLWebhook" HTTP endpoint "Method": callback URL attribute "callback_url" must be a string.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIKeyLocation(t *testing.T) {
+	cases := []struct {
+		Method string
+		Name   string
+		In     string
+	}{
+		{"Header", "Authorization", "header"},
+		{"Query", "k", "query"},
+		{"Cookie", "k", "cookie"},
+	}
+	root := design.RunHTTPDSL(t, testdata.APIKeyLocationDSL)
+	svc := root.Service("APIKeyLocation")
+	for _, c := range cases {
+		t.Run(c.Method, func(t *testing.T) {
+			e := svc.Endpoint(c.Method)
+			if e == nil {
+				t.Fatalf("endpoint %q not found", c.Method)
+			}
+			sch := e.MethodExpr.Requirements[0].Schemes[0]
+			if sch.Name != c.Name {
+				t.Errorf("got name %q, expected %q", sch.Name, c.Name)
+			}
+			if sch.In != c.In {
+				t.Errorf("got location %q, expected %q", sch.In, c.In)
+			}
+		})
+	}
+}
+
+func TestFieldSelectionValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidFieldSelectionDSL, ""},
+		{"primitive", testdata.PrimitiveFieldSelectionDSL, `service "PrimitiveFieldSelection" HTTP endpoint "Method": FieldSelection is set but the method result is not an object or an array of objects.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestInternalValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidInternalDSL, ""},
+		{"param", testdata.InternalParamDSL, `service "InternalParam" HTTP endpoint "Method": parameter "secret" is mapped to internal payload attribute.`},
+		{"header", testdata.InternalHeaderDSL, `service "InternalHeader" HTTP endpoint "Method": header "X-Secret" is mapped to internal payload attribute.`},
+		{"response header", testdata.InternalResponseHeaderDSL, `HTTP response of service "InternalResponseHeader" HTTP endpoint "Method": header "X-Secret" is mapped to internal result attribute.`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
@@ -42,6 +42,9 @@ func (e *ErrorExpr) Validate() *eval.ValidationErrors {
 			verr.Add(e, "Error %#v does not match an error defined in the API", e.Name)
 		}
 	}
+	if e.Response.Trailers != nil && !e.Response.Trailers.IsEmpty() {
+		verr.Add(e, "Error %#v response cannot define trailers, only responses that stream their body may", e.Name)
+	}
 	return verr
 }
 
@@ -57,6 +60,7 @@ func (e *ErrorExpr) Finalize(a *EndpointExpr) {
 		ee = design.Root.Error(e.Name)
 	}
 	e.ErrorExpr = ee
+	e.mapTaggedHeaders()
 	e.Response.Finalize(a, e.AttributeExpr)
 	if e.Response.Body == nil {
 		e.Response.Body = ErrorResponseBody(a, e)
@@ -84,3 +88,38 @@ func (e *ErrorExpr) Dup() *ErrorExpr {
 		Response:  e.Response.Dup(),
 	}
 }
+
+// mapTaggedHeaders adds the error attributes that define the "http:header"
+// metadata to the response headers unless they are already mapped explicitly.
+// The metadata value if any is used as header name, the attribute name is used
+// otherwise.
+func (e *ErrorExpr) mapTaggedHeaders() {
+	obj := design.AsObject(e.Type)
+	if obj == nil {
+		return
+	}
+	if e.Response.Headers == nil {
+		e.Response.Headers = design.NewEmptyMappedAttributeExpr()
+	}
+	for _, nat := range *obj {
+		tag, ok := nat.Attribute.Metadata["http:header"]
+		if !ok {
+			continue
+		}
+		if _, ok := e.Response.Headers.FindKey(nat.Name); ok {
+			continue
+		}
+		name := nat.Name
+		if len(tag) > 0 && tag[0] != "" {
+			name = tag[0]
+		}
+		e.Response.Headers.Type.(*design.Object).Set(nat.Name, design.DupAtt(nat.Attribute))
+		e.Response.Headers.Map(name, nat.Name)
+		if e.AttributeExpr.IsRequired(nat.Name) {
+			if e.Response.Headers.Validation == nil {
+				e.Response.Headers.Validation = &design.ValidationExpr{}
+			}
+			e.Response.Headers.Validation.AddRequired(nat.Name)
+		}
+	}
+}
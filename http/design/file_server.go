@@ -2,10 +2,12 @@ package design
 
 import (
 	"fmt"
+	"os"
 	"path"
 	"strings"
 
 	"goa.design/goa/design"
+	"goa.design/goa/eval"
 )
 
 type (
@@ -21,6 +23,15 @@ type (
 		FilePath string
 		// RequestPaths is the list of HTTP paths that serve the assets.
 		RequestPaths []string
+		// AcceptRanges is true if the file server handles range
+		// requests explicitly.
+		AcceptRanges bool
+		// ChunkSize is the maximum number of bytes returned in a
+		// partial response, zero means there is no limit.
+		ChunkSize int64
+		// Embed is true if the assets are embedded in the generated
+		// server package rather than read from the file system.
+		Embed bool
 		// Metadata is a list of key/value pairs
 		Metadata design.MetadataExpr
 	}
@@ -36,6 +47,28 @@ func (f *FileServerExpr) EvalName() string {
 	return prefix + suffix
 }
 
+// Validate makes sure the chunk size is valid and that embedded assets exist.
+func (f *FileServerExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	if f.ChunkSize < 0 {
+		verr.Add(f, "invalid chunk size %d, chunk size must be positive", f.ChunkSize)
+	}
+	if f.Embed {
+		if path.IsAbs(f.FilePath) || strings.HasPrefix(path.Clean(f.FilePath), "..") {
+			verr.Add(f, "embedded file path %q must be relative to the directory where the code is generated", f.FilePath)
+		} else if fi, err := os.Stat(f.FilePath); err != nil {
+			verr.Add(f, "embedded file path %q not found", f.FilePath)
+		} else if fi.IsDir() != f.IsDir() {
+			if f.IsDir() {
+				verr.Add(f, "embedded file path %q must be a directory when the request path uses a wildcard", f.FilePath)
+			} else {
+				verr.Add(f, "embedded file path %q must be a file when the request path does not use a wildcard", f.FilePath)
+			}
+		}
+	}
+	return verr
+}
+
 // Finalize normalizes the request path.
 func (f *FileServerExpr) Finalize() {
 	current := f.RequestPaths[0]
@@ -0,0 +1,35 @@
+package design_test
+
+import (
+	"testing"
+
+	"goa.design/goa/http/design"
+	"goa.design/goa/http/design/testdata"
+)
+
+func TestFileServerValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidAcceptRangesDSL, ""},
+		{"invalid chunk size", testdata.InvalidChunkSizeDSL, `service "InvalidChunkSize" file server /www/data/videos: invalid chunk size -1, chunk size must be positive`},
+		{"valid embed", testdata.ValidEmbedDSL, ""},
+		{"absolute embed", testdata.AbsoluteEmbedDSL, `service "AbsoluteEmbed" file server /www/data: embedded file path "/www/data" must be relative to the directory where the code is generated`},
+		{"embed not found", testdata.NotFoundEmbedDSL, `service "NotFoundEmbed" file server testdata/notfound: embedded file path "testdata/notfound" not found`},
+		{"embed not dir", testdata.NotDirEmbedDSL, `service "NotDirEmbed" file server testdata/assets/index.html: embedded file path "testdata/assets/index.html" must be a directory when the request path uses a wildcard`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package design
+
+import (
+	"strings"
+
+	"goa.design/goa/eval"
+)
+
+type (
+	// HealthCheckExpr describes the liveness and readiness check endpoints
+	// mounted by the API servers.
+	HealthCheckExpr struct {
+		// LivenessPath is the path of the liveness check endpoint.
+		LivenessPath string
+		// ReadinessPath is the path of the readiness check endpoint.
+		ReadinessPath string
+		// Root is the API HTTP expression.
+		Root *RootExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (h *HealthCheckExpr) EvalName() string {
+	return "health check of " + h.Root.EvalName()
+}
+
+// Validate makes sure the health check paths are absolute, distinct and do not
+// clash with the paths of the API endpoints.
+func (h *HealthCheckExpr) Validate() error {
+	verr := new(eval.ValidationErrors)
+	for _, p := range []string{h.LivenessPath, h.ReadinessPath} {
+		if !strings.HasPrefix(p, "/") {
+			verr.Add(h, "invalid path %q, path must start with /", p)
+		}
+	}
+	if h.LivenessPath == h.ReadinessPath {
+		verr.Add(h, "liveness and readiness paths must be different, got %q", h.LivenessPath)
+	}
+	for _, svc := range h.Root.HTTPServices {
+		for _, e := range svc.HTTPEndpoints {
+			for _, r := range e.Routes {
+				if r.Method != "GET" && r.Method != "HEAD" {
+					continue
+				}
+				for _, p := range r.FullPaths() {
+					if p == h.LivenessPath || p == h.ReadinessPath {
+						verr.Add(h, "path %q is already used by %s", p, e.EvalName())
+					}
+				}
+			}
+		}
+	}
+	return verr
+}
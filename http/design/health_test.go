@@ -0,0 +1,33 @@
+package design_test
+
+import (
+	"testing"
+
+	"goa.design/goa/http/design"
+	"goa.design/goa/http/design/testdata"
+)
+
+func TestHealthCheckValidation(t *testing.T) {
+	cases := []struct {
+		Name  string
+		DSL   func()
+		Error string
+	}{
+		{"valid", testdata.ValidHealthCheckDSL, ""},
+		{"relative path", testdata.RelativePathHealthCheckDSL, `health check of API HTTP: invalid path "live", path must start with /`},
+		{"same paths", testdata.SamePathsHealthCheckDSL, `health check of API HTTP: liveness and readiness paths must be different, got "/health"`},
+		{"clashing path", testdata.ClashingPathHealthCheckDSL, `health check of API HTTP: path "/healthz" is already used by service "ClashingPathHealthCheck" HTTP endpoint "Method"`},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if c.Error == "" {
+				design.RunHTTPDSL(t, c.DSL)
+			} else {
+				err := design.RunInvalidHTTPDSL(t, c.DSL)
+				if err.Error() != c.Error {
+					t.Errorf("got error %q, expected %q", err.Error(), c.Error)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package design_test
+
+import (
+	"testing"
+
+	goadesign "goa.design/goa/design"
+	"goa.design/goa/http/design"
+	"goa.design/goa/http/design/testdata"
+)
+
+func TestNamespace(t *testing.T) {
+	root := design.RunHTTPDSL(t, testdata.NamespaceDSL)
+	if ns := root.Design.Namespace("common"); ns == nil {
+		t.Fatal("namespace common not found")
+	}
+	e := root.Service("Bottles").Endpoint("show")
+	if e == nil {
+		t.Fatal("endpoint show not found")
+	}
+	uuid := root.Design.UserType("common.UUID")
+	if typ := goadesign.AsObject(e.MethodExpr.Payload.Type).Attribute("id").Type; typ != uuid {
+		t.Errorf("got id type %s, expected common.UUID", typ.Name())
+	}
+	if att := goadesign.AsObject(e.PathParams().Type).Attribute("id"); att == nil || att.Type.Name() != "common.UUID" {
+		t.Errorf("got id path parameter %v, expected common.UUID", att)
+	}
+	reqs := e.MethodExpr.Requirements
+	if len(reqs) != 1 || reqs[0].Schemes[0].SchemeName != "common.jwt" {
+		t.Errorf("got requirements %v, expected common.jwt", reqs)
+	}
+}
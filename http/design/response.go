@@ -2,6 +2,7 @@ package design
 
 import (
 	"fmt"
+	"mime"
 	"strings"
 
 	"goa.design/goa/design"
@@ -85,10 +86,22 @@ type (
 		Description string
 		// Headers describe the HTTP response headers.
 		Headers *design.MappedAttributeExpr
+		// Cookies describe the HTTP response cookies.
+		Cookies *design.MappedAttributeExpr
+		// Trailers describe the HTTP response trailers, the headers
+		// written after the response body.
+		Trailers *design.MappedAttributeExpr
 		// Response body if any
 		Body *design.AttributeExpr
 		// Response Content-Type header value
 		ContentType string
+		// ConstantHeaders lists the headers set to constant values
+		// indexed by name.
+		ConstantHeaders map[string]string
+		// Produces lists the MIME types the response may be encoded
+		// with. The response encoder picks the one that best matches
+		// the request Accept header.
+		Produces []string
 		// Tag the value a field of the result must have for this
 		// response to be used.
 		Tag [2]string
@@ -115,6 +128,12 @@ func (r *HTTPResponseExpr) Prepare() {
 	if r.Headers == nil {
 		r.Headers = design.NewEmptyMappedAttributeExpr()
 	}
+	if r.Cookies == nil {
+		r.Cookies = design.NewEmptyMappedAttributeExpr()
+	}
+	if r.Trailers == nil {
+		r.Trailers = design.NewEmptyMappedAttributeExpr()
+	}
 }
 
 // Validate checks that the response definition is consistent: its status is set
@@ -122,6 +141,28 @@ func (r *HTTPResponseExpr) Prepare() {
 func (r *HTTPResponseExpr) Validate(e *EndpointExpr) *eval.ValidationErrors {
 	verr := new(eval.ValidationErrors)
 
+	for _, p := range r.Produces {
+		if _, _, err := mime.ParseMediaType(p); err != nil {
+			verr.Add(r, "invalid MIME type %q in Produces: %s", p, err)
+		}
+	}
+
+	for name, value := range r.ConstantHeaders {
+		if name == "" {
+			verr.Add(r, "constant header name cannot be empty")
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			verr.Add(r, "value of constant header %q cannot contain line breaks", name)
+		}
+		if r.Headers != nil {
+			for _, nat := range *design.AsObject(r.Headers.Type) {
+				if strings.EqualFold(r.Headers.ElemName(nat.Name), name) {
+					verr.Add(r, "header %q is defined both as a constant header and as a result attribute header", name)
+				}
+			}
+		}
+	}
+
 	if r.StatusCode == 0 {
 		verr.Add(r, "HTTP response status not defined")
 	} else if !bodyAllowedForStatus(r.StatusCode) && r.bodyExists() && !e.MethodExpr.IsStreaming() {
@@ -132,6 +173,12 @@ func (r *HTTPResponseExpr) Validate(e *EndpointExpr) *eval.ValidationErrors {
 		if !r.Headers.IsEmpty() {
 			verr.Add(r, "response defines headers but result is empty")
 		}
+		if !r.Cookies.IsEmpty() {
+			verr.Add(r, "response defines cookies but result is empty")
+		}
+		if !r.Trailers.IsEmpty() {
+			verr.Add(r, "response defines trailers but result is empty")
+		}
 		return verr
 	}
 
@@ -170,6 +217,44 @@ func (r *HTTPResponseExpr) Validate(e *EndpointExpr) *eval.ValidationErrors {
 			}
 		}
 	}
+	if !r.Cookies.IsEmpty() {
+		verr.Merge(r.Cookies.Validate("HTTP response cookies", r))
+		mobj := design.AsObject(r.Cookies.Type)
+		for _, c := range *mobj {
+			if !design.IsPrimitive(c.Attribute.Type) {
+				verr.Add(r, "cookie %q must be a primitive", c.Name)
+			}
+			if !hasAttribute(c.Name) {
+				verr.Add(r, "cookie %q has no equivalent attribute in%s result type, use notation 'attribute_name:cookie_name' to identify corresponding result type attribute.", c.Name, inview)
+			}
+		}
+	}
+	if !r.Trailers.IsEmpty() {
+		verr.Merge(r.Trailers.Validate("HTTP response trailers", r))
+		if !e.SkipResponseBodyEncodeDecode {
+			verr.Add(r, "trailers can only be defined when SkipResponseBodyEncodeDecode is set")
+		}
+		mobj := design.AsObject(r.Trailers.Type)
+		for _, t := range *mobj {
+			if !hasAttribute(t.Name) {
+				verr.Add(r, "trailer %q has no equivalent attribute in%s result type, use notation 'attribute_name:trailer_name' to identify corresponding result type attribute.", t.Name, inview)
+				continue
+			}
+			att := e.MethodExpr.Result.Find(t.Name)
+			if !design.IsPrimitive(att.Type) {
+				verr.Add(r, "trailer %q must be a primitive", t.Name)
+			}
+			if e.MethodExpr.Result.IsRequired(t.Name) || att.DefaultValue != nil {
+				verr.Add(r, "trailer %q cannot be required or have a default value, clients only receive trailers once the response body has been read", t.Name)
+			}
+			name := r.Trailers.ElemName(t.Name)
+			for _, h := range *design.AsObject(r.Headers.Type) {
+				if strings.EqualFold(r.Headers.ElemName(h.Name), name) {
+					verr.Add(r, "%q is defined both as a header and as a trailer", name)
+				}
+			}
+		}
+	}
 	if r.Body != nil {
 		verr.Merge(r.Body.Validate("HTTP response body", r))
 		if att, ok := r.Body.Metadata["origin:attribute"]; ok {
@@ -184,6 +269,39 @@ func (r *HTTPResponseExpr) Validate(e *EndpointExpr) *eval.ValidationErrors {
 			}
 		}
 	}
+	if design.IsObject(e.MethodExpr.Result.Type) {
+		isInternalAtt := func(name string) bool {
+			return isInternal(e.MethodExpr.Result.Find(strings.Split(name, ":")[0]))
+		}
+		for _, h := range *design.AsObject(r.Headers.Type) {
+			if isInternalAtt(h.Name) {
+				verr.Add(r, "header %q is mapped to internal result attribute.", r.Headers.ElemName(h.Name))
+			}
+		}
+		for _, c := range *design.AsObject(r.Cookies.Type) {
+			if isInternalAtt(c.Name) {
+				verr.Add(r, "cookie %q is mapped to internal result attribute.", r.Cookies.ElemName(c.Name))
+			}
+		}
+		for _, t := range *design.AsObject(r.Trailers.Type) {
+			if isInternalAtt(t.Name) {
+				verr.Add(r, "trailer %q is mapped to internal result attribute.", r.Trailers.ElemName(t.Name))
+			}
+		}
+		if r.Body != nil {
+			if att, ok := r.Body.Metadata["origin:attribute"]; ok {
+				if isInternalAtt(att[0]) {
+					verr.Add(r, "body %q is an internal result attribute.", att[0])
+				}
+			} else if bobj := design.AsObject(r.Body.Type); bobj != nil {
+				for _, n := range *bobj {
+					if isInternalAtt(n.Name) {
+						verr.Add(r, "body %q is an internal result attribute.", n.Name)
+					}
+				}
+			}
+		}
+	}
 	return verr
 }
 
@@ -228,16 +346,39 @@ func (r *HTTPResponseExpr) Dup() *HTTPResponseExpr {
 		StatusCode:  r.StatusCode,
 		Description: r.Description,
 		ContentType: r.ContentType,
+		Produces:    r.Produces,
 		Parent:      r.Parent,
 		Metadata:    r.Metadata,
 	}
 	if r.Body != nil {
 		res.Body = design.DupAtt(r.Body)
 	}
+	if r.ConstantHeaders != nil {
+		res.ConstantHeaders = make(map[string]string, len(r.ConstantHeaders))
+		for n, v := range r.ConstantHeaders {
+			res.ConstantHeaders[n] = v
+		}
+	}
 	res.Headers = design.DupMappedAtt(r.Headers)
+	res.Cookies = design.DupMappedAtt(r.Cookies)
+	res.Trailers = design.DupMappedAtt(r.Trailers)
 	return &res
 }
 
+// inheritConstantHeaders adds the constant headers in headers that the
+// response does not define explicitly.
+func (r *HTTPResponseExpr) inheritConstantHeaders(headers map[string]string) {
+	for n, v := range headers {
+		if _, ok := r.ConstantHeaders[n]; ok {
+			continue
+		}
+		if r.ConstantHeaders == nil {
+			r.ConstantHeaders = make(map[string]string, len(headers))
+		}
+		r.ConstantHeaders[n] = v
+	}
+}
+
 // bodyAllowedForStatus reports whether a given response status code
 // permits a body. See RFC 2616, section 4.4.
 // See https://golang.org/src/net/http/transfer.go
@@ -21,6 +21,16 @@ func TestResponseValidation(t *testing.T) {
 		{"array result", testdata.ArrayResultResponseWithHeadersDSL, ""},
 		{"map result", testdata.MapResultResponseWithHeadersDSL, ""},
 		{"invalid", testdata.EmptyResultResponseWithHeadersDSL, `HTTP response of service "EmptyResultResponseWithHeaders" HTTP endpoint "Method": response defines headers but result is empty`},
+		{"invalid produces", testdata.InvalidProducesResponseDSL, `HTTP response of service "InvalidProducesResponse" HTTP endpoint "Method": invalid MIME type "invalid//" in Produces: mime: expected token after slash`},
+		{"missing cookie attribute", testdata.MissingCookieAttributeResponseDSL, `HTTP response of service "MissingCookieAttributeResponse" HTTP endpoint "Method": cookie "token" has no equivalent attribute in result type, use notation 'attribute_name:cookie_name' to identify corresponding result type attribute.`},
+		{"constant header", testdata.ConstantHeaderResponseDSL, ""},
+		{"conflicting constant header", testdata.ConflictingConstantHeaderResponseDSL, `HTTP response of service "ConflictingConstantHeaderResponse" HTTP endpoint "Method": header "X-Api-Version" is defined both as a constant header and as a result attribute header`},
+		{"multiline constant header", testdata.MultilineConstantHeaderResponseDSL, `HTTP response of service "MultilineConstantHeaderResponse" HTTP endpoint "Method": value of constant header "X-Api-Version" cannot contain line breaks`},
+		{"trailer", testdata.TrailerResponseDSL, ""},
+		{"trailer without skip", testdata.TrailerWithoutSkipResponseDSL, `HTTP response of service "TrailerWithoutSkipResponse" HTTP endpoint "Method": trailers can only be defined when SkipResponseBodyEncodeDecode is set`},
+		{"required trailer", testdata.RequiredTrailerResponseDSL, `HTTP response of service "RequiredTrailerResponse" HTTP endpoint "Method": trailer "checksum" cannot be required or have a default value, clients only receive trailers once the response body has been read`},
+		{"non primitive trailer", testdata.NonPrimitiveTrailerResponseDSL, `HTTP response of service "NonPrimitiveTrailerResponse" HTTP endpoint "Method": trailer "checksums" must be a primitive`},
+		{"header trailer", testdata.HeaderTrailerResponseDSL, `HTTP response of service "HeaderTrailerResponse" HTTP endpoint "Method": "X-Checksum" is defined both as a header and as a trailer`},
 	}
 	for _, c := range cases {
 		t.Run(c.Name, func(t *testing.T) {
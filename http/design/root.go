@@ -18,6 +18,14 @@ var (
 	// parameters.
 	WildcardRegex = regexp.MustCompile(`/{\*?([a-zA-Z0-9_]+)}`)
 
+	// catchAllRegex is the regular expression used to capture the
+	// catch-all wildcard that ends a path.
+	catchAllRegex = regexp.MustCompile(`/{\*([a-zA-Z0-9_]+)}$`)
+
+	// catchAllAnywhereRegex is the regular expression used to capture the
+	// catch-all wildcards that appear anywhere in a path.
+	catchAllAnywhereRegex = regexp.MustCompile(`/{\*([a-zA-Z0-9_]+)}`)
+
 	// ErrorResult is the built-in result type for error responses.
 	ErrorResult = design.ErrorResult
 
@@ -45,10 +53,25 @@ type (
 		// Produces lists the mime types generated by the API
 		// controllers.
 		Produces []string
+		// ContentType is the content type of the request bodies of the
+		// API endpoints whose service does not define one if any.
+		ContentType string
+		// MaxBodySize is the maximum size in bytes of the request bodies
+		// of the API endpoints whose service does not define one if any.
+		MaxBodySize int64
 		// HTTPServices contains the services created by the DSL.
 		HTTPServices []*ServiceExpr
 		// HTTPErrors lists the error HTTP responses.
 		HTTPErrors []*ErrorExpr
+		// CORS is the CORS policy applied to all the API services that
+		// do not define their own if any.
+		CORS *CORSExpr
+		// Compression is the response compression policy applied to
+		// all the API services that do not define their own if any.
+		Compression *CompressionExpr
+		// HealthCheck describes the liveness and readiness check
+		// endpoints mounted by the API servers if any.
+		HealthCheck *HealthCheckExpr
 		// Metadata is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Metadata design.MetadataExpr
@@ -62,7 +85,7 @@ func (r *RootExpr) Schemes() []string {
 	}
 	schemes := make(map[string]bool)
 	for _, s := range r.Design.API.Servers {
-		if u, err := url.Parse(s.URL); err != nil {
+		if u, err := url.Parse(s.DefaultURL()); err != nil {
 			schemes[u.Scheme] = true
 		}
 	}
@@ -114,8 +137,18 @@ func (r *RootExpr) WalkSets(walk eval.SetWalker) {
 		services  eval.ExpressionSet
 		endpoints eval.ExpressionSet
 		servers   eval.ExpressionSet
+		policies  eval.ExpressionSet
 	)
 	{
+		if r.CORS != nil {
+			policies = append(policies, r.CORS)
+		}
+		if r.Compression != nil {
+			policies = append(policies, r.Compression)
+		}
+		if r.HealthCheck != nil {
+			policies = append(policies, r.HealthCheck)
+		}
 		services = make(eval.ExpressionSet, len(r.HTTPServices))
 		sort.SliceStable(r.HTTPServices, func(i, j int) bool {
 			if r.HTTPServices[j].ParentName == r.HTTPServices[i].Name() {
@@ -131,11 +164,18 @@ func (r *RootExpr) WalkSets(walk eval.SetWalker) {
 			for _, s := range svc.FileServers {
 				servers = append(servers, s)
 			}
+			if svc.CORS != nil {
+				policies = append(policies, svc.CORS)
+			}
+			if svc.Compression != nil {
+				policies = append(policies, svc.Compression)
+			}
 		}
 	}
 	walk(services)
 	walk(endpoints)
 	walk(servers)
+	walk(policies)
 }
 
 // DependsOn is a no-op as the DSL runs when loaded.
@@ -40,6 +40,18 @@ type (
 		HTTPErrors []*ErrorExpr
 		// FileServers is the list of static asset serving endpoints
 		FileServers []*FileServerExpr
+		// CORS is the CORS policy applied to the service endpoints if
+		// any.
+		CORS *CORSExpr
+		// Compression is the response compression policy applied to
+		// the service endpoints if any.
+		Compression *CompressionExpr
+		// ContentType is the content type of the request bodies of the
+		// service endpoints that do not define one if any.
+		ContentType string
+		// MaxBodySize is the maximum size in bytes of the request bodies
+		// of the service endpoints that do not define one if any.
+		MaxBodySize int64
 		// Metadata is a set of key/value pairs with semantic that is
 		// specific to each generator.
 		Metadata design.MetadataExpr
@@ -60,7 +72,7 @@ func (svc *ServiceExpr) Description() string {
 func (svc *ServiceExpr) Schemes() []string {
 	schemes := make(map[string]bool)
 	for _, s := range svc.ServiceExpr.Servers {
-		if u, err := url.Parse(s.URL); err != nil {
+		if u, err := url.Parse(s.DefaultURL()); err != nil {
 			schemes[u.Scheme] = true
 		}
 	}
@@ -133,10 +145,15 @@ func (svc *ServiceExpr) URITemplate() string {
 }
 
 // FullPaths computes the base paths to the service endpoints concatenating the
-// API and parent service base paths as needed.
+// API and parent service base paths as needed. The API base path is followed
+// by the service version if the service uses the path versioning strategy.
 func (svc *ServiceExpr) FullPaths() []string {
+	root := Root.Path
+	if v := svc.Versioning(); v != nil && v.Strategy == VersionPath {
+		root = path.Join("/", root, v.Version)
+	}
 	if len(svc.Paths) == 0 {
-		return []string{path.Join(Root.Path)}
+		return []string{path.Join(root)}
 	}
 	var paths []string
 	for _, p := range svc.Paths {
@@ -159,7 +176,7 @@ func (svc *ServiceExpr) FullPaths() []string {
 				}
 			}
 		} else {
-			basePaths = []string{Root.Path}
+			basePaths = []string{root}
 		}
 		for _, base := range basePaths {
 			paths = append(paths, httppath.Clean(path.Join(base, p)))
@@ -229,6 +246,9 @@ func (svc *ServiceExpr) Validate() error {
 			verr.Add(svc, "Unknown canonical endpoint %s", n)
 		}
 	}
+	if msg := svc.validateVersioning(); msg != "" {
+		verr.Add(svc, "%s", msg)
+	}
 
 	// Validate errors (have status codes and bodies are valid)
 	for _, er := range svc.HTTPErrors {
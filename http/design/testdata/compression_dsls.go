@@ -0,0 +1,36 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/dsl"
+)
+
+var ValidCompressionDSL = func() {
+	API("ValidCompression", func() {
+		HTTP(func() {
+			Compress(0)
+		})
+	})
+	Service("ValidCompression", func() {
+		HTTP(func() {
+			Compress(1024)
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var NegativeMinSizeCompressionDSL = func() {
+	Service("NegativeMinSizeCompression", func() {
+		HTTP(func() {
+			Compress(-1)
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -0,0 +1,76 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/dsl"
+)
+
+var ValidCORSDSL = func() {
+	API("ValidCORS", func() {
+		HTTP(func() {
+			CORS(func() {
+				AllowOrigins("*")
+			})
+		})
+	})
+	Service("ValidCORS", func() {
+		HTTP(func() {
+			CORS(func() {
+				AllowOrigins("https://goa.design", "https://*.goa.design", "http://localhost:8080")
+				AllowMethods("get", "POST")
+				AllowHeaders("X-Shared-Secret")
+				MaxAge(600)
+			})
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var NoOriginCORSDSL = func() {
+	Service("NoOriginCORS", func() {
+		HTTP(func() {
+			CORS(func() {
+				AllowMethods("GET")
+			})
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var InvalidOriginCORSDSL = func() {
+	Service("InvalidOriginCORS", func() {
+		HTTP(func() {
+			CORS(func() {
+				AllowOrigins("goa.design", "https://*.*.goa.design")
+			})
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var InvalidMethodCORSDSL = func() {
+	Service("InvalidMethodCORS", func() {
+		HTTP(func() {
+			CORS(func() {
+				AllowOrigins("*")
+				AllowMethods("FETCH")
+			})
+		})
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
@@ -1,6 +1,8 @@
 package testdata
 
 import (
+	"time"
+
 	. "goa.design/goa/http/design"
 	. "goa.design/goa/http/dsl"
 )
@@ -37,3 +39,838 @@ var DuplicateWCRouteDSL = func() {
 		})
 	})
 }
+
+var ValidBinaryStreamDSL = func() {
+	Service("ValidBinaryStream", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				BinaryStream()
+			})
+		})
+	})
+}
+
+var InvalidBinaryStreamDSL = func() {
+	Service("InvalidBinaryStream", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				BinaryStream()
+			})
+		})
+	})
+}
+
+var ValidSkipBodyEncodeDecodeDSL = func() {
+	Service("ValidSkipBodyEncodeDecode", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			Result(func() {
+				Attribute("length", Int64)
+			})
+			HTTP(func() {
+				PUT("/{name}")
+				SkipRequestBodyEncodeDecode()
+				SkipResponseBodyEncodeDecode()
+				Response(StatusOK, func() {
+					Header("length:Content-Length")
+				})
+			})
+		})
+	})
+}
+
+var InvalidSkipRequestBodyEncodeDecodeDSL = func() {
+	Service("InvalidSkipRequestBodyEncodeDecode", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+				SkipRequestBodyEncodeDecode()
+			})
+		})
+	})
+}
+
+var InvalidSkipResponseBodyEncodeDecodeDSL = func() {
+	Service("InvalidSkipResponseBodyEncodeDecode", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("length", Int64)
+			})
+			HTTP(func() {
+				GET("/")
+				SkipResponseBodyEncodeDecode()
+			})
+		})
+	})
+}
+
+var ValidStreamingMultipartDSL = func() {
+	Service("ValidStreamingMultipart", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("file", Bytes)
+			})
+			HTTP(func() {
+				POST("/")
+				StreamingMultipartRequest(1024, 4096)
+			})
+		})
+	})
+}
+
+var InvalidStreamingMultipartDSL = func() {
+	Service("InvalidStreamingMultipart", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("file", Bytes)
+			})
+			HTTP(func() {
+				POST("/")
+				StreamingMultipartRequest(4096, 1024)
+			})
+		})
+	})
+}
+
+var ValidContentTypeDSL = func() {
+	API("ValidContentType", func() {
+		HTTP(func() {
+			ContentType("application/cbor")
+		})
+	})
+	Service("ValidContentType", func() {
+		HTTP(func() {
+			ContentType("application/msgpack")
+		})
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+				ContentType("application/json")
+			})
+		})
+	})
+}
+
+var MultipartContentTypeDSL = func() {
+	Service("MultipartContentType", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("file", Bytes)
+			})
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+				ContentType("application/cbor")
+			})
+		})
+	})
+}
+
+var InvalidContentTypeDSL = func() {
+	Service("InvalidContentType", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+				ContentType("cbor")
+			})
+		})
+	})
+}
+
+var ValidConsumesDSL = func() {
+	Service("ValidConsumes", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Consumes("application/json", "application/x-www-form-urlencoded", "multipart/form-data")
+				ContentType("application/x-www-form-urlencoded")
+			})
+		})
+	})
+}
+
+var MultipartConsumesDSL = func() {
+	Service("MultipartConsumes", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("file", Bytes)
+			})
+			HTTP(func() {
+				POST("/")
+				MultipartRequest()
+				Consumes("application/json")
+			})
+		})
+	})
+}
+
+var NoPayloadConsumesDSL = func() {
+	Service("NoPayloadConsumes", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+				Consumes("application/json")
+			})
+		})
+	})
+}
+
+var ContentTypeNotConsumedDSL = func() {
+	Service("ContentTypeNotConsumed", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Consumes("application/json", "application/xml")
+				ContentType("application/cbor")
+			})
+		})
+	})
+}
+
+var InvalidConsumesDSL = func() {
+	Service("InvalidConsumes", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Consumes("application/json; charset")
+			})
+		})
+	})
+}
+
+var ValidNDJSONStreamDSL = func() {
+	Service("ValidNDJSONStream", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				NDJSONStream()
+			})
+		})
+	})
+}
+
+var NotStreamingNDJSONStreamDSL = func() {
+	Service("NotStreamingNDJSONStream", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				NDJSONStream()
+			})
+		})
+	})
+}
+
+var BinaryNDJSONStreamDSL = func() {
+	Service("BinaryNDJSONStream", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				BinaryStream()
+				NDJSONStream()
+			})
+		})
+	})
+}
+
+var APIKeyLocationDSL = func() {
+	var APIKeyAuth = APIKeySecurity("api_key")
+	Service("APIKeyLocation", func() {
+		Security(APIKeyAuth)
+		Method("Header", func() {
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/header")
+			})
+		})
+		Method("Query", func() {
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/query")
+				Param("key:k")
+			})
+		})
+		Method("Cookie", func() {
+			Payload(func() {
+				APIKey("api_key", "key", String)
+			})
+			HTTP(func() {
+				GET("/cookie")
+				Cookie("key:k")
+			})
+		})
+	})
+}
+
+var ValidFieldSelectionDSL = func() {
+	Service("ValidFieldSelection", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("id", Int)
+				Attribute("name", String)
+			})
+			HTTP(func() {
+				GET("/")
+				FieldSelection("f")
+			})
+		})
+	})
+}
+
+var PrimitiveFieldSelectionDSL = func() {
+	Service("PrimitiveFieldSelection", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				FieldSelection()
+			})
+		})
+	})
+}
+
+var ValidInternalDSL = func() {
+	Service("ValidInternal", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("secret", String, func() {
+					Internal()
+				})
+			})
+			Result(func() {
+				Attribute("name", String)
+				Attribute("secret", String, func() {
+					Internal()
+				})
+			})
+			HTTP(func() {
+				POST("/{id}")
+			})
+		})
+	})
+}
+
+var InternalParamDSL = func() {
+	Service("InternalParam", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("secret", String, func() {
+					Internal()
+				})
+			})
+			HTTP(func() {
+				GET("/{secret}")
+			})
+		})
+	})
+}
+
+var InternalHeaderDSL = func() {
+	Service("InternalHeader", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("secret", String, func() {
+					Internal()
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				Header("secret:X-Secret")
+			})
+		})
+	})
+}
+
+var InternalResponseHeaderDSL = func() {
+	Service("InternalResponseHeader", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("secret", String, func() {
+					Internal()
+				})
+			})
+			HTTP(func() {
+				GET("/")
+				Response(StatusOK, func() {
+					Header("secret:X-Secret")
+				})
+			})
+		})
+	})
+}
+
+var ValidNestedHeaderDSL = func() {
+	var Tenant = Type("Tenant", func() {
+		Attribute("id", String)
+	})
+	Service("ValidNestedHeader", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("tenant", Tenant)
+			})
+			HTTP(func() {
+				POST("/")
+				Header("tenant.id:X-Tenant")
+			})
+		})
+	})
+}
+
+var MissingNestedHeaderDSL = func() {
+	var Tenant = Type("Tenant", func() {
+		Attribute("id", String)
+	})
+	Service("MissingNestedHeader", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("tenant", Tenant)
+			})
+			HTTP(func() {
+				POST("/")
+				Header("tenant.name:X-Tenant")
+			})
+		})
+	})
+}
+
+var ObjectNestedHeaderDSL = func() {
+	var Org = Type("Org", func() {
+		Attribute("id", String)
+	})
+	var Tenant = Type("Tenant", func() {
+		Attribute("org", Org)
+	})
+	Service("ObjectNestedHeader", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("tenant", Tenant)
+			})
+			HTTP(func() {
+				POST("/")
+				Header("tenant.org:X-Org")
+			})
+		})
+	})
+}
+
+var ValidQueryStyleDSL = func() {
+	Service("ValidQueryStyle", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("tags", ArrayOf(String))
+				Attribute("filter", MapOf(String, String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("tags", func() {
+					Metadata("http:query:style", "pipeDelimited")
+				})
+				Param("filter", func() {
+					Metadata("http:query:style", "deepObject")
+				})
+			})
+		})
+	})
+}
+
+var InvalidQueryStyleDSL = func() {
+	Service("InvalidQueryStyle", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("tags", func() {
+					Metadata("http:query:style", "commaDelimited")
+				})
+			})
+		})
+	})
+}
+
+var DelimitedNotArrayQueryStyleDSL = func() {
+	Service("DelimitedNotArrayQueryStyle", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("tag", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Param("tag", func() {
+					Metadata("http:query:style", "spaceDelimited")
+				})
+			})
+		})
+	})
+}
+
+var DeepObjectNotMapQueryStyleDSL = func() {
+	Service("DeepObjectNotMapQueryStyle", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("tags", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				Param("tags", func() {
+					Metadata("http:query:style", "deepObject")
+				})
+			})
+		})
+	})
+}
+
+var PathQueryStyleDSL = func() {
+	Service("PathQueryStyle", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("ids", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/{ids}")
+				Param("ids", func() {
+					Metadata("http:query:style", "pipeDelimited")
+				})
+			})
+		})
+	})
+}
+
+var ValidCatchAllDSL = func() {
+	Service("ValidCatchAll", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("bucket", String)
+				Attribute("key", String)
+			})
+			HTTP(func() {
+				GET("/{bucket}/objects/{*key}")
+			})
+		})
+	})
+}
+
+var NotLastCatchAllDSL = func() {
+	Service("NotLastCatchAll", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("key", String)
+			})
+			HTTP(func() {
+				GET("/objects/{*key}/meta")
+			})
+		})
+	})
+}
+
+var NotStringCatchAllDSL = func() {
+	Service("NotStringCatchAll", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("key", Int)
+			})
+			HTTP(func() {
+				GET("/objects/{*key}")
+			})
+		})
+	})
+}
+
+var ValidStreamKeepAliveDSL = func() {
+	Service("ValidStreamKeepAlive", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				StreamKeepAlive(30*time.Second, time.Minute, 10*time.Second)
+			})
+		})
+	})
+}
+
+var NDJSONStreamKeepAliveDSL = func() {
+	Service("NDJSONStreamKeepAlive", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				NDJSONStream()
+				StreamKeepAlive(30*time.Second, time.Minute, 10*time.Second)
+			})
+		})
+	})
+}
+
+var PongWaitStreamKeepAliveDSL = func() {
+	Service("PongWaitStreamKeepAlive", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				StreamKeepAlive(time.Minute, 30*time.Second, 10*time.Second)
+			})
+		})
+	})
+}
+
+var ZeroStreamKeepAliveDSL = func() {
+	Service("ZeroStreamKeepAlive", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				StreamKeepAlive(30*time.Second, time.Minute, 0)
+			})
+		})
+	})
+}
+
+var ValidStreamCompressionDSL = func() {
+	Service("ValidStreamCompression", func() {
+		Method("Method", func() {
+			StreamingResult(String)
+			HTTP(func() {
+				GET("/")
+				StreamCompression()
+			})
+		})
+	})
+}
+
+var NotStreamingStreamCompressionDSL = func() {
+	Service("NotStreamingStreamCompression", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				GET("/")
+				StreamCompression()
+			})
+		})
+	})
+}
+
+var ValidStreamResumeDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("id", String)
+	})
+	Service("ValidStreamResume", func() {
+		Method("Method", func() {
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/")
+				StreamResume("id")
+			})
+		})
+	})
+}
+
+var NDJSONStreamResumeDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("id", String)
+	})
+	Service("NDJSONStreamResume", func() {
+		Method("Method", func() {
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/")
+				NDJSONStream()
+				StreamResume("id")
+			})
+		})
+	})
+}
+
+var MissingAttributeStreamResumeDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("id", String)
+	})
+	Service("MissingAttributeStreamResume", func() {
+		Method("Method", func() {
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/")
+				StreamResume("seq")
+			})
+		})
+	})
+}
+
+var NotStringStreamResumeDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("id", Int)
+	})
+	Service("NotStringStreamResume", func() {
+		Method("Method", func() {
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/")
+				StreamResume("id")
+			})
+		})
+	})
+}
+
+var EmptyHeaderStreamResumeDSL = func() {
+	var Event = Type("Event", func() {
+		Attribute("id", String)
+	})
+	Service("EmptyHeaderStreamResume", func() {
+		Method("Method", func() {
+			StreamingResult(Event)
+			HTTP(func() {
+				GET("/")
+				StreamResume("id", "")
+			})
+		})
+	})
+}
+
+var ValidWebhookDSL = func() {
+	var Shipment = Type("Shipment", func() {
+		Attribute("id", String)
+	})
+	Service("ValidWebhook", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("callback_url", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Webhook("shipped", Shipment, func() {
+					CallbackURL("callback_url")
+				})
+			})
+		})
+	})
+}
+
+var DuplicateWebhookDSL = func() {
+	var Shipment = Type("Shipment", func() {
+		Attribute("id", String)
+	})
+	Service("DuplicateWebhook", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("callback_url", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Webhook("shipped", Shipment, func() {
+					CallbackURL("callback_url")
+				})
+			})
+		})
+		Method("Method2", func() {
+			Payload(func() {
+				Attribute("callback_url", String)
+			})
+			HTTP(func() {
+				POST("/2")
+				Webhook("shipped", Shipment, func() {
+					CallbackURL("callback_url")
+				})
+			})
+		})
+	})
+}
+
+var PrimitivePayloadWebhookDSL = func() {
+	var ID = Type("ID", String)
+	Service("PrimitivePayloadWebhook", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("callback_url", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Webhook("shipped", ID, func() {
+					CallbackURL("callback_url")
+				})
+			})
+		})
+	})
+}
+
+var MissingCallbackURLWebhookDSL = func() {
+	var Shipment = Type("Shipment", func() {
+		Attribute("id", String)
+	})
+	Service("MissingCallbackURLWebhook", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("callback_url", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Webhook("shipped", Shipment, func() {})
+			})
+		})
+	})
+}
+
+var UnknownCallbackURLWebhookDSL = func() {
+	var Shipment = Type("Shipment", func() {
+		Attribute("id", String)
+	})
+	Service("UnknownCallbackURLWebhook", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("callback_url", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Webhook("shipped", Shipment, func() {
+					CallbackURL("url")
+				})
+			})
+		})
+	})
+}
+
+var NotStringCallbackURLWebhookDSL = func() {
+	var Shipment = Type("Shipment", func() {
+		Attribute("id", String)
+	})
+	Service("NotStringCallbackURLWebhook", func() {
+		Method("Method", func() {
+			Payload(func() {
+				Attribute("callback_url", Int)
+			})
+			HTTP(func() {
+				POST("/")
+				Webhook("shipped", Shipment, func() {
+					CallbackURL("callback_url")
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,56 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/dsl"
+)
+
+var ValidAcceptRangesDSL = func() {
+	Service("ValidAcceptRanges", func() {
+		Files("/videos/{*filepath}", "/www/data/videos", func() {
+			AcceptRanges(1024)
+		})
+	})
+}
+
+var InvalidChunkSizeDSL = func() {
+	Service("InvalidChunkSize", func() {
+		Files("/videos/{*filepath}", "/www/data/videos", func() {
+			AcceptRanges(-1)
+		})
+	})
+}
+
+var ValidEmbedDSL = func() {
+	Service("ValidEmbed", func() {
+		Files("/{*filepath}", "testdata/assets", func() {
+			Embed()
+		})
+		Files("/index.html", "testdata/assets/index.html", func() {
+			Embed()
+		})
+	})
+}
+
+var AbsoluteEmbedDSL = func() {
+	Service("AbsoluteEmbed", func() {
+		Files("/{*filepath}", "/www/data", func() {
+			Embed()
+		})
+	})
+}
+
+var NotFoundEmbedDSL = func() {
+	Service("NotFoundEmbed", func() {
+		Files("/{*filepath}", "testdata/notfound", func() {
+			Embed()
+		})
+	})
+}
+
+var NotDirEmbedDSL = func() {
+	Service("NotDirEmbed", func() {
+		Files("/{*filepath}", "testdata/assets/index.html", func() {
+			Embed()
+		})
+	})
+}
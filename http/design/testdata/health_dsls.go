@@ -0,0 +1,51 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/dsl"
+)
+
+var ValidHealthCheckDSL = func() {
+	API("ValidHealthCheck", func() {
+		HTTP(func() {
+			HealthCheck()
+		})
+	})
+	Service("ValidHealthCheck", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/")
+			})
+		})
+	})
+}
+
+var RelativePathHealthCheckDSL = func() {
+	API("RelativePathHealthCheck", func() {
+		HTTP(func() {
+			HealthCheck("live", "/ready")
+		})
+	})
+}
+
+var SamePathsHealthCheckDSL = func() {
+	API("SamePathsHealthCheck", func() {
+		HTTP(func() {
+			HealthCheck("/health", "/health")
+		})
+	})
+}
+
+var ClashingPathHealthCheckDSL = func() {
+	API("ClashingPathHealthCheck", func() {
+		HTTP(func() {
+			HealthCheck()
+		})
+	})
+	Service("ClashingPathHealthCheck", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				GET("/healthz")
+			})
+		})
+	})
+}
@@ -0,0 +1,32 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/design"
+	. "goa.design/goa/http/dsl"
+)
+
+var NamespaceDSL = func() {
+	Namespace("common", func() {
+		Type("UUID", String, func() {
+			Format(FormatUUID)
+		})
+		JWTSecurity("jwt", func() {
+			Scope("api:read")
+		})
+	})
+	API("Namespace", func() {
+		Import("common")
+	})
+	Service("Bottles", func() {
+		Method("show", func() {
+			Security("jwt")
+			Payload(func() {
+				Attribute("id", "UUID")
+				Token("token", String)
+			})
+			HTTP(func() {
+				GET("/{id}")
+			})
+		})
+	})
+}
@@ -111,3 +111,168 @@ var EmptyResultResponseWithHeadersDSL = func() {
 		})
 	})
 }
+
+var InvalidProducesResponseDSL = func() {
+	Service("InvalidProducesResponse", func() {
+		Method("Method", func() {
+			Result(String)
+			HTTP(func() {
+				POST("/")
+				Response(func() {
+					Produces("application/json", "invalid//")
+				})
+			})
+		})
+	})
+}
+
+var MissingCookieAttributeResponseDSL = func() {
+	Service("MissingCookieAttributeResponse", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("session", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Response(func() {
+					Cookie("token")
+				})
+			})
+		})
+	})
+}
+
+var ConstantHeaderResponseDSL = func() {
+	Service("ConstantHeaderResponse", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("h", String)
+			})
+			HTTP(func() {
+				POST("/")
+				ConstantHeader("X-API-Version", "2")
+				Response(func() {
+					Header("h")
+					ConstantHeader("Cache-Control", "no-store")
+				})
+			})
+		})
+	})
+}
+
+var ConflictingConstantHeaderResponseDSL = func() {
+	Service("ConflictingConstantHeaderResponse", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("version", String)
+			})
+			HTTP(func() {
+				POST("/")
+				Response(func() {
+					Header("version:X-API-Version")
+					ConstantHeader("X-API-Version", "2")
+				})
+			})
+		})
+	})
+}
+
+var MultilineConstantHeaderResponseDSL = func() {
+	Service("MultilineConstantHeaderResponse", func() {
+		Method("Method", func() {
+			HTTP(func() {
+				POST("/")
+				Response(func() {
+					ConstantHeader("X-API-Version", "2\r\nX-Other: 3")
+				})
+			})
+		})
+	})
+}
+
+var TrailerResponseDSL = func() {
+	Service("TrailerResponse", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("checksum", String)
+			})
+			HTTP(func() {
+				GET("/")
+				SkipResponseBodyEncodeDecode()
+				Response(func() {
+					Trailer("checksum:X-Checksum")
+				})
+			})
+		})
+	})
+}
+
+var TrailerWithoutSkipResponseDSL = func() {
+	Service("TrailerWithoutSkipResponse", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("checksum", String)
+			})
+			HTTP(func() {
+				GET("/")
+				Response(func() {
+					Trailer("checksum:X-Checksum")
+				})
+			})
+		})
+	})
+}
+
+var RequiredTrailerResponseDSL = func() {
+	Service("RequiredTrailerResponse", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("checksum", String)
+				Required("checksum")
+			})
+			HTTP(func() {
+				GET("/")
+				SkipResponseBodyEncodeDecode()
+				Response(func() {
+					Trailer("checksum:X-Checksum")
+				})
+			})
+		})
+	})
+}
+
+var NonPrimitiveTrailerResponseDSL = func() {
+	Service("NonPrimitiveTrailerResponse", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("checksums", ArrayOf(String))
+			})
+			HTTP(func() {
+				GET("/")
+				SkipResponseBodyEncodeDecode()
+				Response(func() {
+					Trailer("checksums:X-Checksum")
+				})
+			})
+		})
+	})
+}
+
+var HeaderTrailerResponseDSL = func() {
+	Service("HeaderTrailerResponse", func() {
+		Method("Method", func() {
+			Result(func() {
+				Attribute("checksum", String)
+				Attribute("digest", String)
+			})
+			HTTP(func() {
+				GET("/")
+				SkipResponseBodyEncodeDecode()
+				Response(func() {
+					Header("digest:X-Checksum")
+					Trailer("checksum:X-Checksum")
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,89 @@
+package testdata
+
+import (
+	. "goa.design/goa/http/dsl"
+)
+
+var VersionPathDSL = func() {
+	API("VersionPath", func() {
+		HTTP(func() {
+			Path("/api")
+		})
+	})
+	Service("calc", func() {
+		Version("v1")
+		HTTP(func() {
+			Path("/calc")
+		})
+		Method("add", func() {
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+	Service("calc_v2", func() {
+		Version("v2")
+		HTTP(func() {
+			Path("/calc")
+		})
+		Method("add", func() {
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+	Service("health", func() {
+		Method("check", func() {
+			HTTP(func() {
+				GET("/health")
+			})
+		})
+	})
+}
+
+var VersionHeaderDSL = func() {
+	API("VersionHeader", func() {
+		Version("1.0")
+		Metadata("version:strategy", "header")
+	})
+	Service("calc", func() {
+		Method("add", func() {
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+	Service("calc_v2", func() {
+		Version("2.0")
+		Metadata("version:header", "X-Version")
+		Method("add", func() {
+			HTTP(func() {
+				GET("/v2/add")
+			})
+		})
+	})
+}
+
+var VersionMediaTypeDSL = func() {
+	Service("calc", func() {
+		Version("v2")
+		Metadata("version:strategy", "mediatype")
+		Method("add", func() {
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+}
+
+var InvalidVersionStrategyDSL = func() {
+	Service("calc", func() {
+		Version("v2")
+		Metadata("version:strategy", "query")
+		Method("add", func() {
+			HTTP(func() {
+				GET("/add")
+			})
+		})
+	})
+}
@@ -0,0 +1,133 @@
+package design
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"goa.design/goa/design"
+)
+
+const (
+	// VersionPath is the versioning strategy that prefixes the service
+	// routes with the version, e.g. "/v2/users". It is the default
+	// strategy and the only one that makes it possible for multiple
+	// versions of a service that define the same routes to be mounted on
+	// the same server.
+	VersionPath = "path"
+	// VersionHeader is the versioning strategy that sets the version in a
+	// request header, "API-Version" by default.
+	VersionHeader = "header"
+	// VersionMediaType is the versioning strategy that sets the version in
+	// the vendor media type listed in the Accept request header, e.g.
+	// "application/vnd.calc.v2+json".
+	VersionMediaType = "mediatype"
+
+	// DefaultVersionHeader is the name of the header used by the header
+	// versioning strategy by default.
+	DefaultVersionHeader = "API-Version"
+)
+
+// Versioning describes how the version of a service is mapped to the HTTP
+// requests.
+type Versioning struct {
+	// Version is the service version.
+	Version string
+	// Strategy is one of VersionPath, VersionHeader or VersionMediaType.
+	Strategy string
+	// Header is the name of the request header that contains the version
+	// for the header and media type strategies.
+	Header string
+	// Value is the value of the request header for the header and media
+	// type strategies.
+	Value string
+}
+
+// Versioning returns the versioning of the service, nil if the service is not
+// versioned. The version is the version of the service or the version of the
+// API if the service does not define one and the API defines the
+// "version:strategy" metadata. The strategy is defined with the
+// "version:strategy" metadata set on the service or the API and defaults to
+// VersionPath. The "version:header" metadata overrides the name of the header
+// used by the header strategy.
+func (svc *ServiceExpr) Versioning() *Versioning {
+	var api *design.APIExpr
+	if Root.Design != nil {
+		api = Root.Design.API
+	}
+	var apiMeta design.MetadataExpr
+	if api != nil {
+		apiMeta = api.Metadata
+	}
+	ver := svc.ServiceExpr.Version
+	if ver == "" {
+		if api == nil || metadataValue("version:strategy", apiMeta, Root.Metadata) == "" {
+			return nil
+		}
+		ver = api.Version
+	}
+	if ver == "" {
+		return nil
+	}
+	v := &Versioning{Version: ver, Strategy: VersionPath}
+	mdatas := []design.MetadataExpr{svc.ServiceExpr.Metadata, svc.Metadata, apiMeta, Root.Metadata}
+	if s := metadataValue("version:strategy", mdatas...); s != "" {
+		v.Strategy = s
+	}
+	switch v.Strategy {
+	case VersionHeader:
+		v.Header = DefaultVersionHeader
+		if h := metadataValue("version:header", mdatas...); h != "" {
+			v.Header = h
+		}
+		v.Value = ver
+	case VersionMediaType:
+		v.Header = "Accept"
+		v.Value = fmt.Sprintf("application/vnd.%s.%s+json", strings.ToLower(svc.Name()), ver)
+	}
+	return v
+}
+
+// Versions returns the sorted list of the versions of the API services.
+func (r *RootExpr) Versions() []string {
+	seen := make(map[string]struct{})
+	var vers []string
+	for _, svc := range r.HTTPServices {
+		v := svc.Versioning()
+		if v == nil {
+			continue
+		}
+		if _, ok := seen[v.Version]; ok {
+			continue
+		}
+		seen[v.Version] = struct{}{}
+		vers = append(vers, v.Version)
+	}
+	sort.Strings(vers)
+	return vers
+}
+
+// validateVersioning makes sure the versioning strategy of the service is
+// valid.
+func (svc *ServiceExpr) validateVersioning() string {
+	v := svc.Versioning()
+	if v == nil {
+		return ""
+	}
+	switch v.Strategy {
+	case VersionPath, VersionHeader, VersionMediaType:
+		return ""
+	}
+	return fmt.Sprintf("invalid versioning strategy %#v, must be one of %#v, %#v or %#v", v.Strategy, VersionPath, VersionHeader, VersionMediaType)
+}
+
+// metadataValue returns the first value of the metadata with the given key
+// found in the given expressions, the empty string if there is none.
+func metadataValue(key string, mdatas ...design.MetadataExpr) string {
+	for _, m := range mdatas {
+		if v, ok := m[key]; ok && len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,69 @@
+package design_test
+
+import (
+	"reflect"
+	"testing"
+
+	"goa.design/goa/http/design"
+	"goa.design/goa/http/design/testdata"
+)
+
+func TestVersioning(t *testing.T) {
+	cases := []struct {
+		Name       string
+		DSL        func()
+		Versioning map[string]*design.Versioning
+		Paths      map[string]string
+		Versions   []string
+	}{
+		{"path", testdata.VersionPathDSL, map[string]*design.Versioning{
+			"calc":    {Version: "v1", Strategy: design.VersionPath},
+			"calc_v2": {Version: "v2", Strategy: design.VersionPath},
+			"health":  nil,
+		}, map[string]string{
+			"calc":    "/api/v1/calc/add",
+			"calc_v2": "/api/v2/calc/add",
+			"health":  "/api/health",
+		}, []string{"v1", "v2"}},
+		{"header", testdata.VersionHeaderDSL, map[string]*design.Versioning{
+			"calc":    {Version: "1.0", Strategy: design.VersionHeader, Header: "API-Version", Value: "1.0"},
+			"calc_v2": {Version: "2.0", Strategy: design.VersionHeader, Header: "X-Version", Value: "2.0"},
+		}, map[string]string{
+			"calc":    "/add",
+			"calc_v2": "/v2/add",
+		}, []string{"1.0", "2.0"}},
+		{"mediatype", testdata.VersionMediaTypeDSL, map[string]*design.Versioning{
+			"calc": {Version: "v2", Strategy: design.VersionMediaType, Header: "Accept", Value: "application/vnd.calc.v2+json"},
+		}, map[string]string{
+			"calc": "/add",
+		}, []string{"v2"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			root := design.RunHTTPDSL(t, c.DSL)
+			for name, expected := range c.Versioning {
+				svc := root.Service(name)
+				if svc == nil {
+					t.Fatalf("service %q not found", name)
+				}
+				if v := svc.Versioning(); !reflect.DeepEqual(v, expected) {
+					t.Errorf("%s: got versioning %+v, expected %+v", name, v, expected)
+				}
+				if p := svc.HTTPEndpoints[0].Routes[0].FullPaths()[0]; p != c.Paths[name] {
+					t.Errorf("%s: got path %q, expected %q", name, p, c.Paths[name])
+				}
+			}
+			if vers := root.Versions(); !reflect.DeepEqual(vers, c.Versions) {
+				t.Errorf("got versions %v, expected %v", vers, c.Versions)
+			}
+		})
+	}
+}
+
+func TestVersioningValidation(t *testing.T) {
+	err := design.RunInvalidHTTPDSL(t, testdata.InvalidVersionStrategyDSL)
+	expected := `service "calc": invalid versioning strategy "query", must be one of "path", "header" or "mediatype"`
+	if err.Error() != expected {
+		t.Errorf("got error %q, expected %q", err.Error(), expected)
+	}
+}
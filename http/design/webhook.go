@@ -0,0 +1,65 @@
+package design
+
+import (
+	"fmt"
+
+	"goa.design/goa/design"
+	"goa.design/goa/eval"
+)
+
+type (
+	// WebhookExpr describes a webhook, a request sent by the API to a URL
+	// registered by a client through an endpoint.
+	WebhookExpr struct {
+		// Name is the name of the webhook sent in the event header.
+		Name string
+		// Description is the webhook description.
+		Description string
+		// Payload describes the webhook request body.
+		Payload *design.AttributeExpr
+		// URLAttribute is the name of the endpoint payload attribute
+		// that holds the URL the webhook is sent to.
+		URLAttribute string
+		// Endpoint is the endpoint that registers the webhook URL.
+		Endpoint *EndpointExpr
+	}
+)
+
+// EvalName returns the generic definition name used in error messages.
+func (w *WebhookExpr) EvalName() string {
+	return fmt.Sprintf("webhook %q of %s", w.Name, w.Endpoint.EvalName())
+}
+
+// Validate makes sure the webhook payload is an object and that the callback
+// URL attribute is a string attribute of the endpoint method payload.
+func (w *WebhookExpr) Validate() *eval.ValidationErrors {
+	verr := new(eval.ValidationErrors)
+	if w.Name == "" {
+		verr.Add(w.Endpoint, "Webhook name cannot be empty.")
+		return verr
+	}
+	for _, e := range w.Endpoint.Service.HTTPEndpoints {
+		for _, o := range e.Webhooks {
+			if o == w {
+				break
+			}
+			if o.Name == w.Name {
+				verr.Add(w, "webhook is already declared by %s.", e.EvalName())
+			}
+		}
+		if e == w.Endpoint {
+			break
+		}
+	}
+	if w.Payload == nil || !design.IsObject(w.Payload.Type) {
+		verr.Add(w, "webhook payload must be an object.")
+	}
+	if w.URLAttribute == "" {
+		verr.Add(w, "callback URL attribute is not defined, use CallbackURL to define it.")
+	} else if att := w.Endpoint.MethodExpr.Payload.Find(w.URLAttribute); att == nil {
+		verr.Add(w, "callback URL attribute %q is not an attribute of the method payload.", w.URLAttribute)
+	} else if att.Type != design.String {
+		verr.Add(w, "callback URL attribute %q must be a string.", w.URLAttribute)
+	}
+	return verr
+}
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ErrContentChanged is the error returned by Download when the content changed
+// since the first bytes were downloaded. The download must be restarted from
+// the beginning.
+var ErrContentChanged = errors.New("content changed during download")
+
+// Download sends the given GET request using doer and writes the content of
+// the response body to w starting at the given offset. Download uses range
+// requests so that servers that limit the size of partial responses (see
+// FileServer) are queried until the whole content has been retrieved.
+// Interrupted transfers are resumed automatically as long as the previous
+// attempt made progress, Download returns io.ErrNoProgress if the server
+// responds with an empty partial content. The resumed requests set the
+// If-Range header to the entity tag or last modification time of the
+// previous response, Download returns ErrContentChanged if the server
+// responds with the whole, different, content instead.
+//
+// Download returns the offset of the last byte written to w plus one. If an
+// error occurs the returned offset may be given to a subsequent call to
+// resume the download.
+func Download(doer Doer, req *http.Request, w io.Writer, offset int64) (int64, error) {
+	var (
+		size    int64 = -1
+		ifRange string
+	)
+	for size < 0 || offset < size {
+		r := new(http.Request)
+		*r = *req
+		r.Header = make(http.Header, len(req.Header)+2)
+		for k, v := range req.Header {
+			r.Header[k] = v
+		}
+		r.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		if ifRange != "" {
+			r.Header.Set("If-Range", ifRange)
+		}
+		resp, err := doer.Do(r)
+		if err != nil {
+			return offset, err
+		}
+		var n int64
+		switch resp.StatusCode {
+		case http.StatusOK:
+			if offset > 0 && ifRange != "" {
+				// The content changed, the bytes already
+				// written belong to the previous version.
+				resp.Body.Close()
+				return offset, ErrContentChanged
+			}
+			// Server does not support ranges, skip what was
+			// already written.
+			if _, err = io.CopyN(ioutil.Discard, resp.Body, offset); err == nil {
+				n, err = io.Copy(w, resp.Body)
+				offset += n
+				if err == nil {
+					size = offset
+				}
+			}
+		case http.StatusPartialContent:
+			var start int64
+			start, size, err = parseContentRange(resp.Header.Get("Content-Range"))
+			if err == nil && start != offset {
+				err = fmt.Errorf("unexpected Content-Range %q, expected range starting at %d", resp.Header.Get("Content-Range"), offset)
+			}
+			if err == nil {
+				n, err = io.Copy(w, resp.Body)
+				offset += n
+				if err == nil && n == 0 && offset < size {
+					// The server sent an empty partial response,
+					// retrying would not make progress.
+					err = io.ErrNoProgress
+				}
+			}
+		case http.StatusRequestedRangeNotSatisfiable:
+			// The content was already fully downloaded.
+			resp.Body.Close()
+			if _, total, err := parseContentRange(resp.Header.Get("Content-Range")); err == nil && total == offset {
+				return offset, nil
+			}
+			return offset, fmt.Errorf("invalid response code %d", resp.StatusCode)
+		default:
+			body, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			return offset, fmt.Errorf("invalid response code %d: %s", resp.StatusCode, string(body))
+		}
+		resp.Body.Close()
+		if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			ifRange = etag
+		} else if lm := resp.Header.Get("Last-Modified"); lm != "" {
+			ifRange = lm
+		}
+		if err != nil && n == 0 {
+			return offset, err
+		}
+	}
+	return offset, nil
+}
+
+// parseContentRange parses the value of a Content-Range header, e.g.
+// "bytes 0-499/1234", and returns the start of the range and the complete
+// length of the content. Ranges of the form "bytes */1234" return a start of
+// -1.
+func parseContentRange(s string) (start, size int64, err error) {
+	const b = "bytes "
+	if !strings.HasPrefix(s, b) {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", s)
+	}
+	i := strings.Index(s, "/")
+	if i < 0 {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", s)
+	}
+	size, err = strconv.ParseInt(s[i+1:], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", s)
+	}
+	rng := s[len(b):i]
+	if rng == "*" {
+		return -1, size, nil
+	}
+	j := strings.Index(rng, "-")
+	if j < 0 {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", s)
+	}
+	start, err = strconv.ParseInt(rng[:j], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid Content-Range %q", s)
+	}
+	return start, size, nil
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rangeDoer serves content using serveContent and interrupts the response
+// bodies after failAfter bytes when failAfter is positive.
+type rangeDoer struct {
+	content   string
+	chunkSize int64
+	failAfter int
+}
+
+func (d *rangeDoer) Do(req *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	modtime := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	serveContent(w, req, "file.txt", modtime, int64(len(d.content)), strings.NewReader(d.content), d.chunkSize)
+	resp := w.Result()
+	if d.failAfter > 0 {
+		b := w.Body.Bytes()
+		if len(b) > d.failAfter {
+			b = b[:d.failAfter]
+		}
+		resp.Body = &failingReader{Reader: bytes.NewReader(b)}
+	}
+	return resp, nil
+}
+
+// failingReader returns an error instead of io.EOF.
+type failingReader struct {
+	io.Reader
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if err == io.EOF {
+		err = errors.New("connection reset")
+	}
+	return n, err
+}
+
+func (r *failingReader) Close() error { return nil }
+
+// emptyRangeDoer responds to all the requests with empty partial content.
+type emptyRangeDoer struct {
+	size int
+}
+
+func (d *emptyRangeDoer) Do(req *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes 0-%d/%d", d.size-1, d.size))
+	w.WriteHeader(http.StatusPartialContent)
+	return w.Result(), nil
+}
+
+// changingDoer serves a first version of the content interrupted after a few
+// bytes and then a second version with a different entity tag.
+type changingDoer struct {
+	calls   int
+	ifRange string
+}
+
+func (d *changingDoer) Do(req *http.Request) (*http.Response, error) {
+	d.calls++
+	w := httptest.NewRecorder()
+	if d.calls == 1 {
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Range", "bytes 0-9/10")
+		w.WriteHeader(http.StatusPartialContent)
+		resp := w.Result()
+		resp.Body = &failingReader{Reader: strings.NewReader("01234")}
+		return resp, nil
+	}
+	d.ifRange = req.Header.Get("If-Range")
+	w.Header().Set("ETag", `"v2"`)
+	w.WriteHeader(http.StatusOK)
+	w.WriteString("abcdefghij")
+	return w.Result(), nil
+}
+
+func TestDownload(t *testing.T) {
+	const content = "0123456789"
+	cases := []struct {
+		Name      string
+		ChunkSize int64
+		FailAfter int
+		Offset    int64
+	}{
+		{"whole", 0, 0, 0},
+		{"chunks", 3, 0, 0},
+		{"resume", 0, 0, 4},
+		{"resume chunks", 4, 0, 6},
+		{"interrupted", 0, 3, 0},
+		{"complete", 0, 0, 10},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			doer := &rangeDoer{content: content, chunkSize: c.ChunkSize, failAfter: c.FailAfter}
+			req := httptest.NewRequest("GET", "/file.txt", nil)
+			var buf bytes.Buffer
+			buf.WriteString(content[:c.Offset])
+			n, err := Download(doer, req, &buf, c.Offset)
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if n != int64(len(content)) {
+				t.Errorf("got offset %d, expected %d", n, len(content))
+			}
+			if buf.String() != content {
+				t.Errorf("got content %q, expected %q", buf.String(), content)
+			}
+		})
+	}
+}
+
+func TestDownloadNoProgress(t *testing.T) {
+	req := httptest.NewRequest("GET", "/file.txt", nil)
+	var buf bytes.Buffer
+	n, err := Download(&emptyRangeDoer{size: 10}, req, &buf, 0)
+	if err != io.ErrNoProgress {
+		t.Fatalf("got error %v, expected %v", err, io.ErrNoProgress)
+	}
+	if n != 0 {
+		t.Errorf("got offset %d, expected 0", n)
+	}
+}
+
+func TestDownloadContentChanged(t *testing.T) {
+	req := httptest.NewRequest("GET", "/file.txt", nil)
+	var (
+		buf  bytes.Buffer
+		doer = &changingDoer{}
+	)
+	n, err := Download(doer, req, &buf, 0)
+	if err != ErrContentChanged {
+		t.Fatalf("got error %v, expected %v", err, ErrContentChanged)
+	}
+	if doer.ifRange != `"v1"` {
+		t.Errorf("got If-Range %q, expected %q", doer.ifRange, `"v1"`)
+	}
+	if n != 5 {
+		t.Errorf("got offset %d, expected 5", n)
+	}
+	if buf.String() != "01234" {
+		t.Errorf("got content %q, expected %q", buf.String(), "01234")
+	}
+}
@@ -7,6 +7,8 @@
 package dsl
 
 import (
+	"time"
+
 	"goa.design/goa/design"
 	dsl "goa.design/goa/dsl"
 )
@@ -81,6 +83,18 @@ func API(name string, fn func()) *design.APIExpr {
 //        })
 //    })
 //
+//    Method("secured_delete", func() {
+//        Security(APIKeyAuth)
+//        Payload(func() {
+//            APIKey("api_key", "key", String, "API key used to perform authorization")
+//            Required("key")
+//        })
+//        HTTP(func() {
+//            DELETE("/")
+//            Cookie("key:api_key") // Provide the key in cookie "api_key"
+//        })
+//    })
+//
 func APIKey(scheme, name string, args ...interface{}) {
 	dsl.APIKey(scheme, name, args...)
 }
@@ -466,11 +480,57 @@ func CreateFrom(obj interface{}) {
 	dsl.CreateFrom(obj)
 }
 
+// Cursor defines the attributes holding the page cursors.
+//
+// Cursor must appear in a Paginate expression.
+//
+// Cursor takes two arguments: the name of the String payload attribute holding
+// the cursor of the requested page and the name of the String result attribute
+// holding the cursor of the next page. The last page is reached when the next
+// page cursor is empty.
+//
+// Example:
+//
+//    Paginate(func() {
+//        Cursor("cursor", "next")
+//        Items("bottles")
+//    })
+func Cursor(param, next string) {
+	dsl.Cursor(param, next)
+}
+
 // Default sets the default value for an attribute.
 func Default(def interface{}) {
 	dsl.Default(def)
 }
 
+// Deprecated marks a method or an attribute as deprecated. The generated code
+// documents deprecated methods and struct fields with a "Deprecated:" comment,
+// the OpenAPI specification flags the corresponding operations and properties
+// as deprecated and the generated CLI prints a warning when a deprecated
+// endpoint is invoked.
+//
+// Deprecated must appear in Method or Attribute.
+//
+// Deprecated accepts a single non-empty argument which is the reason for the
+// deprecation, typically indicating what to use instead.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Deprecated("use the paginated method instead")
+//        Payload(func() {
+//            Attribute("filter", String, func() {
+//                Deprecated("use the query attribute instead")
+//            })
+//            Attribute("query", String)
+//        })
+//    })
+//
+func Deprecated(reason string) {
+	dsl.Deprecated(reason)
+}
+
 // Elem makes it possible to specify validations for array and map values.
 func Elem(fn func()) {
 	dsl.Elem(fn)
@@ -555,6 +615,28 @@ func Example(args ...interface{}) {
 	dsl.Example(args...)
 }
 
+// Event marks the method as an event published on a message broker. The code
+// generator produces a pub/sub transport for the services that define events:
+// a publisher whose endpoints encode the method payload and publish it on the
+// event subject and a subscriber that decodes and validates the messages
+// received on the subject before calling the method endpoint.
+//
+// Event must appear in a Method expression. The method payload must be an
+// object and the method may not define a result or stream.
+//
+// Event takes one argument: the name of the subject (NATS) or topic (Kafka).
+//
+// Example:
+//
+//    Method("order_created", func() {
+//        Payload(Order)
+//        Event("orders.created")
+//    })
+//
+func Event(subject string) {
+	dsl.Event(subject)
+}
+
 // Extend adds the parameter type attributes to the type using Extend. The
 // parameter type must be an object.
 //
@@ -645,10 +727,30 @@ func Field(tag interface{}, name string, args ...interface{}) {
 //
 // FormatRFC1123: RFC1123 date time
 //
+// Custom formats registered with goa.RegisterFormat may also be used, the
+// generated code validates the values using the registered function:
+//
+//    func init() {
+//        goa.RegisterFormat("ulid", func(v string) error {
+//            _, err := ulid.Parse(v)
+//            return err
+//        })
+//    }
+//
+//    Attribute("id", String, func() {
+//        Format("ulid")
+//    })
+//
 func Format(f design.ValidationFormat) {
 	dsl.Format(f)
 }
 
+// GreaterThan validates that the attribute value is greater than the value of
+// the sibling attribute with the given name.
+func GreaterThan(name string) {
+	dsl.GreaterThan(name)
+}
+
 // ImplicitFlow defines an implicit OAuth2 flow as described in section 1.3.2
 // of RFC 6749.
 //
@@ -659,6 +761,104 @@ func ImplicitFlow(authorizationURL, refreshURL string) {
 	dsl.ImplicitFlow(authorizationURL, refreshURL)
 }
 
+// Import makes it possible to refer to the types and security schemes of the
+// given namespaces using their unqualified names. The types and security
+// schemes defined in the design take precedence over the imported ones, a name
+// defined in more than one imported namespace must be qualified.
+//
+// Import must appear in API and should be called prior to any other DSL that
+// refers to the imported types or security schemes. The Go packages defining
+// the namespaces must be imported by the design package, see Namespace.
+//
+// Import accepts one or more namespace names as argument.
+//
+// Example:
+//
+//    import (
+//        common "acme.com/common/design"
+//        . "goa.design/goa/dsl"
+//    )
+//
+//    var _ = API("orders", func() {
+//        Import("common")
+//        Security("jwt") // refers to "common.jwt"
+//    })
+//
+//    var Order = Type("Order", func() {
+//        Attribute("id", "UUID")         // refers to "common.UUID"
+//        Attribute("owner", common.UUID) // same
+//    })
+func Import(namespaces ...string) {
+	dsl.Import(namespaces...)
+}
+
+// Interceptor lists interceptors invoked prior to the service methods. The
+// generated service package defines an Interceptors interface with one method
+// per interceptor name, the generated NewEndpoints function accepts an
+// implementation of the interface and wraps each endpoint so that its
+// interceptors are invoked in order before the service method.
+//
+// Interceptor may appear in an API, Service or Method expression. The
+// interceptors of a method are the API interceptors followed by the service
+// interceptors followed by the method interceptors, an interceptor listed more
+// than once is invoked only once.
+//
+// Interceptor accepts one or more interceptor names as argument. Interceptor
+// may be called multiple times in the same expression.
+//
+// Example:
+//
+//    var _ = Service("divider", func() {
+//        Interceptor("logging")
+//
+//        Method("divide", func() {
+//            Interceptor("auth", "ratelimit")
+//        })
+//    })
+//
+func Interceptor(names ...string) {
+	dsl.Interceptor(names...)
+}
+
+// Internal marks an attribute as internal to the service. The attribute is a
+// field of the generated service types but is never exposed by the transport:
+// the HTTP request and response bodies and the OpenAPI specification do not
+// include it and it cannot be mapped to a header, parameter or cookie. Internal
+// is a shorthand for Metadata("transport:omit").
+//
+// Internal must appear in Attribute.
+//
+// Internal takes no argument.
+//
+// Example:
+//
+//    var User = Type("User", func() {
+//        Attribute("name", String)
+//        Attribute("password_hash", String, func() {
+//            Internal()
+//        })
+//    })
+//
+func Internal() {
+	dsl.Internal()
+}
+
+// Items defines the result attribute listing the items of a page.
+//
+// Items must appear in a Paginate expression.
+//
+// Items takes one argument: the name of the result array attribute.
+//
+// Example:
+//
+//    Paginate(func() {
+//        Cursor("cursor", "next")
+//        Items("bottles")
+//    })
+func Items(name string) {
+	dsl.Items(name)
+}
+
 // JWTSecurity defines an HTTP security scheme where a JWT is passed in the
 // request Authorization header as a bearer token to perform auth. This scheme
 // supports defining scopes that endpoint may require to authorize the request.
@@ -690,6 +890,12 @@ func Key(fn func()) {
 	dsl.Key(fn)
 }
 
+// LessThan validates that the attribute value is lesser than the value of the
+// sibling attribute with the given name.
+func LessThan(name string) {
+	dsl.LessThan(name)
+}
+
 // License sets the API license information.
 func License(fn func()) {
 	dsl.License(fn)
@@ -771,6 +977,49 @@ func Name(name string) {
 	dsl.Name(name)
 }
 
+// Namespace defines types and security schemes that may be shared by multiple
+// designs. A shared design package typically defines a namespace in a
+// package-level variable declaration. The designs making use of the namespace
+// import the package and refer to its types and security schemes either via
+// the package variables or by name.
+//
+// The names of the types and security schemes defined in the namespace are
+// qualified with the namespace name, for example the type "UUID" defined in the
+// namespace "common" is named "common.UUID". This makes it possible for
+// multiple shared design packages to define types or security schemes with the
+// same name. The DSL of the types defined in a namespace may refer to the other
+// types of the namespace using their unqualified names.
+//
+// Namespace is a top level DSL. Namespace may be called multiple times with
+// the same name, for example in different files of the same package, the types
+// and security schemes are added to the same namespace. Contrary to most DSL
+// functions the namespace DSL is executed right away so that the types and
+// security schemes may be assigned to package variables.
+//
+// Namespace takes two arguments: the namespace name and the DSL defining the
+// types and security schemes. The name may not contain the "." character.
+//
+// Example:
+//
+//    package design // import "acme.com/common/design"
+//
+//    var (
+//        UUID    design.UserType
+//        JWTAuth *design.SchemeExpr
+//    )
+//
+//    var _ = Namespace("common", func() {
+//        UUID = Type("UUID", String, func() {
+//            Format(FormatUUID)
+//        })
+//        JWTAuth = JWTSecurity("jwt", func() {
+//            Scope("api:read")
+//        })
+//    })
+func Namespace(name string, fn func()) *design.NamespaceExpr {
+	return dsl.Namespace(name, fn)
+}
+
 // NoSecurity removes the need for an endpoint to perform authorization.
 //
 // NoSecurity must appear in Method.
@@ -801,6 +1050,90 @@ func OAuth2Security(name string, fn ...func()) *design.SchemeExpr {
 	return dsl.OAuth2Security(name, fn...)
 }
 
+// Offset defines the attributes holding the index of the first item of a page
+// and optionally the maximum number of items in a page.
+//
+// Offset must appear in a Paginate expression.
+//
+// Offset takes one or two arguments: the name of the Int payload attribute
+// holding the index of the first item of the requested page and optionally the
+// name of the Int payload attribute holding the maximum number of items. The
+// last page is reached when a page contains no item or fewer items than the
+// limit.
+//
+// Example:
+//
+//    Paginate(func() {
+//        Offset("offset", "limit")
+//        Items("bottles")
+//    })
+func Offset(offset string, limit ...string) {
+	dsl.Offset(offset, limit...)
+}
+
+// Output sets the directory where the code generated for the services is
+// written, making it possible to use layouts such as "services/<name>/gen"
+// instead of a single "gen" directory. The service specific packages (service,
+// views, transport server and client packages etc.) are generated under the
+// given directory using the same structure as the "gen" directory. The files
+// shared by all services such as the CLI and the OpenAPI specification are
+// still generated in the "gen" directory.
+//
+// Output may appear in API or Service. A service uses the output directory of
+// the API if it does not define one.
+//
+// Output accepts one or two arguments. The first argument is the directory
+// relative to the output directory. The second optional argument is the import
+// path of the directory. It only needs to be set when the import path cannot be
+// computed from the import path of the "gen" directory, for example when the
+// generated code belongs to a different Go module. Both arguments may contain
+// the "{service}" placeholder which is replaced with the snake case name of the
+// service.
+//
+// Example:
+//
+//    var _ = API("monorepo", func() {
+//        Output("services/{service}/gen")
+//    })
+//
+//    var _ = Service("billing", func() {
+//        Output("billing/gen", "example.com/billing/gen")
+//    })
+//
+func Output(dir string, importPath ...string) {
+	dsl.Output(dir, importPath...)
+}
+
+// Paginate describes how the results of a method are split into pages. The
+// generated service client exposes an iterator that fetches the subsequent
+// pages transparently and the OpenAPI specification documents the pagination
+// attributes.
+//
+// Paginate must appear in a Method expression.
+//
+// Paginate takes a single argument which is the defining DSL. The DSL must
+// use Items and either Cursor or Offset.
+//
+// Example:
+//
+//    Method("list", func() {
+//        Payload(func() {
+//            Attribute("cursor", String, "Cursor of page to retrieve")
+//            Attribute("limit", Int, "Maximum number of items in page")
+//        })
+//        Result(func() {
+//            Attribute("bottles", ArrayOf(Bottle))
+//            Attribute("next", String, "Cursor of next page")
+//        })
+//        Paginate(func() {
+//            Cursor("cursor", "next")
+//            Items("bottles")
+//        })
+//    })
+func Paginate(fn func()) {
+	dsl.Paginate(fn)
+}
+
 // Password defines the attribute used to provide the password to an endpoint
 // secured with basic authentication. The parameters and usage of Password are
 // the same as the goa DSL Attribute function.
@@ -912,6 +1245,64 @@ func Payload(val interface{}, args ...interface{}) {
 	dsl.Payload(val, args...)
 }
 
+// Proxy implements the method by forwarding the requests to a method of a
+// service defined in another design. The code generator produces a Proxy
+// struct in the service package that implements the proxied methods by calling
+// the typed client generated for the remote service, see dsl.Proxy.
+//
+// Proxy must appear in a Method expression. The method may not stream.
+//
+// Proxy takes three arguments: the import path of the package containing the
+// code generated for the remote design, the name of the remote service and the
+// name of the remote method.
+//
+// Example:
+//
+//    Method("show_user", func() {
+//        Payload(func() {
+//            Token("token", String)
+//            Attribute("id", String)
+//        })
+//        Result(User)
+//        Proxy("acme.com/users/gen", "users", "show")
+//        HTTP(func() {
+//            GET("/users/{id}")
+//        })
+//    })
+//
+func Proxy(pkg, service, method string) {
+	dsl.Proxy(pkg, service, method)
+}
+
+// RateLimit sets the maximum number of requests accepted by the methods over a
+// period of time. The generated HTTP server defines a UseRateLimiter method
+// that wraps the handlers of the rate limited endpoints with a middleware
+// enforcing the limits using a pluggable goahttp.RateLimiter. Requests that
+// exceed the limit receive a 429 Too Many Requests response with a Retry-After
+// header. The limits are documented in the OpenAPI specification with the
+// "x-rate-limit" extension.
+//
+// RateLimit may appear in an API, Service or Method expression. A method
+// inherits the rate limit of its service if it does not define one and a
+// service inherits the rate limit of the API.
+//
+// RateLimit takes two arguments: the positive maximum number of requests and
+// the positive duration of the period.
+//
+// Example:
+//
+//    var _ = Service("divider", func() {
+//        RateLimit(1000, time.Hour)
+//
+//        Method("divide", func() {
+//            RateLimit(10, time.Second)
+//        })
+//    })
+//
+func RateLimit(n int, per time.Duration) {
+	dsl.RateLimit(n, per)
+}
+
 // Reference sets a type or result type reference. The value itself can be a
 // type or a result type. The reference type attributes define the default
 // properties for attributes with the same name in the type using the reference.
@@ -959,6 +1350,12 @@ func Required(names ...string) {
 	dsl.Required(names...)
 }
 
+// RequiredIf makes the attribute required when the sibling attribute with the
+// given name has the given value.
+func RequiredIf(name string, value interface{}) {
+	dsl.RequiredIf(name, value)
+}
+
 // Result defines the data type of a method output.
 //
 // Result must appear in a Method expression.
@@ -1168,7 +1565,26 @@ func Security(args ...interface{}) {
 	dsl.Security(args...)
 }
 
-// Server defines an API host.
+// Server defines an API host. The server URL may contain variables using the
+// "{name}" syntax that are described with Param and must define a default
+// value.
+//
+// Server must appear in API or Service.
+//
+// Server accepts one or two arguments: the server URL and optionally a DSL
+// that defines the server description and variables.
+//
+// Example:
+//
+//    var _ = API("calc", func() {
+//        Server("https://{environment}.calc.com", func() {
+//            Param("environment", String, func() {
+//                Enum("prod", "staging")
+//                Default("prod")
+//            })
+//        })
+//    })
+//
 func Server(url string, fn ...func()) {
 	dsl.Server(url, fn...)
 }
@@ -1283,11 +1699,14 @@ func TermsOfService(terms string) {
 	dsl.TermsOfService(terms)
 }
 
-// Timeout qualifies an error type as describing errors due to timeouts.
+// Timeout qualifies an error type as describing errors due to timeouts when
+// used in an Error expression. Timeout sets the maximum duration of the
+// requests made to the method when used in a Method expression.
 //
-// Timeout must appear in a Error expression.
+// Timeout must appear in a Error or Method expression.
 //
-// Timeout takes no argument.
+// Timeout takes no argument when used in an Error expression and a positive
+// duration when used in a Method expression.
 //
 // Example:
 //
@@ -1295,9 +1714,12 @@ func TermsOfService(terms string) {
 //	   Error("request_timeout", func() {
 //		   Timeout()
 //	   })
+//	   Method("divide", func() {
+//		   Timeout(5 * time.Second)
+//	   })
 //    })
-func Timeout() {
-	dsl.Timeout()
+func Timeout(d ...time.Duration) {
+	dsl.Timeout(d...)
 }
 
 // Title sets the API title used by the generated documentation and code comments.
@@ -1431,7 +1853,50 @@ func Username(name string, args ...interface{}) {
 	dsl.Username(name, args...)
 }
 
-// Version specifies the API version. One design describes one version.
+// Version specifies the API or service version.
+//
+// Version may appear in API or Service. The API version is used by the
+// generated documentation. The service version is mapped to the HTTP requests
+// using one of the following strategies, selected with the "version:strategy"
+// metadata set on the service or the API:
+//
+//    - "path" (default) prefixes the service routes with the version, e.g.
+//      "/v2/calc/add". This makes it possible to mount multiple versions of
+//      a service that define the same routes on the same server.
+//    - "header" sets the version in the "API-Version" request header, the
+//      "version:header" metadata overrides the name of the header.
+//    - "mediatype" sets the version in the vendor media type listed in the
+//      Accept request header, e.g. "application/vnd.calc.v2+json".
+//
+// The generated clients set the version header for the header and media type
+// strategies. When the API defines the "version:strategy" metadata the API
+// version applies to the services that do not define one. The OpenAPI
+// specification of the services of each version is generated in the
+// gen/http/<version> directory in addition to the specification of the API.
+//
+// Version takes a single argument: the version string.
+//
+// Example:
+//
+//    var _ = Service("calc", func() {
+//        Version("v1")
+//        Method("add", func() {
+//            HTTP(func() {
+//                GET("/add") // GET /v1/add
+//            })
+//        })
+//    })
+//
+//    var _ = Service("calc_v2", func() {
+//        Version("v2")
+//        Metadata("version:strategy", "header")
+//        Method("add", func() {
+//            HTTP(func() {
+//                GET("/add") // GET /add with header API-Version: v2
+//            })
+//        })
+//    })
+//
 func Version(ver string) {
 	dsl.Version(ver)
 }
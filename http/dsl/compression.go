@@ -0,0 +1,45 @@
+package dsl
+
+import (
+	"goa.design/goa/eval"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// Compress enables the compression of the responses sent by the API or by a
+// service. The generated HTTP server negotiates the content encoding with the
+// client using the request Accept-Encoding header and compresses the JSON
+// response bodies using gzip or deflate. Only the bodies whose content type is
+// application/json or has the +json suffix are compressed, the bodies encoded
+// with other encoders such as the MessagePack and CBOR encoders of the
+// goa.design/goa/http/encoding/msgpackenc and cborenc packages are sent
+// uncompressed. The generated HTTP client requests compressed responses and
+// decompresses them transparently. A policy defined on a service overrides
+// the policy defined on the API.
+//
+// Compress must appear in the API or a Service HTTP expression.
+//
+// Compress accepts one argument: the minimum size in bytes of the response
+// bodies that get compressed, smaller bodies are sent uncompressed. A value
+// of zero means all the bodies are compressed.
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        HTTP(func() {
+//            Compress(1024) // Compress bodies of 1KB or more
+//        })
+//    })
+//
+func Compress(minSize int) {
+	c := &httpdesign.CompressionExpr{MinSize: minSize}
+	switch actual := eval.Current().(type) {
+	case *httpdesign.RootExpr:
+		c.Parent = actual
+		actual.Compression = c
+	case *httpdesign.ServiceExpr:
+		c.Parent = actual
+		actual.Compression = c
+	default:
+		eval.IncompatibleDSL()
+	}
+}
@@ -0,0 +1,108 @@
+package dsl
+
+import (
+	"strings"
+
+	"goa.design/goa/eval"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// CORS defines the Cross-Origin Resource Sharing policy of the API or of a
+// service. The generated HTTP server handles the preflight OPTIONS requests and
+// sets the CORS response headers for all the service endpoints. A policy
+// defined on a service overrides the policy defined on the API.
+//
+// CORS must appear in the API or a Service HTTP expression.
+//
+// CORS accepts one argument: the defining DSL which must list the allowed
+// origins with AllowOrigins and may use AllowMethods, AllowHeaders and MaxAge
+// to further configure the policy.
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        HTTP(func() {
+//            CORS(func() {
+//                AllowOrigins("https://goa.design", "https://*.goa.design")
+//                AllowMethods("GET", "POST")
+//                AllowHeaders("X-Shared-Secret")
+//                MaxAge(600)
+//            })
+//        })
+//    })
+//
+func CORS(fn func()) {
+	cors := &httpdesign.CORSExpr{}
+	switch actual := eval.Current().(type) {
+	case *httpdesign.RootExpr:
+		cors.Parent = actual
+		actual.CORS = cors
+	case *httpdesign.ServiceExpr:
+		cors.Parent = actual
+		actual.CORS = cors
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	eval.Execute(fn, cors)
+}
+
+// AllowOrigins adds origins to the list of origins allowed to make cross-origin
+// requests. An origin may be "*" to allow any origin or may contain a single
+// "*" wildcard to match a range of origins, e.g. "https://*.goa.design".
+//
+// AllowOrigins must appear in a CORS expression.
+//
+// AllowOrigins accepts one or more strings corresponding to the origins.
+func AllowOrigins(origins ...string) {
+	if cors, ok := eval.Current().(*httpdesign.CORSExpr); ok {
+		cors.Origins = append(cors.Origins, origins...)
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// AllowMethods adds HTTP methods to the list of methods allowed in
+// cross-origin requests. The methods used by the service endpoints are allowed
+// if AllowMethods is not used.
+//
+// AllowMethods must appear in a CORS expression.
+//
+// AllowMethods accepts one or more strings corresponding to the HTTP methods.
+func AllowMethods(methods ...string) {
+	if cors, ok := eval.Current().(*httpdesign.CORSExpr); ok {
+		for _, m := range methods {
+			cors.Methods = append(cors.Methods, strings.ToUpper(m))
+		}
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// AllowHeaders adds headers to the list of request headers allowed in
+// cross-origin requests.
+//
+// AllowHeaders must appear in a CORS expression.
+//
+// AllowHeaders accepts one or more strings corresponding to the header names.
+func AllowHeaders(headers ...string) {
+	if cors, ok := eval.Current().(*httpdesign.CORSExpr); ok {
+		cors.Headers = append(cors.Headers, headers...)
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// MaxAge sets the number of seconds the result of a CORS preflight request may
+// be cached by the client.
+//
+// MaxAge must appear in a CORS expression.
+//
+// MaxAge accepts one argument: the number of seconds.
+func MaxAge(seconds uint) {
+	if cors, ok := eval.Current().(*httpdesign.CORSExpr); ok {
+		cors.MaxAge = seconds
+		return
+	}
+	eval.IncompatibleDSL()
+}
@@ -8,8 +8,8 @@ import (
 
 // Description sets the expression description.
 //
-// Description must appear in API, Service, Endpoint, Files, Response, Type,
-// ResultType or Attribute.
+// Description must appear in API, Service, Endpoint, Files, Response, Webhook,
+// Type, ResultType or Attribute.
 //
 // Description accepts a single argument which is the description value.
 //
@@ -25,6 +25,8 @@ func Description(d string) {
 		expr.Description = d
 	case *httpdesign.FileServerExpr:
 		expr.Description = d
+	case *httpdesign.WebhookExpr:
+		expr.Description = d
 	default:
 		dsl.Description(d)
 	}
@@ -66,3 +66,58 @@ func Files(path, filename string, fns ...func()) {
 		r.FileServers = append(r.FileServers, server)
 	}
 }
+
+// AcceptRanges makes the file server handle range requests explicitly. The
+// generated server always sets the Accept-Ranges response header and returns
+// partial responses with the corresponding Content-Range header for requests
+// that specify a Range header. Clients may use the goa http package Download
+// function to retrieve the content in chunks and to resume interrupted
+// downloads.
+//
+// AcceptRanges must appear in a Files expression.
+//
+// AcceptRanges accepts one argument: the maximum number of bytes returned in a
+// single partial response. A value of zero means there is no limit.
+//
+// Example:
+//
+//    var _ = Service("bottle", func() {
+//        Files("/videos/{*filepath}", "/www/data/videos", func() {
+//            AcceptRanges(1024 * 1024)
+//        })
+//    })
+//
+func AcceptRanges(chunkSize int64) {
+	if s, ok := eval.Current().(*httpdesign.FileServerExpr); ok {
+		s.AcceptRanges = true
+		s.ChunkSize = chunkSize
+		return
+	}
+	eval.IncompatibleDSL()
+}
+
+// Embed embeds the assets served by the file server in the generated server
+// package so that the server binary does not need to access the file system.
+// The assets are copied to the generated code directory each time the code is
+// generated. The file path given to Files must be relative to the directory
+// where the code is generated and must not refer to a parent directory.
+//
+// Embed must appear in a Files expression.
+//
+// Embed takes no argument.
+//
+// Example:
+//
+//    var _ = Service("bottle", func() {
+//        Files("/{*filepath}", "public", func() {
+//            Embed()
+//        })
+//    })
+//
+func Embed() {
+	if s, ok := eval.Current().(*httpdesign.FileServerExpr); ok {
+		s.Embed = true
+		return
+	}
+	eval.IncompatibleDSL()
+}
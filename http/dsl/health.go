@@ -0,0 +1,49 @@
+package dsl
+
+import (
+	"goa.design/goa/eval"
+	httpdesign "goa.design/goa/http/design"
+)
+
+// HealthCheck mounts liveness and readiness check endpoints on the API servers.
+// The endpoints run the checker functions given to the generated server when
+// it is mounted and respond with status 200 when all the checkers succeed or
+// 503 otherwise. The OpenAPI specification documents both endpoints.
+//
+// HealthCheck must appear in the API HTTP expression.
+//
+// HealthCheck accepts zero, one or two arguments: the path of the liveness
+// check endpoint which defaults to "/healthz" and the path of the readiness
+// check endpoint which defaults to "/readyz".
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        HTTP(func() {
+//            HealthCheck() // Mounts /healthz and /readyz
+//        })
+//    })
+//
+func HealthCheck(paths ...string) {
+	r, ok := eval.Current().(*httpdesign.RootExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if len(paths) > 2 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	h := &httpdesign.HealthCheckExpr{
+		LivenessPath:  "/healthz",
+		ReadinessPath: "/readyz",
+		Root:          r,
+	}
+	if len(paths) > 0 {
+		h.LivenessPath = paths[0]
+	}
+	if len(paths) > 1 {
+		h.ReadinessPath = paths[1]
+	}
+	r.HealthCheck = h
+}
@@ -4,6 +4,8 @@ import (
 	"strings"
 
 	"reflect"
+	"strconv"
+	"time"
 
 	"goa.design/goa/design"
 	"goa.design/goa/dsl"
@@ -105,11 +107,23 @@ func HTTP(fn func()) {
 
 // Consumes adds a MIME type to the list of MIME types the APIs supports when
 // accepting requests. While the DSL supports any MIME type, the code generator
-// only knows to generate the code for "application/json", "application/xml" and
-// "application/gob". The service code must provide the decoders for other MIME
-// types.
-//
-// Consumes must appear in the HTTP expression of API.
+// only knows to generate the code for "application/json", "application/xml",
+// "application/gob" and "application/msgpack". The MessagePack decoders are
+// provided by the goa.design/goa/http/encoding/msgpackenc package which must
+// be given to the generated server and client constructors. The service code
+// must provide the decoders for other MIME types. The first MIME type is the
+// default content type of the requests: the generated clients set the request
+// Content-Type header to it so that the request bodies get encoded
+// accordingly.
+//
+// Consumes must appear in the HTTP expression of API or of a Method. When used
+// in a Method HTTP expression Consumes lists the alternative representations
+// of the request body: the generated request decoder switches on the request
+// Content-Type header and responds with 415 Unsupported Media Type if the
+// header does not match any of the MIME types. The request bodies encoded with
+// the "application/x-www-form-urlencoded" and "multipart/form-data" MIME types
+// are decoded by mapping the form fields to the body attributes. The OpenAPI
+// specification lists the MIME types in the operation consumes field.
 //
 // Consumes accepts one or more strings corresponding to the MIME types.
 //
@@ -123,10 +137,20 @@ func HTTP(fn func()) {
 //        })
 //    })
 //
+//    Method("create", func() {
+//        Payload(Bottle)
+//        HTTP(func() {
+//            POST("/")
+//            Consumes("application/json", "application/x-www-form-urlencoded", "multipart/form-data")
+//        })
+//    })
+//
 func Consumes(args ...string) {
 	switch def := eval.Current().(type) {
 	case *httpdesign.RootExpr:
 		def.Consumes = append(httpdesign.Root.Consumes, args...)
+	case *httpdesign.EndpointExpr:
+		def.Consumes = append(def.Consumes, args...)
 	default:
 		eval.IncompatibleDSL()
 	}
@@ -134,11 +158,19 @@ func Consumes(args ...string) {
 
 // Produces adds a MIME type to the list of MIME types the APIs supports when
 // writing responses. While the DSL supports any MIME type, the code generator
-// only knows to generate the code for "application/json", "application/xml" and
-// "application/gob". The service code must provide the encoders for other MIME
-// types.
-//
-// Produces must appear in the HTTP expression of API.
+// only knows to generate the code for "application/json", "application/xml",
+// "application/gob" and "application/msgpack". The MessagePack encoders are
+// provided by the goa.design/goa/http/encoding/msgpackenc package which must
+// be given to the generated server and client constructors. The service code
+// must provide the encoders for other MIME types. When used in the API
+// expression the first MIME type is the default content type of the
+// responses: the generated clients set the request Accept header to it.
+//
+// Produces must appear in the HTTP expression of API or in a Response
+// expression. When used in a Response expression Produces lists the MIME types
+// the response may be encoded with. The generated response encoder inspects
+// the request "Accept" header and uses the best matching MIME type, it
+// defaults to the first MIME type in the list if none match.
 //
 // Produces accepts one or more strings corresponding to the MIME types.
 //
@@ -152,10 +184,22 @@ func Consumes(args ...string) {
 //        })
 //    })
 //
+//    Method("show", func() {
+//        Result(Account)
+//        HTTP(func() {
+//            GET("/{id}")
+//            Response(StatusOK, func() {
+//                Produces("application/json", "application/xml")
+//            })
+//        })
+//    })
+//
 func Produces(args ...string) {
 	switch def := eval.Current().(type) {
 	case *httpdesign.RootExpr:
 		def.Produces = append(httpdesign.Root.Produces, args...)
+	case *httpdesign.HTTPResponseExpr:
+		def.Produces = append(def.Produces, args...)
 	default:
 		eval.IncompatibleDSL()
 	}
@@ -214,7 +258,10 @@ func Docs(fn func()) {
 // between two slashes).
 //
 // A wildcard that starts with '{*' matches the rest of the path. Such wildcards
-// must terminate the path.
+// must terminate the path and the corresponding payload attribute must be a
+// String. The captured value does not include the leading slash, for example
+// the path "/files/{*path}" captures "a/b.txt" for the request path
+// "/files/a/b.txt".
 //
 // GET must appear in a method HTTP function.
 //
@@ -348,6 +395,12 @@ func Headers(args interface{}) {
 // may define a mapping between the attribute name and the HTTP header name when
 // they differ. The mapping syntax is "name of attribute:name of header".
 //
+// Request headers may also map to attributes nested in payload object
+// attributes by using a dot separated attribute path, for example
+// "tenant.id:X-Tenant". The nested attribute must be a primitive or an array.
+// It remains part of the request body and the header value overrides the body
+// value when present.
+//
 // Example:
 //
 //    var _ = Service("account", func() {
@@ -379,6 +432,160 @@ func Header(name string, args ...interface{}) {
 	h.Remap()
 }
 
+// Cookie describes a single HTTP cookie. The properties (description, type,
+// validation etc.) of a cookie are inherited from the request or response type
+// attribute with the same name by default.
+//
+// Cookie must appear in a method HTTP expression (to define request cookies)
+// or in a Response expression (to define the cookies set with the response
+// Set-Cookie headers).
+//
+// Cookie accepts the same arguments as the Attribute function. The cookie name
+// may define a mapping between the attribute name and the cookie name when
+// they differ. The mapping syntax is "name of attribute:name of cookie".
+// Cookie types must be primitive.
+//
+// Example:
+//
+//    var _ = Service("account", func() {
+//        Method("login", func() {
+//            Payload(LoginPayload)
+//            Result(Session)
+//            HTTP(func() {
+//                POST("/login")
+//                Cookie("lang")         // Request cookie inherits
+//                                       // description, type etc. from
+//                                       // LoginPayload "lang" attribute
+//                Response(StatusOK, func() {
+//                    Cookie("session_id:SID") // Set-Cookie header "SID"
+//                                             // set from Session
+//                                             // "session_id" attribute
+//                    CookieMaxAge(3600)
+//                    CookieSecure()
+//                    CookieHTTPOnly()
+//                })
+//            })
+//        })
+//    })
+//
+func Cookie(name string, args ...interface{}) {
+	c := cookies(eval.Current())
+	if c == nil {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("cookie name cannot be empty")
+	}
+	eval.Execute(func() { dsl.Attribute(name, args...) }, c.AttributeExpr)
+	c.Remap()
+}
+
+// CookieMaxAge sets the cookie "Max-Age" attribute of the cookies set by a
+// response.
+//
+// CookieMaxAge must appear in a Response expression.
+//
+// CookieMaxAge accepts one argument: the value of the Max-Age attribute in
+// seconds.
+//
+// Example:
+//
+//    Response(StatusOK, func() {
+//        Cookie("session_id")
+//        CookieMaxAge(3600) // Cookie expires in one hour
+//    })
+//
+func CookieMaxAge(val int) {
+	cookieMetadata("cookie:max-age", strconv.Itoa(val))
+}
+
+// CookieSecure sets the cookie "Secure" attribute of the cookies set by a
+// response so that user agents only send them over secure connections.
+//
+// CookieSecure must appear in a Response expression.
+//
+// Example:
+//
+//    Response(StatusOK, func() {
+//        Cookie("session_id")
+//        CookieSecure()
+//    })
+//
+func CookieSecure() {
+	cookieMetadata("cookie:secure", "Secure")
+}
+
+// CookieHTTPOnly sets the cookie "HttpOnly" attribute of the cookies set by a
+// response so that they cannot be accessed from client side scripts.
+//
+// CookieHTTPOnly must appear in a Response expression.
+//
+// Example:
+//
+//    Response(StatusOK, func() {
+//        Cookie("session_id")
+//        CookieHTTPOnly()
+//    })
+//
+func CookieHTTPOnly() {
+	cookieMetadata("cookie:http-only", "HttpOnly")
+}
+
+// Trailer describes a single HTTP response trailer, a header written by the
+// server after the response body. Trailers are typically used to send values
+// that can only be computed once the whole body has been written such as a
+// checksum or a record count. The properties (description, type, validation
+// etc.) of a trailer are inherited from the result type attribute with the same
+// name by default.
+//
+// Trailer must appear in a Response expression of a method HTTP expression
+// that uses SkipResponseBodyEncodeDecode. The trailer attributes must be
+// primitives that are neither required nor have a default value.
+//
+// Trailer accepts the same arguments as the Attribute function. The trailer
+// name may define a mapping between the attribute name and the trailer name
+// when they differ. The mapping syntax is "name of attribute:name of trailer".
+//
+// The generated server announces the trailers in the response "Trailer" header
+// and writes them after it has copied the body, the service may thus set the
+// corresponding result fields while the body is being read. The generated
+// client sets the result fields once the response body has been read to EOF.
+//
+// Example:
+//
+//    Method("download", func() {
+//        Payload(String)
+//        Result(func() {
+//            Attribute("checksum", String)
+//            Attribute("count", Int)
+//        })
+//        HTTP(func() {
+//            GET("/records/{*path}")
+//            SkipResponseBodyEncodeDecode()
+//            Response(func() {
+//                Trailer("checksum:X-Checksum")
+//                Trailer("count:X-Record-Count")
+//            })
+//        })
+//    })
+//
+func Trailer(name string, args ...interface{}) {
+	r, ok := eval.Current().(*httpdesign.HTTPResponseExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	if name == "" {
+		eval.ReportError("trailer name cannot be empty")
+	}
+	if r.Trailers == nil {
+		r.Trailers = design.NewEmptyMappedAttributeExpr()
+	}
+	eval.Execute(func() { dsl.Attribute(name, args...) }, r.Trailers.AttributeExpr)
+	r.Trailers.Remap()
+}
+
 // Params groups a set of Param expressions. It makes it possible to list
 // required parameters using the Required function.
 //
@@ -430,7 +637,8 @@ func Params(args interface{}) {
 // Param must appear in the API HTTP expression (to define request parameters
 // common to all the API endpoints), a service HTTP expression to define common
 // parameters to all the service methods or a specific method HTTP
-// expression. Param may also appear in a Params expression.
+// expression. Param may also appear in a Params expression. Finally Param may
+// appear in a Server expression to define a server URL variable, see Server.
 //
 // Param accepts the same arguments as the Function Attribute.
 //
@@ -473,6 +681,10 @@ func Params(args interface{}) {
 //    })
 //
 func Param(name string, args ...interface{}) {
+	if _, ok := eval.Current().(*design.ServerExpr); ok {
+		dsl.Param(name, args...)
+		return
+	}
 	p := params(eval.Current())
 	if p == nil {
 		eval.IncompatibleDSL()
@@ -553,8 +765,13 @@ func MapParams(args ...interface{}) {
 // into the payload struct. The generated decoder also accepts a user provided
 // function that takes a multipart reader and a reference to the payload struct
 // as parameter. The user provided decoder is responsible for decoding the
-// multipart content into the payload. The example command generates a default
-// implementation for the user decoder and encoder.
+// multipart content into the payload. If the payload is an object and no user
+// decoder is provided (nil) then the generated decoder maps the form fields and
+// files to the payload attributes with the same name: primitive attributes
+// are parsed from the part content, bytes attributes receive the raw content,
+// arrays of primitives get one element per part and other attributes are
+// decoded from JSON. The example command generates a default implementation for
+// the user encoder and for the user decoder when no default decoder exists.
 //
 func MultipartRequest() {
 	e, ok := eval.Current().(*httpdesign.EndpointExpr)
@@ -565,6 +782,443 @@ func MultipartRequest() {
 	e.MultipartRequest = true
 }
 
+// StreamingMultipartRequest indicates that HTTP requests made to the method
+// use MIME multipart encoding and that the generated server decoder streams the
+// parts to the user provided function instead of reading them in memory.
+//
+// StreamingMultipartRequest must appear in a HTTP endpoint expression.
+//
+// StreamingMultipartRequest accepts two arguments: the maximum size in bytes of
+// a single part and the maximum size in bytes of the whole request body. A
+// value of zero means there is no limit. The generated decoder hands a
+// goahttp.MultipartReader to the user provided function, reading a part past
+// the maximum part size or a body past the maximum size returns an error. The
+// generated client encoder is the same as the one generated for
+// MultipartRequest.
+//
+// Example:
+//
+//    Method("upload", func() {
+//        Payload(Upload)
+//        HTTP(func() {
+//            POST("/")
+//            StreamingMultipartRequest(10*1024*1024, 100*1024*1024)
+//        })
+//    })
+//
+func StreamingMultipartRequest(maxPartSize, maxSize int64) {
+	e, ok := eval.Current().(*httpdesign.EndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.MultipartRequest = true
+	e.StreamingMultipart = true
+	e.MultipartMaxPartSize = maxPartSize
+	e.MultipartMaxSize = maxSize
+}
+
+// MaxBody sets the maximum size in bytes of the request bodies. The generated
+// server code limits the number of bytes read from the request bodies and
+// responds with a 413 Request Entity Too Large status code when the limit is
+// exceeded. A limit defined on a method overrides the limit defined on its
+// service which overrides the limit defined on the API.
+//
+// MaxBody must appear in the API, a Service or a Method HTTP expression.
+//
+// MaxBody accepts one argument: the maximum size in bytes, it must be
+// positive.
+//
+// Example:
+//
+//    var _ = API("cellar", func() {
+//        HTTP(func() {
+//            MaxBody(1024 * 1024) // Limit request bodies to 1MB
+//        })
+//    })
+//
+func MaxBody(size int64) {
+	if size <= 0 {
+		eval.ReportError("invalid maximum body size %d, size must be positive", size)
+		return
+	}
+	switch actual := eval.Current().(type) {
+	case *httpdesign.RootExpr:
+		actual.MaxBodySize = size
+	case *httpdesign.ServiceExpr:
+		actual.MaxBodySize = size
+	case *httpdesign.EndpointExpr:
+		actual.MaxBodySize = size
+	default:
+		eval.IncompatibleDSL()
+	}
+}
+
+// BinaryStream indicates that the websocket connection used by the streaming
+// method sends and receives binary frames instead of JSON text frames.
+//
+// BinaryStream must appear in a HTTP endpoint expression of a method that
+// defines a streaming result.
+//
+// goa generates stream implementations that marshal and unmarshal the stream
+// messages using a user provided codec (e.g. protobuf, msgpack or raw bytes).
+// The codec is given to the generated server and client constructors and must
+// implement the goahttp.StreamCodec interface.
+//
+// Example:
+//
+//    Method("list", func() {
+//        StreamingResult(Car)
+//        HTTP(func() {
+//            GET("/cars")
+//            BinaryStream()
+//        })
+//    })
+//
+func BinaryStream() {
+	e, ok := eval.Current().(*httpdesign.EndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.BinaryStream = true
+}
+
+// NDJSONStream indicates that the streaming result of the method is sent as
+// newline delimited JSON (http://ndjson.org) over a chunked HTTP response
+// instead of using a websocket connection. Each result is encoded on its own
+// line and flushed to the client as soon as it is sent.
+//
+// NDJSONStream must appear in a HTTP endpoint expression of a method that
+// defines a streaming result and no streaming payload.
+//
+// goa generates a server stream which writes the response status and headers
+// on the first call to Send and a client stream which decodes the results
+// incrementally as they are received. The generated server and client do not
+// need a websocket upgrader or dialer for the method.
+//
+// Example:
+//
+//    Method("list", func() {
+//        StreamingResult(Car)
+//        HTTP(func() {
+//            GET("/cars")
+//            NDJSONStream()
+//        })
+//    })
+//
+func NDJSONStream() {
+	e, ok := eval.Current().(*httpdesign.EndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.NDJSONStream = true
+}
+
+// StreamKeepAlive configures the generated server and client streams of a
+// websocket streaming method to send ping control messages at regular
+// intervals so that intermediaries such as proxies or load balancers do not
+// close idle connections. Each side also fails pending reads when the peer
+// has not sent a ping or pong control message for longer than the pong wait
+// and bounds the duration of writes.
+//
+// StreamKeepAlive must appear in a HTTP endpoint expression of a method that
+// uses a websocket stream (i.e. not NDJSONStream).
+//
+// StreamKeepAlive accepts three arguments: the interval between two pings, the
+// pong wait which must be greater than the ping interval and the maximum
+// duration of writes.
+//
+// Example:
+//
+//    Method("watch", func() {
+//        StreamingResult(Event)
+//        HTTP(func() {
+//            GET("/events")
+//            StreamKeepAlive(30*time.Second, time.Minute, 10*time.Second)
+//        })
+//    })
+//
+func StreamKeepAlive(pingInterval, pongWait, writeWait time.Duration) {
+	e, ok := eval.Current().(*httpdesign.EndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.StreamKeepAlive = &httpdesign.StreamKeepAliveExpr{
+		PingInterval: pingInterval,
+		PongWait:     pongWait,
+		WriteWait:    writeWait,
+	}
+}
+
+// StreamCompression enables the websocket permessage-deflate compression
+// extension (RFC 7692) on the generated server and client streams of a
+// websocket streaming method. The generated server and client negotiate the
+// extension when the upgrader and dialer given to them are the gorilla
+// websocket upgrader and dialer. Other upgrader and dialer implementations must
+// enable compression themselves. Messages are sent uncompressed if the peer
+// does not support the extension.
+//
+// StreamCompression must appear in a HTTP endpoint expression of a method that
+// uses a websocket stream (i.e. not NDJSONStream).
+//
+// Example:
+//
+//    Method("watch", func() {
+//        StreamingResult(Event)
+//        HTTP(func() {
+//            GET("/events")
+//            StreamCompression()
+//        })
+//    })
+//
+func StreamCompression() {
+	e, ok := eval.Current().(*httpdesign.EndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.StreamCompression = true
+}
+
+// StreamResume declares the resume token of a websocket streaming method. The
+// generated HTTP client exposes an additional endpoint whose stream re-dials
+// the server when the websocket connection fails, for example because of a
+// network failure, and sends the token of the last received result in a
+// request header so that the server can resume the stream where it left off.
+//
+// StreamResume must appear in a HTTP endpoint expression of a method that
+// streams its result over a websocket connection (i.e. not NDJSONStream).
+//
+// StreamResume accepts the name of the result attribute that holds the token,
+// typically the event ID, as first argument. The attribute must be a string.
+// The optional second argument is the name of the request header that carries
+// the token, it defaults to "Last-Event-ID". The method payload may map the
+// header to an attribute so that the service method can read the token, the
+// callers should not set that attribute themselves.
+//
+// Example:
+//
+//    Method("watch", func() {
+//        Payload(func() {
+//            Attribute("last_event_id", String)
+//        })
+//        StreamingResult(Event)
+//        HTTP(func() {
+//            GET("/events")
+//            Header("last_event_id:Last-Event-ID")
+//            StreamResume("id")
+//        })
+//    })
+//
+func StreamResume(attribute string, header ...string) {
+	e, ok := eval.Current().(*httpdesign.EndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	h := "Last-Event-ID"
+	if len(header) > 0 {
+		h = header[0]
+	}
+	e.StreamResume = &httpdesign.StreamResumeExpr{Attribute: attribute, Header: h}
+}
+
+// Webhook declares a webhook, a POST request that the API sends to a URL
+// registered by the client when it calls the endpoint. The generated code
+// includes a sender with one method per webhook that encodes the webhook
+// payload as JSON, signs it with HMAC-SHA256 and retries failed deliveries,
+// see the goa http package WebhookSender type. The OpenAPI specification
+// documents the webhooks in the "x-callbacks" extension of the endpoint
+// operation.
+//
+// Webhook must appear in a HTTP endpoint expression.
+//
+// Webhook accepts the name of the webhook as first argument, the name is sent
+// in the X-Webhook-Event request header and must be unique in the service. The
+// second argument is the user type that describes the webhook request body,
+// it must be an object. The last argument is a function that must use
+// CallbackURL to define the payload attribute holding the webhook URL and may
+// use Description.
+//
+// Example:
+//
+//    Method("subscribe", func() {
+//        Payload(func() {
+//            Attribute("callback_url", String)
+//        })
+//        HTTP(func() {
+//            POST("/subscriptions")
+//            Webhook("order_shipped", Shipment, func() {
+//                Description("Sent when an order ships")
+//                CallbackURL("callback_url")
+//            })
+//        })
+//    })
+//
+func Webhook(name string, payload design.UserType, fn func()) {
+	e, ok := eval.Current().(*httpdesign.EndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	w := &httpdesign.WebhookExpr{Name: name, Endpoint: e}
+	if payload != nil {
+		w.Payload = &design.AttributeExpr{Type: payload}
+	}
+	if !eval.Execute(fn, w) {
+		return
+	}
+	e.Webhooks = append(e.Webhooks, w)
+}
+
+// CallbackURL defines the endpoint payload attribute that holds the URL the
+// webhook is sent to.
+//
+// CallbackURL must appear in a Webhook expression.
+//
+// CallbackURL accepts the name of the attribute as argument, the attribute must
+// be a string attribute of the endpoint method payload.
+//
+// Example:
+//
+//    Webhook("order_shipped", Shipment, func() {
+//        CallbackURL("callback_url")
+//    })
+//
+func CallbackURL(attribute string) {
+	w, ok := eval.Current().(*httpdesign.WebhookExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	w.URLAttribute = attribute
+}
+
+// FieldSelection lets clients select the result fields returned in the
+// response body with a query string parameter listing comma separated field
+// names, e.g. "?fields=id,author.name". Nested fields are separated with dots.
+// The generated server validates the requested fields against the method
+// result type, responds with a 400 Bad Request status code if a field is not
+// defined and prunes the other fields from the response body. The whole result
+// is returned when the parameter is absent.
+//
+// FieldSelection must appear in a Method HTTP expression. The method result
+// must be an object or an array of objects.
+//
+// FieldSelection accepts an optional argument: the name of the query string
+// parameter, "fields" by default.
+//
+// Example:
+//
+//    Method("show", func() {
+//        Result(Bottle)
+//        HTTP(func() {
+//            GET("/{id}")
+//            FieldSelection()
+//        })
+//    })
+//
+func FieldSelection(param ...string) {
+	if len(param) > 1 {
+		eval.ReportError("too many arguments")
+		return
+	}
+	e, ok := eval.Current().(*httpdesign.EndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.FieldsParam = "fields"
+	if len(param) == 1 {
+		e.FieldsParam = param[0]
+	}
+}
+
+// SkipRequestBodyEncodeDecode indicates that the service method accepts a
+// reader that reads directly from the HTTP request body instead of having the
+// request body decoded into the method payload. This makes it possible to
+// stream large request bodies such as file uploads without loading them in
+// memory.
+//
+// SkipRequestBodyEncodeDecode must appear in a HTTP endpoint expression.
+//
+// The method payload may still be used to describe request path and query
+// string parameters, headers and cookies however all the payload attributes
+// must be mapped to one of these, the generated code does not define a request
+// body type for the endpoint. The generated service method accepts an extra
+// io.ReadCloser argument that the method must close when done.
+//
+// Example:
+//
+//    Method("upload", func() {
+//        Payload(func() {
+//            Attribute("name", String)
+//        })
+//        HTTP(func() {
+//            PUT("/files/{name}")
+//            SkipRequestBodyEncodeDecode()
+//        })
+//    })
+//
+func SkipRequestBodyEncodeDecode() {
+	e, ok := eval.Current().(*httpdesign.EndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.SkipRequestBodyEncodeDecode = true
+	if e.MethodExpr.Metadata == nil {
+		e.MethodExpr.Metadata = make(design.MetadataExpr)
+	}
+	e.MethodExpr.Metadata["goa:skip-request-body-encode-decode"] = nil
+}
+
+// SkipResponseBodyEncodeDecode indicates that the service method returns a
+// reader whose content is copied directly to the HTTP response body instead of
+// having the method result encoded into the response body. This makes it
+// possible to stream large response bodies such as file downloads without
+// loading them in memory.
+//
+// SkipResponseBodyEncodeDecode must appear in a HTTP endpoint expression.
+//
+// The method result may still be used to describe response headers and
+// cookies however all the result attributes must be mapped to one of these,
+// the generated code does not define a response body type for the endpoint.
+// The generated service method returns an extra io.ReadCloser value that the
+// generated server closes after having written the response.
+//
+// Example:
+//
+//    Method("download", func() {
+//        Payload(String)
+//        Result(func() {
+//            Attribute("length", Int64)
+//        })
+//        HTTP(func() {
+//            GET("/files/{*path}")
+//            SkipResponseBodyEncodeDecode()
+//            Response(func() {
+//                Header("length:Content-Length")
+//            })
+//        })
+//    })
+//
+func SkipResponseBodyEncodeDecode() {
+	e, ok := eval.Current().(*httpdesign.EndpointExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	e.SkipResponseBodyEncodeDecode = true
+	if e.MethodExpr.Metadata == nil {
+		e.MethodExpr.Metadata = make(design.MetadataExpr)
+	}
+	e.MethodExpr.Metadata["goa:skip-response-body-encode-decode"] = nil
+}
+
 // Body describes a HTTP request or response body.
 //
 // Body must appear in a Method HTTP expression to define the request body or in
@@ -753,6 +1407,40 @@ func headers(exp eval.Expression) *design.MappedAttributeExpr {
 	}
 }
 
+// cookies returns the mapped attribute containing the cookies for the given
+// expression if it's either an endpoint or a response - nil otherwise.
+func cookies(exp eval.Expression) *design.MappedAttributeExpr {
+	switch e := exp.(type) {
+	case *httpdesign.EndpointExpr:
+		if e.Cookies == nil {
+			e.Cookies = design.NewEmptyMappedAttributeExpr()
+		}
+		return e.Cookies
+	case *httpdesign.HTTPResponseExpr:
+		if e.Cookies == nil {
+			e.Cookies = design.NewEmptyMappedAttributeExpr()
+		}
+		return e.Cookies
+	default:
+		return nil
+	}
+}
+
+// cookieMetadata sets the given metadata on the cookies of the current
+// response expression.
+func cookieMetadata(name, value string) {
+	r, ok := eval.Current().(*httpdesign.HTTPResponseExpr)
+	if !ok {
+		eval.IncompatibleDSL()
+		return
+	}
+	c := cookies(r)
+	if c.Metadata == nil {
+		c.Metadata = make(design.MetadataExpr)
+	}
+	c.Metadata[name] = []string{value}
+}
+
 // params returns the mapped attribute containing the path and query params for
 // the given expression if it's either the root, a service or an endpoint - nil
 // otherwise.
@@ -20,14 +20,25 @@ import (
 //
 //        Metadata("struct:field:name", "MyName")
 //
-// `struct:tag:xxx`: sets the struct field tag xxx on generated Go structs.
-// Overrides tags that goa would otherwise set. If the metadata value is a
-// slice then the strings are joined with the space character as separator.
+// `struct:tag:xxx`: sets the struct field tag xxx on the generated payload,
+// result and HTTP body types, for example to use the types with an ORM or an
+// alternative encoder. The tag is added to the form, json and xml tags goa
+// sets on HTTP body types and overrides the tag with the same name. If the
+// metadata value is a slice then the strings are joined with commas.
 // Applicable to fields only.
 //
-//        Metadata("struct:tag:json", "myName,omitempty")
+//        Metadata("struct:tag:db", "my_name")
+//        Metadata("struct:tag:json", "myName", "omitempty")
 //        Metadata("struct:tag:xml", "myName,attr")
 //
+// `struct:enum`: generates a named Go type with typed constants for a field
+// that defines enum values. The service payload and result struct fields use
+// the named type. The name of the type defaults to the name of the field and
+// may be overridden with the metadata value. Applicable to string and number
+// fields only.
+//
+//        Metadata("struct:enum", "Color")
+//
 // `swagger:tag:xxx`: sets the Swagger object field tag xxx.
 // Applicable to services and endpoints.
 //
@@ -41,10 +52,31 @@ import (
 //
 //        Metadata("swagger:summary", "Short summary of what endpoint does")
 //
-// `swagger:extension:xxx`: defines a swagger extension value.
-// Applicable to all constructs that support Metadata.
+// `swagger:extension:xxx` or `openapi:extension:xxx`: defines a swagger
+// extension value. Applicable to all constructs that support Metadata.
+// Extensions defined on a service or method are added to the corresponding
+// operations, extensions defined on attributes are added to their schemas.
 //
 //        Metadata("swagger:extension:x-apis-json", `{"URL": "http://goa.design"}`)
+//        Metadata("openapi:extension:x-amazon-apigateway-integration", `{"type": "http_proxy"}`)
+//
+// `openapi:split`: writes the definitions of the generated OpenAPI
+// specification to separate files referenced by the specification.
+// Applicable to API only.
+//
+//        Metadata("openapi:split", "true")
+//
+// `version:strategy`: sets the strategy used to map the service version to the
+// HTTP requests, one of "path" (default), "header" or "mediatype". Applicable
+// to API and services, see Version.
+//
+//        Metadata("version:strategy", "header")
+//
+// `version:header`: overrides the name of the request header used by the
+// "header" versioning strategy, "API-Version" by default. Applicable to API
+// and services.
+//
+//        Metadata("version:header", "X-Api-Version")
 //
 // The special key names listed above may be used as follows:
 //
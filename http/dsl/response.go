@@ -1,6 +1,10 @@
 package dsl
 
 import (
+	"mime"
+	"net/http"
+	"strings"
+
 	"goa.design/goa/design"
 	"goa.design/goa/eval"
 	httpdesign "goa.design/goa/http/design"
@@ -238,10 +242,21 @@ func Code(code int) {
 	res.StatusCode = code
 }
 
-// ContentType sets the value of the Content-Type response header. By default
-// the ID of the result type is used.
-//
-// ContentType may appear in a ResultType or a Response expression.
+// ContentType sets the value of the Content-Type header. When used in a
+// ResultType or a Response expression ContentType sets the value of the
+// response header, by default the ID of the result type is used. When used in
+// the API, a Service or a Method HTTP expression ContentType sets the content
+// type of the request bodies: the generated clients set the request
+// Content-Type header accordingly so that the request bodies get encoded with
+// the corresponding encoder and decoded by the server with the corresponding
+// decoder. A content type defined on a method overrides the content type
+// defined on its service which overrides the content type defined on the API.
+// The CBOR encoders and decoders are provided by the
+// goa.design/goa/http/encoding/cborenc package which must be given to the
+// generated server and client constructors.
+//
+// ContentType may appear in a ResultType, a Response or in the API, a Service
+// or a Method HTTP expression.
 // ContentType accepts one argument: the mime type as defined by RFC 6838.
 //
 //    var _ = ResultType("application/vnd.myapp.mytype") {
@@ -256,17 +271,87 @@ func Code(code int) {
 //        })
 //    })
 //
+//    var _ = API("cellar", func() {
+//        HTTP(func() {
+//            ContentType("application/cbor")
+//        })
+//    })
+//
 func ContentType(typ string) {
 	switch actual := eval.Current().(type) {
 	case *design.ResultTypeExpr:
 		actual.ContentType = typ
 	case *httpdesign.HTTPResponseExpr:
 		actual.ContentType = typ
+	case *httpdesign.RootExpr:
+		if validContentType(typ) {
+			actual.ContentType = typ
+		}
+	case *httpdesign.ServiceExpr:
+		if validContentType(typ) {
+			actual.ContentType = typ
+		}
+	case *httpdesign.EndpointExpr:
+		if validContentType(typ) {
+			actual.ContentType = typ
+		}
 	default:
 		eval.IncompatibleDSL()
 	}
 }
 
+// ConstantHeader sets a response header to a constant value. The header is not
+// mapped to a result attribute: the generated response encoders always write
+// it and the OpenAPI specification documents it with its value. When used in a
+// method HTTP expression ConstantHeader applies to all the method responses
+// including the error responses, a ConstantHeader defined in a Response
+// expression takes precedence.
+//
+// ConstantHeader may appear in a Response or in a method HTTP expression.
+//
+// ConstantHeader accepts two arguments: the name of the header and its value.
+//
+// Example:
+//
+//    var _ = Service("account", func() {
+//        Method("show", func() {
+//            HTTP(func() {
+//                GET("/{id}")
+//                ConstantHeader("X-API-Version", "2")
+//                Response(StatusOK, func() {
+//                    ConstantHeader("Cache-Control", "max-age=60")
+//                })
+//            })
+//        })
+//    })
+//
+func ConstantHeader(name, value string) {
+	var headers *map[string]string
+	switch actual := eval.Current().(type) {
+	case *httpdesign.HTTPResponseExpr:
+		headers = &actual.ConstantHeaders
+	case *httpdesign.EndpointExpr:
+		headers = &actual.ConstantHeaders
+	default:
+		eval.IncompatibleDSL()
+		return
+	}
+	if *headers == nil {
+		*headers = make(map[string]string)
+	}
+	(*headers)[http.CanonicalHeaderKey(name)] = value
+}
+
+// validContentType reports an error and returns false if typ is not a valid
+// MIME type.
+func validContentType(typ string) bool {
+	if mt, _, err := mime.ParseMediaType(typ); err != nil || !strings.Contains(mt, "/") {
+		eval.ReportError("invalid content type %q, content type must be of the form type/subtype", typ)
+		return false
+	}
+	return true
+}
+
 func parseResponseArgs(val interface{}, args ...interface{}) (code int, fn func()) {
 	switch t := val.(type) {
 	case int:
@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"mime"
 	"net/http"
+	"strconv"
 	"strings"
 )
 
@@ -17,6 +18,16 @@ const (
 	// request Accept-Type header. The value may be used by encoders and
 	// decoders to implement a content type negotiation algorithm.
 	AcceptTypeKey contextKey = iota + 1
+
+	// RequestIDKey is the context key used to store the ID of the request
+	// being served as set by RequestIDHandler. The generated clients send
+	// the ID stored in the context in the X-Request-Id header.
+	RequestIDKey
+
+	// FieldsKey is the context key used to store the result fields
+	// selected by the client of an endpoint that uses FieldSelection, see
+	// WithFields.
+	FieldsKey
 )
 
 type (
@@ -48,28 +59,25 @@ type (
 //     * application/json using package encoding/json
 //     * application/xml using package encoding/xml
 //     * application/gob using package encoding/gob
+//     * application/x-www-form-urlencoded and multipart/form-data using FormDecoder
 //
 // RequestDecoder defaults to the JSON decoder if the request "Content-Type"
 // header does not match any of the supported mime type or is missing
 // altogether.
+//
+// Packages goa.design/goa/http/encoding/msgpackenc and
+// goa.design/goa/http/encoding/cborenc provide request decoders that handle
+// MessagePack and CBOR respectively in addition to the mime types above.
 func RequestDecoder(r *http.Request) Decoder {
-	contentType := r.Header.Get("Content-Type")
-	if contentType == "" {
-		// default to JSON
-		contentType = "application/json"
-	} else {
-		// sanitize
-		if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
-			contentType = mediaType
-		}
-	}
-	switch contentType {
+	switch RequestContentType(r, "application/json") {
 	case "application/json":
 		return json.NewDecoder(r.Body)
 	case "application/gob":
 		return gob.NewDecoder(r.Body)
 	case "application/xml":
 		return xml.NewDecoder(r.Body)
+	case FormContentType, MultipartFormContentType:
+		return FormDecoder(r)
 	default:
 		return json.NewDecoder(r.Body)
 	}
@@ -85,46 +93,103 @@ func RequestDecoder(r *http.Request) Decoder {
 //
 // ResponseEncoder defaults to the JSON encoder if the request "Accept" header
 // does not match any of the supported mime types or is missing altogether.
+//
+// Packages goa.design/goa/http/encoding/msgpackenc and
+// goa.design/goa/http/encoding/cborenc provide response encoders that handle
+// MessagePack and CBOR respectively in addition to the mime types above.
 func ResponseEncoder(ctx context.Context, w http.ResponseWriter) Encoder {
-	negotiate := func(a string) (Encoder, string) {
-		switch a {
-		case "", "application/json":
-			// default to JSON
-			return json.NewEncoder(w), "application/json"
-		case "application/xml":
-			return xml.NewEncoder(w), "application/xml"
-		case "application/gob":
-			return gob.NewEncoder(w), "application/gob"
-		}
-		return nil, ""
+	var enc Encoder
+	mt := NegotiateContentType(ctx, "application/json", "application/xml", "application/gob")
+	switch mt {
+	case "application/xml":
+		enc = xml.NewEncoder(w)
+	case "application/gob":
+		enc = gob.NewEncoder(w)
+	default:
+		enc = json.NewEncoder(w)
+	}
+	SetContentType(w, mt)
+	return enc
+}
+
+// NegotiateContentType returns the MIME type in supported that best matches
+// the request "Accept" header value stored in the context under AcceptTypeKey.
+// The header may list multiple media ranges with quality values as described
+// in RFC 7231 section 5.3.2. NegotiateContentType returns the first element of
+// supported if the header is missing or if none of the supported MIME types
+// are acceptable.
+func NegotiateContentType(ctx context.Context, supported ...string) string {
+	if len(supported) == 0 {
+		return ""
 	}
 	var accept string
 	if a := ctx.Value(AcceptTypeKey); a != nil {
 		accept = a.(string)
 	}
+	type mediaRange struct {
+		mt string
+		q  float64
+	}
+	var ranges []mediaRange
+	for _, r := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(r))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		ranges = append(ranges, mediaRange{mt, q})
+	}
 	var (
-		enc Encoder
-		mt  string
+		best  string
+		bestQ float64
 	)
-	if enc, mt = negotiate(accept); enc == nil {
-		// attempt to normalize
-		if mt, _, err := mime.ParseMediaType(accept); err == nil {
-			enc, mt = negotiate(mt)
+	for _, s := range supported {
+		// The quality of a MIME type is given by the most specific media
+		// range that matches it.
+		q, prc := 0.0, -1
+		for _, r := range ranges {
+			if p := mediaRangePrecedence(r.mt, s); p > prc {
+				q, prc = r.q, p
+			}
+		}
+		if q > bestQ {
+			best, bestQ = s, q
 		}
 	}
-	if enc == nil {
-		enc, mt = negotiate("")
+	if best == "" {
+		return supported[0]
 	}
-	SetContentType(w, mt)
-	return enc
+	return best
 }
 
-// RequestEncoder returns a HTTP request encoder.
-// The encoder uses package encoding/json.
+// RequestEncoder returns a HTTP request encoder suitable for the request
+// "Content-Type" header. The encoder handles the following content types:
+//
+//   * application/json using package encoding/json (default)
+//   * application/xml using package encoding/xml
+//   * application/gob using package encoding/gob
+//   * application/x-www-form-urlencoded and multipart/form-data using FormEncoder
+//
 func RequestEncoder(r *http.Request) Encoder {
+	ct := RequestContentType(r, "")
+	if ct == FormContentType || ct == MultipartFormContentType {
+		return FormEncoder(r)
+	}
 	var buf bytes.Buffer
 	r.Body = ioutil.NopCloser(&buf)
-	return json.NewEncoder(&buf)
+	switch {
+	case ct == "application/xml" || strings.HasSuffix(ct, "+xml"):
+		return xml.NewEncoder(&buf)
+	case ct == "application/gob" || strings.HasSuffix(ct, "+gob"):
+		return gob.NewEncoder(&buf)
+	default:
+		return json.NewEncoder(&buf)
+	}
 }
 
 // ResponseDecoder returns a HTTP response decoder.
@@ -175,6 +240,24 @@ func (f EncodingFunc) Decode(v interface{}) error { return f(v) }
 // Encode implements the Encoder interface. It simply calls f(v).
 func (f EncodingFunc) Encode(v interface{}) error { return f(v) }
 
+// mediaRangePrecedence returns a non-negative number if the given media range
+// matches the MIME type, -1 otherwise. More specific media ranges have higher
+// precedence.
+func mediaRangePrecedence(rng, mt string) int {
+	if parsed, _, err := mime.ParseMediaType(mt); err == nil {
+		mt = parsed
+	}
+	switch {
+	case rng == mt:
+		return 2
+	case rng == "*/*":
+		return 0
+	case strings.HasSuffix(rng, "/*") && strings.HasPrefix(mt, rng[:len(rng)-1]):
+		return 1
+	}
+	return -1
+}
+
 // SetContentType initializes the response Content-Type header given a MIME
 // type. If the Content-Type header is already set and the MIME type is
 // "application/json" or "application/xml" then SetContentType appends a suffix
@@ -0,0 +1,106 @@
+/*
+Package cborenc provides the goa HTTP encoders and decoders for CBOR (RFC 7049)
+encoded bodies. The package is opt-in so that services that do not use CBOR do
+not depend on the CBOR library. The encoders and decoders use the "json"
+struct field tags of the generated types to name the encoded fields.
+
+The request decoder and response encoder handle CBOR bodies and fall back to
+the goahttp default decoder and encoder for the other content types. Give them
+to the generated server constructors in place of the defaults:
+
+	srv := server.New(endpoints, mux, cborenc.RequestDecoder, cborenc.ResponseEncoder, nil)
+
+Similarly the request encoder and response decoder may be given to the
+generated client constructors:
+
+	c := client.NewClient("http", "localhost:8080", http.DefaultClient,
+		cborenc.RequestEncoder, cborenc.ResponseDecoder, false)
+
+The responses encoded with CBOR are not compressed by the goahttp.Compress
+handler used by the generated servers when the design enables compression,
+only JSON bodies are.
+*/
+package cborenc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/ugorji/go/codec"
+	goahttp "goa.design/goa/http"
+)
+
+// ContentType is the CBOR MIME type.
+const ContentType = "application/cbor"
+
+// handle configures the CBOR encoders and decoders. It uses the "json" struct
+// field tags of the generated types to name the encoded fields.
+var handle = &codec.CborHandle{BasicHandle: codec.BasicHandle{TypeInfos: codec.NewTypeInfos([]string{"json"})}}
+
+// RequestDecoder returns a decoder for CBOR request bodies. It returns the
+// decoder created by goahttp.RequestDecoder if the request Content-Type header
+// does not designate CBOR.
+func RequestDecoder(r *http.Request) goahttp.Decoder {
+	if IsCbor(goahttp.RequestContentType(r, "")) {
+		return NewDecoder(r.Body)
+	}
+	return goahttp.RequestDecoder(r)
+}
+
+// ResponseEncoder returns a CBOR response encoder if CBOR is the MIME type that
+// best matches the request Accept header, it returns the encoder created by
+// goahttp.ResponseEncoder otherwise.
+func ResponseEncoder(ctx context.Context, w http.ResponseWriter) goahttp.Encoder {
+	mt := goahttp.NegotiateContentType(ctx, "application/json", "application/xml", "application/gob", ContentType)
+	if mt != ContentType {
+		return goahttp.ResponseEncoder(ctx, w)
+	}
+	goahttp.SetContentType(w, ContentType)
+	return NewEncoder(w)
+}
+
+// RequestEncoder returns a CBOR request encoder if the request Content-Type
+// header designates CBOR, it returns the encoder created by
+// goahttp.RequestEncoder otherwise.
+func RequestEncoder(r *http.Request) goahttp.Encoder {
+	if !IsCbor(goahttp.RequestContentType(r, "")) {
+		return goahttp.RequestEncoder(r)
+	}
+	var buf bytes.Buffer
+	r.Body = ioutil.NopCloser(&buf)
+	return NewEncoder(&buf)
+}
+
+// ResponseDecoder returns a decoder for CBOR response bodies. It returns the
+// decoder created by goahttp.ResponseDecoder if the response Content-Type
+// header does not designate CBOR.
+func ResponseDecoder(resp *http.Response) goahttp.Decoder {
+	ct := resp.Header.Get("Content-Type")
+	if mt, _, err := mime.ParseMediaType(ct); err == nil {
+		ct = mt
+	}
+	if IsCbor(ct) {
+		return NewDecoder(resp.Body)
+	}
+	return goahttp.ResponseDecoder(resp)
+}
+
+// NewEncoder returns an encoder that writes CBOR encoded values to w.
+func NewEncoder(w io.Writer) goahttp.Encoder {
+	return codec.NewEncoder(w, handle)
+}
+
+// NewDecoder returns a decoder that reads CBOR encoded values from r.
+func NewDecoder(r io.Reader) goahttp.Decoder {
+	return codec.NewDecoder(r, handle)
+}
+
+// IsCbor returns true if the given media type designates CBOR.
+func IsCbor(mt string) bool {
+	return mt == ContentType || strings.HasSuffix(mt, "+cbor")
+}
@@ -0,0 +1,103 @@
+/*
+Package msgpackenc provides the goa HTTP encoders and decoders for MessagePack
+(https://msgpack.org) encoded bodies. The package is opt-in so that services
+that do not use MessagePack do not depend on the MessagePack library. The
+encoders and decoders use the "json" struct field tags of the generated types
+to name the encoded fields.
+
+The request decoder and response encoder handle MessagePack bodies and fall
+back to the goahttp default decoder and encoder for the other content types.
+Give them to the generated server constructors in place of the defaults:
+
+	srv := server.New(endpoints, mux, msgpackenc.RequestDecoder, msgpackenc.ResponseEncoder, nil)
+
+Similarly the request encoder and response decoder may be given to the
+generated client constructors:
+
+	c := client.NewClient("http", "localhost:8080", http.DefaultClient,
+		msgpackenc.RequestEncoder, msgpackenc.ResponseDecoder, false)
+
+The responses encoded with MessagePack are not compressed by the goahttp.Compress
+handler used by the generated servers when the design enables compression,
+only JSON bodies are.
+*/
+package msgpackenc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/vmihailenco/msgpack"
+	goahttp "goa.design/goa/http"
+)
+
+// ContentType is the MessagePack MIME type.
+const ContentType = "application/msgpack"
+
+// RequestDecoder returns a decoder for MessagePack request bodies. It returns
+// the decoder created by goahttp.RequestDecoder if the request Content-Type
+// header does not designate MessagePack.
+func RequestDecoder(r *http.Request) goahttp.Decoder {
+	if IsMsgpack(goahttp.RequestContentType(r, "")) {
+		return NewDecoder(r.Body)
+	}
+	return goahttp.RequestDecoder(r)
+}
+
+// ResponseEncoder returns a MessagePack response encoder if MessagePack is the
+// MIME type that best matches the request Accept header, it returns the
+// encoder created by goahttp.ResponseEncoder otherwise.
+func ResponseEncoder(ctx context.Context, w http.ResponseWriter) goahttp.Encoder {
+	mt := goahttp.NegotiateContentType(ctx, "application/json", "application/xml", "application/gob", ContentType)
+	if mt != ContentType {
+		return goahttp.ResponseEncoder(ctx, w)
+	}
+	goahttp.SetContentType(w, ContentType)
+	return NewEncoder(w)
+}
+
+// RequestEncoder returns a MessagePack request encoder if the request
+// Content-Type header designates MessagePack, it returns the encoder created
+// by goahttp.RequestEncoder otherwise.
+func RequestEncoder(r *http.Request) goahttp.Encoder {
+	if !IsMsgpack(goahttp.RequestContentType(r, "")) {
+		return goahttp.RequestEncoder(r)
+	}
+	var buf bytes.Buffer
+	r.Body = ioutil.NopCloser(&buf)
+	return NewEncoder(&buf)
+}
+
+// ResponseDecoder returns a decoder for MessagePack response bodies. It returns
+// the decoder created by goahttp.ResponseDecoder if the response Content-Type
+// header does not designate MessagePack.
+func ResponseDecoder(resp *http.Response) goahttp.Decoder {
+	ct := resp.Header.Get("Content-Type")
+	if mt, _, err := mime.ParseMediaType(ct); err == nil {
+		ct = mt
+	}
+	if IsMsgpack(ct) {
+		return NewDecoder(resp.Body)
+	}
+	return goahttp.ResponseDecoder(resp)
+}
+
+// NewEncoder returns an encoder that writes MessagePack encoded values to w.
+func NewEncoder(w io.Writer) goahttp.Encoder {
+	return msgpack.NewEncoder(w).UseJSONTag(true)
+}
+
+// NewDecoder returns a decoder that reads MessagePack encoded values from r.
+func NewDecoder(r io.Reader) goahttp.Decoder {
+	return msgpack.NewDecoder(r).UseJSONTag(true)
+}
+
+// IsMsgpack returns true if the given media type designates MessagePack.
+func IsMsgpack(mt string) bool {
+	return mt == ContentType || mt == "application/x-msgpack" || strings.HasSuffix(mt, "+msgpack")
+}
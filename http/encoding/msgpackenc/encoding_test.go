@@ -0,0 +1,82 @@
+package msgpackenc
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	goahttp "goa.design/goa/http"
+)
+
+type body struct {
+	Name  string `json:"name"`
+	Count int    `json:"count,omitempty"`
+}
+
+func TestEncoding(t *testing.T) {
+	v := &body{Name: "goa", Count: 2}
+
+	// Client request to server
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Header.Set("Content-Type", ContentType)
+	if err := RequestEncoder(req).Encode(v); err != nil {
+		t.Fatalf("failed to encode request: %s", err)
+	}
+	var m map[string]interface{}
+	if err := NewDecoder(req.Body).Decode(&m); err != nil {
+		t.Fatalf("request body is not MessagePack: %s", err)
+	}
+	if _, ok := m["name"]; !ok {
+		t.Errorf("got fields %v, expected field %q", m, "name")
+	}
+	req = httptest.NewRequest("POST", "/", nil)
+	req.Header.Set("Content-Type", ContentType)
+	if err := RequestEncoder(req).Encode(v); err != nil {
+		t.Fatalf("failed to encode request: %s", err)
+	}
+	var decoded body
+	if err := RequestDecoder(req).Decode(&decoded); err != nil {
+		t.Fatalf("failed to decode request: %s", err)
+	}
+	if decoded != *v {
+		t.Errorf("got request body %#v, expected %#v", decoded, *v)
+	}
+
+	// Server response to client
+	ctx := context.WithValue(context.Background(), goahttp.AcceptTypeKey, ContentType)
+	w := httptest.NewRecorder()
+	if err := ResponseEncoder(ctx, w).Encode(v); err != nil {
+		t.Fatalf("failed to encode response: %s", err)
+	}
+	resp := w.Result()
+	if ct := resp.Header.Get("Content-Type"); ct != ContentType {
+		t.Errorf("got response Content-Type %q, expected %q", ct, ContentType)
+	}
+	decoded = body{}
+	if err := ResponseDecoder(resp).Decode(&decoded); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	if decoded != *v {
+		t.Errorf("got response body %#v, expected %#v", decoded, *v)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	v := &body{Name: "goa"}
+	ctx := context.WithValue(context.Background(), goahttp.AcceptTypeKey, "application/json")
+	w := httptest.NewRecorder()
+	if err := ResponseEncoder(ctx, w).Encode(v); err != nil {
+		t.Fatalf("failed to encode response: %s", err)
+	}
+	resp := w.Result()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("got response Content-Type %q, expected %q", ct, "application/json")
+	}
+	var decoded body
+	if err := ResponseDecoder(resp).Decode(&decoded); err != nil {
+		t.Fatalf("failed to decode response: %s", err)
+	}
+	if decoded != *v {
+		t.Errorf("got response body %#v, expected %#v", decoded, *v)
+	}
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	supported := []string{"application/json", "application/xml"}
+	cases := []struct{ Name, Accept, Expected string }{
+		{"empty", "", "application/json"},
+		{"exact", "application/xml", "application/xml"},
+		{"params", "application/xml; charset=utf-8", "application/xml"},
+		{"unsupported", "text/html", "application/json"},
+		{"invalid", "invalid//", "application/json"},
+		{"any", "*/*", "application/json"},
+		{"subtype wildcard", "text/html, application/*", "application/json"},
+		{"list", "text/html, application/xml", "application/xml"},
+		{"quality", "application/json;q=0.5, application/xml", "application/xml"},
+		{"zero quality", "application/json;q=0, */*", "application/xml"},
+		{"specificity", "application/json;q=0.5, */*", "application/xml"},
+		{"tie", "application/xml, application/json", "application/json"},
+	}
+	for _, c := range cases {
+		ctx := context.WithValue(context.Background(), AcceptTypeKey, c.Accept)
+		actual := NegotiateContentType(ctx, supported...)
+		if actual != c.Expected {
+			t.Errorf("%s: expected %#v, got %#v", c.Name, c.Expected, actual)
+		}
+	}
+}
@@ -2,8 +2,20 @@ package http
 
 import (
 	"net/http"
+	"strings"
 
 	"goa.design/goa"
+	"goa.design/goa/security"
+)
+
+const (
+	// requestBodyTooLarge is the name of the errors created by
+	// ErrRequestBodyTooLarge.
+	requestBodyTooLarge = "request_body_too_large"
+
+	// unsupportedMediaType is the name of the errors created by
+	// ErrUnsupportedMediaType.
+	unsupportedMediaType = "unsupported_media_type"
 )
 
 type (
@@ -23,13 +35,16 @@ type (
 		Timeout bool `json:"timeout" xml:"timeout" form:"timeout"`
 		// Fault indicates whether the error is a server-side fault.
 		Fault bool `json:"fault" xml:"fault" form:"fault"`
+		// Errors lists the individual errors when the error aggregates
+		// multiple errors, see goa.AggregateErrors.
+		Errors []*ErrorResponse `json:"errors,omitempty" xml:"errors,omitempty" form:"errors,omitempty"`
 	}
 )
 
 // NewErrorResponse creates a HTTP response from the given error.
 func NewErrorResponse(err error) *ErrorResponse {
 	if gerr, ok := err.(*goa.ServiceError); ok {
-		return &ErrorResponse{
+		resp := &ErrorResponse{
 			Name:      gerr.Name,
 			ID:        gerr.ID,
 			Message:   gerr.Message,
@@ -37,8 +52,26 @@ func NewErrorResponse(err error) *ErrorResponse {
 			Temporary: gerr.Temporary,
 			Fault:     gerr.Fault,
 		}
+		for _, e := range gerr.Errors {
+			resp.Errors = append(resp.Errors, NewErrorResponse(e))
+		}
+		return resp
 	}
-	return NewErrorResponse(goa.Fault(err.Error()))
+	return NewErrorResponse(goa.Fault("%s", err.Error()))
+}
+
+// ErrRequestBodyTooLarge is the error produced by the generated server code
+// when the size of a request body exceeds the maximum defined in the design
+// with MaxBody.
+func ErrRequestBodyTooLarge(max int64) error {
+	return goa.PermanentError(requestBodyTooLarge, "request body exceeds the maximum size of %d bytes", max)
+}
+
+// ErrUnsupportedMediaType is the error produced by the generated server code
+// when the request Content-Type header does not match any of the MIME types
+// listed in the design with Consumes.
+func ErrUnsupportedMediaType(ct string, supported ...string) error {
+	return goa.PermanentError(unsupportedMediaType, "unsupported content type %q, must be one of %s", ct, strings.Join(supported, ", "))
 }
 
 // StatusCode implements a heuristic that computes a HTTP response status code
@@ -46,6 +79,14 @@ func NewErrorResponse(err error) *ErrorResponse {
 // error. This method is used by the generated server code when the error is not
 // described explicitly in the design.
 func (resp *ErrorResponse) StatusCode() int {
+	switch resp.Name {
+	case requestBodyTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case unsupportedMediaType:
+		return http.StatusUnsupportedMediaType
+	case security.InsufficientScopeErrorName:
+		return http.StatusForbidden
+	}
 	if resp.Fault {
 		return http.StatusInternalServerError
 	}
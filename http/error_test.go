@@ -0,0 +1,65 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"goa.design/goa"
+	"goa.design/goa/security"
+)
+
+func TestNewErrorResponse(t *testing.T) {
+	var (
+		missing = goa.MissingFieldError("a", "body")
+		pattern = goa.InvalidPatternError("b", "foo", "bar")
+		length  = goa.InvalidLengthError("c", "foo", 3, 5, true)
+	)
+	cases := []struct {
+		Name     string
+		Err      error
+		Messages []string
+	}{
+		{"single", goa.MissingFieldError("a", "body"), nil},
+		{"merged", goa.MergeErrors(goa.MissingFieldError("a", "body"), pattern), nil},
+		{"aggregated", goa.AggregateErrors(goa.MergeErrors(goa.MissingFieldError("a", "body"), pattern)), []string{missing.Error(), pattern.Error()}},
+		{"aggregated-merged", goa.AggregateErrors(goa.MergeErrors(goa.MergeErrors(goa.MissingFieldError("a", "body"), pattern), length)), []string{missing.Error(), pattern.Error(), length.Error()}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			resp := NewErrorResponse(c.Err)
+			if resp.Message != c.Err.Error() {
+				t.Errorf("got message %q, expected %q", resp.Message, c.Err.Error())
+			}
+			if len(resp.Errors) != len(c.Messages) {
+				t.Fatalf("got %d errors, expected %d", len(resp.Errors), len(c.Messages))
+			}
+			for i, e := range resp.Errors {
+				if e.Message != c.Messages[i] {
+					t.Errorf("got error message %q at index %d, expected %q", e.Message, i, c.Messages[i])
+				}
+			}
+		})
+	}
+}
+
+func TestErrorResponseStatusCode(t *testing.T) {
+	cases := []struct {
+		Name string
+		Err  error
+		Code int
+	}{
+		{"bad request", goa.MissingFieldError("a", "body"), http.StatusBadRequest},
+		{"fault", goa.Fault("fault"), http.StatusInternalServerError},
+		{"body too large", ErrRequestBodyTooLarge(10), http.StatusRequestEntityTooLarge},
+		{"unsupported media type", ErrUnsupportedMediaType("text/plain", "application/json"), http.StatusUnsupportedMediaType},
+		{"insufficient scope", security.CheckScopes(security.ContextWithScopes(context.Background(), nil), []string{"api:read"}), http.StatusForbidden},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if code := NewErrorResponse(c.Err).StatusCode(); code != c.Code {
+				t.Errorf("got status code %d, expected %d", code, c.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,572 @@
+package http
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+type (
+	// JSONWriter writes JSON values to a buffer without using reflection.
+	// It is used by the MarshalJSON methods generated for the body types
+	// when the "http:body:fastjson" metadata is set. The writer inserts
+	// the commas separating the object fields and array elements.
+	JSONWriter struct {
+		buf   []byte
+		comma bool
+		err   error
+	}
+
+	// JSONReader reads JSON values from a buffer without using reflection.
+	// It is used by the UnmarshalJSON methods generated for the body types
+	// when the "http:body:fastjson" metadata is set. The first error
+	// encountered is recorded and returned by Err, all subsequent reads
+	// are no-ops.
+	JSONReader struct {
+		data []byte
+		pos  int
+		err  error
+	}
+)
+
+// NewJSONWriter returns a writer that writes to an empty buffer.
+func NewJSONWriter() *JSONWriter {
+	return &JSONWriter{buf: make([]byte, 0, 256)}
+}
+
+// Bytes returns the JSON written so far.
+func (w *JSONWriter) Bytes() []byte { return w.buf }
+
+// Err returns the first error encountered while writing if any.
+func (w *JSONWriter) Err() error { return w.err }
+
+// ObjectStart writes the beginning of an object.
+func (w *JSONWriter) ObjectStart() {
+	w.sep()
+	w.buf = append(w.buf, '{')
+	w.comma = false
+}
+
+// ObjectEnd writes the end of an object.
+func (w *JSONWriter) ObjectEnd() {
+	w.buf = append(w.buf, '}')
+	w.comma = true
+}
+
+// ArrayStart writes the beginning of an array.
+func (w *JSONWriter) ArrayStart() {
+	w.sep()
+	w.buf = append(w.buf, '[')
+	w.comma = false
+}
+
+// ArrayEnd writes the end of an array.
+func (w *JSONWriter) ArrayEnd() {
+	w.buf = append(w.buf, ']')
+	w.comma = true
+}
+
+// Field writes the name of an object field, it must be followed by the field
+// value.
+func (w *JSONWriter) Field(name string) {
+	w.sep()
+	w.buf = appendJSONString(w.buf, name)
+	w.buf = append(w.buf, ':')
+	w.comma = false
+}
+
+// Null writes null.
+func (w *JSONWriter) Null() {
+	w.sep()
+	w.buf = append(w.buf, "null"...)
+}
+
+// String writes a string.
+func (w *JSONWriter) String(s string) {
+	w.sep()
+	w.buf = appendJSONString(w.buf, s)
+}
+
+// Bool writes a boolean.
+func (w *JSONWriter) Bool(b bool) {
+	w.sep()
+	w.buf = strconv.AppendBool(w.buf, b)
+}
+
+// Int writes a signed integer.
+func (w *JSONWriter) Int(i int64) {
+	w.sep()
+	w.buf = strconv.AppendInt(w.buf, i, 10)
+}
+
+// Uint writes an unsigned integer.
+func (w *JSONWriter) Uint(u uint64) {
+	w.sep()
+	w.buf = strconv.AppendUint(w.buf, u, 10)
+}
+
+// Float writes a floating point number with the given bit size (32 or 64)
+// using the same format as encoding/json.
+func (w *JSONWriter) Float(f float64, bits int) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		w.fail(fmt.Errorf("json: unsupported value: %s", strconv.FormatFloat(f, 'g', -1, bits)))
+		return
+	}
+	w.sep()
+	format := byte('f')
+	if abs := math.Abs(f); abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) || bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			format = 'e'
+		}
+	}
+	w.buf = strconv.AppendFloat(w.buf, f, format, -1, bits)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		if n := len(w.buf); n >= 4 && w.buf[n-4] == 'e' && w.buf[n-3] == '-' && w.buf[n-2] == '0' {
+			w.buf[n-2] = w.buf[n-1]
+			w.buf = w.buf[:n-1]
+		}
+	}
+}
+
+// Base64 writes a byte slice as a base64 encoded string, a nil slice is
+// written as null.
+func (w *JSONWriter) Base64(b []byte) {
+	if b == nil {
+		w.Null()
+		return
+	}
+	w.sep()
+	w.buf = append(w.buf, '"')
+	n := len(w.buf)
+	w.buf = append(w.buf, make([]byte, base64.StdEncoding.EncodedLen(len(b)))...)
+	base64.StdEncoding.Encode(w.buf[n:], b)
+	w.buf = append(w.buf, '"')
+}
+
+// Value writes v using encoding/json. It is used for the values whose type
+// is not supported by the writer.
+func (w *JSONWriter) Value(v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	w.sep()
+	w.buf = append(w.buf, b...)
+}
+
+// sep writes the comma separating the value about to be written from the
+// previous one if needed.
+func (w *JSONWriter) sep() {
+	if w.comma {
+		w.buf = append(w.buf, ',')
+	}
+	w.comma = true
+}
+
+// fail records the first error.
+func (w *JSONWriter) fail(err error) {
+	if w.err == nil {
+		w.err = err
+	}
+}
+
+// NewJSONReader returns a reader that reads the given JSON document.
+func NewJSONReader(data []byte) *JSONReader {
+	return &JSONReader{data: data}
+}
+
+// Err returns the first error encountered while reading if any.
+func (r *JSONReader) Err() error { return r.err }
+
+// ObjectStart reads the beginning of an object.
+func (r *JSONReader) ObjectStart() { r.delim('{') }
+
+// ObjectEnd reads the end of an object.
+func (r *JSONReader) ObjectEnd() { r.delim('}') }
+
+// ArrayStart reads the beginning of an array.
+func (r *JSONReader) ArrayStart() { r.delim('[') }
+
+// ArrayEnd reads the end of an array.
+func (r *JSONReader) ArrayEnd() { r.delim(']') }
+
+// More returns true if the current object or array has more fields or
+// elements. It consumes the comma separating them from the previous ones.
+func (r *JSONReader) More() bool {
+	if r.err != nil {
+		return false
+	}
+	r.skipSpace()
+	if r.pos < len(r.data) && r.data[r.pos] == ',' {
+		r.pos++
+		r.skipSpace()
+	}
+	if r.pos >= len(r.data) {
+		r.fail("unexpected end of JSON input")
+		return false
+	}
+	c := r.data[r.pos]
+	return c != '}' && c != ']'
+}
+
+// Field reads the name of an object field and the colon that follows it.
+func (r *JSONReader) Field() string {
+	name := r.String()
+	r.delim(':')
+	return name
+}
+
+// Null reads null and returns true if the next value is null, it returns
+// false and reads nothing otherwise.
+func (r *JSONReader) Null() bool {
+	if r.err != nil {
+		return true
+	}
+	r.skipSpace()
+	if len(r.data)-r.pos >= 4 && string(r.data[r.pos:r.pos+4]) == "null" {
+		r.pos += 4
+		return true
+	}
+	return false
+}
+
+// String reads a string.
+func (r *JSONReader) String() string {
+	if r.err != nil {
+		return ""
+	}
+	r.skipSpace()
+	if r.pos >= len(r.data) || r.data[r.pos] != '"' {
+		r.fail("expected string")
+		return ""
+	}
+	r.pos++
+	start := r.pos
+	for r.pos < len(r.data) {
+		switch c := r.data[r.pos]; {
+		case c == '"':
+			s := string(r.data[start:r.pos])
+			r.pos++
+			return s
+		case c == '\\', c < ' ', c >= utf8.RuneSelf:
+			return r.unquote(start)
+		}
+		r.pos++
+	}
+	r.fail("unexpected end of JSON input")
+	return ""
+}
+
+// Bool reads a boolean.
+func (r *JSONReader) Bool() bool {
+	if r.err != nil {
+		return false
+	}
+	r.skipSpace()
+	switch {
+	case len(r.data)-r.pos >= 4 && string(r.data[r.pos:r.pos+4]) == "true":
+		r.pos += 4
+		return true
+	case len(r.data)-r.pos >= 5 && string(r.data[r.pos:r.pos+5]) == "false":
+		r.pos += 5
+		return false
+	}
+	r.fail("expected boolean")
+	return false
+}
+
+// Int reads a signed integer that fits in the given bit size, a bit size of
+// zero corresponds to int.
+func (r *JSONReader) Int(bits int) int64 {
+	n := r.number()
+	if r.err != nil {
+		return 0
+	}
+	i, err := strconv.ParseInt(n, 10, bits)
+	if err != nil {
+		r.fail(fmt.Sprintf("invalid integer %s", n))
+	}
+	return i
+}
+
+// Uint reads an unsigned integer that fits in the given bit size, a bit size
+// of zero corresponds to uint.
+func (r *JSONReader) Uint(bits int) uint64 {
+	n := r.number()
+	if r.err != nil {
+		return 0
+	}
+	u, err := strconv.ParseUint(n, 10, bits)
+	if err != nil {
+		r.fail(fmt.Sprintf("invalid unsigned integer %s", n))
+	}
+	return u
+}
+
+// Float reads a floating point number with the given bit size (32 or 64).
+func (r *JSONReader) Float(bits int) float64 {
+	n := r.number()
+	if r.err != nil {
+		return 0
+	}
+	f, err := strconv.ParseFloat(n, bits)
+	if err != nil {
+		r.fail(fmt.Sprintf("invalid number %s", n))
+	}
+	return f
+}
+
+// Base64 reads a base64 encoded string.
+func (r *JSONReader) Base64() []byte {
+	s := r.String()
+	if r.err != nil {
+		return nil
+	}
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		r.fail("invalid base64 string")
+	}
+	return b
+}
+
+// Value reads the next value using encoding/json. It is used for the values
+// whose type is not supported by the reader.
+func (r *JSONReader) Value(v interface{}) {
+	start := r.skipSpace()
+	r.Skip()
+	if r.err != nil {
+		return
+	}
+	if err := json.Unmarshal(r.data[start:r.pos], v); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+// Skip reads and discards the next value.
+func (r *JSONReader) Skip() {
+	if r.err != nil {
+		return
+	}
+	r.skipSpace()
+	if r.pos >= len(r.data) {
+		r.fail("unexpected end of JSON input")
+		return
+	}
+	switch r.data[r.pos] {
+	case '"':
+		_ = r.String()
+	case '{':
+		r.ObjectStart()
+		for r.More() {
+			r.Field()
+			r.Skip()
+		}
+		r.ObjectEnd()
+	case '[':
+		r.ArrayStart()
+		for r.More() {
+			r.Skip()
+		}
+		r.ArrayEnd()
+	case 't', 'f':
+		r.Bool()
+	case 'n':
+		if !r.Null() {
+			r.fail("invalid literal")
+		}
+	default:
+		r.number()
+	}
+}
+
+// number reads a number literal.
+func (r *JSONReader) number() string {
+	if r.err != nil {
+		return ""
+	}
+	r.skipSpace()
+	start := r.pos
+	for r.pos < len(r.data) {
+		c := r.data[r.pos]
+		if c != '-' && c != '+' && c != '.' && c != 'e' && c != 'E' && (c < '0' || c > '9') {
+			break
+		}
+		r.pos++
+	}
+	if start == r.pos {
+		r.fail("expected number")
+		return ""
+	}
+	return string(r.data[start:r.pos])
+}
+
+// unquote reads the rest of a string that contains escape sequences or non
+// ASCII characters, start is the position of the first character of the
+// string.
+func (r *JSONReader) unquote(start int) string {
+	b := make([]byte, 0, r.pos-start+16)
+	b = append(b, r.data[start:r.pos]...)
+	for r.pos < len(r.data) {
+		c := r.data[r.pos]
+		switch {
+		case c == '"':
+			r.pos++
+			return string(b)
+		case c == '\\':
+			r.pos++
+			if r.pos >= len(r.data) {
+				break
+			}
+			switch e := r.data[r.pos]; e {
+			case '"', '\\', '/':
+				b = append(b, e)
+			case 'b':
+				b = append(b, '\b')
+			case 'f':
+				b = append(b, '\f')
+			case 'n':
+				b = append(b, '\n')
+			case 'r':
+				b = append(b, '\r')
+			case 't':
+				b = append(b, '\t')
+			case 'u':
+				r.pos++
+				rr := r.hex4()
+				if utf16.IsSurrogate(rr) {
+					if len(r.data)-r.pos >= 6 && r.data[r.pos] == '\\' && r.data[r.pos+1] == 'u' {
+						save := r.pos
+						r.pos += 2
+						if dec := utf16.DecodeRune(rr, r.hex4()); dec != utf8.RuneError {
+							b = utf8.AppendRune(b, dec)
+							continue
+						}
+						r.pos = save
+					}
+					rr = utf8.RuneError
+				}
+				b = utf8.AppendRune(b, rr)
+				continue
+			default:
+				r.fail("invalid escape sequence")
+				return ""
+			}
+			r.pos++
+		case c < ' ':
+			r.fail("invalid character in string")
+			return ""
+		case c >= utf8.RuneSelf:
+			rr, size := utf8.DecodeRune(r.data[r.pos:])
+			b = utf8.AppendRune(b, rr)
+			r.pos += size
+		default:
+			b = append(b, c)
+			r.pos++
+		}
+	}
+	r.fail("unexpected end of JSON input")
+	return ""
+}
+
+// hex4 reads the four hexadecimal digits of a \u escape sequence.
+func (r *JSONReader) hex4() rune {
+	if len(r.data)-r.pos < 4 {
+		r.fail("invalid escape sequence")
+		return utf8.RuneError
+	}
+	v, err := strconv.ParseUint(string(r.data[r.pos:r.pos+4]), 16, 32)
+	if err != nil {
+		r.fail("invalid escape sequence")
+		return utf8.RuneError
+	}
+	r.pos += 4
+	return rune(v)
+}
+
+// delim reads the given delimiter.
+func (r *JSONReader) delim(d byte) {
+	if r.err != nil {
+		return
+	}
+	r.skipSpace()
+	if r.pos >= len(r.data) || r.data[r.pos] != d {
+		r.fail(fmt.Sprintf("expected %q", d))
+		return
+	}
+	r.pos++
+}
+
+// skipSpace skips white spaces and returns the new position.
+func (r *JSONReader) skipSpace() int {
+	for r.pos < len(r.data) {
+		switch r.data[r.pos] {
+		case ' ', '\t', '\n', '\r':
+			r.pos++
+			continue
+		}
+		break
+	}
+	return r.pos
+}
+
+// fail records the first error.
+func (r *JSONReader) fail(msg string) {
+	if r.err == nil {
+		r.err = fmt.Errorf("invalid JSON at offset %d: %s", r.pos, msg)
+	}
+}
+
+// appendJSONString appends the JSON representation of s to b.
+func appendJSONString(b []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	b = append(b, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= ' ' && c != '"' && c != '\\' {
+				i++
+				continue
+			}
+			b = append(b, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				b = append(b, '\\', c)
+			case '\n':
+				b = append(b, '\\', 'n')
+			case '\r':
+				b = append(b, '\\', 'r')
+			case '\t':
+				b = append(b, '\\', 't')
+			default:
+				b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		rr, size := utf8.DecodeRuneInString(s[i:])
+		if rr == utf8.RuneError && size == 1 {
+			b = append(b, s[start:i]...)
+			b = append(b, `\ufffd`...)
+			i += size
+			start = i
+			continue
+		}
+		if rr == '\u2028' || rr == '\u2029' {
+			b = append(b, s[start:i]...)
+			b = append(b, '\\', 'u', '2', '0', '2', hex[rr&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	b = append(b, s[start:]...)
+	return append(b, '"')
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestJSONWriter(t *testing.T) {
+	cases := []struct {
+		Name  string
+		Write func(w *JSONWriter)
+		Value interface{}
+	}{
+		{"string", func(w *JSONWriter) { w.String("a\"b\\c\n\t\u2028é") }, "a\"b\\c\n\t\u2028é"},
+		{"int", func(w *JSONWriter) { w.Int(-42) }, -42},
+		{"uint", func(w *JSONWriter) { w.Uint(math.MaxUint64) }, uint64(math.MaxUint64)},
+		{"float32", func(w *JSONWriter) { w.Float(float64(float32(1.1)), 32) }, float32(1.1)},
+		{"float64", func(w *JSONWriter) { w.Float(1e-7, 64) }, 1e-7},
+		{"bytes", func(w *JSONWriter) { w.Base64([]byte("hello")) }, []byte("hello")},
+		{"object", func(w *JSONWriter) {
+			w.ObjectStart()
+			w.Field("a")
+			w.ArrayStart()
+			w.Bool(true)
+			w.Null()
+			w.ArrayEnd()
+			w.Field("b")
+			w.Value(map[string]int{"c": 1})
+			w.ObjectEnd()
+		}, map[string]interface{}{"a": []interface{}{true, nil}, "b": map[string]int{"c": 1}}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := NewJSONWriter()
+			c.Write(w)
+			if err := w.Err(); err != nil {
+				t.Fatal(err)
+			}
+			expected, err := json.Marshal(c.Value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(w.Bytes()) != string(expected) {
+				t.Errorf("got %s, expected %s", w.Bytes(), expected)
+			}
+		})
+	}
+}
+
+func TestJSONReader(t *testing.T) {
+	r := NewJSONReader([]byte(` {"s": "aé😀\"", "skip": [1, {"x": null}], "n": -12, "f": 2.5e1, "b": "aGk=", "v": {"k": [true]}, "z": null} `))
+	var (
+		s    string
+		n    int64
+		f    float64
+		b    []byte
+		v    map[string][]bool
+		null bool
+	)
+	r.ObjectStart()
+	for r.More() {
+		switch r.Field() {
+		case "s":
+			s = r.String()
+		case "n":
+			n = r.Int(64)
+		case "f":
+			f = r.Float(64)
+		case "b":
+			b = r.Base64()
+		case "v":
+			r.Value(&v)
+		case "z":
+			null = r.Null()
+		default:
+			r.Skip()
+		}
+	}
+	r.ObjectEnd()
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if s != "aé\U0001F600\"" {
+		t.Errorf("got string %q", s)
+	}
+	if n != -12 {
+		t.Errorf("got int %d", n)
+	}
+	if f != 25 {
+		t.Errorf("got float %v", f)
+	}
+	if string(b) != "hi" {
+		t.Errorf("got bytes %q", b)
+	}
+	if len(v["k"]) != 1 || !v["k"][0] {
+		t.Errorf("got value %v", v)
+	}
+	if !null {
+		t.Error("got non null value")
+	}
+
+	r = NewJSONReader([]byte(`{"i": 300}`))
+	r.ObjectStart()
+	r.Field()
+	r.Int(8)
+	if r.Err() == nil {
+		t.Error("expected out of range error")
+	}
+}
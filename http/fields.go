@@ -0,0 +1,106 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+
+	"goa.design/goa"
+)
+
+// fieldTree is the tree of selected fields, a nil subtree selects the whole
+// field value.
+type fieldTree map[string]fieldTree
+
+// WithFields parses the comma separated list of result fields given in the
+// request query string, validates them against the allowed field paths and
+// returns a copy of ctx holding the selection under FieldsKey. WithFields
+// returns ctx unchanged if param is empty. The generated server handlers of
+// the endpoints that use FieldSelection call WithFields before invoking the
+// endpoint.
+func WithFields(ctx context.Context, param string, allowed []string) (context.Context, error) {
+	if param == "" {
+		return ctx, nil
+	}
+	valid := make(map[string]struct{}, len(allowed))
+	for _, f := range allowed {
+		valid[f] = struct{}{}
+	}
+	tree := make(fieldTree)
+	for _, f := range strings.Split(param, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if _, ok := valid[f]; !ok {
+			return ctx, goa.PermanentError("invalid_field", "invalid field %q, valid fields are %s", f, strings.Join(allowed, ", "))
+		}
+		tree.add(strings.Split(f, "."))
+	}
+	return context.WithValue(ctx, FieldsKey, tree), nil
+}
+
+// SelectFields returns a value that encodes like body but only contains the
+// fields stored in ctx by WithFields. The selection applies to each element if
+// body is a collection. SelectFields returns body unchanged if ctx does not
+// hold any selection. The generated response encoders of the endpoints that use
+// FieldSelection call SelectFields to prune the response body.
+func SelectFields(ctx context.Context, body interface{}) (interface{}, error) {
+	tree, ok := ctx.Value(FieldsKey).(fieldTree)
+	if !ok || len(tree) == 0 {
+		return body, nil
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return tree.prune(v), nil
+}
+
+// add adds the field with the given path to the tree.
+func (t fieldTree) add(path []string) {
+	for i, p := range path {
+		if i == len(path)-1 {
+			t[p] = nil
+			return
+		}
+		child, ok := t[p]
+		if ok && child == nil {
+			return // whole field already selected
+		}
+		if !ok {
+			child = make(fieldTree)
+			t[p] = child
+		}
+		t = child
+	}
+}
+
+// prune removes the fields of v that are not in the tree.
+func (t fieldTree) prune(v interface{}) interface{} {
+	switch actual := v.(type) {
+	case map[string]interface{}:
+		for k, val := range actual {
+			sub, ok := t[k]
+			if !ok {
+				delete(actual, k)
+				continue
+			}
+			if sub != nil {
+				actual[k] = sub.prune(val)
+			}
+		}
+	case []interface{}:
+		for i, val := range actual {
+			actual[i] = t.prune(val)
+		}
+	}
+	return v
+}
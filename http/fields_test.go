@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestSelectFields(t *testing.T) {
+	type (
+		author struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		}
+		book struct {
+			ID      int       `json:"id"`
+			Title   string    `json:"title"`
+			Authors []*author `json:"authors"`
+		}
+	)
+	var (
+		allowed = []string{"id", "title", "authors", "authors.name", "authors.email"}
+		b       = &book{ID: 1, Title: "goa", Authors: []*author{{Name: "a", Email: "a@goa.design"}}}
+	)
+	cases := []struct {
+		Name     string
+		Param    string
+		Body     interface{}
+		Expected string
+		Error    bool
+	}{
+		{"none", "", b, `{"id":1,"title":"goa","authors":[{"name":"a","email":"a@goa.design"}]}`, false},
+		{"top level", "id, title", b, `{"id":1,"title":"goa"}`, false},
+		{"nested", "id,authors.name", b, `{"authors":[{"name":"a"}],"id":1}`, false},
+		{"whole and nested", "authors,authors.name", b, `{"authors":[{"email":"a@goa.design","name":"a"}]}`, false},
+		{"collection", "title", []*book{b, b}, `[{"title":"goa"},{"title":"goa"}]`, false},
+		{"invalid", "id,price", b, "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx, err := WithFields(context.Background(), c.Param, allowed)
+			if c.Error {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			v, err := SelectFields(ctx, c.Body)
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			js, _ := json.Marshal(v)
+			if string(js) != c.Expected {
+				t.Errorf("got %s, expected %s", js, c.Expected)
+			}
+		})
+	}
+}
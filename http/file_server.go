@@ -0,0 +1,186 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FileServer returns a handler that serves HTTP requests with the contents of
+// the file system rooted at root. Unlike http.FileServer the handler handles
+// range requests explicitly: it always sets the Accept-Ranges header, responds
+// to single range requests with the corresponding Content-Range and returns at
+// most chunkSize bytes in a partial response. A chunkSize of zero means there
+// is no limit.
+func FileServer(root http.FileSystem, chunkSize int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := r.URL.Path
+		if !strings.HasPrefix(name, "/") {
+			name = "/" + name
+		}
+		serveFile(w, r, root, path.Clean(name), chunkSize, http.FileServer(root).ServeHTTP)
+	})
+}
+
+// ServeFile replies to the request with the contents of the named file or
+// directory, see FileServer for a description of how range requests are
+// handled.
+func ServeFile(w http.ResponseWriter, r *http.Request, name string, chunkSize int64) {
+	dir, file := filepath.Split(name)
+	serveFile(w, r, http.Dir(dir), "/"+file, chunkSize, func(w http.ResponseWriter, r *http.Request) {
+		http.ServeFile(w, r, name)
+	})
+}
+
+// ServeFileFS replies to the request with the contents of the named file or
+// directory read from fsys, see FileServer for a description of how range
+// requests are handled.
+func ServeFileFS(w http.ResponseWriter, r *http.Request, fsys http.FileSystem, name string, chunkSize int64) {
+	if !strings.HasPrefix(name, "/") {
+		name = "/" + name
+	}
+	serveFile(w, r, fsys, path.Clean(name), chunkSize, http.FileServer(fsys).ServeHTTP)
+}
+
+// serveFile opens the file with the given name in fs and writes its content
+// to w honoring the request Range header. serveDir is called if name is a
+// directory.
+func serveFile(w http.ResponseWriter, r *http.Request, fs http.FileSystem, name string, chunkSize int64, serveDir http.HandlerFunc) {
+	f, err := fs.Open(name)
+	if err != nil {
+		serveError(w, err)
+		return
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		serveError(w, err)
+		return
+	}
+	if fi.IsDir() {
+		// Let the standard library deal with directory listings and
+		// index files.
+		serveDir(w, r)
+		return
+	}
+	serveContent(w, r, fi.Name(), fi.ModTime(), fi.Size(), f, chunkSize)
+}
+
+// serveContent writes the content read from rs honoring the request Range
+// header. Only single ranges are supported, requests for multiple ranges are
+// served the first range only.
+func serveContent(w http.ResponseWriter, r *http.Request, name string, modtime time.Time, size int64, rs io.ReadSeeker, chunkSize int64) {
+	ctype := mime.TypeByExtension(filepath.Ext(name))
+	if ctype == "" {
+		ctype = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", ctype)
+	w.Header().Set("Accept-Ranges", "bytes")
+	lastModified := modtime.UTC().Format(http.TimeFormat)
+	if !modtime.IsZero() {
+		w.Header().Set("Last-Modified", lastModified)
+	}
+
+	rng := r.Header.Get("Range")
+	if ir := r.Header.Get("If-Range"); ir != "" && ir != lastModified {
+		// The file changed since the client started downloading it,
+		// send the whole content.
+		rng = ""
+	}
+	if rng == "" {
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+		w.WriteHeader(http.StatusOK)
+		if r.Method != "HEAD" {
+			io.CopyN(w, rs, size)
+		}
+		return
+	}
+	start, length, err := ParseRange(rng, size)
+	if err != nil {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", size))
+		http.Error(w, err.Error(), http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+	if chunkSize > 0 && length > chunkSize {
+		length = chunkSize
+	}
+	if _, err := rs.Seek(start, io.SeekStart); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, start+length-1, size))
+	w.Header().Set("Content-Length", strconv.FormatInt(length, 10))
+	w.WriteHeader(http.StatusPartialContent)
+	if r.Method != "HEAD" {
+		io.CopyN(w, rs, length)
+	}
+}
+
+// ParseRange parses the value of a Range header, e.g. "bytes=0-499", and
+// returns the start and length of the first range given the size of the
+// content. It returns an error if the range is invalid or cannot be
+// satisfied.
+func ParseRange(s string, size int64) (start, length int64, err error) {
+	const b = "bytes="
+	if !strings.HasPrefix(s, b) {
+		return 0, 0, fmt.Errorf("invalid range %q", s)
+	}
+	spec := strings.TrimSpace(strings.Split(s[len(b):], ",")[0])
+	i := strings.Index(spec, "-")
+	if i < 0 {
+		return 0, 0, fmt.Errorf("invalid range %q", s)
+	}
+	first, last := strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+1:])
+	if first == "" {
+		// Suffix range, e.g. "bytes=-500" requests the last 500 bytes.
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n <= 0 {
+			return 0, 0, fmt.Errorf("invalid range %q", s)
+		}
+		if n > size {
+			n = size
+		}
+		if n == 0 {
+			return 0, 0, fmt.Errorf("range %q cannot be satisfied", s)
+		}
+		return size - n, n, nil
+	}
+	start, err = strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return 0, 0, fmt.Errorf("invalid range %q", s)
+	}
+	if start >= size {
+		return 0, 0, fmt.Errorf("range %q cannot be satisfied", s)
+	}
+	end := size - 1
+	if last != "" {
+		end, err = strconv.ParseInt(last, 10, 64)
+		if err != nil || end < start {
+			return 0, 0, fmt.Errorf("invalid range %q", s)
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	return start, end - start + 1, nil
+}
+
+// serveError writes the response corresponding to the error returned when
+// opening or reading a file.
+func serveError(w http.ResponseWriter, err error) {
+	switch {
+	case os.IsNotExist(err):
+		http.Error(w, "404 page not found", http.StatusNotFound)
+	case os.IsPermission(err):
+		http.Error(w, "403 Forbidden", http.StatusForbidden)
+	default:
+		http.Error(w, "500 Internal Server Error", http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRange(t *testing.T) {
+	cases := []struct {
+		Name           string
+		Range          string
+		Start, Length  int64
+		ExpectedErrMsg string
+	}{
+		{"full", "bytes=0-", 0, 10, ""},
+		{"bounded", "bytes=2-5", 2, 4, ""},
+		{"end past size", "bytes=5-20", 5, 5, ""},
+		{"suffix", "bytes=-3", 7, 3, ""},
+		{"suffix past size", "bytes=-20", 0, 10, ""},
+		{"multiple", "bytes=1-2, 4-5", 1, 2, ""},
+		{"invalid unit", "items=0-1", 0, 0, `invalid range "items=0-1"`},
+		{"invalid spec", "bytes=1", 0, 0, `invalid range "bytes=1"`},
+		{"invalid end", "bytes=5-2", 0, 0, `invalid range "bytes=5-2"`},
+		{"not satisfiable", "bytes=10-", 0, 0, `range "bytes=10-" cannot be satisfied`},
+	}
+	for _, c := range cases {
+		start, length, err := ParseRange(c.Range, 10)
+		if c.ExpectedErrMsg != "" {
+			if err == nil || err.Error() != c.ExpectedErrMsg {
+				t.Errorf("%s: expected error %q, got %v", c.Name, c.ExpectedErrMsg, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", c.Name, err)
+			continue
+		}
+		if start != c.Start || length != c.Length {
+			t.Errorf("%s: expected start %d and length %d, got %d and %d", c.Name, c.Start, c.Length, start, length)
+		}
+	}
+}
+
+func TestServeContent(t *testing.T) {
+	const content = "0123456789"
+	modtime := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		Name         string
+		Range        string
+		IfRange      string
+		ChunkSize    int64
+		Status       int
+		ContentRange string
+		Body         string
+	}{
+		{"no range", "", "", 0, http.StatusOK, "", content},
+		{"range", "bytes=2-5", "", 0, http.StatusPartialContent, "bytes 2-5/10", "2345"},
+		{"chunk size", "bytes=2-", "", 3, http.StatusPartialContent, "bytes 2-4/10", "234"},
+		{"if-range match", "bytes=8-", modtime.Format(http.TimeFormat), 0, http.StatusPartialContent, "bytes 8-9/10", "89"},
+		{"if-range mismatch", "bytes=8-", "Mon, 01 Jan 2001 00:00:00 GMT", 0, http.StatusOK, "", content},
+		{"not satisfiable", "bytes=12-", "", 0, http.StatusRequestedRangeNotSatisfiable, "bytes */10", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/file.txt", nil)
+			if c.Range != "" {
+				r.Header.Set("Range", c.Range)
+			}
+			if c.IfRange != "" {
+				r.Header.Set("If-Range", c.IfRange)
+			}
+			w := httptest.NewRecorder()
+			serveContent(w, r, "file.txt", modtime, int64(len(content)), strings.NewReader(content), c.ChunkSize)
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if ar := w.Header().Get("Accept-Ranges"); ar != "bytes" {
+				t.Errorf("got Accept-Ranges %q, expected %q", ar, "bytes")
+			}
+			if cr := w.Header().Get("Content-Range"); cr != c.ContentRange {
+				t.Errorf("got Content-Range %q, expected %q", cr, c.ContentRange)
+			}
+			if c.Status != http.StatusRequestedRangeNotSatisfiable && w.Body.String() != c.Body {
+				t.Errorf("got body %q, expected %q", w.Body.String(), c.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	// FormContentType is the MIME type of URL encoded form bodies.
+	FormContentType = "application/x-www-form-urlencoded"
+
+	// MultipartFormContentType is the MIME type of multipart form bodies.
+	MultipartFormContentType = "multipart/form-data"
+
+	// formMaxMemory is the maximum number of bytes of the multipart form
+	// files kept in memory by FormDecoder, the remainder is stored in
+	// temporary files.
+	formMaxMemory = 32 << 20
+)
+
+// FormDecoder returns a HTTP request body decoder that decodes URL encoded
+// ("application/x-www-form-urlencoded") and multipart ("multipart/form-data")
+// form bodies into structs. The form fields are mapped to the struct fields
+// using the "form" struct field tags of the generated body types. Fields of
+// type []byte may also be initialized from the content of a multipart file.
+// Fields whose types are structs or maps are decoded from the JSON
+// representation given in the form value.
+func FormDecoder(r *http.Request) Decoder {
+	return EncodingFunc(func(v interface{}) error {
+		var files map[string][]*multipart.FileHeader
+		if RequestContentType(r, FormContentType) == MultipartFormContentType {
+			if err := r.ParseMultipartForm(formMaxMemory); err != nil {
+				return err
+			}
+			files = r.MultipartForm.File
+		} else if err := r.ParseForm(); err != nil {
+			return err
+		}
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			return fmt.Errorf("form decoder requires a non-nil pointer, got %T", v)
+		}
+		return decodeForm(rv.Elem(), r.PostForm, files)
+	})
+}
+
+// FormEncoder returns a HTTP request body encoder that encodes structs into
+// URL encoded or multipart forms depending on the request Content-Type header.
+// The encoder uses the "form" struct field tags of the generated body types to
+// name the form fields. It sets the boundary parameter of the Content-Type
+// header when encoding multipart forms.
+func FormEncoder(r *http.Request) Encoder {
+	var buf bytes.Buffer
+	r.Body = ioutil.NopCloser(&buf)
+	return EncodingFunc(func(v interface{}) error {
+		vals, err := encodeForm(reflect.ValueOf(v))
+		if err != nil {
+			return err
+		}
+		if RequestContentType(r, FormContentType) != MultipartFormContentType {
+			_, err := buf.WriteString(vals.Encode())
+			return err
+		}
+		mw := multipart.NewWriter(&buf)
+		for k, vs := range vals {
+			for _, val := range vs {
+				if err := mw.WriteField(k, val); err != nil {
+					return err
+				}
+			}
+		}
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		return mw.Close()
+	})
+}
+
+// RequestContentType returns the MIME type of the request Content-Type header
+// stripped from its parameters. It returns def if the header is missing.
+func RequestContentType(r *http.Request, def string) string {
+	ct := r.Header.Get("Content-Type")
+	if ct == "" {
+		return def
+	}
+	if mediaType, _, err := mime.ParseMediaType(ct); err == nil {
+		return mediaType
+	}
+	return ct
+}
+
+// decodeForm initializes the fields of the struct rv with the form values and
+// files.
+func decodeForm(rv reflect.Value, vals url.Values, files map[string][]*multipart.FileHeader) error {
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("form decoder requires a pointer to a struct, got %s", rv.Type())
+	}
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := formFieldName(t.Field(i))
+		if name == "" {
+			continue
+		}
+		fv := rv.Field(i)
+		if fhs := files[name]; len(fhs) > 0 && isBytes(fv.Type()) {
+			b, err := readFormFile(fhs[0])
+			if err != nil {
+				return err
+			}
+			setBytes(fv, b)
+			continue
+		}
+		vs, ok := vals[name]
+		if !ok || len(vs) == 0 {
+			continue
+		}
+		if err := setFormValue(fv, vs); err != nil {
+			return fmt.Errorf("invalid value for form field %q: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// setFormValue sets v to the value(s) vs.
+func setFormValue(v reflect.Value, vs []string) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(v.Type().Elem())
+		if err := setFormValue(elem.Elem(), vs); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case reflect.Slice:
+		if isBytes(v.Type()) {
+			v.SetBytes([]byte(vs[0]))
+			return nil
+		}
+		if isCollection(v.Type().Elem()) {
+			return json.Unmarshal([]byte(vs[0]), v.Addr().Interface())
+		}
+		s := reflect.MakeSlice(v.Type(), len(vs), len(vs))
+		for i, val := range vs {
+			if err := setFormValue(s.Index(i), []string{val}); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.String:
+		v.SetString(vs[0])
+	case reflect.Bool:
+		b, err := strconv.ParseBool(vs[0])
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(vs[0], 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(vs[0], 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(vs[0], v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Interface:
+		if v.NumMethod() > 0 {
+			return json.Unmarshal([]byte(vs[0]), v.Addr().Interface())
+		}
+		var val interface{}
+		if err := json.Unmarshal([]byte(vs[0]), &val); err != nil {
+			// Not a JSON value, use the raw string.
+			val = vs[0]
+		}
+		v.Set(reflect.ValueOf(val))
+	default:
+		return json.Unmarshal([]byte(vs[0]), v.Addr().Interface())
+	}
+	return nil
+}
+
+// encodeForm returns the form values that correspond to the fields of the
+// struct rv.
+func encodeForm(rv reflect.Value) (url.Values, error) {
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return url.Values{}, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("form encoder requires a struct, got %s", rv.Type())
+	}
+	vals := make(url.Values)
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := formFieldName(t.Field(i))
+		if name == "" {
+			continue
+		}
+		vs, err := formValues(rv.Field(i))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for form field %q: %s", name, err)
+		}
+		if len(vs) > 0 {
+			vals[name] = vs
+		}
+	}
+	return vals, nil
+}
+
+// formValues returns the string representations of v, nil if v is nil.
+func formValues(v reflect.Value) ([]string, error) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return formValues(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if isBytes(v.Type()) {
+			return []string{string(v.Bytes())}, nil
+		}
+		if isCollection(v.Type().Elem()) {
+			// Collections of collections cannot be represented
+			// with repeated fields.
+			return jsonFormValue(v)
+		}
+		vs := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			evs, err := formValues(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			vs = append(vs, evs...)
+		}
+		return vs, nil
+	case reflect.String:
+		return []string{v.String()}, nil
+	case reflect.Bool:
+		return []string{strconv.FormatBool(v.Bool())}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []string{strconv.FormatInt(v.Int(), 10)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []string{strconv.FormatUint(v.Uint(), 10)}, nil
+	case reflect.Float32, reflect.Float64:
+		return []string{strconv.FormatFloat(v.Float(), 'f', -1, v.Type().Bits())}, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+	}
+	return jsonFormValue(v)
+}
+
+// jsonFormValue returns the JSON representation of v.
+func jsonFormValue(v reflect.Value) ([]string, error) {
+	b, err := json.Marshal(v.Interface())
+	if err != nil {
+		return nil, err
+	}
+	return []string{string(b)}, nil
+}
+
+// formFieldName returns the name of the form field that corresponds to the
+// given struct field, the empty string if the field is not mapped.
+func formFieldName(f reflect.StructField) string {
+	if f.PkgPath != "" {
+		return ""
+	}
+	tag := f.Tag.Get("form")
+	if tag == "-" {
+		return ""
+	}
+	if idx := strings.Index(tag, ","); idx >= 0 {
+		tag = tag[:idx]
+	}
+	if tag == "" {
+		return f.Name
+	}
+	return tag
+}
+
+// readFormFile returns the content of the multipart form file.
+func readFormFile(fh *multipart.FileHeader) ([]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ioutil.ReadAll(f)
+}
+
+// isBytes returns true if t is []byte or a pointer to []byte.
+func isBytes(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// isCollection returns true if t is a slice other than []byte or a map or a
+// pointer to one.
+func isCollection(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Map || t.Kind() == reflect.Slice && !isBytes(t)
+}
+
+// setBytes sets v which is either a []byte or a pointer to a []byte to b.
+func setBytes(v reflect.Value, b []byte) {
+	if v.Kind() == reflect.Ptr {
+		p := reflect.New(v.Type().Elem())
+		p.Elem().SetBytes(b)
+		v.Set(p)
+		return
+	}
+	v.SetBytes(b)
+}
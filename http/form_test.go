@@ -0,0 +1,130 @@
+package http
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type formBody struct {
+	Name    *string                `form:"name,omitempty" json:"name,omitempty"`
+	Count   int                    `form:"count" json:"count"`
+	Ratio   *float64               `form:"ratio,omitempty" json:"ratio,omitempty"`
+	Tags    []string               `form:"tags,omitempty" json:"tags,omitempty"`
+	Matrix  [][]int                `form:"matrix,omitempty" json:"matrix,omitempty"`
+	Meta    map[string]string      `form:"meta,omitempty" json:"meta,omitempty"`
+	Nested  *formNested            `form:"nested,omitempty" json:"nested,omitempty"`
+	Any     interface{}            `form:"any,omitempty" json:"any,omitempty"`
+	Data    []byte                 `form:"data,omitempty" json:"data,omitempty"`
+	Ignored string                 `form:"-" json:"-"`
+	Extra   map[string]interface{} `form:"extra,omitempty" json:"extra,omitempty"`
+}
+
+type formNested struct {
+	Value string `json:"value"`
+}
+
+func TestFormEncoding(t *testing.T) {
+	var (
+		name  = "goa"
+		ratio = 0.5
+	)
+	v := &formBody{
+		Name:    &name,
+		Count:   2,
+		Ratio:   &ratio,
+		Tags:    []string{"a", "b"},
+		Matrix:  [][]int{{1, 2}, {3}},
+		Meta:    map[string]string{"k": "v"},
+		Nested:  &formNested{Value: "nested"},
+		Any:     "any",
+		Data:    []byte("data"),
+		Ignored: "ignored",
+	}
+	expected := *v
+	expected.Ignored = ""
+	for _, ct := range []string{FormContentType, MultipartFormContentType} {
+		t.Run(ct, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/", nil)
+			req.Header.Set("Content-Type", ct)
+			if err := RequestEncoder(req).Encode(v); err != nil {
+				t.Fatalf("failed to encode request: %s", err)
+			}
+			if ct == MultipartFormContentType && !strings.Contains(req.Header.Get("Content-Type"), "boundary=") {
+				t.Errorf("got Content-Type %q, expected a boundary", req.Header.Get("Content-Type"))
+			}
+			var decoded formBody
+			if err := RequestDecoder(req).Decode(&decoded); err != nil {
+				t.Fatalf("failed to decode request: %s", err)
+			}
+			if !reflect.DeepEqual(decoded, expected) {
+				t.Errorf("got request body %#v, expected %#v", decoded, expected)
+			}
+		})
+	}
+}
+
+func TestFormDecoder(t *testing.T) {
+	t.Run("invalid value", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader("count=two"))
+		req.Header.Set("Content-Type", FormContentType)
+		var decoded formBody
+		err := FormDecoder(req).Decode(&decoded)
+		if err == nil || !strings.Contains(err.Error(), `"count"`) {
+			t.Errorf("got error %v, expected invalid count error", err)
+		}
+	})
+
+	t.Run("multipart file", func(t *testing.T) {
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		fw, err := mw.CreateFormFile("data", "data.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte("file content"))
+		mw.WriteField("count", "3")
+		mw.Close()
+		req := httptest.NewRequest("POST", "/", &buf)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		var decoded formBody
+		if err := FormDecoder(req).Decode(&decoded); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+		if string(decoded.Data) != "file content" || decoded.Count != 3 {
+			t.Errorf("got data %q and count %d, expected %q and 3", decoded.Data, decoded.Count, "file content")
+		}
+	})
+
+	t.Run("any json", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/", strings.NewReader(`any=%7B%22a%22%3A1%7D`))
+		req.Header.Set("Content-Type", FormContentType)
+		var decoded formBody
+		if err := FormDecoder(req).Decode(&decoded); err != nil {
+			t.Fatalf("failed to decode request: %s", err)
+		}
+		if m, ok := decoded.Any.(map[string]interface{}); !ok || m["a"] != 1.0 {
+			t.Errorf("got any %#v, expected map with a=1", decoded.Any)
+		}
+	})
+}
+
+func TestRequestContentType(t *testing.T) {
+	cases := []struct{ Name, Header, Expected string }{
+		{"missing", "", "application/json"},
+		{"plain", "application/xml", "application/xml"},
+		{"params", "multipart/form-data; boundary=xyz", "multipart/form-data"},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest("POST", "/", nil)
+		if c.Header != "" {
+			req.Header.Set("Content-Type", c.Header)
+		}
+		if actual := RequestContentType(req, "application/json"); actual != c.Expected {
+			t.Errorf("%s: expected %q, got %q", c.Name, c.Expected, actual)
+		}
+	}
+}
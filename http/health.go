@@ -0,0 +1,54 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+type (
+	// Checker checks the health of the service or of one of its
+	// dependencies, it returns a non-nil error if the check fails.
+	Checker func(ctx context.Context) error
+
+	// healthResponse is the body of the health check responses.
+	healthResponse struct {
+		// Status is "ok" if all the checks succeeded, "unavailable"
+		// otherwise.
+		Status string `json:"status"`
+		// Errors lists the errors returned by the failing checks.
+		Errors []string `json:"errors,omitempty"`
+	}
+)
+
+// HealthHandler returns a HTTP handler that runs the given checkers and
+// responds with status 200 if all of them succeed or 503 otherwise. The
+// response body is a JSON object that lists the errors returned by the failing
+// checkers.
+func HealthHandler(checkers ...Checker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res := healthResponse{Status: "ok"}
+		for _, check := range checkers {
+			if err := check(r.Context()); err != nil {
+				res.Errors = append(res.Errors, err.Error())
+			}
+		}
+		status := http.StatusOK
+		if len(res.Errors) > 0 {
+			res.Status = "unavailable"
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(&res)
+	})
+}
+
+// MountHealthChecks mounts the liveness and readiness check handlers on mux
+// under the given paths. The liveness handler runs the liveness checkers and
+// the readiness handler runs the readiness checkers, see HealthHandler.
+func MountHealthChecks(mux Muxer, livenessPath, readinessPath string, liveness, readiness []Checker) {
+	mux.Handle("GET", livenessPath, HealthHandler(liveness...).ServeHTTP)
+	mux.Handle("GET", readinessPath, HealthHandler(readiness...).ServeHTTP)
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealthHandler(t *testing.T) {
+	var (
+		ok   = func(context.Context) error { return nil }
+		fail = func(context.Context) error { return errors.New("database unreachable") }
+	)
+	cases := []struct {
+		Name     string
+		Checkers []Checker
+		Status   int
+		Body     string
+	}{
+		{"no checker", nil, http.StatusOK, `{"status":"ok"}` + "\n"},
+		{"success", []Checker{ok, ok}, http.StatusOK, `{"status":"ok"}` + "\n"},
+		{"failure", []Checker{ok, fail}, http.StatusServiceUnavailable, `{"status":"unavailable","errors":["database unreachable"]}` + "\n"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			HealthHandler(c.Checkers...).ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if w.Body.String() != c.Body {
+				t.Errorf("got body %q, expected %q", w.Body.String(), c.Body)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("got content type %q, expected application/json", ct)
+			}
+		})
+	}
+}
+
+func TestMountHealthChecks(t *testing.T) {
+	fail := func(context.Context) error { return errors.New("not ready") }
+	mux := NewMuxer()
+	MountHealthChecks(mux, "/healthz", "/readyz", nil, []Checker{fail})
+	cases := []struct {
+		Path   string
+		Status int
+	}{
+		{"/healthz", http.StatusOK},
+		{"/readyz", http.StatusServiceUnavailable},
+	}
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest("GET", c.Path, nil))
+		if w.Code != c.Status {
+			t.Errorf("%s: got status %d, expected %d", c.Path, w.Code, c.Status)
+		}
+	}
+}
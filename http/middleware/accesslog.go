@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+type (
+	// AccessLogger is the logging interface used by the AccessLog
+	// middleware. It is implemented by logr.Logger, AccessLoggerFunc can be
+	// used to plug loggers with a different signature such as zap:
+	//
+	//     middleware.AccessLoggerFunc(sugar.Infow)
+	//
+	AccessLogger interface {
+		// Info creates a log entry with the given message and a
+		// sequence of alternating keys and values.
+		Info(msg string, keyvals ...interface{})
+	}
+
+	// AccessLoggerFunc is an adapter that makes it possible to use a
+	// function as AccessLogger.
+	AccessLoggerFunc func(msg string, keyvals ...interface{})
+
+	// AccessLogField identifies a field of the access log entries. The
+	// value is the key used in the entries.
+	AccessLogField string
+)
+
+const (
+	// AccessLogRoute is the design route pattern of the request, for
+	// example "GET /accounts/{id}".
+	AccessLogRoute AccessLogField = "route"
+	// AccessLogStatus is the response HTTP status code.
+	AccessLogStatus AccessLogField = "status"
+	// AccessLogLatency is the time it took to serve the request.
+	AccessLogLatency AccessLogField = "latency"
+	// AccessLogRequestSize is the length of the request body in bytes if
+	// known, -1 otherwise.
+	AccessLogRequestSize AccessLogField = "req_bytes"
+	// AccessLogResponseSize is the length of the response body in bytes.
+	AccessLogResponseSize AccessLogField = "resp_bytes"
+)
+
+// DefaultAccessLogFields lists the fields logged by AccessLog when none is
+// given.
+var DefaultAccessLogFields = []AccessLogField{
+	AccessLogRoute,
+	AccessLogStatus,
+	AccessLogLatency,
+	AccessLogRequestSize,
+	AccessLogResponseSize,
+}
+
+// Info calls f(msg, keyvals...).
+func (f AccessLoggerFunc) Info(msg string, keyvals ...interface{}) {
+	f(msg, keyvals...)
+}
+
+// AccessLog returns a middleware that logs a single entry for each request
+// made to the given endpoint once the response has been written. routes lists
+// the endpoint route patterns as defined in the design, for example
+// "GET /accounts/{id}", the entry records the first route whose HTTP method
+// matches the request. fields lists the fields recorded in the entries in
+// addition to the endpoint name, DefaultAccessLogFields is used if empty.
+//
+// The generated servers expose an AccessLog method that mounts the middleware
+// on all the endpoint handlers with the routes defined in the design.
+func AccessLog(l AccessLogger, endpoint string, routes []string, fields ...AccessLogField) func(http.Handler) http.Handler {
+	if len(fields) == 0 {
+		fields = DefaultAccessLogFields
+	}
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started := time.Now()
+			rw := CaptureResponse(w)
+			h.ServeHTTP(rw, r)
+
+			keyvals := make([]interface{}, 0, 2*len(fields)+2)
+			keyvals = append(keyvals, "endpoint", endpoint)
+			for _, f := range fields {
+				var v interface{}
+				switch f {
+				case AccessLogRoute:
+					v = matchRoute(routes, r.Method)
+				case AccessLogStatus:
+					status := rw.StatusCode
+					if status == 0 {
+						status = http.StatusOK
+					}
+					v = status
+				case AccessLogLatency:
+					v = time.Since(started)
+				case AccessLogRequestSize:
+					v = r.ContentLength
+				case AccessLogResponseSize:
+					v = rw.ContentLength
+				default:
+					continue
+				}
+				keyvals = append(keyvals, string(f), v)
+			}
+			l.Info("request served", keyvals...)
+		})
+	}
+}
+
+// matchRoute returns the first route of routes whose HTTP method is method,
+// the first route if none matches.
+func matchRoute(routes []string, method string) string {
+	if len(routes) == 0 {
+		return ""
+	}
+	for _, r := range routes {
+		if strings.HasPrefix(r, method+" ") {
+			return r
+		}
+	}
+	return routes[0]
+}
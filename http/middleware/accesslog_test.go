@@ -0,0 +1,80 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"goa.design/goa/http/middleware"
+)
+
+func TestAccessLog(t *testing.T) {
+	routes := []string{"GET /accounts/{id}", "POST /accounts"}
+	cases := []struct {
+		Name     string
+		Method   string
+		Body     string
+		Fields   []middleware.AccessLogField
+		Expected map[string]interface{}
+	}{
+		{"default", "POST", "foo", nil, map[string]interface{}{
+			"endpoint":   "Create",
+			"route":      "POST /accounts",
+			"status":     http.StatusCreated,
+			"req_bytes":  int64(3),
+			"resp_bytes": 2,
+		}},
+		{"fields", "GET", "", []middleware.AccessLogField{middleware.AccessLogRoute}, map[string]interface{}{
+			"endpoint": "Create",
+			"route":    "GET /accounts/{id}",
+		}},
+		{"unknown-method", "PUT", "", []middleware.AccessLogField{middleware.AccessLogRoute}, map[string]interface{}{
+			"endpoint": "Create",
+			"route":    "GET /accounts/{id}",
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var (
+				msg     string
+				keyvals []interface{}
+			)
+			l := middleware.AccessLoggerFunc(func(m string, kv ...interface{}) {
+				msg, keyvals = m, kv
+			})
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte("ok"))
+			})
+			req := httptest.NewRequest(c.Method, "/accounts", strings.NewReader(c.Body))
+			middleware.AccessLog(l, "Create", routes, c.Fields...)(h).ServeHTTP(httptest.NewRecorder(), req)
+
+			if msg == "" {
+				t.Fatal("no entry logged")
+			}
+			if len(keyvals)%2 != 0 {
+				t.Fatalf("got odd number of keys and values: %v", keyvals)
+			}
+			got := make(map[string]interface{}, len(keyvals)/2)
+			for i := 0; i < len(keyvals); i += 2 {
+				got[keyvals[i].(string)] = keyvals[i+1]
+			}
+			if c.Fields == nil {
+				if _, ok := got["latency"].(time.Duration); !ok {
+					t.Errorf("got latency %#v, expected a duration", got["latency"])
+				}
+				delete(got, "latency")
+			}
+			if len(got) != len(c.Expected) {
+				t.Errorf("got fields %v, expected %v", got, c.Expected)
+			}
+			for k, v := range c.Expected {
+				if got[k] != v {
+					t.Errorf("got %s %#v, expected %#v", k, got[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package prometheus
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	goahttp "goa.design/goa/http"
+	"goa.design/goa/http/middleware"
+)
+
+// Metrics holds the Prometheus collectors used to instrument the HTTP
+// handlers of the service endpoints.
+type Metrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+}
+
+// New creates the collectors recording the number of requests, the request
+// durations and the number of requests being served and registers them with
+// reg. The request count and durations are labeled by service, endpoint and
+// response status code, the number of requests in flight by service and
+// endpoint.
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "goa",
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests served.",
+		}, []string{"service", "endpoint", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "goa",
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of HTTP requests in seconds.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"service", "endpoint", "status"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "goa",
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "Number of HTTP requests being served.",
+		}, []string{"service", "endpoint"}),
+	}
+	for _, c := range []prometheus.Collector{m.requests, m.duration, m.inFlight} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Middleware returns a middleware that records the metrics of the requests
+// made to the given service endpoint. It is meant to be given to the Instrument
+// method of the generated servers:
+//
+//     metrics, err := goaprometheus.New(prometheus.DefaultRegisterer)
+//     if err != nil {
+//         return err
+//     }
+//     accountServer.Instrument(metrics.Middleware)
+//
+func (m *Metrics) Middleware(service, endpoint string) func(http.Handler) http.Handler {
+	inFlight := m.inFlight.WithLabelValues(service, endpoint)
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			inFlight.Inc()
+			defer inFlight.Dec()
+			started := time.Now()
+			rw := middleware.CaptureResponse(w)
+			h.ServeHTTP(rw, r)
+			status := rw.StatusCode
+			if status == 0 {
+				status = http.StatusOK
+			}
+			code := strconv.Itoa(status)
+			m.requests.WithLabelValues(service, endpoint, code).Inc()
+			m.duration.WithLabelValues(service, endpoint, code).Observe(time.Since(started).Seconds())
+		})
+	}
+}
+
+// Mount configures the mux to serve the metrics collected by g on GET requests
+// made to path using the Prometheus exposition format.
+func Mount(mux goahttp.Muxer, path string, g prometheus.Gatherer) {
+	mux.Handle("GET", path, promhttp.HandlerFor(g, promhttp.HandlerOpts{}).ServeHTTP)
+}
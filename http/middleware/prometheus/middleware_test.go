@@ -0,0 +1,77 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMiddleware(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := New(reg)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	cases := map[string]struct {
+		Status int
+		Label  string
+	}{
+		"implicit": {0, "200"},
+		"explicit": {http.StatusNotFound, "404"},
+	}
+	for k, c := range cases {
+		h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if c.Status != 0 {
+				w.WriteHeader(c.Status)
+			}
+		})
+		rw := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/", nil)
+		m.Middleware("svc", k)(h).ServeHTTP(rw, req)
+
+		mfs, err := reg.Gather()
+		if err != nil {
+			t.Fatalf("%s: unexpected error %s", k, err)
+		}
+		var found bool
+		for _, mf := range mfs {
+			if mf.GetName() != "goa_http_requests_total" {
+				continue
+			}
+			for _, metric := range mf.GetMetric() {
+				labels := make(map[string]string)
+				for _, l := range metric.GetLabel() {
+					labels[l.GetName()] = l.GetValue()
+				}
+				if labels["endpoint"] != k {
+					continue
+				}
+				found = true
+				if labels["service"] != "svc" {
+					t.Errorf("%s: got service label %q, expected %q", k, labels["service"], "svc")
+				}
+				if labels["status"] != c.Label {
+					t.Errorf("%s: got status label %q, expected %q", k, labels["status"], c.Label)
+				}
+				if v := metric.GetCounter().GetValue(); v != 1 {
+					t.Errorf("%s: got count %v, expected 1", k, v)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("%s: request count not recorded", k)
+		}
+	}
+}
+
+func TestNewRegistersOnce(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := New(reg); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	if _, err := New(reg); err == nil {
+		t.Error("expected error registering the collectors twice")
+	}
+}
@@ -3,6 +3,8 @@ package middleware
 import (
 	"context"
 	"net/http"
+
+	goahttp "goa.design/goa/http"
 )
 
 type (
@@ -24,7 +26,9 @@ type (
 // RequestID returns a middleware, which initializes the context with a unique
 // value under the RequestIDKey key. Optionally uses the incoming "X-Request-Id"
 // header, if present, with or without a length limit to use as request ID. the
-// default behavior is to always generate a new ID.
+// default behavior is to always generate a new ID. The ID is also stored in the
+// context using goahttp.ContextWithRequestID so that the generated servers
+// return it in the responses and the generated clients forward it.
 //
 // examples of use:
 //  service.Use(middleware.RequestID())
@@ -52,6 +56,7 @@ func RequestID(options ...RequestIDOption) func(http.Handler) http.Handler {
 				id = shortID()
 			}
 			ctx := context.WithValue(r.Context(), RequestIDKey, id)
+			ctx = goahttp.ContextWithRequestID(ctx, id)
 			h.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
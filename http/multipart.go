@@ -0,0 +1,176 @@
+package http
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"goa.design/goa"
+)
+
+type (
+	// MultipartReader streams the parts of a multipart request body and
+	// enforces limits on the size of each part and on the size of the whole
+	// request body. It wraps the *multipart.Reader returned by the request
+	// MultipartReader method so that parts are never buffered in memory.
+	MultipartReader struct {
+		*multipart.Reader
+		maxPartSize int64
+	}
+
+	// MultipartPart is a part of a multipart request body read with a
+	// MultipartReader. Reading more than the maximum part size returns an
+	// error.
+	MultipartPart struct {
+		*multipart.Part
+		max, read int64
+	}
+
+	// limitedBody wraps a request body and returns an error once more than
+	// max bytes have been read.
+	limitedBody struct {
+		io.ReadCloser
+		max, read int64
+	}
+)
+
+// NewMultipartReader returns a MultipartReader that reads the parts of the
+// multipart request body. maxPartSize is the maximum number of bytes that may
+// be read from a single part and maxSize the maximum number of bytes of the
+// whole request body, zero means there is no limit.
+func NewMultipartReader(r *http.Request, maxPartSize, maxSize int64) (*MultipartReader, error) {
+	if maxSize > 0 && r.Body != nil {
+		if r.ContentLength > maxSize {
+			return nil, ErrRequestTooLarge(maxSize)
+		}
+		r.Body = &limitedBody{ReadCloser: r.Body, max: maxSize}
+	}
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, goa.DecodePayloadError(err.Error())
+	}
+	return &MultipartReader{Reader: mr, maxPartSize: maxPartSize}, nil
+}
+
+// NextPart returns the next part of the multipart request body or io.EOF when
+// there are no more parts.
+func (r *MultipartReader) NextPart() (*MultipartPart, error) {
+	p, err := r.Reader.NextPart()
+	if err != nil {
+		return nil, err
+	}
+	return &MultipartPart{Part: p, max: r.maxPartSize}, nil
+}
+
+// Read reads the content of the part and returns an error if the content is
+// larger than the maximum part size.
+func (p *MultipartPart) Read(b []byte) (int, error) {
+	if p.max <= 0 {
+		return p.Part.Read(b)
+	}
+	if p.read > p.max {
+		return 0, ErrPartTooLarge(p.FormName(), p.FileName())
+	}
+	if int64(len(b)) > p.max-p.read+1 {
+		b = b[:p.max-p.read+1]
+	}
+	n, err := p.Part.Read(b)
+	p.read += int64(n)
+	if p.read > p.max {
+		return n - int(p.read-p.max), ErrPartTooLarge(p.FormName(), p.FileName())
+	}
+	return n, err
+}
+
+// Read reads from the request body and returns an error if more than the
+// maximum number of bytes are read.
+func (l *limitedBody) Read(b []byte) (int, error) {
+	if l.read > l.max {
+		return 0, ErrRequestTooLarge(l.max)
+	}
+	if int64(len(b)) > l.max-l.read+1 {
+		b = b[:l.max-l.read+1]
+	}
+	n, err := l.ReadCloser.Read(b)
+	l.read += int64(n)
+	if l.read > l.max {
+		return n - int(l.read-l.max), ErrRequestTooLarge(l.max)
+	}
+	return n, err
+}
+
+// ErrPartTooLarge is the error returned when a part of a multipart request
+// body is larger than the maximum part size.
+func ErrPartTooLarge(name, filename string) error {
+	if filename != "" {
+		return goa.PermanentError("part_too_large", "multipart file %q of part %q is too large", filename, name)
+	}
+	return goa.PermanentError("part_too_large", "multipart part %q is too large", name)
+}
+
+// ErrRequestTooLarge is the error returned when a multipart request body is
+// larger than the maximum request size.
+func ErrRequestTooLarge(max int64) error {
+	return goa.PermanentError("request_too_large", "request body is larger than %d bytes", max)
+}
+
+// WriteMultipartFiles writes the content of the files listed in files to new
+// parts of mw. files is a comma separated list of NAME=PATH pairs where NAME is
+// the form name of the part and PATH the path to the file. The content type of
+// each part is detected from the file extension or from the file content if
+// the extension is unknown. The generated CLI uses WriteMultipartFiles to
+// implement the "file" flag of the multipart endpoints.
+func WriteMultipartFiles(mw *multipart.Writer, files string) error {
+	for _, file := range strings.Split(files, ",") {
+		file = strings.TrimSpace(file)
+		if file == "" {
+			continue
+		}
+		idx := strings.Index(file, "=")
+		if idx < 1 {
+			return fmt.Errorf("invalid file %q, must be of the form NAME=PATH", file)
+		}
+		if err := WriteMultipartFile(mw, file[:idx], file[idx+1:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteMultipartFile writes the content of the file at the given path to a new
+// part of mw with the given form name. The content type of the part is
+// detected from the file extension or from the file content if the extension
+// is unknown. The file is streamed into the part and is never loaded in memory
+// as a whole.
+func WriteMultipartFile(mw *multipart.Writer, name, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r := bufio.NewReaderSize(f, 512)
+	ct := mime.TypeByExtension(filepath.Ext(path))
+	if ct == "" {
+		head, err := r.Peek(512)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		ct = http.DetectContentType(head)
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, name, filepath.Base(path)))
+	h.Set("Content-Type", ct)
+	w, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
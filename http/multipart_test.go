@@ -0,0 +1,131 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMultipartReader(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Parts       []string
+		MaxPartSize int64
+		MaxSize     int64
+		Error       string
+	}{
+		{"no limit", []string{"abc", "defgh"}, 0, 0, ""},
+		{"part size", []string{"abc", "defgh"}, 5, 0, ""},
+		{"part too large", []string{"abc", "defgh"}, 4, 0, `multipart part "part1" is too large`},
+		{"request too large", []string{"abc", "defgh"}, 0, 100, "request body is larger than 100 bytes"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			for i, p := range c.Parts {
+				mw.WriteField(fmt.Sprintf("part%d", i), p)
+			}
+			mw.Close()
+			r := httptest.NewRequest("POST", "/", &body)
+			r.ContentLength = -1
+			r.Header.Set("Content-Type", mw.FormDataContentType())
+
+			mr, err := NewMultipartReader(r, c.MaxPartSize, c.MaxSize)
+			if err == nil {
+				for i := 0; err == nil && i < len(c.Parts); i++ {
+					var p *MultipartPart
+					p, err = mr.NextPart()
+					if err != nil {
+						break
+					}
+					var b []byte
+					b, err = ioutil.ReadAll(p)
+					if err == nil && string(b) != c.Parts[i] {
+						t.Errorf("got part %q, expected %q", string(b), c.Parts[i])
+					}
+				}
+			}
+			if c.Error == "" {
+				if err != nil {
+					t.Errorf("unexpected error %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), c.Error) {
+				t.Errorf("got error %v, expected %q", err, c.Error)
+			}
+		})
+	}
+}
+
+func TestWriteMultipartFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "goa-multipart")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var (
+		jsonFile = filepath.Join(dir, "doc.json")
+		textFile = filepath.Join(dir, "notes")
+	)
+	if err := ioutil.WriteFile(jsonFile, []byte(`{"a":1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(textFile, []byte("some notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		Name         string
+		Files        string
+		ContentTypes []string
+		Contents     []string
+		Error        string
+	}{
+		{"json", "doc=" + jsonFile, []string{"application/json"}, []string{`{"a":1}`}, ""},
+		{"detected", "notes=" + textFile, []string{"text/plain; charset=utf-8"}, []string{"some notes"}, ""},
+		{"multiple", "doc=" + jsonFile + ", notes=" + textFile, []string{"application/json", "text/plain; charset=utf-8"}, []string{`{"a":1}`, "some notes"}, ""},
+		{"invalid", jsonFile, nil, nil, "must be of the form NAME=PATH"},
+		{"missing", "doc=" + filepath.Join(dir, "missing"), nil, nil, "no such file"},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var body bytes.Buffer
+			mw := multipart.NewWriter(&body)
+			err := WriteMultipartFiles(mw, c.Files)
+			mw.Close()
+			if c.Error != "" {
+				if err == nil || !strings.Contains(err.Error(), c.Error) {
+					t.Errorf("got error %v, expected %q", err, c.Error)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			mr := multipart.NewReader(&body, mw.Boundary())
+			for i, ct := range c.ContentTypes {
+				p, err := mr.NextPart()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := p.Header.Get("Content-Type"); got != ct {
+					t.Errorf("got content type %q, expected %q", got, ct)
+				}
+				b, err := ioutil.ReadAll(p)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(b) != c.Contents[i] {
+					t.Errorf("got content %q, expected %q", string(b), c.Contents[i])
+				}
+			}
+		})
+	}
+}
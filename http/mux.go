@@ -3,6 +3,8 @@ package http
 import (
 	"net/http"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/dimfeld/httptreemux"
 )
@@ -29,7 +31,9 @@ type (
 	//     example the pattern "/images/{*filename}" captures
 	//     "/images/public/thumbnail.jpg" and associates the key key
 	//     "filename" with "public/thumbnail.jpg" in the map returned by
-	//     Vars.
+	//     Vars. The captured value does not include the slash that
+	//     precedes the wildcard. Static segments and "{name}" wildcards
+	//     take precedence over "{*name}" wildcards.
 	//
 	// The names of wildcards must match the regular expression
 	// "[a-zA-Z0-9_]+".
@@ -58,9 +62,22 @@ type (
 )
 
 // NewMuxer returns a Muxer implementation based on the httptreemux router.
+// Requests whose path matches a registered pattern but whose method does not
+// are answered with 405 Method Not Allowed and the Allow header listing the
+// methods registered for the pattern. OPTIONS requests are answered with 200
+// OK and the Allow header unless an OPTIONS handler is registered for the
+// pattern. HEAD requests are served by the GET handler unless a HEAD handler is
+// registered for the pattern.
 func NewMuxer() Muxer {
 	r := httptreemux.NewContextMux()
 	r.EscapeAddedRoutes = true
+	r.MethodNotAllowedHandler = func(w http.ResponseWriter, r *http.Request, methods map[string]httptreemux.HandlerFunc) {
+		allowed := make([]string, 0, len(methods))
+		for m := range methods {
+			allowed = append(allowed, m)
+		}
+		methodNotAllowed(w, r, allowed)
+	}
 	return &mux{r}
 }
 
@@ -82,3 +99,36 @@ func treemuxify(pattern string) string {
 	pattern = wildPath.ReplaceAllString(pattern, "/*$1")
 	return pattern
 }
+
+// methodNotAllowed writes the response to a request whose path matches a
+// registered pattern but whose method does not. allowed lists the methods
+// registered for the pattern. OPTIONS requests are answered with 200 OK and
+// the other requests with 405 Method Not Allowed, both responses set the Allow
+// header to the list of allowed methods.
+func methodNotAllowed(w http.ResponseWriter, r *http.Request, allowed []string) {
+	var hasGet, hasHead, hasOptions bool
+	for _, m := range allowed {
+		switch m {
+		case "GET":
+			hasGet = true
+		case "HEAD":
+			hasHead = true
+		case "OPTIONS":
+			hasOptions = true
+		}
+	}
+	allowed = allowed[:len(allowed):len(allowed)]
+	if hasGet && !hasHead {
+		allowed = append(allowed, "HEAD")
+	}
+	if !hasOptions {
+		allowed = append(allowed, "OPTIONS")
+	}
+	sort.Strings(allowed)
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	if r.Method == "OPTIONS" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,76 @@
+/*
+Package chimux provides a goa Muxer implementation that mounts the generated
+HTTP servers on a chi router (https://github.com/go-chi/chi) so that goa
+services can be embedded in existing chi applications:
+
+	r := chi.NewRouter()
+	mux := chimux.New(r)
+	server.Mount(mux, srv)
+	http.ListenAndServe(":8080", r)
+*/
+package chimux
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/go-chi/chi"
+	goahttp "goa.design/goa/http"
+)
+
+type (
+	// mux is the chi Muxer implementation.
+	mux struct {
+		chi.Router
+	}
+
+	// catchAllKey is the request context key used to store the name of
+	// the "{*name}" wildcard of the matched route.
+	catchAllKey struct{}
+)
+
+// New returns a Muxer that registers the handlers with the given chi router.
+func New(r chi.Router) goahttp.Muxer {
+	return &mux{r}
+}
+
+// Handle registers the handler with the chi router, mapping the goa "{*name}"
+// wildcard to the chi "*" wildcard.
+func (m *mux) Handle(method, pattern string, handler http.HandlerFunc) {
+	if match := wildPath.FindStringSubmatch(pattern); match != nil {
+		name, h := match[1], handler
+		handler = func(w http.ResponseWriter, r *http.Request) {
+			h(w, r.WithContext(context.WithValue(r.Context(), catchAllKey{}, name)))
+		}
+	}
+	m.Router.MethodFunc(method, chify(pattern), handler)
+}
+
+// Vars returns the path variables captured by chi for the given request.
+func (m *mux) Vars(r *http.Request) map[string]string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return nil
+	}
+	params := rctx.URLParams
+	vars := make(map[string]string, len(params.Keys))
+	for i, k := range params.Keys {
+		if k == "*" {
+			name, ok := r.Context().Value(catchAllKey{}).(string)
+			if !ok {
+				continue
+			}
+			k = name
+		}
+		vars[k] = params.Values[i]
+	}
+	return vars
+}
+
+var wildPath = regexp.MustCompile(`/{\*([a-zA-Z0-9_]+)}`)
+
+// chify maps the wildcard format used by goa to the one used by chi.
+func chify(pattern string) string {
+	return wildPath.ReplaceAllString(pattern, "/*")
+}
@@ -0,0 +1,76 @@
+/*
+Package echomux provides a goa Muxer implementation that mounts the generated
+HTTP servers on an echo instance (https://github.com/labstack/echo) so that goa
+services can be embedded in existing echo applications:
+
+	e := echo.New()
+	mux := echomux.New(e)
+	server.Mount(mux, srv)
+	e.Start(":8080")
+*/
+package echomux
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/labstack/echo"
+	goahttp "goa.design/goa/http"
+)
+
+type (
+	// mux is the echo Muxer implementation.
+	mux struct {
+		*echo.Echo
+	}
+
+	// varsKey is the request context key used to store the path
+	// variables.
+	varsKey struct{}
+)
+
+// New returns a Muxer that registers the handlers with the given echo
+// instance.
+func New(e *echo.Echo) goahttp.Muxer {
+	return &mux{e}
+}
+
+// Handle registers the handler with echo, mapping the goa "{name}" and
+// "{*name}" wildcards to the echo ":name" and "*" wildcards. The handler
+// request context holds the captured path variables.
+func (m *mux) Handle(method, pattern string, handler http.HandlerFunc) {
+	var catchAll string
+	if match := wildPath.FindStringSubmatch(pattern); match != nil {
+		catchAll = match[1]
+	}
+	m.Echo.Add(method, echoify(pattern), func(c echo.Context) error {
+		names, values := c.ParamNames(), c.ParamValues()
+		vars := make(map[string]string, len(names))
+		for i, name := range names {
+			if name == "*" {
+				name = catchAll
+			}
+			vars[name] = values[i]
+		}
+		r := c.Request()
+		handler(c.Response(), r.WithContext(context.WithValue(r.Context(), varsKey{}, vars)))
+		return nil
+	})
+}
+
+// Vars returns the path variables captured by echo for the given request.
+func (m *mux) Vars(r *http.Request) map[string]string {
+	vars, _ := r.Context().Value(varsKey{}).(map[string]string)
+	return vars
+}
+
+var wildSeg = regexp.MustCompile(`/{([a-zA-Z0-9_]+)}`)
+var wildPath = regexp.MustCompile(`/{\*([a-zA-Z0-9_]+)}`)
+
+// echoify maps the wildcard format used by goa to the one used by echo.
+func echoify(pattern string) string {
+	pattern = wildSeg.ReplaceAllString(pattern, "/:$1")
+	pattern = wildPath.ReplaceAllString(pattern, "/*")
+	return pattern
+}
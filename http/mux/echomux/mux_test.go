@@ -0,0 +1,53 @@
+package echomux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/labstack/echo"
+)
+
+func TestEchoify(t *testing.T) {
+	cases := []struct{ Name, Pattern, Expected string }{
+		{"no capture", "/a/b", "/a/b"},
+		{"segment", "/a/{b}", "/a/:b"},
+		{"path", "/a/{*b}", "/a/*"},
+		{"segment and path", "/{a}/{*b}", "/:a/*"},
+	}
+	for _, c := range cases {
+		if actual := echoify(c.Pattern); actual != c.Expected {
+			t.Errorf("%s: got %q, expected %q", c.Name, actual, c.Expected)
+		}
+	}
+}
+
+func TestMux(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Pattern string
+		URL     string
+		Vars    map[string]string
+	}{
+		{"segment", "/users/{id}", "/users/42", map[string]string{"id": "42"}},
+		{"path", "/files/{dir}/{*path}", "/files/public/a/b.txt", map[string]string{"dir": "public", "path": "a/b.txt"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var vars map[string]string
+			m := New(echo.New())
+			m.Handle("GET", c.Pattern, func(w http.ResponseWriter, r *http.Request) {
+				vars = m.Vars(r)
+			})
+			w := httptest.NewRecorder()
+			m.ServeHTTP(w, httptest.NewRequest("GET", c.URL, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("got status %d, expected %d", w.Code, http.StatusOK)
+			}
+			if !reflect.DeepEqual(vars, c.Vars) {
+				t.Errorf("got vars %v, expected %v", vars, c.Vars)
+			}
+		})
+	}
+}
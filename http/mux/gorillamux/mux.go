@@ -0,0 +1,48 @@
+/*
+Package gorillamux provides a goa Muxer implementation that mounts the
+generated HTTP servers on a gorilla router (https://github.com/gorilla/mux) so
+that goa services can be embedded in existing gorilla applications:
+
+	r := mux.NewRouter()
+	m := gorillamux.New(r)
+	server.Mount(m, srv)
+	http.ListenAndServe(":8080", r)
+*/
+package gorillamux
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/gorilla/mux"
+	goahttp "goa.design/goa/http"
+)
+
+// router is the gorilla Muxer implementation.
+type router struct {
+	*mux.Router
+}
+
+// New returns a Muxer that registers the handlers with the given gorilla
+// router.
+func New(r *mux.Router) goahttp.Muxer {
+	return &router{r}
+}
+
+// Handle registers the handler with the gorilla router, mapping the goa
+// "{*name}" wildcard to a gorilla "{name:.*}" variable.
+func (m *router) Handle(method, pattern string, handler http.HandlerFunc) {
+	m.Router.HandleFunc(gorillify(pattern), handler).Methods(method)
+}
+
+// Vars returns the path variables captured by gorilla for the given request.
+func (m *router) Vars(r *http.Request) map[string]string {
+	return mux.Vars(r)
+}
+
+var wildPath = regexp.MustCompile(`/{\*([a-zA-Z0-9_]+)}`)
+
+// gorillify maps the wildcard format used by goa to the one used by gorilla.
+func gorillify(pattern string) string {
+	return wildPath.ReplaceAllString(pattern, "/{$1:.*}")
+}
@@ -1,6 +1,10 @@
 package http
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
 
 func TestMuxRegexp(t *testing.T) {
 	cases := []struct{ Name, Pattern, Expected string }{
@@ -24,3 +28,67 @@ func TestMuxRegexp(t *testing.T) {
 		}
 	}
 }
+
+func TestMuxCatchAll(t *testing.T) {
+	cases := []struct{ Name, URL, Expected string }{
+		{"path", "/files/a/b.txt", "a/b.txt"},
+		{"segment", "/files/a", "a"},
+	}
+	mux := NewMuxer()
+	var vars map[string]string
+	mux.Handle("GET", "/files/{*path}", func(w http.ResponseWriter, r *http.Request) {
+		vars = mux.Vars(r)
+	})
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			vars = nil
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest("GET", c.URL, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("got status %d, expected %d", w.Code, http.StatusOK)
+			}
+			if actual, ok := vars["path"]; !ok || actual != c.Expected {
+				t.Errorf("got path %q, expected %q", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Method string
+		Status int
+		Allow  string
+	}{
+		{"first service", "GET", http.StatusOK, ""},
+		{"second service", "POST", http.StatusOK, ""},
+		{"head", "HEAD", http.StatusOK, ""},
+		{"not allowed", "DELETE", http.StatusMethodNotAllowed, "GET, HEAD, OPTIONS, POST"},
+		{"options", "OPTIONS", http.StatusOK, "GET, HEAD, OPTIONS, POST"},
+	}
+	muxers := map[string]func() Muxer{
+		"default": NewMuxer,
+		"route":   func() Muxer { return NewRouteMuxer() },
+	}
+	for name, newMux := range muxers {
+		t.Run(name, func(t *testing.T) {
+			// Mount the handlers of two services sharing the same path.
+			mux := newMux()
+			mux.Handle("GET", "/items/{id}", func(w http.ResponseWriter, r *http.Request) {})
+			mux.Handle("POST", "/items/{id}", func(w http.ResponseWriter, r *http.Request) {})
+			for _, c := range cases {
+				t.Run(c.Name, func(t *testing.T) {
+					w := httptest.NewRecorder()
+					mux.ServeHTTP(w, httptest.NewRequest(c.Method, "/items/1", nil))
+					if w.Code != c.Status {
+						t.Errorf("got status %d, expected %d", w.Code, c.Status)
+					}
+					if allow := w.Header().Get("Allow"); allow != c.Allow {
+						t.Errorf("got Allow header %q, expected %q", allow, c.Allow)
+					}
+				})
+			}
+		})
+	}
+}
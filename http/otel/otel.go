@@ -0,0 +1,90 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer used to create the spans.
+const TracerName = "goa.design/goa/http/otel"
+
+// propagator reads and writes the W3C trace context headers.
+var propagator = propagation.TraceContext{}
+
+// StartServerSpan starts a server span with the given name. The span is a
+// child of the span described by the W3C trace context headers of r if any.
+func StartServerSpan(ctx context.Context, r *http.Request, name string) (context.Context, trace.Span) {
+	ctx = propagator.Extract(ctx, propagation.HeaderCarrier(r.Header))
+	return otel.Tracer(TracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("http.method", r.Method),
+			attribute.String("http.target", r.URL.Path),
+		))
+}
+
+// StartClientSpan starts a client span with the given name as a child of the
+// span stored in ctx if any.
+func StartClientSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+}
+
+// Inject writes the W3C trace context headers describing the span stored in
+// ctx to req.
+func Inject(ctx context.Context, req *http.Request) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// SetAttribute records v as the value of the span attribute with the given
+// key. Pointers are dereferenced, nil values are not recorded and values that
+// are not booleans, numbers or strings are recorded using their default string
+// representation.
+func SetAttribute(span trace.Span, key string, v interface{}) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+	var kv attribute.KeyValue
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return
+	case reflect.Bool:
+		kv = attribute.Bool(key, rv.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		kv = attribute.Int64(key, rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		kv = attribute.Int64(key, int64(rv.Uint()))
+	case reflect.Float32, reflect.Float64:
+		kv = attribute.Float64(key, rv.Float())
+	case reflect.String:
+		kv = attribute.String(key, rv.String())
+	default:
+		kv = attribute.String(key, fmt.Sprint(rv.Interface()))
+	}
+	span.SetAttributes(kv)
+}
+
+// RecordError records err on span and sets the span status to error.
+func RecordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// EndSpan records err on span if not nil and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		RecordError(span, err)
+	}
+	span.End()
+}
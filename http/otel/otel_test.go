@@ -0,0 +1,90 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestPropagation(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+
+	ctx, cspan := StartClientSpan(context.Background(), "svc.method")
+	req, _ := http.NewRequest("GET", "http://example.com/foo", nil)
+	Inject(ctx, req)
+	if req.Header.Get("traceparent") == "" {
+		t.Fatal("traceparent header not set")
+	}
+	_, sspan := StartServerSpan(context.Background(), req, "svc.method")
+	sspan.End()
+	EndSpan(cspan, errors.New("boom"))
+
+	spans := sr.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, expected 2", len(spans))
+	}
+	server, client := spans[0], spans[1]
+	if server.Name() != "svc.method" {
+		t.Errorf("got span name %q, expected %q", server.Name(), "svc.method")
+	}
+	if server.SpanContext().TraceID() != client.SpanContext().TraceID() {
+		t.Errorf("got trace ID %s, expected %s", server.SpanContext().TraceID(), client.SpanContext().TraceID())
+	}
+	if server.Parent().SpanID() != client.SpanContext().SpanID() {
+		t.Errorf("got parent span ID %s, expected %s", server.Parent().SpanID(), client.SpanContext().SpanID())
+	}
+	if client.Status().Code != codes.Error {
+		t.Errorf("got client span status %v, expected %v", client.Status().Code, codes.Error)
+	}
+}
+
+func TestSetAttribute(t *testing.T) {
+	var (
+		i    = 42
+		nilp *string
+	)
+	cases := []struct {
+		Name     string
+		Value    interface{}
+		Expected *attribute.KeyValue
+	}{
+		{"string", "foo", &attribute.KeyValue{Key: "string", Value: attribute.StringValue("foo")}},
+		{"pointer", &i, &attribute.KeyValue{Key: "pointer", Value: attribute.Int64Value(42)}},
+		{"bool", true, &attribute.KeyValue{Key: "bool", Value: attribute.BoolValue(true)}},
+		{"float", 1.5, &attribute.KeyValue{Key: "float", Value: attribute.Float64Value(1.5)}},
+		{"slice", []string{"a", "b"}, &attribute.KeyValue{Key: "slice", Value: attribute.StringValue("[a b]")}},
+		{"nil pointer", nilp, nil},
+		{"nil", nil, nil},
+	}
+	sr := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)).Tracer("test")
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			_, span := tracer.Start(context.Background(), c.Name)
+			SetAttribute(span, c.Name, c.Value)
+			span.End()
+			spans := sr.Ended()
+			attrs := spans[len(spans)-1].Attributes()
+			if c.Expected == nil {
+				if len(attrs) != 0 {
+					t.Errorf("got attributes %v, expected none", attrs)
+				}
+				return
+			}
+			if len(attrs) != 1 {
+				t.Fatalf("got %d attributes, expected 1", len(attrs))
+			}
+			if attrs[0] != *c.Expected {
+				t.Errorf("got attribute %v, expected %v", attrs[0], *c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"goa.design/goa"
+)
+
+// ProblemContentType is the content type of the RFC 7807 problem details
+// documents.
+const ProblemContentType = "application/problem+json"
+
+// Problem is the RFC 7807 problem details document written by the generated
+// error encoders when the "http:error:problem" metadata is set on the API.
+type Problem struct {
+	// Type is a URI reference that identifies the problem type. The
+	// generated code uses the name of the error.
+	Type string `json:"type,omitempty"`
+	// Title is a short summary of the problem type, the generated code
+	// uses the text of the HTTP status code.
+	Title string `json:"title,omitempty"`
+	// Status is the HTTP status code.
+	Status int `json:"status,omitempty"`
+	// Detail is an explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Instance is a URI reference that identifies the specific occurrence
+	// of the problem.
+	Instance string `json:"instance,omitempty"`
+	// Errors lists the individual problems when the error aggregates
+	// multiple errors, see goa.AggregateErrors.
+	Errors []*Problem `json:"errors,omitempty"`
+}
+
+// NewProblem creates a problem details document for the error err with the
+// given name and HTTP status code.
+func NewProblem(name string, status int, err error) *Problem {
+	p := &Problem{
+		Type:   name,
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if err != nil {
+		p.Detail = err.Error()
+	}
+	if gerr, ok := err.(*goa.ServiceError); ok {
+		p.Instance = gerr.ID
+		for _, e := range gerr.Errors {
+			p.Errors = append(p.Errors, NewProblem(e.Name, status, e))
+		}
+	}
+	return p
+}
+
+// Error returns the problem detail or its title if there is no detail.
+func (p *Problem) Error() string {
+	if p.Detail != "" {
+		return p.Detail
+	}
+	return p.Title
+}
+
+// ErrorName returns the problem type.
+func (p *Problem) ErrorName() string { return p.Type }
+
+// EncodeProblem writes the problem details document to w using the problem
+// status as HTTP response status code.
+func EncodeProblem(w http.ResponseWriter, p *Problem) error {
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
+
+// DecodeProblem reads the problem details document from the body of a
+// response returned by the given service method and returns it as an error.
+func DecodeProblem(svc, m string, resp *http.Response) error {
+	var p Problem
+	if err := json.NewDecoder(resp.Body).Decode(&p); err != nil {
+		return ErrDecodingError(svc, m, err)
+	}
+	if p.Status == 0 {
+		p.Status = resp.StatusCode
+	}
+	return &p
+}
+
+// ProblemErrorEncoder returns an encoder that encodes the errors returned by
+// service methods as RFC 7807 problem details documents. The HTTP status code
+// is computed from the error like ErrorEncoder does.
+func ProblemErrorEncoder() func(context.Context, http.ResponseWriter, error) error {
+	return func(ctx context.Context, w http.ResponseWriter, err error) error {
+		if _, ok := err.(*goa.ServiceError); !ok {
+			err = goa.Fault("%s", err.Error())
+		}
+		resp := NewErrorResponse(err)
+		return EncodeProblem(w, NewProblem(resp.Name, resp.StatusCode(), err))
+	}
+}
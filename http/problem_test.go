@@ -0,0 +1,59 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"goa.design/goa"
+)
+
+func TestProblemErrorEncoder(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Err    error
+		Type   string
+		Status int
+		Errors int
+	}{
+		{"service-error", goa.MissingFieldError("a", "body"), "missing_field", http.StatusBadRequest, 0},
+		{"merged-errors", goa.MergeErrors(goa.MissingFieldError("a", "body"), goa.MissingFieldError("b", "body")), "missing_field", http.StatusBadRequest, 0},
+		{"aggregated-errors", goa.AggregateErrors(goa.MergeErrors(goa.MissingFieldError("a", "body"), goa.MissingFieldError("b", "body"))), "missing_field", http.StatusBadRequest, 2},
+		{"error", errors.New("boom"), "fault", http.StatusInternalServerError, 0},
+		{"body-too-large", ErrRequestBodyTooLarge(512), "request_body_too_large", http.StatusRequestEntityTooLarge, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			if err := ProblemErrorEncoder()(context.Background(), w, c.Err); err != nil {
+				t.Fatal(err)
+			}
+			if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+				t.Errorf("got content type %q, expected %q", ct, ProblemContentType)
+			}
+			resp := w.Result()
+			err := DecodeProblem("svc", "method", resp)
+			p, ok := err.(*Problem)
+			if !ok {
+				t.Fatalf("got error %#v, expected a problem", err)
+			}
+			if p.Type != c.Type {
+				t.Errorf("got type %q, expected %q", p.Type, c.Type)
+			}
+			if p.Status != c.Status || resp.StatusCode != c.Status {
+				t.Errorf("got status %d (response %d), expected %d", p.Status, resp.StatusCode, c.Status)
+			}
+			if p.Title != http.StatusText(c.Status) {
+				t.Errorf("got title %q, expected %q", p.Title, http.StatusText(c.Status))
+			}
+			if p.Detail != c.Err.Error() {
+				t.Errorf("got detail %q, expected %q", p.Detail, c.Err.Error())
+			}
+			if len(p.Errors) != c.Errors {
+				t.Errorf("got %d errors, expected %d", len(p.Errors), c.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package http
+
+import (
+	"net/url"
+	"strings"
+)
+
+// SplitQueryParam returns the values of the query string parameter with the
+// given name split with sep. It returns nil if the query string does not
+// include the parameter. The generated server code uses SplitQueryParam to
+// decode array parameters that use the "pipeDelimited" or "spaceDelimited"
+// styles, for example "tags=a|b|c".
+func SplitQueryParam(q url.Values, name, sep string) []string {
+	vs, ok := q[name]
+	if !ok {
+		return nil
+	}
+	res := []string{}
+	for _, v := range vs {
+		if v == "" {
+			continue
+		}
+		res = append(res, strings.Split(v, sep)...)
+	}
+	return res
+}
+
+// DeepObjectQueryParam returns the values of the query string parameters that
+// encode the keys of the parameter with the given name using the "deepObject"
+// style, for example "filter[color]=red&filter[size]=xl", indexed by key. It
+// returns nil if the query string does not include any such parameter.
+func DeepObjectQueryParam(q url.Values, name string) url.Values {
+	var res url.Values
+	prefix := name + "["
+	for k, vs := range q {
+		if !strings.HasPrefix(k, prefix) || !strings.HasSuffix(k, "]") {
+			continue
+		}
+		if res == nil {
+			res = make(url.Values)
+		}
+		key := k[len(prefix) : len(k)-1]
+		res[key] = append(res[key], vs...)
+	}
+	return res
+}
+
+// DeepObjectQueryKey returns the name of the query string parameter that
+// encodes the given key of the parameter with the given name using the
+// "deepObject" style.
+func DeepObjectQueryKey(name, key string) string {
+	return name + "[" + key + "]"
+}
@@ -0,0 +1,61 @@
+package http
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestSplitQueryParam(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Query    string
+		Sep      string
+		Expected []string
+	}{
+		{"missing", "other=a", "|", nil},
+		{"empty", "q=", "|", []string{}},
+		{"pipes", "q=a|b|c", "|", []string{"a", "b", "c"}},
+		{"spaces", "q=a%20b+c", " ", []string{"a", "b", "c"}},
+		{"repeated", "q=a|b&q=c", "|", []string{"a", "b", "c"}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			q, err := url.ParseQuery(c.Query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := SplitQueryParam(q, "q", c.Sep)
+			if !reflect.DeepEqual(actual, c.Expected) {
+				t.Errorf("got %#v, expected %#v", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestDeepObjectQueryParam(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Query    string
+		Expected url.Values
+	}{
+		{"missing", "filter=a&other[k]=v", nil},
+		{"keys", "filter[color]=red&filter[size]=xl&filter[size]=l", url.Values{"color": {"red"}, "size": {"xl", "l"}}},
+		{"encoded", "filter%5Bcolor%5D=red", url.Values{"color": {"red"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			q, err := url.ParseQuery(c.Query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := DeepObjectQueryParam(q, "filter")
+			if !reflect.DeepEqual(actual, c.Expected) {
+				t.Errorf("got %#v, expected %#v", actual, c.Expected)
+			}
+		})
+	}
+	if k := DeepObjectQueryKey("filter", "color"); k != "filter[color]" {
+		t.Errorf("got key %q, expected %q", k, "filter[color]")
+	}
+}
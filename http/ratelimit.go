@@ -0,0 +1,86 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	// RateLimiter is the rate limiter interface invoked by the generated
+	// servers before handling requests made to methods that define a rate
+	// limit in the design. Use the UseRateLimiter method of the generated
+	// servers to install a rate limiter, adapters for distributed stores
+	// only need to implement this interface.
+	RateLimiter interface {
+		// Allow reports whether the request made to the given service
+		// method may be handled given the method accepts at most n
+		// requests per period. If not, Allow also returns the duration
+		// after which the client may retry.
+		Allow(r *http.Request, service, method string, n int, per time.Duration) (bool, time.Duration)
+	}
+
+	// rateLimiter is the default RateLimiter implementation.
+	rateLimiter struct {
+		mu      sync.Mutex
+		windows map[string]*window
+	}
+
+	// window is the state of the rate limiter for a single method.
+	window struct {
+		// start is the start time of the current window.
+		start time.Time
+		// count is the number of requests accepted in the current
+		// window.
+		count int
+	}
+)
+
+// NewRateLimiter returns an in-memory RateLimiter that counts the requests
+// made to each method in fixed windows of the method period. The limits apply
+// to all the clients of the server process.
+func NewRateLimiter() RateLimiter {
+	return &rateLimiter{windows: make(map[string]*window)}
+}
+
+// RateLimit returns a middleware that invokes l before handling requests made
+// to the given service method. Requests that are not allowed receive a 429 Too
+// Many Requests response with a Retry-After header. The generated servers use
+// RateLimit to implement UseRateLimiter.
+func RateLimit(l RateLimiter, service, method string, n int, per time.Duration) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ok, retry := l.Allow(r, service, method, n, per)
+			if !ok {
+				secs := int((retry + time.Second - 1) / time.Second)
+				if secs < 1 {
+					secs = 1
+				}
+				w.Header().Set("Retry-After", strconv.Itoa(secs))
+				http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+				return
+			}
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Allow accepts the request if fewer than n requests were accepted in the
+// current window of the method.
+func (l *rateLimiter) Allow(_ *http.Request, service, method string, n int, per time.Duration) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	key := service + "#" + method
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= per {
+		w = &window{start: now}
+		l.windows[key] = w
+	}
+	if w.count >= n {
+		return false, w.start.Add(per).Sub(now)
+	}
+	w.count++
+	return true, 0
+}
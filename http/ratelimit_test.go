@@ -0,0 +1,47 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	var calls int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { calls++ })
+	l := NewRateLimiter()
+	rl := RateLimit(l, "svc", "method", 2, 20*time.Millisecond)(h)
+	serve := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		rl.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+		return w
+	}
+
+	serve()
+	serve()
+	w := serve()
+	if calls != 2 {
+		t.Fatalf("got %d calls, expected 2", calls)
+	}
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusTooManyRequests)
+	}
+	if ra := w.Header().Get("Retry-After"); ra != "1" {
+		t.Errorf("got Retry-After %q, expected \"1\"", ra)
+	}
+
+	// Other methods have their own window.
+	RateLimit(l, "svc", "other", 2, 20*time.Millisecond)(h).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if calls != 3 {
+		t.Fatalf("got %d calls, expected 3", calls)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if w := serve(); w.Code != http.StatusOK {
+		t.Errorf("got status %d after window, expected %d", w.Code, http.StatusOK)
+	}
+	if calls != 4 {
+		t.Errorf("got %d calls, expected 4", calls)
+	}
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"runtime/debug"
+	"sync"
+
+	"goa.design/goa"
+)
+
+// PanicHook is a function called with the value recovered from a panic in a
+// generated handler and the stack trace of the goroutine that panicked.
+type PanicHook func(ctx context.Context, rec interface{}, stack []byte)
+
+var (
+	// panicHook is the hook registered with OnPanic if any.
+	panicHook PanicHook
+	// panicHookMu protects panicHook.
+	panicHookMu sync.RWMutex
+)
+
+// OnPanic registers a function called whenever a generated handler recovers
+// from a panic, for example to log the panic or report it to an error tracking
+// service. The stack trace is only captured if a hook is registered. OnPanic
+// replaces the hook registered previously if any, nil removes it.
+func OnPanic(h PanicHook) {
+	panicHookMu.Lock()
+	defer panicHookMu.Unlock()
+	panicHook = h
+}
+
+// Recover recovers from a panic in the handler that defers it and writes an
+// error response using encodeError. The error is a goa.ServiceError with the
+// given name and the Fault field set so that the generated error encoders
+// produce the 500 response defined in the design for that error if any. eh
+// is called if the error response cannot be written. Recover must be deferred
+// directly:
+//
+//    defer goahttp.Recover(ctx, w, "internal", encodeError, eh)
+//
+// Panics with the value http.ErrAbortHandler are not recovered so that the
+// server aborts the response as documented in the net/http package.
+func Recover(ctx context.Context, w http.ResponseWriter, name string, encodeError func(context.Context, http.ResponseWriter, error) error, eh func(context.Context, http.ResponseWriter, error)) {
+	rec := recover()
+	if rec == nil {
+		return
+	}
+	if rec == http.ErrAbortHandler {
+		panic(rec)
+	}
+	panicHookMu.RLock()
+	hook := panicHook
+	panicHookMu.RUnlock()
+	if hook != nil {
+		hook(ctx, rec, debug.Stack())
+	}
+	err := &goa.ServiceError{
+		Name:    name,
+		ID:      goa.NewErrorID(),
+		Message: "internal server error",
+		Fault:   true,
+	}
+	if err := encodeError(ctx, w, err); err != nil {
+		eh(ctx, w, err)
+	}
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"goa.design/goa"
+)
+
+func TestRecover(t *testing.T) {
+	var (
+		encoded error
+		hookRec interface{}
+		stack   []byte
+	)
+	OnPanic(func(_ context.Context, rec interface{}, s []byte) {
+		hookRec, stack = rec, s
+	})
+	defer OnPanic(nil)
+	encodeError := func(ctx context.Context, w http.ResponseWriter, err error) error {
+		encoded = err
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil
+	}
+	eh := func(context.Context, http.ResponseWriter, error) {
+		t.Error("unexpected call to error handler")
+	}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer Recover(r.Context(), w, "internal", encodeError, eh)
+		panic("boom")
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("got status %d, expected %d", w.Code, http.StatusInternalServerError)
+	}
+	serr, ok := encoded.(*goa.ServiceError)
+	if !ok {
+		t.Fatalf("got error %#v, expected a *goa.ServiceError", encoded)
+	}
+	if serr.Name != "internal" || !serr.Fault {
+		t.Errorf("got error name %q and fault %v, expected %q and true", serr.Name, serr.Fault, "internal")
+	}
+	if hookRec != "boom" {
+		t.Errorf("got recovered value %#v, expected %q", hookRec, "boom")
+	}
+	if !strings.Contains(string(stack), "TestRecover") {
+		t.Errorf("stack does not contain the panicking function:\n%s", stack)
+	}
+}
+
+func TestRecoverAbortHandler(t *testing.T) {
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler {
+			t.Errorf("got %#v, expected http.ErrAbortHandler to be re-panicked", rec)
+		}
+	}()
+	encodeError := func(context.Context, http.ResponseWriter, error) error {
+		t.Error("unexpected call to error encoder")
+		return nil
+	}
+	func() {
+		defer Recover(context.Background(), httptest.NewRecorder(), "fault", encodeError, nil)
+		panic(http.ErrAbortHandler)
+	}()
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"net/http"
+)
+
+const (
+	// RequestIDHeader is the name of the HTTP header holding the request
+	// ID.
+	RequestIDHeader = "X-Request-Id"
+
+	// maxRequestIDLength is the maximum length of the request IDs read
+	// from the request headers, longer IDs are truncated.
+	maxRequestIDLength = 128
+)
+
+// RequestIDHandler returns a handler that stores the ID of the request in the
+// context under RequestIDKey and writes it to the response X-Request-Id header
+// before calling h. The ID is read from the context if already set, from the
+// request X-Request-Id header otherwise. A new ID is generated if neither is
+// set. The generated servers mount all the endpoint handlers with
+// RequestIDHandler.
+func RequestIDHandler(h http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id := RequestID(ctx)
+		if id == "" {
+			id = r.Header.Get(RequestIDHeader)
+			if len(id) > maxRequestIDLength {
+				id = id[:maxRequestIDLength]
+			}
+			if id == "" {
+				id = newRequestID()
+			}
+			ctx = context.WithValue(ctx, RequestIDKey, id)
+		}
+		w.Header().Set(RequestIDHeader, id)
+		h.ServeHTTP(w, r.WithContext(ctx))
+	}
+}
+
+// ContextWithRequestID returns a copy of ctx that holds the given request ID.
+// The generated clients send the ID in the X-Request-Id header of the
+// requests made with the returned context.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, id)
+}
+
+// RequestID returns the request ID stored in ctx, the empty string if none.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(RequestIDKey).(string)
+	return id
+}
+
+// newRequestID returns a short random request ID.
+func newRequestID() string {
+	b := make([]byte, 6)
+	io.ReadFull(rand.Reader, b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
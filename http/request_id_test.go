@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDHandler(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Context  string
+		Header   string
+		Expected string
+	}{
+		{"context", "ctx", "header", "ctx"},
+		{"header", "", "header", "header"},
+		{"truncated", "", strings.Repeat("a", 200), strings.Repeat("a", 128)},
+		{"generated", "", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var got string
+			h := RequestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = RequestID(r.Context())
+			}))
+			req := httptest.NewRequest("GET", "/", nil)
+			if c.Context != "" {
+				req = req.WithContext(ContextWithRequestID(req.Context(), c.Context))
+			}
+			if c.Header != "" {
+				req.Header.Set(RequestIDHeader, c.Header)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if c.Expected == "" {
+				if len(got) != 8 {
+					t.Errorf("got generated ID %q, expected 8 characters", got)
+				}
+			} else if got != c.Expected {
+				t.Errorf("got ID %q, expected %q", got, c.Expected)
+			}
+			if h := w.Header().Get(RequestIDHeader); h != got {
+				t.Errorf("got response header %q, expected %q", h, got)
+			}
+		})
+	}
+}
+
+func TestRequestIDForwarding(t *testing.T) {
+	ctx := ContextWithRequestID(context.Background(), "id")
+	req, _ := http.NewRequest("GET", "http://localhost/items", nil)
+	ApplyRequestOptions(ctx, req)
+	if h := req.Header.Get(RequestIDHeader); h != "id" {
+		t.Errorf("got X-Request-Id header %q, expected %q", h, "id")
+	}
+
+	ctx = WithRequestOptions(ctx, WithHeader(RequestIDHeader, "explicit"))
+	req, _ = http.NewRequest("GET", "http://localhost/items", nil)
+	ApplyRequestOptions(ctx, req)
+	if hs := req.Header[RequestIDHeader]; len(hs) != 1 || hs[0] != "explicit" {
+		t.Errorf("got X-Request-Id headers %v, expected [explicit]", hs)
+	}
+}
@@ -0,0 +1,117 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type (
+	// RequestOption customizes a single request made by a generated client
+	// endpoint. Use WithRequestOptions to attach options to the context
+	// given to the endpoint, for example:
+	//
+	//    ctx = goahttp.WithRequestOptions(ctx, goahttp.WithHeader("Idempotency-Key", key))
+	//    res, err := client.Create(ctx, payload)
+	//
+	RequestOption func(*requestOptions)
+
+	// requestOptions holds the options attached to a context.
+	requestOptions struct {
+		// header lists the headers added to the request.
+		header http.Header
+		// query lists the query string parameters added to the request.
+		query url.Values
+		// timeout is the request timeout, zero if none.
+		timeout time.Duration
+	}
+
+	// requestOptionsKey is the context key used to store the request
+	// options.
+	requestOptionsKey struct{}
+)
+
+// WithHeader returns a request option that adds the given header to the
+// request. The header is added after the request is encoded so that it may
+// override headers set by the encoder.
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.header.Add(key, value)
+	}
+}
+
+// WithQuery returns a request option that adds the given query string
+// parameter to the request URL.
+func WithQuery(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		o.query.Add(key, value)
+	}
+}
+
+// WithTimeout returns a request option that sets a timeout on the request. The
+// timeout takes precedence over the one defined in the design if any. The
+// timeout does not apply to streaming endpoints nor to endpoints whose response
+// body is returned to the caller unread.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRequestOptions returns a copy of ctx that carries the given options in
+// addition to the options already attached to ctx if any.
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	o := &requestOptions{header: make(http.Header), query: make(url.Values)}
+	if prev, ok := ctx.Value(requestOptionsKey{}).(*requestOptions); ok {
+		for k, v := range prev.header {
+			o.header[k] = append([]string(nil), v...)
+		}
+		for k, v := range prev.query {
+			o.query[k] = append([]string(nil), v...)
+		}
+		o.timeout = prev.timeout
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, o)
+}
+
+// ContextWithRequestTimeout returns a copy of ctx with a deadline set to the
+// timeout given via WithTimeout if any. The generated client endpoints call
+// ContextWithRequestTimeout prior to building the request.
+func ContextWithRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	o, ok := ctx.Value(requestOptionsKey{}).(*requestOptions)
+	if !ok || o.timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, o.timeout)
+}
+
+// ApplyRequestOptions adds the headers and query string parameters given via
+// WithHeader and WithQuery to req. It also sets the X-Request-Id header to the
+// request ID stored in ctx if the header is not already set so that the ID of
+// the request being served is forwarded to the services it calls. The generated
+// client endpoints call ApplyRequestOptions once the request is encoded.
+func ApplyRequestOptions(ctx context.Context, req *http.Request) {
+	if o, ok := ctx.Value(requestOptionsKey{}).(*requestOptions); ok {
+		for k, vs := range o.header {
+			for _, v := range vs {
+				req.Header.Add(k, v)
+			}
+		}
+		if len(o.query) > 0 {
+			q := req.URL.Query()
+			for k, vs := range o.query {
+				for _, v := range vs {
+					q.Add(k, v)
+				}
+			}
+			req.URL.RawQuery = q.Encode()
+		}
+	}
+	if id := RequestID(ctx); id != "" && req.Header.Get(RequestIDHeader) == "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+}
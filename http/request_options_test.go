@@ -0,0 +1,48 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRequestOptions(t *testing.T) {
+	ctx := WithRequestOptions(context.Background(), WithHeader("X-Request-Id", "1"), WithQuery("a", "1"))
+	ctx = WithRequestOptions(ctx, WithQuery("a", "2"), WithTimeout(time.Second))
+	req, _ := http.NewRequest("GET", "http://localhost/items?b=1", nil)
+	req.Header.Set("Content-Type", "application/json")
+
+	ApplyRequestOptions(ctx, req)
+
+	if h := req.Header.Get("X-Request-Id"); h != "1" {
+		t.Errorf("got X-Request-Id header %q, expected %q", h, "1")
+	}
+	if h := req.Header.Get("Content-Type"); h != "application/json" {
+		t.Errorf("got Content-Type header %q, expected %q", h, "application/json")
+	}
+	if q := req.URL.RawQuery; q != "a=1&a=2&b=1" {
+		t.Errorf("got query %q, expected %q", q, "a=1&a=2&b=1")
+	}
+	tctx, cancel := ContextWithRequestTimeout(ctx)
+	defer cancel()
+	if _, ok := tctx.Deadline(); !ok {
+		t.Errorf("expected deadline to be set")
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Errorf("expected original context to have no deadline")
+	}
+}
+
+func TestRequestOptionsNone(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://localhost/items?b=1", nil)
+	ApplyRequestOptions(context.Background(), req)
+	if q := req.URL.RawQuery; q != "b=1" {
+		t.Errorf("got query %q, expected %q", q, "b=1")
+	}
+	ctx, cancel := ContextWithRequestTimeout(context.Background())
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Errorf("expected no deadline")
+	}
+}
@@ -0,0 +1,223 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type (
+	// Route describes a route mounted by a generated server. The generated
+	// code lists the routes of each service in the Routes variable of the
+	// service server package.
+	Route struct {
+		// Verb is the HTTP method.
+		Verb string
+		// Pattern is the route path including wildcards.
+		Pattern string
+		// Segments lists the path segments of Pattern (without the
+		// leading slash), wildcards included.
+		Segments []string
+		// Wildcards lists the indices of the wildcard segments.
+		Wildcards []int
+	}
+
+	// routeMux is a Muxer implementation that matches requests against a
+	// tree of path segments. Static segments take precedence over "{name}"
+	// wildcards which take precedence over "{*name}" wildcards.
+	routeMux struct {
+		// root is the tree root node.
+		root *routeNode
+		// routes indexes the routes given to NewRouteMuxer by pattern.
+		routes map[string]*Route
+	}
+
+	// routeNode is a node of the routeMux tree.
+	routeNode struct {
+		// static maps static segments to the child nodes.
+		static map[string]*routeNode
+		// wildcard is the child node matching any segment.
+		wildcard *routeNode
+		// catchAll is the child node matching the rest of the path.
+		catchAll *routeNode
+		// handlers maps the HTTP methods to the handlers of the routes
+		// ending at the node.
+		handlers map[string]*routeHandler
+	}
+
+	// routeHandler is a handler registered with a routeMux.
+	routeHandler struct {
+		// handler is the handler function.
+		handler http.HandlerFunc
+		// names lists the names of the route wildcards.
+		names []string
+	}
+
+	// routeVarsKey is the request context key used to store the path
+	// variables.
+	routeVarsKey struct{}
+)
+
+// NewRouteMuxer returns a Muxer implementation that dispatches requests using
+// a tree of path segments. The tree is initialized with the given routes,
+// typically the Routes variables of the generated server packages, so that
+// mounting the corresponding handlers does not require parsing the patterns.
+// Patterns that are not listed in routes may still be registered via Handle.
+func NewRouteMuxer(routes ...*Route) Muxer {
+	m := &routeMux{root: &routeNode{}, routes: make(map[string]*Route, len(routes))}
+	for _, r := range routes {
+		m.routes[r.Pattern] = r
+		m.root.insert(r.Segments)
+	}
+	return m
+}
+
+// Handle registers the handler function for the given method and pattern.
+func (m *routeMux) Handle(method, pattern string, handler http.HandlerFunc) {
+	r, ok := m.routes[pattern]
+	if !ok {
+		r = &Route{Verb: method, Pattern: pattern, Segments: splitPath(pattern)}
+		for i, s := range r.Segments {
+			if _, ok := wildcardName(s); ok {
+				r.Wildcards = append(r.Wildcards, i)
+			}
+		}
+	}
+	names := make([]string, len(r.Wildcards))
+	for i, idx := range r.Wildcards {
+		name, _ := wildcardName(r.Segments[idx])
+		names[i] = strings.TrimPrefix(name, "*")
+	}
+	n := m.root.insert(r.Segments)
+	if n.handlers == nil {
+		n.handlers = make(map[string]*routeHandler)
+	}
+	n.handlers[method] = &routeHandler{handler: handler, names: names}
+}
+
+// ServeHTTP dispatches the request to the handler registered for the request
+// method and path. HEAD requests are dispatched to the GET handler if no HEAD
+// handler is registered for the path. It responds with 404 Not Found if no
+// route matches the path and with 405 Method Not Allowed and the Allow header
+// if no route matches the method. OPTIONS requests that no route matches are
+// answered with 200 OK and the Allow header.
+func (m *routeMux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path, escaped := r.URL.Path, false
+	if r.URL.RawPath != "" {
+		path, escaped = r.URL.RawPath, true
+	}
+	n, vals := m.root.match(splitPath(path), nil, escaped)
+	if n == nil {
+		http.NotFound(w, r)
+		return
+	}
+	h, ok := n.handlers[r.Method]
+	if !ok && r.Method == "HEAD" {
+		h, ok = n.handlers["GET"]
+	}
+	if !ok {
+		allowed := make([]string, 0, len(n.handlers))
+		for m := range n.handlers {
+			allowed = append(allowed, m)
+		}
+		methodNotAllowed(w, r, allowed)
+		return
+	}
+	if len(h.names) > 0 {
+		vars := make(map[string]string, len(h.names))
+		for i, name := range h.names {
+			vars[name] = vals[i]
+		}
+		r = r.WithContext(context.WithValue(r.Context(), routeVarsKey{}, vars))
+	}
+	h.handler(w, r)
+}
+
+// Vars returns the path variables captured for the given request.
+func (m *routeMux) Vars(r *http.Request) map[string]string {
+	vars, _ := r.Context().Value(routeVarsKey{}).(map[string]string)
+	return vars
+}
+
+// insert returns the node for the given segments, creating the missing nodes.
+func (n *routeNode) insert(segs []string) *routeNode {
+	for _, s := range segs {
+		if name, ok := wildcardName(s); ok {
+			if strings.HasPrefix(name, "*") {
+				if n.catchAll == nil {
+					n.catchAll = &routeNode{}
+				}
+				return n.catchAll
+			}
+			if n.wildcard == nil {
+				n.wildcard = &routeNode{}
+			}
+			n = n.wildcard
+			continue
+		}
+		c, ok := n.static[s]
+		if !ok {
+			if n.static == nil {
+				n.static = make(map[string]*routeNode)
+			}
+			c = &routeNode{}
+			n.static[s] = c
+		}
+		n = c
+	}
+	return n
+}
+
+// match returns the node with handlers matching the given path segments and
+// the values of the captured wildcards appended to vals. escaped indicates
+// whether the segments must be unescaped before being compared and captured.
+func (n *routeNode) match(segs, vals []string, escaped bool) (*routeNode, []string) {
+	if len(segs) == 0 {
+		if len(n.handlers) == 0 {
+			return nil, nil
+		}
+		return n, vals
+	}
+	seg := segs[0]
+	if escaped {
+		if s, err := url.PathUnescape(seg); err == nil {
+			seg = s
+		}
+	}
+	if c, ok := n.static[seg]; ok {
+		if m, v := c.match(segs[1:], vals, escaped); m != nil {
+			return m, v
+		}
+	}
+	if n.wildcard != nil && seg != "" {
+		if m, v := n.wildcard.match(segs[1:], append(vals, seg), escaped); m != nil {
+			return m, v
+		}
+	}
+	if n.catchAll != nil && len(n.catchAll.handlers) > 0 {
+		rest := strings.Join(segs, "/")
+		if escaped {
+			if s, err := url.PathUnescape(rest); err == nil {
+				rest = s
+			}
+		}
+		return n.catchAll, append(vals, rest)
+	}
+	return nil, nil
+}
+
+// splitPath returns the segments of the given path without the leading slash.
+func splitPath(path string) []string {
+	return strings.Split(strings.TrimPrefix(path, "/"), "/")
+}
+
+// wildcardName returns the name of the wildcard defined by the given path
+// segment including the leading "*" for wildcards matching the rest of the
+// path, false if the segment is not a wildcard.
+func wildcardName(seg string) (string, bool) {
+	if len(seg) < 3 || seg[0] != '{' || seg[len(seg)-1] != '}' {
+		return "", false
+	}
+	return seg[1 : len(seg)-1], true
+}
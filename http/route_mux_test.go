@@ -0,0 +1,72 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestRouteMuxer(t *testing.T) {
+	routes := []*Route{
+		{Verb: "GET", Pattern: "/accounts/{id}/users/{name}", Segments: []string{"accounts", "{id}", "users", "{name}"}, Wildcards: []int{1, 3}},
+		{Verb: "POST", Pattern: "/accounts/{name}/{id}", Segments: []string{"accounts", "{name}", "{id}"}, Wildcards: []int{1, 2}},
+		{Verb: "GET", Pattern: "/accounts/me/users/{name}", Segments: []string{"accounts", "me", "users", "{name}"}, Wildcards: []int{3}},
+		{Verb: "GET", Pattern: "/", Segments: []string{""}},
+	}
+	cases := []struct {
+		Name    string
+		Method  string
+		URL     string
+		Status  int
+		Pattern string
+		Vars    map[string]string
+		Allow   string
+	}{
+		{"root", "GET", "/", http.StatusOK, "/", nil, ""},
+		{"wildcards", "GET", "/accounts/1/users/joe", http.StatusOK, "/accounts/{id}/users/{name}", map[string]string{"id": "1", "name": "joe"}, ""},
+		{"wildcard names", "POST", "/accounts/joe/1", http.StatusOK, "/accounts/{name}/{id}", map[string]string{"id": "1", "name": "joe"}, ""},
+		{"static first", "GET", "/accounts/me/users/joe", http.StatusOK, "/accounts/me/users/{name}", map[string]string{"name": "joe"}, ""},
+		{"backtrack", "POST", "/accounts/me/1", http.StatusOK, "/accounts/{name}/{id}", map[string]string{"id": "1", "name": "me"}, ""},
+		{"escaped", "GET", "/accounts/a%2Fb/users/j%20o", http.StatusOK, "/accounts/{id}/users/{name}", map[string]string{"id": "a/b", "name": "j o"}, ""},
+		{"catch all", "GET", "/files/a/b.txt", http.StatusOK, "/files/{*path}", map[string]string{"path": "a/b.txt"}, ""},
+		{"catch all empty", "GET", "/files/", http.StatusOK, "/files/{*path}", map[string]string{"path": ""}, ""},
+		{"not found", "GET", "/accounts", http.StatusNotFound, "", nil, ""},
+		{"empty segment", "GET", "/accounts//users/joe", http.StatusNotFound, "", nil, ""},
+		{"head", "HEAD", "/accounts/1/users/joe", http.StatusOK, "/accounts/{id}/users/{name}", map[string]string{"id": "1", "name": "joe"}, ""},
+		{"method not allowed", "DELETE", "/accounts/joe/1", http.StatusMethodNotAllowed, "", nil, "OPTIONS, POST"},
+		{"method not allowed get", "DELETE", "/", http.StatusMethodNotAllowed, "", nil, "GET, HEAD, OPTIONS"},
+		{"options", "OPTIONS", "/accounts/joe/1", http.StatusOK, "", nil, "OPTIONS, POST"},
+	}
+	mux := NewRouteMuxer(routes...)
+	var (
+		pattern string
+		vars    map[string]string
+	)
+	for _, r := range append(routes, &Route{Verb: "GET", Pattern: "/files/{*path}"}) {
+		p := r.Pattern
+		mux.Handle(r.Verb, p, func(w http.ResponseWriter, r *http.Request) {
+			pattern = p
+			vars = mux.Vars(r)
+		})
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			pattern, vars = "", nil
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(c.Method, c.URL, nil))
+			if w.Code != c.Status {
+				t.Errorf("got status %d, expected %d", w.Code, c.Status)
+			}
+			if pattern != c.Pattern {
+				t.Errorf("got pattern %q, expected %q", pattern, c.Pattern)
+			}
+			if !reflect.DeepEqual(vars, c.Vars) {
+				t.Errorf("got vars %v, expected %v", vars, c.Vars)
+			}
+			if allow := w.Header().Get("Allow"); allow != c.Allow {
+				t.Errorf("got Allow header %q, expected %q", allow, c.Allow)
+			}
+		})
+	}
+}
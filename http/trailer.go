@@ -0,0 +1,33 @@
+package http
+
+import "io"
+
+// TrailerReader returns a reader that reads from body and calls fn once body
+// has been read to EOF. The HTTP client initializes the response trailers only
+// once the response body has been read to EOF so that fn may access them. The
+// generated clients use TrailerReader to set the result fields mapped to
+// response trailers. The Read call that reaches EOF returns the error returned
+// by fn if any.
+func TrailerReader(body io.ReadCloser, fn func() error) io.ReadCloser {
+	return &trailerReader{ReadCloser: body, fn: fn}
+}
+
+// trailerReader is the reader returned by TrailerReader.
+type trailerReader struct {
+	io.ReadCloser
+	fn   func() error
+	done bool
+}
+
+// Read reads from the underlying body and calls fn when it returns io.EOF
+// for the first time.
+func (r *trailerReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if err == io.EOF && !r.done {
+		r.done = true
+		if ferr := r.fn(); ferr != nil {
+			return n, ferr
+		}
+	}
+	return n, err
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTrailerReader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Trailer", "X-Checksum")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("content"))
+		w.Header().Set("X-Checksum", "abc")
+	}))
+	defer srv.Close()
+
+	t.Run("trailers", func(t *testing.T) {
+		resp, err := http.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var (
+			calls    int
+			checksum string
+		)
+		body := TrailerReader(resp.Body, func() error {
+			calls++
+			checksum = resp.Trailer.Get("X-Checksum")
+			return nil
+		})
+		defer body.Close()
+		if calls != 0 {
+			t.Errorf("got %d calls before reading the body, expected none", calls)
+		}
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if string(b) != "content" {
+			t.Errorf("got body %q, expected %q", string(b), "content")
+		}
+		body.Read(make([]byte, 1))
+		if calls != 1 {
+			t.Errorf("got %d calls, expected 1", calls)
+		}
+		if checksum != "abc" {
+			t.Errorf("got checksum %q, expected %q", checksum, "abc")
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		resp, err := http.Get(srv.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := errors.New("invalid trailer")
+		body := TrailerReader(resp.Body, func() error { return expected })
+		defer body.Close()
+		if _, err := ioutil.ReadAll(body); err != expected {
+			t.Errorf("got error %v, expected %v", err, expected)
+		}
+	})
+}
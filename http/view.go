@@ -0,0 +1,57 @@
+package http
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strings"
+
+	"goa.design/goa"
+)
+
+// ViewHeader is the name of the request header used by clients to select the
+// view used to render the result of methods whose view is chosen at request
+// time.
+const ViewHeader = "X-View"
+
+// WithView returns a request option that requests the result to be rendered
+// with the given view. The view is sent in the X-View header.
+func WithView(view string) RequestOption {
+	return WithHeader(ViewHeader, view)
+}
+
+// RequestedView returns the view requested by the client if any. The view is
+// read from the X-View header or else from the "view" parameter of the
+// media types listed in the Accept header, e.g.
+// "application/vnd.goa.book+json; view=tiny".
+func RequestedView(r *http.Request) string {
+	if v := r.Header.Get(ViewHeader); v != "" {
+		return v
+	}
+	for _, mt := range strings.Split(r.Header.Get("Accept"), ",") {
+		if _, params, err := mime.ParseMediaType(strings.TrimSpace(mt)); err == nil {
+			if v := params["view"]; v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// NegotiateView validates the view requested by the client against the views
+// defined by the method result type and returns a copy of ctx holding the view
+// under goa.ViewKey. NegotiateView returns ctx unchanged if the request does
+// not specify a view. The generated server handlers call NegotiateView before
+// invoking the endpoints of methods whose view is chosen at request time.
+func NegotiateView(ctx context.Context, r *http.Request, views []string) (context.Context, error) {
+	view := RequestedView(r)
+	if view == "" {
+		return ctx, nil
+	}
+	for _, v := range views {
+		if v == view {
+			return context.WithValue(ctx, goa.ViewKey, view), nil
+		}
+	}
+	return ctx, goa.PermanentError("invalid_view", "invalid view %q, valid views are %s", view, strings.Join(views, ", "))
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"goa.design/goa"
+)
+
+func TestNegotiateView(t *testing.T) {
+	views := []string{"default", "tiny"}
+	cases := []struct {
+		Name     string
+		Header   string
+		Accept   string
+		Expected string
+		Error    bool
+	}{
+		{"none", "", "application/json", "", false},
+		{"header", "tiny", "", "tiny", false},
+		{"accept", "", "text/html, application/vnd.goa.book+json; view=tiny", "tiny", false},
+		{"header precedence", "default", "application/json; view=tiny", "default", false},
+		{"invalid", "full", "", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			r, _ := http.NewRequest("GET", "/", nil)
+			if c.Header != "" {
+				r.Header.Set(ViewHeader, c.Header)
+			}
+			if c.Accept != "" {
+				r.Header.Set("Accept", c.Accept)
+			}
+			ctx, err := NegotiateView(context.Background(), r, views)
+			if c.Error {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			view, _ := ctx.Value(goa.ViewKey).(string)
+			if view != c.Expected {
+				t.Errorf("got view %q, expected %q", view, c.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// WebhookEventHeader is the name of the request header that carries
+	// the name of the webhook.
+	WebhookEventHeader = "X-Webhook-Event"
+
+	// WebhookSignatureHeader is the default name of the request header
+	// that carries the webhook signature.
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+type (
+	// WebhookSender delivers the webhook requests declared in the design
+	// with the Webhook DSL. The generated webhook senders use a
+	// WebhookSender to encode, sign and send the requests.
+	WebhookSender struct {
+		// Doer makes the HTTP requests.
+		Doer Doer
+		// Secret is the key used to sign the request bodies, the
+		// requests are not signed if it is empty.
+		Secret []byte
+		// SignatureHeader is the name of the request header that
+		// carries the signature, WebhookSignatureHeader if empty.
+		SignatureHeader string
+		// MaxAttempts is the maximum number of attempts made to
+		// deliver a webhook. The requests that fail because of a
+		// network error or because the receiver responds with a 408,
+		// 429 or 5xx status code are retried.
+		MaxAttempts int
+	}
+
+	// WebhookError is the error returned by WebhookSender when a webhook
+	// could not be delivered.
+	WebhookError struct {
+		// Event is the name of the webhook.
+		Event string
+		// URL is the URL the webhook was sent to.
+		URL string
+		// Attempts is the number of attempts made.
+		Attempts int
+		// StatusCode is the status code of the last response, zero if
+		// the last request failed.
+		StatusCode int
+		// Err is the error of the last request, nil if the receiver
+		// responded.
+		Err error
+	}
+)
+
+// NewWebhookSender returns a WebhookSender that sends the requests with doer,
+// signs them with secret and makes up to 3 attempts to deliver each webhook.
+func NewWebhookSender(doer Doer, secret []byte) *WebhookSender {
+	return &WebhookSender{Doer: doer, Secret: secret, MaxAttempts: 3}
+}
+
+// Send sends a POST request to url whose body is the JSON encoding of body.
+// The request carries the name of the webhook in the WebhookEventHeader header
+// and the signature of the body computed with SignWebhook if the sender has a
+// secret. The delay between two attempts follows WaitRedial. Send returns nil
+// if the receiver responds with a 2xx status code and a *WebhookError
+// otherwise.
+func (s *WebhookSender) Send(ctx context.Context, url, event string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode %q webhook body: %s", event, err)
+	}
+	max := s.MaxAttempts
+	if max < 1 {
+		max = 1
+	}
+	for attempt := 1; ; attempt++ {
+		status, err := s.send(ctx, url, event, data)
+		if err == nil && status >= 200 && status < 300 {
+			return nil
+		}
+		werr := &WebhookError{Event: event, URL: url, Attempts: attempt, StatusCode: status, Err: err}
+		if attempt >= max || !retryWebhook(status, err) {
+			return werr
+		}
+		if WaitRedial(ctx, attempt) != nil {
+			return werr
+		}
+	}
+}
+
+// send makes a single attempt to deliver the webhook and returns the status
+// code of the response.
+func (s *WebhookSender) send(ctx context.Context, url, event string, data []byte) (int, error) {
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event)
+	if len(s.Secret) > 0 {
+		h := s.SignatureHeader
+		if h == "" {
+			h = WebhookSignatureHeader
+		}
+		req.Header.Set(h, SignWebhook(s.Secret, time.Now(), data))
+	}
+	resp, err := s.Doer.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// retryWebhook returns true if a webhook request that failed with err or whose
+// response has the given status code may be retried.
+func retryWebhook(status int, err error) bool {
+	if err != nil {
+		return true
+	}
+	return status >= 500 || status == http.StatusRequestTimeout || status == http.StatusTooManyRequests
+}
+
+// Error returns the error message.
+func (e *WebhookError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("failed to send %q webhook to %s after %d attempt(s): %s", e.Event, e.URL, e.Attempts, e.Err)
+	}
+	return fmt.Sprintf("failed to send %q webhook to %s after %d attempt(s): receiver responded with status %d", e.Event, e.URL, e.Attempts, e.StatusCode)
+}
+
+// SignWebhook returns the signature of the webhook request body sent at time t.
+// The signature has the form "t=<timestamp>,v1=<signature>" where timestamp is
+// the Unix time t and signature the hex encoded HMAC-SHA256 of the timestamp,
+// a dot and the body computed with secret. Including the timestamp lets the
+// receivers reject replayed requests.
+func SignWebhook(secret []byte, t time.Time, body []byte) string {
+	ts := strconv.FormatInt(t.Unix(), 10)
+	return "t=" + ts + ",v1=" + webhookMAC(secret, ts, body)
+}
+
+// VerifyWebhook checks the signature of a webhook request body computed with
+// SignWebhook. It returns an error if the signature is not valid or, when
+// tolerance is not zero, if the signature timestamp is more than tolerance
+// away from the current time.
+func VerifyWebhook(secret []byte, signature string, body []byte, tolerance time.Duration) error {
+	var (
+		ts   string
+		sigs []string
+	)
+	for _, p := range strings.Split(signature, ",") {
+		kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case "t":
+			ts = kv[1]
+		case "v1":
+			sigs = append(sigs, kv[1])
+		}
+	}
+	if ts == "" || len(sigs) == 0 {
+		return errors.New("invalid webhook signature format")
+	}
+	if tolerance > 0 {
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid webhook signature timestamp %q", ts)
+		}
+		d := time.Since(time.Unix(sec, 0))
+		if d < 0 {
+			d = -d
+		}
+		if d > tolerance {
+			return errors.New("webhook signature timestamp is outside of the tolerance")
+		}
+	}
+	expected := webhookMAC(secret, ts, body)
+	for _, sig := range sigs {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return nil
+		}
+	}
+	return errors.New("webhook signature mismatch")
+}
+
+// webhookMAC returns the hex encoded HMAC-SHA256 of the given timestamp, a dot
+// and body.
+func webhookMAC(secret []byte, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
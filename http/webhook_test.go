@@ -0,0 +1,103 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookSender(t *testing.T) {
+	secret := []byte("secret")
+	cases := []struct {
+		Name     string
+		Statuses []int
+		Err      error
+		Calls    int
+		Status   int
+	}{
+		{"delivered", []int{http.StatusOK}, nil, 1, 0},
+		{"retried", []int{http.StatusServiceUnavailable, http.StatusNoContent}, nil, 2, 0},
+		{"rejected", []int{http.StatusBadRequest}, nil, 1, http.StatusBadRequest},
+		{"exhausted", []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusTooManyRequests}, nil, 3, http.StatusTooManyRequests},
+		{"network", nil, errors.New("connection refused"), 3, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			var calls int
+			doer := doerFunc(func(req *http.Request) (*http.Response, error) {
+				calls++
+				if req.Method != "POST" {
+					t.Errorf("got method %q, expected POST", req.Method)
+				}
+				if ev := req.Header.Get(WebhookEventHeader); ev != "created" {
+					t.Errorf("got event %q, expected %q", ev, "created")
+				}
+				body, _ := ioutil.ReadAll(req.Body)
+				if string(body) != `{"id":1}` {
+					t.Errorf("got body %q, expected %q", body, `{"id":1}`)
+				}
+				if err := VerifyWebhook(secret, req.Header.Get(WebhookSignatureHeader), body, time.Minute); err != nil {
+					t.Errorf("invalid signature: %s", err)
+				}
+				if c.Err != nil {
+					return nil, c.Err
+				}
+				return &http.Response{StatusCode: c.Statuses[calls-1], Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+			})
+			s := NewWebhookSender(doer, secret)
+			err := s.Send(context.Background(), "http://localhost/hook", "created", map[string]int{"id": 1})
+			if calls != c.Calls {
+				t.Errorf("got %d calls, expected %d", calls, c.Calls)
+			}
+			if c.Status == 0 && c.Err == nil {
+				if err != nil {
+					t.Fatalf("got error %v, expected none", err)
+				}
+				return
+			}
+			werr, ok := err.(*WebhookError)
+			if !ok {
+				t.Fatalf("got error %v, expected *WebhookError", err)
+			}
+			if werr.StatusCode != c.Status || werr.Err != c.Err || werr.Attempts != c.Calls {
+				t.Errorf("got error %+v, expected status %d, error %v and %d attempts", werr, c.Status, c.Err, c.Calls)
+			}
+		})
+	}
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	var (
+		secret = []byte("secret")
+		body   = []byte(`{"id":1}`)
+		now    = time.Now()
+	)
+	cases := []struct {
+		Name      string
+		Signature string
+		Tolerance time.Duration
+		Valid     bool
+	}{
+		{"valid", SignWebhook(secret, now, body), time.Minute, true},
+		{"no-tolerance", SignWebhook(secret, now.Add(-time.Hour), body), 0, true},
+		{"expired", SignWebhook(secret, now.Add(-time.Hour), body), time.Minute, false},
+		{"other-secret", SignWebhook([]byte("other"), now, body), time.Minute, false},
+		{"multiple", SignWebhook([]byte("other"), now, body) + ",v1=" + strings.SplitN(SignWebhook(secret, now, body), "v1=", 2)[1], time.Minute, true},
+		{"malformed", "invalid", time.Minute, false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			err := VerifyWebhook(secret, c.Signature, body, c.Tolerance)
+			if c.Valid && err != nil {
+				t.Errorf("got error %q, expected none", err)
+			}
+			if !c.Valid && err == nil {
+				t.Error("got no error")
+			}
+		})
+	}
+}
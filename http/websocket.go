@@ -1,9 +1,38 @@
 package http
 
 import (
+	"context"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/gorilla/websocket"
+	"goa.design/goa"
+)
+
+const (
+	// streamErrorCodeBase is added to the HTTP status code of the errors
+	// reported in websocket close control messages to compute the close
+	// code. The resulting codes are in the 4000-4999 range that RFC 6455
+	// reserves for private use.
+	streamErrorCodeBase = 4000
+
+	// maxCloseReasonLen is the maximum length in bytes of the reason of
+	// a websocket close control message.
+	maxCloseReasonLen = 123
+
+	// minRedialDelay is the delay before the first attempt to re-dial a
+	// client stream.
+	minRedialDelay = 100 * time.Millisecond
+
+	// maxRedialDelay is the maximum delay between two attempts to re-dial
+	// a client stream.
+	maxRedialDelay = 10 * time.Second
 )
 
 type (
@@ -22,4 +51,217 @@ type (
 	// ConnConfigureFunc is used to configure a websocket connection with
 	// custom handlers.
 	ConnConfigureFunc func(*websocket.Conn) *websocket.Conn
+
+	// StreamCodec marshals and unmarshals the messages sent over websocket
+	// connections that use binary frames, see the BinaryStream DSL.
+	StreamCodec interface {
+		// Marshal returns the binary encoding of v.
+		Marshal(v interface{}) ([]byte, error)
+		// Unmarshal decodes data and stores the result in the value
+		// pointed to by v.
+		Unmarshal(data []byte, v interface{}) error
+	}
+
+	// rawStreamCodec is a StreamCodec that sends and receives raw bytes.
+	rawStreamCodec struct{}
 )
+
+// RawStreamCodec is a StreamCodec that sends byte slices as is and receives
+// messages into byte slices.
+var RawStreamCodec StreamCodec = rawStreamCodec{}
+
+// Marshal returns v if it is a byte slice or a pointer to a byte slice.
+func (rawStreamCodec) Marshal(v interface{}) ([]byte, error) {
+	switch b := v.(type) {
+	case []byte:
+		return b, nil
+	case *[]byte:
+		return *b, nil
+	default:
+		return nil, fmt.Errorf("raw stream codec cannot marshal value of type %T", v)
+	}
+}
+
+// Unmarshal copies data into v which must be a pointer to a byte slice.
+func (rawStreamCodec) Unmarshal(data []byte, v interface{}) error {
+	b, ok := v.(*[]byte)
+	if !ok {
+		return fmt.Errorf("raw stream codec cannot unmarshal into value of type %T", v)
+	}
+	*b = append((*b)[:0], data...)
+	return nil
+}
+
+// KeepAlive sends a ping control message over conn every pingInterval so that
+// intermediaries do not close the connection when it is idle. It also extends
+// the read deadline of conn by pongWait each time a ping or pong control
+// message is received so that reads fail once the peer has been silent for
+// longer than pongWait. Pings and the pongs sent in response to the peer pings
+// must be written within writeWait. KeepAlive stops sending pings once a ping
+// cannot be written, for example because the connection was closed, or when
+// the returned function is called.
+func KeepAlive(conn *websocket.Conn, pingInterval, pongWait, writeWait time.Duration) func() {
+	conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	conn.SetPingHandler(func(data string) error {
+		conn.SetReadDeadline(time.Now().Add(pongWait))
+		err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(writeWait))
+		if err == websocket.ErrCloseSent {
+			return nil
+		}
+		if ne, ok := err.(net.Error); ok && ne.Timeout() {
+			return nil
+		}
+		return err
+	})
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// CompressionUpgrader returns an upgrader that negotiates the permessage-deflate
+// compression extension with the clients that support it. It returns a copy
+// of up with compression enabled if up is a *websocket.Upgrader, up itself
+// otherwise in which case up is responsible for negotiating compression.
+func CompressionUpgrader(up Upgrader) Upgrader {
+	u, ok := up.(*websocket.Upgrader)
+	if !ok || u.EnableCompression {
+		return up
+	}
+	cu := *u
+	cu.EnableCompression = true
+	return &cu
+}
+
+// CompressionDialer returns a dialer that negotiates the permessage-deflate
+// compression extension with the servers that support it. It returns a copy
+// of d with compression enabled if d is a *websocket.Dialer, d itself
+// otherwise in which case d is responsible for negotiating compression.
+func CompressionDialer(d Dialer) Dialer {
+	wd, ok := d.(*websocket.Dialer)
+	if !ok || wd.EnableCompression {
+		return d
+	}
+	cd := *wd
+	cd.EnableCompression = true
+	return &cd
+}
+
+// FormatStreamError returns the payload of the websocket close control message
+// that reports the error with the given name and HTTP status code to the peer.
+// The close code is 4000 plus the status code and the close reason consists of
+// the error name and message separated with a colon, the reason is truncated
+// if it exceeds the maximum size of control messages. The close code is 1011
+// (internal error) if the status code does not map to the 4000-4999 range
+// reserved to applications.
+func FormatStreamError(status int, name, msg string) []byte {
+	reason := name + ": " + msg
+	if len(reason) > maxCloseReasonLen {
+		reason = reason[:maxCloseReasonLen]
+		for !utf8.ValidString(reason) {
+			reason = reason[:len(reason)-1]
+		}
+	}
+	code := streamErrorCodeBase + status
+	if code < streamErrorCodeBase || code > streamErrorCodeBase+999 {
+		code = websocket.CloseInternalServerErr
+	}
+	return websocket.FormatCloseMessage(code, reason)
+}
+
+// DecodeStreamError returns the error reported by the websocket close control
+// message described by err as formatted by FormatStreamError. It returns nil
+// if err is not a close error or if the close code does not correspond to an
+// error status code. The returned error has the name and message of the
+// error sent by the peer and is a fault if the status code is 500 or more.
+func DecodeStreamError(err error) *goa.ServiceError {
+	ce, ok := err.(*websocket.CloseError)
+	if !ok {
+		return nil
+	}
+	status := ce.Code - streamErrorCodeBase
+	if status < 400 || status > 599 {
+		return nil
+	}
+	name, msg := ce.Text, ""
+	if idx := strings.Index(ce.Text, ": "); idx >= 0 {
+		name, msg = ce.Text[:idx], ce.Text[idx+2:]
+	}
+	return &goa.ServiceError{
+		Name:    name,
+		ID:      goa.NewErrorID(),
+		Message: msg,
+		Fault:   status >= http.StatusInternalServerError,
+	}
+}
+
+// IsTransientStreamError returns true if err indicates that the websocket
+// connection of a client stream failed in a way that re-dialing the server may
+// recover from: network errors, connections closed without a close control
+// message or with a going away, service restart or try again later close
+// code and the errors returned by the generated client endpoints when the
+// dial fails or when the server responds with a temporary error status code.
+// The resumable client streams re-dial the server only when
+// IsTransientStreamError returns true.
+func IsTransientStreamError(err error) bool {
+	switch e := err.(type) {
+	case nil:
+		return false
+	case *websocket.CloseError:
+		switch e.Code {
+		case websocket.CloseAbnormalClosure, websocket.CloseGoingAway,
+			websocket.CloseServiceRestart, websocket.CloseTryAgainLater:
+			return true
+		}
+		return false
+	case *ClientError:
+		return e.Name == "request_error" || e.Temporary || e.Timeout
+	case net.Error:
+		return true
+	}
+	return err == io.ErrUnexpectedEOF
+}
+
+// WaitRedial waits before the given attempt to re-dial a client stream,
+// attempts start at 1. The delay starts at 100ms and doubles with each attempt
+// up to 10s. WaitRedial returns the ctx error if ctx is done before the delay
+// elapses.
+func WaitRedial(ctx context.Context, attempt int) error {
+	t := time.NewTimer(redialDelay(attempt))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// redialDelay returns the delay before the given attempt to re-dial a client
+// stream.
+func redialDelay(attempt int) time.Duration {
+	d := minRedialDelay
+	for i := 1; i < attempt && d < maxRedialDelay; i++ {
+		d *= 2
+	}
+	if d > maxRedialDelay {
+		d = maxRedialDelay
+	}
+	return d
+}
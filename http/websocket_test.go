@@ -0,0 +1,229 @@
+package http
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestKeepAlive(t *testing.T) {
+	var (
+		upgrader websocket.Upgrader
+		readErr  = make(chan error, 1)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("failed to upgrade connection: %s", err)
+			return
+		}
+		defer conn.Close()
+		stop := KeepAlive(conn, 10*time.Millisecond, 50*time.Millisecond, 50*time.Millisecond)
+		defer stop()
+		_, _, err = conn.ReadMessage()
+		readErr <- err
+	}))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to dial: %s", err)
+	}
+	defer conn.Close()
+	var pings int32
+	conn.SetPingHandler(func(string) error {
+		atomic.AddInt32(&pings, 1)
+		return nil // do not reply so that the server read times out
+	})
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case err := <-readErr:
+		if err == nil {
+			t.Fatal("got no error, expected read deadline error")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("server read did not time out")
+	}
+	if n := atomic.LoadInt32(&pings); n < 2 {
+		t.Errorf("got %d pings, expected at least 2", n)
+	}
+}
+
+func TestCompression(t *testing.T) {
+	up := CompressionUpgrader(&websocket.Upgrader{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := up.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("failed to upgrade connection: %s", err)
+			return
+		}
+		defer conn.Close()
+		mt, msg, err := conn.ReadMessage()
+		if err != nil {
+			t.Errorf("failed to read message: %s", err)
+			return
+		}
+		conn.WriteMessage(mt, msg)
+	}))
+	defer srv.Close()
+
+	d := CompressionDialer(websocket.DefaultDialer)
+	if websocket.DefaultDialer.EnableCompression {
+		t.Fatal("CompressionDialer modified the given dialer")
+	}
+	conn, resp, err := d.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to dial: %s", err)
+	}
+	defer conn.Close()
+	if ext := resp.Header.Get("Sec-Websocket-Extensions"); !strings.Contains(ext, "permessage-deflate") {
+		t.Errorf("got extensions %q, expected permessage-deflate", ext)
+	}
+	msg := strings.Repeat("goa", 100)
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(msg)); err != nil {
+		t.Fatalf("failed to write message: %s", err)
+	}
+	_, got, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("failed to read message: %s", err)
+	}
+	if string(got) != msg {
+		t.Errorf("got message %q, expected %q", got, msg)
+	}
+}
+
+func TestStreamError(t *testing.T) {
+	long := strings.Repeat("é", 100)
+	cases := []struct {
+		Name     string
+		Status   int
+		ErrName  string
+		Message  string
+		Expected string
+		Fault    bool
+	}{
+		{"not found", http.StatusNotFound, "not_found", "no such car", "no such car", false},
+		{"empty message", http.StatusBadRequest, "bad_request", "", "", false},
+		{"fault", http.StatusInternalServerError, "fault", "boom", "boom", true},
+		{"truncated", http.StatusConflict, "conflict", long, long[:maxCloseReasonLen-len("conflict: ")-1], false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			msg := FormatStreamError(c.Status, c.ErrName, c.Message)
+			if len(msg) > maxCloseReasonLen+2 {
+				t.Fatalf("got close message of %d bytes, expected at most %d", len(msg), maxCloseReasonLen+2)
+			}
+			code := int(binary.BigEndian.Uint16(msg))
+			if code != 4000+c.Status {
+				t.Errorf("got close code %d, expected %d", code, 4000+c.Status)
+			}
+			serr := DecodeStreamError(&websocket.CloseError{Code: code, Text: string(msg[2:])})
+			if serr == nil {
+				t.Fatal("got nil error")
+			}
+			if serr.Name != c.ErrName {
+				t.Errorf("got name %q, expected %q", serr.Name, c.ErrName)
+			}
+			if serr.Message != c.Expected {
+				t.Errorf("got message %q, expected %q", serr.Message, c.Expected)
+			}
+			if serr.Fault != c.Fault {
+				t.Errorf("got fault %v, expected %v", serr.Fault, c.Fault)
+			}
+		})
+	}
+
+	t.Run("out of range status", func(t *testing.T) {
+		for _, status := range []int{-1, 1000, 5000} {
+			msg := FormatStreamError(status, "fault", "boom")
+			if code := int(binary.BigEndian.Uint16(msg)); code != websocket.CloseInternalServerErr {
+				t.Errorf("status %d: got close code %d, expected %d", status, code, websocket.CloseInternalServerErr)
+			}
+			if reason := string(msg[2:]); reason != "fault: boom" {
+				t.Errorf("status %d: got reason %q, expected %q", status, reason, "fault: boom")
+			}
+		}
+	})
+
+	t.Run("not an error", func(t *testing.T) {
+		errs := []error{
+			&websocket.CloseError{Code: websocket.CloseNormalClosure},
+			&websocket.CloseError{Code: websocket.CloseInternalServerErr, Text: "fault: boom"},
+			errors.New("boom"),
+		}
+		for _, err := range errs {
+			if serr := DecodeStreamError(err); serr != nil {
+				t.Errorf("got error %v for %v, expected nil", serr, err)
+			}
+		}
+	})
+}
+
+func TestIsTransientStreamError(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Err      error
+		Expected bool
+	}{
+		{"nil", nil, false},
+		{"eof", io.EOF, false},
+		{"unexpected eof", io.ErrUnexpectedEOF, true},
+		{"abnormal closure", &websocket.CloseError{Code: websocket.CloseAbnormalClosure}, true},
+		{"going away", &websocket.CloseError{Code: websocket.CloseGoingAway}, true},
+		{"normal closure", &websocket.CloseError{Code: websocket.CloseNormalClosure}, false},
+		{"stream error", &websocket.CloseError{Code: 4404, Text: "not_found: no such car"}, false},
+		{"request error", ErrRequestError("svc", "m", errors.New("connection refused")), true},
+		{"temporary response", ErrInvalidResponse("svc", "m", http.StatusServiceUnavailable, ""), true},
+		{"invalid response", ErrInvalidResponse("svc", "m", http.StatusNotFound, ""), false},
+		{"validation error", ErrValidationError("svc", "m", errors.New("invalid")), false},
+		{"net error", &net.OpError{Op: "read", Err: errors.New("connection reset")}, true},
+		{"other", errors.New("boom"), false},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if actual := IsTransientStreamError(c.Err); actual != c.Expected {
+				t.Errorf("got %v, expected %v", actual, c.Expected)
+			}
+		})
+	}
+}
+
+func TestRedialDelay(t *testing.T) {
+	cases := []struct {
+		Attempt  int
+		Expected time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{5, 1600 * time.Millisecond},
+		{8, 10 * time.Second},
+		{100, 10 * time.Second},
+	}
+	for _, c := range cases {
+		if actual := redialDelay(c.Attempt); actual != c.Expected {
+			t.Errorf("attempt %d: got %s, expected %s", c.Attempt, actual, c.Expected)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := WaitRedial(ctx, 100); err != context.Canceled {
+		t.Errorf("got error %v, expected %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,67 @@
+package goa
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Forward calls the method of a service client with the payload p and stores
+// the method result in res. fn must be a client method value with signature
+// func(context.Context[, payload]) ([result, ]error) such as the methods of the
+// typed clients generated in the service packages. The payload is converted
+// to the type of the client method payload and the method result to the type
+// pointed by res, the fields are matched by name. p must be nil if the client
+// method has no payload and res may be nil if the result should be discarded.
+// Forward returns the error returned by fn as is.
+//
+// The generated code uses Forward to implement the methods of the services
+// that proxy the methods of services defined in other designs.
+func Forward(ctx context.Context, fn interface{}, p interface{}, res interface{}) error {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func {
+		return fmt.Errorf("goa: cannot forward to %T, must be a function", fn)
+	}
+	ft := fv.Type()
+	if ft.NumIn() < 1 || ft.NumIn() > 2 || ft.NumOut() < 1 || ft.NumOut() > 2 ||
+		ft.Out(ft.NumOut()-1) != errorType {
+		return fmt.Errorf("goa: cannot forward to %s, must be a client method", ft)
+	}
+	if ft.NumIn() == 1 && p != nil {
+		return fmt.Errorf("goa: cannot forward payload of type %T to %s, client method has no payload", p, ft)
+	}
+	args := []reflect.Value{reflect.ValueOf(&ctx).Elem()}
+	if ft.NumIn() == 2 {
+		in := reflect.New(ft.In(1))
+		if p != nil {
+			if err := convertJSON(p, in.Interface()); err != nil {
+				return fmt.Errorf("goa: failed to convert payload: %s", err)
+			}
+		}
+		args = append(args, in.Elem())
+	}
+	out := fv.Call(args)
+	if errv := out[len(out)-1]; !errv.IsNil() {
+		return errv.Interface().(error)
+	}
+	if len(out) == 2 && res != nil {
+		if err := convertJSON(out[0].Interface(), res); err != nil {
+			return fmt.Errorf("goa: failed to convert result: %s", err)
+		}
+	}
+	return nil
+}
+
+// errorType is the reflect type of the error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// convertJSON copies src into the value pointed by dst by encoding src to JSON
+// and decoding the result into dst.
+func convertJSON(src, dst interface{}) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dst)
+}
@@ -0,0 +1,80 @@
+package goa
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestForward(t *testing.T) {
+	type (
+		localPayload struct {
+			Token *string
+			ID    string
+			Extra int
+		}
+		remotePayload struct {
+			Token *string
+			ID    string
+		}
+		remoteResult struct {
+			ID   string
+			Name string
+		}
+		localResult struct {
+			ID   string
+			Name *string
+		}
+	)
+	var (
+		token = "secret"
+		got   *remotePayload
+		show  = func(ctx context.Context, p *remotePayload) (*remoteResult, error) {
+			got = p
+			return &remoteResult{ID: p.ID, Name: "joe"}, nil
+		}
+	)
+	var res *localResult
+	if err := Forward(context.Background(), show, &localPayload{Token: &token, ID: "42", Extra: 1}, &res); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got == nil || got.ID != "42" || got.Token == nil || *got.Token != token {
+		t.Errorf("got remote payload %+v, expected ID 42 and token %q", got, token)
+	}
+	if res == nil || res.ID != "42" || res.Name == nil || *res.Name != "joe" {
+		t.Errorf("got result %+v, expected ID 42 and name joe", res)
+	}
+
+	remoteErr := errors.New("not found")
+	del := func(ctx context.Context, p *remotePayload) error { return remoteErr }
+	if err := Forward(context.Background(), del, &localPayload{ID: "42"}, nil); err != remoteErr {
+		t.Errorf("got error %v, expected %v", err, remoteErr)
+	}
+
+	list := func(ctx context.Context) ([]*remoteResult, error) {
+		return []*remoteResult{{ID: "1"}, {ID: "2"}}, nil
+	}
+	var items []*localResult
+	if err := Forward(context.Background(), list, nil, &items); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(items) != 2 || items[1].ID != "2" {
+		t.Errorf("got items %+v, expected 2 items", items)
+	}
+
+	var called bool
+	health := func(ctx context.Context) (string, error) {
+		called = true
+		return "ok", nil
+	}
+	if err := Forward(context.Background(), health, &localPayload{ID: "42"}, nil); err == nil {
+		t.Error("expected an error when forwarding a payload to a method with no payload")
+	}
+	if called {
+		t.Error("method with no payload called with a payload")
+	}
+
+	if err := Forward(context.Background(), 42, nil, nil); err == nil {
+		t.Error("expected an error when forwarding to a non function")
+	}
+}
@@ -0,0 +1,134 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+)
+
+// PublisherFiles returns the pub/sub transport publisher files of the services
+// that define events.
+func PublisherFiles(genpkg string, root *design.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.Services {
+		data := PubSubServices.Get(svc.Name)
+		if data == nil {
+			continue
+		}
+		fw = append(fw, publisher(genpkg, svc, data), publisherTypes(genpkg, svc, data))
+	}
+	return fw
+}
+
+// publisher returns the file defining the publisher of the service events.
+func publisher(genpkg string, svc *design.ServiceExpr, data *ServiceData) *codegen.File {
+	path := filepath.Join(codegen.ServiceGendir(svc), "pubsub", codegen.SnakeCase(svc.Name), "publisher", "publisher.go")
+	title := fmt.Sprintf("%s pub/sub publisher", svc.Name)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "publisher", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "encoding/json"},
+			{Path: "goa.design/goa", Name: "goa"},
+			{Path: "goa.design/goa/pubsub"},
+			{Path: codegen.ServiceGenpkg(genpkg, svc) + "/" + codegen.SnakeCase(svc.Name), Name: data.Service.PkgName},
+		}),
+		{Name: "publisher-struct", Source: publisherStructT, Data: data},
+		{Name: "publisher-init", Source: publisherInitT, Data: data},
+	}
+	for _, e := range data.Events {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "publisher-endpoint",
+			Source: publisherEndpointT,
+			Data:   e,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// publisherTypes returns the file defining the messages published by the
+// service publisher.
+func publisherTypes(genpkg string, svc *design.ServiceExpr, data *ServiceData) *codegen.File {
+	path := filepath.Join(codegen.ServiceGendir(svc), "pubsub", codegen.SnakeCase(svc.Name), "publisher", "types.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name+" pub/sub publisher types", "publisher", []*codegen.ImportSpec{
+			{Path: codegen.ServiceGenpkg(genpkg, svc) + "/" + codegen.SnakeCase(svc.Name), Name: data.Service.PkgName},
+		}),
+	}
+	for _, t := range data.PublisherTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "publisher-type-decl",
+			Source: messageTypeDeclT,
+			Data:   t,
+		})
+	}
+	for _, e := range data.Events {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "publisher-message-init",
+			Source: messageInitT,
+			Data:   e.MessageInit,
+		})
+	}
+	for _, h := range data.PublisherTransformHelpers {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "publisher-transform-helper",
+			Source: transformHelperT,
+			Data:   h,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: ServiceData
+const publisherStructT = `{{ printf "Publisher publishes the %s service events." .Service.Name | comment }}
+type Publisher struct {
+	publisher pubsub.Publisher
+}
+`
+
+// input: ServiceData
+const publisherInitT = `{{ printf "New instantiates a publisher for the %s service events that publishes the messages with p." .Service.Name | comment }}
+func New(p pubsub.Publisher) *Publisher {
+	return &Publisher{publisher: p}
+}
+`
+
+// input: EventData
+const publisherEndpointT = `{{ printf "%s returns an endpoint that publishes the %s service %s event on the %q subject." .Method.VarName .ServiceName .Method.Name .Subject | comment }}
+func (c *Publisher) {{ .Method.VarName }}() goa.Endpoint {
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		p, ok := v.({{ .PayloadRef }})
+		if !ok {
+			return nil, goa.InvalidFieldTypeError("payload", v, {{ printf "%q" .PayloadRef }})
+		}
+		body := {{ .MessageInit.Name }}(p)
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, goa.Fault("failed to encode %s message: %s", {{ printf "%q" .Subject }}, err)
+		}
+		return nil, c.publisher.Publish(ctx, {{ printf "%q" .Subject }}, data)
+	}
+}
+`
+
+// input: TypeData
+const messageTypeDeclT = `{{ comment .Description }}
+type {{ .VarName }} {{ .Def }}
+`
+
+// input: InitData
+const messageInitT = `{{ comment .Description }}
+func {{ .Name }}({{ .ArgName }} {{ .ArgTypeRef }}) {{ .ReturnTypeRef }} {
+	{{ .Code }}
+	return {{ .ReturnVar }}
+}
+`
+
+// input: TransformFunctionData
+const transformHelperT = `{{ printf "%s builds a value of type %s from a value of type %s." .Name .ResultTypeRef .ParamTypeRef | comment }}
+func {{ .Name }}(v {{ .ParamTypeRef }}) {{ .ResultTypeRef }} {
+	{{ .Code }}
+	return res
+}
+`
@@ -0,0 +1,51 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+	"goa.design/goa/pubsub/codegen/testdata"
+)
+
+func TestPublisher(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Section string
+		Index   int
+		Code    string
+	}{
+		{"endpoint", testdata.EventDSL, "publisher-endpoint", 0, testdata.PublisherEndpointCode},
+		{"type-decl", testdata.EventDSL, "publisher-type-decl", 0, testdata.PublisherTypeDeclCode},
+		{"transform-helper", testdata.EventDSL, "publisher-transform-helper", 0, testdata.PublisherTransformHelperCode},
+		{"message-init-default", testdata.MultipleEventsDSL, "publisher-message-init", 0, testdata.PublisherMessageInitDefaultCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunPubSubDSL(t, c.DSL)
+			fs := PublisherFiles("goa.design/goa/example", design.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected 2", len(fs))
+			}
+			var sections []*codegen.SectionTemplate
+			for _, f := range fs {
+				sections = append(sections, f.Section(c.Section)...)
+			}
+			if len(sections) <= c.Index {
+				t.Fatalf("got %d sections %q, expected at least %d", len(sections), c.Section, c.Index+1)
+			}
+			code := codegen.SectionCode(t, sections[c.Index])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
+
+func TestPublisherFilesNoEvent(t *testing.T) {
+	RunPubSubDSL(t, testdata.NoEventDSL)
+	if fs := PublisherFiles("goa.design/goa/example", design.Root); len(fs) != 0 {
+		t.Errorf("got %d files, expected none", len(fs))
+	}
+}
@@ -0,0 +1,409 @@
+package codegen
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/service"
+	"goa.design/goa/design"
+)
+
+// PubSubServices holds the data computed from the design needed to generate
+// the pub/sub transport code of the services.
+var PubSubServices = NewServicesData()
+
+type (
+	// ServicesData encapsulates the data computed from the design.
+	// ServicesData is safe for concurrent use, the data of each service is
+	// computed once.
+	ServicesData struct {
+		// mu protects services.
+		mu sync.Mutex
+		// services lists the analyzed services indexed by name.
+		services map[string]*ServiceData
+	}
+
+	// ServiceData contains the data used to render the pub/sub transport
+	// code of a service.
+	ServiceData struct {
+		// Service contains the related service data.
+		Service *service.Data
+		// Events lists the service events.
+		Events []*EventData
+		// PublisherTypes lists the message types used by the publisher.
+		PublisherTypes []*TypeData
+		// SubscriberTypes lists the message types used by the
+		// subscriber.
+		SubscriberTypes []*TypeData
+		// PublisherTransformHelpers is the list of transform functions
+		// required by the publisher message constructors.
+		PublisherTransformHelpers []*codegen.TransformFunctionData
+		// SubscriberTransformHelpers is the list of transform functions
+		// required by the subscriber payload constructors.
+		SubscriberTransformHelpers []*codegen.TransformFunctionData
+	}
+
+	// EventData contains the data used to render the publisher endpoint
+	// and subscriber handler of an event.
+	EventData struct {
+		// Method is the related service method data.
+		Method *service.MethodData
+		// ServiceName is the name of the service.
+		ServiceName string
+		// Subject is the name of the subject the event is published
+		// on.
+		Subject string
+		// PayloadRef is the fully qualified reference to the method
+		// payload type.
+		PayloadRef string
+		// HandlerInit is the name of the function that builds the
+		// subscriber handler.
+		HandlerInit string
+		// PublisherMessage is the message type used to publish the
+		// event.
+		PublisherMessage *TypeData
+		// SubscriberMessage is the message type used to decode and
+		// validate the received events.
+		SubscriberMessage *TypeData
+		// MessageInit is the constructor that builds the message from
+		// the method payload.
+		MessageInit *InitData
+		// PayloadInit is the constructor that builds the method
+		// payload from the received message.
+		PayloadInit *InitData
+	}
+
+	// TypeData contains the data needed to render a message type.
+	TypeData struct {
+		// Name is the type name.
+		Name string
+		// VarName is the Go type name.
+		VarName string
+		// Description is the type human description.
+		Description string
+		// Def is the type definition Go code.
+		Def string
+		// Ref is the reference to the type.
+		Ref string
+		// ValidateDef contains the validation code.
+		ValidateDef string
+	}
+
+	// InitData contains the data needed to render a constructor.
+	InitData struct {
+		// Name is the constructor function name.
+		Name string
+		// Description is the function description.
+		Description string
+		// ArgName is the name of the function argument.
+		ArgName string
+		// ArgTypeRef is the reference to the argument type.
+		ArgTypeRef string
+		// ReturnVar is the name of the variable initialized by Code.
+		ReturnVar string
+		// ReturnTypeRef is the reference to the returned type.
+		ReturnTypeRef string
+		// Code is the transformation code.
+		Code string
+	}
+)
+
+// NewServicesData returns an empty ServicesData.
+func NewServicesData() *ServicesData {
+	return &ServicesData{services: make(map[string]*ServiceData)}
+}
+
+// Get retrieves the pub/sub transport data for the service with the given
+// name computing it if needed. It returns nil if there is no service with the
+// given name or if the service does not define events.
+func (d *ServicesData) Get(name string) *ServiceData {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if data, ok := d.services[name]; ok {
+		return data
+	}
+	svc := design.Root.Service(name)
+	if svc == nil {
+		return nil
+	}
+	data := d.analyze(svc)
+	d.services[name] = data
+	return data
+}
+
+// HasEvents returns true if at least one of the methods of the given services
+// is an event.
+func HasEvents(services []*design.ServiceExpr) bool {
+	for _, svc := range services {
+		for _, m := range svc.Methods {
+			if m.Event != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// analyze builds the pub/sub transport data of the given service, nil if the
+// service does not define events.
+func (d *ServicesData) analyze(svc *design.ServiceExpr) *ServiceData {
+	var events []*design.MethodExpr
+	for _, m := range svc.Methods {
+		if m.Event != nil {
+			events = append(events, m)
+		}
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	var (
+		sd = service.Services.Get(svc.Name)
+
+		pubSeen = make(map[string]struct{})
+		subSeen = make(map[string]struct{})
+	)
+	data := &ServiceData{Service: sd}
+	for _, m := range events {
+		var (
+			md     = sd.Method(m.Name)
+			msg    = messageType(m)
+			ut     = msg.Type.(design.UserType)
+			pubMsg = messageTypeData(ut, m.Name, false, sd, pubSeen, &data.PublisherTypes)
+			subMsg = messageTypeData(ut, m.Name, true, sd, subSeen, &data.SubscriberTypes)
+			payRef = sd.Scope.GoFullTypeRef(m.Payload, sd.PkgName)
+		)
+		pubCode, pubHelpers, err := codegen.GoTypeTransform(m.Payload.Type, ut, "p", "body", sd.PkgName, "", false, sd.Scope)
+		if err != nil {
+			panic(err) // bug, the design validation makes sure the payload is an object
+		}
+		data.PublisherTransformHelpers = codegen.AppendHelpers(data.PublisherTransformHelpers, pubHelpers)
+		subCode, subHelpers, err := codegen.GoTypeTransform(ut, m.Payload.Type, "body", "v", "", sd.PkgName, true, sd.Scope)
+		if err != nil {
+			panic(err) // bug
+		}
+		data.SubscriberTransformHelpers = codegen.AppendHelpers(data.SubscriberTransformHelpers, subHelpers)
+
+		msgInit := "New" + pubMsg.VarName
+		payInit := "New" + md.VarName + "Payload"
+		data.Events = append(data.Events, &EventData{
+			Method:            md,
+			ServiceName:       svc.Name,
+			Subject:           m.Event.Subject,
+			PayloadRef:        payRef,
+			HandlerInit:       "New" + md.VarName + "Handler",
+			PublisherMessage:  pubMsg,
+			SubscriberMessage: subMsg,
+			MessageInit: &InitData{
+				Name:          msgInit,
+				Description:   fmt.Sprintf("%s builds the message published by the %q service %q event from its payload.", msgInit, svc.Name, m.Name),
+				ArgName:       "p",
+				ArgTypeRef:    payRef,
+				ReturnVar:     "body",
+				ReturnTypeRef: pubMsg.Ref,
+				Code:          pubCode,
+			},
+			PayloadInit: &InitData{
+				Name:          payInit,
+				Description:   fmt.Sprintf("%s builds the %q service %q event payload from the received message.", payInit, svc.Name, m.Name),
+				ArgName:       "body",
+				ArgTypeRef:    subMsg.Ref,
+				ReturnVar:     "v",
+				ReturnTypeRef: payRef,
+				Code:          subCode,
+			},
+		})
+	}
+	return data
+}
+
+// messageType returns the user type describing the messages of the given
+// event method. The type is a copy of the method payload type renamed after
+// the method whose nested user types are suffixed with "Message", whose
+// attributes do not define named enum types and that does not contain the
+// internal attributes.
+func messageType(m *design.MethodExpr) *design.AttributeExpr {
+	att := design.DupAtt(m.Payload)
+	name := codegen.Goify(m.Name, true) + "Message"
+	ut, ok := att.Type.(design.UserType)
+	if ok {
+		ut.Rename(name)
+		prepareMessageType(ut.Attribute(), make(map[string]struct{}))
+	} else {
+		prepareMessageType(att, make(map[string]struct{}))
+		ut = &design.UserTypeExpr{AttributeExpr: att, TypeName: name}
+	}
+	return &design.AttributeExpr{Type: ut}
+}
+
+// prepareMessageType appends the "Message" suffix to the names of the user
+// types used by att and removes the named enum types and internal attributes.
+func prepareMessageType(att *design.AttributeExpr, seen map[string]struct{}) {
+	switch actual := att.Type.(type) {
+	case design.Primitive:
+		if _, ok := att.Metadata["struct:enum"]; ok {
+			// The metadata is shared with the attribute the message
+			// type was duplicated from, make a copy.
+			att.Metadata = att.Metadata.Dup()
+			delete(att.Metadata, "struct:enum")
+		}
+	case design.UserType:
+		if _, ok := seen[actual.ID()]; ok {
+			return
+		}
+		actual.Rename(actual.Name() + "Message")
+		seen[actual.ID()] = struct{}{}
+		prepareMessageType(actual.Attribute(), seen)
+	case *design.Object:
+		var internal []string
+		for _, nat := range *actual {
+			if _, ok := nat.Attribute.Metadata["transport:omit"]; ok {
+				internal = append(internal, nat.Name)
+				continue
+			}
+			prepareMessageType(nat.Attribute, seen)
+		}
+		for _, n := range internal {
+			actual.Delete(n)
+			if att.Validation != nil {
+				att.Validation.RemoveRequired(n)
+			}
+		}
+	case *design.Array:
+		prepareMessageType(actual.ElemType, seen)
+	case *design.Map:
+		prepareMessageType(actual.KeyType, seen)
+		prepareMessageType(actual.ElemType, seen)
+	}
+}
+
+// messageTypeData returns the data of the message type ut of the given event
+// and appends the data of the nested user types it uses to types. The
+// subscriber message types use pointers for all fields so that the generated
+// code may validate them explicitly, the publisher message types use values for
+// the required fields and the fields with default values. seen records the
+// names of the types that have already been appended.
+func messageTypeData(ut design.UserType, event string, sub bool, sd *service.Data, seen map[string]struct{}, types *[]*TypeData) *TypeData {
+	data := typeData(ut, sub, sd.Scope)
+	data.Description = fmt.Sprintf("%s is the type of the %q service %q event message.", data.VarName, sd.Name, event)
+	if _, ok := seen[ut.Name()]; !ok {
+		seen[ut.Name()] = struct{}{}
+		*types = append(*types, data)
+	}
+	collectUserTypes(ut.Attribute().Type, func(nested design.UserType) {
+		if _, ok := seen[nested.Name()]; ok {
+			return
+		}
+		seen[nested.Name()] = struct{}{}
+		d := typeData(nested, sub, sd.Scope)
+		d.Description = fmt.Sprintf("%s is used to define fields on event message types.", d.VarName)
+		*types = append(*types, d)
+	}, map[string]struct{}{ut.ID(): {}})
+	return data
+}
+
+// typeData returns the data needed to render the definition and validation
+// of the given message user type.
+func typeData(ut design.UserType, sub bool, scope *codegen.NameScope) *TypeData {
+	att := &design.AttributeExpr{Type: ut}
+	data := &TypeData{
+		Name:    ut.Name(),
+		VarName: scope.GoTypeName(att),
+		Def:     messageTypeDef(scope, ut.Attribute(), sub, !sub),
+		Ref:     scope.GoTypeRef(att),
+	}
+	if sub {
+		data.ValidateDef = codegen.RecursiveValidationCode(ut.Attribute(), true, true, false, "body")
+	}
+	return data
+}
+
+// collectUserTypes calls cb once with each of the user types used by dt.
+func collectUserTypes(dt design.DataType, cb func(design.UserType), seen map[string]struct{}) {
+	switch actual := dt.(type) {
+	case *design.Object:
+		for _, nat := range *actual {
+			collectUserTypes(nat.Attribute.Type, cb, seen)
+		}
+	case *design.Array:
+		collectUserTypes(actual.ElemType.Type, cb, seen)
+	case *design.Map:
+		collectUserTypes(actual.KeyType.Type, cb, seen)
+		collectUserTypes(actual.ElemType.Type, cb, seen)
+	case design.UserType:
+		if _, ok := seen[actual.ID()]; ok {
+			return
+		}
+		seen[actual.ID()] = struct{}{}
+		cb(actual)
+		collectUserTypes(actual.Attribute().Type, cb, seen)
+	}
+}
+
+// messageTypeDef returns the Go code that defines the struct corresponding to
+// att. It differs from the function defined in the codegen package in that it
+// defines json tags using the attribute wire names and produces fields with
+// pointers even if the corresponding attribute is required when ptr is true so
+// that the generated code may validate explicitly.
+func messageTypeDef(scope *codegen.NameScope, att *design.AttributeExpr, ptr, useDefault bool) string {
+	switch actual := att.Type.(type) {
+	case design.Primitive:
+		return codegen.GoNativeTypeName(actual)
+	case *design.Array:
+		d := messageTypeDef(scope, actual.ElemType, ptr, useDefault)
+		if design.IsObject(actual.ElemType.Type) {
+			d = "*" + d
+		}
+		return "[]" + d
+	case *design.Map:
+		keyDef := messageTypeDef(scope, actual.KeyType, ptr, useDefault)
+		if design.IsObject(actual.KeyType.Type) {
+			keyDef = "*" + keyDef
+		}
+		elemDef := messageTypeDef(scope, actual.ElemType, ptr, useDefault)
+		if design.IsObject(actual.ElemType.Type) {
+			elemDef = "*" + elemDef
+		}
+		return fmt.Sprintf("map[%s]%s", keyDef, elemDef)
+	case *design.Object:
+		var ss []string
+		ss = append(ss, "struct {")
+		for _, nat := range *actual {
+			var (
+				name = nat.Name
+				at   = nat.Attribute
+
+				fn   = codegen.GoifyAtt(at, name, true)
+				tdef = messageTypeDef(scope, at, ptr, useDefault)
+				desc string
+				o    string
+			)
+			if design.IsPrimitive(at.Type) {
+				if ptr || att.IsPrimitivePointer(name, useDefault) {
+					tdef = "*" + tdef
+				}
+			} else if design.IsObject(at.Type) {
+				tdef = "*" + tdef
+			}
+			if at.Description != "" {
+				desc = codegen.Comment(at.Description) + "\n\t"
+			}
+			if ptr || !att.IsRequired(name) {
+				o = ",omitempty"
+			}
+			tags := map[string]string{"json": codegen.WireName(name) + o}
+			for n, v := range codegen.StructTags(at) {
+				tags[n] = v
+			}
+			ss = append(ss, fmt.Sprintf("\t%s%s %s%s", desc, fn, tdef, codegen.FormatTags(tags)))
+		}
+		ss = append(ss, "}")
+		return strings.Join(ss, "\n")
+	case design.UserType:
+		return scope.GoTypeName(att)
+	default:
+		panic(fmt.Sprintf("unknown data type %T", actual)) // bug
+	}
+}
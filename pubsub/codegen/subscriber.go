@@ -0,0 +1,186 @@
+package codegen
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+)
+
+// SubscriberFiles returns the pub/sub transport subscriber files of the
+// services that define events.
+func SubscriberFiles(genpkg string, root *design.RootExpr) []*codegen.File {
+	var fw []*codegen.File
+	for _, svc := range root.Services {
+		data := PubSubServices.Get(svc.Name)
+		if data == nil {
+			continue
+		}
+		fw = append(fw, subscriber(genpkg, svc, data), subscriberTypes(genpkg, svc, data))
+	}
+	return fw
+}
+
+// subscriber returns the file defining the handlers of the service events.
+func subscriber(genpkg string, svc *design.ServiceExpr, data *ServiceData) *codegen.File {
+	path := filepath.Join(codegen.ServiceGendir(svc), "pubsub", codegen.SnakeCase(svc.Name), "subscriber", "subscriber.go")
+	title := fmt.Sprintf("%s pub/sub subscriber", svc.Name)
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(title, "subscriber", []*codegen.ImportSpec{
+			{Path: "context"},
+			{Path: "encoding/json"},
+			{Path: "goa.design/goa", Name: "goa"},
+			{Path: "goa.design/goa/pubsub"},
+			{Path: codegen.ServiceGenpkg(genpkg, svc) + "/" + codegen.SnakeCase(svc.Name), Name: data.Service.PkgName},
+		}),
+		{Name: "subscriber-struct", Source: subscriberStructT, Data: data},
+		{Name: "subscriber-init", Source: subscriberInitT, Data: data},
+		{Name: "subscriber-use", Source: subscriberUseT, Data: data},
+		{Name: "subscriber-subscribe", Source: subscriberSubscribeT, Data: data},
+	}
+	for _, e := range data.Events {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "subscriber-handler-init",
+			Source: subscriberHandlerInitT,
+			Data:   e,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// subscriberTypes returns the file defining the messages handled by the
+// service subscriber.
+func subscriberTypes(genpkg string, svc *design.ServiceExpr, data *ServiceData) *codegen.File {
+	path := filepath.Join(codegen.ServiceGendir(svc), "pubsub", codegen.SnakeCase(svc.Name), "subscriber", "types.go")
+	sections := []*codegen.SectionTemplate{
+		codegen.Header(svc.Name+" pub/sub subscriber types", "subscriber", []*codegen.ImportSpec{
+			{Path: "unicode/utf8"},
+			{Path: "goa.design/goa", Name: "goa"},
+			{Path: codegen.ServiceGenpkg(genpkg, svc) + "/" + codegen.SnakeCase(svc.Name), Name: data.Service.PkgName},
+		}),
+	}
+	for _, t := range data.SubscriberTypes {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "subscriber-type-decl",
+			Source: messageTypeDeclT,
+			Data:   t,
+		})
+	}
+	for _, e := range data.Events {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "subscriber-payload-init",
+			Source: messageInitT,
+			Data:   e.PayloadInit,
+		})
+	}
+	for _, t := range data.SubscriberTypes {
+		if t.ValidateDef != "" {
+			sections = append(sections, &codegen.SectionTemplate{
+				Name:   "subscriber-validate",
+				Source: messageValidateT,
+				Data:   t,
+			})
+		}
+	}
+	for _, h := range data.SubscriberTransformHelpers {
+		sections = append(sections, &codegen.SectionTemplate{
+			Name:   "subscriber-transform-helper",
+			Source: transformHelperT,
+			Data:   h,
+		})
+	}
+	return &codegen.File{Path: path, SectionTemplates: sections}
+}
+
+// input: ServiceData
+const subscriberStructT = `{{ printf "Subscriber lists the %s service event handlers." .Service.Name | comment }}
+type Subscriber struct {
+{{- range .Events }}
+	{{ printf "%s handles the %q messages." .Method.VarName .Subject | comment }}
+	{{ .Method.VarName }} pubsub.Handler
+{{- end }}
+}
+`
+
+// input: ServiceData
+const subscriberInitT = `{{ printf "New instantiates the handlers of the %s service events." .Service.Name | comment }}
+func New(e *{{ .Service.PkgName }}.Endpoints) *Subscriber {
+	return &Subscriber{
+{{- range .Events }}
+		{{ .Method.VarName }}: {{ .HandlerInit }}(e.{{ .Method.VarName }}),
+{{- end }}
+	}
+}
+`
+
+// input: ServiceData
+const subscriberUseT = `{{ printf "Use wraps the event handlers of the %s service with the given middleware." .Service.Name | comment }}
+func (s *Subscriber) Use(m func(pubsub.Handler) pubsub.Handler) {
+{{- range .Events }}
+	s.{{ .Method.VarName }} = m(s.{{ .Method.VarName }})
+{{- end }}
+}
+`
+
+// input: ServiceData
+const subscriberSubscribeT = `// Subscribe subscribes the event handlers to their subjects with sub. It
+// cancels the subscriptions already made and returns the error if a
+// subscription fails. The handlers stop receiving messages once ctx is done.
+func (s *Subscriber) Subscribe(ctx context.Context, sub pubsub.Subscriber) ([]pubsub.Subscription, error) {
+	handlers := []struct {
+		subject string
+		handler pubsub.Handler
+	}{
+{{- range .Events }}
+		{ {{ printf "%q" .Subject }}, s.{{ .Method.VarName }} },
+{{- end }}
+	}
+	subs := make([]pubsub.Subscription, 0, len(handlers))
+	for _, h := range handlers {
+		subn, err := sub.Subscribe(ctx, h.subject, h.handler)
+		if err != nil {
+			for _, subn := range subs {
+				subn.Unsubscribe()
+			}
+			return nil, err
+		}
+		subs = append(subs, subn)
+	}
+	return subs, nil
+}
+`
+
+// input: EventData
+const subscriberHandlerInitT = `{{ printf "%s returns a handler that decodes and validates the messages published on the %q subject and calls the %s service %s endpoint." .HandlerInit .Subject .ServiceName .Method.Name | comment }}
+func {{ .HandlerInit }}(endpoint goa.Endpoint) pubsub.Handler {
+	return func(ctx context.Context, data []byte) error {
+		ctx = context.WithValue(ctx, goa.MethodKey, {{ printf "%q" .Method.Name }})
+		ctx = context.WithValue(ctx, goa.ServiceKey, {{ printf "%q" .ServiceName }})
+		var (
+			body {{ .SubscriberMessage.VarName }}
+			err  error
+		)
+		err = json.Unmarshal(data, &body)
+		if err != nil {
+			return goa.DecodePayloadError(err.Error())
+		}
+	{{- if .SubscriberMessage.ValidateDef }}
+		err = body.Validate()
+		if err != nil {
+			return err
+		}
+	{{- end }}
+		_, err = endpoint(ctx, {{ .PayloadInit.Name }}(&body))
+		return err
+	}
+}
+`
+
+// input: TypeData
+const messageValidateT = `{{ printf "Validate runs the validations defined on %s" .Name | comment }}
+func (body {{ .Ref }}) Validate() (err error) {
+	{{ .ValidateDef }}
+	return
+}
+`
@@ -0,0 +1,47 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/design"
+	"goa.design/goa/pubsub/codegen/testdata"
+)
+
+func TestSubscriber(t *testing.T) {
+	cases := []struct {
+		Name    string
+		DSL     func()
+		Section string
+		Index   int
+		Code    string
+	}{
+		{"struct", testdata.MultipleEventsDSL, "subscriber-struct", 0, testdata.SubscriberStructCode},
+		{"subscribe", testdata.MultipleEventsDSL, "subscriber-subscribe", 0, testdata.SubscriberSubscribeCode},
+		{"handler-init", testdata.EventDSL, "subscriber-handler-init", 0, testdata.SubscriberHandlerInitCode},
+		{"handler-init-no-validation", testdata.MultipleEventsDSL, "subscriber-handler-init", 1, testdata.SubscriberHandlerInitNoValidationCode},
+		{"type-decl", testdata.EventDSL, "subscriber-type-decl", 0, testdata.SubscriberTypeDeclCode},
+		{"payload-init-default", testdata.MultipleEventsDSL, "subscriber-payload-init", 0, testdata.SubscriberPayloadInitDefaultCode},
+		{"validate", testdata.EventDSL, "subscriber-validate", 0, testdata.SubscriberValidateCode},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			RunPubSubDSL(t, c.DSL)
+			fs := SubscriberFiles("goa.design/goa/example", design.Root)
+			if len(fs) != 2 {
+				t.Fatalf("got %d files, expected 2", len(fs))
+			}
+			var sections []*codegen.SectionTemplate
+			for _, f := range fs {
+				sections = append(sections, f.Section(c.Section)...)
+			}
+			if len(sections) <= c.Index {
+				t.Fatalf("got %d sections %q, expected at least %d", len(sections), c.Section, c.Index+1)
+			}
+			code := codegen.SectionCode(t, sections[c.Index])
+			if code != c.Code {
+				t.Errorf("invalid code, got:\n%s\ngot vs. expected:\n%s", code, codegen.Diff(t, code, c.Code))
+			}
+		})
+	}
+}
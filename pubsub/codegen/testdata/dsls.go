@@ -0,0 +1,69 @@
+package testdata
+
+import (
+	. "goa.design/goa/design"
+	. "goa.design/goa/dsl"
+)
+
+var EventDSL = func() {
+	var Item = Type("Item", func() {
+		Attribute("name", String)
+		Attribute("tags", ArrayOf(String), func() {
+			MaxLength(3)
+		})
+		Required("name")
+	})
+	var Order = Type("Order", func() {
+		Attribute("id", String, func() {
+			MinLength(8)
+		})
+		Attribute("quantity", Int, func() {
+			Minimum(1)
+		})
+		Attribute("item", Item)
+		Attribute("note", String, func() {
+			Internal()
+		})
+		Required("id", "quantity", "note")
+	})
+	Service("orders", func() {
+		Method("created", func() {
+			Payload(Order)
+			Event("orders.created")
+		})
+		Method("list", func() {
+			Result(ArrayOf(Order))
+		})
+	})
+}
+
+var MultipleEventsDSL = func() {
+	Service("orders", func() {
+		Method("created", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("priority", Int, func() {
+					Default(1)
+				})
+				Required("id")
+			})
+			Event("orders.created")
+		})
+		Method("cancelled", func() {
+			Payload(func() {
+				Attribute("id", String)
+				Attribute("reason", String)
+			})
+			Event("orders.cancelled")
+		})
+	})
+}
+
+var NoEventDSL = func() {
+	Service("orders", func() {
+		Method("show", func() {
+			Payload(String)
+			Result(String)
+		})
+	})
+}
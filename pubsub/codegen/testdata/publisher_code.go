@@ -0,0 +1,58 @@
+package testdata
+
+var PublisherEndpointCode = `// Created returns an endpoint that publishes the orders service created event
+// on the "orders.created" subject.
+func (c *Publisher) Created() goa.Endpoint {
+	return func(ctx context.Context, v interface{}) (interface{}, error) {
+		p, ok := v.(*orders.Order)
+		if !ok {
+			return nil, goa.InvalidFieldTypeError("payload", v, "*orders.Order")
+		}
+		body := NewCreatedMessage(p)
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, goa.Fault("failed to encode %s message: %s", "orders.created", err)
+		}
+		return nil, c.publisher.Publish(ctx, "orders.created", data)
+	}
+}
+`
+
+var PublisherTypeDeclCode = `// CreatedMessage is the type of the "orders" service "created" event message.
+type CreatedMessage struct {
+	ID       string       ` + "`" + `json:"id"` + "`" + `
+	Quantity int          ` + "`" + `json:"quantity"` + "`" + `
+	Item     *ItemMessage ` + "`" + `json:"item,omitempty"` + "`" + `
+}
+`
+
+var PublisherMessageInitDefaultCode = `// NewCreatedMessage builds the message published by the "orders" service
+// "created" event from its payload.
+func NewCreatedMessage(p *orders.CreatedPayload) *CreatedMessage {
+	body := &CreatedMessage{
+		ID:       p.ID,
+		Priority: p.Priority,
+	}
+	return body
+}
+`
+
+var PublisherTransformHelperCode = `// marshalItemToItemMessage builds a value of type *ItemMessage from a value of
+// type *orders.Item.
+func marshalItemToItemMessage(v *orders.Item) *ItemMessage {
+	if v == nil {
+		return nil
+	}
+	res := &ItemMessage{
+		Name: v.Name,
+	}
+	if v.Tags != nil {
+		res.Tags = make([]string, len(v.Tags))
+		for j, val := range v.Tags {
+			res.Tags[j] = val
+		}
+	}
+
+	return res
+}
+`
@@ -0,0 +1,133 @@
+package testdata
+
+var SubscriberStructCode = `// Subscriber lists the orders service event handlers.
+type Subscriber struct {
+	// Created handles the "orders.created" messages.
+	Created pubsub.Handler
+	// Cancelled handles the "orders.cancelled" messages.
+	Cancelled pubsub.Handler
+}
+`
+
+var SubscriberSubscribeCode = `// Subscribe subscribes the event handlers to their subjects with sub. It
+// cancels the subscriptions already made and returns the error if a
+// subscription fails. The handlers stop receiving messages once ctx is done.
+func (s *Subscriber) Subscribe(ctx context.Context, sub pubsub.Subscriber) ([]pubsub.Subscription, error) {
+	handlers := []struct {
+		subject string
+		handler pubsub.Handler
+	}{
+		{"orders.created", s.Created},
+		{"orders.cancelled", s.Cancelled},
+	}
+	subs := make([]pubsub.Subscription, 0, len(handlers))
+	for _, h := range handlers {
+		subn, err := sub.Subscribe(ctx, h.subject, h.handler)
+		if err != nil {
+			for _, subn := range subs {
+				subn.Unsubscribe()
+			}
+			return nil, err
+		}
+		subs = append(subs, subn)
+	}
+	return subs, nil
+}
+`
+
+var SubscriberHandlerInitCode = `// NewCreatedHandler returns a handler that decodes and validates the messages
+// published on the "orders.created" subject and calls the orders service
+// created endpoint.
+func NewCreatedHandler(endpoint goa.Endpoint) pubsub.Handler {
+	return func(ctx context.Context, data []byte) error {
+		ctx = context.WithValue(ctx, goa.MethodKey, "created")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "orders")
+		var (
+			body CreatedMessage
+			err  error
+		)
+		err = json.Unmarshal(data, &body)
+		if err != nil {
+			return goa.DecodePayloadError(err.Error())
+		}
+		err = body.Validate()
+		if err != nil {
+			return err
+		}
+		_, err = endpoint(ctx, NewCreatedPayload(&body))
+		return err
+	}
+}
+`
+
+var SubscriberHandlerInitNoValidationCode = `// NewCancelledHandler returns a handler that decodes and validates the
+// messages published on the "orders.cancelled" subject and calls the orders
+// service cancelled endpoint.
+func NewCancelledHandler(endpoint goa.Endpoint) pubsub.Handler {
+	return func(ctx context.Context, data []byte) error {
+		ctx = context.WithValue(ctx, goa.MethodKey, "cancelled")
+		ctx = context.WithValue(ctx, goa.ServiceKey, "orders")
+		var (
+			body CancelledMessage
+			err  error
+		)
+		err = json.Unmarshal(data, &body)
+		if err != nil {
+			return goa.DecodePayloadError(err.Error())
+		}
+		_, err = endpoint(ctx, NewCancelledPayload(&body))
+		return err
+	}
+}
+`
+
+var SubscriberTypeDeclCode = `// CreatedMessage is the type of the "orders" service "created" event message.
+type CreatedMessage struct {
+	ID       *string      ` + "`" + `json:"id,omitempty"` + "`" + `
+	Quantity *int         ` + "`" + `json:"quantity,omitempty"` + "`" + `
+	Item     *ItemMessage ` + "`" + `json:"item,omitempty"` + "`" + `
+}
+`
+
+var SubscriberPayloadInitDefaultCode = `// NewCreatedPayload builds the "orders" service "created" event payload from
+// the received message.
+func NewCreatedPayload(body *CreatedMessage) *orders.CreatedPayload {
+	v := &orders.CreatedPayload{
+		ID: *body.ID,
+	}
+	if body.Priority != nil {
+		v.Priority = *body.Priority
+	}
+	if body.Priority == nil {
+		v.Priority = 1
+	}
+	return v
+}
+`
+
+var SubscriberValidateCode = `// Validate runs the validations defined on CreatedMessage
+func (body *CreatedMessage) Validate() (err error) {
+	if body.ID == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("id", "body"))
+	}
+	if body.Quantity == nil {
+		err = goa.MergeErrors(err, goa.MissingFieldError("quantity", "body"))
+	}
+	if body.ID != nil {
+		if utf8.RuneCountInString(*body.ID) < 8 {
+			err = goa.MergeErrors(err, goa.InvalidLengthError("body.id", *body.ID, utf8.RuneCountInString(*body.ID), 8, true))
+		}
+	}
+	if body.Quantity != nil {
+		if *body.Quantity < 1 {
+			err = goa.MergeErrors(err, goa.InvalidRangeError("body.quantity", *body.Quantity, 1, true))
+		}
+	}
+	if body.Item != nil {
+		if err2 := body.Item.Validate(); err2 != nil {
+			err = goa.MergeErrors(err, err2)
+		}
+	}
+	return
+}
+`
@@ -0,0 +1,17 @@
+package codegen
+
+import (
+	"testing"
+
+	"goa.design/goa/codegen"
+	"goa.design/goa/codegen/service"
+	"goa.design/goa/design"
+)
+
+// RunPubSubDSL returns the DSL root resulting from running the given DSL.
+func RunPubSubDSL(t *testing.T, dsl func()) *design.RootExpr {
+	// reset all roots and codegen data structures
+	service.Services = service.NewServicesData()
+	PubSubServices = NewServicesData()
+	return codegen.RunDSL(t, dsl)
+}
@@ -0,0 +1,135 @@
+/*
+Package kafkapubsub implements the pubsub Publisher and Subscriber interfaces
+on top of Kafka writers and readers (https://github.com/segmentio/kafka-go) so
+that the generated pub/sub transport code can publish and handle events with
+Kafka. The event subjects are used as topic names:
+
+	pub := kafkapubsub.NewPublisher(&kafka.Writer{Addr: kafka.TCP("localhost:9092")})
+	sub := kafkapubsub.NewSubscriber(kafka.ReaderConfig{
+		Brokers: []string{"localhost:9092"},
+		GroupID: "orders",
+	})
+*/
+package kafkapubsub
+
+import (
+	"context"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+	"goa.design/goa/pubsub"
+)
+
+type (
+	// Publisher publishes messages using a Kafka writer.
+	Publisher struct {
+		w *kafka.Writer
+	}
+
+	// Subscriber subscribes to topics using Kafka readers.
+	Subscriber struct {
+		config kafka.ReaderConfig
+		errh   pubsub.ErrorHandler
+	}
+
+	// Option configures a Subscriber.
+	Option func(*Subscriber)
+
+	// subscription reads the messages of a topic until cancelled.
+	subscription struct {
+		reader *kafka.Reader
+		cancel context.CancelFunc
+		done   chan struct{}
+		once   sync.Once
+		err    error
+	}
+)
+
+// NewPublisher returns a publisher that writes the messages with w. w must not
+// set a Topic, the messages are written to the topic named after the event
+// subject.
+func NewPublisher(w *kafka.Writer) *Publisher {
+	return &Publisher{w: w}
+}
+
+// Publish writes data to the topic.
+func (p *Publisher) Publish(ctx context.Context, subject string, data []byte) error {
+	return p.w.WriteMessages(ctx, kafka.Message{Topic: subject, Value: data})
+}
+
+// NewSubscriber returns a subscriber that reads the messages with readers
+// created from config. Subscribe sets the config Topic. Setting a GroupID
+// makes the subscribers share the messages of each topic and commits the
+// offsets of the handled messages.
+func NewSubscriber(config kafka.ReaderConfig, opts ...Option) *Subscriber {
+	s := &Subscriber{config: config}
+	for _, o := range opts {
+		o(s)
+	}
+	return s
+}
+
+// WithErrorHandler sets the function called with the errors returned by the
+// subscription handlers and by the readers.
+func WithErrorHandler(errh pubsub.ErrorHandler) Option {
+	return func(s *Subscriber) {
+		s.errh = errh
+	}
+}
+
+// Subscribe starts reading the messages of the topic and calls h with each
+// message until the subscription is cancelled or ctx is done. The offset of a
+// message is committed once h returns even if it returns an error, the error
+// is given to the error handler.
+func (s *Subscriber) Subscribe(ctx context.Context, subject string, h pubsub.Handler) (pubsub.Subscription, error) {
+	config := s.config
+	config.Topic = subject
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &subscription{
+		reader: kafka.NewReader(config),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(sub.done)
+		for {
+			m, err := sub.reader.FetchMessage(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.handleError(ctx, subject, err)
+				}
+				return
+			}
+			if err := h(ctx, m.Value); err != nil {
+				s.handleError(ctx, subject, err)
+			}
+			if config.GroupID == "" {
+				continue
+			}
+			if err := sub.reader.CommitMessages(ctx, m); err != nil && ctx.Err() == nil {
+				s.handleError(ctx, subject, err)
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// handleError calls the error handler if any.
+func (s *Subscriber) handleError(ctx context.Context, subject string, err error) {
+	if s.errh != nil {
+		s.errh(ctx, subject, err)
+	}
+}
+
+// Unsubscribe stops reading messages and closes the reader.
+func (s *subscription) Unsubscribe() error {
+	s.once.Do(func() {
+		s.cancel()
+		<-s.done
+		s.err = s.reader.Close()
+	})
+	return s.err
+}
@@ -0,0 +1,94 @@
+/*
+Package natspubsub implements the pubsub Publisher and Subscriber interfaces on
+top of a NATS connection (https://github.com/nats-io/nats.go) so that the
+generated pub/sub transport code can publish and handle events with NATS:
+
+	nc, err := nats.Connect(nats.DefaultURL)
+	...
+	broker := natspubsub.New(nc, natspubsub.WithQueue("orders"))
+	subs, err := subscriber.New(endpoints).Subscribe(ctx, broker)
+*/
+package natspubsub
+
+import (
+	"context"
+
+	natsio "github.com/nats-io/nats.go"
+	"goa.design/goa/pubsub"
+)
+
+type (
+	// Broker publishes and subscribes to messages using a NATS
+	// connection.
+	Broker struct {
+		conn  *natsio.Conn
+		queue string
+		errh  pubsub.ErrorHandler
+	}
+
+	// Option configures a Broker.
+	Option func(*Broker)
+)
+
+// New returns a broker that publishes and subscribes to messages using the
+// given NATS connection.
+func New(conn *natsio.Conn, opts ...Option) *Broker {
+	b := &Broker{conn: conn}
+	for _, o := range opts {
+		o(b)
+	}
+	return b
+}
+
+// WithQueue makes the subscriptions join the NATS queue group with the given
+// name so that each message is handled by a single member of the group.
+func WithQueue(name string) Option {
+	return func(b *Broker) {
+		b.queue = name
+	}
+}
+
+// WithErrorHandler sets the function called with the errors returned by the
+// subscription handlers.
+func WithErrorHandler(errh pubsub.ErrorHandler) Option {
+	return func(b *Broker) {
+		b.errh = errh
+	}
+}
+
+// Publish publishes data on the NATS subject.
+func (b *Broker) Publish(ctx context.Context, subject string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return b.conn.Publish(subject, data)
+}
+
+// Subscribe subscribes h to the NATS subject. The subscription is cancelled
+// when ctx is done.
+func (b *Broker) Subscribe(ctx context.Context, subject string, h pubsub.Handler) (pubsub.Subscription, error) {
+	cb := func(m *natsio.Msg) {
+		if err := h(ctx, m.Data); err != nil && b.errh != nil {
+			b.errh(ctx, subject, err)
+		}
+	}
+	var (
+		sub *natsio.Subscription
+		err error
+	)
+	if b.queue != "" {
+		sub, err = b.conn.QueueSubscribe(subject, b.queue, cb)
+	} else {
+		sub, err = b.conn.Subscribe(subject, cb)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if done := ctx.Done(); done != nil {
+		go func() {
+			<-done
+			sub.Unsubscribe()
+		}()
+	}
+	return sub, nil
+}
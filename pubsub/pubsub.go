@@ -0,0 +1,126 @@
+/*
+Package pubsub defines the interfaces used by the generated pub/sub transport
+code to publish the events defined in the design with the Event DSL and to
+subscribe to them. The natspubsub and kafkapubsub packages implement the
+interfaces on top of NATS and Kafka, Broker implements them in memory for tests
+and single process applications:
+
+	broker := pubsub.NewBroker()
+	subs, err := subscriber.New(endpoints).Subscribe(ctx, broker)
+	...
+	pub := publisher.New(broker)
+	_, err = pub.Created()(ctx, &orders.Order{ID: "1", Quantity: 2})
+*/
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+type (
+	// Handler handles the messages received on a subject. The generated
+	// subscriber handlers decode and validate the message data before
+	// calling the service method endpoint.
+	Handler func(ctx context.Context, data []byte) error
+
+	// ErrorHandler is called with the errors returned by the handlers. The
+	// drivers cannot report these errors to the publishers as publishing
+	// does not wait for the messages to be handled.
+	ErrorHandler func(ctx context.Context, subject string, err error)
+
+	// Publisher publishes messages on subjects.
+	Publisher interface {
+		// Publish publishes the message data on the given subject.
+		Publish(ctx context.Context, subject string, data []byte) error
+	}
+
+	// Subscriber subscribes to the messages published on subjects.
+	Subscriber interface {
+		// Subscribe calls h with the messages published on the given
+		// subject until the subscription is cancelled or ctx is done.
+		// ctx is the parent context of the contexts given to h.
+		Subscribe(ctx context.Context, subject string, h Handler) (Subscription, error)
+	}
+
+	// Subscription is the subscription to the messages of a subject.
+	Subscription interface {
+		// Unsubscribe cancels the subscription.
+		Unsubscribe() error
+	}
+
+	// Broker is an in-memory message broker that implements both Publisher
+	// and Subscriber. Publish calls the handlers of the subject
+	// subscriptions synchronously.
+	Broker struct {
+		// ErrorHandler if not nil is called with the errors returned
+		// by the handlers.
+		ErrorHandler ErrorHandler
+
+		mu   sync.RWMutex
+		subs map[string][]*subscription
+	}
+
+	// subscription is the Broker subscription.
+	subscription struct {
+		broker  *Broker
+		ctx     context.Context
+		subject string
+		handler Handler
+	}
+)
+
+// NewBroker returns an in-memory message broker.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[string][]*subscription)}
+}
+
+// Publish calls the handlers of the subject subscriptions with data. It
+// returns once all the handlers have returned.
+func (b *Broker) Publish(ctx context.Context, subject string, data []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b.mu.RLock()
+	subs := b.subs[subject]
+	b.mu.RUnlock()
+	for _, s := range subs {
+		if s.ctx.Err() != nil {
+			continue
+		}
+		if err := s.handler(s.ctx, data); err != nil && b.ErrorHandler != nil {
+			b.ErrorHandler(s.ctx, subject, err)
+		}
+	}
+	return nil
+}
+
+// Subscribe registers h with the messages published on subject.
+func (b *Broker) Subscribe(ctx context.Context, subject string, h Handler) (Subscription, error) {
+	s := &subscription{broker: b, ctx: ctx, subject: subject, handler: h}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	subs := make([]*subscription, len(b.subs[subject]), len(b.subs[subject])+1)
+	copy(subs, b.subs[subject])
+	b.subs[subject] = append(subs, s)
+	return s, nil
+}
+
+// Unsubscribe removes the subscription from the broker.
+func (s *subscription) Unsubscribe() error {
+	b := s.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var subs []*subscription
+	for _, sub := range b.subs[s.subject] {
+		if sub != s {
+			subs = append(subs, sub)
+		}
+	}
+	if len(subs) == 0 {
+		delete(b.subs, s.subject)
+	} else {
+		b.subs[s.subject] = subs
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBroker(t *testing.T) {
+	var (
+		b        = NewBroker()
+		ctx      = context.Background()
+		received []string
+		errs     []error
+	)
+	b.ErrorHandler = func(_ context.Context, subject string, err error) {
+		errs = append(errs, err)
+	}
+	h := func(name string, err error) Handler {
+		return func(_ context.Context, data []byte) error {
+			received = append(received, name+":"+string(data))
+			return err
+		}
+	}
+	errFailed := errors.New("failed")
+	s1, _ := b.Subscribe(ctx, "a", h("s1", nil))
+	b.Subscribe(ctx, "a", h("s2", errFailed))
+	b.Subscribe(ctx, "b", h("s3", nil))
+
+	if err := b.Publish(ctx, "a", []byte("1")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := s1.Unsubscribe(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	b.Publish(ctx, "a", []byte("2"))
+	b.Publish(ctx, "c", []byte("3"))
+
+	expected := []string{"s1:1", "s2:1", "s2:2"}
+	if !reflect.DeepEqual(received, expected) {
+		t.Errorf("got messages %v, expected %v", received, expected)
+	}
+	if len(errs) != 2 || errs[0] != errFailed {
+		t.Errorf("got errors %v, expected two %q errors", errs, errFailed)
+	}
+
+	cctx, cancel := context.WithCancel(ctx)
+	b.Subscribe(cctx, "b", h("s4", nil))
+	cancel()
+	received = nil
+	b.Publish(ctx, "b", []byte("4"))
+	if expected := []string{"s3:4"}; !reflect.DeepEqual(received, expected) {
+		t.Errorf("got messages %v after cancel, expected %v", received, expected)
+	}
+	if err := b.Publish(cctx, "b", []byte("5")); err != context.Canceled {
+		t.Errorf("got error %v publishing with cancelled context, expected %v", err, context.Canceled)
+	}
+}
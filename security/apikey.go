@@ -0,0 +1,37 @@
+package security
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+)
+
+// ErrInvalidAPIKey is the error returned by the authorization functions
+// created with AuthAPIKeys when the request key is not in the set of valid
+// keys.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// AuthAPIKeys returns an API key authorization function that accepts any of
+// the keys returned by keys. This makes it possible to rotate keys without
+// downtime: the new key is added as primary key and the previous key is kept
+// as secondary key until all clients have been updated. Keys are compared in
+// constant time.
+func AuthAPIKeys(keys APIKeysFunc) AuthAPIKeyFunc {
+	return func(ctx context.Context, key string, s *APIKeyScheme) (context.Context, error) {
+		valid, err := keys(ctx, s)
+		if err != nil {
+			return ctx, err
+		}
+		var found int
+		for _, k := range valid {
+			if k == "" {
+				continue
+			}
+			found |= subtle.ConstantTimeCompare([]byte(key), []byte(k))
+		}
+		if found == 0 {
+			return ctx, ErrInvalidAPIKey
+		}
+		return ctx, nil
+	}
+}
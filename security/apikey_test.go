@@ -0,0 +1,40 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAuthAPIKeys(t *testing.T) {
+	keys := func(ctx context.Context, s *APIKeyScheme) ([]string, error) {
+		if s.Name != "api_key" {
+			return nil, errors.New("unknown scheme")
+		}
+		return []string{"primary", "", "secondary"}, nil
+	}
+	cases := []struct {
+		Name   string
+		Key    string
+		Scheme string
+		Error  error
+	}{
+		{"primary", "primary", "api_key", nil},
+		{"secondary", "secondary", "api_key", nil},
+		{"invalid", "invalid", "api_key", ErrInvalidAPIKey},
+		{"empty", "", "api_key", ErrInvalidAPIKey},
+		{"keys error", "primary", "other", errors.New("unknown scheme")},
+	}
+	auth := AuthAPIKeys(keys)
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			_, err := auth(context.Background(), c.Key, &APIKeyScheme{Name: c.Scheme})
+			if c.Error == nil && err != nil {
+				t.Fatalf("unexpected error %s", err)
+			}
+			if c.Error != nil && (err == nil || err.Error() != c.Error.Error()) {
+				t.Errorf("got error %v, expected %s", err, c.Error)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ClientCredentialsFlowType is the value of the Type field of the OAuth2 client
+// credentials flows.
+const ClientCredentialsFlowType = "client_credentials"
+
+type (
+	// tokenCache caches the access tokens retrieved by a token source.
+	tokenCache struct {
+		sync.Mutex
+		tokens map[string]*cachedToken
+	}
+
+	// cachedToken is an access token and its expiry time.
+	cachedToken struct {
+		value   string
+		expires time.Time
+	}
+
+	// tokenResponse is the token endpoint response body as described in
+	// section 5.1 of RFC 6749.
+	tokenResponse struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+)
+
+// ClientCredentialsTokenSource returns a token source that retrieves access
+// tokens using the client credentials flow described in section 4.4 of RFC
+// 6749. The token request is sent to the token URL of the client credentials
+// flow of the scheme and requests the scheme required scopes. The tokens are
+// cached until they expire. ClientCredentialsTokenSource uses
+// http.DefaultClient if c is nil.
+func ClientCredentialsTokenSource(clientID, clientSecret string, c *http.Client) OAuth2TokenSource {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	cache := &tokenCache{tokens: make(map[string]*cachedToken)}
+	return func(ctx context.Context, s *OAuth2Scheme) (string, error) {
+		var tokenURL string
+		for _, f := range s.Flows {
+			if f.Type == ClientCredentialsFlowType {
+				tokenURL = f.TokenURL
+				break
+			}
+		}
+		if tokenURL == "" {
+			return "", fmt.Errorf("security scheme %q does not define a client credentials flow", s.Name)
+		}
+		scope := strings.Join(s.RequiredScopes, " ")
+		key := tokenURL + " " + scope
+		cache.Lock()
+		defer cache.Unlock()
+		if t, ok := cache.tokens[key]; ok && (t.expires.IsZero() || time.Now().Before(t.expires)) {
+			return t.value, nil
+		}
+		form := url.Values{"grant_type": {"client_credentials"}}
+		if scope != "" {
+			form.Set("scope", scope)
+		}
+		req, err := http.NewRequest("POST", tokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return "", err
+		}
+		req = req.WithContext(ctx)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+		resp, err := c.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("token request to %s failed with status %d", tokenURL, resp.StatusCode)
+		}
+		var tr tokenResponse
+		if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+			return "", fmt.Errorf("invalid token response: %s", err)
+		}
+		if tr.AccessToken == "" {
+			return "", fmt.Errorf("token response does not contain an access token")
+		}
+		t := &cachedToken{value: tr.AccessToken}
+		if tr.ExpiresIn > 0 {
+			// Renew the token a little before it expires.
+			t.expires = time.Now().Add(time.Duration(tr.ExpiresIn)*time.Second - 10*time.Second)
+		}
+		cache.tokens[key] = t
+		return t.value, nil
+	}
+}
@@ -0,0 +1,53 @@
+package security
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientCredentialsTokenSource(t *testing.T) {
+	var calls int
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if gt := r.PostForm.Get("grant_type"); gt != "client_credentials" {
+			t.Errorf("got grant type %q, expected client_credentials", gt)
+		}
+		if scope := r.PostForm.Get("scope"); scope != "api:read api:write" {
+			t.Errorf("got scope %q, expected %q", scope, "api:read api:write")
+		}
+		if id, secret, _ := r.BasicAuth(); id != "id" || secret != "secret" {
+			t.Errorf("got credentials %q/%q, expected id/secret", id, secret)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer svr.Close()
+
+	s := &OAuth2Scheme{
+		Name:           "oauth2",
+		RequiredScopes: []string{"api:read", "api:write"},
+		Flows:          []*OAuthFlow{{Type: ClientCredentialsFlowType, TokenURL: svr.URL}},
+	}
+	ts := ClientCredentialsTokenSource("id", "secret", nil)
+	for i := 0; i < 2; i++ {
+		token, err := ts(context.Background(), s)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if token != "token" {
+			t.Errorf("got token %q, expected %q", token, "token")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("got %d token requests, expected 1", calls)
+	}
+
+	if _, err := ts(context.Background(), &OAuth2Scheme{Name: "implicit"}); err == nil {
+		t.Error("expected an error for a scheme without client credentials flow")
+	}
+}
@@ -73,6 +73,17 @@ type (
 	// AuthJWTFunc is the function type that implements the JWT
 	// scheme of using a JWT token.
 	AuthJWTFunc func(ctx context.Context, token string, s *JWTScheme) (context.Context, error)
+
+	// OAuth2TokenSource is the function type used by clients to retrieve
+	// the OAuth2 access token sent with the requests made to methods
+	// secured with the given scheme.
+	OAuth2TokenSource func(ctx context.Context, s *OAuth2Scheme) (string, error)
+
+	// APIKeysFunc is the function type used by servers to retrieve the set
+	// of API keys accepted by the given scheme. The first key is the
+	// primary key, the others are secondary keys that remain valid while
+	// clients are rotated to the primary key.
+	APIKeysFunc func(ctx context.Context, s *APIKeyScheme) ([]string, error)
 )
 
 // Validate returns a non-nil error if scopes does not contain all of
@@ -104,5 +115,5 @@ func validateScopes(expected, actual []string) error {
 	if len(missing) == 0 {
 		return nil
 	}
-	return fmt.Errorf("missing sopes: %s", strings.Join(missing, ", "))
+	return fmt.Errorf("missing scopes: %s", strings.Join(missing, ", "))
 }
@@ -0,0 +1,45 @@
+package security
+
+import (
+	"context"
+
+	"goa.design/goa"
+)
+
+// InsufficientScopeErrorName is the name of the errors returned by CheckScopes
+// when the request credentials do not grant the scopes required by an
+// endpoint. The HTTP transport maps these errors to 403 Forbidden responses.
+const InsufficientScopeErrorName = "insufficient_scope"
+
+// scopesKey is the context key used to store the scopes granted to a request.
+type scopesKey struct{}
+
+// ContextWithScopes returns a copy of ctx that holds the given scopes.
+// Authorization functions use ContextWithScopes to record the scopes granted
+// by the request credentials so that the generated code may enforce the
+// scopes required by the endpoint.
+func ContextWithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, scopes)
+}
+
+// ContextScopes returns the scopes recorded in ctx with ContextWithScopes. The
+// boolean is false if no scopes were recorded.
+func ContextScopes(ctx context.Context) ([]string, bool) {
+	scopes, ok := ctx.Value(scopesKey{}).([]string)
+	return scopes, ok
+}
+
+// CheckScopes returns an insufficient scope error if the scopes recorded in ctx
+// do not include all the required scopes. CheckScopes returns nil if ctx does
+// not record any scope, in which case the authorization function is
+// responsible for validating the scopes.
+func CheckScopes(ctx context.Context, required []string) error {
+	scopes, ok := ContextScopes(ctx)
+	if !ok {
+		return nil
+	}
+	if err := validateScopes(required, scopes); err != nil {
+		return goa.PermanentError(InsufficientScopeErrorName, "%s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package security
+
+import (
+	"context"
+	"testing"
+
+	"goa.design/goa"
+)
+
+func TestCheckScopes(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Scopes   []string
+		Record   bool
+		Required []string
+		Error    bool
+	}{
+		{"not recorded", nil, false, []string{"api:read"}, false},
+		{"not recorded no required scope", nil, false, nil, false},
+		{"no required scope", nil, true, nil, false},
+		{"granted", []string{"api:read", "api:write"}, true, []string{"api:read"}, false},
+		{"missing", []string{"api:read"}, true, []string{"api:read", "api:write"}, true},
+		{"none granted", nil, true, []string{"api:read"}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			ctx := context.Background()
+			if c.Record {
+				ctx = ContextWithScopes(ctx, c.Scopes)
+			}
+			err := CheckScopes(ctx, c.Required)
+			if !c.Error {
+				if err != nil {
+					t.Errorf("unexpected error %s", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			serr, ok := err.(*goa.ServiceError)
+			if !ok {
+				t.Fatalf("got error type %T, expected *goa.ServiceError", err)
+			}
+			if serr.Name != InsufficientScopeErrorName {
+				t.Errorf("got error name %q, expected %q", serr.Name, InsufficientScopeErrorName)
+			}
+		})
+	}
+}
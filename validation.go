@@ -83,6 +83,8 @@ var (
 //     - "cidr": RFC4632 and RFC4291 CIDR notation IP address value
 //     - "regexp": Regular expression syntax accepted by RE2
 //     - "rfc1123": RFC1123 date time value
+//
+// Additional formats may be registered with RegisterFormat.
 func ValidateFormat(name string, val string, f Format) error {
 	var err error
 	switch f {
@@ -129,7 +131,13 @@ func ValidateFormat(name string, val string, f Format) error {
 	case FormatRFC1123:
 		_, err = time.Parse(time.RFC1123, val)
 	default:
-		return fmt.Errorf("unknown format %#v", f)
+		customFormatsLock.RLock()
+		fn, ok := customFormats[f]
+		customFormatsLock.RUnlock()
+		if !ok {
+			return fmt.Errorf("unknown format %#v", f)
+		}
+		err = fn(val)
 	}
 	if err != nil {
 		return InvalidFormatError(name, val, f, err)
@@ -137,6 +145,36 @@ func ValidateFormat(name string, val string, f Format) error {
 	return nil
 }
 
+// RegisterFormat registers a custom format with the given name. fn returns a
+// non nil error if the value does not conform to the format. Registered
+// formats may be used in the Format DSL of the design and are validated by
+// ValidateFormat. The built-in formats cannot be overridden.
+//
+// The format must be registered both when the design is evaluated and when
+// the generated code runs, a common practice consists of registering the
+// formats in the init function of a package imported by both the design and
+// the service implementation.
+func RegisterFormat(f Format, fn func(string) error) {
+	customFormatsLock.Lock()
+	defer customFormatsLock.Unlock()
+	customFormats[f] = fn
+}
+
+// IsFormatRegistered returns true if a custom format with the given name was
+// registered with RegisterFormat.
+func IsFormatRegistered(f Format) bool {
+	customFormatsLock.RLock()
+	defer customFormatsLock.RUnlock()
+	_, ok := customFormats[f]
+	return ok
+}
+
+// customFormats records the formats registered with RegisterFormat.
+var customFormats = make(map[Format]func(string) error)
+
+// customFormatsLock is the mutex used to access customFormats.
+var customFormatsLock = &sync.RWMutex{}
+
 // knownPatterns records the compiled patterns.
 // TBD: refactor all this so that the generated code initializes the map on start to get rid of the
 // need for a RW mutex.
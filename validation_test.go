@@ -117,3 +117,38 @@ func TestValidatePattern(t *testing.T) {
 		}
 	}
 }
+
+func TestRegisterFormat(t *testing.T) {
+	var (
+		name   = "foo"
+		format = Format("even")
+		errOdd = fmt.Errorf("odd length")
+	)
+	RegisterFormat(format, func(v string) error {
+		if len(v)%2 != 0 {
+			return errOdd
+		}
+		return nil
+	})
+	if !IsFormatRegistered(format) {
+		t.Fatalf("format %q not registered", format)
+	}
+	cases := map[string]struct {
+		val      string
+		format   Format
+		expected error
+	}{
+		"valid value":    {"ab", format, nil},
+		"invalid value":  {"abc", format, InvalidFormatError(name, "abc", format, errOdd)},
+		"unknown format": {"ab", "unknown", fmt.Errorf("unknown format %#v", Format("unknown"))},
+	}
+
+	for k, tc := range cases {
+		actual := ValidateFormat(name, tc.val, tc.format)
+		if actual != tc.expected {
+			if actual == nil || tc.expected == nil || actual.Error() != tc.expected.Error() {
+				t.Errorf("%s: got %#v, expected %#v", k, actual, tc.expected)
+			}
+		}
+	}
+}